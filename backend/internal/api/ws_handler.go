@@ -6,7 +6,6 @@ import (
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
-	"io"
 	"log/slog"
 	"net/http"
 	"os"
@@ -21,9 +20,11 @@ import (
 	"github.com/getarcaneapp/arcane/backend/internal/common"
 	"github.com/getarcaneapp/arcane/backend/internal/config"
 	"github.com/getarcaneapp/arcane/backend/internal/middleware"
+	"github.com/getarcaneapp/arcane/backend/internal/models"
 	"github.com/getarcaneapp/arcane/backend/internal/services"
 	"github.com/getarcaneapp/arcane/backend/internal/utils/docker"
 	httputil "github.com/getarcaneapp/arcane/backend/internal/utils/http"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/logparse"
 	ws "github.com/getarcaneapp/arcane/backend/internal/utils/ws"
 	systemtypes "github.com/getarcaneapp/arcane/types/system"
 	"github.com/gin-gonic/gin"
@@ -149,13 +150,14 @@ func DefaultWebSocketMetrics() *WebSocketMetrics {
 // WebSocketHandler consolidates all WebSocket and streaming endpoints.
 // REST endpoints are handled by Huma handlers.
 type WebSocketHandler struct {
-	projectService    *services.ProjectService
-	containerService  *services.ContainerService
-	systemService     *services.SystemService
-	wsUpgrader        websocket.Upgrader
-	wsMetrics         *WebSocketMetrics
-	activeConnections sync.Map
-	cpuCache          struct {
+	projectService     *services.ProjectService
+	containerService   *services.ContainerService
+	systemService      *services.SystemService
+	execSessionService *services.ExecSessionService
+	wsUpgrader         websocket.Upgrader
+	wsMetrics          *WebSocketMetrics
+	activeConnections  sync.Map
+	cpuCache           struct {
 		sync.RWMutex
 		value     float64
 		timestamp time.Time
@@ -210,6 +212,7 @@ func NewWebSocketHandler(
 	projectService *services.ProjectService,
 	containerService *services.ContainerService,
 	systemService *services.SystemService,
+	execSessionService *services.ExecSessionService,
 	authMiddleware *middleware.AuthMiddleware,
 	cfg *config.Config,
 ) {
@@ -217,6 +220,7 @@ func NewWebSocketHandler(
 		projectService:       projectService,
 		containerService:     containerService,
 		systemService:        systemService,
+		execSessionService:   execSessionService,
 		wsMetrics:            defaultWebSocketMetrics,
 		gpuMonitoringEnabled: cfg.GPUMonitoringEnabled,
 		gpuType:              cfg.GPUType,
@@ -256,6 +260,7 @@ func NewWebSocketHandler(
 //	@Param			timestamps	query	bool	false	"Show timestamps"				default(false)
 //	@Param			format		query	string	false	"Output format (text or json)"	default(text)
 //	@Param			batched		query	bool	false	"Batch log messages"			default(false)
+//	@Param			parse		query	string	false	"Parse log lines into structured fields with format=json: auto, or a comma-separated list of json, logfmt, access, klog"
 //	@Router			/api/environments/{id}/ws/projects/{projectId}/logs [get]
 func (h *WebSocketHandler) ProjectLogs(c *gin.Context) {
 	projectID := c.Param("projectId")
@@ -276,6 +281,11 @@ func (h *WebSocketHandler) ProjectLogs(c *gin.Context) {
 		format = "text"
 	}
 	batched := c.DefaultQuery("batched", "false") == "true"
+	parser, err := logParserFromQueryInternal(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": err.Error()})
+		return
+	}
 
 	conn, err := h.wsUpgrader.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
@@ -283,7 +293,7 @@ func (h *WebSocketHandler) ProjectLogs(c *gin.Context) {
 	}
 
 	connID := h.wsMetrics.RegisterConnection(buildWSConnectionInfoInternal(c, systemtypes.WSKindProjectLogs, projectID))
-	hub := h.startProjectLogHub(projectID, format, batched, follow, tail, since, timestamps, func() {
+	hub := h.startProjectLogHub(projectID, format, parser, batched, follow, tail, since, timestamps, func() {
 		h.wsMetrics.UnregisterConnection(connID)
 	})
 	// WebSocket connections use context.Background() because they are long-lived and should not
@@ -292,7 +302,7 @@ func (h *WebSocketHandler) ProjectLogs(c *gin.Context) {
 	ws.ServeClient(context.Background(), hub, conn)
 }
 
-func (h *WebSocketHandler) startProjectLogHub(projectID, format string, batched, follow bool, tail, since string, timestamps bool, onEmptyHook func()) *ws.Hub {
+func (h *WebSocketHandler) startProjectLogHub(projectID, format string, parser *logparse.Parser, batched, follow bool, tail, since string, timestamps bool, onEmptyHook func()) *ws.Hub {
 	ls := &wsLogStream{
 		hub:    ws.NewHub(1024),
 		format: format,
@@ -328,13 +338,17 @@ func (h *WebSocketHandler) startProjectLogHub(projectID, format string, batched,
 				if timestamp == "" {
 					timestamp = ws.NowRFC3339()
 				}
-				msgs <- ws.LogMessage{
+				logMsg := ws.LogMessage{
 					Seq:       seq,
 					Level:     level,
 					Message:   msg,
 					Service:   service,
 					Timestamp: timestamp,
 				}
+				if parser != nil {
+					logMsg.Parsed = new(parser.Parse(msg))
+				}
+				msgs <- logMsg
 			}
 		}()
 		if batched {
@@ -357,6 +371,19 @@ func (h *WebSocketHandler) startProjectLogHub(projectID, format string, batched,
 	return ls.hub
 }
 
+// logParserFromQueryInternal returns the parser selected by the parse query parameter,
+// or nil if parsing was not requested. Parsing applies to format=json only.
+func logParserFromQueryInternal(c *gin.Context) (*logparse.Parser, error) {
+	parse, _ := httputil.GetQueryParam(c, "parse", false)
+	if parse == "" || parse == "false" || c.Query("format") != "json" {
+		return nil, nil
+	}
+	if parse == "true" || parse == "auto" {
+		return logparse.NewParser()
+	}
+	return logparse.NewParser(strings.Split(parse, ",")...)
+}
+
 // ============================================================================
 // Container WebSocket Endpoints
 // ============================================================================
@@ -374,6 +401,7 @@ func (h *WebSocketHandler) startProjectLogHub(projectID, format string, batched,
 //	@Param			timestamps	query	bool	false	"Show timestamps"				default(false)
 //	@Param			format		query	string	false	"Output format (text or json)"	default(text)
 //	@Param			batched		query	bool	false	"Batch log messages"			default(false)
+//	@Param			parse		query	string	false	"Parse log lines into structured fields with format=json: auto, or a comma-separated list of json, logfmt, access, klog"
 //	@Router			/api/environments/{id}/ws/containers/{containerId}/logs [get]
 func (h *WebSocketHandler) ContainerLogs(c *gin.Context) {
 	containerID := c.Param("containerId")
@@ -394,6 +422,11 @@ func (h *WebSocketHandler) ContainerLogs(c *gin.Context) {
 		format = "text"
 	}
 	batched := c.DefaultQuery("batched", "false") == "true"
+	parser, err := logParserFromQueryInternal(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": err.Error()})
+		return
+	}
 
 	conn, err := h.wsUpgrader.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
@@ -401,7 +434,7 @@ func (h *WebSocketHandler) ContainerLogs(c *gin.Context) {
 	}
 
 	connID := h.wsMetrics.RegisterConnection(buildWSConnectionInfoInternal(c, systemtypes.WSKindContainerLogs, containerID))
-	hub := h.startContainerLogHub(containerID, format, batched, follow, tail, since, timestamps, func() {
+	hub := h.startContainerLogHub(containerID, format, parser, batched, follow, tail, since, timestamps, func() {
 		h.wsMetrics.UnregisterConnection(connID)
 	})
 	// WebSocket connections use context.Background() because they are long-lived and should not
@@ -410,7 +443,7 @@ func (h *WebSocketHandler) ContainerLogs(c *gin.Context) {
 	ws.ServeClient(context.Background(), hub, conn)
 }
 
-func (h *WebSocketHandler) startContainerLogHub(containerID, format string, batched, follow bool, tail, since string, timestamps bool, onEmptyHook func()) *ws.Hub {
+func (h *WebSocketHandler) startContainerLogHub(containerID, format string, parser *logparse.Parser, batched, follow bool, tail, since string, timestamps bool, onEmptyHook func()) *ws.Hub {
 	ls := &wsLogStream{
 		hub:    ws.NewHub(1024),
 		format: format,
@@ -446,12 +479,16 @@ func (h *WebSocketHandler) startContainerLogHub(containerID, format string, batc
 				if timestamp == "" {
 					timestamp = ws.NowRFC3339()
 				}
-				msgs <- ws.LogMessage{
+				logMsg := ws.LogMessage{
 					Seq:       seq,
 					Level:     level,
 					Message:   msg,
 					Timestamp: timestamp,
 				}
+				if parser != nil {
+					logMsg.Parsed = new(parser.Parse(msg))
+				}
+				msgs <- logMsg
 			}
 		}()
 		if batched {
@@ -536,7 +573,10 @@ func (h *WebSocketHandler) startContainerStatsHub(containerID string, onEmptyHoo
 	return hub
 }
 
-// ContainerExec provides interactive terminal access to a container.
+// ContainerExec provides interactive terminal access to a container. Sessions are
+// recorded and keep running when the connection drops, so they can be reattached with
+// the session query parameter. The session ID is returned in the X-Arcane-Exec-Session
+// header of the upgrade response.
 //
 //	@Summary		Execute command in container via WebSocket
 //	@Description	Interactive terminal access to a container over WebSocket
@@ -544,6 +584,9 @@ func (h *WebSocketHandler) startContainerStatsHub(containerID string, onEmptyHoo
 //	@Param			id			path	string	true	"Environment ID"
 //	@Param			containerId	path	string	true	"Container ID"
 //	@Param			shell		query	string	false	"Shell to execute"	default(/bin/sh)
+//	@Param			session		query	string	false	"ID of a running exec session to reattach to"
+//	@Param			cols		query	int		false	"Terminal width"	default(80)
+//	@Param			rows		query	int		false	"Terminal height"	default(24)
 //	@Router			/api/environments/{id}/ws/containers/{containerId}/terminal [get]
 func (h *WebSocketHandler) ContainerExec(c *gin.Context) {
 	containerID := c.Param("containerId")
@@ -552,111 +595,109 @@ func (h *WebSocketHandler) ContainerExec(c *gin.Context) {
 		return
 	}
 
-	shell := c.DefaultQuery("shell", "/bin/sh")
-
-	conn, err := h.wsUpgrader.Upgrade(c.Writer, c.Request, nil)
-	if err != nil {
+	user := getContextUserInternal(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"success": false, "error": "not authenticated"})
 		return
 	}
-	connID := h.wsMetrics.RegisterConnection(buildWSConnectionInfoInternal(c, systemtypes.WSKindContainerExec, containerID))
-	defer h.wsMetrics.UnregisterConnection(connID)
-	defer conn.Close()
-
-	ctx, cancel := context.WithCancel(c.Request.Context())
-	defer cancel()
 
-	h.runContainerExecInternal(ctx, cancel, conn, containerID, shell)
-}
+	attachment, execErr := h.attachContainerExecInternal(c, containerID, *user)
 
-func (h *WebSocketHandler) runContainerExecInternal(ctx context.Context, cancel context.CancelFunc, conn *websocket.Conn, containerID, shell string) {
-	// Create exec instance
-	execID, err := h.containerService.CreateExec(ctx, containerID, []string{shell})
+	var header http.Header
+	if attachment != nil {
+		header = http.Header{"X-Arcane-Exec-Session": []string{attachment.SessionID()}}
+	}
+	conn, err := h.wsUpgrader.Upgrade(c.Writer, c.Request, header)
 	if err != nil {
-		h.writeExecErrorInternal(conn, &common.ExecCreationError{Err: err})
+		if attachment != nil {
+			attachment.Detach()
+		}
 		return
 	}
+	connID := h.wsMetrics.RegisterConnection(buildWSConnectionInfoInternal(c, systemtypes.WSKindContainerExec, containerID))
+	defer h.wsMetrics.UnregisterConnection(connID)
+	defer conn.Close()
 
-	// Attach to exec
-	execSession, err := h.containerService.AttachExec(ctx, containerID, execID)
-	if err != nil {
-		h.writeExecErrorInternal(conn, &common.ExecAttachError{Err: err})
+	if execErr != nil {
+		h.writeExecErrorInternal(conn, execErr)
 		return
 	}
-	cleanup := h.execCleanupFuncInternal(ctx, execSession, execID, containerID)
-	defer cleanup()
-	h.watchExecContextInternal(ctx, execID, containerID, cleanup)
+	defer attachment.Detach()
 
 	done := make(chan struct{})
-	go h.pipeExecOutputInternal(ctx, conn, execSession.Stdout(), execID, containerID, done)
-	go h.pipeExecInputInternal(ctx, cancel, conn, execSession.Stdin(), execID, containerID)
+	go h.pipeExecOutputInternal(conn, attachment, done)
+	go h.pipeExecInputInternal(conn, attachment)
 
 	<-done
 }
 
-func (h *WebSocketHandler) writeExecErrorInternal(conn *websocket.Conn, err error) {
-	_ = conn.WriteMessage(websocket.TextMessage, []byte(err.Error()+"\r\n"))
+// attachContainerExecInternal starts a new exec session, or reattaches to the one
+// named by the session query parameter.
+func (h *WebSocketHandler) attachContainerExecInternal(c *gin.Context, containerID string, user models.User) (*services.ExecAttachment, error) {
+	ctx := c.Request.Context()
+	isAdmin := c.GetBool("userIsAdmin")
+
+	if sessionID := c.Query("session"); sessionID != "" {
+		attachment, err := h.execSessionService.AttachSession(ctx, containerID, sessionID, user, isAdmin)
+		if err != nil {
+			return nil, &common.ExecAttachError{Err: err}
+		}
+		return attachment, nil
+	}
+
+	cols, _ := strconv.Atoi(c.DefaultQuery("cols", "80"))
+	rows, _ := strconv.Atoi(c.DefaultQuery("rows", "24"))
+	attachment, err := h.execSessionService.StartSession(ctx, services.ExecSessionOptions{
+		EnvironmentID: c.Param("id"),
+		ContainerID:   containerID,
+		Command:       []string{c.DefaultQuery("shell", "/bin/sh")},
+		Width:         cols,
+		Height:        rows,
+	}, user)
+	if err != nil {
+		return nil, &common.ExecCreationError{Err: err}
+	}
+	return attachment, nil
 }
 
-func (h *WebSocketHandler) execCleanupFuncInternal(ctx context.Context, execSession *services.ExecSession, execID, containerID string) func() {
-	return func() {
-		slog.Debug("Cleaning up exec session", "execID", execID, "containerID", containerID, "contextErr", ctx.Err())
-		// Cleanup must proceed even if parent ctx is canceled.
-		cleanupCtx, cleanupCancel := context.WithTimeout(context.Background(), 5*time.Second) //nolint:contextcheck
-		defer cleanupCancel()
-		if err := execSession.Close(cleanupCtx); err != nil { //nolint:contextcheck
-			slog.Warn("Failed to clean up exec session", "execID", execID, "error", err)
+func getContextUserInternal(c *gin.Context) *models.User {
+	if val, ok := c.Get("currentUser"); ok {
+		if user, ok := val.(*models.User); ok {
+			return user
 		}
 	}
+	return nil
 }
 
-func (h *WebSocketHandler) watchExecContextInternal(ctx context.Context, execID, containerID string, cleanup func()) {
-	go func() {
-		<-ctx.Done()
-		slog.Debug("Exec context cancelled", "execID", execID, "containerID", containerID)
-		cleanup()
-	}()
+func (h *WebSocketHandler) writeExecErrorInternal(conn *websocket.Conn, err error) {
+	_ = conn.WriteMessage(websocket.TextMessage, []byte(err.Error()+"\r\n"))
 }
 
-func (h *WebSocketHandler) pipeExecOutputInternal(ctx context.Context, conn *websocket.Conn, stdout io.Reader, execID, containerID string, done chan<- struct{}) {
+// pipeExecOutputInternal forwards session output until the session ends or the
+// terminal is detached.
+func (h *WebSocketHandler) pipeExecOutputInternal(conn *websocket.Conn, attachment *services.ExecAttachment, done chan<- struct{}) {
 	defer close(done)
-	buf := make([]byte, 4096)
-	for {
-		select {
-		case <-ctx.Done():
+	for data := range attachment.Output() {
+		if err := conn.WriteMessage(websocket.BinaryMessage, data); err != nil {
+			slog.Debug("Exec websocket write error", "session", attachment.SessionID(), "error", err)
+			attachment.Detach()
 			return
-		default:
-		}
-
-		n, err := stdout.Read(buf)
-		if err != nil {
-			slog.Debug("Exec stdout read error", "execID", execID, "containerID", containerID, "error", err)
-			return
-		}
-		if n > 0 {
-			if err := conn.WriteMessage(websocket.BinaryMessage, buf[:n]); err != nil {
-				slog.Debug("Exec websocket write error", "execID", execID, "containerID", containerID, "error", err)
-				return
-			}
 		}
 	}
 }
 
-func (h *WebSocketHandler) pipeExecInputInternal(ctx context.Context, cancel context.CancelFunc, conn *websocket.Conn, stdin io.Writer, execID, containerID string) {
+// pipeExecInputInternal forwards terminal input to the session and detaches the
+// terminal when the connection closes.
+func (h *WebSocketHandler) pipeExecInputInternal(conn *websocket.Conn, attachment *services.ExecAttachment) {
+	defer attachment.Detach()
 	for {
-		select {
-		case <-ctx.Done():
-			return
-		default:
-		}
-
 		_, data, err := conn.ReadMessage()
 		if err != nil {
-			slog.Debug("Exec websocket read error", "execID", execID, "containerID", containerID, "error", err)
-			cancel()
+			slog.Debug("Exec websocket read error", "session", attachment.SessionID(), "error", err)
 			return
 		}
-		if _, err := stdin.Write(data); err != nil {
-			slog.Debug("Exec stdin write error", "execID", execID, "containerID", containerID, "error", err)
+		if _, err := attachment.Write(data); err != nil {
+			slog.Debug("Exec stdin write error", "session", attachment.SessionID(), "error", err)
 			return
 		}
 	}
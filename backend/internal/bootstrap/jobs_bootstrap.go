@@ -27,12 +27,15 @@ func registerJobs(appCtx context.Context, newScheduler *pkg_scheduler.JobSchedul
 	// Send initial heartbeat on startup without blocking bootstrap.
 	go analyticsJob.Run(appCtx)
 
-	eventCleanupJob := pkg_scheduler.NewEventCleanupJob(appServices.Event, appServices.Settings)
+	eventCleanupJob := pkg_scheduler.NewEventCleanupJob(appServices.Event, appServices.Settings, appServices.GitOpsSync, appServices.ExecSession)
 	newScheduler.RegisterJob(eventCleanupJob)
 
 	scheduledPruneJob := pkg_scheduler.NewScheduledPruneJob(appServices.System, appServices.Settings, appServices.Notification)
 	newScheduler.RegisterJob(scheduledPruneJob)
 
+	scheduledVolumeBackupJob := pkg_scheduler.NewScheduledVolumeBackupJob(appServices.Volume, appServices.Settings, appServices.Notification, appConfig.GetLocation())
+	newScheduler.RegisterJob(scheduledVolumeBackupJob)
+
 	fsWatcherJob, err := pkg_scheduler.RegisterFilesystemWatcherJob(appCtx, appServices.Project, appServices.Template, appServices.Settings)
 	if err != nil {
 		slog.ErrorContext(appCtx, "Failed to register filesystem watcher job", "error", err)
@@ -41,6 +44,9 @@ func registerJobs(appCtx context.Context, newScheduler *pkg_scheduler.JobSchedul
 	gitOpsSyncJob := pkg_scheduler.NewGitOpsSyncJob(appServices.GitOpsSync, appServices.Settings)
 	newScheduler.RegisterJob(gitOpsSyncJob)
 
+	gitOpsDriftJob := pkg_scheduler.NewGitOpsDriftJob(appServices.GitOpsSync, appServices.Settings, appServices.Notification)
+	newScheduler.RegisterJob(gitOpsDriftJob)
+
 	vulnerabilityScanJob := pkg_scheduler.NewVulnerabilityScanJob(appServices.Vulnerability, appServices.Settings)
 	newScheduler.RegisterJob(vulnerabilityScanJob)
 
@@ -55,7 +61,9 @@ func registerJobs(appCtx context.Context, newScheduler *pkg_scheduler.JobSchedul
 		analyticsJob,
 		eventCleanupJob,
 		scheduledPruneJob,
+		scheduledVolumeBackupJob,
 		gitOpsSyncJob,
+		gitOpsDriftJob,
 		vulnerabilityScanJob,
 	)
 	setupSettingsCallbacks(appCtx, appServices, appConfig, newScheduler, imagePollingJob, autoUpdateJob, environmentHealthJob, fsWatcherJob, scheduledPruneJob, vulnerabilityScanJob)
@@ -72,7 +80,9 @@ func setupJobScheduleCallbacks(
 	analyticsJob *pkg_scheduler.AnalyticsJob,
 	eventCleanupJob *pkg_scheduler.EventCleanupJob,
 	scheduledPruneJob *pkg_scheduler.ScheduledPruneJob,
+	scheduledVolumeBackupJob *pkg_scheduler.ScheduledVolumeBackupJob,
 	gitOpsSyncJob *pkg_scheduler.GitOpsSyncJob,
+	gitOpsDriftJob *pkg_scheduler.GitOpsDriftJob,
 	vulnerabilityScanJob *pkg_scheduler.VulnerabilityScanJob,
 ) {
 	if appServices.JobSchedule == nil {
@@ -93,7 +103,9 @@ func setupJobScheduleCallbacks(
 				analyticsJob,
 				eventCleanupJob,
 				scheduledPruneJob,
+				scheduledVolumeBackupJob,
 				gitOpsSyncJob,
+				gitOpsDriftJob,
 				vulnerabilityScanJob,
 			)
 		}
@@ -111,7 +123,9 @@ func handleJobScheduleChangeInternal(
 	analyticsJob *pkg_scheduler.AnalyticsJob,
 	eventCleanupJob *pkg_scheduler.EventCleanupJob,
 	scheduledPruneJob *pkg_scheduler.ScheduledPruneJob,
+	scheduledVolumeBackupJob *pkg_scheduler.ScheduledVolumeBackupJob,
 	gitOpsSyncJob *pkg_scheduler.GitOpsSyncJob,
+	gitOpsDriftJob *pkg_scheduler.GitOpsDriftJob,
 	vulnerabilityScanJob *pkg_scheduler.VulnerabilityScanJob,
 ) {
 	switch key {
@@ -142,10 +156,18 @@ func handleJobScheduleChangeInternal(
 		if err := newScheduler.RescheduleJob(ctx, scheduledPruneJob); err != nil {
 			slog.WarnContext(ctx, "Failed to reschedule scheduled-prune job", "error", err)
 		}
+	case "scheduledBackupInterval":
+		if err := newScheduler.RescheduleJob(ctx, scheduledVolumeBackupJob); err != nil {
+			slog.WarnContext(ctx, "Failed to reschedule scheduled-volume-backup job", "error", err)
+		}
 	case "gitopsSyncInterval":
 		if err := newScheduler.RescheduleJob(ctx, gitOpsSyncJob); err != nil {
 			slog.WarnContext(ctx, "Failed to reschedule gitops sync job", "error", err)
 		}
+	case "gitopsDriftInterval":
+		if err := newScheduler.RescheduleJob(ctx, gitOpsDriftJob); err != nil {
+			slog.WarnContext(ctx, "Failed to reschedule gitops drift job", "error", err)
+		}
 	case "vulnerabilityScanInterval":
 		if err := newScheduler.RescheduleJob(ctx, vulnerabilityScanJob); err != nil {
 			slog.WarnContext(ctx, "Failed to reschedule vulnerability-scan job", "error", err)
@@ -14,6 +14,7 @@ import (
 	"github.com/getarcaneapp/arcane/backend/internal/config"
 	"github.com/getarcaneapp/arcane/backend/internal/huma"
 	"github.com/getarcaneapp/arcane/backend/internal/middleware"
+	"github.com/getarcaneapp/arcane/backend/internal/models"
 	"github.com/getarcaneapp/arcane/backend/internal/utils/cookie"
 	"github.com/getarcaneapp/arcane/backend/internal/utils/edge"
 	"github.com/getarcaneapp/arcane/types"
@@ -59,11 +60,11 @@ func shouldLogRequest(c *gin.Context) bool {
 }
 
 func createAuthValidator(appServices *Services) middleware.AuthValidator {
-	return func(ctx context.Context, c *gin.Context) bool {
+	return func(ctx context.Context, c *gin.Context) (*models.User, bool) {
 		// Check for API key authentication
 		if apiKey := c.GetHeader("X-API-Key"); apiKey != "" {
 			user, err := appServices.ApiKey.ValidateApiKey(ctx, apiKey)
-			return err == nil && user != nil
+			return user, err == nil && user != nil
 		}
 
 		// Check for Bearer token authentication
@@ -75,11 +76,11 @@ func createAuthValidator(appServices *Services) middleware.AuthValidator {
 		}
 
 		if token == "" {
-			return false
+			return nil, false
 		}
 
 		user, err := appServices.Auth.VerifyToken(ctx, token)
-		return err == nil && user != nil
+		return user, err == nil && user != nil
 	}
 }
 
@@ -137,7 +138,9 @@ func setupRouter(ctx context.Context, cfg *config.Config, appServices *Services)
 		Image:             appServices.Image,
 		ImageUpdate:       appServices.ImageUpdate,
 		Volume:            appServices.Volume,
+		BackupTarget:      appServices.BackupTarget,
 		Container:         appServices.Container,
+		ExecSession:       appServices.ExecSession,
 		Network:           appServices.Network,
 		Notification:      appServices.Notification,
 		Apprise:           appServices.Apprise,
@@ -160,7 +163,7 @@ func setupRouter(ctx context.Context, cfg *config.Config, appServices *Services)
 	api.RegisterDiagnosticsRoutes(apiGroup, authMiddleware, api.DefaultWebSocketMetrics()) //nolint:contextcheck
 
 	// Remaining Gin handlers (WebSocket/streaming)
-	api.NewWebSocketHandler(apiGroup, appServices.Project, appServices.Container, appServices.System, authMiddleware, cfg) //nolint:contextcheck
+	api.NewWebSocketHandler(apiGroup, appServices.Project, appServices.Container, appServices.System, appServices.ExecSession, authMiddleware, cfg) //nolint:contextcheck
 
 	// Register edge tunnel endpoint for manager to accept agent connections
 	// This is only registered when NOT in agent mode (i.e., running as manager)
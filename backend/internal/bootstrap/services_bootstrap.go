@@ -21,8 +21,10 @@ type Services struct {
 	SettingsSearch    *services.SettingsSearchService
 	CustomizeSearch   *services.CustomizeSearchService
 	Container         *services.ContainerService
+	ExecSession       *services.ExecSessionService
 	Image             *services.ImageService
 	Volume            *services.VolumeService
+	BackupTarget      *services.BackupTargetService
 	Network           *services.NetworkService
 	ImageUpdate       *services.ImageUpdateService
 	Auth              *services.AuthService
@@ -66,10 +68,12 @@ func initializeServices(ctx context.Context, db *database.DB, cfg *config.Config
 	svcs.Vulnerability = services.NewVulnerabilityService(db, svcs.Docker, svcs.Event, svcs.Settings, svcs.Notification)
 	svcs.ImageUpdate = services.NewImageUpdateService(db, svcs.Settings, svcs.ContainerRegistry, svcs.Docker, svcs.Event, svcs.Notification)
 	svcs.Image = services.NewImageService(db, svcs.Docker, svcs.ContainerRegistry, svcs.ImageUpdate, svcs.Vulnerability, svcs.Event)
-	svcs.Project = services.NewProjectService(db, svcs.Settings, svcs.Event, svcs.Image, svcs.Docker)
 	svcs.Environment = services.NewEnvironmentService(db, httpClient, svcs.Docker, svcs.Event, svcs.Settings)
 	svcs.Container = services.NewContainerService(db, svcs.Event, svcs.Docker, svcs.Image, svcs.Settings)
-	svcs.Volume = services.NewVolumeService(db, svcs.Docker, svcs.Event, svcs.Settings, svcs.Container, svcs.Image, cfg.BackupVolumeName)
+	svcs.ExecSession = services.NewExecSessionService(ctx, db, svcs.Container, svcs.Event, cfg.ExecRecordingsDir)
+	svcs.BackupTarget = services.NewBackupTargetService(db)
+	svcs.Volume = services.NewVolumeService(db, svcs.Docker, svcs.Event, svcs.Settings, svcs.Container, svcs.Image, svcs.BackupTarget, cfg.BackupVolumeName)
+	svcs.Project = services.NewProjectService(db, svcs.Settings, svcs.Event, svcs.Image, svcs.Docker, svcs.Volume)
 	svcs.Network = services.NewNetworkService(db, svcs.Docker, svcs.Event)
 	svcs.Template = services.NewTemplateService(ctx, db, httpClient, svcs.Settings)
 	svcs.Auth = services.NewAuthService(svcs.User, svcs.Settings, svcs.Event, cfg.JWTSecret, cfg)
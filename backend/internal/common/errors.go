@@ -116,6 +116,30 @@ func (e *ExecAttachError) Error() string {
 	return fmt.Sprintf("Error attaching to exec: %v", e.Err)
 }
 
+type ExecSessionListError struct {
+	Err error
+}
+
+func (e *ExecSessionListError) Error() string {
+	return fmt.Sprintf("Failed to list exec sessions: %v", e.Err)
+}
+
+type ExecSessionError struct {
+	Err error
+}
+
+func (e *ExecSessionError) Error() string {
+	return fmt.Sprintf("Failed to access exec session: %v", e.Err)
+}
+
+type ExecSessionTerminateError struct {
+	Err error
+}
+
+func (e *ExecSessionTerminateError) Error() string {
+	return fmt.Sprintf("Failed to terminate exec session: %v", e.Err)
+}
+
 type ContainerListError struct {
 	Err error
 }
@@ -164,6 +188,38 @@ func (e *ContainerDeleteError) Error() string {
 	return fmt.Sprintf("Failed to delete container: %v", e.Err)
 }
 
+type ContainerUpdateError struct {
+	Err error
+}
+
+func (e *ContainerUpdateError) Error() string {
+	return fmt.Sprintf("Failed to update container: %v", e.Err)
+}
+
+type ContainerCommitError struct {
+	Err error
+}
+
+func (e *ContainerCommitError) Error() string {
+	return fmt.Sprintf("Failed to commit container: %v", e.Err)
+}
+
+type ContainerFilesError struct {
+	Err error
+}
+
+func (e *ContainerFilesError) Error() string {
+	return fmt.Sprintf("Failed to access container files: %v", e.Err)
+}
+
+type ContainerDiffError struct {
+	Err error
+}
+
+func (e *ContainerDiffError) Error() string {
+	return fmt.Sprintf("Failed to get container changes: %v", e.Err)
+}
+
 type ContainerStatusCountsError struct {
 	Err error
 }
@@ -546,6 +602,30 @@ func (e *NetworkPruneError) Error() string {
 	return fmt.Sprintf("Failed to prune networks: %v", e.Err)
 }
 
+type NetworkConnectError struct {
+	Err error
+}
+
+func (e *NetworkConnectError) Error() string {
+	return fmt.Sprintf("Failed to connect container to network: %v", e.Err)
+}
+
+type NetworkDisconnectError struct {
+	Err error
+}
+
+func (e *NetworkDisconnectError) Error() string {
+	return fmt.Sprintf("Failed to disconnect container from network: %v", e.Err)
+}
+
+type NetworkTopologyError struct {
+	Err error
+}
+
+func (e *NetworkTopologyError) Error() string {
+	return fmt.Sprintf("Failed to get network topology: %v", e.Err)
+}
+
 type NotificationSettingsListError struct {
 	Err error
 }
@@ -738,6 +818,22 @@ func (e *ProjectStatusCountsError) Error() string {
 	return fmt.Sprintf("Failed to get project status counts: %v", e.Err)
 }
 
+type ProjectLogSearchError struct {
+	Err error
+}
+
+func (e *ProjectLogSearchError) Error() string {
+	return fmt.Sprintf("Failed to search project logs: %v", e.Err)
+}
+
+type ProjectLogExportError struct {
+	Err error
+}
+
+func (e *ProjectLogExportError) Error() string {
+	return fmt.Sprintf("Failed to export project logs: %v", e.Err)
+}
+
 type SettingsMappingError struct {
 	Err error
 }
@@ -1054,6 +1150,22 @@ func (e *UserUpdateError) Error() string {
 	return "Failed to update user"
 }
 
+type InvalidRoleError struct {
+	Role string
+}
+
+func (e *InvalidRoleError) Error() string {
+	return fmt.Sprintf("Unknown role: %s", e.Role)
+}
+
+type InvalidEnvironmentAccessError struct {
+	Err error
+}
+
+func (e *InvalidEnvironmentAccessError) Error() string {
+	return fmt.Sprintf("Invalid environment access: %v", e.Err)
+}
+
 type UserDeletionError struct {
 	Err error
 }
@@ -1207,6 +1319,14 @@ func (e *GitRepositoryTestError) Error() string {
 	return fmt.Sprintf("Failed to test git repository connection: %v", e.Err)
 }
 
+type GitRepositoryTagsError struct {
+	Err error
+}
+
+func (e *GitRepositoryTagsError) Error() string {
+	return fmt.Sprintf("Failed to list git repository tags: %v", e.Err)
+}
+
 type GitRepositoryMappingError struct {
 	Err error
 }
@@ -1295,6 +1415,46 @@ func (e *GitOpsSyncMappingError) Error() string {
 	return "Failed to map GitOps sync"
 }
 
+type GitOpsWebhookError struct {
+	Err error
+}
+
+func (e *GitOpsWebhookError) Error() string {
+	return fmt.Sprintf("Failed to process GitOps webhook: %v", e.Err)
+}
+
+type GitOpsDriftCheckError struct {
+	Err error
+}
+
+func (e *GitOpsDriftCheckError) Error() string {
+	return fmt.Sprintf("Failed to check GitOps drift: %v", e.Err)
+}
+
+type GitOpsSyncRollbackError struct {
+	Err error
+}
+
+func (e *GitOpsSyncRollbackError) Error() string {
+	return fmt.Sprintf("Failed to roll back GitOps sync: %v", e.Err)
+}
+
+type GitOpsSyncCommitsError struct {
+	Err error
+}
+
+func (e *GitOpsSyncCommitsError) Error() string {
+	return fmt.Sprintf("Failed to list GitOps sync commits: %v", e.Err)
+}
+
+type GitOpsSyncRunsError struct {
+	Err error
+}
+
+func (e *GitOpsSyncRunsError) Error() string {
+	return fmt.Sprintf("Failed to list GitOps sync runs: %v", e.Err)
+}
+
 type VulnerabilityScanError struct {
 	Err error
 }
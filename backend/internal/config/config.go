@@ -41,6 +41,8 @@ type Config struct {
 	OidcScopes                 string `env:"OIDC_SCOPES" default:"openid email profile"`
 	OidcAdminClaim             string `env:"OIDC_ADMIN_CLAIM" default:""`
 	OidcAdminValue             string `env:"OIDC_ADMIN_VALUE" default:""`
+	OidcRoleClaim              string `env:"OIDC_ROLE_CLAIM" default:""`
+	OidcRoleMapping            string `env:"OIDC_ROLE_MAPPING" default:""`
 	OidcSkipTlsVerify          bool   `env:"OIDC_SKIP_TLS_VERIFY" default:"false"`
 	OidcAutoRedirectToProvider bool   `env:"OIDC_AUTO_REDIRECT_TO_PROVIDER" default:"false"`
 	OidcProviderName           string `env:"OIDC_PROVIDER_NAME" default:""`
@@ -65,6 +67,8 @@ type Config struct {
 	DirPerm    os.FileMode `env:"DIR_PERM" default:"0755"`
 	GitWorkDir string      `env:"GIT_WORK_DIR" default:"data/git"`
 
+	ExecRecordingsDir string `env:"EXEC_RECORDINGS_DIR" default:"data/exec-recordings"`
+
 	DockerAPITimeout       int    `env:"DOCKER_API_TIMEOUT" default:"0"`
 	DockerImagePullTimeout int    `env:"DOCKER_IMAGE_PULL_TIMEOUT" default:"0"`
 	TrivyScanTimeout       int    `env:"TRIVY_SCAN_TIMEOUT" default:"0"`
@@ -8,6 +8,7 @@ import (
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/getarcaneapp/arcane/backend/internal/common"
 	humamw "github.com/getarcaneapp/arcane/backend/internal/huma/middleware"
+	"github.com/getarcaneapp/arcane/backend/internal/models"
 	"github.com/getarcaneapp/arcane/backend/internal/services"
 	"github.com/getarcaneapp/arcane/backend/internal/utils/pagination"
 	"github.com/getarcaneapp/arcane/types/apikey"
@@ -208,6 +209,9 @@ func (h *ApiKeyHandler) CreateApiKey(ctx context.Context, input *CreateApiKeyInp
 
 	apiKey, err := h.apiKeyService.CreateApiKey(ctx, user.ID, input.Body)
 	if err != nil {
+		if errors.Is(err, models.ErrInvalidEnvironmentAccess) {
+			return nil, huma.Error400BadRequest((&common.InvalidEnvironmentAccessError{Err: err}).Error())
+		}
 		return nil, huma.Error500InternalServerError((&common.ApiKeyCreationError{Err: err}).Error())
 	}
 
@@ -259,6 +263,9 @@ func (h *ApiKeyHandler) UpdateApiKey(ctx context.Context, input *UpdateApiKeyInp
 		if errors.Is(err, services.ErrApiKeyNotFound) {
 			return nil, huma.Error404NotFound((&common.ApiKeyNotFoundError{}).Error())
 		}
+		if errors.Is(err, models.ErrInvalidEnvironmentAccess) {
+			return nil, huma.Error400BadRequest((&common.InvalidEnvironmentAccessError{Err: err}).Error())
+		}
 		return nil, huma.Error500InternalServerError((&common.ApiKeyUpdateError{Err: err}).Error())
 	}
 
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	humamw "github.com/getarcaneapp/arcane/backend/internal/huma/middleware"
+	"github.com/getarcaneapp/arcane/backend/internal/models"
+	"github.com/getarcaneapp/arcane/backend/internal/services"
+	"github.com/getarcaneapp/arcane/types/apikey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApiKeyHandlersRejectScopedAdminKeys(t *testing.T) {
+	h := &ApiKeyHandler{apiKeyService: &services.ApiKeyService{}}
+	user := &models.User{
+		BaseModel:            models.BaseModel{ID: "u1"},
+		Roles:                []string{"admin"},
+		KeyEnvironmentAccess: models.EnvironmentAccess{"0": models.EnvironmentAccessWrite},
+	}
+	ctx := context.WithValue(context.Background(), humamw.ContextKeyCurrentUser, user)
+	ctx = context.WithValue(ctx, humamw.ContextKeyUserIsAdmin, true)
+
+	calls := map[string]func() error{
+		"create": func() error {
+			_, err := h.CreateApiKey(ctx, &CreateApiKeyInput{Body: apikey.CreateApiKey{Name: "wider"}})
+			return err
+		},
+		"widen own access": func() error {
+			_, err := h.UpdateApiKey(ctx, &UpdateApiKeyInput{
+				ID:   "k1",
+				Body: apikey.UpdateApiKey{EnvironmentAccess: map[string]string{"*": "write"}},
+			})
+			return err
+		},
+	}
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			var statusErr huma.StatusError
+			require.ErrorAs(t, call(), &statusErr)
+			assert.Equal(t, http.StatusForbidden, statusErr.GetStatus())
+		})
+	}
+}
@@ -0,0 +1,317 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/getarcaneapp/arcane/backend/internal/services"
+	"github.com/getarcaneapp/arcane/types/base"
+	volumetypes "github.com/getarcaneapp/arcane/types/volume"
+)
+
+// BackupTargetHandler handles the off-host storage locations used for volume backups.
+type BackupTargetHandler struct {
+	backupTargetService *services.BackupTargetService
+}
+
+// ============================================================================
+// Input/Output Types
+// ============================================================================
+
+type ListBackupTargetsInput struct {
+	EnvironmentID string `path:"id" doc:"Environment ID"`
+}
+
+type ListBackupTargetsOutput struct {
+	Body base.ApiResponse[[]volumetypes.BackupTarget]
+}
+
+type CreateBackupTargetInput struct {
+	EnvironmentID string `path:"id" doc:"Environment ID"`
+	Body          volumetypes.CreateBackupTarget
+}
+
+type CreateBackupTargetOutput struct {
+	Body base.ApiResponse[volumetypes.BackupTarget]
+}
+
+type GetBackupTargetInput struct {
+	EnvironmentID string `path:"id" doc:"Environment ID"`
+	TargetID      string `path:"targetId" doc:"Backup target ID"`
+}
+
+type GetBackupTargetOutput struct {
+	Body base.ApiResponse[volumetypes.BackupTarget]
+}
+
+type UpdateBackupTargetInput struct {
+	EnvironmentID string `path:"id" doc:"Environment ID"`
+	TargetID      string `path:"targetId" doc:"Backup target ID"`
+	Body          volumetypes.UpdateBackupTarget
+}
+
+type UpdateBackupTargetOutput struct {
+	Body base.ApiResponse[volumetypes.BackupTarget]
+}
+
+type DeleteBackupTargetInput struct {
+	EnvironmentID string `path:"id" doc:"Environment ID"`
+	TargetID      string `path:"targetId" doc:"Backup target ID"`
+}
+
+type DeleteBackupTargetOutput struct {
+	Body base.ApiResponse[base.MessageResponse]
+}
+
+type TestBackupTargetInput struct {
+	EnvironmentID string `path:"id" doc:"Environment ID"`
+	TargetID      string `path:"targetId" doc:"Backup target ID"`
+}
+
+type TestBackupTargetOutput struct {
+	Body base.ApiResponse[base.MessageResponse]
+}
+
+// ============================================================================
+// Registration
+// ============================================================================
+
+// RegisterBackupTargets registers backup target management routes using Huma.
+func RegisterBackupTargets(api huma.API, backupTargetService *services.BackupTargetService) {
+	h := &BackupTargetHandler{backupTargetService: backupTargetService}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-backup-targets",
+		Method:      http.MethodGet,
+		Path:        "/environments/{id}/backup-targets",
+		Summary:     "List backup targets",
+		Description: "List the off-host locations volume backups can be stored in",
+		Tags:        []string{"Volume Backup"},
+		Security: []map[string][]string{
+			{"BearerAuth": {}},
+			{"ApiKeyAuth": {}},
+		},
+	}, h.ListTargets)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "create-backup-target",
+		Method:      http.MethodPost,
+		Path:        "/environments/{id}/backup-targets",
+		Summary:     "Create a backup target",
+		Description: "Create an S3-compatible, SFTP or host path backup target. Credentials are stored encrypted",
+		Tags:        []string{"Volume Backup"},
+		Security: []map[string][]string{
+			{"BearerAuth": {}},
+			{"ApiKeyAuth": {}},
+		},
+	}, h.CreateTarget)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-backup-target",
+		Method:      http.MethodGet,
+		Path:        "/environments/{id}/backup-targets/{targetId}",
+		Summary:     "Get a backup target",
+		Tags:        []string{"Volume Backup"},
+		Security: []map[string][]string{
+			{"BearerAuth": {}},
+			{"ApiKeyAuth": {}},
+		},
+	}, h.GetTarget)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "update-backup-target",
+		Method:      http.MethodPut,
+		Path:        "/environments/{id}/backup-targets/{targetId}",
+		Summary:     "Update a backup target",
+		Description: "Update a backup target. Credentials left empty keep their stored value",
+		Tags:        []string{"Volume Backup"},
+		Security: []map[string][]string{
+			{"BearerAuth": {}},
+			{"ApiKeyAuth": {}},
+		},
+	}, h.UpdateTarget)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "delete-backup-target",
+		Method:      http.MethodDelete,
+		Path:        "/environments/{id}/backup-targets/{targetId}",
+		Summary:     "Delete a backup target",
+		Description: "Delete a backup target that holds no backups and is not used by a backup policy",
+		Tags:        []string{"Volume Backup"},
+		Security: []map[string][]string{
+			{"BearerAuth": {}},
+			{"ApiKeyAuth": {}},
+		},
+	}, h.DeleteTarget)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "test-backup-target",
+		Method:      http.MethodPost,
+		Path:        "/environments/{id}/backup-targets/{targetId}/test",
+		Summary:     "Test a backup target",
+		Description: "Connect to a backup target and list its contents",
+		Tags:        []string{"Volume Backup"},
+		Security: []map[string][]string{
+			{"BearerAuth": {}},
+			{"ApiKeyAuth": {}},
+		},
+	}, h.TestTarget)
+}
+
+// ============================================================================
+// Handler Methods
+// ============================================================================
+
+// ListTargets returns all backup targets.
+func (h *BackupTargetHandler) ListTargets(ctx context.Context, _ *ListBackupTargetsInput) (*ListBackupTargetsOutput, error) {
+	if h.backupTargetService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	if err := checkAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	targets, err := h.backupTargetService.ListTargets(ctx)
+	if err != nil {
+		return nil, huma.Error500InternalServerError(err.Error())
+	}
+
+	out := make([]volumetypes.BackupTarget, 0, len(targets))
+	for i := range targets {
+		out = append(out, targets[i].ToDTO())
+	}
+
+	return &ListBackupTargetsOutput{
+		Body: base.ApiResponse[[]volumetypes.BackupTarget]{
+			Success: true,
+			Data:    out,
+		},
+	}, nil
+}
+
+// CreateTarget creates a backup target.
+func (h *BackupTargetHandler) CreateTarget(ctx context.Context, input *CreateBackupTargetInput) (*CreateBackupTargetOutput, error) {
+	if h.backupTargetService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	if err := checkAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	target, err := h.backupTargetService.CreateTarget(ctx, input.Body)
+	if err != nil {
+		return nil, huma.Error400BadRequest(err.Error())
+	}
+
+	return &CreateBackupTargetOutput{
+		Body: base.ApiResponse[volumetypes.BackupTarget]{
+			Success: true,
+			Data:    target.ToDTO(),
+		},
+	}, nil
+}
+
+// GetTarget returns a backup target by ID.
+func (h *BackupTargetHandler) GetTarget(ctx context.Context, input *GetBackupTargetInput) (*GetBackupTargetOutput, error) {
+	if h.backupTargetService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	if err := checkAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	target, err := h.backupTargetService.GetTarget(ctx, input.TargetID)
+	if err != nil {
+		return nil, backupTargetErrorInternal(err)
+	}
+
+	return &GetBackupTargetOutput{
+		Body: base.ApiResponse[volumetypes.BackupTarget]{
+			Success: true,
+			Data:    target.ToDTO(),
+		},
+	}, nil
+}
+
+// UpdateTarget updates a backup target.
+func (h *BackupTargetHandler) UpdateTarget(ctx context.Context, input *UpdateBackupTargetInput) (*UpdateBackupTargetOutput, error) {
+	if h.backupTargetService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	if err := checkAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	target, err := h.backupTargetService.UpdateTarget(ctx, input.TargetID, input.Body)
+	if err != nil {
+		return nil, backupTargetErrorInternal(err)
+	}
+
+	return &UpdateBackupTargetOutput{
+		Body: base.ApiResponse[volumetypes.BackupTarget]{
+			Success: true,
+			Data:    target.ToDTO(),
+		},
+	}, nil
+}
+
+// DeleteTarget deletes a backup target.
+func (h *BackupTargetHandler) DeleteTarget(ctx context.Context, input *DeleteBackupTargetInput) (*DeleteBackupTargetOutput, error) {
+	if h.backupTargetService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	if err := checkAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := h.backupTargetService.DeleteTarget(ctx, input.TargetID); err != nil {
+		return nil, backupTargetErrorInternal(err)
+	}
+
+	return &DeleteBackupTargetOutput{
+		Body: base.ApiResponse[base.MessageResponse]{
+			Success: true,
+			Data: base.MessageResponse{
+				Message: "Backup target deleted successfully",
+			},
+		},
+	}, nil
+}
+
+// TestTarget checks that a backup target is reachable with its stored credentials.
+func (h *BackupTargetHandler) TestTarget(ctx context.Context, input *TestBackupTargetInput) (*TestBackupTargetOutput, error) {
+	if h.backupTargetService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	if err := checkAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := h.backupTargetService.TestTarget(ctx, input.TargetID); err != nil {
+		return nil, backupTargetErrorInternal(err)
+	}
+
+	return &TestBackupTargetOutput{
+		Body: base.ApiResponse[base.MessageResponse]{
+			Success: true,
+			Data: base.MessageResponse{
+				Message: "Backup target is reachable",
+			},
+		},
+	}, nil
+}
+
+func backupTargetErrorInternal(err error) error {
+	if errors.Is(err, services.ErrBackupTargetNotFound) {
+		return huma.Error404NotFound(err.Error())
+	}
+	return huma.Error400BadRequest(err.Error())
+}
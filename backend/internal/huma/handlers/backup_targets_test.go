@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	humamw "github.com/getarcaneapp/arcane/backend/internal/huma/middleware"
+	"github.com/getarcaneapp/arcane/backend/internal/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupTargetHandlersRequireAdmin(t *testing.T) {
+	h := &BackupTargetHandler{backupTargetService: &services.BackupTargetService{}}
+	ctx := context.WithValue(context.Background(), humamw.ContextKeyUserIsAdmin, false)
+
+	calls := map[string]func() error{
+		"list": func() error {
+			_, err := h.ListTargets(ctx, &ListBackupTargetsInput{})
+			return err
+		},
+		"create": func() error {
+			_, err := h.CreateTarget(ctx, &CreateBackupTargetInput{})
+			return err
+		},
+		"get": func() error {
+			_, err := h.GetTarget(ctx, &GetBackupTargetInput{TargetID: "t1"})
+			return err
+		},
+		"update": func() error {
+			_, err := h.UpdateTarget(ctx, &UpdateBackupTargetInput{TargetID: "t1"})
+			return err
+		},
+		"delete": func() error {
+			_, err := h.DeleteTarget(ctx, &DeleteBackupTargetInput{TargetID: "t1"})
+			return err
+		},
+		"test": func() error {
+			_, err := h.TestTarget(ctx, &TestBackupTargetInput{TargetID: "t1"})
+			return err
+		},
+	}
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			var statusErr huma.StatusError
+			require.ErrorAs(t, call(), &statusErr)
+			assert.Equal(t, http.StatusForbidden, statusErr.GetStatus())
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/getarcaneapp/arcane/backend/internal/common"
+	humamw "github.com/getarcaneapp/arcane/backend/internal/huma/middleware"
+	"github.com/getarcaneapp/arcane/backend/internal/services"
+	"github.com/getarcaneapp/arcane/types/base"
+	containertypes "github.com/getarcaneapp/arcane/types/container"
+	volumetypes "github.com/getarcaneapp/arcane/types/volume"
+)
+
+// --- Container File Browser ---
+
+type ListContainerFilesInput struct {
+	EnvironmentID string `path:"id" doc:"Environment ID"`
+	ContainerID   string `path:"containerId" doc:"Container ID"`
+	Path          string `query:"path" default:"/" doc:"Directory path to list"`
+}
+
+type ListContainerFilesOutput struct {
+	Body base.ApiResponse[[]volumetypes.FileEntry]
+}
+
+type GetContainerFileContentInput struct {
+	EnvironmentID string `path:"id" doc:"Environment ID"`
+	ContainerID   string `path:"containerId" doc:"Container ID"`
+	Path          string `query:"path" doc:"File path"`
+	MaxBytes      int64  `query:"maxBytes" default:"1048576" minimum:"1" doc:"Maximum bytes to read (default 1MB)"`
+}
+
+type GetContainerFileContentOutput struct {
+	Body base.ApiResponse[FileContentResponse]
+}
+
+type DownloadContainerPathInput struct {
+	EnvironmentID string `path:"id" doc:"Environment ID"`
+	ContainerID   string `path:"containerId" doc:"Container ID"`
+	Path          string `query:"path" doc:"File or directory path"`
+}
+
+type UploadContainerFileInput struct {
+	EnvironmentID string         `path:"id" doc:"Environment ID"`
+	ContainerID   string         `path:"containerId" doc:"Container ID"`
+	Path          string         `query:"path" default:"/" doc:"Destination directory"`
+	RawBody       multipart.Form `contentType:"multipart/form-data"`
+}
+
+// ContainerChangesResponse is a dedicated response type
+type ContainerChangesResponse struct {
+	Success bool                        `json:"success"`
+	Data    []containertypes.FileChange `json:"data"`
+}
+
+type GetContainerChangesOutput struct {
+	Body ContainerChangesResponse
+}
+
+// registerContainerFiles registers the container file browser endpoints.
+func registerContainerFiles(api huma.API, h *ContainerHandler) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-container-files",
+		Method:      http.MethodGet,
+		Path:        "/environments/{id}/containers/{containerId}/files",
+		Summary:     "List container directory",
+		Tags:        []string{"Containers"},
+		Security:    []map[string][]string{{"BearerAuth": {}}, {"ApiKeyAuth": {}}},
+	}, h.ListContainerFiles)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-container-file-content",
+		Method:      http.MethodGet,
+		Path:        "/environments/{id}/containers/{containerId}/files/content",
+		Summary:     "Get container file content preview",
+		Tags:        []string{"Containers"},
+		Security:    []map[string][]string{{"BearerAuth": {}}, {"ApiKeyAuth": {}}},
+	}, h.GetContainerFileContent)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "download-container-path",
+		Method:      http.MethodGet,
+		Path:        "/environments/{id}/containers/{containerId}/files/download",
+		Summary:     "Download a file or directory from a container",
+		Description: "Returns a tar archive of the path, as produced by the Docker archive API",
+		Tags:        []string{"Containers"},
+		Security:    []map[string][]string{{"BearerAuth": {}}, {"ApiKeyAuth": {}}},
+	}, h.DownloadContainerPath)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "upload-container-file",
+		Method:      http.MethodPost,
+		Path:        "/environments/{id}/containers/{containerId}/files/upload",
+		Summary:     "Upload a file into a container",
+		Tags:        []string{"Containers"},
+		Security:    []map[string][]string{{"BearerAuth": {}}, {"ApiKeyAuth": {}}},
+		RequestBody: &huma.RequestBody{
+			Content: map[string]*huma.MediaType{
+				"multipart/form-data": {
+					Schema: &huma.Schema{
+						Type: "object",
+						Properties: map[string]*huma.Schema{
+							"file": {
+								Type:        "string",
+								Format:      "binary",
+								Description: "File to upload",
+							},
+						},
+						Required: []string{"file"},
+					},
+				},
+			},
+		},
+	}, h.UploadContainerFile)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-container-changes",
+		Method:      http.MethodGet,
+		Path:        "/environments/{id}/containers/{containerId}/changes",
+		Summary:     "List container filesystem changes",
+		Description: "List files added, changed or deleted in the container since it was created, like docker diff",
+		Tags:        []string{"Containers"},
+		Security:    []map[string][]string{{"BearerAuth": {}}, {"ApiKeyAuth": {}}},
+	}, h.GetContainerChanges)
+}
+
+// containerFilesErrorInternal maps a container file browser error to an HTTP error.
+func containerFilesErrorInternal(err error) error {
+	if errors.Is(err, services.ErrInvalidContainerPath) {
+		return huma.Error400BadRequest((&common.ContainerFilesError{Err: err}).Error())
+	}
+	return huma.Error500InternalServerError((&common.ContainerFilesError{Err: err}).Error())
+}
+
+func (h *ContainerHandler) ListContainerFiles(ctx context.Context, input *ListContainerFilesInput) (*ListContainerFilesOutput, error) {
+	if h.containerService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	entries, err := h.containerService.ListContainerDirectory(ctx, input.ContainerID, input.Path)
+	if err != nil {
+		return nil, containerFilesErrorInternal(err)
+	}
+
+	return &ListContainerFilesOutput{
+		Body: base.ApiResponse[[]volumetypes.FileEntry]{
+			Success: true,
+			Data:    entries,
+		},
+	}, nil
+}
+
+func (h *ContainerHandler) GetContainerFileContent(ctx context.Context, input *GetContainerFileContentInput) (*GetContainerFileContentOutput, error) {
+	if h.containerService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	content, mimeType, err := h.containerService.GetContainerFileContent(ctx, input.ContainerID, input.Path, input.MaxBytes)
+	if err != nil {
+		return nil, containerFilesErrorInternal(err)
+	}
+
+	return &GetContainerFileContentOutput{
+		Body: base.ApiResponse[FileContentResponse]{
+			Success: true,
+			Data: FileContentResponse{
+				Content:  content,
+				MimeType: mimeType,
+			},
+		},
+	}, nil
+}
+
+// DownloadContainerPath streams a tar archive of a file or directory of a container.
+func (h *ContainerHandler) DownloadContainerPath(ctx context.Context, input *DownloadContainerPathInput) (*huma.StreamResponse, error) {
+	if h.containerService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	user, exists := humamw.GetCurrentUserFromContext(ctx)
+	if !exists {
+		return nil, huma.Error401Unauthorized("not authenticated")
+	}
+
+	reader, stat, err := h.containerService.DownloadContainerPath(ctx, input.ContainerID, input.Path, *user)
+	if err != nil {
+		return nil, containerFilesErrorInternal(err)
+	}
+
+	return &huma.StreamResponse{
+		Body: func(humaCtx huma.Context) {
+			defer func() { _ = reader.Close() }()
+			humaCtx.SetHeader("Content-Type", "application/x-tar")
+			humaCtx.SetHeader("Content-Disposition", fmt.Sprintf("attachment; filename=%q", containerArchiveFilename(stat.Name)))
+			_, _ = io.Copy(humaCtx.BodyWriter(), reader)
+		},
+	}, nil
+}
+
+// containerArchiveFilename names a container path archive after the base name of the path.
+func containerArchiveFilename(name string) string {
+	name = path.Base(name)
+	if name == "" || name == "/" || name == "." {
+		name = "root"
+	}
+	return name + ".tar"
+}
+
+func (h *ContainerHandler) UploadContainerFile(ctx context.Context, input *UploadContainerFileInput) (*base.ApiResponse[base.MessageResponse], error) {
+	if h.containerService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	user, exists := humamw.GetCurrentUserFromContext(ctx)
+	if !exists {
+		return nil, huma.Error401Unauthorized("not authenticated")
+	}
+
+	files := input.RawBody.File["file"]
+	if len(files) == 0 {
+		return nil, huma.Error400BadRequest((&common.NoFileUploadedError{}).Error())
+	}
+
+	fileHeader := files[0]
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, huma.Error500InternalServerError((&common.FileUploadReadError{Err: err}).Error())
+	}
+	defer func() { _ = file.Close() }()
+
+	if err := h.containerService.UploadContainerFile(ctx, input.ContainerID, input.Path, file, fileHeader.Filename, *user); err != nil {
+		return nil, containerFilesErrorInternal(err)
+	}
+
+	return &base.ApiResponse[base.MessageResponse]{
+		Success: true,
+		Data:    base.MessageResponse{Message: "File uploaded successfully"},
+	}, nil
+}
+
+func (h *ContainerHandler) GetContainerChanges(ctx context.Context, input *ContainerActionInput) (*GetContainerChangesOutput, error) {
+	if h.containerService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	changes, err := h.containerService.DiffContainer(ctx, input.ContainerID)
+	if err != nil {
+		return nil, huma.Error500InternalServerError((&common.ContainerDiffError{Err: err}).Error())
+	}
+
+	return &GetContainerChangesOutput{
+		Body: ContainerChangesResponse{
+			Success: true,
+			Data:    changes,
+		},
+	}, nil
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"maps"
 	"net/http"
 	"strings"
@@ -17,6 +18,7 @@ import (
 	"github.com/getarcaneapp/arcane/backend/pkg/libarcane"
 	"github.com/getarcaneapp/arcane/types/base"
 	containertypes "github.com/getarcaneapp/arcane/types/container"
+	imagetypes "github.com/getarcaneapp/arcane/types/image"
 )
 
 type ContainerHandler struct {
@@ -118,6 +120,38 @@ type DeleteContainerOutput struct {
 	Body ContainerActionResponse
 }
 
+type UpdateContainerResourcesInput struct {
+	EnvironmentID string `path:"id" doc:"Environment ID"`
+	ContainerID   string `path:"containerId" doc:"Container ID"`
+	Body          containertypes.UpdateResources
+}
+
+// ContainerUpdateResponse is a dedicated response type
+type ContainerUpdateResponse struct {
+	Success bool                        `json:"success"`
+	Data    containertypes.UpdateResult `json:"data"`
+}
+
+type UpdateContainerResourcesOutput struct {
+	Body ContainerUpdateResponse
+}
+
+type CommitContainerInput struct {
+	EnvironmentID string `path:"id" doc:"Environment ID"`
+	ContainerID   string `path:"containerId" doc:"Container ID"`
+	Body          containertypes.Commit
+}
+
+// ContainerCommitResponse is a dedicated response type
+type ContainerCommitResponse struct {
+	Success bool                     `json:"success"`
+	Data    imagetypes.DetailSummary `json:"data"`
+}
+
+type CommitContainerOutput struct {
+	Body ContainerCommitResponse
+}
+
 // RegisterContainers registers container endpoints.
 func RegisterContainers(api huma.API, containerSvc *services.ContainerService, dockerSvc *services.DockerClientService) {
 	h := &ContainerHandler{
@@ -197,6 +231,28 @@ func RegisterContainers(api huma.API, containerSvc *services.ContainerService, d
 		Tags:        []string{"Containers"},
 		Security:    []map[string][]string{{"BearerAuth": {}}, {"ApiKeyAuth": {}}},
 	}, h.DeleteContainer)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "update-container-resources",
+		Method:      http.MethodPut,
+		Path:        "/environments/{id}/containers/{containerId}/resources",
+		Summary:     "Update container resources",
+		Description: "Change the memory/CPU limits, pids limit or restart policy of a container without recreating it",
+		Tags:        []string{"Containers"},
+		Security:    []map[string][]string{{"BearerAuth": {}}, {"ApiKeyAuth": {}}},
+	}, h.UpdateContainerResources)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "commit-container",
+		Method:      http.MethodPost,
+		Path:        "/environments/{id}/containers/{containerId}/commit",
+		Summary:     "Commit container to image",
+		Description: "Create a new image from the current state of a container",
+		Tags:        []string{"Containers"},
+		Security:    []map[string][]string{{"BearerAuth": {}}, {"ApiKeyAuth": {}}},
+	}, h.CommitContainer)
+
+	registerContainerFiles(api, h)
 }
 
 func (h *ContainerHandler) ListContainers(ctx context.Context, input *ListContainersInput) (*ListContainersOutput, error) {
@@ -643,3 +699,55 @@ func (h *ContainerHandler) DeleteContainer(ctx context.Context, input *DeleteCon
 		},
 	}, nil
 }
+
+func (h *ContainerHandler) UpdateContainerResources(ctx context.Context, input *UpdateContainerResourcesInput) (*UpdateContainerResourcesOutput, error) {
+	if h.containerService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	user, exists := humamw.GetCurrentUserFromContext(ctx)
+	if !exists {
+		return nil, huma.Error401Unauthorized("not authenticated")
+	}
+
+	result, err := h.containerService.UpdateContainerResources(ctx, input.ContainerID, input.Body, *user)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidResourceUpdate) {
+			return nil, huma.Error400BadRequest((&common.ContainerUpdateError{Err: err}).Error())
+		}
+		return nil, huma.Error500InternalServerError((&common.ContainerUpdateError{Err: err}).Error())
+	}
+
+	return &UpdateContainerResourcesOutput{
+		Body: ContainerUpdateResponse{
+			Success: true,
+			Data:    result,
+		},
+	}, nil
+}
+
+func (h *ContainerHandler) CommitContainer(ctx context.Context, input *CommitContainerInput) (*CommitContainerOutput, error) {
+	if h.containerService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	user, exists := humamw.GetCurrentUserFromContext(ctx)
+	if !exists {
+		return nil, huma.Error401Unauthorized("not authenticated")
+	}
+
+	img, err := h.containerService.CommitContainer(ctx, input.ContainerID, input.Body, *user)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidCommitReference) {
+			return nil, huma.Error400BadRequest((&common.ContainerCommitError{Err: err}).Error())
+		}
+		return nil, huma.Error500InternalServerError((&common.ContainerCommitError{Err: err}).Error())
+	}
+
+	return &CommitContainerOutput{
+		Body: ContainerCommitResponse{
+			Success: true,
+			Data:    *img,
+		},
+	}, nil
+}
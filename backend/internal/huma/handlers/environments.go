@@ -18,6 +18,7 @@ import (
 	"github.com/getarcaneapp/arcane/backend/internal/utils/edge"
 	"github.com/getarcaneapp/arcane/backend/internal/utils/mapper"
 	"github.com/getarcaneapp/arcane/backend/internal/utils/pagination"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/rbac"
 	"github.com/getarcaneapp/arcane/backend/internal/utils/stringutils"
 	"github.com/getarcaneapp/arcane/types/base"
 	"github.com/getarcaneapp/arcane/types/environment"
@@ -351,7 +352,12 @@ func (h *EnvironmentHandler) ListEnvironments(ctx context.Context, input *ListEn
 		},
 	}
 
-	envs, paginationResp, err := h.environmentService.ListEnvironmentsPaginated(ctx, params)
+	var access models.EnvironmentAccess
+	if user, ok := humamw.GetCurrentUserFromContext(ctx); ok {
+		access = rbac.EnvironmentAccessFor(user)
+	}
+
+	envs, paginationResp, err := h.environmentService.ListEnvironmentsPaginated(ctx, params, access)
 	if err != nil {
 		return nil, huma.Error500InternalServerError((&common.EnvironmentListError{Err: err}).Error())
 	}
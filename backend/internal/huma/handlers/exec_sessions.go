@@ -0,0 +1,250 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/getarcaneapp/arcane/backend/internal/common"
+	humamw "github.com/getarcaneapp/arcane/backend/internal/huma/middleware"
+	"github.com/getarcaneapp/arcane/backend/internal/services"
+	"github.com/getarcaneapp/arcane/types/base"
+	containertypes "github.com/getarcaneapp/arcane/types/container"
+)
+
+// ExecSessionHandler handles recorded container exec session endpoints. The sessions
+// themselves are started and reattached over the container terminal WebSocket.
+type ExecSessionHandler struct {
+	execSessionService *services.ExecSessionService
+}
+
+// ============================================================================
+// Input/Output Types
+// ============================================================================
+
+// ExecSessionPaginatedResponse is the paginated response for exec sessions.
+type ExecSessionPaginatedResponse struct {
+	Success    bool                         `json:"success"`
+	Data       []containertypes.ExecSession `json:"data"`
+	Pagination base.PaginationResponse      `json:"pagination"`
+}
+
+type ListExecSessionsInput struct {
+	EnvironmentID string `path:"id" doc:"Environment ID"`
+	Search        string `query:"search" doc:"Search by container, user or command"`
+	Sort          string `query:"sort" doc:"Column to sort by"`
+	Order         string `query:"order" default:"asc" doc:"Sort direction"`
+	Start         int    `query:"start" default:"0" doc:"Start index"`
+	Limit         int    `query:"limit" default:"20" doc:"Limit"`
+	ContainerID   string `query:"containerId" doc:"Filter by container ID or name"`
+	Status        string `query:"status" doc:"Filter by status (active, detached or ended)"`
+}
+
+type ListExecSessionsOutput struct {
+	Body ExecSessionPaginatedResponse
+}
+
+type ExecSessionInput struct {
+	EnvironmentID string `path:"id" doc:"Environment ID"`
+	SessionID     string `path:"sessionId" doc:"Exec session ID"`
+}
+
+// ExecSessionResponse is a dedicated response type
+type ExecSessionResponse struct {
+	Success bool                       `json:"success"`
+	Data    containertypes.ExecSession `json:"data"`
+}
+
+type GetExecSessionOutput struct {
+	Body ExecSessionResponse
+}
+
+// ============================================================================
+// Registration
+// ============================================================================
+
+// RegisterExecSessions registers the exec session recording endpoints.
+func RegisterExecSessions(api huma.API, execSessionService *services.ExecSessionService) {
+	h := &ExecSessionHandler{execSessionService: execSessionService}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-exec-sessions",
+		Method:      http.MethodGet,
+		Path:        "/environments/{id}/containers/exec-sessions",
+		Summary:     "List exec sessions",
+		Description: "Paginated list of recorded container exec sessions, newest first. Non-admin users only see their own sessions.",
+		Tags:        []string{"Containers"},
+		Security:    []map[string][]string{{"BearerAuth": {}}, {"ApiKeyAuth": {}}},
+	}, h.ListExecSessions)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-exec-session",
+		Method:      http.MethodGet,
+		Path:        "/environments/{id}/containers/exec-sessions/{sessionId}",
+		Summary:     "Get exec session",
+		Tags:        []string{"Containers"},
+		Security:    []map[string][]string{{"BearerAuth": {}}, {"ApiKeyAuth": {}}},
+	}, h.GetExecSession)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-exec-session-recording",
+		Method:      http.MethodGet,
+		Path:        "/environments/{id}/containers/exec-sessions/{sessionId}/recording",
+		Summary:     "Download exec session recording",
+		Description: "Returns the terminal I/O of the session in asciicast v2 format, which can be replayed with asciinema",
+		Tags:        []string{"Containers"},
+		Security:    []map[string][]string{{"BearerAuth": {}}, {"ApiKeyAuth": {}}},
+	}, h.GetExecSessionRecording)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "terminate-exec-session",
+		Method:      http.MethodDelete,
+		Path:        "/environments/{id}/containers/exec-sessions/{sessionId}",
+		Summary:     "Terminate exec session",
+		Description: "End a running exec session. Its recording is kept.",
+		Tags:        []string{"Containers"},
+		Security:    []map[string][]string{{"BearerAuth": {}}, {"ApiKeyAuth": {}}},
+	}, h.TerminateExecSession)
+}
+
+// execSessionErrorInternal maps an exec session error to an HTTP error.
+func execSessionErrorInternal(err error) error {
+	switch {
+	case errors.Is(err, services.ErrExecSessionNotFound), errors.Is(err, services.ErrExecRecordingNotFound):
+		return huma.Error404NotFound((&common.ExecSessionError{Err: err}).Error())
+	case errors.Is(err, services.ErrExecSessionForbidden):
+		return huma.Error403Forbidden((&common.ExecSessionError{Err: err}).Error())
+	case errors.Is(err, services.ErrExecSessionNotRunning):
+		return huma.Error409Conflict((&common.ExecSessionError{Err: err}).Error())
+	default:
+		return huma.Error500InternalServerError((&common.ExecSessionError{Err: err}).Error())
+	}
+}
+
+// ============================================================================
+// Handler Methods
+// ============================================================================
+
+// ListExecSessions returns recorded exec sessions.
+func (h *ExecSessionHandler) ListExecSessions(ctx context.Context, input *ListExecSessionsInput) (*ListExecSessionsOutput, error) {
+	if h.execSessionService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	user, exists := humamw.GetCurrentUserFromContext(ctx)
+	if !exists {
+		return nil, huma.Error401Unauthorized("not authenticated")
+	}
+
+	params := buildPaginationParams(0, input.Start, input.Limit, input.Sort, input.Order, input.Search)
+	if input.Status != "" {
+		params.Filters["status"] = input.Status
+	}
+
+	userID := user.ID
+	if humamw.IsAdminFromContext(ctx) {
+		userID = ""
+	}
+
+	sessions, paginationResp, err := h.execSessionService.ListSessions(ctx, params, input.ContainerID, userID)
+	if err != nil {
+		return nil, huma.Error500InternalServerError((&common.ExecSessionListError{Err: err}).Error())
+	}
+
+	data := make([]containertypes.ExecSession, 0, len(sessions))
+	for i := range sessions {
+		data = append(data, sessions[i].ToDTO())
+	}
+
+	return &ListExecSessionsOutput{
+		Body: ExecSessionPaginatedResponse{
+			Success: true,
+			Data:    data,
+			Pagination: base.PaginationResponse{
+				TotalPages:      paginationResp.TotalPages,
+				TotalItems:      paginationResp.TotalItems,
+				CurrentPage:     paginationResp.CurrentPage,
+				ItemsPerPage:    paginationResp.ItemsPerPage,
+				GrandTotalItems: paginationResp.GrandTotalItems,
+			},
+		},
+	}, nil
+}
+
+// GetExecSession returns an exec session.
+func (h *ExecSessionHandler) GetExecSession(ctx context.Context, input *ExecSessionInput) (*GetExecSessionOutput, error) {
+	if h.execSessionService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	user, exists := humamw.GetCurrentUserFromContext(ctx)
+	if !exists {
+		return nil, huma.Error401Unauthorized("not authenticated")
+	}
+
+	session, err := h.execSessionService.GetSession(ctx, input.SessionID, *user, humamw.IsAdminFromContext(ctx))
+	if err != nil {
+		return nil, execSessionErrorInternal(err)
+	}
+
+	return &GetExecSessionOutput{
+		Body: ExecSessionResponse{
+			Success: true,
+			Data:    session.ToDTO(),
+		},
+	}, nil
+}
+
+// GetExecSessionRecording streams the asciicast recording of an exec session.
+func (h *ExecSessionHandler) GetExecSessionRecording(ctx context.Context, input *ExecSessionInput) (*huma.StreamResponse, error) {
+	if h.execSessionService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	user, exists := humamw.GetCurrentUserFromContext(ctx)
+	if !exists {
+		return nil, huma.Error401Unauthorized("not authenticated")
+	}
+
+	reader, size, err := h.execSessionService.OpenRecording(ctx, input.SessionID, *user, humamw.IsAdminFromContext(ctx))
+	if err != nil {
+		return nil, execSessionErrorInternal(err)
+	}
+
+	return &huma.StreamResponse{
+		Body: func(humaCtx huma.Context) {
+			defer func() { _ = reader.Close() }()
+			humaCtx.SetHeader("Content-Type", "application/x-asciicast")
+			humaCtx.SetHeader("Content-Length", fmt.Sprintf("%d", size))
+			humaCtx.SetHeader("Content-Disposition", fmt.Sprintf("attachment; filename=%q", input.SessionID+".cast"))
+			_, _ = io.Copy(humaCtx.BodyWriter(), reader)
+		},
+	}, nil
+}
+
+// TerminateExecSession ends a running exec session.
+func (h *ExecSessionHandler) TerminateExecSession(ctx context.Context, input *ExecSessionInput) (*base.ApiResponse[base.MessageResponse], error) {
+	if h.execSessionService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	user, exists := humamw.GetCurrentUserFromContext(ctx)
+	if !exists {
+		return nil, huma.Error401Unauthorized("not authenticated")
+	}
+
+	if err := h.execSessionService.TerminateSession(ctx, input.SessionID, *user, humamw.IsAdminFromContext(ctx)); err != nil {
+		if errors.Is(err, services.ErrExecSessionNotFound) || errors.Is(err, services.ErrExecSessionForbidden) || errors.Is(err, services.ErrExecSessionNotRunning) {
+			return nil, execSessionErrorInternal(err)
+		}
+		return nil, huma.Error500InternalServerError((&common.ExecSessionTerminateError{Err: err}).Error())
+	}
+
+	return &base.ApiResponse[base.MessageResponse]{
+		Success: true,
+		Data:    base.MessageResponse{Message: "Exec session terminated"},
+	}, nil
+}
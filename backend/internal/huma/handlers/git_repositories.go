@@ -90,6 +90,14 @@ type ListBranchesOutput struct {
 	Body base.ApiResponse[gitops.BranchesResponse]
 }
 
+type ListTagsInput struct {
+	ID string `path:"id" doc:"Repository ID"`
+}
+
+type ListTagsOutput struct {
+	Body base.ApiResponse[gitops.TagsResponse]
+}
+
 type BrowseFilesInput struct {
 	ID     string `path:"id" doc:"Repository ID"`
 	Branch string `query:"branch" doc:"Branch to browse"`
@@ -207,6 +215,19 @@ func RegisterGitRepositories(api huma.API, repoService *services.GitRepositorySe
 		},
 	}, h.ListBranches)
 
+	huma.Register(api, huma.Operation{
+		OperationID: "listGitRepositoryTags",
+		Method:      "GET",
+		Path:        "/customize/git-repositories/{id}/tags",
+		Summary:     "List repository tags",
+		Description: "Get all tags from a git repository, semantic version tags first, newest first",
+		Tags:        []string{"Customize"},
+		Security: []map[string][]string{
+			{"BearerAuth": {}},
+			{"ApiKeyAuth": {}},
+		},
+	}, h.ListTags)
+
 	huma.Register(api, huma.Operation{
 		OperationID: "browseGitRepositoryFiles",
 		Method:      "GET",
@@ -403,6 +424,27 @@ func (h *GitRepositoryHandler) ListBranches(ctx context.Context, input *ListBran
 	}, nil
 }
 
+// ListTags returns all tags from a git repository.
+func (h *GitRepositoryHandler) ListTags(ctx context.Context, input *ListTagsInput) (*ListTagsOutput, error) {
+	if h.repoService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	tags, err := h.repoService.ListTags(ctx, input.ID)
+	if err != nil {
+		return nil, huma.Error400BadRequest((&common.GitRepositoryTagsError{Err: err}).Error())
+	}
+
+	return &ListTagsOutput{
+		Body: base.ApiResponse[gitops.TagsResponse]{
+			Success: true,
+			Data: gitops.TagsResponse{
+				Tags: tags,
+			},
+		},
+	}, nil
+}
+
 // BrowseFiles returns files and directories from a git repository.
 func (h *GitRepositoryHandler) BrowseFiles(ctx context.Context, input *BrowseFilesInput) (*BrowseFilesOutput, error) {
 	if h.repoService == nil {
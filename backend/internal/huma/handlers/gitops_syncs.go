@@ -2,9 +2,12 @@ package handlers
 
 import (
 	"context"
+	"errors"
+	"net/http"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/getarcaneapp/arcane/backend/internal/common"
+	humamw "github.com/getarcaneapp/arcane/backend/internal/huma/middleware"
 	"github.com/getarcaneapp/arcane/backend/internal/models"
 	"github.com/getarcaneapp/arcane/backend/internal/services"
 	"github.com/getarcaneapp/arcane/backend/internal/utils/mapper"
@@ -97,6 +100,55 @@ type GetSyncStatusOutput struct {
 	Body base.ApiResponse[gitops.SyncStatus]
 }
 
+type CheckSyncDriftInput struct {
+	EnvironmentID string `path:"id" doc:"Environment ID"`
+	SyncID        string `path:"syncId" doc:"Sync ID"`
+}
+
+type CheckSyncDriftOutput struct {
+	Body base.ApiResponse[gitops.DriftReport]
+}
+
+type RollbackSyncInput struct {
+	EnvironmentID string `path:"id" doc:"Environment ID"`
+	SyncID        string `path:"syncId" doc:"Sync ID"`
+	Body          gitops.RollbackRequest
+}
+
+type RollbackSyncOutput struct {
+	Body base.ApiResponse[gitops.SyncResult]
+}
+
+type ListSyncCommitsInput struct {
+	EnvironmentID string `path:"id" doc:"Environment ID"`
+	SyncID        string `path:"syncId" doc:"Sync ID"`
+}
+
+type ListSyncCommitsOutput struct {
+	Body base.ApiResponse[[]gitops.CommitInfo]
+}
+
+// GitOpsSyncRunPaginatedResponse is the paginated response for the runs of a GitOps sync.
+type GitOpsSyncRunPaginatedResponse struct {
+	Success    bool                    `json:"success"`
+	Data       []gitops.SyncRun        `json:"data"`
+	Pagination base.PaginationResponse `json:"pagination"`
+}
+
+type ListSyncRunsInput struct {
+	EnvironmentID string `path:"id" doc:"Environment ID"`
+	SyncID        string `path:"syncId" doc:"Sync ID"`
+	Status        string `query:"status" doc:"Filter by result (success or failed)"`
+	Sort          string `query:"sort" doc:"Column to sort by (default: newest run first)"`
+	Order         string `query:"order" default:"desc" doc:"Sort direction (asc or desc)"`
+	Start         int    `query:"start" default:"0" doc:"Start index"`
+	Limit         int    `query:"limit" default:"20" doc:"Items per page"`
+}
+
+type ListSyncRunsOutput struct {
+	Body GitOpsSyncRunPaginatedResponse
+}
+
 type BrowseSyncFilesInput struct {
 	EnvironmentID string `path:"id" doc:"Environment ID"`
 	SyncID        string `path:"syncId" doc:"Sync ID"`
@@ -116,6 +168,48 @@ type ImportGitOpsSyncsOutput struct {
 	Body base.ApiResponse[gitops.ImportGitOpsSyncResponse]
 }
 
+type GitOpsSyncWebhookInput struct {
+	EnvironmentID     string `path:"id" doc:"Environment ID"`
+	SyncID            string `path:"syncId" doc:"Sync ID"`
+	GitHubEvent       string `header:"X-GitHub-Event" doc:"GitHub event name"`
+	HubSignature256   string `header:"X-Hub-Signature-256" doc:"GitHub HMAC-SHA256 signature"`
+	HubSignature      string `header:"X-Hub-Signature" doc:"Bitbucket HMAC-SHA256 signature"`
+	GitLabEvent       string `header:"X-Gitlab-Event" doc:"GitLab event name"`
+	GitLabToken       string `header:"X-Gitlab-Token" doc:"GitLab secret token"`
+	GiteaEvent        string `header:"X-Gitea-Event" doc:"Gitea event name"`
+	GiteaSignature    string `header:"X-Gitea-Signature" doc:"Gitea HMAC-SHA256 signature"`
+	ForgejoEvent      string `header:"X-Forgejo-Event" doc:"Forgejo event name"`
+	ForgejoSignature  string `header:"X-Forgejo-Signature" doc:"Forgejo HMAC-SHA256 signature"`
+	BitbucketEventKey string `header:"X-Event-Key" doc:"Bitbucket event key"`
+	RawBody           []byte `contentType:"application/json"`
+}
+
+// header returns the provider headers of the request.
+func (i *GitOpsSyncWebhookInput) header() http.Header {
+	header := http.Header{}
+	for key, value := range map[string]string{
+		"X-GitHub-Event":      i.GitHubEvent,
+		"X-Hub-Signature-256": i.HubSignature256,
+		"X-Hub-Signature":     i.HubSignature,
+		"X-Gitlab-Event":      i.GitLabEvent,
+		"X-Gitlab-Token":      i.GitLabToken,
+		"X-Gitea-Event":       i.GiteaEvent,
+		"X-Gitea-Signature":   i.GiteaSignature,
+		"X-Forgejo-Event":     i.ForgejoEvent,
+		"X-Forgejo-Signature": i.ForgejoSignature,
+		"X-Event-Key":         i.BitbucketEventKey,
+	} {
+		if value != "" {
+			header.Set(key, value)
+		}
+	}
+	return header
+}
+
+type GitOpsSyncWebhookOutput struct {
+	Body base.ApiResponse[gitops.WebhookResult]
+}
+
 // ============================================================================
 // Registration
 // ============================================================================
@@ -228,6 +322,58 @@ func RegisterGitOpsSyncs(api huma.API, syncService *services.GitOpsSyncService)
 		},
 	}, h.GetStatus)
 
+	huma.Register(api, huma.Operation{
+		OperationID: "checkGitOpsSyncDrift",
+		Method:      "POST",
+		Path:        "/environments/{id}/gitops-syncs/{syncId}/drift-check",
+		Summary:     "Check a GitOps sync for drift",
+		Description: "Compare the sync's deployed project and containers with the ref it deploys. The project is never changed",
+		Tags:        []string{"GitOps Syncs"},
+		Security: []map[string][]string{
+			{"BearerAuth": {}},
+			{"ApiKeyAuth": {}},
+		},
+	}, h.CheckDrift)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "rollbackGitOpsSync",
+		Method:      "POST",
+		Path:        "/environments/{id}/gitops-syncs/{syncId}/rollback",
+		Summary:     "Roll back a GitOps sync",
+		Description: "Pin the sync to a commit and deploy it. The sync stays pinned until its ref type is changed back",
+		Tags:        []string{"GitOps Syncs"},
+		Security: []map[string][]string{
+			{"BearerAuth": {}},
+			{"ApiKeyAuth": {}},
+		},
+	}, h.Rollback)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "listGitOpsSyncCommits",
+		Method:      "GET",
+		Path:        "/environments/{id}/gitops-syncs/{syncId}/commits",
+		Summary:     "List GitOps sync commits",
+		Description: "List the latest commits of the sync's branch, newest first, to pick a rollback target",
+		Tags:        []string{"GitOps Syncs"},
+		Security: []map[string][]string{
+			{"BearerAuth": {}},
+			{"ApiKeyAuth": {}},
+		},
+	}, h.ListCommits)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "listGitOpsSyncRuns",
+		Method:      "GET",
+		Path:        "/environments/{id}/gitops-syncs/{syncId}/runs",
+		Summary:     "List GitOps sync runs",
+		Description: "Get a paginated history of the sync's runs with the commit, changed files, output, duration and result of each",
+		Tags:        []string{"GitOps Syncs"},
+		Security: []map[string][]string{
+			{"BearerAuth": {}},
+			{"ApiKeyAuth": {}},
+		},
+	}, h.ListRuns)
+
 	huma.Register(api, huma.Operation{
 		OperationID: "browseGitOpsSyncFiles",
 		Method:      "GET",
@@ -240,6 +386,19 @@ func RegisterGitOpsSyncs(api huma.API, syncService *services.GitOpsSyncService)
 			{"ApiKeyAuth": {}},
 		},
 	}, h.BrowseFiles)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "gitOpsSyncWebhook",
+		Method:      "POST",
+		Path:        "/environments/{id}/gitops-syncs/{syncId}/webhook",
+		Summary:     "Receive a GitOps push webhook",
+		Description: "Trigger a sync on pushes to the sync's branch. Accepts JSON push webhooks from GitHub, GitLab, Gitea, Forgejo and Bitbucket, authenticated with the sync's webhook secret instead of a user session",
+		Tags:        []string{"GitOps Syncs"},
+		// Push payloads can be far larger than the default 1 MiB limit. The body is verified
+		// against its signature byte for byte, so it is passed through unvalidated.
+		MaxBodyBytes:     25 << 20,
+		SkipValidateBody: true,
+	}, h.Webhook)
 }
 
 // ============================================================================
@@ -430,6 +589,139 @@ func (h *GitOpsSyncHandler) GetStatus(ctx context.Context, input *GetSyncStatusI
 	}, nil
 }
 
+// CheckDrift compares a sync's deployed project with its repository and records the result.
+func (h *GitOpsSyncHandler) CheckDrift(ctx context.Context, input *CheckSyncDriftInput) (*CheckSyncDriftOutput, error) {
+	if h.syncService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	report, err := h.syncService.CheckDrift(ctx, input.EnvironmentID, input.SyncID)
+	if err != nil {
+		var notFoundErr *models.NotFoundError
+		switch {
+		case errors.Is(err, services.ErrGitOpsDriftNoProject):
+			return nil, huma.Error400BadRequest((&common.GitOpsDriftCheckError{Err: err}).Error())
+		case errors.As(err, &notFoundErr):
+			return nil, huma.Error404NotFound((&common.GitOpsDriftCheckError{Err: err}).Error())
+		default:
+			return nil, huma.Error500InternalServerError((&common.GitOpsDriftCheckError{Err: err}).Error())
+		}
+	}
+
+	return &CheckSyncDriftOutput{
+		Body: base.ApiResponse[gitops.DriftReport]{
+			Success: true,
+			Data:    *report,
+		},
+	}, nil
+}
+
+// Rollback pins a sync to a commit and deploys it.
+func (h *GitOpsSyncHandler) Rollback(ctx context.Context, input *RollbackSyncInput) (*RollbackSyncOutput, error) {
+	if h.syncService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	user, exists := humamw.GetCurrentUserFromContext(ctx)
+	if !exists {
+		return nil, huma.Error401Unauthorized((&common.NotAuthenticatedError{}).Error())
+	}
+
+	result, err := h.syncService.RollbackSync(ctx, input.EnvironmentID, input.SyncID, input.Body.Commit, *user)
+	if err != nil {
+		apiErr := models.ToAPIError(err)
+		return nil, huma.NewError(apiErr.HTTPStatus(), (&common.GitOpsSyncRollbackError{Err: err}).Error())
+	}
+
+	return &RollbackSyncOutput{
+		Body: base.ApiResponse[gitops.SyncResult]{
+			Success: result.Success,
+			Data:    *result,
+		},
+	}, nil
+}
+
+// ListCommits returns the latest commits of a sync's branch.
+func (h *GitOpsSyncHandler) ListCommits(ctx context.Context, input *ListSyncCommitsInput) (*ListSyncCommitsOutput, error) {
+	if h.syncService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	commits, err := h.syncService.ListSyncCommits(ctx, input.EnvironmentID, input.SyncID)
+	if err != nil {
+		apiErr := models.ToAPIError(err)
+		return nil, huma.NewError(apiErr.HTTPStatus(), (&common.GitOpsSyncCommitsError{Err: err}).Error())
+	}
+
+	return &ListSyncCommitsOutput{
+		Body: base.ApiResponse[[]gitops.CommitInfo]{
+			Success: true,
+			Data:    commits,
+		},
+	}, nil
+}
+
+// ListRuns returns a paginated history of a sync's runs.
+func (h *GitOpsSyncHandler) ListRuns(ctx context.Context, input *ListSyncRunsInput) (*ListSyncRunsOutput, error) {
+	if h.syncService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	params := buildPaginationParams(0, input.Start, input.Limit, input.Sort, input.Order, "")
+	if input.Status != "" {
+		params.Filters["status"] = input.Status
+	}
+
+	runs, paginationResp, err := h.syncService.ListSyncRuns(ctx, input.EnvironmentID, input.SyncID, params)
+	if err != nil {
+		apiErr := models.ToAPIError(err)
+		return nil, huma.NewError(apiErr.HTTPStatus(), (&common.GitOpsSyncRunsError{Err: err}).Error())
+	}
+
+	return &ListSyncRunsOutput{
+		Body: GitOpsSyncRunPaginatedResponse{
+			Success: true,
+			Data:    runs,
+			Pagination: base.PaginationResponse{
+				TotalPages:      paginationResp.TotalPages,
+				TotalItems:      paginationResp.TotalItems,
+				CurrentPage:     paginationResp.CurrentPage,
+				ItemsPerPage:    paginationResp.ItemsPerPage,
+				GrandTotalItems: paginationResp.GrandTotalItems,
+			},
+		},
+	}, nil
+}
+
+// Webhook verifies a push webhook and triggers the sync when its branch was updated.
+func (h *GitOpsSyncHandler) Webhook(ctx context.Context, input *GitOpsSyncWebhookInput) (*GitOpsSyncWebhookOutput, error) {
+	if h.syncService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	result, err := h.syncService.HandleWebhook(ctx, input.EnvironmentID, input.SyncID, input.header(), input.RawBody)
+	if err != nil {
+		var notFoundErr *models.NotFoundError
+		switch {
+		case errors.Is(err, services.ErrGitOpsWebhookUnauthorized):
+			return nil, huma.Error401Unauthorized((&common.GitOpsWebhookError{Err: err}).Error())
+		case errors.Is(err, services.ErrGitOpsWebhookInvalid):
+			return nil, huma.Error400BadRequest((&common.GitOpsWebhookError{Err: err}).Error())
+		case errors.As(err, &notFoundErr):
+			return nil, huma.Error404NotFound((&common.GitOpsWebhookError{Err: err}).Error())
+		default:
+			return nil, huma.Error500InternalServerError((&common.GitOpsWebhookError{Err: err}).Error())
+		}
+	}
+
+	return &GitOpsSyncWebhookOutput{
+		Body: base.ApiResponse[gitops.WebhookResult]{
+			Success: true,
+			Data:    *result,
+		},
+	}, nil
+}
+
 // BrowseFiles returns the file tree at the specified path in the repository.
 func (h *GitOpsSyncHandler) BrowseFiles(ctx context.Context, input *BrowseSyncFilesInput) (*BrowseSyncFilesOutput, error) {
 	if h.syncService == nil {
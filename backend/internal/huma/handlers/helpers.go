@@ -9,10 +9,14 @@ import (
 )
 
 // checkAdmin checks if the current user is an admin and returns a 403 error if not.
+// Requests made with an environment-scoped API key are never treated as admin.
 func checkAdmin(ctx context.Context) error {
 	if !humamw.IsAdminFromContext(ctx) {
 		return huma.Error403Forbidden("admin access required")
 	}
+	if user, ok := humamw.GetCurrentUserFromContext(ctx); ok && user.KeyEnvironmentAccess.IsRestricted() {
+		return huma.Error403Forbidden("admin access required")
+	}
 	return nil
 }
 
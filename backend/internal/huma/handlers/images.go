@@ -108,6 +108,25 @@ type UploadImageOutput struct {
 	Body base.ApiResponse[image.LoadResult]
 }
 
+type TagImageInput struct {
+	EnvironmentID string `path:"id" doc:"Environment ID"`
+	Body          image.TagOptions
+}
+
+type TagImageOutput struct {
+	Body base.ApiResponse[base.MessageResponse]
+}
+
+type PushImageInput struct {
+	EnvironmentID string `path:"id" doc:"Environment ID"`
+	Body          image.PushOptions
+}
+
+type SaveImagesInput struct {
+	EnvironmentID string `path:"id" doc:"Environment ID"`
+	Body          image.SaveOptions
+}
+
 // RegisterImages registers image management routes using Huma.
 func RegisterImages(api huma.API, dockerService *services.DockerClientService, imageService *services.ImageService, imageUpdateService *services.ImageUpdateService, settingsService *services.SettingsService) {
 	h := &ImageHandler{
@@ -224,6 +243,45 @@ func RegisterImages(api huma.API, dockerService *services.DockerClientService, i
 			},
 		},
 	}, h.UploadImage)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "tag-image",
+		Method:      http.MethodPost,
+		Path:        "/environments/{id}/images/tag",
+		Summary:     "Tag an image",
+		Description: "Add a new reference to an existing image",
+		Tags:        []string{"Images"},
+		Security: []map[string][]string{
+			{"BearerAuth": {}},
+			{"ApiKeyAuth": {}},
+		},
+	}, h.TagImage)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "push-image",
+		Method:      http.MethodPost,
+		Path:        "/environments/{id}/images/push",
+		Summary:     "Push an image",
+		Description: "Push an image to a registry, optionally a configured one, with streaming progress output",
+		Tags:        []string{"Images"},
+		Security: []map[string][]string{
+			{"BearerAuth": {}},
+			{"ApiKeyAuth": {}},
+		},
+	}, h.PushImage)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "save-images",
+		Method:      http.MethodPost,
+		Path:        "/environments/{id}/images/save",
+		Summary:     "Export images",
+		Description: "Export one or more images as a tar archive that can be loaded on another host",
+		Tags:        []string{"Images"},
+		Security: []map[string][]string{
+			{"BearerAuth": {}},
+			{"ApiKeyAuth": {}},
+		},
+	}, h.SaveImages)
 }
 
 // ListImages returns a paginated list of images.
@@ -496,3 +554,105 @@ func (h *ImageHandler) UploadImage(ctx context.Context, input *UploadImageInput)
 		},
 	}, nil
 }
+
+// TagImage adds a new reference to an image.
+func (h *ImageHandler) TagImage(ctx context.Context, input *TagImageInput) (*TagImageOutput, error) {
+	if h.imageService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	user, exists := humamw.GetCurrentUserFromContext(ctx)
+	if !exists {
+		return nil, huma.Error401Unauthorized((&common.NotAuthenticatedError{}).Error())
+	}
+
+	if err := h.imageService.TagImage(ctx, input.Body.Source, input.Body.Target, *user); err != nil {
+		return nil, huma.Error500InternalServerError(err.Error())
+	}
+
+	return &TagImageOutput{
+		Body: base.ApiResponse[base.MessageResponse]{
+			Success: true,
+			Data:    base.MessageResponse{Message: fmt.Sprintf("Image tagged as %s", input.Body.Target)},
+		},
+	}, nil
+}
+
+// PushImage pushes an image with streaming progress.
+func (h *ImageHandler) PushImage(ctx context.Context, input *PushImageInput) (*huma.StreamResponse, error) {
+	if h.imageService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	user, exists := humamw.GetCurrentUserFromContext(ctx)
+	if !exists {
+		return nil, huma.Error401Unauthorized((&common.NotAuthenticatedError{}).Error())
+	}
+
+	return &huma.StreamResponse{
+		Body: func(humaCtx huma.Context) { //nolint:contextcheck // context is obtained from humaCtx.Context()
+			humaCtx.SetHeader("Content-Type", "application/x-json-stream")
+			humaCtx.SetHeader("Cache-Control", "no-cache")
+			humaCtx.SetHeader("Connection", "keep-alive")
+			humaCtx.SetHeader("X-Accel-Buffering", "no")
+
+			writer := humaCtx.BodyWriter()
+
+			ref, err := h.imageService.PushImage(humaCtx.Context(), input.Body.ImageName, input.Body.RegistryID, writer, *user)
+			if err != nil {
+				_, _ = fmt.Fprintf(writer, `{"error":%q}`+"\n", err.Error())
+				return
+			}
+			_, _ = fmt.Fprintf(writer, `{"status":"complete","image":%q}`+"\n", ref)
+		},
+	}, nil
+}
+
+// SaveImages streams a tar archive of the requested images.
+func (h *ImageHandler) SaveImages(ctx context.Context, input *SaveImagesInput) (*huma.StreamResponse, error) {
+	if h.imageService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	user, exists := humamw.GetCurrentUserFromContext(ctx)
+	if !exists {
+		return nil, huma.Error401Unauthorized((&common.NotAuthenticatedError{}).Error())
+	}
+
+	return &huma.StreamResponse{
+		Body: func(humaCtx huma.Context) { //nolint:contextcheck // context is obtained from humaCtx.Context()
+			writer := &lazyHeaderWriter{ctx: humaCtx, filename: imageArchiveFilename(input.Body.Images)}
+			if err := h.imageService.SaveImages(humaCtx.Context(), input.Body.Images, writer, *user); err != nil && !writer.started {
+				humaCtx.SetHeader("Content-Type", "application/json")
+				humaCtx.SetStatus(http.StatusInternalServerError)
+				_, _ = fmt.Fprintf(humaCtx.BodyWriter(), `{"error":%q}`+"\n", err.Error())
+			}
+		},
+	}, nil
+}
+
+// lazyHeaderWriter sets the archive download headers on the first write, so errors that
+// happen before any archive data is written can still be reported with a status code.
+type lazyHeaderWriter struct {
+	ctx      huma.Context
+	filename string
+	started  bool
+}
+
+func (w *lazyHeaderWriter) Write(p []byte) (int, error) {
+	if !w.started {
+		w.started = true
+		w.ctx.SetHeader("Content-Type", "application/x-tar")
+		w.ctx.SetHeader("Content-Disposition", fmt.Sprintf("attachment; filename=%q", w.filename))
+	}
+	return w.ctx.BodyWriter().Write(p)
+}
+
+// imageArchiveFilename names the archive after the image when a single one is exported.
+func imageArchiveFilename(images []string) string {
+	if len(images) != 1 {
+		return "images.tar"
+	}
+	name := strings.NewReplacer("/", "_", ":", "_", "@", "_").Replace(images[0])
+	return name + ".tar"
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"bytes"
 	"context"
+	"errors"
 	"net"
 	"net/http"
 	"sort"
@@ -118,6 +119,40 @@ type PruneNetworksOutput struct {
 	Body NetworkPruneResponse
 }
 
+type ConnectNetworkInput struct {
+	EnvironmentID string `path:"id" doc:"Environment ID"`
+	NetworkID     string `path:"networkId" doc:"Network ID"`
+	Body          networktypes.ConnectRequest
+}
+
+type ConnectNetworkOutput struct {
+	Body NetworkMessageApiResponse
+}
+
+type DisconnectNetworkInput struct {
+	EnvironmentID string `path:"id" doc:"Environment ID"`
+	NetworkID     string `path:"networkId" doc:"Network ID"`
+	Body          networktypes.DisconnectRequest
+}
+
+type DisconnectNetworkOutput struct {
+	Body NetworkMessageApiResponse
+}
+
+type GetNetworkTopologyInput struct {
+	EnvironmentID string `path:"id" doc:"Environment ID"`
+}
+
+// NetworkTopologyApiResponse is a dedicated response type
+type NetworkTopologyApiResponse struct {
+	Success bool                  `json:"success"`
+	Data    networktypes.Topology `json:"data"`
+}
+
+type GetNetworkTopologyOutput struct {
+	Body NetworkTopologyApiResponse
+}
+
 // RegisterNetworks registers network endpoints.
 func RegisterNetworks(api huma.API, networkSvc *services.NetworkService, dockerSvc *services.DockerClientService) {
 	h := &NetworkHandler{
@@ -178,6 +213,35 @@ func RegisterNetworks(api huma.API, networkSvc *services.NetworkService, dockerS
 		Tags:        []string{"Networks"},
 		Security:    []map[string][]string{{"BearerAuth": {}}, {"ApiKeyAuth": {}}},
 	}, h.PruneNetworks)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "network-topology",
+		Method:      http.MethodGet,
+		Path:        "/environments/{id}/networks/topology",
+		Summary:     "Network topology",
+		Description: "Get a graph of networks, the containers attached to them and the projects the containers belong to",
+		Tags:        []string{"Networks"},
+		Security:    []map[string][]string{{"BearerAuth": {}}, {"ApiKeyAuth": {}}},
+	}, h.GetNetworkTopology)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "connect-network",
+		Method:      http.MethodPost,
+		Path:        "/environments/{id}/networks/{networkId}/connect",
+		Summary:     "Connect container to network",
+		Description: "Attach a container to a network, optionally with aliases and static IP addresses",
+		Tags:        []string{"Networks"},
+		Security:    []map[string][]string{{"BearerAuth": {}}, {"ApiKeyAuth": {}}},
+	}, h.ConnectNetwork)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "disconnect-network",
+		Method:      http.MethodPost,
+		Path:        "/environments/{id}/networks/{networkId}/disconnect",
+		Summary:     "Disconnect container from network",
+		Tags:        []string{"Networks"},
+		Security:    []map[string][]string{{"BearerAuth": {}}, {"ApiKeyAuth": {}}},
+	}, h.DisconnectNetwork)
 }
 
 func (h *NetworkHandler) ListNetworks(ctx context.Context, input *ListNetworksInput) (*ListNetworksOutput, error) {
@@ -383,3 +447,56 @@ func (h *NetworkHandler) PruneNetworks(ctx context.Context, input *PruneNetworks
 		},
 	}, nil
 }
+
+func (h *NetworkHandler) GetNetworkTopology(ctx context.Context, input *GetNetworkTopologyInput) (*GetNetworkTopologyOutput, error) {
+	topology, err := h.networkService.GetTopology(ctx)
+	if err != nil {
+		return nil, huma.Error500InternalServerError((&common.NetworkTopologyError{Err: err}).Error())
+	}
+
+	return &GetNetworkTopologyOutput{
+		Body: NetworkTopologyApiResponse{
+			Success: true,
+			Data:    topology,
+		},
+	}, nil
+}
+
+func (h *NetworkHandler) ConnectNetwork(ctx context.Context, input *ConnectNetworkInput) (*ConnectNetworkOutput, error) {
+	user, exists := humamw.GetCurrentUserFromContext(ctx)
+	if !exists {
+		return nil, huma.Error401Unauthorized("not authenticated")
+	}
+
+	if err := h.networkService.ConnectContainer(ctx, input.NetworkID, input.Body, *user); err != nil {
+		if errors.Is(err, services.ErrInvalidEndpointAddress) {
+			return nil, huma.Error400BadRequest((&common.NetworkConnectError{Err: err}).Error())
+		}
+		return nil, huma.Error500InternalServerError((&common.NetworkConnectError{Err: err}).Error())
+	}
+
+	return &ConnectNetworkOutput{
+		Body: NetworkMessageApiResponse{
+			Success: true,
+			Data:    base.MessageResponse{Message: "Container connected to network successfully"},
+		},
+	}, nil
+}
+
+func (h *NetworkHandler) DisconnectNetwork(ctx context.Context, input *DisconnectNetworkInput) (*DisconnectNetworkOutput, error) {
+	user, exists := humamw.GetCurrentUserFromContext(ctx)
+	if !exists {
+		return nil, huma.Error401Unauthorized("not authenticated")
+	}
+
+	if err := h.networkService.DisconnectContainer(ctx, input.NetworkID, input.Body, *user); err != nil {
+		return nil, huma.Error500InternalServerError((&common.NetworkDisconnectError{Err: err}).Error())
+	}
+
+	return &DisconnectNetworkOutput{
+		Body: NetworkMessageApiResponse{
+			Success: true,
+			Data:    base.MessageResponse{Message: "Container disconnected from network successfully"},
+		},
+	}, nil
+}
@@ -0,0 +1,253 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/getarcaneapp/arcane/backend/internal/common"
+	humamw "github.com/getarcaneapp/arcane/backend/internal/huma/middleware"
+	"github.com/getarcaneapp/arcane/backend/internal/services"
+	"github.com/getarcaneapp/arcane/types/base"
+	"github.com/getarcaneapp/arcane/types/project"
+)
+
+// ProjectBackupHandler provides Huma-based endpoints for backing up and restoring all
+// volumes of a project as one set.
+type ProjectBackupHandler struct {
+	projectService *services.ProjectService
+}
+
+// --- Huma Input/Output Wrappers ---
+
+type ListProjectBackupsInput struct {
+	EnvironmentID string `path:"id" doc:"Environment ID"`
+	ProjectID     string `path:"projectId" doc:"Project ID"`
+}
+
+type ListProjectBackupsOutput struct {
+	Body base.ApiResponse[[]project.Backup]
+}
+
+type CreateProjectBackupInput struct {
+	EnvironmentID string               `path:"id" doc:"Environment ID"`
+	ProjectID     string               `path:"projectId" doc:"Project ID"`
+	Passphrase    string               `header:"X-Backup-Passphrase" doc:"Passphrase to encrypt the archives with"`
+	Body          project.CreateBackup `required:"false"`
+}
+
+type CreateProjectBackupOutput struct {
+	Body base.ApiResponse[project.Backup]
+}
+
+type GetProjectBackupInput struct {
+	EnvironmentID string `path:"id" doc:"Environment ID"`
+	ProjectID     string `path:"projectId" doc:"Project ID"`
+	BackupID      string `path:"backupId" doc:"Project backup ID"`
+}
+
+type GetProjectBackupOutput struct {
+	Body base.ApiResponse[project.Backup]
+}
+
+type DeleteProjectBackupInput struct {
+	EnvironmentID string `path:"id" doc:"Environment ID"`
+	ProjectID     string `path:"projectId" doc:"Project ID"`
+	BackupID      string `path:"backupId" doc:"Project backup ID"`
+}
+
+type DeleteProjectBackupOutput struct {
+	Body base.ApiResponse[base.MessageResponse]
+}
+
+type RestoreProjectBackupInput struct {
+	EnvironmentID string                `path:"id" doc:"Environment ID"`
+	ProjectID     string                `path:"projectId" doc:"Project ID"`
+	BackupID      string                `path:"backupId" doc:"Project backup ID"`
+	Passphrase    string                `header:"X-Backup-Passphrase" doc:"Passphrase of passphrase-encrypted archives"`
+	Body          project.RestoreBackup `required:"false"`
+}
+
+type RestoreProjectBackupOutput struct {
+	Body base.ApiResponse[base.MessageResponse]
+}
+
+// RegisterProjectBackups registers the project backup endpoints.
+func RegisterProjectBackups(api huma.API, projectService *services.ProjectService) {
+	h := &ProjectBackupHandler{projectService: projectService}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-project-backups",
+		Method:      http.MethodGet,
+		Path:        "/environments/{id}/projects/{projectId}/backups",
+		Summary:     "List project backups",
+		Description: "List the backups of all volumes of a project, newest first",
+		Tags:        []string{"Projects"},
+		Security: []map[string][]string{
+			{"BearerAuth": {}},
+			{"ApiKeyAuth": {}},
+		},
+	}, h.ListProjectBackups)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "create-project-backup",
+		Method:      http.MethodPost,
+		Path:        "/environments/{id}/projects/{projectId}/backups",
+		Summary:     "Back up a project",
+		Description: "Back up all named volumes of a project as one set, optionally stopping the project for a consistent backup",
+		Tags:        []string{"Projects"},
+		Security: []map[string][]string{
+			{"BearerAuth": {}},
+			{"ApiKeyAuth": {}},
+		},
+	}, h.CreateProjectBackup)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-project-backup",
+		Method:      http.MethodGet,
+		Path:        "/environments/{id}/projects/{projectId}/backups/{backupId}",
+		Summary:     "Get a project backup",
+		Tags:        []string{"Projects"},
+		Security: []map[string][]string{
+			{"BearerAuth": {}},
+			{"ApiKeyAuth": {}},
+		},
+	}, h.GetProjectBackup)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "delete-project-backup",
+		Method:      http.MethodDelete,
+		Path:        "/environments/{id}/projects/{projectId}/backups/{backupId}",
+		Summary:     "Delete a project backup",
+		Description: "Delete a project backup and the volume backups of its set",
+		Tags:        []string{"Projects"},
+		Security: []map[string][]string{
+			{"BearerAuth": {}},
+			{"ApiKeyAuth": {}},
+		},
+	}, h.DeleteProjectBackup)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "restore-project-backup",
+		Method:      http.MethodPost,
+		Path:        "/environments/{id}/projects/{projectId}/backups/{backupId}/restore",
+		Summary:     "Restore a project backup",
+		Description: "Bring the project down, restore all volumes of the backup and deploy the project again if it was running",
+		Tags:        []string{"Projects"},
+		Security: []map[string][]string{
+			{"BearerAuth": {}},
+			{"ApiKeyAuth": {}},
+		},
+	}, h.RestoreProjectBackup)
+}
+
+// ListProjectBackups lists the backups of a project.
+func (h *ProjectBackupHandler) ListProjectBackups(ctx context.Context, input *ListProjectBackupsInput) (*ListProjectBackupsOutput, error) {
+	if h.projectService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	backups, err := h.projectService.ListProjectBackups(ctx, input.ProjectID)
+	if err != nil {
+		return nil, huma.Error500InternalServerError(err.Error())
+	}
+
+	data := make([]project.Backup, 0, len(backups))
+	for i := range backups {
+		data = append(data, backups[i].ToDTO())
+	}
+	return &ListProjectBackupsOutput{
+		Body: base.ApiResponse[[]project.Backup]{Success: true, Data: data},
+	}, nil
+}
+
+// CreateProjectBackup backs up all volumes of a project.
+func (h *ProjectBackupHandler) CreateProjectBackup(ctx context.Context, input *CreateProjectBackupInput) (*CreateProjectBackupOutput, error) {
+	if h.projectService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	user, exists := humamw.GetCurrentUserFromContext(ctx)
+	if !exists {
+		return nil, huma.Error401Unauthorized((&common.NotAuthenticatedError{}).Error())
+	}
+
+	backup, err := h.projectService.BackupProject(ctx, input.ProjectID, input.Body, input.Passphrase, *user)
+	if err != nil {
+		return nil, huma.Error500InternalServerError(err.Error())
+	}
+
+	return &CreateProjectBackupOutput{
+		Body: base.ApiResponse[project.Backup]{Success: true, Data: backup.ToDTO()},
+	}, nil
+}
+
+// GetProjectBackup returns a single project backup.
+func (h *ProjectBackupHandler) GetProjectBackup(ctx context.Context, input *GetProjectBackupInput) (*GetProjectBackupOutput, error) {
+	if h.projectService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	backup, err := h.projectService.GetProjectBackup(ctx, input.ProjectID, input.BackupID)
+	if err != nil {
+		return nil, projectBackupErrorInternal(err)
+	}
+
+	return &GetProjectBackupOutput{
+		Body: base.ApiResponse[project.Backup]{Success: true, Data: backup.ToDTO()},
+	}, nil
+}
+
+// DeleteProjectBackup deletes a project backup with its volume backups.
+func (h *ProjectBackupHandler) DeleteProjectBackup(ctx context.Context, input *DeleteProjectBackupInput) (*DeleteProjectBackupOutput, error) {
+	if h.projectService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	user, exists := humamw.GetCurrentUserFromContext(ctx)
+	if !exists {
+		return nil, huma.Error401Unauthorized((&common.NotAuthenticatedError{}).Error())
+	}
+
+	if err := h.projectService.DeleteProjectBackup(ctx, input.ProjectID, input.BackupID, *user); err != nil {
+		return nil, projectBackupErrorInternal(err)
+	}
+
+	return &DeleteProjectBackupOutput{
+		Body: base.ApiResponse[base.MessageResponse]{
+			Success: true,
+			Data:    base.MessageResponse{Message: "Project backup deleted successfully"},
+		},
+	}, nil
+}
+
+// RestoreProjectBackup restores all volumes of a project backup.
+func (h *ProjectBackupHandler) RestoreProjectBackup(ctx context.Context, input *RestoreProjectBackupInput) (*RestoreProjectBackupOutput, error) {
+	if h.projectService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	user, exists := humamw.GetCurrentUserFromContext(ctx)
+	if !exists {
+		return nil, huma.Error401Unauthorized((&common.NotAuthenticatedError{}).Error())
+	}
+
+	if err := h.projectService.RestoreProjectBackup(ctx, input.ProjectID, input.BackupID, input.Body, input.Passphrase, *user); err != nil {
+		return nil, projectBackupErrorInternal(err)
+	}
+
+	return &RestoreProjectBackupOutput{
+		Body: base.ApiResponse[base.MessageResponse]{
+			Success: true,
+			Data:    base.MessageResponse{Message: "Project backup restored successfully"},
+		},
+	}, nil
+}
+
+func projectBackupErrorInternal(err error) error {
+	if errors.Is(err, services.ErrProjectBackupNotFound) {
+		return huma.Error404NotFound(err.Error())
+	}
+	return backupArchiveErrorInternal(err)
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/getarcaneapp/arcane/backend/internal/common"
+	"github.com/getarcaneapp/arcane/backend/internal/services"
+	"github.com/getarcaneapp/arcane/types/base"
+	"github.com/getarcaneapp/arcane/types/project"
+)
+
+// ProjectLogsHandler provides the Huma-based log search and export endpoints for projects.
+// Live logs are streamed over the project logs WebSocket.
+type ProjectLogsHandler struct {
+	projectService *services.ProjectService
+}
+
+// ProjectLogQueryInput holds the log filters shared by the search and export endpoints.
+type ProjectLogQueryInput struct {
+	EnvironmentID string   `path:"id" doc:"Environment ID"`
+	ProjectID     string   `path:"projectId" doc:"Project ID"`
+	Since         string   `query:"since" doc:"Only lines at or after this time: RFC 3339, Unix timestamp or a duration before now such as 2h"`
+	Until         string   `query:"until" doc:"Only lines before this time, in the same formats as since"`
+	Regex         string   `query:"regex" doc:"Regular expression (RE2 syntax) the line must match"`
+	IgnoreCase    bool     `query:"ignoreCase" default:"false" doc:"Match the regular expression case-insensitively"`
+	Stream        []string `query:"stream" doc:"Comma-separated streams to include: stdout, stderr"`
+	Service       []string `query:"service" doc:"Comma-separated compose services to include"`
+	Level         []string `query:"level" doc:"Comma-separated levels to include: trace, debug, info, warn, error, fatal"`
+}
+
+func (in *ProjectLogQueryInput) queryInternal() services.ProjectLogQuery {
+	return services.ProjectLogQuery{
+		Since:      in.Since,
+		Until:      in.Until,
+		Pattern:    in.Regex,
+		IgnoreCase: in.IgnoreCase,
+		Streams:    in.Stream,
+		Services:   in.Service,
+		Levels:     in.Level,
+	}
+}
+
+type SearchProjectLogsInput struct {
+	ProjectLogQueryInput
+	Limit int `query:"limit" default:"1000" minimum:"1" maximum:"10000" doc:"Maximum number of lines to return; the most recent matches are kept"`
+}
+
+type SearchProjectLogsOutput struct {
+	Body base.ApiResponse[project.LogSearchResult]
+}
+
+type ExportProjectLogsInput struct {
+	ProjectLogQueryInput
+	Format string `query:"format" default:"zip" enum:"zip,ndjson" doc:"zip for a log file per container, ndjson for one JSON log entry per line"`
+}
+
+// RegisterProjectLogs registers the project log search and export endpoints.
+func RegisterProjectLogs(api huma.API, projectService *services.ProjectService) {
+	h := &ProjectLogsHandler{projectService: projectService}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "search-project-logs",
+		Method:      http.MethodGet,
+		Path:        "/environments/{id}/projects/{projectId}/logs/search",
+		Summary:     "Search project logs",
+		Description: "Search the logs of all containers of a project by time range, regular expression, stream, service and level",
+		Tags:        []string{"Projects"},
+		Security: []map[string][]string{
+			{"BearerAuth": {}},
+			{"ApiKeyAuth": {}},
+		},
+	}, h.SearchProjectLogs)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "export-project-logs",
+		Method:      http.MethodGet,
+		Path:        "/environments/{id}/projects/{projectId}/logs/export",
+		Summary:     "Export project logs",
+		Description: "Download the logs of all containers of a project as a zip archive or newline-delimited JSON, with the same filters as the search",
+		Tags:        []string{"Projects"},
+		Security: []map[string][]string{
+			{"BearerAuth": {}},
+			{"ApiKeyAuth": {}},
+		},
+	}, h.ExportProjectLogs)
+}
+
+// projectLogsErrorInternal maps a project log error to an HTTP error.
+func projectLogsErrorInternal(err error, wrap func(error) error) error {
+	if errors.Is(err, services.ErrInvalidLogQuery) {
+		return huma.Error400BadRequest(wrap(err).Error())
+	}
+	return huma.Error500InternalServerError(wrap(err).Error())
+}
+
+// SearchProjectLogs returns the matching log lines of a project's containers.
+func (h *ProjectLogsHandler) SearchProjectLogs(ctx context.Context, input *SearchProjectLogsInput) (*SearchProjectLogsOutput, error) {
+	if h.projectService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	q := input.queryInternal()
+	q.Limit = input.Limit
+	result, err := h.projectService.SearchProjectLogs(ctx, input.ProjectID, q)
+	if err != nil {
+		return nil, projectLogsErrorInternal(err, func(err error) error { return &common.ProjectLogSearchError{Err: err} })
+	}
+
+	return &SearchProjectLogsOutput{
+		Body: base.ApiResponse[project.LogSearchResult]{Success: true, Data: result},
+	}, nil
+}
+
+// ExportProjectLogs streams the matching log lines of a project's containers.
+func (h *ProjectLogsHandler) ExportProjectLogs(ctx context.Context, input *ExportProjectLogsInput) (*huma.StreamResponse, error) {
+	if h.projectService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	export, err := h.projectService.OpenProjectLogExport(ctx, input.ProjectID, input.queryInternal())
+	if err != nil {
+		return nil, projectLogsErrorInternal(err, func(err error) error { return &common.ProjectLogExportError{Err: err} })
+	}
+
+	contentType, write := "application/zip", export.WriteZip
+	if input.Format == "ndjson" {
+		contentType, write = "application/x-ndjson", export.WriteNDJSON
+	}
+	filename := fmt.Sprintf("%s-logs-%s.%s", export.ProjectName, time.Now().UTC().Format("20060102-150405"), input.Format)
+
+	return &huma.StreamResponse{
+		Body: func(humaCtx huma.Context) {
+			humaCtx.SetHeader("Content-Type", contentType)
+			humaCtx.SetHeader("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+			if err := write(humaCtx.Context(), humaCtx.BodyWriter()); err != nil {
+				// Headers are sent, so the error can only be logged.
+				slog.WarnContext(ctx, "Project log export failed", "projectID", input.ProjectID, "error", err)
+			}
+		},
+	}, nil
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/getarcaneapp/arcane/backend/internal/services"
+	"github.com/getarcaneapp/arcane/types/base"
+	"github.com/getarcaneapp/arcane/types/project"
+)
+
+// ProjectPlanHandler provides the Huma-based pre-deploy validation endpoint for projects.
+type ProjectPlanHandler struct {
+	projectService *services.ProjectService
+}
+
+type PlanProjectInput struct {
+	EnvironmentID string `path:"id" doc:"Environment ID"`
+	ProjectID     string `path:"projectId" doc:"Project ID"`
+}
+
+type PlanProjectOutput struct {
+	Body base.ApiResponse[project.Plan]
+}
+
+// RegisterProjectPlan registers the project plan endpoint.
+func RegisterProjectPlan(api huma.API, projectService *services.ProjectService) {
+	h := &ProjectPlanHandler{projectService: projectService}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "plan-project",
+		Method:      http.MethodGet,
+		Path:        "/environments/{id}/projects/{projectId}/plan",
+		Summary:     "Plan a project deployment",
+		Description: "Validate a project and show which services a deployment would create, recreate or leave unchanged, without changing anything",
+		Tags:        []string{"Projects"},
+		Security: []map[string][]string{
+			{"BearerAuth": {}},
+			{"ApiKeyAuth": {}},
+		},
+	}, h.PlanProject)
+}
+
+// PlanProject validates a project and returns its deployment plan.
+func (h *ProjectPlanHandler) PlanProject(ctx context.Context, input *PlanProjectInput) (*PlanProjectOutput, error) {
+	if h.projectService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	plan, err := h.projectService.PlanProject(ctx, input.ProjectID)
+	if err != nil {
+		return nil, huma.Error500InternalServerError(err.Error())
+	}
+
+	return &PlanProjectOutput{
+		Body: base.ApiResponse[project.Plan]{Success: true, Data: plan},
+	}, nil
+}
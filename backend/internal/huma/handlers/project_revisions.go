@@ -0,0 +1,237 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/getarcaneapp/arcane/backend/internal/common"
+	humamw "github.com/getarcaneapp/arcane/backend/internal/huma/middleware"
+	"github.com/getarcaneapp/arcane/backend/internal/services"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/pagination"
+	"github.com/getarcaneapp/arcane/types/base"
+	"github.com/getarcaneapp/arcane/types/project"
+)
+
+// ProjectRevisionHandler provides Huma-based endpoints for the revision history of a
+// project's compose, env and include files.
+type ProjectRevisionHandler struct {
+	projectService *services.ProjectService
+}
+
+// --- Huma Input/Output Wrappers ---
+
+// ProjectRevisionPaginatedResponse is the paginated response for project revisions.
+type ProjectRevisionPaginatedResponse struct {
+	Success    bool                      `json:"success"`
+	Data       []project.RevisionSummary `json:"data"`
+	Pagination base.PaginationResponse   `json:"pagination"`
+}
+
+type ListProjectRevisionsInput struct {
+	EnvironmentID string `path:"id" doc:"Environment ID"`
+	ProjectID     string `path:"projectId" doc:"Project ID"`
+	Sort          string `query:"sort" doc:"Column to sort by (default: newest revision first)"`
+	Order         string `query:"order" default:"desc" doc:"Sort direction (asc or desc)"`
+	Start         int    `query:"start" default:"0" doc:"Start index for pagination"`
+	Limit         int    `query:"limit" default:"20" doc:"Number of items per page"`
+}
+
+type ListProjectRevisionsOutput struct {
+	Body ProjectRevisionPaginatedResponse
+}
+
+type GetProjectRevisionInput struct {
+	EnvironmentID string `path:"id" doc:"Environment ID"`
+	ProjectID     string `path:"projectId" doc:"Project ID"`
+	Revision      int    `path:"revision" doc:"Revision number"`
+}
+
+type GetProjectRevisionOutput struct {
+	Body base.ApiResponse[project.Revision]
+}
+
+type DiffProjectRevisionsInput struct {
+	EnvironmentID string `path:"id" doc:"Environment ID"`
+	ProjectID     string `path:"projectId" doc:"Project ID"`
+	From          int    `query:"from" required:"true" doc:"Revision number to diff from"`
+	To            int    `query:"to" required:"true" doc:"Revision number to diff to"`
+}
+
+type DiffProjectRevisionsOutput struct {
+	Body base.ApiResponse[project.RevisionDiff]
+}
+
+type RedeployProjectRevisionInput struct {
+	EnvironmentID string `path:"id" doc:"Environment ID"`
+	ProjectID     string `path:"projectId" doc:"Project ID"`
+	Revision      int    `path:"revision" doc:"Revision number to redeploy"`
+}
+
+type RedeployProjectRevisionOutput struct {
+	Body base.ApiResponse[project.RevisionSummary]
+}
+
+// RegisterProjectRevisions registers the project revision endpoints.
+func RegisterProjectRevisions(api huma.API, projectService *services.ProjectService) {
+	h := &ProjectRevisionHandler{projectService: projectService}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-project-revisions",
+		Method:      http.MethodGet,
+		Path:        "/environments/{id}/projects/{projectId}/revisions",
+		Summary:     "List project revisions",
+		Description: "List the stored revisions of a project's compose, env and include files",
+		Tags:        []string{"Projects"},
+		Security: []map[string][]string{
+			{"BearerAuth": {}},
+			{"ApiKeyAuth": {}},
+		},
+	}, h.ListProjectRevisions)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "diff-project-revisions",
+		Method:      http.MethodGet,
+		Path:        "/environments/{id}/projects/{projectId}/revisions/diff",
+		Summary:     "Diff two project revisions",
+		Description: "Get the unified diff of all files between two revisions of a project",
+		Tags:        []string{"Projects"},
+		Security: []map[string][]string{
+			{"BearerAuth": {}},
+			{"ApiKeyAuth": {}},
+		},
+	}, h.DiffProjectRevisions)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-project-revision",
+		Method:      http.MethodGet,
+		Path:        "/environments/{id}/projects/{projectId}/revisions/{revision}",
+		Summary:     "Get a project revision",
+		Description: "Get a stored revision of a project with its file content and diff to the previous revision",
+		Tags:        []string{"Projects"},
+		Security: []map[string][]string{
+			{"BearerAuth": {}},
+			{"ApiKeyAuth": {}},
+		},
+	}, h.GetProjectRevision)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "redeploy-project-revision",
+		Method:      http.MethodPost,
+		Path:        "/environments/{id}/projects/{projectId}/revisions/{revision}/redeploy",
+		Summary:     "Redeploy a project revision",
+		Description: "Restore the files of an earlier revision, store them as a new revision and redeploy the project",
+		Tags:        []string{"Projects"},
+		Security: []map[string][]string{
+			{"BearerAuth": {}},
+			{"ApiKeyAuth": {}},
+		},
+	}, h.RedeployProjectRevision)
+}
+
+// ListProjectRevisions lists the revisions of a project.
+func (h *ProjectRevisionHandler) ListProjectRevisions(ctx context.Context, input *ListProjectRevisionsInput) (*ListProjectRevisionsOutput, error) {
+	if h.projectService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	params := pagination.QueryParams{
+		SortParams: pagination.SortParams{
+			Sort:  input.Sort,
+			Order: pagination.SortOrder(input.Order),
+		},
+		PaginationParams: pagination.PaginationParams{
+			Start: input.Start,
+			Limit: input.Limit,
+		},
+	}
+
+	revisions, paginationResp, err := h.projectService.ListProjectRevisions(ctx, input.ProjectID, params)
+	if err != nil {
+		return nil, huma.Error500InternalServerError(err.Error())
+	}
+
+	data := make([]project.RevisionSummary, 0, len(revisions))
+	for i := range revisions {
+		data = append(data, revisions[i].ToSummaryDTO())
+	}
+
+	return &ListProjectRevisionsOutput{
+		Body: ProjectRevisionPaginatedResponse{
+			Success: true,
+			Data:    data,
+			Pagination: base.PaginationResponse{
+				TotalPages:      paginationResp.TotalPages,
+				TotalItems:      paginationResp.TotalItems,
+				CurrentPage:     paginationResp.CurrentPage,
+				ItemsPerPage:    paginationResp.ItemsPerPage,
+				GrandTotalItems: paginationResp.GrandTotalItems,
+			},
+		},
+	}, nil
+}
+
+// GetProjectRevision returns a single revision with its content.
+func (h *ProjectRevisionHandler) GetProjectRevision(ctx context.Context, input *GetProjectRevisionInput) (*GetProjectRevisionOutput, error) {
+	if h.projectService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	rev, err := h.projectService.GetProjectRevision(ctx, input.ProjectID, input.Revision)
+	if err != nil {
+		return nil, projectRevisionErrorInternal(err)
+	}
+
+	return &GetProjectRevisionOutput{
+		Body: base.ApiResponse[project.Revision]{Success: true, Data: rev.ToDTO()},
+	}, nil
+}
+
+// DiffProjectRevisions returns the diff between two revisions.
+func (h *ProjectRevisionHandler) DiffProjectRevisions(ctx context.Context, input *DiffProjectRevisionsInput) (*DiffProjectRevisionsOutput, error) {
+	if h.projectService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	diff, err := h.projectService.DiffProjectRevisions(ctx, input.ProjectID, input.From, input.To)
+	if err != nil {
+		return nil, projectRevisionErrorInternal(err)
+	}
+
+	return &DiffProjectRevisionsOutput{
+		Body: base.ApiResponse[project.RevisionDiff]{Success: true, Data: diff},
+	}, nil
+}
+
+// RedeployProjectRevision rolls a project back to an earlier revision and redeploys it.
+func (h *ProjectRevisionHandler) RedeployProjectRevision(ctx context.Context, input *RedeployProjectRevisionInput) (*RedeployProjectRevisionOutput, error) {
+	if h.projectService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	user, exists := humamw.GetCurrentUserFromContext(ctx)
+	if !exists {
+		return nil, huma.Error401Unauthorized((&common.NotAuthenticatedError{}).Error())
+	}
+
+	rev, err := h.projectService.RedeployProjectRevision(ctx, input.ProjectID, input.Revision, *user)
+	if err != nil {
+		return nil, projectRevisionErrorInternal(err)
+	}
+
+	var data project.RevisionSummary
+	if rev != nil {
+		data = rev.ToSummaryDTO()
+	}
+	return &RedeployProjectRevisionOutput{
+		Body: base.ApiResponse[project.RevisionSummary]{Success: true, Data: data},
+	}, nil
+}
+
+func projectRevisionErrorInternal(err error) error {
+	if errors.Is(err, services.ErrProjectRevisionNotFound) {
+		return huma.Error404NotFound(err.Error())
+	}
+	return huma.Error500InternalServerError(err.Error())
+}
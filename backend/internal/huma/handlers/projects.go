@@ -145,6 +145,12 @@ type PullProjectImagesInput struct {
 	ProjectID     string `path:"projectId" doc:"Project ID"`
 }
 
+type BuildProjectImagesInput struct {
+	EnvironmentID string              `path:"id" doc:"Environment ID"`
+	ProjectID     string              `path:"projectId" doc:"Project ID"`
+	Body          project.BuildImages `required:"false"`
+}
+
 // PullProgressEvent represents a Docker pull progress event
 type PullProgressEvent struct {
 	Status         string `json:"status,omitempty"`
@@ -319,6 +325,19 @@ func RegisterProjects(api huma.API, projectService *services.ProjectService) {
 			{"ApiKeyAuth": {}},
 		},
 	}, h.PullProjectImages)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "build-project-images",
+		Method:      http.MethodPost,
+		Path:        "/environments/{id}/projects/{projectId}/build",
+		Summary:     "Build project images",
+		Description: "Build the images of services with a build section using the project directory as context, with streaming build output",
+		Tags:        []string{"Projects"},
+		Security: []map[string][]string{
+			{"BearerAuth": {}},
+			{"ApiKeyAuth": {}},
+		},
+	}, h.BuildProjectImages)
 }
 
 // ListProjects returns a paginated list of projects.
@@ -601,7 +620,12 @@ func (h *ProjectHandler) UpdateProject(ctx context.Context, input *UpdateProject
 		return nil, huma.Error400BadRequest((&common.ProjectIDRequiredError{}).Error())
 	}
 
-	if _, err := h.projectService.UpdateProject(ctx, input.ProjectID, input.Body.Name, input.Body.ComposeContent, input.Body.EnvContent); err != nil {
+	user, exists := humamw.GetCurrentUserFromContext(ctx)
+	if !exists {
+		return nil, huma.Error401Unauthorized((&common.NotAuthenticatedError{}).Error())
+	}
+
+	if _, err := h.projectService.UpdateProject(ctx, input.ProjectID, input.Body.Name, input.Body.ComposeContent, input.Body.EnvContent, *user); err != nil {
 		return nil, huma.Error400BadRequest((&common.ProjectUpdateError{Err: err}).Error())
 	}
 
@@ -628,7 +652,12 @@ func (h *ProjectHandler) UpdateProjectInclude(ctx context.Context, input *Update
 		return nil, huma.Error400BadRequest((&common.ProjectIDRequiredError{}).Error())
 	}
 
-	if err := h.projectService.UpdateProjectIncludeFile(ctx, input.ProjectID, input.Body.RelativePath, input.Body.Content); err != nil {
+	user, exists := humamw.GetCurrentUserFromContext(ctx)
+	if !exists {
+		return nil, huma.Error401Unauthorized((&common.NotAuthenticatedError{}).Error())
+	}
+
+	if err := h.projectService.UpdateProjectIncludeFile(ctx, input.ProjectID, input.Body.RelativePath, input.Body.Content, *user); err != nil {
 		return nil, huma.Error400BadRequest((&common.ProjectUpdateError{Err: err}).Error())
 	}
 
@@ -713,3 +742,43 @@ func (h *ProjectHandler) PullProjectImages(ctx context.Context, input *PullProje
 		},
 	}, nil
 }
+
+// BuildProjectImages builds the images of a project's services with streaming build output.
+func (h *ProjectHandler) BuildProjectImages(ctx context.Context, input *BuildProjectImagesInput) (*huma.StreamResponse, error) {
+	if h.projectService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	if input.ProjectID == "" {
+		return nil, huma.Error400BadRequest((&common.ProjectIDRequiredError{}).Error())
+	}
+
+	user, exists := humamw.GetCurrentUserFromContext(ctx)
+	if !exists {
+		return nil, huma.Error401Unauthorized((&common.NotAuthenticatedError{}).Error())
+	}
+
+	return &huma.StreamResponse{
+		Body: func(humaCtx huma.Context) { //nolint:contextcheck // context is obtained from humaCtx.Context()
+			humaCtx.SetHeader("Content-Type", "application/x-json-stream")
+			humaCtx.SetHeader("Cache-Control", "no-cache")
+			humaCtx.SetHeader("Connection", "keep-alive")
+			humaCtx.SetHeader("X-Accel-Buffering", "no")
+
+			writer := humaCtx.BodyWriter()
+
+			if _, err := h.projectService.BuildProjectImages(humaCtx.Context(), input.ProjectID, input.Body, writer, *user); err != nil {
+				_, _ = fmt.Fprintf(writer, `{"error":%q}`+"\n", err.Error())
+				if f, ok := writer.(http.Flusher); ok {
+					f.Flush()
+				}
+				return
+			}
+
+			_, _ = writer.Write([]byte(`{"status":"complete"}` + "\n"))
+			if f, ok := writer.(http.Flusher); ok {
+				f.Flush()
+			}
+		},
+	}, nil
+}
@@ -280,24 +280,8 @@ func (h *SystemHandler) GetDockerInfo(ctx context.Context, input *GetDockerInfoI
 		return nil, huma.Error500InternalServerError((&common.DockerInfoError{Err: err}).Error())
 	}
 
-	cpuCount := info.NCPU
-	memTotal := info.MemTotal
-
 	// Check for cgroup limits (LXC, Docker, etc.)
-	if cgroupLimits, err := docker.DetectCgroupLimits(); err == nil {
-		if limit := cgroupLimits.MemoryLimit; limit > 0 {
-			limitInt := int64(limit)
-			if memTotal == 0 || limitInt < memTotal {
-				memTotal = limitInt
-			}
-		}
-		if cgroupLimits.CPUCount > 0 && (cpuCount == 0 || cgroupLimits.CPUCount < cpuCount) {
-			cpuCount = cgroupLimits.CPUCount
-		}
-	}
-
-	info.NCPU = cpuCount
-	info.MemTotal = memTotal
+	info.NCPU, info.MemTotal = docker.EffectiveHostCapacity(info.NCPU, info.MemTotal)
 
 	return &GetDockerInfoOutput{
 		Body: dockerinfo.Info{
@@ -9,6 +9,7 @@ import (
 	"github.com/getarcaneapp/arcane/backend/internal/models"
 	"github.com/getarcaneapp/arcane/backend/internal/services"
 	"github.com/getarcaneapp/arcane/backend/internal/utils/mapper"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/rbac"
 	"github.com/getarcaneapp/arcane/types/base"
 	"github.com/getarcaneapp/arcane/types/user"
 )
@@ -212,8 +213,18 @@ func (h *UserHandler) CreateUser(ctx context.Context, input *CreateUserInput) (*
 	}
 
 	if userModel.Roles == nil {
-		userModel.Roles = []string{"user"}
+		userModel.Roles = []string{rbac.RoleUser}
 	}
+	if err := validateRoles(userModel.Roles); err != nil {
+		return nil, err
+	}
+	userModel.Roles = rbac.NormalizeRoles(userModel.Roles)
+
+	access, err := models.ParseEnvironmentAccess(input.Body.EnvironmentAccess)
+	if err != nil {
+		return nil, huma.Error400BadRequest((&common.InvalidEnvironmentAccessError{Err: err}).Error())
+	}
+	userModel.EnvironmentAccess = access
 
 	createdUser, err := h.userService.CreateUser(ctx, userModel)
 	if err != nil {
@@ -224,6 +235,7 @@ func (h *UserHandler) CreateUser(ctx context.Context, input *CreateUserInput) (*
 	if err != nil {
 		return nil, huma.Error500InternalServerError((&common.UserMappingError{Err: err}).Error())
 	}
+	out.EnvironmentAccess = createdUser.EnvironmentAccess.ToMap()
 
 	return &CreateUserOutput{
 		Body: base.ApiResponse[user.User]{
@@ -252,6 +264,7 @@ func (h *UserHandler) GetUser(ctx context.Context, input *GetUserInput) (*GetUse
 	if err != nil {
 		return nil, huma.Error500InternalServerError((&common.UserMappingError{Err: err}).Error())
 	}
+	out.EnvironmentAccess = userModel.EnvironmentAccess.ToMap()
 
 	return &GetUserOutput{
 		Body: base.ApiResponse[user.User]{
@@ -283,11 +296,21 @@ func (h *UserHandler) UpdateUser(ctx context.Context, input *UpdateUserInput) (*
 		userModel.Email = input.Body.Email
 	}
 	if input.Body.Roles != nil {
-		userModel.Roles = input.Body.Roles
+		if err := validateRoles(input.Body.Roles); err != nil {
+			return nil, err
+		}
+		userModel.Roles = rbac.NormalizeRoles(input.Body.Roles)
 	}
 	if input.Body.Locale != nil {
 		userModel.Locale = input.Body.Locale
 	}
+	if input.Body.EnvironmentAccess != nil {
+		access, err := models.ParseEnvironmentAccess(input.Body.EnvironmentAccess)
+		if err != nil {
+			return nil, huma.Error400BadRequest((&common.InvalidEnvironmentAccessError{Err: err}).Error())
+		}
+		userModel.EnvironmentAccess = access
+	}
 
 	if input.Body.Password != nil && *input.Body.Password != "" {
 		hashedPassword, err := h.userService.HashPassword(*input.Body.Password)
@@ -309,6 +332,7 @@ func (h *UserHandler) UpdateUser(ctx context.Context, input *UpdateUserInput) (*
 	if err != nil {
 		return nil, huma.Error500InternalServerError((&common.UserMappingError{Err: err}).Error())
 	}
+	out.EnvironmentAccess = updatedUser.EnvironmentAccess.ToMap()
 
 	return &UpdateUserOutput{
 		Body: base.ApiResponse[user.User]{
@@ -341,3 +365,13 @@ func (h *UserHandler) DeleteUser(ctx context.Context, input *DeleteUserInput) (*
 		},
 	}, nil
 }
+
+// validateRoles rejects role names that are not built-in roles.
+func validateRoles(roles []string) error {
+	for _, r := range roles {
+		if !rbac.IsKnownRole(r) {
+			return huma.Error400BadRequest((&common.InvalidRoleError{Role: r}).Error())
+		}
+	}
+	return nil
+}
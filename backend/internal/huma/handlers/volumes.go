@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"io"
 	"mime/multipart"
 	"net/http"
@@ -13,6 +14,7 @@ import (
 	humamw "github.com/getarcaneapp/arcane/backend/internal/huma/middleware"
 	"github.com/getarcaneapp/arcane/backend/internal/models"
 	"github.com/getarcaneapp/arcane/backend/internal/services"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/crypto"
 	"github.com/getarcaneapp/arcane/backend/internal/utils/pagination"
 	"github.com/getarcaneapp/arcane/types/base"
 	volumetypes "github.com/getarcaneapp/arcane/types/volume"
@@ -223,6 +225,9 @@ type ListBackupsOutput struct {
 type CreateBackupInput struct {
 	EnvironmentID string `path:"id" doc:"Environment ID"`
 	VolumeName    string `path:"volumeName" doc:"Volume name"`
+	TargetID      string `query:"targetId" doc:"Backup target to store the archive on; defaults to the local backup volume"`
+	Encrypt       bool   `query:"encrypt" doc:"Encrypt the archive with the passphrase header, or with the instance key when no passphrase is given"`
+	Passphrase    string `header:"X-Backup-Passphrase" doc:"Passphrase to encrypt the archive with"`
 }
 
 type CreateBackupOutput struct {
@@ -233,6 +238,7 @@ type RestoreBackupInput struct {
 	EnvironmentID string `path:"id" doc:"Environment ID"`
 	VolumeName    string `path:"volumeName" doc:"Volume name"`
 	BackupID      string `path:"backupId" doc:"Backup ID"`
+	Passphrase    string `header:"X-Backup-Passphrase" doc:"Passphrase of a passphrase-encrypted backup"`
 }
 
 type RestoreBackupOutput struct {
@@ -243,6 +249,7 @@ type RestoreBackupFilesInput struct {
 	EnvironmentID string `path:"id" doc:"Environment ID"`
 	VolumeName    string `path:"volumeName" doc:"Volume name"`
 	BackupID      string `path:"backupId" doc:"Backup ID"`
+	Passphrase    string `header:"X-Backup-Passphrase" doc:"Passphrase of a passphrase-encrypted backup"`
 	Body          struct {
 		Paths []string `json:"paths" doc:"Paths to restore from backup"`
 	}
@@ -256,6 +263,7 @@ type BackupHasPathInput struct {
 	EnvironmentID string `path:"id" doc:"Environment ID"`
 	BackupID      string `path:"backupId" doc:"Backup ID"`
 	Path          string `query:"path" doc:"Path to check"`
+	Passphrase    string `header:"X-Backup-Passphrase" doc:"Passphrase of a passphrase-encrypted backup"`
 }
 
 type BackupHasPathResponse struct {
@@ -269,6 +277,7 @@ type BackupHasPathOutput struct {
 type ListBackupFilesInput struct {
 	EnvironmentID string `path:"id" doc:"Environment ID"`
 	BackupID      string `path:"backupId" doc:"Backup ID"`
+	Passphrase    string `header:"X-Backup-Passphrase" doc:"Passphrase of a passphrase-encrypted backup"`
 }
 
 type ListBackupFilesOutput struct {
@@ -296,9 +305,51 @@ type DownloadBackupOutput struct {
 	Body               io.ReadCloser
 }
 
+type GetBackupPolicyInput struct {
+	EnvironmentID string `path:"id" doc:"Environment ID"`
+	VolumeName    string `path:"volumeName" doc:"Volume name"`
+}
+
+type GetBackupPolicyOutput struct {
+	Body base.ApiResponse[*models.VolumeBackupPolicy]
+}
+
+type UpdateBackupPolicyInput struct {
+	EnvironmentID string `path:"id" doc:"Environment ID"`
+	VolumeName    string `path:"volumeName" doc:"Volume name"`
+	Body          volumetypes.UpdateBackupPolicy
+}
+
+type UpdateBackupPolicyOutput struct {
+	Body base.ApiResponse[*models.VolumeBackupPolicy]
+}
+
+type DeleteBackupPolicyInput struct {
+	EnvironmentID string `path:"id" doc:"Environment ID"`
+	VolumeName    string `path:"volumeName" doc:"Volume name"`
+}
+
+type DeleteBackupPolicyOutput struct {
+	Body base.ApiResponse[base.MessageResponse]
+}
+
+type RescanBackupsInput struct {
+	EnvironmentID string `path:"id" doc:"Environment ID"`
+	VolumeName    string `path:"volumeName" doc:"Volume name"`
+}
+
+type RescanBackupsResult struct {
+	Imported int `json:"imported" doc:"Number of backups imported from backup targets"`
+}
+
+type RescanBackupsOutput struct {
+	Body base.ApiResponse[RescanBackupsResult]
+}
+
 type UploadAndRestoreInput struct {
 	EnvironmentID string         `path:"id" doc:"Environment ID"`
 	VolumeName    string         `path:"volumeName" doc:"Volume name"`
+	Passphrase    string         `header:"X-Backup-Passphrase" doc:"Passphrase of a passphrase-encrypted archive"`
 	RawBody       multipart.Form `contentType:"multipart/form-data"`
 }
 
@@ -522,6 +573,18 @@ func RegisterVolumes(api huma.API, dockerService *services.DockerClientService,
 		},
 	}, h.ListBackups)
 
+	huma.Register(api, huma.Operation{
+		OperationID: "rescan-volume-backups",
+		Method:      http.MethodPost,
+		Path:        "/environments/{id}/volumes/{volumeName}/backups/rescan",
+		Summary:     "Import volume backups found on backup targets",
+		Tags:        []string{"Volume Backup"},
+		Security: []map[string][]string{
+			{"BearerAuth": {}},
+			{"ApiKeyAuth": {}},
+		},
+	}, h.RescanBackups)
+
 	huma.Register(api, huma.Operation{
 		OperationID: "create-volume-backup",
 		Method:      http.MethodPost,
@@ -634,6 +697,45 @@ func RegisterVolumes(api huma.API, dockerService *services.DockerClientService,
 			},
 		},
 	}, h.UploadAndRestore)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-volume-backup-policy",
+		Method:      http.MethodGet,
+		Path:        "/environments/{id}/volumes/{volumeName}/backup-policy",
+		Summary:     "Get volume backup policy",
+		Description: "Get the scheduled backup and retention policy of a volume",
+		Tags:        []string{"Volume Backup"},
+		Security: []map[string][]string{
+			{"BearerAuth": {}},
+			{"ApiKeyAuth": {}},
+		},
+	}, h.GetBackupPolicy)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "update-volume-backup-policy",
+		Method:      http.MethodPut,
+		Path:        "/environments/{id}/volumes/{volumeName}/backup-policy",
+		Summary:     "Update volume backup policy",
+		Description: "Enable scheduled backups for a volume and configure how many backups are kept",
+		Tags:        []string{"Volume Backup"},
+		Security: []map[string][]string{
+			{"BearerAuth": {}},
+			{"ApiKeyAuth": {}},
+		},
+	}, h.UpdateBackupPolicy)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "delete-volume-backup-policy",
+		Method:      http.MethodDelete,
+		Path:        "/environments/{id}/volumes/{volumeName}/backup-policy",
+		Summary:     "Delete volume backup policy",
+		Description: "Remove a volume from scheduled backups; existing backups are kept",
+		Tags:        []string{"Volume Backup"},
+		Security: []map[string][]string{
+			{"BearerAuth": {}},
+			{"ApiKeyAuth": {}},
+		},
+	}, h.DeleteBackupPolicy)
 }
 
 // ListVolumes returns a paginated list of volumes.
@@ -1029,6 +1131,27 @@ func (h *VolumeHandler) ListBackups(ctx context.Context, input *ListBackupsInput
 	}, nil
 }
 
+func (h *VolumeHandler) RescanBackups(ctx context.Context, input *RescanBackupsInput) (*RescanBackupsOutput, error) {
+	if h.volumeService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	if err := checkAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	imported, err := h.volumeService.RescanBackupTargets(ctx, input.VolumeName)
+	if err != nil {
+		return nil, huma.Error500InternalServerError(err.Error())
+	}
+	return &RescanBackupsOutput{
+		Body: base.ApiResponse[RescanBackupsResult]{
+			Success: true,
+			Data:    RescanBackupsResult{Imported: imported},
+		},
+	}, nil
+}
+
 func (h *VolumeHandler) CreateBackup(ctx context.Context, input *CreateBackupInput) (*CreateBackupOutput, error) {
 	if h.volumeService == nil {
 		return nil, huma.Error500InternalServerError("service not available")
@@ -1038,7 +1161,11 @@ func (h *VolumeHandler) CreateBackup(ctx context.Context, input *CreateBackupInp
 		return nil, huma.Error401Unauthorized((&common.NotAuthenticatedError{}).Error())
 	}
 
-	backup, err := h.volumeService.CreateBackup(ctx, input.VolumeName, *user)
+	backup, err := h.volumeService.CreateBackupWithOptions(ctx, input.VolumeName, services.BackupOptions{
+		TargetID:   input.TargetID,
+		Encrypt:    input.Encrypt,
+		Passphrase: input.Passphrase,
+	}, *user)
 	if err != nil {
 		return nil, huma.Error500InternalServerError(err.Error())
 	}
@@ -1059,9 +1186,9 @@ func (h *VolumeHandler) RestoreBackup(ctx context.Context, input *RestoreBackupI
 		return nil, huma.Error401Unauthorized((&common.NotAuthenticatedError{}).Error())
 	}
 
-	err := h.volumeService.RestoreBackup(ctx, input.VolumeName, input.BackupID, *user)
+	err := h.volumeService.RestoreBackup(ctx, input.VolumeName, input.BackupID, input.Passphrase, *user)
 	if err != nil {
-		return nil, huma.Error500InternalServerError(err.Error())
+		return nil, backupArchiveErrorInternal(err)
 	}
 	return &RestoreBackupOutput{
 		Body: base.ApiResponse[base.MessageResponse]{
@@ -1085,8 +1212,8 @@ func (h *VolumeHandler) RestoreBackupFiles(ctx context.Context, input *RestoreBa
 		return nil, huma.Error400BadRequest("paths are required")
 	}
 
-	if err := h.volumeService.RestoreBackupFiles(ctx, input.VolumeName, input.BackupID, input.Body.Paths, *user); err != nil {
-		return nil, huma.Error500InternalServerError(err.Error())
+	if err := h.volumeService.RestoreBackupFiles(ctx, input.VolumeName, input.BackupID, input.Body.Paths, input.Passphrase, *user); err != nil {
+		return nil, backupArchiveErrorInternal(err)
 	}
 
 	return &RestoreBackupFilesOutput{
@@ -1106,9 +1233,9 @@ func (h *VolumeHandler) BackupHasPath(ctx context.Context, input *BackupHasPathI
 		return nil, huma.Error400BadRequest("path is required")
 	}
 
-	exists, err := h.volumeService.BackupHasPath(ctx, input.BackupID, input.Path)
+	exists, err := h.volumeService.BackupHasPath(ctx, input.BackupID, input.Path, input.Passphrase)
 	if err != nil {
-		return nil, huma.Error500InternalServerError(err.Error())
+		return nil, backupArchiveErrorInternal(err)
 	}
 
 	return &BackupHasPathOutput{
@@ -1124,9 +1251,9 @@ func (h *VolumeHandler) ListBackupFiles(ctx context.Context, input *ListBackupFi
 		return nil, huma.Error500InternalServerError("service not available")
 	}
 
-	files, err := h.volumeService.ListBackupFiles(ctx, input.BackupID)
+	files, err := h.volumeService.ListBackupFiles(ctx, input.BackupID, input.Passphrase)
 	if err != nil {
-		return nil, huma.Error500InternalServerError(err.Error())
+		return nil, backupArchiveErrorInternal(err)
 	}
 
 	return &ListBackupFilesOutput{
@@ -1192,9 +1319,14 @@ func (h *VolumeHandler) UploadAndRestore(ctx context.Context, input *UploadAndRe
 	}
 	defer func() { _ = file.Close() }()
 
-	err = h.volumeService.UploadAndRestore(ctx, input.VolumeName, file, fileHeader.Filename, *user)
+	checksum := ""
+	if values := input.RawBody.Value["checksum"]; len(values) > 0 {
+		checksum = values[0]
+	}
+
+	err = h.volumeService.UploadAndRestore(ctx, input.VolumeName, file, fileHeader.Filename, checksum, input.Passphrase, *user)
 	if err != nil {
-		return nil, huma.Error500InternalServerError(err.Error())
+		return nil, backupArchiveErrorInternal(err)
 	}
 	return &UploadAndRestoreOutput{
 		Body: base.ApiResponse[base.MessageResponse]{
@@ -1203,3 +1335,67 @@ func (h *VolumeHandler) UploadAndRestore(ctx context.Context, input *UploadAndRe
 		},
 	}, nil
 }
+
+func (h *VolumeHandler) GetBackupPolicy(ctx context.Context, input *GetBackupPolicyInput) (*GetBackupPolicyOutput, error) {
+	if h.volumeService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	policy, err := h.volumeService.GetBackupPolicy(ctx, input.VolumeName)
+	if err != nil {
+		return nil, huma.Error500InternalServerError(err.Error())
+	}
+	return &GetBackupPolicyOutput{
+		Body: base.ApiResponse[*models.VolumeBackupPolicy]{
+			Success: true,
+			Data:    policy,
+		},
+	}, nil
+}
+
+func (h *VolumeHandler) UpdateBackupPolicy(ctx context.Context, input *UpdateBackupPolicyInput) (*UpdateBackupPolicyOutput, error) {
+	if h.volumeService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	policy, err := h.volumeService.SaveBackupPolicy(ctx, input.VolumeName, input.Body)
+	if err != nil {
+		apiErr := models.ToAPIError(err)
+		return nil, huma.NewError(apiErr.HTTPStatus(), err.Error())
+	}
+	return &UpdateBackupPolicyOutput{
+		Body: base.ApiResponse[*models.VolumeBackupPolicy]{
+			Success: true,
+			Data:    policy,
+		},
+	}, nil
+}
+
+func (h *VolumeHandler) DeleteBackupPolicy(ctx context.Context, input *DeleteBackupPolicyInput) (*DeleteBackupPolicyOutput, error) {
+	if h.volumeService == nil {
+		return nil, huma.Error500InternalServerError("service not available")
+	}
+
+	if err := h.volumeService.DeleteBackupPolicy(ctx, input.VolumeName); err != nil {
+		return nil, huma.Error500InternalServerError(err.Error())
+	}
+	return &DeleteBackupPolicyOutput{
+		Body: base.ApiResponse[base.MessageResponse]{
+			Success: true,
+			Data:    base.MessageResponse{Message: "Backup policy deleted successfully"},
+		},
+	}, nil
+}
+
+// backupArchiveErrorInternal maps archive verification and decryption failures to client
+// errors; anything else is an internal error.
+func backupArchiveErrorInternal(err error) error {
+	switch {
+	case errors.Is(err, services.ErrBackupChecksumMismatch), errors.Is(err, services.ErrBackupUnverified), errors.Is(err, crypto.ErrStreamCorrupted):
+		return huma.Error422UnprocessableEntity(err.Error())
+	case errors.Is(err, crypto.ErrPassphraseRequired):
+		return huma.Error400BadRequest(err.Error())
+	default:
+		return huma.Error500InternalServerError(err.Error())
+	}
+}
@@ -10,6 +10,7 @@ import (
 	humamw "github.com/getarcaneapp/arcane/backend/internal/huma/middleware"
 	"github.com/getarcaneapp/arcane/backend/internal/models"
 	"github.com/getarcaneapp/arcane/backend/internal/services"
+	volumetypes "github.com/getarcaneapp/arcane/types/volume"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -48,3 +49,29 @@ func TestUploadAndRestoreReturnsBadRequestWhenNoFileProvided(t *testing.T) {
 	require.ErrorAs(t, err, &statusErr)
 	assert.Equal(t, http.StatusBadRequest, statusErr.GetStatus())
 }
+
+func TestRescanBackupsRequiresAdmin(t *testing.T) {
+	h := &VolumeHandler{volumeService: &services.VolumeService{}}
+
+	ctx := context.WithValue(context.Background(), humamw.ContextKeyUserIsAdmin, false)
+
+	_, err := h.RescanBackups(ctx, &RescanBackupsInput{EnvironmentID: "0", VolumeName: "vol-1"})
+
+	var statusErr huma.StatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusForbidden, statusErr.GetStatus())
+}
+
+func TestUpdateBackupPolicyReturnsBadRequestForNegativeRetention(t *testing.T) {
+	h := &VolumeHandler{volumeService: &services.VolumeService{}}
+
+	_, err := h.UpdateBackupPolicy(context.Background(), &UpdateBackupPolicyInput{
+		EnvironmentID: "0",
+		VolumeName:    "vol-1",
+		Body:          volumetypes.UpdateBackupPolicy{KeepDaily: -1},
+	})
+
+	var statusErr huma.StatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusBadRequest, statusErr.GetStatus())
+}
@@ -133,7 +133,9 @@ type Services struct {
 	Image             *services.ImageService
 	ImageUpdate       *services.ImageUpdateService
 	Volume            *services.VolumeService
+	BackupTarget      *services.BackupTargetService
 	Container         *services.ContainerService
+	ExecSession       *services.ExecSessionService
 	Network           *services.NetworkService
 	Notification      *services.NotificationService
 	Apprise           *services.AppriseService //nolint:staticcheck // Apprise still functional, deprecated in favor of Shoutrrr
@@ -292,6 +294,7 @@ func registerHandlers(api huma.API, svc *Services) {
 	var imageSvc *services.ImageService
 	var imageUpdateSvc *services.ImageUpdateService
 	var volumeSvc *services.VolumeService
+	var backupTargetSvc *services.BackupTargetService
 	var containerSvc *services.ContainerService
 	var networkSvc *services.NetworkService
 	var notificationSvc *services.NotificationService
@@ -303,6 +306,7 @@ func registerHandlers(api huma.API, svc *Services) {
 	var gitRepositorySvc *services.GitRepositoryService
 	var gitOpsSyncSvc *services.GitOpsSyncService
 	var vulnerabilitySvc *services.VulnerabilityService
+	var execSessionSvc *services.ExecSessionService
 	var cfg *config.Config
 
 	if svc != nil {
@@ -325,6 +329,7 @@ func registerHandlers(api huma.API, svc *Services) {
 		imageSvc = svc.Image
 		imageUpdateSvc = svc.ImageUpdate
 		volumeSvc = svc.Volume
+		backupTargetSvc = svc.BackupTarget
 		containerSvc = svc.Container
 		networkSvc = svc.Network
 		notificationSvc = svc.Notification
@@ -336,6 +341,7 @@ func registerHandlers(api huma.API, svc *Services) {
 		gitRepositorySvc = svc.GitRepository
 		gitOpsSyncSvc = svc.GitOpsSync
 		vulnerabilitySvc = svc.Vulnerability
+		execSessionSvc = svc.ExecSession
 		cfg = svc.Config
 	}
 	handlers.RegisterHealth(api)
@@ -344,6 +350,10 @@ func registerHandlers(api huma.API, svc *Services) {
 	handlers.RegisterAppImages(api, appImagesSvc)
 	handlers.RegisterFonts(api, fontSvc)
 	handlers.RegisterProjects(api, projectSvc)
+	handlers.RegisterProjectBackups(api, projectSvc)
+	handlers.RegisterProjectRevisions(api, projectSvc)
+	handlers.RegisterProjectPlan(api, projectSvc)
+	handlers.RegisterProjectLogs(api, projectSvc)
 	handlers.RegisterUsers(api, userSvc)
 	handlers.RegisterVersion(api, versionSvc)
 	handlers.RegisterEvents(api, eventSvc)
@@ -356,6 +366,8 @@ func registerHandlers(api huma.API, svc *Services) {
 	handlers.RegisterSettings(api, settingsSvc, settingsSearchSvc, environmentSvc, cfg)
 	handlers.RegisterJobSchedules(api, jobScheduleSvc, environmentSvc)
 	handlers.RegisterVolumes(api, dockerSvc, volumeSvc)
+	handlers.RegisterBackupTargets(api, backupTargetSvc)
+	handlers.RegisterExecSessions(api, execSessionSvc)
 	handlers.RegisterContainers(api, containerSvc, dockerSvc)
 	handlers.RegisterNetworks(api, networkSvc, dockerSvc)
 	handlers.RegisterNotifications(api, notificationSvc, appriseSvc)
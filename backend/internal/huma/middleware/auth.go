@@ -9,7 +9,7 @@ import (
 	"github.com/getarcaneapp/arcane/backend/internal/config"
 	"github.com/getarcaneapp/arcane/backend/internal/models"
 	"github.com/getarcaneapp/arcane/backend/internal/services"
-	pkgutils "github.com/getarcaneapp/arcane/backend/pkg/utils"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/rbac"
 )
 
 const (
@@ -158,9 +158,7 @@ func NewAuthBridge(api huma.API, authService *services.AuthService, apiKeyServic
 		// Check agent authentication first (if in agent mode)
 		if cfg != nil && cfg.AgentMode {
 			if user, ok := tryAgentAuth(ctx, cfg); ok {
-				newCtx := setUserInContext(ctx.Context(), user)
-				ctx = huma.WithContext(ctx, newCtx)
-				next(ctx)
+				authorizeAndContinue(api, ctx, user, next)
 				return
 			}
 		}
@@ -175,9 +173,7 @@ func NewAuthBridge(api huma.API, authService *services.AuthService, apiKeyServic
 		// If validation fails, do NOT fall back to Bearer auth.
 		if reqs.apiKeyAuth && ctx.Header(headerApiKey) != "" {
 			if user, ok := tryApiKeyAuth(ctx, apiKeyService); ok {
-				newCtx := setUserInContext(ctx.Context(), user)
-				ctx = huma.WithContext(ctx, newCtx)
-				next(ctx)
+				authorizeAndContinue(api, ctx, user, next)
 				return
 			}
 			// API key was present but invalid. Fail immediately.
@@ -187,9 +183,7 @@ func NewAuthBridge(api huma.API, authService *services.AuthService, apiKeyServic
 
 		if reqs.bearerAuth {
 			if user, ok := tryBearerAuth(ctx, authService); ok {
-				newCtx := setUserInContext(ctx.Context(), user)
-				ctx = huma.WithContext(ctx, newCtx)
-				next(ctx)
+				authorizeAndContinue(api, ctx, user, next)
 				return
 			}
 		}
@@ -199,6 +193,32 @@ func NewAuthBridge(api huma.API, authService *services.AuthService, apiKeyServic
 	}
 }
 
+// authorizeAndContinue stores the authenticated user in the context and calls next when the
+// user's roles grant the permission the operation requires; otherwise it writes a 403.
+func authorizeAndContinue(api huma.API, ctx huma.Context, user *models.User, next func(huma.Context)) {
+	if !isOperationAllowed(ctx, user) {
+		_ = huma.WriteErr(api, ctx, http.StatusForbidden, "Forbidden: insufficient permissions for this action")
+		return
+	}
+	ctx = huma.WithContext(ctx, setUserInContext(ctx.Context(), user))
+	next(ctx)
+}
+
+// isOperationAllowed checks the user's environment grants and roles against the operation.
+// Operations outside the role model (auth, users, events, ...) are only subject to the
+// environment check here and rely on their handler's own checks, such as checkAdmin.
+func isOperationAllowed(ctx huma.Context, user *models.User) bool {
+	path := ctx.URL().Path
+	if envID, ok := rbac.EnvironmentIDFromPath(path); ok && !rbac.CanAccessEnvironment(user, envID, rbac.ActionFor(ctx.Method(), path)) {
+		return false
+	}
+
+	if op := ctx.Operation(); op != nil && op.Path != "" {
+		path = op.Path
+	}
+	return rbac.RequestAllowed(user.Roles, ctx.Method(), path)
+}
+
 // extractBearerToken extracts the JWT token from Authorization header or cookie.
 func extractBearerToken(ctx huma.Context) string {
 	// Try Authorization header first
@@ -235,6 +255,6 @@ func extractTokenFromCookieHeader(cookieHeader string) string {
 func setUserInContext(ctx context.Context, user *models.User) context.Context {
 	ctx = context.WithValue(ctx, ContextKeyUserID, user.ID)
 	ctx = context.WithValue(ctx, ContextKeyCurrentUser, user)
-	ctx = context.WithValue(ctx, ContextKeyUserIsAdmin, pkgutils.UserHasRole(user.Roles, "admin"))
+	ctx = context.WithValue(ctx, ContextKeyUserIsAdmin, rbac.IsAdmin(user))
 	return ctx
 }
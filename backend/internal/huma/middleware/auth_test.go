@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/getarcaneapp/arcane/backend/internal/models"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/rbac"
+)
+
+func TestAuthorizeAndContinue(t *testing.T) {
+	_, api := humatest.New(t)
+
+	tests := []struct {
+		name       string
+		roles      []string
+		access     models.EnvironmentAccess
+		keyAccess  models.EnvironmentAccess
+		method     string
+		path       string
+		wantNext   bool
+		wantStatus int
+	}{
+		{name: "viewer lists containers", roles: []string{rbac.RoleViewer}, method: http.MethodGet, path: "/environments/{id}/containers", wantNext: true},
+		{name: "viewer cannot start container", roles: []string{rbac.RoleViewer}, method: http.MethodPost, path: "/environments/{id}/containers/{containerId}/start", wantStatus: http.StatusForbidden},
+		{name: "deployer deploys project", roles: []string{rbac.RoleProjectDeployer}, method: http.MethodPost, path: "/environments/{id}/projects/{projectId}/up", wantNext: true},
+		{name: "deployer cannot remove volume", roles: []string{rbac.RoleProjectDeployer}, method: http.MethodDelete, path: "/environments/{id}/volumes/{volumeName}", wantStatus: http.StatusForbidden},
+		{name: "operator cannot edit registries", roles: []string{rbac.RoleOperator}, method: http.MethodPut, path: "/container-registries/{id}", wantStatus: http.StatusForbidden},
+		{name: "operator restarts container", roles: []string{rbac.RoleOperator}, method: http.MethodPost, path: "/environments/{id}/containers/{containerId}/restart", wantNext: true},
+		{name: "legacy user keeps write access", roles: []string{rbac.RoleUser}, method: http.MethodDelete, path: "/environments/{id}/networks/{networkId}", wantNext: true},
+		{name: "routes outside the role model pass through", roles: []string{rbac.RoleViewer}, method: http.MethodPut, path: "/auth/password", wantNext: true},
+		{name: "read grant lists containers", roles: []string{rbac.RoleUser}, access: models.EnvironmentAccess{"prod": models.EnvironmentAccessRead}, method: http.MethodGet, path: "/environments/prod/containers", wantNext: true},
+		{name: "read grant cannot start container", roles: []string{rbac.RoleUser}, access: models.EnvironmentAccess{"prod": models.EnvironmentAccessRead}, method: http.MethodPost, path: "/environments/prod/containers/abc/start", wantStatus: http.StatusForbidden},
+		{name: "ungranted environment is rejected", roles: []string{rbac.RoleUser}, access: models.EnvironmentAccess{"prod": models.EnvironmentAccessWrite}, method: http.MethodGet, path: "/environments/staging", wantStatus: http.StatusForbidden},
+		{name: "write grant does not lift role limits", roles: []string{rbac.RoleViewer}, access: models.EnvironmentAccess{"prod": models.EnvironmentAccessWrite}, method: http.MethodDelete, path: "/environments/prod/volumes/data", wantStatus: http.StatusForbidden},
+		{name: "admin ignores own environment grants", roles: []string{rbac.RoleAdmin}, access: models.EnvironmentAccess{}, method: http.MethodDelete, path: "/environments/prod/volumes/data", wantNext: true},
+		{name: "admin-owned key is limited to its environment", roles: []string{rbac.RoleAdmin}, keyAccess: models.EnvironmentAccess{"staging": models.EnvironmentAccessWrite}, method: http.MethodGet, path: "/environments/prod/containers", wantStatus: http.StatusForbidden},
+		{name: "admin-owned key works in its environment", roles: []string{rbac.RoleAdmin}, keyAccess: models.EnvironmentAccess{"staging": models.EnvironmentAccessWrite}, method: http.MethodDelete, path: "/environments/staging/volumes/data", wantNext: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op := &huma.Operation{Method: tt.method, Path: tt.path}
+			rec := httptest.NewRecorder()
+			ctx := humatest.NewContext(op, httptest.NewRequest(tt.method, "/api"+tt.path, nil), rec)
+			user := &models.User{BaseModel: models.BaseModel{ID: "u1"}, Roles: tt.roles, EnvironmentAccess: tt.access, KeyEnvironmentAccess: tt.keyAccess}
+
+			called := false
+			authorizeAndContinue(api, ctx, user, func(next huma.Context) {
+				called = true
+				if u, ok := GetCurrentUserFromContext(next.Context()); !ok || u.ID != "u1" {
+					t.Errorf("expected user in context, got %v", u)
+				}
+			})
+
+			if called != tt.wantNext {
+				t.Fatalf("next called = %v, want %v", called, tt.wantNext)
+			}
+			if !tt.wantNext && rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
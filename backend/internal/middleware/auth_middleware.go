@@ -11,7 +11,7 @@ import (
 	"github.com/getarcaneapp/arcane/backend/internal/models"
 	"github.com/getarcaneapp/arcane/backend/internal/services"
 	"github.com/getarcaneapp/arcane/backend/internal/utils/cookie"
-	pkgutils "github.com/getarcaneapp/arcane/backend/pkg/utils"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/rbac"
 	"github.com/gin-gonic/gin"
 )
 
@@ -112,8 +112,8 @@ func (m *AuthMiddleware) managerAuth(ctx context.Context, c *gin.Context) {
 	if apiKey := c.GetHeader(headerApiKey); apiKey != "" && m.apiKeyValidator != nil {
 		user, err := m.apiKeyValidator.ValidateApiKey(ctx, apiKey)
 		if err == nil && user != nil {
-			isAdmin := pkgutils.UserHasRole(user.Roles, "admin")
-			if m.options.AdminRequired && !isAdmin {
+			isAdmin := rbac.IsAdmin(user)
+			if (m.options.AdminRequired && !isAdmin) || !isRequestAllowed(c, user) {
 				c.JSON(http.StatusForbidden, models.APIError{
 					Code:    "FORBIDDEN",
 					Message: "You don't have permission to access this resource",
@@ -175,8 +175,8 @@ func (m *AuthMiddleware) managerAuth(ctx context.Context, c *gin.Context) {
 		return
 	}
 
-	isAdmin := pkgutils.UserHasRole(user.Roles, "admin")
-	if m.options.AdminRequired && !isAdmin {
+	isAdmin := rbac.IsAdmin(user)
+	if (m.options.AdminRequired && !isAdmin) || !isRequestAllowed(c, user) {
 		c.JSON(http.StatusForbidden, models.APIError{
 			Code:    "FORBIDDEN",
 			Message: "You don't have permission to access this resource",
@@ -191,6 +191,20 @@ func (m *AuthMiddleware) managerAuth(ctx context.Context, c *gin.Context) {
 	c.Next()
 }
 
+// isRequestAllowed checks the user's environment grants and roles against the request.
+func isRequestAllowed(c *gin.Context, user *models.User) bool {
+	if envID, ok := rbac.EnvironmentIDFromPath(c.Request.URL.Path); ok &&
+		!rbac.CanAccessEnvironment(user, envID, rbac.ActionFor(c.Request.Method, c.Request.URL.Path)) {
+		return false
+	}
+
+	path := c.FullPath()
+	if path == "" {
+		path = c.Request.URL.Path
+	}
+	return rbac.RequestAllowed(user.Roles, c.Request.Method, path)
+}
+
 func isPreflight(c *gin.Context) bool {
 	return c.Request.Method == http.MethodOptions
 }
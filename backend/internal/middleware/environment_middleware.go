@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/getarcaneapp/arcane/backend/internal/models"
 	"github.com/getarcaneapp/arcane/backend/internal/services"
 	"github.com/getarcaneapp/arcane/backend/internal/utils/edge"
 	"github.com/getarcaneapp/arcane/backend/internal/utils/remenv"
@@ -27,6 +28,7 @@ const (
 	errFailedCreateProxyRequest = "Failed to create proxy request"
 	errProxyRequestFailedPrefix = "Proxy request failed:"
 	errUnauthorized             = "Authentication required to access remote environments"
+	errForbidden                = "You do not have permission to perform this action on this environment"
 
 	// proxyTimeout is intentionally generous because some proxied operations
 	// (e.g., image pulls with progress streaming) can take multiple minutes.
@@ -52,8 +54,8 @@ var managementEndpointSet = map[string]struct{}{
 type EnvResolver func(ctx context.Context, id string) (string, *string, bool, error)
 
 // AuthValidator validates authentication for a request.
-// Returns true if the request is authenticated, false otherwise.
-type AuthValidator func(ctx context.Context, c *gin.Context) bool
+// Returns the authenticated user and true, or false if the request is not authenticated.
+type AuthValidator func(ctx context.Context, c *gin.Context) (*models.User, bool)
 
 // EnvironmentMiddleware proxies requests for remote environments to their respective agents.
 type EnvironmentMiddleware struct {
@@ -104,14 +106,29 @@ func (m *EnvironmentMiddleware) Handle(c *gin.Context) {
 	// SECURITY: Validate authentication BEFORE proxying to remote environments.
 	// The proxy attaches the agent token to forwarded requests, which grants full access
 	// on the remote agent. Without this check, unauthenticated users could access
-	// remote environment resources.
-	if m.authValidator != nil && !m.authValidator(c.Request.Context(), c) {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"success": false,
-			"data":    gin.H{"error": errUnauthorized},
-		})
-		c.Abort()
-		return
+	// remote environment resources. GitOps webhooks are the only exception: git providers
+	// cannot log in, and the agent verifies their signature against the sync's secret.
+	if m.authValidator != nil && !m.isGitOpsWebhook(c, envID) {
+		user, ok := m.authValidator(c.Request.Context(), c)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"success": false,
+				"data":    gin.H{"error": errUnauthorized},
+			})
+			c.Abort()
+			return
+		}
+
+		// The agent trusts the manager's token, so environment grants and role permissions
+		// must be enforced here.
+		if user != nil && !isRequestAllowed(c, user) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"success": false,
+				"data":    gin.H{"error": errForbidden},
+			})
+			c.Abort()
+			return
+		}
 	}
 
 	// Resolve remote environment
@@ -181,6 +198,20 @@ func (m *EnvironmentMiddleware) hasResourcePath(c *gin.Context, envID string) bo
 	return !isManagement
 }
 
+// isGitOpsWebhook reports whether the request is a POST to
+// /api/environments/{id}/gitops-syncs/{syncId}/webhook.
+func (m *EnvironmentMiddleware) isGitOpsWebhook(c *gin.Context, envID string) bool {
+	if c.Request.Method != http.MethodPost {
+		return false
+	}
+	suffix, _ := strings.CutPrefix(c.Request.URL.Path, apiEnvironmentsPrefix+envID)
+	if path.Clean(suffix) != suffix {
+		return false
+	}
+	parts := strings.Split(strings.TrimPrefix(suffix, "/"), "/")
+	return len(parts) == 3 && parts[0] == "gitops-syncs" && parts[1] != "" && parts[2] == "webhook"
+}
+
 // extractEnvironmentID gets the environment ID from the request.
 // Only processes paths containing "/environments/" to avoid conflicts with other routes.
 func (m *EnvironmentMiddleware) extractEnvironmentID(c *gin.Context) string {
@@ -13,6 +13,9 @@ type ApiKey struct {
 	EnvironmentID *string    `json:"environmentId,omitempty" gorm:"column:environment_id"`
 	ExpiresAt     *time.Time `json:"expiresAt,omitempty" gorm:"column:expires_at" sortable:"true"`
 	LastUsedAt    *time.Time `json:"lastUsedAt,omitempty" gorm:"column:last_used_at" sortable:"true"`
+
+	// EnvironmentAccess restricts the key to specific environments on top of its owner's access.
+	EnvironmentAccess EnvironmentAccess `json:"environmentAccess,omitempty" gorm:"column:environment_access;type:text"`
 	BaseModel
 }
 
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/getarcaneapp/arcane/types/volume"
+)
+
+// BackupTarget is an off-host location volume backups are written to. Config holds the
+// type-specific settings without credentials; Credentials holds the secret settings as
+// an encrypted JSON object.
+type BackupTarget struct {
+	Name        string `json:"name" gorm:"column:name;uniqueIndex"`
+	Type        string `json:"type" gorm:"column:type"` // s3, sftp, path
+	Config      JSON   `json:"config" gorm:"column:config;type:text"`
+	Credentials string `json:"-" gorm:"column:credentials"` // encrypted
+	BaseModel
+}
+
+func (BackupTarget) TableName() string {
+	return "backup_targets"
+}
+
+func (t *BackupTarget) ToDTO() volume.BackupTarget {
+	dto := volume.BackupTarget{
+		ID:             t.ID,
+		Name:           t.Name,
+		Type:           t.Type,
+		Config:         map[string]any(t.Config),
+		HasCredentials: t.Credentials != "",
+		CreatedAt:      t.CreatedAt.Format(time.RFC3339),
+	}
+	if dto.Config == nil {
+		dto.Config = map[string]any{}
+	}
+	if t.UpdatedAt != nil {
+		dto.UpdatedAt = t.UpdatedAt.Format(time.RFC3339)
+	}
+	return dto
+}
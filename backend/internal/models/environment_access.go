@@ -0,0 +1,163 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// EnvironmentAccessLevel is the access granted on a single environment.
+type EnvironmentAccessLevel string
+
+const (
+	EnvironmentAccessRead  EnvironmentAccessLevel = "read"
+	EnvironmentAccessWrite EnvironmentAccessLevel = "write"
+
+	// EnvironmentAccessAll is the key granting a level on every environment not listed explicitly.
+	EnvironmentAccessAll = "*"
+)
+
+// IsValid reports whether the level is one of the known access levels.
+func (l EnvironmentAccessLevel) IsValid() bool {
+	return l == EnvironmentAccessRead || l == EnvironmentAccessWrite
+}
+
+// ErrInvalidEnvironmentAccess is returned (wrapped) when an environment grant is malformed.
+var ErrInvalidEnvironmentAccess = errors.New("invalid environment access")
+
+// EnvironmentAccess maps environment IDs to the access level granted on them, e.g.
+// {"prod": "read", "staging": "write"} or {"*": "read", "staging": "write"}.
+// A nil map means the holder is not restricted; environments neither listed nor
+// covered by "*" are not accessible.
+//
+// nolint:recvcheck
+type EnvironmentAccess map[string]EnvironmentAccessLevel
+
+// ParseEnvironmentAccess converts an API representation into EnvironmentAccess.
+// A nil map, or one that only grants write on "*", means unrestricted and yields nil.
+func ParseEnvironmentAccess(m map[string]string) (EnvironmentAccess, error) {
+	if m == nil {
+		return nil, nil
+	}
+	out := make(EnvironmentAccess, len(m))
+	for id, level := range m {
+		l := EnvironmentAccessLevel(strings.ToLower(strings.TrimSpace(level)))
+		if strings.TrimSpace(id) == "" || !l.IsValid() {
+			return nil, fmt.Errorf("%w: %q=%q, level must be read or write", ErrInvalidEnvironmentAccess, id, level)
+		}
+		out[strings.TrimSpace(id)] = l
+	}
+	if len(out) == 1 && out[EnvironmentAccessAll] == EnvironmentAccessWrite {
+		return nil, nil
+	}
+	return out, nil
+}
+
+// ToMap converts the grant into its API representation.
+func (a EnvironmentAccess) ToMap() map[string]string {
+	if a == nil {
+		return nil
+	}
+	out := make(map[string]string, len(a))
+	for id, level := range a {
+		out[id] = string(level)
+	}
+	return out
+}
+
+func (a EnvironmentAccess) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return json.Marshal(a)
+}
+
+func (a *EnvironmentAccess) Scan(value any) error {
+	if value == nil {
+		*a = nil
+		return nil
+	}
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, a)
+	case string:
+		return json.Unmarshal([]byte(v), a)
+	default:
+		return json.Unmarshal(nil, a)
+	}
+}
+
+// IsRestricted reports whether access is limited by the grant.
+func (a EnvironmentAccess) IsRestricted() bool {
+	return a != nil
+}
+
+// Level returns the access level granted on the environment. ok is false when the
+// environment is not accessible at all.
+func (a EnvironmentAccess) Level(environmentID string) (level EnvironmentAccessLevel, ok bool) {
+	if a == nil {
+		return EnvironmentAccessWrite, true
+	}
+	if l, found := a[environmentID]; found {
+		return l, true
+	}
+	if l, found := a[EnvironmentAccessAll]; found {
+		return l, true
+	}
+	return "", false
+}
+
+// Allows reports whether the grant permits reading (write=false) or modifying
+// (write=true) the given environment.
+func (a EnvironmentAccess) Allows(environmentID string, write bool) bool {
+	level, ok := a.Level(environmentID)
+	if !ok {
+		return false
+	}
+	return level == EnvironmentAccessWrite || !write
+}
+
+// Intersect returns the access allowed by both a and b, taking the lower level
+// where both grant access. A nil side does not restrict the other.
+func (a EnvironmentAccess) Intersect(b EnvironmentAccess) EnvironmentAccess {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	out := make(EnvironmentAccess)
+	for _, grant := range []EnvironmentAccess{a, b} {
+		for id := range grant {
+			la, okA := a.Level(id)
+			lb, okB := b.Level(id)
+			if !okA || !okB {
+				continue
+			}
+			if la == EnvironmentAccessWrite && lb == EnvironmentAccessWrite {
+				out[id] = EnvironmentAccessWrite
+			} else {
+				out[id] = EnvironmentAccessRead
+			}
+		}
+	}
+	return out
+}
+
+// AccessibleEnvironmentIDs returns the explicitly listed environment IDs and whether
+// the grant also covers every other environment through "*".
+func (a EnvironmentAccess) AccessibleEnvironmentIDs() (ids []string, all bool) {
+	if a == nil {
+		return nil, true
+	}
+	for id := range a {
+		if id == EnvironmentAccessAll {
+			all = true
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids, all
+}
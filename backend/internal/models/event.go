@@ -9,18 +9,28 @@ type EventSeverity string
 
 const (
 	// Event types
-	EventTypeContainerStart   EventType = "container.start"
-	EventTypeContainerStop    EventType = "container.stop"
-	EventTypeContainerRestart EventType = "container.restart"
-	EventTypeContainerDelete  EventType = "container.delete"
-	EventTypeContainerCreate  EventType = "container.create"
-	EventTypeContainerScan    EventType = "container.scan"
-	EventTypeContainerUpdate  EventType = "container.update"
-	EventTypeContainerError   EventType = "container.error"
+	EventTypeContainerStart    EventType = "container.start"
+	EventTypeContainerStop     EventType = "container.stop"
+	EventTypeContainerRestart  EventType = "container.restart"
+	EventTypeContainerDelete   EventType = "container.delete"
+	EventTypeContainerCreate   EventType = "container.create"
+	EventTypeContainerScan     EventType = "container.scan"
+	EventTypeContainerUpdate   EventType = "container.update"
+	EventTypeContainerError    EventType = "container.error"
+	EventTypeContainerRollback EventType = "container.rollback"
+
+	EventTypeContainerFileUpload   EventType = "container.file.upload"
+	EventTypeContainerFileDownload EventType = "container.file.download"
+	EventTypeContainerExecStart    EventType = "container.exec.start"
+	EventTypeContainerExecStop     EventType = "container.exec.stop"
 
 	EventTypeImagePull              EventType = "image.pull"
 	EventTypeImageLoad              EventType = "image.load"
 	EventTypeImageDelete            EventType = "image.delete"
+	EventTypeImageTag               EventType = "image.tag"
+	EventTypeImagePush              EventType = "image.push"
+	EventTypeImageSave              EventType = "image.save"
+	EventTypeImageCommit            EventType = "image.commit"
 	EventTypeImageScan              EventType = "image.scan"
 	EventTypeImageError             EventType = "image.error"
 	EventTypeImageVulnerabilityScan EventType = "image.vulnerability_scan"
@@ -33,6 +43,12 @@ const (
 	EventTypeProjectUpdate EventType = "project.update"
 	EventTypeProjectError  EventType = "project.error"
 
+	EventTypeProjectBackupCreate  EventType = "project.backup.create"
+	EventTypeProjectBackupRestore EventType = "project.backup.restore"
+	EventTypeProjectBackupDelete  EventType = "project.backup.delete"
+	EventTypeProjectRollback      EventType = "project.rollback"
+	EventTypeProjectBuild         EventType = "project.build"
+
 	EventTypeGitRepositoryCreate EventType = "git.repository.create"
 	EventTypeGitRepositoryUpdate EventType = "git.repository.update"
 	EventTypeGitRepositoryDelete EventType = "git.repository.delete"
@@ -44,6 +60,7 @@ const (
 	EventTypeGitSyncDelete EventType = "git.sync.delete"
 	EventTypeGitSyncRun    EventType = "git.sync.run"
 	EventTypeGitSyncError  EventType = "git.sync.error"
+	EventTypeGitSyncDrift  EventType = "git.sync.drift"
 
 	EventTypeVolumeCreate EventType = "volume.create"
 	EventTypeVolumeDelete EventType = "volume.delete"
@@ -58,11 +75,16 @@ const (
 	EventTypeVolumeBackupRestore      EventType = "volume.backup.restore"
 	EventTypeVolumeBackupRestoreFiles EventType = "volume.backup.restore_files"
 	EventTypeVolumeBackupDownload     EventType = "volume.backup.download"
+	EventTypeVolumeBackupFailed       EventType = "volume.backup.failed"
+	EventTypeVolumeBackupPrune        EventType = "volume.backup.prune"
 
 	EventTypeNetworkCreate EventType = "network.create"
 	EventTypeNetworkDelete EventType = "network.delete"
 	EventTypeNetworkError  EventType = "network.error"
 
+	EventTypeNetworkConnect    EventType = "network.connect"
+	EventTypeNetworkDisconnect EventType = "network.disconnect"
+
 	EventTypeSystemPrune      EventType = "system.prune"
 	EventTypeUserLogin        EventType = "user.login"
 	EventTypeUserLogout       EventType = "user.logout"
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"github.com/getarcaneapp/arcane/types/container"
+)
+
+// ExecSession is an interactive exec session in a container, recorded in asciicast
+// format to RecordingPath.
+type ExecSession struct {
+	EnvironmentID string     `json:"environmentId" gorm:"column:environment_id"`
+	ContainerID   string     `json:"containerId" gorm:"column:container_id"`
+	ContainerName string     `json:"containerName" gorm:"column:container_name" sortable:"true"`
+	Command       string     `json:"command" gorm:"column:command"`
+	UserID        string     `json:"userId" gorm:"column:user_id"`
+	Username      string     `json:"username" gorm:"column:username" sortable:"true"`
+	Status        string     `json:"status" gorm:"column:status" sortable:"true"`
+	StartedAt     time.Time  `json:"startedAt" gorm:"column:started_at" sortable:"true"`
+	EndedAt       *time.Time `json:"endedAt,omitempty" gorm:"column:ended_at" sortable:"true"`
+	ExitCode      *int       `json:"exitCode,omitempty" gorm:"column:exit_code"`
+	RecordingPath string     `json:"-" gorm:"column:recording_path"`
+	RecordingSize int64      `json:"recordingSize" gorm:"column:recording_size"`
+	BaseModel
+}
+
+func (ExecSession) TableName() string {
+	return "exec_sessions"
+}
+
+func (e *ExecSession) ToDTO() container.ExecSession {
+	out := container.ExecSession{
+		ID:            e.ID,
+		EnvironmentID: e.EnvironmentID,
+		ContainerID:   e.ContainerID,
+		ContainerName: e.ContainerName,
+		Command:       e.Command,
+		UserID:        e.UserID,
+		Username:      e.Username,
+		Status:        e.Status,
+		StartedAt:     e.StartedAt.Format(time.RFC3339),
+		ExitCode:      e.ExitCode,
+		RecordingSize: e.RecordingSize,
+	}
+	if e.EndedAt != nil {
+		out.EndedAt = new(e.EndedAt.Format(time.RFC3339))
+	}
+	return out
+}
@@ -2,28 +2,50 @@ package models
 
 import (
 	"time"
+
+	"github.com/getarcaneapp/arcane/types/gitops"
+	"gorm.io/gorm"
 )
 
 type GitOpsSync struct {
-	Name           string         `json:"name" sortable:"true" search:"sync,gitops,automation,deploy,deployment,continuous"`
-	EnvironmentID  string         `json:"environmentId" sortable:"true"`
-	Environment    *Environment   `json:"environment,omitempty" gorm:"foreignKey:EnvironmentID"`
-	RepositoryID   string         `json:"repositoryId" sortable:"true"`
-	Repository     *GitRepository `json:"repository,omitempty" gorm:"foreignKey:RepositoryID"`
-	Branch         string         `json:"branch" sortable:"true" search:"branch,main,master,develop,feature,release"`
-	ComposePath    string         `json:"composePath" sortable:"true" search:"compose,docker-compose,path,file,yaml,yml"`
-	ProjectName    string         `json:"projectName" sortable:"true" search:"project,name,stack,application,service"` // Name of project to create/update
-	ProjectID      *string        `json:"projectId,omitempty" sortable:"true"`                                         // Set after project is created
-	Project        *Project       `json:"project,omitempty" gorm:"foreignKey:ProjectID"`
-	AutoSync       bool           `json:"autoSync" sortable:"true" search:"auto,automatic,sync,continuous,scheduled"`
-	SyncInterval   int            `json:"syncInterval" sortable:"true" search:"interval,frequency,schedule,cron,minutes"` // in minutes
-	LastSyncAt     *time.Time     `json:"lastSyncAt,omitempty" sortable:"true"`
-	LastSyncStatus *string        `json:"lastSyncStatus,omitempty" search:"status,success,failed,pending,error"`
-	LastSyncError  *string        `json:"lastSyncError,omitempty"`
-	LastSyncCommit *string        `json:"lastSyncCommit,omitempty" search:"commit,hash,sha,revision"`
+	Name           string             `json:"name" sortable:"true" search:"sync,gitops,automation,deploy,deployment,continuous"`
+	EnvironmentID  string             `json:"environmentId" sortable:"true"`
+	Environment    *Environment       `json:"environment,omitempty" gorm:"foreignKey:EnvironmentID"`
+	RepositoryID   string             `json:"repositoryId" sortable:"true"`
+	Repository     *GitRepository     `json:"repository,omitempty" gorm:"foreignKey:RepositoryID"`
+	Branch         string             `json:"branch" sortable:"true" search:"branch,main,master,develop,feature,release"`
+	RefType        string             `json:"refType" sortable:"true"`                                      // branch, tag, commit or semver
+	Ref            string             `json:"ref,omitempty" search:"tag,commit,version,semver,pin,release"` // tag, commit hash or semver range
+	ComposePath    string             `json:"composePath" sortable:"true" search:"compose,docker-compose,path,file,yaml,yml"`
+	ProjectName    string             `json:"projectName" sortable:"true" search:"project,name,stack,application,service"` // Name of project to create/update
+	ProjectID      *string            `json:"projectId,omitempty" sortable:"true"`                                         // Set after project is created
+	Project        *Project           `json:"project,omitempty" gorm:"foreignKey:ProjectID"`
+	AutoSync       bool               `json:"autoSync" sortable:"true" search:"auto,automatic,sync,continuous,scheduled"`
+	SyncInterval   int                `json:"syncInterval" sortable:"true" search:"interval,frequency,schedule,cron,minutes"` // in minutes
+	LastSyncAt     *time.Time         `json:"lastSyncAt,omitempty" sortable:"true"`
+	LastSyncStatus *string            `json:"lastSyncStatus,omitempty" search:"status,success,failed,pending,error"`
+	LastSyncError  *string            `json:"lastSyncError,omitempty"`
+	LastSyncCommit *string            `json:"lastSyncCommit,omitempty" search:"commit,hash,sha,revision"`
+	DriftAction    string             `json:"driftAction"` // report, notify or heal
+	DriftStatus    *string            `json:"driftStatus,omitempty" search:"drift,drifted,in_sync"`
+	DriftCheckedAt *time.Time         `json:"driftCheckedAt,omitempty"`
+	DriftError     *string            `json:"driftError,omitempty"`
+	DriftItems     []gitops.DriftItem `json:"driftItems,omitempty" gorm:"serializer:json"`
+	WebhookSecret  *string            `json:"-"` // encrypted
+	// WebhookEnabled reports whether a webhook secret is set.
+	WebhookEnabled bool    `json:"webhookEnabled" gorm:"-"`
+	SopsAgeKey     *string `json:"-"` // encrypted
+	// SopsEnabled reports whether an age key for SOPS-encrypted files is set.
+	SopsEnabled bool `json:"sopsEnabled" gorm:"-"`
 	BaseModel
 }
 
 func (GitOpsSync) TableName() string {
 	return "gitops_syncs"
 }
+
+func (s *GitOpsSync) AfterFind(_ *gorm.DB) error {
+	s.WebhookEnabled = s.WebhookSecret != nil && *s.WebhookSecret != ""
+	s.SopsEnabled = s.SopsAgeKey != nil && *s.SopsAgeKey != ""
+	return nil
+}
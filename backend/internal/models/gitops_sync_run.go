@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// GitOpsSyncRun records a run of a GitOps sync: the commit it deployed, the files that
+// changed since the previous run and the log of the steps it took.
+type GitOpsSyncRun struct {
+	SyncID        string    `json:"syncId" gorm:"column:sync_id"`
+	Status        string    `json:"status" gorm:"column:status" sortable:"true"` // success or failed
+	Message       string    `json:"message" gorm:"column:message"`
+	Error         *string   `json:"error,omitempty" gorm:"column:error"`
+	Ref           string    `json:"ref,omitempty" gorm:"column:ref"` // resolved branch, tag or commit
+	Commit        string    `json:"commit,omitempty" gorm:"column:commit_hash" sortable:"true"`
+	CommitMessage string    `json:"commitMessage,omitempty" gorm:"column:commit_message"`
+	CommitAuthor  string    `json:"commitAuthor,omitempty" gorm:"column:commit_author"`
+	ChangedFiles  []string  `json:"changedFiles" gorm:"column:changed_files;serializer:json"`
+	Output        string    `json:"output" gorm:"column:output"`
+	StartedAt     time.Time `json:"startedAt" gorm:"column:started_at" sortable:"true"`
+	DurationMs    int64     `json:"durationMs" gorm:"column:duration_ms" sortable:"true"`
+	BaseModel
+}
+
+func (GitOpsSyncRun) TableName() string {
+	return "gitops_sync_runs"
+}
@@ -44,6 +44,8 @@ const (
 	NotificationEventContainerUpdate    NotificationEventType = "container_update"
 	NotificationEventVulnerabilityFound NotificationEventType = "vulnerability_found"
 	NotificationEventPruneReport        NotificationEventType = "prune_report"
+	NotificationEventVolumeBackup       NotificationEventType = "volume_backup"
+	NotificationEventGitOpsDrift        NotificationEventType = "gitops_drift"
 )
 
 type EmailTLSMode string
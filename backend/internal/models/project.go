@@ -21,6 +21,9 @@ type Project struct {
 	ServiceCount    int           `json:"service_count" sortable:"true"`
 	RunningCount    int           `json:"running_count" sortable:"true"`
 	GitOpsManagedBy *string       `json:"gitops_managed_by,omitempty" gorm:"column:gitops_managed_by"`
+	// SopsDecrypted is set while the project's files were written by a GitOps sync from
+	// SOPS-decrypted content; no revisions are stored for such projects.
+	SopsDecrypted bool `json:"sops_decrypted" gorm:"column:sops_decrypted;not null;default:false"`
 
 	BaseModel
 }
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/getarcaneapp/arcane/types/project"
+)
+
+// ProjectBackup is a set of backups of all named volumes of a project taken together,
+// with the compose and env files that were active at backup time.
+type ProjectBackup struct {
+	ProjectID      string                 `json:"projectId" gorm:"column:project_id;index"`
+	ProjectName    string                 `json:"projectName" gorm:"column:project_name"`
+	StoppedProject bool                   `json:"stoppedProject" gorm:"column:stopped_project"`
+	ComposeContent string                 `json:"composeContent" gorm:"column:compose_content"`
+	EnvContent     string                 `json:"envContent,omitempty" gorm:"column:env_content"`
+	Volumes        []project.BackupVolume `json:"volumes" gorm:"column:volumes;serializer:json"`
+	BaseModel
+}
+
+func (ProjectBackup) TableName() string {
+	return "project_backups"
+}
+
+func (b *ProjectBackup) ToDTO() project.Backup {
+	volumes := b.Volumes
+	if volumes == nil {
+		volumes = []project.BackupVolume{}
+	}
+	return project.Backup{
+		ID:             b.ID,
+		ProjectID:      b.ProjectID,
+		ProjectName:    b.ProjectName,
+		StoppedProject: b.StoppedProject,
+		ComposeContent: b.ComposeContent,
+		EnvContent:     b.EnvContent,
+		Volumes:        volumes,
+		CreatedAt:      b.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+
+	"github.com/getarcaneapp/arcane/types/project"
+)
+
+// Sources of a project revision.
+const (
+	ProjectRevisionSourceInitial       = "initial"
+	ProjectRevisionSourceCreate        = "create"
+	ProjectRevisionSourceUpdate        = "update"
+	ProjectRevisionSourceInclude       = "include"
+	ProjectRevisionSourceGitOps        = "gitops"
+	ProjectRevisionSourceBackupRestore = "backup_restore"
+	ProjectRevisionSourceRollback      = "rollback"
+)
+
+// ProjectRevision is a snapshot of the compose, env and include files of a project,
+// stored each time they change, with the diff to the previous revision.
+type ProjectRevision struct {
+	ProjectID      string            `json:"projectId" gorm:"column:project_id"`
+	Revision       int               `json:"revision" gorm:"column:revision" sortable:"true"`
+	Source         string            `json:"source" gorm:"column:source"`
+	Message        string            `json:"message,omitempty" gorm:"column:message"`
+	AuthorID       *string           `json:"authorId,omitempty" gorm:"column:author_id"`
+	AuthorName     string            `json:"authorName,omitempty" gorm:"column:author_name"`
+	ComposeContent string            `json:"composeContent" gorm:"column:compose_content"`
+	EnvContent     string            `json:"envContent,omitempty" gorm:"column:env_content"`
+	IncludeFiles   map[string]string `json:"includeFiles,omitempty" gorm:"column:include_files;serializer:json"`
+	Diff           string            `json:"diff,omitempty" gorm:"column:diff"`
+	LinesAdded     int               `json:"linesAdded" gorm:"column:lines_added"`
+	LinesRemoved   int               `json:"linesRemoved" gorm:"column:lines_removed"`
+	BaseModel
+}
+
+func (ProjectRevision) TableName() string {
+	return "project_revisions"
+}
+
+func (r *ProjectRevision) ToSummaryDTO() project.RevisionSummary {
+	return project.RevisionSummary{
+		ID:           r.ID,
+		ProjectID:    r.ProjectID,
+		Revision:     r.Revision,
+		Source:       r.Source,
+		Message:      r.Message,
+		AuthorID:     r.AuthorID,
+		AuthorName:   r.AuthorName,
+		LinesAdded:   r.LinesAdded,
+		LinesRemoved: r.LinesRemoved,
+		CreatedAt:    r.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func (r *ProjectRevision) ToDTO() project.Revision {
+	return project.Revision{
+		RevisionSummary: r.ToSummaryDTO(),
+		ComposeContent:  r.ComposeContent,
+		EnvContent:      r.EnvContent,
+		IncludeFiles:    r.IncludeFiles,
+		Diff:            r.Diff,
+	}
+}
@@ -56,14 +56,19 @@ type Settings struct {
 	AutoUpdate                   SettingVariable `key:"autoUpdate" meta:"label=Auto Update;type=boolean;keywords=auto,update,automatic,upgrade,refresh,restart,deploy;category=internal;description=Automatically update containers when new images are available"`
 	AutoUpdateInterval           SettingVariable `key:"autoUpdateInterval" meta:"label=Auto Update Interval;type=cron;keywords=auto,update,interval,frequency,schedule,automatic,timing;category=internal;description=How often to check for automatic updates (cron expression)"`
 	AutoUpdateExcludedContainers SettingVariable `key:"autoUpdateExcludedContainers" meta:"label=Excluded Containers;type=text;keywords=exclude,containers,ignore,skip;category=internal;description=Comma-separated list of containers to exclude from auto-update"`
+	AutoUpdateHealthTimeout      SettingVariable `key:"autoUpdateHealthTimeout" meta:"label=Auto Update Health Timeout;type=number;keywords=auto,update,health,healthcheck,rollback,grace,timeout,seconds;category=internal;description=Seconds to wait for an updated container to become healthy before rolling back to the previous image (0 disables)"`
 	PollingEnabled               SettingVariable `key:"pollingEnabled" meta:"label=Enable Polling;type=boolean;keywords=polling,check,monitor,watch,scan,detection,automatic;category=internal;description=Enable automatic checking for image updates"`
 	PollingInterval              SettingVariable `key:"pollingInterval" meta:"label=Polling Interval;type=cron;keywords=interval,frequency,schedule,time,minutes,period,delay;category=internal;description=How often to check for image updates (cron expression)"`
-	EventCleanupInterval         SettingVariable `key:"eventCleanupInterval" meta:"label=Event Cleanup Interval;type=cron;keywords=events,cleanup,retention,interval,frequency,schedule,history,logs,jobs;description=How often to delete old events (cron expression)"`
+	EventCleanupInterval         SettingVariable `key:"eventCleanupInterval" meta:"label=Event Cleanup Interval;type=cron;keywords=events,cleanup,retention,interval,frequency,schedule,history,logs,jobs;description=How often to delete old events and GitOps sync runs (cron expression)"`
 	AnalyticsHeartbeatInterval   SettingVariable `key:"analyticsHeartbeatInterval" meta:"label=Analytics Heartbeat Interval;type=cron;keywords=analytics,heartbeat,interval,frequency,schedule,telemetry,jobs;description=How often to send the anonymous analytics heartbeat (cron expression)"`
 	AutoInjectEnv                SettingVariable `key:"autoInjectEnv" meta:"label=Auto Inject Env Variables;type=boolean;keywords=auto,inject,env,environment,variables,interpolation;category=internal;description=Automatically inject project .env variables into all containers (default: false)"`
 	PruneMode                    SettingVariable `key:"dockerPruneMode" meta:"label=Docker Prune Action;type=select;keywords=prune,cleanup,clean,remove,delete,unused,dangling,space,disk;category=internal;description=Configure how unused Docker images are cleaned up"`
 	ScheduledPruneEnabled        SettingVariable `key:"scheduledPruneEnabled" meta:"label=Scheduled Prune Enabled;type=boolean;keywords=prune,cleanup,maintenance,schedule,automatic;category=internal;description=Enable scheduled pruning of unused Docker resources"`
 	ScheduledPruneInterval       SettingVariable `key:"scheduledPruneInterval" meta:"label=Scheduled Prune Interval;type=cron;keywords=prune,cleanup,interval,minutes,schedule;category=internal;description=How often to run scheduled prunes (cron expression)"`
+	ScheduledBackupEnabled       SettingVariable `key:"scheduledBackupEnabled" meta:"label=Scheduled Volume Backups Enabled;type=boolean;keywords=backup,volume,schedule,retention,automatic;category=internal;description=Enable scheduled backups of volumes with a backup policy"`
+	ScheduledBackupInterval      SettingVariable `key:"scheduledBackupInterval" meta:"label=Scheduled Volume Backup Interval;type=cron;keywords=backup,volume,interval,schedule;category=internal;description=How often to run scheduled volume backups (cron expression)"`
+	GitopsDriftEnabled           SettingVariable `key:"gitopsDriftEnabled" meta:"label=GitOps Drift Check Enabled;type=boolean;keywords=gitops,drift,compare,heal,repository;category=internal;description=Enable scheduled checks that deployed GitOps projects still match git"`
+	GitopsDriftInterval          SettingVariable `key:"gitopsDriftInterval" meta:"label=GitOps Drift Check Interval;type=cron;keywords=gitops,drift,interval,schedule;category=internal;description=How often to check GitOps projects for drift (cron expression)"`
 	GitopsSyncInterval           SettingVariable `key:"gitopsSyncInterval" meta:"label=GitOps Sync Interval;type=cron;keywords=gitops,sync,interval,frequency,schedule,repository;category=internal;description=How often to run GitOps synchronization checks (cron expression)"`
 	ScheduledPruneContainers     SettingVariable `key:"scheduledPruneContainers" meta:"label=Scheduled Prune Containers;type=boolean;keywords=prune,containers,cleanup,maintenance;category=internal;description=Remove stopped containers during scheduled prune"`
 	ScheduledPruneImages         SettingVariable `key:"scheduledPruneImages" meta:"label=Scheduled Prune Images;type=boolean;keywords=prune,images,cleanup,maintenance;category=internal;description=Remove unused images during scheduled prune"`
@@ -99,6 +104,8 @@ type Settings struct {
 	OidcScopes                      SettingVariable `key:"oidcScopes,public,envOverride" meta:"label=OIDC Scopes;type=text;keywords=oidc,scopes,oauth,openid,permissions;category=security;description=OIDC scopes to request"`
 	OidcAdminClaim                  SettingVariable `key:"oidcAdminClaim,public,envOverride" meta:"label=OIDC Admin Claim;type=text;keywords=oidc,admin,claim,role,group;category=security;description=Claim name for admin role mapping"`
 	OidcAdminValue                  SettingVariable `key:"oidcAdminValue,public,envOverride" meta:"label=OIDC Admin Value;type=text;keywords=oidc,admin,value,role,group;category=security;description=Claim value that grants admin access"`
+	OidcRoleClaim                   SettingVariable `key:"oidcRoleClaim,public,envOverride" meta:"label=OIDC Role Claim;type=text;keywords=oidc,role,claim,group,rbac,viewer,operator;category=security;description=Claim name (e.g. groups) mapped onto Arcane roles"`
+	OidcRoleMapping                 SettingVariable `key:"oidcRoleMapping,public,envOverride" meta:"label=OIDC Role Mapping;type=text;keywords=oidc,role,mapping,group,rbac,viewer,operator,deployer;category=security;description=Comma-separated claim value=role pairs; users matching none get the viewer role"`
 	OidcSkipTlsVerify               SettingVariable `key:"oidcSkipTlsVerify,public,envOverride" meta:"label=OIDC Skip TLS Verify;type=boolean;keywords=oidc,tls,verify,skip,insecure;category=security;description=Skip TLS verification for OIDC provider"`
 	OidcAutoRedirectToProvider      SettingVariable `key:"oidcAutoRedirectToProvider,public,envOverride" meta:"label=OIDC Auto Redirect;type=boolean;keywords=oidc,auto,redirect,automatic,login,provider,sso;category=security;description=Automatically redirect to OIDC provider on login page"`
 	OidcMergeAccounts               SettingVariable `key:"oidcMergeAccounts,public,envOverride" meta:"label=OIDC Account Merging;type=boolean;keywords=oidc,merge,link,accounts,email,match,existing,users,combine;category=security;description=Allow OIDC logins to merge with existing accounts by email"`
@@ -298,5 +305,10 @@ type OidcConfig struct {
 	AdminClaim string `json:"adminClaim,omitempty"`
 	AdminValue string `json:"adminValue,omitempty"`
 
+	// Role mapping: values of this claim are mapped onto non-admin roles.
+	// Example: roleClaim: "groups", roleMapping: "arcane-ops=operator,arcane-viewers=viewer"
+	RoleClaim   string `json:"roleClaim,omitempty"`
+	RoleMapping string `json:"roleMapping,omitempty"`
+
 	SkipTlsVerify bool `json:"skipTlsVerify"`
 }
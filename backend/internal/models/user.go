@@ -15,6 +15,14 @@ type User struct {
 	Locale                 *string     `json:"locale,omitempty" gorm:"column:locale"`
 	RequiresPasswordChange bool        `json:"requiresPasswordChange" gorm:"column:requires_password_change"`
 
+	// EnvironmentAccess restricts the user to specific environments; nil means all environments.
+	EnvironmentAccess EnvironmentAccess `json:"environmentAccess,omitempty" gorm:"column:environment_access;type:text"`
+
+	// KeyEnvironmentAccess is the restriction of the API key the request authenticated
+	// with; nil for sessions and unrestricted keys. Unlike EnvironmentAccess it also
+	// applies to admins.
+	KeyEnvironmentAccess EnvironmentAccess `json:"-" gorm:"-"`
+
 	// OIDC provider tokens
 	OidcAccessToken          *string    `json:"-" gorm:"type:text"`
 	OidcRefreshToken         *string    `json:"-" gorm:"type:text"`
@@ -4,12 +4,18 @@ import (
 	"time"
 
 	"github.com/getarcaneapp/arcane/types/volume"
+	"gorm.io/gorm"
 )
 
 type VolumeBackup struct {
 	BaseModel
 	VolumeName string    `json:"volumeName" gorm:"column:volume_name;index"`
 	Size       int64     `json:"size" gorm:"column:size"`
+	Scheduled  bool      `json:"scheduled" gorm:"column:scheduled"`
+	TargetID   *string   `json:"targetId,omitempty" gorm:"column:target_id;index"`
+	Checksum   string    `json:"checksum,omitempty" gorm:"column:checksum"`      // SHA-256 of the stored archive
+	Encryption string    `json:"encryption,omitempty" gorm:"column:encryption"`  // "", instance, passphrase
+	HookOutput string    `json:"hookOutput,omitempty" gorm:"column:hook_output"` // output of the pre/post-backup hooks
 	CreatedAt  time.Time `json:"createdAt" gorm:"column:created_at"`
 }
 
@@ -22,6 +28,45 @@ func (b *VolumeBackup) ToDTO() volume.BackupEntry {
 		ID:         b.ID,
 		VolumeName: b.VolumeName,
 		Size:       b.Size,
+		Scheduled:  b.Scheduled,
+		TargetID:   b.TargetID,
+		Checksum:   b.Checksum,
+		Encryption: b.Encryption,
+		HookOutput: b.HookOutput,
 		CreatedAt:  b.CreatedAt.Format(time.RFC3339),
 	}
 }
+
+// VolumeBackupPolicy selects a volume for scheduled backups, the target they are
+// written to, whether they are encrypted and how many scheduled backups are retained.
+// Retention counts of zero are ignored; when all of them are zero, scheduled backups
+// are never pruned.
+type VolumeBackupPolicy struct {
+	BaseModel
+	VolumeName string  `json:"volumeName" gorm:"column:volume_name;uniqueIndex"`
+	Enabled    bool    `json:"enabled" gorm:"column:enabled"`
+	KeepLast   int     `json:"keepLast" gorm:"column:keep_last"`
+	KeepDaily  int     `json:"keepDaily" gorm:"column:keep_daily"`
+	KeepWeekly int     `json:"keepWeekly" gorm:"column:keep_weekly"`
+	TargetID   *string `json:"targetId,omitempty" gorm:"column:target_id"`
+	Encrypt    bool    `json:"encrypt" gorm:"column:encrypt"`
+	Passphrase string  `json:"-" gorm:"column:passphrase"` // encrypted
+	// HasPassphrase reports whether scheduled backups use a stored passphrase rather than the instance key.
+	HasPassphrase bool       `json:"hasPassphrase" gorm:"-"`
+	LastRunAt     *time.Time `json:"lastRunAt,omitempty" gorm:"column:last_run_at"`
+	LastError     *string    `json:"lastError,omitempty" gorm:"column:last_error"`
+}
+
+func (*VolumeBackupPolicy) TableName() string {
+	return "volume_backup_policies"
+}
+
+func (p *VolumeBackupPolicy) AfterFind(_ *gorm.DB) error {
+	p.HasPassphrase = p.Passphrase != ""
+	return nil
+}
+
+// HasRetention reports whether any retention rule is configured.
+func (p *VolumeBackupPolicy) HasRetention() bool {
+	return p.KeepLast > 0 || p.KeepDaily > 0 || p.KeepWeekly > 0
+}
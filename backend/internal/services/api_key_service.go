@@ -42,6 +42,21 @@ func NewApiKeyService(db *database.DB, userService *UserService) *ApiKeyService
 	}
 }
 
+func toApiKeyDto(ak *models.ApiKey) apikey.ApiKey {
+	return apikey.ApiKey{
+		ID:                ak.ID,
+		Name:              ak.Name,
+		Description:       ak.Description,
+		KeyPrefix:         ak.KeyPrefix,
+		UserID:            ak.UserID,
+		EnvironmentAccess: ak.EnvironmentAccess.ToMap(),
+		ExpiresAt:         ak.ExpiresAt,
+		LastUsedAt:        ak.LastUsedAt,
+		CreatedAt:         ak.CreatedAt,
+		UpdatedAt:         ak.UpdatedAt,
+	}
+}
+
 func (s *ApiKeyService) generateApiKey() (string, error) {
 	bytes := make([]byte, apiKeyLength)
 	if _, err := rand.Read(bytes); err != nil {
@@ -71,13 +86,19 @@ func (s *ApiKeyService) CreateApiKey(ctx context.Context, userID string, req api
 
 	keyPrefix := rawKey[:len(apiKeyPrefix)+apiKeyPrefixLen]
 
+	access, err := models.ParseEnvironmentAccess(req.EnvironmentAccess)
+	if err != nil {
+		return nil, err
+	}
+
 	ak := &models.ApiKey{
-		Name:        req.Name,
-		Description: req.Description,
-		KeyHash:     keyHash,
-		KeyPrefix:   keyPrefix,
-		UserID:      userID,
-		ExpiresAt:   req.ExpiresAt,
+		Name:              req.Name,
+		Description:       req.Description,
+		KeyHash:           keyHash,
+		KeyPrefix:         keyPrefix,
+		UserID:            userID,
+		ExpiresAt:         req.ExpiresAt,
+		EnvironmentAccess: access,
 	}
 
 	if err := s.db.WithContext(ctx).Create(ak).Error; err != nil {
@@ -85,18 +106,8 @@ func (s *ApiKeyService) CreateApiKey(ctx context.Context, userID string, req api
 	}
 
 	return &apikey.ApiKeyCreatedDto{
-		ApiKey: apikey.ApiKey{
-			ID:          ak.ID,
-			Name:        ak.Name,
-			Description: ak.Description,
-			KeyPrefix:   ak.KeyPrefix,
-			UserID:      ak.UserID,
-			ExpiresAt:   ak.ExpiresAt,
-			LastUsedAt:  ak.LastUsedAt,
-			CreatedAt:   ak.CreatedAt,
-			UpdatedAt:   ak.UpdatedAt,
-		},
-		Key: rawKey,
+		ApiKey: toApiKeyDto(ak),
+		Key:    rawKey,
 	}, nil
 }
 
@@ -134,18 +145,8 @@ func (s *ApiKeyService) CreateEnvironmentApiKey(ctx context.Context, environment
 	}
 
 	return &apikey.ApiKeyCreatedDto{
-		ApiKey: apikey.ApiKey{
-			ID:          ak.ID,
-			Name:        ak.Name,
-			Description: ak.Description,
-			KeyPrefix:   ak.KeyPrefix,
-			UserID:      ak.UserID,
-			ExpiresAt:   ak.ExpiresAt,
-			LastUsedAt:  ak.LastUsedAt,
-			CreatedAt:   ak.CreatedAt,
-			UpdatedAt:   ak.UpdatedAt,
-		},
-		Key: rawKey,
+		ApiKey: toApiKeyDto(ak),
+		Key:    rawKey,
 	}, nil
 }
 
@@ -158,17 +159,8 @@ func (s *ApiKeyService) GetApiKey(ctx context.Context, id string) (*apikey.ApiKe
 		return nil, fmt.Errorf("failed to get API key: %w", err)
 	}
 
-	return &apikey.ApiKey{
-		ID:          ak.ID,
-		Name:        ak.Name,
-		Description: ak.Description,
-		KeyPrefix:   ak.KeyPrefix,
-		UserID:      ak.UserID,
-		ExpiresAt:   ak.ExpiresAt,
-		LastUsedAt:  ak.LastUsedAt,
-		CreatedAt:   ak.CreatedAt,
-		UpdatedAt:   ak.UpdatedAt,
-	}, nil
+	dto := toApiKeyDto(&ak)
+	return &dto, nil
 }
 
 func (s *ApiKeyService) ListApiKeys(ctx context.Context, params pagination.QueryParams) ([]apikey.ApiKey, pagination.Response, error) {
@@ -190,17 +182,7 @@ func (s *ApiKeyService) ListApiKeys(ctx context.Context, params pagination.Query
 
 	result := make([]apikey.ApiKey, len(apiKeys))
 	for i, ak := range apiKeys {
-		result[i] = apikey.ApiKey{
-			ID:          ak.ID,
-			Name:        ak.Name,
-			Description: ak.Description,
-			KeyPrefix:   ak.KeyPrefix,
-			UserID:      ak.UserID,
-			ExpiresAt:   ak.ExpiresAt,
-			LastUsedAt:  ak.LastUsedAt,
-			CreatedAt:   ak.CreatedAt,
-			UpdatedAt:   ak.UpdatedAt,
-		}
+		result[i] = toApiKeyDto(&ak)
 	}
 
 	return result, paginationResp, nil
@@ -224,22 +206,20 @@ func (s *ApiKeyService) UpdateApiKey(ctx context.Context, id string, req apikey.
 	if req.ExpiresAt != nil {
 		ak.ExpiresAt = req.ExpiresAt
 	}
+	if req.EnvironmentAccess != nil {
+		access, err := models.ParseEnvironmentAccess(req.EnvironmentAccess)
+		if err != nil {
+			return nil, err
+		}
+		ak.EnvironmentAccess = access
+	}
 
 	if err := s.db.WithContext(ctx).Save(&ak).Error; err != nil {
 		return nil, fmt.Errorf("failed to update API key: %w", err)
 	}
 
-	return &apikey.ApiKey{
-		ID:          ak.ID,
-		Name:        ak.Name,
-		Description: ak.Description,
-		KeyPrefix:   ak.KeyPrefix,
-		UserID:      ak.UserID,
-		ExpiresAt:   ak.ExpiresAt,
-		LastUsedAt:  ak.LastUsedAt,
-		CreatedAt:   ak.CreatedAt,
-		UpdatedAt:   ak.UpdatedAt,
-	}, nil
+	dto := toApiKeyDto(&ak)
+	return &dto, nil
 }
 
 func (s *ApiKeyService) DeleteApiKey(ctx context.Context, id string) error {
@@ -283,6 +263,10 @@ func (s *ApiKeyService) ValidateApiKey(ctx context.Context, rawKey string) (*mod
 				return nil, fmt.Errorf("failed to get user for API key: %w", err)
 			}
 
+			// A key can only narrow its owner's environment access, never widen it. The
+			// restriction is kept apart from the owner's grant so it also binds admins.
+			user.KeyEnvironmentAccess = apiKey.EnvironmentAccess
+
 			return user, nil
 		}
 	}
@@ -113,6 +113,12 @@ func (s *AppriseService) SendNotification(ctx context.Context, title, body, form
 
 	case models.NotificationEventVulnerabilityFound:
 		// No dedicated tag in AppriseSettings; notification is sent without a tag
+
+	case models.NotificationEventVolumeBackup:
+		// No dedicated tag in AppriseSettings; notification is sent without a tag
+
+	case models.NotificationEventGitOpsDrift:
+		// No dedicated tag in AppriseSettings; notification is sent without a tag
 	}
 
 	payload := AppriseNotificationPayload{
@@ -12,6 +12,7 @@ import (
 	"github.com/getarcaneapp/arcane/backend/internal/config"
 	"github.com/getarcaneapp/arcane/backend/internal/models"
 	"github.com/getarcaneapp/arcane/backend/internal/utils/crypto"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/rbac"
 	"github.com/getarcaneapp/arcane/types/auth"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
@@ -97,6 +98,8 @@ func (s *AuthService) getAuthSettings(ctx context.Context) (*AuthSettings, error
 			Scopes:                      settings.OidcScopes.Value,
 			AdminClaim:                  settings.OidcAdminClaim.Value,
 			AdminValue:                  settings.OidcAdminValue.Value,
+			RoleClaim:                   settings.OidcRoleClaim.Value,
+			RoleMapping:                 settings.OidcRoleMapping.Value,
 			SkipTlsVerify:               settings.OidcSkipTlsVerify.IsTrue(),
 		}
 
@@ -379,10 +382,7 @@ func (s *AuthService) createOidcUser(ctx context.Context, userInfo auth.OidcUser
 		username = userInfo.PreferredUsername
 	}
 
-	roles := models.StringSlice{"user"}
-	if s.isAdminFromOidc(ctx, userInfo, tokenResp) {
-		roles = append(roles, "admin")
-	}
+	roles := s.syncOidcRoles(ctx, models.StringSlice{rbac.RoleUser}, userInfo, tokenResp)
 
 	var displayName *string
 	switch {
@@ -420,14 +420,7 @@ func (s *AuthService) updateOidcUser(ctx context.Context, user *models.User, use
 		user.Email = new(userInfo.Email)
 	}
 
-	wantAdmin := s.isAdminFromOidc(ctx, userInfo, tokenResp)
-	hasAdmin := hasRole(user.Roles, "admin")
-	switch {
-	case wantAdmin && !hasAdmin:
-		user.Roles = addRole(user.Roles, "admin")
-	case !wantAdmin && hasAdmin:
-		user.Roles = removeRole(user.Roles, "admin")
-	}
+	user.Roles = s.syncOidcRoles(ctx, user.Roles, userInfo, tokenResp)
 
 	s.persistOidcTokens(user, tokenResp)
 
@@ -444,15 +437,8 @@ func (s *AuthService) mergeOidcWithExistingUser(ctx context.Context, user *model
 			u.DisplayName = new(userInfo.Name)
 		}
 
-		// Update admin role based on OIDC claims
-		wantAdmin := s.isAdminFromOidc(ctx, userInfo, tokenResp)
-		hasAdmin := hasRole(u.Roles, "admin")
-		switch {
-		case wantAdmin && !hasAdmin:
-			u.Roles = addRole(u.Roles, "admin")
-		case !wantAdmin && hasAdmin:
-			u.Roles = removeRole(u.Roles, "admin")
-		}
+		// Update roles based on OIDC claims
+		u.Roles = s.syncOidcRoles(ctx, u.Roles, userInfo, tokenResp)
 
 		// Persist OIDC tokens
 		s.persistOidcTokens(u, tokenResp)
@@ -488,6 +474,83 @@ func removeRole(roles models.StringSlice, role string) models.StringSlice {
 	return out
 }
 
+// syncOidcRoles applies the OIDC claim mappings to roles. The admin role follows the admin
+// claim. When a role claim is configured, all other roles are replaced by the mapped ones and
+// users matching no mapping fall back to viewer; otherwise they are left as assigned in Arcane.
+func (s *AuthService) syncOidcRoles(ctx context.Context, roles models.StringSlice, userInfo auth.OidcUserInfo, tokenResp *auth.OidcTokenResponse) models.StringSlice {
+	if mapped, ok := s.rolesFromOidc(ctx, userInfo, tokenResp); ok {
+		next := make(models.StringSlice, 0, len(mapped)+1)
+		if hasRole(roles, rbac.RoleAdmin) {
+			next = append(next, rbac.RoleAdmin)
+		}
+		if len(mapped) == 0 {
+			mapped = []string{rbac.RoleViewer}
+		}
+		for _, r := range mapped {
+			next = addRole(next, r)
+		}
+		roles = next
+	}
+
+	wantAdmin := s.isAdminFromOidc(ctx, userInfo, tokenResp)
+	hasAdmin := hasRole(roles, rbac.RoleAdmin)
+	switch {
+	case wantAdmin && !hasAdmin:
+		roles = addRole(roles, rbac.RoleAdmin)
+	case !wantAdmin && hasAdmin:
+		roles = removeRole(roles, rbac.RoleAdmin)
+	}
+	return roles
+}
+
+// rolesFromOidc returns the non-admin roles granted by the configured role claim mapping.
+// ok is false when no role claim is configured.
+func (s *AuthService) rolesFromOidc(ctx context.Context, userInfo auth.OidcUserInfo, tokenResp *auth.OidcTokenResponse) (roles []string, ok bool) {
+	as, err := s.getAuthSettings(ctx)
+	if err != nil || as.Oidc == nil {
+		return nil, false
+	}
+	claimKey := strings.TrimSpace(as.Oidc.RoleClaim)
+	if claimKey == "" {
+		return nil, false
+	}
+	mapping := rbac.ParseRoleMapping(as.Oidc.RoleMapping)
+
+	var values []string
+	if v, found := crypto.GetByPath(userInfo.Extra, claimKey); found {
+		values = append(values, claimStrings(v)...)
+	}
+	if tokenResp != nil && tokenResp.IDToken != "" {
+		if claims := crypto.ParseJWTClaims(tokenResp.IDToken); claims != nil {
+			if v, found := crypto.GetByPath(claims, claimKey); found {
+				values = append(values, claimStrings(v)...)
+			}
+		}
+	}
+
+	return rbac.MapClaimValues(values, mapping), true
+}
+
+// claimStrings flattens a string or string array claim into its values.
+func claimStrings(v any) []string {
+	switch x := v.(type) {
+	case string:
+		return []string{x}
+	case []string:
+		return x
+	case []any:
+		out := make([]string, 0, len(x))
+		for _, it := range x {
+			if str, ok := it.(string); ok {
+				out = append(out, str)
+			}
+		}
+		return out
+	default:
+		return nil
+	}
+}
+
 func (s *AuthService) isAdminFromOidc(ctx context.Context, userInfo auth.OidcUserInfo, tokenResp *auth.OidcTokenResponse) bool {
 	claimKey, values := s.getAdminClaimConfig(ctx)
 	if claimKey == "" {
@@ -411,3 +411,69 @@ func TestFindOrCreateOidcUser_MergeEnabled_EmailVerificationMissing_WithExisting
 	require.NotNil(t, fetched.OidcSubjectId)
 	require.Equal(t, userInfo.Subject, *fetched.OidcSubjectId)
 }
+
+func TestFindOrCreateOidcUser_RoleClaimMapping(t *testing.T) {
+	ctx := context.Background()
+	db := setupAuthServiceTestDB(t)
+
+	settingsSvc, err := NewSettingsService(ctx, db)
+	require.NoError(t, err)
+	require.NoError(t, settingsSvc.EnsureDefaultSettings(ctx))
+	require.NoError(t, settingsSvc.SetBoolSetting(ctx, "oidcEnabled", true))
+	require.NoError(t, settingsSvc.SetStringSetting(ctx, "oidcClientId", "arcane"))
+	require.NoError(t, settingsSvc.SetStringSetting(ctx, "oidcRoleClaim", "groups"))
+	require.NoError(t, settingsSvc.SetStringSetting(ctx, "oidcRoleMapping", "arcane-ops=operator,arcane-deploy=project-deployer"))
+
+	userSvc := NewUserService(db)
+	authSvc := newTestAuthService("")
+	authSvc.userService = userSvc
+	authSvc.settingsService = settingsSvc
+
+	userInfo := auth.OidcUserInfo{
+		Subject: "sub-roles",
+		Email:   "ops@example.com",
+		Extra:   map[string]any{"groups": []any{"arcane-ops", "everyone"}},
+	}
+
+	created, isNew, err := authSvc.findOrCreateOidcUser(ctx, userInfo, nil)
+	require.NoError(t, err)
+	require.True(t, isNew)
+	require.Equal(t, models.StringSlice{"operator"}, created.Roles)
+
+	// Group membership changes are applied on the next login.
+	userInfo.Extra = map[string]any{"groups": []any{"arcane-deploy"}}
+	updated, isNew, err := authSvc.findOrCreateOidcUser(ctx, userInfo, nil)
+	require.NoError(t, err)
+	require.False(t, isNew)
+	require.Equal(t, models.StringSlice{"project-deployer"}, updated.Roles)
+
+	// Users matching no mapping fall back to viewer.
+	userInfo.Extra = map[string]any{"groups": []any{"everyone"}}
+	updated, _, err = authSvc.findOrCreateOidcUser(ctx, userInfo, nil)
+	require.NoError(t, err)
+	require.Equal(t, models.StringSlice{"viewer"}, updated.Roles)
+}
+
+func TestSyncOidcRoles_WithoutRoleClaimKeepsAssignedRoles(t *testing.T) {
+	ctx := context.Background()
+	db := setupAuthServiceTestDB(t)
+
+	settingsSvc, err := NewSettingsService(ctx, db)
+	require.NoError(t, err)
+	require.NoError(t, settingsSvc.EnsureDefaultSettings(ctx))
+	require.NoError(t, settingsSvc.SetBoolSetting(ctx, "oidcEnabled", true))
+	require.NoError(t, settingsSvc.SetStringSetting(ctx, "oidcClientId", "arcane"))
+	require.NoError(t, settingsSvc.SetStringSetting(ctx, "oidcAdminClaim", "groups"))
+	require.NoError(t, settingsSvc.SetStringSetting(ctx, "oidcAdminValue", "admins"))
+
+	authSvc := newTestAuthService("")
+	authSvc.settingsService = settingsSvc
+
+	userInfo := auth.OidcUserInfo{Extra: map[string]any{"groups": []any{"admins"}}}
+	roles := authSvc.syncOidcRoles(ctx, models.StringSlice{"operator"}, userInfo, nil)
+	require.Equal(t, models.StringSlice{"operator", "admin"}, roles)
+
+	userInfo.Extra = map[string]any{"groups": []any{}}
+	roles = authSvc.syncOidcRoles(ctx, roles, userInfo, nil)
+	require.Equal(t, models.StringSlice{"operator"}, roles)
+}
@@ -0,0 +1,293 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/getarcaneapp/arcane/backend/internal/database"
+	"github.com/getarcaneapp/arcane/backend/internal/models"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/backuptarget"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/crypto"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/git"
+	"github.com/getarcaneapp/arcane/types/volume"
+	"gorm.io/gorm"
+)
+
+// ErrBackupTargetNotFound is returned when a backup target ID does not exist.
+var ErrBackupTargetNotFound = errors.New("backup target not found")
+
+// BackupTargetService manages the off-host locations volume backups can be stored in.
+// Credentials are encrypted at rest and never leave the service except to open a target.
+type BackupTargetService struct {
+	db *database.DB
+}
+
+func NewBackupTargetService(db *database.DB) *BackupTargetService {
+	return &BackupTargetService{db: db}
+}
+
+func (s *BackupTargetService) ListTargets(ctx context.Context) ([]models.BackupTarget, error) {
+	var targets []models.BackupTarget
+	if err := s.db.WithContext(ctx).Order("name ASC").Find(&targets).Error; err != nil {
+		return nil, fmt.Errorf("failed to list backup targets: %w", err)
+	}
+	return targets, nil
+}
+
+func (s *BackupTargetService) GetTarget(ctx context.Context, id string) (*models.BackupTarget, error) {
+	var target models.BackupTarget
+	if err := s.db.WithContext(ctx).Where("id = ?", id).First(&target).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBackupTargetNotFound
+		}
+		return nil, fmt.Errorf("failed to get backup target: %w", err)
+	}
+	return &target, nil
+}
+
+func (s *BackupTargetService) CreateTarget(ctx context.Context, req volume.CreateBackupTarget) (*models.BackupTarget, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("backup target name is required")
+	}
+	if !backuptarget.IsValidType(req.Type) {
+		return nil, fmt.Errorf("unsupported backup target type %q", req.Type)
+	}
+
+	target := models.BackupTarget{Name: name, Type: req.Type}
+	if err := setBackupTargetConfigInternal(&target, req.Config, nil); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.WithContext(ctx).Create(&target).Error; err != nil {
+		return nil, fmt.Errorf("failed to create backup target: %w", err)
+	}
+	return &target, nil
+}
+
+func (s *BackupTargetService) UpdateTarget(ctx context.Context, id string, req volume.UpdateBackupTarget) (*models.BackupTarget, error) {
+	target, err := s.GetTarget(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, fmt.Errorf("backup target name is required")
+		}
+		target.Name = name
+	}
+
+	if req.Config != nil {
+		existing, err := decryptBackupTargetCredentialsInternal(target.Credentials)
+		if err != nil {
+			return nil, err
+		}
+		if err := setBackupTargetConfigInternal(target, req.Config, existing); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.db.WithContext(ctx).Save(target).Error; err != nil {
+		return nil, fmt.Errorf("failed to update backup target: %w", err)
+	}
+	return target, nil
+}
+
+func (s *BackupTargetService) DeleteTarget(ctx context.Context, id string) error {
+	if _, err := s.GetTarget(ctx, id); err != nil {
+		return err
+	}
+
+	var backups int64
+	if err := s.db.WithContext(ctx).Model(&models.VolumeBackup{}).Where("target_id = ?", id).Count(&backups).Error; err != nil {
+		return fmt.Errorf("failed to check backup target usage: %w", err)
+	}
+	if backups > 0 {
+		return fmt.Errorf("backup target still holds %d backup(s)", backups)
+	}
+
+	var policies int64
+	if err := s.db.WithContext(ctx).Model(&models.VolumeBackupPolicy{}).Where("target_id = ?", id).Count(&policies).Error; err != nil {
+		return fmt.Errorf("failed to check backup target usage: %w", err)
+	}
+	if policies > 0 {
+		return fmt.Errorf("backup target is used by %d backup policy(ies)", policies)
+	}
+
+	if err := s.db.WithContext(ctx).Where("id = ?", id).Delete(&models.BackupTarget{}).Error; err != nil {
+		return fmt.Errorf("failed to delete backup target: %w", err)
+	}
+	return nil
+}
+
+// TestTarget connects to the target and lists its root.
+func (s *BackupTargetService) TestTarget(ctx context.Context, id string) error {
+	target, err := s.OpenTarget(ctx, id)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = target.Close() }()
+
+	if _, err := target.List(ctx, ""); err != nil {
+		return fmt.Errorf("failed to list backup target: %w", err)
+	}
+	return nil
+}
+
+// OpenTarget connects to a stored backup target. Callers must Close the returned target.
+func (s *BackupTargetService) OpenTarget(ctx context.Context, id string) (backuptarget.Target, error) {
+	target, err := s.GetTarget(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets, err := decryptBackupTargetCredentialsInternal(target.Credentials)
+	if err != nil {
+		return nil, err
+	}
+	raw := make(map[string]any, len(target.Config)+len(secrets))
+	for k, v := range target.Config {
+		raw[k] = v
+	}
+	for k, v := range secrets {
+		raw[k] = v
+	}
+
+	switch target.Type {
+	case backuptarget.TypeS3:
+		var cfg backuptarget.S3Config
+		if err := decodeBackupTargetConfigInternal(raw, &cfg); err != nil {
+			return nil, err
+		}
+		return backuptarget.NewS3(cfg, nil)
+	case backuptarget.TypeSFTP:
+		var cfg backuptarget.SFTPConfig
+		if err := decodeBackupTargetConfigInternal(raw, &cfg); err != nil {
+			return nil, err
+		}
+		hostKeyCallback, err := git.SSHHostKeyCallback(cfg.HostKeyVerification)
+		if err != nil {
+			return nil, fmt.Errorf("failed to set up host key verification: %w", err)
+		}
+		return backuptarget.NewSFTP(ctx, cfg, hostKeyCallback)
+	case backuptarget.TypePath:
+		var cfg backuptarget.PathConfig
+		if err := decodeBackupTargetConfigInternal(raw, &cfg); err != nil {
+			return nil, err
+		}
+		return backuptarget.NewPath(cfg)
+	default:
+		return nil, fmt.Errorf("unsupported backup target type %q", target.Type)
+	}
+}
+
+// setBackupTargetConfigInternal validates raw against the target's type and stores it,
+// keeping credentials separate and encrypted. Credentials missing from raw fall back to
+// existing so clients can update a target without re-sending its secrets.
+func setBackupTargetConfigInternal(target *models.BackupTarget, raw map[string]any, existing map[string]string) error {
+	merged := make(map[string]any, len(raw))
+	for k, v := range raw {
+		merged[k] = v
+	}
+	for k, v := range existing {
+		if s, _ := merged[k].(string); s == "" {
+			merged[k] = v
+		}
+	}
+
+	var normalized any
+	switch target.Type {
+	case backuptarget.TypeS3:
+		var cfg backuptarget.S3Config
+		if err := decodeBackupTargetConfigInternal(merged, &cfg); err != nil {
+			return err
+		}
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+		normalized = cfg
+	case backuptarget.TypeSFTP:
+		var cfg backuptarget.SFTPConfig
+		if err := decodeBackupTargetConfigInternal(merged, &cfg); err != nil {
+			return err
+		}
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+		normalized = cfg
+	case backuptarget.TypePath:
+		var cfg backuptarget.PathConfig
+		if err := decodeBackupTargetConfigInternal(merged, &cfg); err != nil {
+			return err
+		}
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+		normalized = cfg
+	default:
+		return fmt.Errorf("unsupported backup target type %q", target.Type)
+	}
+
+	data, err := json.Marshal(normalized)
+	if err != nil {
+		return fmt.Errorf("failed to encode backup target config: %w", err)
+	}
+	config := models.JSON{}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return fmt.Errorf("failed to encode backup target config: %w", err)
+	}
+
+	secrets := map[string]string{}
+	for _, key := range backuptarget.SecretKeys(target.Type) {
+		if v, _ := config[key].(string); v != "" {
+			secrets[key] = v
+		}
+		delete(config, key)
+	}
+
+	target.Config = config
+	target.Credentials = ""
+	if len(secrets) > 0 {
+		plain, err := json.Marshal(secrets)
+		if err != nil {
+			return fmt.Errorf("failed to encode backup target credentials: %w", err)
+		}
+		encrypted, err := crypto.Encrypt(string(plain))
+		if err != nil {
+			return fmt.Errorf("failed to encrypt backup target credentials: %w", err)
+		}
+		target.Credentials = encrypted
+	}
+	return nil
+}
+
+func decryptBackupTargetCredentialsInternal(encrypted string) (map[string]string, error) {
+	if encrypted == "" {
+		return nil, nil
+	}
+	plain, err := crypto.Decrypt(encrypted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt backup target credentials: %w", err)
+	}
+	var secrets map[string]string
+	if err := json.Unmarshal([]byte(plain), &secrets); err != nil {
+		return nil, fmt.Errorf("failed to decode backup target credentials: %w", err)
+	}
+	return secrets, nil
+}
+
+func decodeBackupTargetConfigInternal(raw map[string]any, out any) error {
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return fmt.Errorf("invalid backup target config: %w", err)
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("invalid backup target config: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+
+	glsqlite "github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/getarcaneapp/arcane/backend/internal/config"
+	"github.com/getarcaneapp/arcane/backend/internal/database"
+	"github.com/getarcaneapp/arcane/backend/internal/models"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/crypto"
+	"github.com/getarcaneapp/arcane/types/volume"
+)
+
+func setupBackupTargetTestDB(t *testing.T) *database.DB {
+	t.Helper()
+	db, err := gorm.Open(glsqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.BackupTarget{}, &models.VolumeBackup{}, &models.VolumeBackupPolicy{}))
+
+	crypto.InitEncryption(&config.Config{
+		EncryptionKey: "test-encryption-key-for-testing-32bytes-min",
+		Environment:   "test",
+	})
+
+	return &database.DB{DB: db}
+}
+
+func TestBackupTargetService_CredentialsAreEncryptedAndHidden(t *testing.T) {
+	ctx := context.Background()
+	svc := NewBackupTargetService(setupBackupTargetTestDB(t))
+
+	target, err := svc.CreateTarget(ctx, volume.CreateBackupTarget{
+		Name: "minio",
+		Type: "s3",
+		Config: map[string]any{
+			"endpoint":        "http://minio:9000",
+			"bucket":          "backups",
+			"accessKeyId":     "AKIDEXAMPLE",
+			"secretAccessKey": "super-secret",
+			"pathStyle":       true,
+		},
+	})
+	require.NoError(t, err)
+
+	assert.NotContains(t, target.Config, "secretAccessKey")
+	assert.NotEmpty(t, target.Credentials)
+	assert.NotContains(t, target.Credentials, "super-secret")
+
+	dto := target.ToDTO()
+	assert.True(t, dto.HasCredentials)
+	body, err := json.Marshal(dto)
+	require.NoError(t, err)
+	assert.NotContains(t, string(body), "super-secret")
+	assert.Equal(t, "backups", dto.Config["bucket"])
+
+	secrets, err := decryptBackupTargetCredentialsInternal(target.Credentials)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"secretAccessKey": "super-secret"}, secrets)
+}
+
+func TestBackupTargetService_UpdateKeepsOmittedCredentials(t *testing.T) {
+	ctx := context.Background()
+	svc := NewBackupTargetService(setupBackupTargetTestDB(t))
+
+	created, err := svc.CreateTarget(ctx, volume.CreateBackupTarget{
+		Name: "nas",
+		Type: "sftp",
+		Config: map[string]any{
+			"host":     "nas.local",
+			"username": "backup",
+			"password": "hunter2",
+			"path":     "/srv/backups",
+		},
+	})
+	require.NoError(t, err)
+
+	updated, err := svc.UpdateTarget(ctx, created.ID, volume.UpdateBackupTarget{
+		Config: map[string]any{
+			"host":     "nas.local",
+			"port":     2222,
+			"username": "backup",
+			"path":     "/srv/arcane",
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "/srv/arcane", updated.Config["path"])
+	assert.InDelta(t, 2222, updated.Config["port"], 0)
+	secrets, err := decryptBackupTargetCredentialsInternal(updated.Credentials)
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", secrets["password"])
+}
+
+func TestBackupTargetService_RejectsInvalidConfig(t *testing.T) {
+	ctx := context.Background()
+	svc := NewBackupTargetService(setupBackupTargetTestDB(t))
+
+	_, err := svc.CreateTarget(ctx, volume.CreateBackupTarget{Name: "bad", Type: "path", Config: map[string]any{"path": "relative/dir"}})
+	require.Error(t, err)
+
+	_, err = svc.CreateTarget(ctx, volume.CreateBackupTarget{Name: "bad", Type: "ftp", Config: map[string]any{}})
+	require.Error(t, err)
+
+	_, err = svc.CreateTarget(ctx, volume.CreateBackupTarget{Name: "bad", Type: "sftp", Config: map[string]any{"host": "nas", "username": "backup"}})
+	require.Error(t, err)
+}
+
+func TestBackupTargetService_OpenPathTarget(t *testing.T) {
+	ctx := context.Background()
+	svc := NewBackupTargetService(setupBackupTargetTestDB(t))
+
+	created, err := svc.CreateTarget(ctx, volume.CreateBackupTarget{Name: "disk", Type: "path", Config: map[string]any{"path": t.TempDir()}})
+	require.NoError(t, err)
+
+	target, err := svc.OpenTarget(ctx, created.ID)
+	require.NoError(t, err)
+	defer func() { _ = target.Close() }()
+
+	data := []byte("archive")
+	require.NoError(t, target.Put(ctx, "data/backup.tar.gz", bytes.NewReader(data), int64(len(data))))
+	rc, size, err := target.Get(ctx, "data/backup.tar.gz")
+	require.NoError(t, err)
+	defer func() { _ = rc.Close() }()
+	got, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	assert.Equal(t, data, got)
+	assert.Equal(t, int64(len(data)), size)
+
+	require.NoError(t, svc.TestTarget(ctx, created.ID))
+}
+
+func TestBackupTargetService_DeleteRefusesTargetsInUse(t *testing.T) {
+	ctx := context.Background()
+	db := setupBackupTargetTestDB(t)
+	svc := NewBackupTargetService(db)
+
+	created, err := svc.CreateTarget(ctx, volume.CreateBackupTarget{Name: "disk", Type: "path", Config: map[string]any{"path": t.TempDir()}})
+	require.NoError(t, err)
+
+	backup := models.VolumeBackup{BaseModel: models.BaseModel{ID: "data-1"}, VolumeName: "data", TargetID: new(created.ID)}
+	require.NoError(t, db.Create(&backup).Error)
+	require.Error(t, svc.DeleteTarget(ctx, created.ID))
+
+	require.NoError(t, db.Delete(&backup).Error)
+	require.NoError(t, svc.DeleteTarget(ctx, created.ID))
+
+	_, err = svc.GetTarget(ctx, created.ID)
+	require.ErrorIs(t, err, ErrBackupTargetNotFound)
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/getarcaneapp/arcane/backend/internal/models"
+	containertypes "github.com/getarcaneapp/arcane/types/container"
+	imagetypes "github.com/getarcaneapp/arcane/types/image"
+	ref "go.podman.io/image/v5/docker/reference"
+)
+
+// ErrInvalidCommitReference is returned when the image reference for a container
+// commit is not a valid repository and tag.
+var ErrInvalidCommitReference = errors.New("invalid image reference")
+
+// CommitContainer creates a new image from the current state of a container, like
+// docker commit. The container is paused while it is committed unless opts.Pause is
+// false. The new image is returned as ImageService reports it.
+func (s *ContainerService) CommitContainer(ctx context.Context, containerID string, opts containertypes.Commit, user models.User) (*imagetypes.DetailSummary, error) {
+	reference, err := normalizeCommitReferenceInternal(opts.Reference)
+	if err != nil {
+		return nil, err
+	}
+
+	dockerClient, err := s.dockerService.GetClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Docker: %w", err)
+	}
+
+	info, err := dockerClient.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return nil, fmt.Errorf("container not found: %w", err)
+	}
+	containerName := strings.TrimPrefix(info.Name, "/")
+
+	pause := opts.Pause == nil || *opts.Pause
+	metadata := models.JSON{
+		"action":        "commit",
+		"containerId":   info.ID,
+		"containerName": containerName,
+		"reference":     reference,
+		"pause":         pause,
+	}
+
+	resp, err := dockerClient.ContainerCommit(ctx, info.ID, container.CommitOptions{
+		Reference: reference,
+		Author:    strings.TrimSpace(opts.Author),
+		Comment:   opts.Message,
+		Pause:     pause,
+	})
+	if err != nil {
+		s.eventService.LogErrorEvent(ctx, models.EventTypeImageError, "image", "", reference, user.ID, user.Username, "0", err, metadata)
+		return nil, fmt.Errorf("failed to commit container %s: %w", containerName, err)
+	}
+
+	imageName := reference
+	if imageName == "" {
+		imageName = resp.ID
+	}
+	if logErr := s.eventService.LogImageEvent(ctx, models.EventTypeImageCommit, resp.ID, imageName, user.ID, user.Username, "0", metadata); logErr != nil {
+		slog.WarnContext(ctx, "could not log container commit action", "container", containerName, "image", imageName, "error", logErr.Error())
+	}
+
+	if s.imageService == nil {
+		return &imagetypes.DetailSummary{ID: resp.ID}, nil
+	}
+	detail, err := s.imageService.GetImageDetail(ctx, resp.ID)
+	if err != nil {
+		return nil, fmt.Errorf("container committed to image %s but it could not be inspected: %w", resp.ID, err)
+	}
+	return detail, nil
+}
+
+// normalizeCommitReferenceInternal validates the reference of a commit and returns it in
+// its familiar form with the tag defaulted to latest. An empty reference is allowed and
+// creates an untagged image.
+func normalizeCommitReferenceInternal(reference string) (string, error) {
+	reference = strings.TrimSpace(reference)
+	if reference == "" {
+		return "", nil
+	}
+
+	named, err := ref.ParseNormalizedNamed(reference)
+	if err != nil {
+		return "", fmt.Errorf("%w %q: %w", ErrInvalidCommitReference, reference, err)
+	}
+	if _, ok := named.(ref.Digested); ok {
+		return "", fmt.Errorf("%w %q: a digest cannot be used as a tag", ErrInvalidCommitReference, reference)
+	}
+	return ref.FamiliarString(ref.TagNameOnly(named)), nil
+}
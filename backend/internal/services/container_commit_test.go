@@ -0,0 +1,28 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeCommitReferenceInternal(t *testing.T) {
+	cases := map[string]string{
+		"":                             "",
+		"myapp":                        "myapp:latest",
+		" myapp:debug ":                "myapp:debug",
+		"docker.io/library/nginx:fix":  "nginx:fix",
+		"registry.local:5000/team/app": "registry.local:5000/team/app:latest",
+	}
+	for input, want := range cases {
+		got, err := normalizeCommitReferenceInternal(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, got, input)
+	}
+
+	for _, input := range []string{"MyApp:debug", "app:bad tag", "app@sha256:" + "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"} {
+		_, err := normalizeCommitReferenceInternal(input)
+		require.ErrorIs(t, err, ErrInvalidCommitReference, input)
+	}
+}
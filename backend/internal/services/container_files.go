@@ -0,0 +1,432 @@
+package services
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/getarcaneapp/arcane/backend/internal/models"
+	containertypes "github.com/getarcaneapp/arcane/types/container"
+	volumetypes "github.com/getarcaneapp/arcane/types/volume"
+)
+
+const (
+	// containerListOutputLimit caps the listing output read from an exec in the container.
+	containerListOutputLimit = 8 << 20
+	// containerListArchiveLimit caps how much of a directory archive is read to list a
+	// directory of a container that has no shell.
+	containerListArchiveLimit = 256 << 20
+)
+
+// ErrInvalidContainerPath is returned when a container path is malformed or does not
+// have the file type an operation needs.
+var ErrInvalidContainerPath = errors.New("invalid container path")
+
+// errContainerListFallback signals that a directory could not be listed with a shell in
+// the container and the archive API should be used instead.
+var errContainerListFallback = errors.New("listing with a shell is not available")
+
+// ListContainerDirectory lists a directory of a container's filesystem. Running
+// containers are listed with find and stat inside the container; stopped containers and
+// containers without a shell are listed from the Docker archive API.
+func (s *ContainerService) ListContainerDirectory(ctx context.Context, containerID, dirPath string) ([]volumetypes.FileEntry, error) {
+	sanitizedPath, err := sanitizeBrowsePathInternal(dirPath)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidContainerPath, err)
+	}
+
+	dockerClient, err := s.dockerService.GetClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Docker: %w", err)
+	}
+
+	info, err := dockerClient.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return nil, fmt.Errorf("container not found: %w", err)
+	}
+
+	if info.State != nil && info.State.Running {
+		entries, err := s.listContainerDirectoryExecInternal(ctx, info.ID, sanitizedPath)
+		if err == nil {
+			return entries, nil
+		}
+		if !errors.Is(err, errContainerListFallback) {
+			return nil, err
+		}
+		slog.DebugContext(ctx, "container files: falling back to archive listing", "container", info.ID, "path", sanitizedPath)
+	}
+
+	stat, err := dockerClient.ContainerStatPath(ctx, info.ID, sanitizedPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat path: %w", err)
+	}
+	if !stat.Mode.IsDir() {
+		return nil, fmt.Errorf("%w: path is not a directory", ErrInvalidContainerPath)
+	}
+
+	reader, _, err := dockerClient.CopyFromContainer(ctx, info.ID, sanitizedPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory: %w", err)
+	}
+	defer func() { _ = reader.Close() }()
+
+	return listTarDirectoryInternal(io.LimitReader(reader, containerListArchiveLimit), sanitizedPath)
+}
+
+// containerListScript lists the directory given as its first positional argument. The
+// path is never interpolated into the script, so shell syntax in it is not evaluated.
+const containerListScript = `[ -d "$1" ] || { echo 'not a directory' >&2; exit 2; }; find "$1" -mindepth 1 -maxdepth 1 -exec sh -c 'for f; do out=$(stat -c "%s %Y %f %A" -- "$f" 2>/dev/null) || continue; link=; [ -L "$f" ] && link=$(readlink -- "$f"); printf "%s\0%s\0%s\0" "$f" "$out" "$link"; done' sh {} +`
+
+// containerListCommandInternal returns the exec command that lists dirPath.
+func containerListCommandInternal(dirPath string) []string {
+	return []string{"sh", "-c", containerListScript, "sh", dirPath}
+}
+
+// listContainerDirectoryExecInternal lists a directory with a shell script run in the
+// container. It returns errContainerListFallback if the container has no usable shell.
+func (s *ContainerService) listContainerDirectoryExecInternal(ctx context.Context, containerID, dirPath string) ([]volumetypes.FileEntry, error) {
+	stdout, stderr, exitCode, err := s.execCaptureInternal(ctx, containerID, containerListCommandInternal(dirPath))
+	switch {
+	case err != nil:
+		return nil, fmt.Errorf("%w: %w", errContainerListFallback, err)
+	case exitCode == 2 && strings.Contains(stderr, "not a directory"):
+		return nil, fmt.Errorf("%w: path is not a directory", ErrInvalidContainerPath)
+	case exitCode != 0:
+		return nil, fmt.Errorf("%w: exit code %d: %s", errContainerListFallback, exitCode, strings.TrimSpace(stderr))
+	}
+
+	return parseContainerListingInternal(stdout), nil
+}
+
+// parseContainerListingInternal parses the NUL separated path, stat and link target
+// triples written by the listing script.
+func parseContainerListingInternal(output string) []volumetypes.FileEntry {
+	fields := strings.Split(output, "\x00")
+	entries := make([]volumetypes.FileEntry, 0, len(fields)/3)
+	for i := 0; i+2 < len(fields); i += 3 {
+		fullPath := fields[i]
+		meta := strings.Fields(strings.TrimSpace(fields[i+1]))
+		if fullPath == "" || len(meta) < 4 {
+			continue
+		}
+		size, _ := strconv.ParseInt(meta[0], 10, 64)
+		modTimeSec, _ := strconv.ParseInt(meta[1], 10, 64)
+		mode := meta[3]
+
+		entries = append(entries, volumetypes.FileEntry{
+			Name:        path.Base(fullPath),
+			Path:        fullPath,
+			IsDirectory: strings.HasPrefix(mode, "d"),
+			Size:        size,
+			ModTime:     time.Unix(modTimeSec, 0),
+			Mode:        mode,
+			IsSymlink:   strings.HasPrefix(mode, "l"),
+			LinkTarget:  fields[i+2],
+		})
+	}
+	sortFileEntriesInternal(entries)
+	return entries
+}
+
+// listTarDirectoryInternal lists the direct children of dirPath from a Docker archive
+// of that directory. The archive entries are prefixed with the base name of dirPath.
+func listTarDirectoryInternal(r io.Reader, dirPath string) ([]volumetypes.FileEntry, error) {
+	entries := make([]volumetypes.FileEntry, 0)
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			if errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil, fmt.Errorf("directory is too large to list without a shell in the container")
+			}
+			return nil, fmt.Errorf("failed to read directory archive: %w", err)
+		}
+
+		// Strip the archive root (the directory itself) and keep direct children only.
+		_, rel, found := strings.Cut(strings.TrimSuffix(hdr.Name, "/"), "/")
+		if !found || rel == "" || strings.Contains(rel, "/") {
+			continue
+		}
+
+		info := hdr.FileInfo()
+		entry := volumetypes.FileEntry{
+			Name:        rel,
+			Path:        path.Join(dirPath, rel),
+			IsDirectory: info.IsDir(),
+			Size:        hdr.Size,
+			ModTime:     hdr.ModTime,
+			Mode:        info.Mode().String(),
+			IsSymlink:   hdr.Typeflag == tar.TypeSymlink,
+		}
+		if entry.IsSymlink {
+			entry.LinkTarget = hdr.Linkname
+		}
+		entries = append(entries, entry)
+	}
+	sortFileEntriesInternal(entries)
+	return entries, nil
+}
+
+func sortFileEntriesInternal(entries []volumetypes.FileEntry) {
+	slices.SortFunc(entries, func(a, b volumetypes.FileEntry) int {
+		if a.IsDirectory != b.IsDirectory {
+			if a.IsDirectory {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+}
+
+// execCaptureInternal runs cmd in a container without a TTY and returns its stdout and
+// stderr, each capped at containerListOutputLimit bytes, and its exit code.
+func (s *ContainerService) execCaptureInternal(ctx context.Context, containerID string, cmd []string) (string, string, int, error) {
+	dockerClient, err := s.dockerService.GetClient()
+	if err != nil {
+		return "", "", -1, fmt.Errorf("failed to connect to Docker: %w", err)
+	}
+
+	execResp, err := dockerClient.ContainerExecCreate(ctx, containerID, container.ExecOptions{
+		AttachStdout: true,
+		AttachStderr: true,
+		Cmd:          cmd,
+	})
+	if err != nil {
+		return "", "", -1, fmt.Errorf("failed to create exec: %w", err)
+	}
+
+	attach, err := dockerClient.ContainerExecAttach(ctx, execResp.ID, container.ExecAttachOptions{})
+	if err != nil {
+		return "", "", -1, fmt.Errorf("failed to attach to exec: %w", err)
+	}
+	defer attach.Close()
+	stop := context.AfterFunc(ctx, attach.Close)
+	defer stop()
+
+	var stdout, stderr bytes.Buffer
+	if _, err := stdcopy.StdCopy(&limitedBuffer{buf: &stdout, limit: containerListOutputLimit}, &limitedBuffer{buf: &stderr, limit: containerListOutputLimit}, attach.Reader); err != nil && ctx.Err() == nil {
+		return "", "", -1, fmt.Errorf("failed to read exec output: %w", err)
+	}
+	if err := ctx.Err(); err != nil {
+		return "", "", -1, err
+	}
+
+	inspect, err := dockerClient.ContainerExecInspect(ctx, execResp.ID)
+	if err != nil {
+		return "", "", -1, fmt.Errorf("failed to inspect exec: %w", err)
+	}
+	return stdout.String(), stderr.String(), inspect.ExitCode, nil
+}
+
+// limitedBuffer keeps at most limit bytes and silently discards the rest.
+type limitedBuffer struct {
+	buf   *bytes.Buffer
+	limit int
+}
+
+func (l *limitedBuffer) Write(p []byte) (int, error) {
+	if keep := min(len(p), l.limit-l.buf.Len()); keep > 0 {
+		l.buf.Write(p[:keep])
+	}
+	return len(p), nil
+}
+
+// GetContainerFileContent returns up to maxBytes of a file in a container and its
+// detected MIME type.
+func (s *ContainerService) GetContainerFileContent(ctx context.Context, containerID, filePath string, maxBytes int64) ([]byte, string, error) {
+	reader, _, err := s.openContainerFileInternal(ctx, containerID, filePath)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() { _ = reader.Close() }()
+
+	content, err := io.ReadAll(io.LimitReader(reader, maxBytes))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read file: %w", err)
+	}
+	return content, http.DetectContentType(content), nil
+}
+
+// openContainerFileInternal opens a regular file of a container through the archive API
+// and returns its content and size.
+func (s *ContainerService) openContainerFileInternal(ctx context.Context, containerID, filePath string) (io.ReadCloser, int64, error) {
+	sanitizedPath, err := sanitizeBrowsePathInternal(filePath)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: %w", ErrInvalidContainerPath, err)
+	}
+
+	dockerClient, err := s.dockerService.GetClient()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to connect to Docker: %w", err)
+	}
+
+	reader, _, err := dockerClient.CopyFromContainer(ctx, containerID, sanitizedPath)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	tr := tar.NewReader(reader)
+	hdr, err := tr.Next()
+	if err != nil {
+		_ = reader.Close()
+		return nil, 0, fmt.Errorf("failed to read tar stream: %w", err)
+	}
+	if hdr.Typeflag != tar.TypeReg {
+		_ = reader.Close()
+		return nil, 0, fmt.Errorf("%w: path is not a regular file", ErrInvalidContainerPath)
+	}
+
+	return &cleanupReadCloser{Reader: tr, Closer: reader, cleanup: func() {}}, hdr.Size, nil
+}
+
+// DownloadContainerPath returns a tar archive of a file or directory of a container, as
+// produced by the Docker archive API, with the stat of the path.
+func (s *ContainerService) DownloadContainerPath(ctx context.Context, containerID, srcPath string, user models.User) (io.ReadCloser, container.PathStat, error) {
+	sanitizedPath, err := sanitizeBrowsePathInternal(srcPath)
+	if err != nil {
+		return nil, container.PathStat{}, fmt.Errorf("%w: %w", ErrInvalidContainerPath, err)
+	}
+
+	dockerClient, err := s.dockerService.GetClient()
+	if err != nil {
+		return nil, container.PathStat{}, fmt.Errorf("failed to connect to Docker: %w", err)
+	}
+
+	reader, stat, err := dockerClient.CopyFromContainer(ctx, containerID, sanitizedPath)
+	if err != nil {
+		return nil, container.PathStat{}, fmt.Errorf("failed to download: %w", err)
+	}
+
+	metadata := models.JSON{"action": "file_download", "containerId": containerID, "path": sanitizedPath}
+	containerName := s.containerNameInternal(ctx, containerID)
+	if logErr := s.eventService.LogContainerEvent(ctx, models.EventTypeContainerFileDownload, containerID, containerName, user.ID, user.Username, "0", metadata); logErr != nil {
+		slog.WarnContext(ctx, "could not log container file download event", "container", containerID, "error", logErr.Error())
+	}
+
+	return reader, stat, nil
+}
+
+// UploadContainerFile writes a file into a directory of a container through the archive
+// API. It works for stopped containers too.
+func (s *ContainerService) UploadContainerFile(ctx context.Context, containerID, destPath string, content io.Reader, filename string, user models.User) error {
+	sanitizedPath, err := sanitizeBrowsePathInternal(destPath)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidContainerPath, err)
+	}
+	filename = path.Base(strings.ReplaceAll(filename, "\\", "/"))
+	if filename == "" || filename == "." || filename == ".." || filename == "/" {
+		return fmt.Errorf("%w: invalid file name", ErrInvalidContainerPath)
+	}
+
+	dockerClient, err := s.dockerService.GetClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to Docker: %w", err)
+	}
+
+	stat, err := dockerClient.ContainerStatPath(ctx, containerID, sanitizedPath)
+	if err != nil {
+		return fmt.Errorf("failed to stat destination: %w", err)
+	}
+	if !stat.Mode.IsDir() {
+		return fmt.Errorf("%w: destination is not a directory", ErrInvalidContainerPath)
+	}
+
+	contentBytes, err := io.ReadAll(content)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	hdr := &tar.Header{
+		Name:    filename,
+		Mode:    0644,
+		Size:    int64(len(contentBytes)),
+		ModTime: time.Now(),
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	if _, err := tw.Write(contentBytes); err != nil {
+		_ = tw.Close()
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+
+	metadata := models.JSON{
+		"action":      "file_upload",
+		"containerId": containerID,
+		"path":        sanitizedPath,
+		"filename":    filename,
+	}
+	containerName := s.containerNameInternal(ctx, containerID)
+	if err := dockerClient.CopyToContainer(ctx, containerID, sanitizedPath, &buf, container.CopyToContainerOptions{}); err != nil {
+		s.eventService.LogErrorEvent(ctx, models.EventTypeContainerError, "container", containerID, containerName, user.ID, user.Username, "0", err, metadata)
+		return fmt.Errorf("failed to upload: %w", err)
+	}
+
+	if logErr := s.eventService.LogContainerEvent(ctx, models.EventTypeContainerFileUpload, containerID, containerName, user.ID, user.Username, "0", metadata); logErr != nil {
+		slog.WarnContext(ctx, "could not log container file upload event", "container", containerID, "error", logErr.Error())
+	}
+	return nil
+}
+
+// containerNameInternal returns the name of a container for events, falling back to the
+// given ID if it cannot be inspected.
+func (s *ContainerService) containerNameInternal(ctx context.Context, containerID string) string {
+	info, err := s.GetContainerByID(ctx, containerID)
+	if err != nil {
+		return containerID
+	}
+	return strings.TrimPrefix(info.Name, "/")
+}
+
+// DiffContainer lists the changes to the filesystem of a container relative to its
+// image, as docker diff does.
+func (s *ContainerService) DiffContainer(ctx context.Context, containerID string) ([]containertypes.FileChange, error) {
+	dockerClient, err := s.dockerService.GetClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Docker: %w", err)
+	}
+
+	changes, err := dockerClient.ContainerDiff(ctx, containerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get container changes: %w", err)
+	}
+	return convertFileChangesInternal(changes), nil
+}
+
+func convertFileChangesInternal(changes []container.FilesystemChange) []containertypes.FileChange {
+	out := make([]containertypes.FileChange, 0, len(changes))
+	for _, change := range changes {
+		kind := containertypes.FileChangeModified
+		switch change.Kind {
+		case container.ChangeAdd:
+			kind = containertypes.FileChangeAdded
+		case container.ChangeDelete:
+			kind = containertypes.FileChangeDeleted
+		case container.ChangeModify:
+		}
+		out = append(out, containertypes.FileChange{Path: change.Path, Kind: kind})
+	}
+	slices.SortFunc(out, func(a, b containertypes.FileChange) int { return strings.Compare(a.Path, b.Path) })
+	return out
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	containertypes "github.com/getarcaneapp/arcane/types/container"
+	volumetypes "github.com/getarcaneapp/arcane/types/volume"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseContainerListingInternal(t *testing.T) {
+	output := "/etc/nginx/nginx.conf\x001024 1700000000 81a4 -rw-r--r--\n\x00\x00" +
+		"/etc/nginx/conf.d\x004096 1700000000 41ed drwxr-xr-x\n\x00\x00" +
+		"/etc/nginx/mime\x007 1700000000 a1ff lrwxrwxrwx\n\x00mime.types\x00"
+
+	entries := parseContainerListingInternal(output)
+
+	require.Len(t, entries, 3)
+	assert.Equal(t, volumetypes.FileEntry{Name: "conf.d", Path: "/etc/nginx/conf.d", IsDirectory: true, Size: 4096, ModTime: time.Unix(1700000000, 0), Mode: "drwxr-xr-x"}, entries[0])
+	assert.Equal(t, "mime", entries[1].Name)
+	assert.True(t, entries[1].IsSymlink)
+	assert.Equal(t, "mime.types", entries[1].LinkTarget)
+	assert.Equal(t, "nginx.conf", entries[2].Name)
+	assert.Equal(t, int64(1024), entries[2].Size)
+}
+
+func TestContainerListCommandInternal_DoesNotEvaluatePath(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	root := t.TempDir()
+	dir := filepath.Join(root, "$(touch pwned)")
+	require.NoError(t, os.Mkdir(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app.conf"), []byte("x"), 0o600))
+
+	argv := containerListCommandInternal(dir)
+	assert.NotContains(t, argv[2], dir)
+
+	cmd := exec.Command(argv[0], argv[1:]...) //nolint:gosec // test runs a fixed script
+	cmd.Dir = root
+	out, err := cmd.Output()
+	require.NoError(t, err)
+	assert.NoFileExists(t, filepath.Join(root, "pwned"))
+
+	entries := parseContainerListingInternal(string(out))
+	require.Len(t, entries, 1)
+	assert.Equal(t, filepath.Join(dir, "app.conf"), entries[0].Path)
+}
+
+func TestListTarDirectoryInternal(t *testing.T) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	modTime := time.Unix(1700000000, 0)
+	for _, hdr := range []*tar.Header{
+		{Name: "nginx/", Typeflag: tar.TypeDir, Mode: 0o755, ModTime: modTime},
+		{Name: "nginx/conf.d/", Typeflag: tar.TypeDir, Mode: 0o755, ModTime: modTime},
+		{Name: "nginx/conf.d/default.conf", Typeflag: tar.TypeReg, Mode: 0o644, Size: 3, ModTime: modTime},
+		{Name: "nginx/nginx.conf", Typeflag: tar.TypeReg, Mode: 0o644, Size: 5, ModTime: modTime},
+		{Name: "nginx/mime", Typeflag: tar.TypeSymlink, Linkname: "mime.types", Mode: 0o777, ModTime: modTime},
+	} {
+		require.NoError(t, tw.WriteHeader(hdr))
+		if hdr.Size > 0 {
+			_, err := tw.Write(bytes.Repeat([]byte("x"), int(hdr.Size)))
+			require.NoError(t, err)
+		}
+	}
+	require.NoError(t, tw.Close())
+
+	entries, err := listTarDirectoryInternal(&buf, "/etc/nginx")
+	require.NoError(t, err)
+
+	require.Len(t, entries, 3)
+	assert.Equal(t, "/etc/nginx/conf.d", entries[0].Path)
+	assert.True(t, entries[0].IsDirectory)
+	assert.Equal(t, volumetypes.FileEntry{Name: "mime", Path: "/etc/nginx/mime", ModTime: modTime, Mode: "Lrwxrwxrwx", IsSymlink: true, LinkTarget: "mime.types"}, entries[1])
+	assert.Equal(t, "nginx.conf", entries[2].Name)
+	assert.Equal(t, int64(5), entries[2].Size)
+}
+
+func TestListTarDirectoryInternal_Truncated(t *testing.T) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "data/big.bin", Typeflag: tar.TypeReg, Mode: 0o644, Size: 4096}))
+	_, err := tw.Write(make([]byte, 4096))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+
+	_, err = listTarDirectoryInternal(bytes.NewReader(buf.Bytes()[:1024]), "/data")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "too large")
+}
+
+func TestConvertFileChangesInternal(t *testing.T) {
+	changes := convertFileChangesInternal([]container.FilesystemChange{
+		{Path: "/tmp/cache", Kind: container.ChangeAdd},
+		{Path: "/etc", Kind: container.ChangeModify},
+		{Path: "/etc/motd", Kind: container.ChangeDelete},
+	})
+
+	assert.Equal(t, []containertypes.FileChange{
+		{Path: "/etc", Kind: containertypes.FileChangeModified},
+		{Path: "/etc/motd", Kind: containertypes.FileChangeDeleted},
+		{Path: "/tmp/cache", Kind: containertypes.FileChangeAdded},
+	}, changes)
+}
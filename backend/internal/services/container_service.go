@@ -667,6 +667,15 @@ func (e *ExecSession) Close(ctx context.Context) error {
 	return closeErr
 }
 
+// exitCodeInternal returns the exit code of the exec process and whether it has exited.
+func (e *ExecSession) exitCodeInternal(ctx context.Context) (int, bool) {
+	inspect, err := e.dockerClient.ContainerExecInspect(ctx, e.execID)
+	if err != nil || inspect.Running {
+		return 0, false
+	}
+	return inspect.ExitCode, true
+}
+
 // AttachExec attaches to an exec instance and returns an ExecSession for lifecycle management.
 func (s *ContainerService) AttachExec(ctx context.Context, containerID, execID string) (*ExecSession, error) {
 	dockerClient, err := s.dockerService.GetClient()
@@ -688,3 +697,48 @@ func (s *ContainerService) AttachExec(ctx context.Context, containerID, execID s
 		dockerClient: dockerClient,
 	}, nil
 }
+
+// execOutputLimit caps the output RunExec keeps; anything beyond it is discarded.
+const execOutputLimit = 64 << 10
+
+// RunExec runs cmd in a container, waits for it to exit and returns its combined output
+// (at most execOutputLimit bytes) and exit code.
+func (s *ContainerService) RunExec(ctx context.Context, containerID string, cmd []string) (string, int, error) {
+	execID, err := s.CreateExec(ctx, containerID, cmd)
+	if err != nil {
+		return "", -1, err
+	}
+	session, err := s.AttachExec(ctx, containerID, execID)
+	if err != nil {
+		return "", -1, err
+	}
+	// The command is not interactive: close the connection instead of sending an exit
+	// like ExecSession.Close does for shells.
+	defer session.hijackedResp.Close()
+	stop := context.AfterFunc(ctx, session.hijackedResp.Close)
+	defer stop()
+
+	var out strings.Builder
+	buf := make([]byte, 32<<10)
+	for {
+		n, readErr := session.Stdout().Read(buf)
+		if keep := min(n, execOutputLimit-out.Len()); keep > 0 {
+			out.Write(buf[:keep])
+		}
+		if readErr != nil {
+			if !errors.Is(readErr, io.EOF) && ctx.Err() == nil {
+				return out.String(), -1, fmt.Errorf("failed to read exec output: %w", readErr)
+			}
+			break
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return out.String(), -1, err
+	}
+
+	inspect, err := session.dockerClient.ContainerExecInspect(ctx, execID)
+	if err != nil {
+		return out.String(), -1, fmt.Errorf("failed to inspect exec: %w", err)
+	}
+	return out.String(), inspect.ExitCode, nil
+}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/getarcaneapp/arcane/backend/internal/models"
+	dockerutil "github.com/getarcaneapp/arcane/backend/internal/utils/docker"
+	containertypes "github.com/getarcaneapp/arcane/types/container"
+)
+
+// ErrInvalidResourceUpdate is returned when a resource update is malformed or exceeds
+// the capacity of the host.
+var ErrInvalidResourceUpdate = errors.New("invalid resource update")
+
+// minContainerMemory is the smallest memory limit the Docker daemon accepts.
+const minContainerMemory = 6 * 1024 * 1024
+
+// UpdateContainerResources changes the resource limits and restart policy of an existing
+// container without recreating it. Limits are validated against the capacity of the
+// host, narrowed to the cgroup limits Arcane runs under.
+func (s *ContainerService) UpdateContainerResources(ctx context.Context, containerID string, req containertypes.UpdateResources, user models.User) (containertypes.UpdateResult, error) {
+	dockerClient, err := s.dockerService.GetClient()
+	if err != nil {
+		return containertypes.UpdateResult{}, fmt.Errorf("failed to connect to Docker: %w", err)
+	}
+
+	info, err := dockerClient.Info(ctx)
+	if err != nil {
+		return containertypes.UpdateResult{}, fmt.Errorf("failed to get Docker info: %w", err)
+	}
+	hostCPUs, hostMemory := dockerutil.EffectiveHostCapacity(info.NCPU, info.MemTotal)
+
+	current, err := dockerClient.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return containertypes.UpdateResult{}, fmt.Errorf("container not found: %w", err)
+	}
+	containerName := strings.TrimPrefix(current.Name, "/")
+
+	updateConfig, err := buildContainerUpdateConfigInternal(req, current.HostConfig, hostCPUs, hostMemory)
+	if err != nil {
+		return containertypes.UpdateResult{}, err
+	}
+
+	metadata := models.JSON{
+		"action":      "update",
+		"containerId": current.ID,
+		"changes":     resourceChangesInternal(req, current.HostConfig),
+	}
+
+	resp, err := dockerClient.ContainerUpdate(ctx, current.ID, updateConfig)
+	if err != nil {
+		s.eventService.LogErrorEvent(ctx, models.EventTypeContainerError, "container", current.ID, containerName, user.ID, user.Username, "0", err, metadata)
+		return containertypes.UpdateResult{}, fmt.Errorf("failed to update container: %w", err)
+	}
+
+	if len(resp.Warnings) > 0 {
+		metadata["warnings"] = resp.Warnings
+	}
+	if logErr := s.eventService.LogContainerEvent(ctx, models.EventTypeContainerUpdate, current.ID, containerName, user.ID, user.Username, "0", metadata); logErr != nil {
+		slog.WarnContext(ctx, "could not log container update action", "error", logErr, "container", containerName)
+	}
+
+	return containertypes.UpdateResult{Warnings: resp.Warnings}, nil
+}
+
+// buildContainerUpdateConfigInternal validates a resource update against the current
+// host config of the container and the host capacity, and maps it to a Docker update
+// config. A host capacity of zero is not checked.
+func buildContainerUpdateConfigInternal(req containertypes.UpdateResources, current *container.HostConfig, hostCPUs int, hostMemory int64) (container.UpdateConfig, error) {
+	if current == nil {
+		current = &container.HostConfig{}
+	}
+	var cfg container.UpdateConfig
+	changed := false
+
+	memory := current.Memory
+	if req.Memory != nil {
+		memory = *req.Memory
+		switch {
+		case memory < minContainerMemory:
+			return cfg, fmt.Errorf("%w: memory limit must be at least 6MB", ErrInvalidResourceUpdate)
+		case hostMemory > 0 && memory > hostMemory:
+			return cfg, fmt.Errorf("%w: memory limit of %d bytes exceeds host memory of %d bytes", ErrInvalidResourceUpdate, memory, hostMemory)
+		}
+		cfg.Memory = memory
+		changed = true
+	}
+
+	if req.MemoryReservation != nil {
+		reservation := *req.MemoryReservation
+		switch {
+		case reservation < minContainerMemory:
+			return cfg, fmt.Errorf("%w: memory reservation must be at least 6MB", ErrInvalidResourceUpdate)
+		case memory > 0 && reservation > memory:
+			return cfg, fmt.Errorf("%w: memory reservation must not exceed the memory limit", ErrInvalidResourceUpdate)
+		case hostMemory > 0 && reservation > hostMemory:
+			return cfg, fmt.Errorf("%w: memory reservation exceeds host memory of %d bytes", ErrInvalidResourceUpdate, hostMemory)
+		}
+		cfg.MemoryReservation = reservation
+		changed = true
+	}
+
+	if req.MemorySwap != nil {
+		swap := *req.MemorySwap
+		switch {
+		case swap == -1:
+		case memory == 0:
+			return cfg, fmt.Errorf("%w: a memory limit is required to limit swap", ErrInvalidResourceUpdate)
+		case swap < memory:
+			return cfg, fmt.Errorf("%w: memory swap limit must be at least the memory limit, or -1 for unlimited", ErrInvalidResourceUpdate)
+		}
+		cfg.MemorySwap = swap
+		changed = true
+	} else if req.Memory != nil && current.MemorySwap > 0 && current.MemorySwap < memory {
+		// Docker rejects a memory limit above the existing swap limit; keep the swap
+		// allowance as large as the new memory limit.
+		cfg.MemorySwap = memory
+	}
+
+	if req.CPUs != nil {
+		cpus := *req.CPUs
+		switch {
+		case cpus <= 0 || math.IsNaN(cpus) || math.IsInf(cpus, 0):
+			return cfg, fmt.Errorf("%w: CPUs must be greater than zero", ErrInvalidResourceUpdate)
+		case hostCPUs > 0 && cpus > float64(hostCPUs):
+			return cfg, fmt.Errorf("%w: %.2f CPUs exceeds the %d CPUs available on the host", ErrInvalidResourceUpdate, cpus, hostCPUs)
+		}
+		cfg.NanoCPUs = int64(math.Round(cpus * 1e9))
+		changed = true
+	}
+
+	if req.CPUShares != nil {
+		if *req.CPUShares < 2 {
+			return cfg, fmt.Errorf("%w: CPU shares must be at least 2", ErrInvalidResourceUpdate)
+		}
+		cfg.CPUShares = *req.CPUShares
+		changed = true
+	}
+
+	if req.PidsLimit != nil {
+		if *req.PidsLimit == 0 || *req.PidsLimit < -1 {
+			return cfg, fmt.Errorf("%w: pids limit must be positive, or -1 for unlimited", ErrInvalidResourceUpdate)
+		}
+		cfg.PidsLimit = req.PidsLimit
+		changed = true
+	}
+
+	if req.RestartPolicy != nil {
+		policy := container.RestartPolicy{
+			Name:              container.RestartPolicyMode(req.RestartPolicy.Name),
+			MaximumRetryCount: req.RestartPolicy.MaximumRetryCount,
+		}
+		if policy.Name == "" {
+			return cfg, fmt.Errorf("%w: restart policy name is required", ErrInvalidResourceUpdate)
+		}
+		if err := container.ValidateRestartPolicy(policy); err != nil {
+			return cfg, fmt.Errorf("%w: %w", ErrInvalidResourceUpdate, err)
+		}
+		if current.AutoRemove && !policy.IsNone() {
+			return cfg, fmt.Errorf("%w: a restart policy cannot be set on a container that is removed when it exits", ErrInvalidResourceUpdate)
+		}
+		cfg.RestartPolicy = policy
+		changed = true
+	}
+
+	if !changed {
+		return cfg, fmt.Errorf("%w: no changes requested", ErrInvalidResourceUpdate)
+	}
+	return cfg, nil
+}
+
+// resourceChangesInternal describes the requested changes with their previous values
+// for the update event.
+func resourceChangesInternal(req containertypes.UpdateResources, current *container.HostConfig) models.JSON {
+	if current == nil {
+		current = &container.HostConfig{}
+	}
+	changes := models.JSON{}
+	change := func(name string, from, to any) {
+		changes[name] = models.JSON{"from": from, "to": to}
+	}
+
+	if req.Memory != nil {
+		change("memory", current.Memory, *req.Memory)
+	}
+	if req.MemoryReservation != nil {
+		change("memoryReservation", current.MemoryReservation, *req.MemoryReservation)
+	}
+	if req.MemorySwap != nil {
+		change("memorySwap", current.MemorySwap, *req.MemorySwap)
+	}
+	if req.CPUs != nil {
+		change("cpus", float64(current.NanoCPUs)/1e9, *req.CPUs)
+	}
+	if req.CPUShares != nil {
+		change("cpuShares", current.CPUShares, *req.CPUShares)
+	}
+	if req.PidsLimit != nil {
+		var previous int64
+		if current.PidsLimit != nil {
+			previous = *current.PidsLimit
+		}
+		change("pidsLimit", previous, *req.PidsLimit)
+	}
+	if req.RestartPolicy != nil {
+		change("restartPolicy", string(current.RestartPolicy.Name), req.RestartPolicy.Name)
+	}
+	return changes
+}
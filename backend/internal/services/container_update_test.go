@@ -0,0 +1,75 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	containertypes "github.com/getarcaneapp/arcane/types/container"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildContainerUpdateConfigInternal(t *testing.T) {
+	const gib = int64(1024 * 1024 * 1024)
+	current := &container.HostConfig{Resources: container.Resources{Memory: gib, MemorySwap: 2 * gib}}
+
+	t.Run("maps requested changes", func(t *testing.T) {
+		cfg, err := buildContainerUpdateConfigInternal(containertypes.UpdateResources{
+			Memory:        new(int64(512 * 1024 * 1024)),
+			CPUs:          new(1.5),
+			PidsLimit:     new(int64(200)),
+			RestartPolicy: &containertypes.RestartPolicyCreate{Name: "on-failure", MaximumRetryCount: 3},
+		}, current, 4, 8*gib)
+		require.NoError(t, err)
+		assert.Equal(t, int64(512*1024*1024), cfg.Memory)
+		assert.Equal(t, int64(1_500_000_000), cfg.NanoCPUs)
+		assert.Equal(t, new(int64(200)), cfg.PidsLimit)
+		assert.Equal(t, container.RestartPolicy{Name: container.RestartPolicyOnFailure, MaximumRetryCount: 3}, cfg.RestartPolicy)
+		assert.Zero(t, cfg.MemorySwap)
+	})
+
+	t.Run("raises swap limit below new memory limit", func(t *testing.T) {
+		cfg, err := buildContainerUpdateConfigInternal(containertypes.UpdateResources{Memory: new(4 * gib)}, current, 4, 8*gib)
+		require.NoError(t, err)
+		assert.Equal(t, 4*gib, cfg.MemorySwap)
+	})
+
+	t.Run("rejects limits above host capacity", func(t *testing.T) {
+		_, err := buildContainerUpdateConfigInternal(containertypes.UpdateResources{Memory: new(16 * gib)}, current, 4, 8*gib)
+		require.ErrorIs(t, err, ErrInvalidResourceUpdate)
+
+		_, err = buildContainerUpdateConfigInternal(containertypes.UpdateResources{CPUs: new(6.0)}, current, 4, 8*gib)
+		require.ErrorIs(t, err, ErrInvalidResourceUpdate)
+	})
+
+	t.Run("skips capacity check when unknown", func(t *testing.T) {
+		_, err := buildContainerUpdateConfigInternal(containertypes.UpdateResources{CPUs: new(64.0)}, current, 0, 0)
+		require.NoError(t, err)
+	})
+
+	t.Run("rejects invalid values", func(t *testing.T) {
+		cases := []containertypes.UpdateResources{
+			{},
+			{Memory: new(int64(1024))},
+			{MemoryReservation: new(2 * gib)},
+			{MemorySwap: new(gib / 2)},
+			{CPUs: new(0.0)},
+			{CPUShares: new(int64(1))},
+			{PidsLimit: new(int64(0))},
+			{RestartPolicy: &containertypes.RestartPolicyCreate{Name: "always", MaximumRetryCount: 2}},
+			{RestartPolicy: &containertypes.RestartPolicyCreate{Name: "sometimes"}},
+			{RestartPolicy: &containertypes.RestartPolicyCreate{}},
+		}
+		for _, req := range cases {
+			_, err := buildContainerUpdateConfigInternal(req, current, 4, 8*gib)
+			require.ErrorIs(t, err, ErrInvalidResourceUpdate, "%+v", req)
+		}
+	})
+
+	t.Run("rejects restart policy with auto remove", func(t *testing.T) {
+		_, err := buildContainerUpdateConfigInternal(containertypes.UpdateResources{
+			RestartPolicy: &containertypes.RestartPolicyCreate{Name: "always"},
+		}, &container.HostConfig{AutoRemove: true}, 4, 8*gib)
+		require.ErrorIs(t, err, ErrInvalidResourceUpdate)
+	})
+}
@@ -122,10 +122,15 @@ func (s *EnvironmentService) GetEnvironmentByID(ctx context.Context, id string)
 	return &environment, nil
 }
 
-func (s *EnvironmentService) ListEnvironmentsPaginated(ctx context.Context, params pagination.QueryParams) ([]environment.Environment, pagination.Response, error) {
+// ListEnvironmentsPaginated lists environments visible under access; a nil access lists all of them.
+func (s *EnvironmentService) ListEnvironmentsPaginated(ctx context.Context, params pagination.QueryParams, access models.EnvironmentAccess) ([]environment.Environment, pagination.Response, error) {
 	var envs []models.Environment
 	q := s.db.WithContext(ctx).Model(&models.Environment{})
 
+	if ids, all := access.AccessibleEnvironmentIDs(); !all {
+		q = q.Where("id IN ?", ids)
+	}
+
 	if term := strings.TrimSpace(params.Search); term != "" {
 		searchPattern := "%" + term + "%"
 		q = q.Where(
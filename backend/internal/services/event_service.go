@@ -367,18 +367,28 @@ var eventDefinitions = map[models.EventType]struct {
 	DescriptionFormat string
 	Severity          models.EventSeverity
 }{
-	models.EventTypeContainerStart:   {"Container started: %s", "Container '%s' has been started", models.EventSeveritySuccess},
-	models.EventTypeContainerStop:    {"Container stopped: %s", "Container '%s' has been stopped", models.EventSeverityInfo},
-	models.EventTypeContainerRestart: {"Container restarted: %s", "Container '%s' has been restarted", models.EventSeverityInfo},
-	models.EventTypeContainerDelete:  {"Container deleted: %s", "Container '%s' has been deleted", models.EventSeverityWarning},
-	models.EventTypeContainerCreate:  {"Container created: %s", "Container '%s' has been created", models.EventSeveritySuccess},
-	models.EventTypeContainerScan:    {"Container scanned: %s", "Security scan completed for container '%s'", models.EventSeverityInfo},
-	models.EventTypeContainerUpdate:  {"Container updated: %s", "Container '%s' has been updated", models.EventSeverityInfo},
-	models.EventTypeContainerError:   {"Container error: %s", "An error occurred with container '%s'", models.EventSeverityError},
+	models.EventTypeContainerStart:    {"Container started: %s", "Container '%s' has been started", models.EventSeveritySuccess},
+	models.EventTypeContainerStop:     {"Container stopped: %s", "Container '%s' has been stopped", models.EventSeverityInfo},
+	models.EventTypeContainerRestart:  {"Container restarted: %s", "Container '%s' has been restarted", models.EventSeverityInfo},
+	models.EventTypeContainerDelete:   {"Container deleted: %s", "Container '%s' has been deleted", models.EventSeverityWarning},
+	models.EventTypeContainerCreate:   {"Container created: %s", "Container '%s' has been created", models.EventSeveritySuccess},
+	models.EventTypeContainerScan:     {"Container scanned: %s", "Security scan completed for container '%s'", models.EventSeverityInfo},
+	models.EventTypeContainerUpdate:   {"Container updated: %s", "Container '%s' has been updated", models.EventSeverityInfo},
+	models.EventTypeContainerError:    {"Container error: %s", "An error occurred with container '%s'", models.EventSeverityError},
+	models.EventTypeContainerRollback: {"Container rolled back: %s", "Container '%s' was rolled back to its previous image after a failed update", models.EventSeverityWarning},
+
+	models.EventTypeContainerFileUpload:   {"Container file uploaded: %s", "A file was uploaded to container '%s'", models.EventSeveritySuccess},
+	models.EventTypeContainerFileDownload: {"Container file downloaded: %s", "A file or directory was downloaded from container '%s'", models.EventSeverityInfo},
+	models.EventTypeContainerExecStart:    {"Exec session started: %s", "An interactive exec session was started in container '%s'", models.EventSeverityInfo},
+	models.EventTypeContainerExecStop:     {"Exec session ended: %s", "An interactive exec session in container '%s' has ended", models.EventSeverityInfo},
 
 	models.EventTypeImagePull:   {"Image pulled: %s", "Image '%s' has been pulled", models.EventSeveritySuccess},
 	models.EventTypeImageLoad:   {"Image loaded: %s", "Image '%s' has been loaded from archive", models.EventSeveritySuccess},
 	models.EventTypeImageDelete: {"Image deleted: %s", "Image '%s' has been deleted", models.EventSeverityWarning},
+	models.EventTypeImageTag:    {"Image tagged: %s", "Image '%s' has been tagged", models.EventSeveritySuccess},
+	models.EventTypeImagePush:   {"Image pushed: %s", "Image '%s' has been pushed", models.EventSeveritySuccess},
+	models.EventTypeImageSave:   {"Image exported: %s", "Image '%s' has been exported as an archive", models.EventSeverityInfo},
+	models.EventTypeImageCommit: {"Image committed: %s", "Container has been committed to image '%s'", models.EventSeveritySuccess},
 	models.EventTypeImageScan:   {"Image scanned: %s", "Security scan completed for image '%s'", models.EventSeverityInfo},
 	models.EventTypeImageError:  {"Image error: %s", "An error occurred with image '%s'", models.EventSeverityError},
 
@@ -390,6 +400,12 @@ var eventDefinitions = map[models.EventType]struct {
 	models.EventTypeProjectUpdate: {"Project updated: %s", "Project '%s' has been updated", models.EventSeverityInfo},
 	models.EventTypeProjectError:  {"Project error: %s", "An error occurred with project '%s'", models.EventSeverityError},
 
+	models.EventTypeProjectBackupCreate:  {"Project backup created: %s", "All volumes of project '%s' have been backed up", models.EventSeveritySuccess},
+	models.EventTypeProjectBackupRestore: {"Project backup restored: %s", "All volumes of project '%s' have been restored from a backup", models.EventSeverityWarning},
+	models.EventTypeProjectBackupDelete:  {"Project backup deleted: %s", "A backup of project '%s' was deleted", models.EventSeverityWarning},
+	models.EventTypeProjectRollback:      {"Project rolled back: %s", "Project '%s' was redeployed from an earlier revision", models.EventSeverityWarning},
+	models.EventTypeProjectBuild:         {"Project images built: %s", "The images of project '%s' have been built", models.EventSeveritySuccess},
+
 	models.EventTypeVolumeCreate:             {"Volume created: %s", "Volume '%s' has been created", models.EventSeveritySuccess},
 	models.EventTypeVolumeDelete:             {"Volume deleted: %s", "Volume '%s' has been deleted", models.EventSeverityWarning},
 	models.EventTypeVolumeError:              {"Volume error: %s", "An error occurred with volume '%s'", models.EventSeverityError},
@@ -401,11 +417,16 @@ var eventDefinitions = map[models.EventType]struct {
 	models.EventTypeVolumeBackupRestore:      {"Volume backup restored: %s", "A backup was restored for volume '%s'", models.EventSeverityWarning},
 	models.EventTypeVolumeBackupRestoreFiles: {"Volume backup files restored: %s", "Selected files were restored for volume '%s'", models.EventSeverityWarning},
 	models.EventTypeVolumeBackupDownload:     {"Volume backup downloaded: %s", "A backup was downloaded for volume '%s'", models.EventSeverityInfo},
+	models.EventTypeVolumeBackupFailed:       {"Volume backup failed: %s", "A scheduled backup failed for volume '%s'", models.EventSeverityError},
+	models.EventTypeVolumeBackupPrune:        {"Volume backups pruned: %s", "Scheduled backups outside the retention policy were removed for volume '%s'", models.EventSeverityInfo},
 
 	models.EventTypeNetworkCreate: {"Network created: %s", "Network '%s' has been created", models.EventSeveritySuccess},
 	models.EventTypeNetworkDelete: {"Network deleted: %s", "Network '%s' has been deleted", models.EventSeverityWarning},
 	models.EventTypeNetworkError:  {"Network error: %s", "An error occurred with network '%s'", models.EventSeverityError},
 
+	models.EventTypeNetworkConnect:    {"Container connected to network: %s", "A container has been connected to network '%s'", models.EventSeverityInfo},
+	models.EventTypeNetworkDisconnect: {"Container disconnected from network: %s", "A container has been disconnected from network '%s'", models.EventSeverityInfo},
+
 	models.EventTypeSystemPrune:      {"System prune completed", "System resources have been pruned", models.EventSeverityInfo},
 	models.EventTypeSystemAutoUpdate: {"System auto-update completed", "System auto-update process has completed", models.EventSeverityInfo},
 	models.EventTypeSystemUpgrade:    {"System upgrade completed", "System upgrade process has completed", models.EventSeverityInfo},
@@ -0,0 +1,543 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/getarcaneapp/arcane/backend/internal/common"
+	"github.com/getarcaneapp/arcane/backend/internal/database"
+	"github.com/getarcaneapp/arcane/backend/internal/models"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/asciicast"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/pagination"
+	containertypes "github.com/getarcaneapp/arcane/types/container"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// execSessionDetachTimeout is how long an exec session keeps running after its last
+	// terminal disconnected, waiting to be reattached.
+	execSessionDetachTimeout = 15 * time.Minute
+	// execSessionBacklogSize is how much recent output is replayed to a terminal that
+	// reattaches to a session.
+	execSessionBacklogSize = 64 << 10
+	// execAttachmentBuffer is the number of output chunks buffered per terminal. A
+	// terminal that falls further behind is disconnected.
+	execAttachmentBuffer = 256
+)
+
+var (
+	// ErrExecSessionNotFound is returned when no exec session has the requested ID.
+	ErrExecSessionNotFound = errors.New("exec session not found")
+	// ErrExecSessionNotRunning is returned when attaching to or terminating an exec
+	// session that has ended.
+	ErrExecSessionNotRunning = errors.New("exec session is not running")
+	// ErrExecSessionForbidden is returned when a user accesses another user's exec
+	// session without being an admin.
+	ErrExecSessionForbidden = errors.New("exec session belongs to another user")
+	// ErrExecSessionWrongContainer is returned when attaching to an exec session through
+	// a container other than the one it runs in.
+	ErrExecSessionWrongContainer = errors.New("exec session belongs to another container")
+	// ErrExecRecordingNotFound is returned when the recording file of a session is missing.
+	ErrExecRecordingNotFound = errors.New("exec session recording not found")
+)
+
+// ExecSessionService runs interactive exec sessions in containers, records their
+// terminal I/O in asciicast format and keeps them running when their terminal
+// disconnects so they can be reattached.
+type ExecSessionService struct {
+	db               *database.DB
+	containerService *ContainerService
+	eventService     *EventService
+	recordingsDir    string
+	detachTimeout    time.Duration
+
+	mu       sync.Mutex
+	sessions map[string]*liveExecSession
+}
+
+func NewExecSessionService(ctx context.Context, db *database.DB, containerService *ContainerService, eventService *EventService, recordingsDir string) *ExecSessionService {
+	s := &ExecSessionService{
+		db:               db,
+		containerService: containerService,
+		eventService:     eventService,
+		recordingsDir:    recordingsDir,
+		detachTimeout:    execSessionDetachTimeout,
+		sessions:         make(map[string]*liveExecSession),
+	}
+	s.endInterruptedSessionsInternal(ctx)
+	return s
+}
+
+// ExecSessionOptions describes a new exec session.
+type ExecSessionOptions struct {
+	EnvironmentID string
+	ContainerID   string
+	Command       []string
+	Width         int
+	Height        int
+}
+
+// liveExecSession is a running exec session and the terminals attached to it.
+type liveExecSession struct {
+	record   models.ExecSession
+	exec     *ExecSession
+	file     *os.File
+	recorder *asciicast.Writer
+	done     chan struct{}
+
+	mu          sync.Mutex
+	clients     map[*ExecAttachment]struct{}
+	backlog     []byte
+	detachTimer *time.Timer
+	ended       bool
+}
+
+// ExecAttachment is a terminal attached to an exec session. Output delivers the output
+// of the session and is closed when the session ends or the terminal falls behind;
+// Write sends input to the session.
+type ExecAttachment struct {
+	service    *ExecSessionService
+	live       *liveExecSession
+	output     chan []byte
+	detachOnce sync.Once
+}
+
+// SessionID returns the ID of the attached exec session.
+func (a *ExecAttachment) SessionID() string { return a.live.record.ID }
+
+// Output returns the output of the session, starting with recent output when the
+// terminal reattached to a running session.
+func (a *ExecAttachment) Output() <-chan []byte { return a.output }
+
+// Write records p as input and sends it to the session.
+func (a *ExecAttachment) Write(p []byte) (int, error) {
+	if err := a.live.recorder.Input(p); err != nil {
+		slog.Warn("Failed to record exec session input", "session", a.live.record.ID, "error", err)
+	}
+	return a.live.exec.Stdin().Write(p)
+}
+
+// Detach disconnects the terminal. The session keeps running until it is reattached or
+// the detach timeout passes.
+func (a *ExecAttachment) Detach() {
+	a.detachOnce.Do(func() { a.service.detachInternal(a) })
+}
+
+// StartSession starts a recorded exec session and attaches a terminal to it.
+func (s *ExecSessionService) StartSession(ctx context.Context, opts ExecSessionOptions, user models.User) (*ExecAttachment, error) {
+	info, err := s.containerService.GetContainerByID(ctx, opts.ContainerID)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Width <= 0 || opts.Height <= 0 {
+		opts.Width, opts.Height = 80, 24
+	}
+
+	execID, err := s.containerService.CreateExec(ctx, info.ID, opts.Command)
+	if err != nil {
+		return nil, err
+	}
+	// The session outlives the request that started it.
+	session, err := s.containerService.AttachExec(context.WithoutCancel(ctx), info.ID, execID)
+	if err != nil {
+		return nil, err
+	}
+
+	live, err := s.createSessionInternal(ctx, opts, info.ID, strings.TrimPrefix(info.Name, "/"), session, user)
+	if err != nil {
+		session.hijackedResp.Close()
+		return nil, err
+	}
+
+	attachment := live.attachInternal(s)
+	s.mu.Lock()
+	s.sessions[live.record.ID] = live
+	s.mu.Unlock()
+	go s.runInternal(live)
+
+	metadata := models.JSON{"action": "exec_start", "sessionId": live.record.ID, "command": live.record.Command}
+	if logErr := s.eventService.LogContainerEvent(ctx, models.EventTypeContainerExecStart, live.record.ContainerID, live.record.ContainerName, user.ID, user.Username, live.record.EnvironmentID, metadata); logErr != nil {
+		slog.WarnContext(ctx, "could not log exec session start", "session", live.record.ID, "error", logErr.Error())
+	}
+	return attachment, nil
+}
+
+// createSessionInternal opens the recording and stores the session record.
+func (s *ExecSessionService) createSessionInternal(ctx context.Context, opts ExecSessionOptions, containerID, containerName string, session *ExecSession, user models.User) (*liveExecSession, error) {
+	if err := os.MkdirAll(s.recordingsDir, common.DirPerm); err != nil {
+		return nil, fmt.Errorf("failed to create recordings directory: %w", err)
+	}
+
+	now := time.Now()
+	record := models.ExecSession{
+		EnvironmentID: opts.EnvironmentID,
+		ContainerID:   containerID,
+		ContainerName: containerName,
+		Command:       strings.Join(opts.Command, " "),
+		UserID:        user.ID,
+		Username:      user.Username,
+		Status:        containertypes.ExecSessionActive,
+		StartedAt:     now,
+		BaseModel:     models.BaseModel{ID: uuid.NewString()},
+	}
+	record.RecordingPath = filepath.Join(s.recordingsDir, record.ID+".cast")
+
+	// Recordings contain everything typed into the terminal, so only Arcane may read them.
+	file, err := os.OpenFile(record.RecordingPath, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create recording: %w", err)
+	}
+	recorder, err := asciicast.NewWriter(file, asciicast.Header{
+		Width:     opts.Width,
+		Height:    opts.Height,
+		Timestamp: now.Unix(),
+		Command:   record.Command,
+		Title:     fmt.Sprintf("%s@%s", user.Username, containerName),
+		Env:       map[string]string{"TERM": "xterm", "SHELL": opts.Command[0]},
+	})
+	if err != nil {
+		_ = file.Close()
+		_ = os.Remove(record.RecordingPath)
+		return nil, fmt.Errorf("failed to write recording header: %w", err)
+	}
+
+	if err := s.db.WithContext(ctx).Create(&record).Error; err != nil {
+		_ = file.Close()
+		_ = os.Remove(record.RecordingPath)
+		return nil, fmt.Errorf("failed to save exec session: %w", err)
+	}
+
+	return &liveExecSession{
+		record:   record,
+		exec:     session,
+		file:     file,
+		recorder: recorder,
+		done:     make(chan struct{}),
+		clients:  make(map[*ExecAttachment]struct{}),
+	}, nil
+}
+
+// AttachSession attaches a terminal to a running exec session of the container
+// containerID, which may be a container ID, short ID or name. Users may only attach to
+// their own sessions unless they are admins.
+func (s *ExecSessionService) AttachSession(ctx context.Context, containerID, sessionID string, user models.User, isAdmin bool) (*ExecAttachment, error) {
+	live, err := s.liveSessionInternal(ctx, sessionID, user, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if !execSessionInContainerInternal(&live.record, containerID) {
+		return nil, ErrExecSessionWrongContainer
+	}
+
+	attachment := live.attachInternal(s)
+	if attachment == nil {
+		return nil, ErrExecSessionNotRunning
+	}
+	s.updateStatusInternal(ctx, live, containertypes.ExecSessionActive)
+	return attachment, nil
+}
+
+// TerminateSession ends a running exec session.
+func (s *ExecSessionService) TerminateSession(ctx context.Context, sessionID string, user models.User, isAdmin bool) error {
+	live, err := s.liveSessionInternal(ctx, sessionID, user, isAdmin)
+	if err != nil {
+		return err
+	}
+	if err := live.exec.Close(ctx); err != nil {
+		return fmt.Errorf("failed to terminate exec session: %w", err)
+	}
+	<-live.done
+	return nil
+}
+
+func (s *ExecSessionService) liveSessionInternal(ctx context.Context, sessionID string, user models.User, isAdmin bool) (*liveExecSession, error) {
+	s.mu.Lock()
+	live, ok := s.sessions[sessionID]
+	s.mu.Unlock()
+	if !ok {
+		if _, err := s.GetSession(ctx, sessionID, user, isAdmin); err != nil {
+			return nil, err
+		}
+		return nil, ErrExecSessionNotRunning
+	}
+	if !canAccessExecSessionInternal(&live.record, user, isAdmin) {
+		return nil, ErrExecSessionForbidden
+	}
+	return live, nil
+}
+
+// attachInternal adds a terminal to the session and queues the recent output for it.
+// It returns nil if the session has ended.
+func (l *liveExecSession) attachInternal(s *ExecSessionService) *ExecAttachment {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.ended {
+		return nil
+	}
+	if l.detachTimer != nil {
+		l.detachTimer.Stop()
+		l.detachTimer = nil
+	}
+
+	a := &ExecAttachment{service: s, live: l, output: make(chan []byte, execAttachmentBuffer)}
+	if len(l.backlog) > 0 {
+		a.output <- append([]byte(nil), l.backlog...)
+	}
+	l.clients[a] = struct{}{}
+	return a
+}
+
+func (s *ExecSessionService) detachInternal(a *ExecAttachment) {
+	l := a.live
+	l.mu.Lock()
+	if _, ok := l.clients[a]; ok {
+		delete(l.clients, a)
+		close(a.output)
+	}
+	orphaned := !l.ended && len(l.clients) == 0
+	if orphaned && l.detachTimer == nil {
+		l.detachTimer = time.AfterFunc(s.detachTimeout, func() {
+			slog.Info("Terminating detached exec session", "session", l.record.ID, "container", l.record.ContainerName)
+			_ = l.exec.Close(context.Background())
+		})
+	}
+	l.mu.Unlock()
+
+	if orphaned {
+		s.updateStatusInternal(context.Background(), l, containertypes.ExecSessionDetached)
+	}
+}
+
+// runInternal records and distributes the output of a session until its process exits
+// or the session is terminated.
+func (s *ExecSessionService) runInternal(live *liveExecSession) {
+	buf := make([]byte, 32<<10)
+	for {
+		n, err := live.exec.Stdout().Read(buf)
+		if n > 0 {
+			live.outputInternal(buf[:n])
+		}
+		if err != nil {
+			break
+		}
+	}
+	s.finishInternal(live)
+}
+
+func (l *liveExecSession) outputInternal(data []byte) {
+	if err := l.recorder.Output(data); err != nil {
+		slog.Warn("Failed to record exec session output", "session", l.record.ID, "error", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.backlog = append(l.backlog, data...)
+	if over := len(l.backlog) - execSessionBacklogSize; over > 0 {
+		l.backlog = append(l.backlog[:0], l.backlog[over:]...)
+	}
+	for a := range l.clients {
+		select {
+		case a.output <- append([]byte(nil), data...):
+		default:
+			slog.Debug("Disconnecting slow exec session terminal", "session", l.record.ID)
+			delete(l.clients, a)
+			close(a.output)
+		}
+	}
+}
+
+// finishInternal closes the recording, stores the result of the session and
+// disconnects its terminals.
+func (s *ExecSessionService) finishInternal(live *liveExecSession) {
+	ctx := context.Background()
+
+	live.mu.Lock()
+	live.ended = true
+	if live.detachTimer != nil {
+		live.detachTimer.Stop()
+	}
+	for a := range live.clients {
+		close(a.output)
+	}
+	live.clients = nil
+	live.mu.Unlock()
+
+	exitCode, exited := live.exec.exitCodeInternal(ctx)
+	live.exec.hijackedResp.Close()
+
+	if err := live.recorder.Close(); err != nil {
+		slog.Warn("Failed to flush exec session recording", "session", live.record.ID, "error", err)
+	}
+	_ = live.file.Close()
+
+	endedAt := time.Now()
+	updates := map[string]any{"status": containertypes.ExecSessionEnded, "ended_at": endedAt}
+	if exited {
+		updates["exit_code"] = exitCode
+	}
+	if stat, err := os.Stat(live.record.RecordingPath); err == nil {
+		updates["recording_size"] = stat.Size()
+	}
+	if err := s.db.WithContext(ctx).Model(&models.ExecSession{}).Where("id = ?", live.record.ID).Updates(updates).Error; err != nil {
+		slog.Error("Failed to save ended exec session", "session", live.record.ID, "error", err)
+	}
+
+	s.mu.Lock()
+	delete(s.sessions, live.record.ID)
+	s.mu.Unlock()
+	close(live.done)
+
+	metadata := models.JSON{
+		"action":          "exec_stop",
+		"sessionId":       live.record.ID,
+		"command":         live.record.Command,
+		"durationSeconds": int(endedAt.Sub(live.record.StartedAt).Seconds()),
+	}
+	if exited {
+		metadata["exitCode"] = exitCode
+	}
+	if logErr := s.eventService.LogContainerEvent(ctx, models.EventTypeContainerExecStop, live.record.ContainerID, live.record.ContainerName, live.record.UserID, live.record.Username, live.record.EnvironmentID, metadata); logErr != nil {
+		slog.Warn("could not log exec session stop", "session", live.record.ID, "error", logErr.Error())
+	}
+}
+
+func (s *ExecSessionService) updateStatusInternal(ctx context.Context, live *liveExecSession, status string) {
+	if err := s.db.WithContext(ctx).Model(&models.ExecSession{}).
+		Where("id = ? AND status <> ?", live.record.ID, containertypes.ExecSessionEnded).
+		Update("status", status).Error; err != nil {
+		slog.WarnContext(ctx, "Failed to update exec session status", "session", live.record.ID, "status", status, "error", err)
+	}
+}
+
+// ListSessions returns exec sessions, newest first unless params sort otherwise.
+// containerID matches a container ID or name; empty containerID and userID match all
+// sessions.
+func (s *ExecSessionService) ListSessions(ctx context.Context, params pagination.QueryParams, containerID, userID string) ([]models.ExecSession, pagination.Response, error) {
+	query := s.db.WithContext(ctx).Model(&models.ExecSession{})
+	if containerID != "" {
+		query = query.Where("container_id = ? OR container_name = ?", containerID, strings.TrimPrefix(containerID, "/"))
+	}
+	if term := strings.TrimSpace(params.Search); term != "" {
+		like := "%" + strings.ToLower(term) + "%"
+		query = query.Where("LOWER(container_name) LIKE ? OR LOWER(username) LIKE ? OR LOWER(command) LIKE ?", like, like, like)
+	}
+	if userID != "" {
+		query = query.Where("user_id = ?", userID)
+	}
+	if status := params.Filters["status"]; status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if params.Sort == "" {
+		query = query.Order("started_at DESC")
+	}
+
+	var sessions []models.ExecSession
+	paginationResp, err := pagination.PaginateAndSortDB(params, query, &sessions)
+	if err != nil {
+		return nil, pagination.Response{}, fmt.Errorf("failed to list exec sessions: %w", err)
+	}
+	return sessions, paginationResp, nil
+}
+
+// GetSession returns an exec session. Users may only read their own sessions unless
+// they are admins.
+func (s *ExecSessionService) GetSession(ctx context.Context, sessionID string, user models.User, isAdmin bool) (*models.ExecSession, error) {
+	var record models.ExecSession
+	if err := s.db.WithContext(ctx).Where("id = ?", sessionID).First(&record).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrExecSessionNotFound
+		}
+		return nil, fmt.Errorf("failed to get exec session: %w", err)
+	}
+	if !canAccessExecSessionInternal(&record, user, isAdmin) {
+		return nil, ErrExecSessionForbidden
+	}
+	return &record, nil
+}
+
+// OpenRecording opens the asciicast recording of an exec session for replay. The
+// recording of a running session contains the I/O up to the time it is opened.
+func (s *ExecSessionService) OpenRecording(ctx context.Context, sessionID string, user models.User, isAdmin bool) (io.ReadCloser, int64, error) {
+	record, err := s.GetSession(ctx, sessionID, user, isAdmin)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	file, err := os.Open(record.RecordingPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, 0, ErrExecRecordingNotFound
+		}
+		return nil, 0, fmt.Errorf("failed to open recording: %w", err)
+	}
+	stat, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, 0, fmt.Errorf("failed to open recording: %w", err)
+	}
+
+	return &cleanupReadCloser{Reader: io.LimitReader(file, stat.Size()), Closer: file, cleanup: func() {}}, stat.Size(), nil
+}
+
+// endInterruptedSessionsInternal marks sessions that were running when Arcane stopped
+// as ended; their exec processes were closed with Arcane's connection to Docker.
+func (s *ExecSessionService) endInterruptedSessionsInternal(ctx context.Context) {
+	var interrupted []models.ExecSession
+	if err := s.db.WithContext(ctx).Where("status <> ?", containertypes.ExecSessionEnded).Find(&interrupted).Error; err != nil {
+		slog.WarnContext(ctx, "Failed to load interrupted exec sessions", "error", err)
+		return
+	}
+
+	for _, record := range interrupted {
+		updates := map[string]any{"status": containertypes.ExecSessionEnded, "ended_at": time.Now()}
+		if stat, err := os.Stat(record.RecordingPath); err == nil {
+			updates["recording_size"] = stat.Size()
+		}
+		if err := s.db.WithContext(ctx).Model(&models.ExecSession{}).Where("id = ?", record.ID).Updates(updates).Error; err != nil {
+			slog.WarnContext(ctx, "Failed to end interrupted exec session", "session", record.ID, "error", err)
+		}
+	}
+}
+
+// PruneSessions removes ended exec sessions that started before olderThan ago, along
+// with their recordings.
+func (s *ExecSessionService) PruneSessions(ctx context.Context, olderThan time.Duration) error {
+	cutoff := time.Now().Add(-olderThan)
+	var expired []models.ExecSession
+	if err := s.db.WithContext(ctx).Where("status = ? AND started_at < ?", containertypes.ExecSessionEnded, cutoff).Find(&expired).Error; err != nil {
+		return fmt.Errorf("failed to load old exec sessions: %w", err)
+	}
+
+	for _, record := range expired {
+		if record.RecordingPath != "" {
+			if err := os.Remove(record.RecordingPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+				slog.WarnContext(ctx, "Failed to remove exec session recording", "session", record.ID, "path", record.RecordingPath, "error", err)
+				continue
+			}
+		}
+		if err := s.db.WithContext(ctx).Delete(&models.ExecSession{}, "id = ?", record.ID).Error; err != nil {
+			return fmt.Errorf("failed to delete exec session %s: %w", record.ID, err)
+		}
+	}
+	return nil
+}
+
+func execSessionInContainerInternal(record *models.ExecSession, containerID string) bool {
+	if containerID == "" {
+		return false
+	}
+	return strings.HasPrefix(record.ContainerID, containerID) || record.ContainerName == strings.TrimPrefix(containerID, "/")
+}
+
+func canAccessExecSessionInternal(record *models.ExecSession, user models.User, isAdmin bool) bool {
+	return isAdmin || (record.UserID != "" && record.UserID == user.ID)
+}
@@ -0,0 +1,172 @@
+package services
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/getarcaneapp/arcane/backend/internal/database"
+	"github.com/getarcaneapp/arcane/backend/internal/models"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/pagination"
+	containertypes "github.com/getarcaneapp/arcane/types/container"
+	glsqlite "github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func setupExecSessionTestDB(t *testing.T) *database.DB {
+	t.Helper()
+	db, err := gorm.Open(glsqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.ExecSession{}))
+	return &database.DB{DB: db}
+}
+
+func createExecSessionRecordInternal(t *testing.T, db *database.DB, dir, id, containerID, userID, status string, startedAt time.Time) models.ExecSession {
+	t.Helper()
+	record := models.ExecSession{
+		BaseModel:     models.BaseModel{ID: id},
+		ContainerID:   containerID,
+		ContainerName: containerID + "-name",
+		Command:       "/bin/sh",
+		UserID:        userID,
+		Username:      userID,
+		Status:        status,
+		StartedAt:     startedAt,
+		RecordingPath: filepath.Join(dir, id+".cast"),
+	}
+	require.NoError(t, os.WriteFile(record.RecordingPath, []byte("{\"version\":2,\"width\":80,\"height\":24}\n"), 0o600))
+	require.NoError(t, db.Create(&record).Error)
+	return record
+}
+
+func TestNewExecSessionService_EndsInterruptedSessions(t *testing.T) {
+	ctx := context.Background()
+	db := setupExecSessionTestDB(t)
+	dir := t.TempDir()
+	now := time.Now()
+
+	createExecSessionRecordInternal(t, db, dir, "running", "c1", "u1", containertypes.ExecSessionDetached, now)
+	ended := createExecSessionRecordInternal(t, db, dir, "ended", "c1", "u1", containertypes.ExecSessionEnded, now)
+
+	NewExecSessionService(ctx, db, nil, nil, dir)
+
+	var running models.ExecSession
+	require.NoError(t, db.First(&running, "id = ?", "running").Error)
+	assert.Equal(t, containertypes.ExecSessionEnded, running.Status)
+	assert.NotNil(t, running.EndedAt)
+	assert.Positive(t, running.RecordingSize)
+
+	var unchanged models.ExecSession
+	require.NoError(t, db.First(&unchanged, "id = ?", ended.ID).Error)
+	assert.Nil(t, unchanged.EndedAt)
+	assert.Zero(t, unchanged.RecordingSize)
+}
+
+func TestExecSessionService_ListSessions(t *testing.T) {
+	ctx := context.Background()
+	db := setupExecSessionTestDB(t)
+	dir := t.TempDir()
+	now := time.Now()
+
+	createExecSessionRecordInternal(t, db, dir, "s1", "c1", "u1", containertypes.ExecSessionEnded, now.Add(-2*time.Hour))
+	createExecSessionRecordInternal(t, db, dir, "s2", "c2", "u1", containertypes.ExecSessionEnded, now.Add(-time.Hour))
+	createExecSessionRecordInternal(t, db, dir, "s3", "c1", "u2", containertypes.ExecSessionEnded, now)
+	svc := NewExecSessionService(ctx, db, nil, nil, dir)
+
+	ids := func(sessions []models.ExecSession) []string {
+		out := make([]string, 0, len(sessions))
+		for _, s := range sessions {
+			out = append(out, s.ID)
+		}
+		return out
+	}
+	params := pagination.QueryParams{Filters: map[string]string{}}
+
+	sessions, resp, err := svc.ListSessions(ctx, params, "", "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"s3", "s2", "s1"}, ids(sessions))
+	assert.EqualValues(t, 3, resp.TotalItems)
+
+	sessions, _, err = svc.ListSessions(ctx, params, "c1", "u1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"s1"}, ids(sessions))
+
+	sessions, _, err = svc.ListSessions(ctx, params, "c2-name", "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"s2"}, ids(sessions))
+}
+
+func TestExecSessionService_AccessControl(t *testing.T) {
+	ctx := context.Background()
+	db := setupExecSessionTestDB(t)
+	dir := t.TempDir()
+
+	createExecSessionRecordInternal(t, db, dir, "s1", "c1", "u1", containertypes.ExecSessionEnded, time.Now())
+	svc := NewExecSessionService(ctx, db, nil, nil, dir)
+
+	owner := models.User{BaseModel: models.BaseModel{ID: "u1"}}
+	other := models.User{BaseModel: models.BaseModel{ID: "u2"}}
+
+	_, err := svc.GetSession(ctx, "s1", other, false)
+	require.ErrorIs(t, err, ErrExecSessionForbidden)
+
+	_, err = svc.GetSession(ctx, "missing", owner, false)
+	require.ErrorIs(t, err, ErrExecSessionNotFound)
+
+	reader, size, err := svc.OpenRecording(ctx, "s1", other, true)
+	require.NoError(t, err)
+	defer func() { _ = reader.Close() }()
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.EqualValues(t, len(data), size)
+
+	err = svc.TerminateSession(ctx, "s1", owner, false)
+	require.ErrorIs(t, err, ErrExecSessionNotRunning)
+}
+
+func TestExecSessionService_AttachSessionChecksContainer(t *testing.T) {
+	ctx := context.Background()
+	db := setupExecSessionTestDB(t)
+	dir := t.TempDir()
+
+	record := createExecSessionRecordInternal(t, db, dir, "s1", "c1abcdef", "u1", containertypes.ExecSessionDetached, time.Now())
+	svc := NewExecSessionService(ctx, db, nil, nil, dir)
+	svc.sessions[record.ID] = &liveExecSession{record: record, ended: true}
+	owner := models.User{BaseModel: models.BaseModel{ID: "u1"}}
+
+	_, err := svc.AttachSession(ctx, "c2", "s1", owner, false)
+	require.ErrorIs(t, err, ErrExecSessionWrongContainer)
+	_, err = svc.AttachSession(ctx, "", "s1", owner, false)
+	require.ErrorIs(t, err, ErrExecSessionWrongContainer)
+
+	for _, containerID := range []string{"c1abcdef", "c1ab", "c1abcdef-name", "/c1abcdef-name"} {
+		_, err = svc.AttachSession(ctx, containerID, "s1", owner, false)
+		require.ErrorIs(t, err, ErrExecSessionNotRunning, containerID)
+	}
+}
+
+func TestExecSessionService_PruneSessions(t *testing.T) {
+	ctx := context.Background()
+	db := setupExecSessionTestDB(t)
+	dir := t.TempDir()
+	now := time.Now()
+
+	old := createExecSessionRecordInternal(t, db, dir, "old", "c1", "u1", containertypes.ExecSessionEnded, now.Add(-48*time.Hour))
+	recent := createExecSessionRecordInternal(t, db, dir, "recent", "c1", "u1", containertypes.ExecSessionEnded, now)
+	svc := NewExecSessionService(ctx, db, nil, nil, dir)
+	running := createExecSessionRecordInternal(t, db, dir, "running", "c1", "u1", containertypes.ExecSessionActive, now.Add(-48*time.Hour))
+
+	require.NoError(t, svc.PruneSessions(ctx, 24*time.Hour))
+
+	var remaining []string
+	require.NoError(t, db.Model(&models.ExecSession{}).Order("id").Pluck("id", &remaining).Error)
+	assert.Equal(t, []string{"recent", "running"}, remaining)
+	assert.NoFileExists(t, old.RecordingPath)
+	assert.FileExists(t, recent.RecordingPath)
+	assert.FileExists(t, running.RecordingPath)
+}
@@ -348,6 +348,29 @@ func (s *GitRepositoryService) ListBranches(ctx context.Context, id string) ([]g
 	return result, nil
 }
 
+// ListTags lists the tags of a repository, semantic version tags first, newest first.
+func (s *GitRepositoryService) ListTags(ctx context.Context, id string) ([]string, error) {
+	settings := s.settingsService.GetSettingsConfig()
+	listCtx, cancel := timeouts.WithTimeout(ctx, settings.GitOperationTimeout.AsInt(), timeouts.DefaultGitOperation)
+	defer cancel()
+
+	repository, err := s.GetRepositoryByID(listCtx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	authConfig, err := s.GetAuthConfig(listCtx, repository)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := s.gitClient.ListTags(listCtx, repository.URL, authConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+	return tags, nil
+}
+
 func (s *GitRepositoryService) BrowseFiles(ctx context.Context, id, branch, path string) (*gitops.BrowseResponse, error) {
 	settings := s.settingsService.GetSettingsConfig()
 	ctx, cancel := timeouts.WithTimeout(ctx, settings.GitOperationTimeout.AsInt(), timeouts.DefaultGitOperation)
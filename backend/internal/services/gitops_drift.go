@@ -0,0 +1,526 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	composetypes "github.com/compose-spec/compose-go/v2/types"
+	"github.com/docker/compose/v5/pkg/api"
+	"github.com/docker/docker/api/types/container"
+	"github.com/getarcaneapp/arcane/backend/internal/models"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/fs"
+	"github.com/getarcaneapp/arcane/backend/pkg/projects"
+	"github.com/getarcaneapp/arcane/types/gitops"
+	ref "go.podman.io/image/v5/docker/reference"
+)
+
+// Drift item kinds.
+const (
+	driftKindComposeFile = "compose_file"
+	driftKindEnvFile     = "env_file"
+	driftKindMissing     = "missing"
+	driftKindReplaced    = "replaced"
+	driftKindStopped     = "stopped"
+	driftKindImage       = "image"
+	driftKindEnv         = "env"
+	driftKindPort        = "port"
+	driftKindOrphaned    = "orphaned"
+)
+
+// ErrGitOpsDriftNoProject is returned when checking a sync that has not deployed a
+// project yet.
+var ErrGitOpsDriftNoProject = errors.New("sync has not deployed a project yet")
+
+// CheckDrift compares a sync's project with the head of its branch and records the result
+// on the sync. The project files are compared with the repository's compose and .env
+// files, and the project's containers with the services the repository defines. The
+// project is never changed.
+func (s *GitOpsSyncService) CheckDrift(ctx context.Context, environmentID, id string) (*gitops.DriftReport, error) {
+	gitSync, err := s.GetSyncByID(ctx, environmentID, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.checkDriftInternal(ctx, gitSync, false)
+}
+
+// DetectDriftForAll checks every sync with a deployed project and heals the projects of
+// syncs whose drift action is heal. It returns the reports to notify about: those of
+// syncs with the notify or heal action that drifted since their previous check or were
+// healed.
+func (s *GitOpsSyncService) DetectDriftForAll(ctx context.Context) ([]gitops.DriftReport, error) {
+	var syncs []models.GitOpsSync
+	if err := s.db.WithContext(ctx).
+		Preload("Repository").
+		Preload("Project").
+		Where("project_id IS NOT NULL AND project_id <> ''").
+		Find(&syncs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get syncs for drift detection: %w", err)
+	}
+
+	var notify []gitops.DriftReport
+	for i := range syncs {
+		if ctx.Err() != nil {
+			return notify, ctx.Err()
+		}
+
+		gitSync := &syncs[i]
+		wasDrifted := gitSync.DriftStatus != nil && *gitSync.DriftStatus == gitops.DriftStatusDrifted
+		report, err := s.checkDriftInternal(ctx, gitSync, true)
+		if err != nil {
+			slog.WarnContext(ctx, "GitOps drift check failed", "syncId", gitSync.ID, "error", err)
+			continue
+		}
+		if !report.Drifted || driftActionInternal(gitSync) == gitops.DriftActionReport {
+			continue
+		}
+		if report.Healed || !wasDrifted {
+			notify = append(notify, *report)
+		}
+	}
+
+	return notify, nil
+}
+
+func driftActionInternal(gitSync *models.GitOpsSync) string {
+	if gitSync.DriftAction == "" {
+		return gitops.DriftActionReport
+	}
+	return gitSync.DriftAction
+}
+
+func validateDriftActionInternal(action string) error {
+	switch action {
+	case gitops.DriftActionReport, gitops.DriftActionNotify, gitops.DriftActionHeal:
+		return nil
+	default:
+		return fmt.Errorf("invalid drift action %q: must be report, notify or heal", action)
+	}
+}
+
+func (s *GitOpsSyncService) checkDriftInternal(ctx context.Context, gitSync *models.GitOpsSync, allowHeal bool) (*gitops.DriftReport, error) {
+	checkCtx, cancel := context.WithTimeout(ctx, defaultGitSyncTimeout)
+	defer cancel()
+
+	report, project, err := s.compareWithRepositoryInternal(checkCtx, gitSync)
+	if err != nil {
+		s.recordDriftInternal(checkCtx, gitSync.ID, gitops.DriftStatusError, err.Error(), nil)
+		return nil, err
+	}
+
+	status := gitops.DriftStatusInSync
+	if report.Drifted {
+		status = gitops.DriftStatusDrifted
+		previous := gitSync.DriftStatus
+		if previous == nil || *previous != gitops.DriftStatusDrifted {
+			slog.WarnContext(checkCtx, "GitOps drift detected", "syncId", gitSync.ID, "project", project.Name, "items", len(report.Items))
+			_, _ = s.eventService.CreateEvent(checkCtx, CreateEventRequest{
+				Type:         models.EventTypeGitSyncDrift,
+				Severity:     models.EventSeverityWarning,
+				Title:        "Git sync drift detected",
+				Description:  fmt.Sprintf("Project '%s' no longer matches '%s': %s", project.Name, gitSync.Name, report.Items[0].Message),
+				ResourceType: new("git_sync"),
+				ResourceID:   new(gitSync.ID),
+				ResourceName: new(gitSync.Name),
+				UserID:       new(systemUser.ID),
+				Username:     new(systemUser.Username),
+			})
+		}
+	}
+
+	if report.Drifted && allowHeal && driftActionInternal(gitSync) == gitops.DriftActionHeal {
+		if err := s.healDriftInternal(checkCtx, gitSync, project, report.Items); err != nil {
+			s.recordDriftInternal(checkCtx, gitSync.ID, gitops.DriftStatusDrifted, fmt.Sprintf("failed to heal drift: %v", err), report.Items)
+			return nil, fmt.Errorf("failed to heal drift: %w", err)
+		}
+		report.Healed = true
+		status = gitops.DriftStatusInSync
+	}
+
+	items := report.Items
+	if report.Healed {
+		items = nil
+	}
+	s.recordDriftInternal(checkCtx, gitSync.ID, status, "", items)
+	return report, nil
+}
+
+// compareWithRepositoryInternal clones the sync's branch and compares its compose and
+// .env files and services with the deployed project.
+func (s *GitOpsSyncService) compareWithRepositoryInternal(ctx context.Context, gitSync *models.GitOpsSync) (*gitops.DriftReport, *models.Project, error) {
+	if gitSync.ProjectID == nil || *gitSync.ProjectID == "" {
+		return nil, nil, ErrGitOpsDriftNoProject
+	}
+	if gitSync.Repository == nil {
+		return nil, nil, fmt.Errorf("repository not found")
+	}
+
+	project, err := s.projectService.GetProjectFromDatabaseByID(ctx, *gitSync.ProjectID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	authConfig, err := s.repoService.GetAuthConfig(ctx, gitSync.Repository)
+	if err != nil {
+		return nil, nil, err
+	}
+	repoPath, _, err := s.cloneSyncRefInternal(ctx, gitSync, authConfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to clone repository: %w", err)
+	}
+	defer func() {
+		if cleanupErr := s.repoService.gitClient.Cleanup(repoPath); cleanupErr != nil {
+			slog.WarnContext(ctx, "Failed to cleanup repository", "path", repoPath, "error", cleanupErr)
+		}
+	}()
+
+	report := &gitops.DriftReport{
+		SyncID:      gitSync.ID,
+		SyncName:    gitSync.Name,
+		ProjectName: project.Name,
+		Items:       []gitops.DriftItem{},
+		CheckedAt:   time.Now(),
+	}
+	if commit, err := s.repoService.gitClient.GetCurrentCommit(ctx, repoPath); err == nil {
+		report.Commit = commit
+	}
+
+	composeContent, err := s.repoService.gitClient.ReadFile(ctx, repoPath, gitSync.ComposePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read compose file %s: %w", gitSync.ComposePath, err)
+	}
+	composeContent, composeDecrypted, err := decryptSyncFileInternal(gitSync, gitSync.ComposePath, composeContent)
+	if err != nil {
+		return nil, nil, err
+	}
+	var envContent *string
+	envDecrypted := false
+	envPath := filepath.Join(filepath.Dir(gitSync.ComposePath), ".env")
+	if s.repoService.gitClient.FileExists(ctx, repoPath, envPath) {
+		content, err := s.repoService.gitClient.ReadFile(ctx, repoPath, envPath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read %s: %w", envPath, err)
+		}
+		content, envDecrypted, err = decryptSyncFileInternal(gitSync, envPath, content)
+		if err != nil {
+			return nil, nil, err
+		}
+		envContent = &content
+	}
+
+	projectCompose, projectEnv, err := s.projectService.GetProjectContent(ctx, project.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read project files: %w", err)
+	}
+	report.Items = append(report.Items, fileDriftInternal(composeContent, envContent, projectCompose, projectEnv)...)
+
+	projectsDirectory, pdErr := fs.GetProjectsDirectory(ctx, strings.TrimSpace(s.projectService.settingsService.GetStringSetting(ctx, "projectsDirectory", "/app/data/projects")))
+	if pdErr != nil {
+		slog.WarnContext(ctx, "unable to determine projects directory; using default", "error", pdErr)
+		projectsDirectory = "/app/data/projects"
+	}
+	pathMapper, pmErr := s.projectService.getPathMapper(ctx)
+	if pmErr != nil {
+		slog.WarnContext(ctx, "failed to create path mapper, continuing without translation", "error", pmErr)
+	}
+	autoInjectEnv := s.projectService.settingsService.GetBoolSetting(ctx, "autoInjectEnv", false)
+
+	// The clone holds SOPS-encrypted files as ciphertext, and plaintext is only written to
+	// the project directory. File drift is reported above, so the containers are compared
+	// with the project's own files instead.
+	var composeProject *composetypes.Project
+	if composeDecrypted || envDecrypted {
+		composeProject, _, err = projects.LoadComposeProjectFromDir(ctx, project.Path, normalizeComposeProjectName(project.Name), projectsDirectory, autoInjectEnv, pathMapper)
+	} else {
+		composeProject, err = projects.LoadComposeProject(ctx, filepath.Join(repoPath, gitSync.ComposePath), normalizeComposeProjectName(project.Name), projectsDirectory, autoInjectEnv, pathMapper)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load compose project: %w", err)
+	}
+
+	containers, err := s.inspectProjectContainersInternal(ctx, composeProject)
+	if err != nil {
+		return nil, nil, err
+	}
+	report.Items = append(report.Items, containerDriftInternal(composeProject, containers)...)
+
+	report.Drifted = len(report.Items) > 0
+	return report, project, nil
+}
+
+// inspectProjectContainersInternal inspects the containers of a compose project, and
+// containers of other origin that took the name of one of its services' containers.
+func (s *GitOpsSyncService) inspectProjectContainersInternal(ctx context.Context, composeProject *composetypes.Project) ([]container.InspectResponse, error) {
+	dockerClient, err := s.projectService.dockerService.GetClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Docker: %w", err)
+	}
+
+	names := map[string]struct{}{}
+	for name, service := range composeProject.Services {
+		names[expectedContainerNameInternal(composeProject.Name, name, service)] = struct{}{}
+	}
+
+	summaries, err := dockerClient.ContainerList(ctx, container.ListOptions{All: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	var inspected []container.InspectResponse
+	for _, c := range summaries {
+		_, named := names[strings.TrimPrefix(firstOrEmptyInternal(c.Names), "/")]
+		if c.Labels[api.ProjectLabel] != composeProject.Name && !named {
+			continue
+		}
+		info, err := dockerClient.ContainerInspect(ctx, c.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to inspect container %s: %w", c.ID, err)
+		}
+		inspected = append(inspected, info)
+	}
+	return inspected, nil
+}
+
+func expectedContainerNameInternal(projectName, serviceName string, service composetypes.ServiceConfig) string {
+	if service.ContainerName != "" {
+		return service.ContainerName
+	}
+	return strings.Join([]string{projectName, serviceName, "1"}, api.Separator)
+}
+
+// healDriftInternal restores a drifted project: changed project files are synced from
+// the repository, which also redeploys the project, and otherwise the project is deployed
+// so compose recreates diverged containers.
+func (s *GitOpsSyncService) healDriftInternal(ctx context.Context, gitSync *models.GitOpsSync, project *models.Project, items []gitops.DriftItem) error {
+	slog.InfoContext(ctx, "Healing GitOps drift", "syncId", gitSync.ID, "project", project.Name, "items", len(items))
+
+	filesDrifted := slices.ContainsFunc(items, func(item gitops.DriftItem) bool {
+		return item.Kind == driftKindComposeFile || item.Kind == driftKindEnvFile
+	})
+	if filesDrifted {
+		if _, err := s.PerformSync(ctx, gitSync.EnvironmentID, gitSync.ID); err != nil {
+			return err
+		}
+	} else if err := s.projectService.DeployProject(ctx, project.ID, systemUser); err != nil {
+		return err
+	}
+
+	_, _ = s.eventService.CreateEvent(ctx, CreateEventRequest{
+		Type:         models.EventTypeGitSyncDrift,
+		Severity:     models.EventSeveritySuccess,
+		Title:        "Git sync drift healed",
+		Description:  fmt.Sprintf("Redeployed project '%s' from '%s' to undo %d change(s)", project.Name, gitSync.Name, len(items)),
+		ResourceType: new("git_sync"),
+		ResourceID:   new(gitSync.ID),
+		ResourceName: new(gitSync.Name),
+		UserID:       new(systemUser.ID),
+		Username:     new(systemUser.Username),
+	})
+	return nil
+}
+
+func (s *GitOpsSyncService) recordDriftInternal(ctx context.Context, id, status, errorMsg string, items []gitops.DriftItem) {
+	record := &models.GitOpsSync{
+		DriftStatus:    &status,
+		DriftCheckedAt: new(time.Now()),
+		DriftItems:     items,
+	}
+	if errorMsg != "" {
+		record.DriftError = &errorMsg
+	}
+	// Updating from a struct applies the JSON serializer of the items column.
+	if err := s.db.WithContext(ctx).Model(&models.GitOpsSync{}).Where("id = ?", id).
+		Select("drift_status", "drift_checked_at", "drift_error", "drift_items").
+		Updates(record).Error; err != nil {
+		slog.ErrorContext(ctx, "Failed to record drift status", "error", err, "syncId", id)
+	}
+}
+
+// fileDriftInternal compares the repository's compose and .env files with the project's.
+// A missing .env file in the repository matches an empty project .env file.
+func fileDriftInternal(gitCompose string, gitEnv *string, projectCompose, projectEnv string) []gitops.DriftItem {
+	var items []gitops.DriftItem
+	if gitCompose != projectCompose {
+		items = append(items, gitops.DriftItem{Kind: driftKindComposeFile, Message: "compose file differs from the repository"})
+	}
+	wantEnv := ""
+	if gitEnv != nil {
+		wantEnv = *gitEnv
+	}
+	if wantEnv != projectEnv {
+		items = append(items, gitops.DriftItem{Kind: driftKindEnvFile, Message: ".env file differs from the repository"})
+	}
+	return items
+}
+
+// containerDriftInternal compares the containers of a project with the services of its
+// compose file: every service needs a running container with the defined image,
+// environment and published ports, and no container may run an undefined service.
+func containerDriftInternal(composeProject *composetypes.Project, containers []container.InspectResponse) []gitops.DriftItem {
+	var items []gitops.DriftItem
+
+	byService := map[string][]container.InspectResponse{}
+	byName := map[string]container.InspectResponse{}
+	for _, c := range containers {
+		if c.ContainerJSONBase == nil || c.Config == nil {
+			continue
+		}
+		if c.Config.Labels[api.ProjectLabel] == composeProject.Name && c.Config.Labels[api.OneoffLabel] != "True" {
+			service := c.Config.Labels[api.ServiceLabel]
+			byService[service] = append(byService[service], c)
+			continue
+		}
+		byName[strings.TrimPrefix(c.Name, "/")] = c
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(composeProject.Services)) {
+		service := composeProject.Services[name]
+		existing := byService[name]
+		delete(byService, name)
+
+		if len(existing) == 0 {
+			containerName := expectedContainerNameInternal(composeProject.Name, name, service)
+			if _, ok := byName[containerName]; ok {
+				items = append(items, gitops.DriftItem{Kind: driftKindReplaced, Service: name, Message: fmt.Sprintf("container %s was replaced by a container not managed by compose", containerName)})
+			} else {
+				items = append(items, gitops.DriftItem{Kind: driftKindMissing, Service: name, Message: fmt.Sprintf("service %s has no container", name)})
+			}
+			continue
+		}
+
+		for _, c := range existing {
+			items = append(items, serviceContainerDriftInternal(name, service, c)...)
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(byService)) {
+		for _, c := range byService[name] {
+			items = append(items, gitops.DriftItem{Kind: driftKindOrphaned, Service: name, Message: fmt.Sprintf("container %s runs service %s, which the repository does not define", strings.TrimPrefix(c.Name, "/"), name)})
+		}
+	}
+
+	return items
+}
+
+// completedContainerInternal reports whether c is a one-shot container that exited
+// successfully and is not meant to be restarted, such as a migration job.
+func completedContainerInternal(c container.InspectResponse) bool {
+	if c.State == nil || c.State.Status != container.StateExited || c.State.ExitCode != 0 {
+		return false
+	}
+	if c.HostConfig == nil {
+		return true
+	}
+	switch c.HostConfig.RestartPolicy.Name {
+	case container.RestartPolicyDisabled, container.RestartPolicyOnFailure, "":
+		return true
+	default:
+		return false
+	}
+}
+
+func serviceContainerDriftInternal(name string, service composetypes.ServiceConfig, c container.InspectResponse) []gitops.DriftItem {
+	var items []gitops.DriftItem
+	containerName := strings.TrimPrefix(c.Name, "/")
+
+	if c.State != nil && !c.State.Running && !completedContainerInternal(c) {
+		items = append(items, gitops.DriftItem{Kind: driftKindStopped, Service: name, Message: fmt.Sprintf("container %s is %s", containerName, c.State.Status)})
+	}
+
+	if service.Image != "" && !sameImageRefInternal(service.Image, c.Config.Image) {
+		items = append(items, gitops.DriftItem{Kind: driftKindImage, Service: name, Message: fmt.Sprintf("container %s runs image %s instead of %s", containerName, c.Config.Image, service.Image)})
+	}
+
+	// Only keys are reported since values may be secrets. Variables the container has on
+	// top of the service's, e.g. from its image, are not drift.
+	env := make(map[string]string, len(c.Config.Env))
+	for _, kv := range c.Config.Env {
+		key, value, _ := strings.Cut(kv, "=")
+		env[key] = value
+	}
+	for _, key := range slices.Sorted(maps.Keys(service.Environment)) {
+		want := service.Environment[key]
+		if want == nil {
+			continue
+		}
+		got, ok := env[key]
+		switch {
+		case !ok:
+			items = append(items, gitops.DriftItem{Kind: driftKindEnv, Service: name, Message: fmt.Sprintf("environment variable %s is not set in container %s", key, containerName)})
+		case got != *want:
+			items = append(items, gitops.DriftItem{Kind: driftKindEnv, Service: name, Message: fmt.Sprintf("environment variable %s differs in container %s", key, containerName)})
+		}
+	}
+
+	wantPorts := map[planPort]struct{}{}
+	for _, p := range service.Ports {
+		for _, port := range publishedPortsInternal(p) {
+			wantPorts[normalizeDriftPortInternal(port)] = struct{}{}
+		}
+	}
+	gotPorts := map[planPort]struct{}{}
+	if c.HostConfig != nil {
+		for containerPort, bindings := range c.HostConfig.PortBindings {
+			for _, binding := range bindings {
+				hostPort, err := strconv.Atoi(binding.HostPort)
+				if err != nil {
+					continue
+				}
+				gotPorts[normalizeDriftPortInternal(planPort{hostIP: binding.HostIP, port: hostPort, protocol: containerPort.Proto()})] = struct{}{}
+			}
+		}
+	}
+	for _, port := range sortedDriftPortsInternal(wantPorts) {
+		if _, ok := gotPorts[port]; !ok {
+			items = append(items, gitops.DriftItem{Kind: driftKindPort, Service: name, Message: fmt.Sprintf("port %d/%s is not published by container %s", port.port, port.protocol, containerName)})
+		}
+	}
+	for _, port := range sortedDriftPortsInternal(gotPorts) {
+		if _, ok := wantPorts[port]; !ok {
+			items = append(items, gitops.DriftItem{Kind: driftKindPort, Service: name, Message: fmt.Sprintf("container %s publishes port %d/%s, which the repository does not define", containerName, port.port, port.protocol)})
+		}
+	}
+
+	return items
+}
+
+func normalizeDriftPortInternal(p planPort) planPort {
+	if isWildcardHostIPInternal(p.hostIP) {
+		p.hostIP = ""
+	}
+	p.protocol = strings.ToLower(p.protocol)
+	return p
+}
+
+func sortedDriftPortsInternal(ports map[planPort]struct{}) []planPort {
+	return slices.SortedFunc(maps.Keys(ports), func(a, b planPort) int {
+		if a.port != b.port {
+			return a.port - b.port
+		}
+		if a.protocol != b.protocol {
+			return strings.Compare(a.protocol, b.protocol)
+		}
+		return strings.Compare(a.hostIP, b.hostIP)
+	})
+}
+
+// sameImageRefInternal reports whether two image references name the same image, so
+// nginx and docker.io/library/nginx:latest are equal.
+func sameImageRefInternal(a, b string) bool {
+	if a == b {
+		return true
+	}
+	namedA, errA := ref.ParseNormalizedNamed(a)
+	namedB, errB := ref.ParseNormalizedNamed(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	return ref.TagNameOnly(namedA).String() == ref.TagNameOnly(namedB).String()
+}
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	composetypes "github.com/compose-spec/compose-go/v2/types"
+	"github.com/docker/compose/v5/pkg/api"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
+	"github.com/getarcaneapp/arcane/types/gitops"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func driftContainerInternal(name, service, image string, env []string, ports nat.PortMap) container.InspectResponse {
+	labels := map[string]string{}
+	if service != "" {
+		labels[api.ProjectLabel] = "web"
+		labels[api.ServiceLabel] = service
+	}
+	return container.InspectResponse{
+		ContainerJSONBase: &container.ContainerJSONBase{
+			Name:       "/" + name,
+			State:      &container.State{Running: true, Status: "running"},
+			HostConfig: &container.HostConfig{PortBindings: ports},
+		},
+		Config: &container.Config{Image: image, Env: env, Labels: labels},
+	}
+}
+
+func driftProjectInternal(services ...composetypes.ServiceConfig) *composetypes.Project {
+	project := &composetypes.Project{Name: "web", Services: composetypes.Services{}}
+	for _, s := range services {
+		project.Services[s.Name] = s
+	}
+	return project
+}
+
+func driftKindsInternal(items []gitops.DriftItem) []string {
+	kinds := make([]string, 0, len(items))
+	for _, item := range items {
+		kinds = append(kinds, item.Kind)
+	}
+	return kinds
+}
+
+func TestFileDriftInternal(t *testing.T) {
+	env := "A=1\n"
+
+	assert.Empty(t, fileDriftInternal("services: {}\n", &env, "services: {}\n", "A=1\n"))
+	assert.Empty(t, fileDriftInternal("services: {}\n", nil, "services: {}\n", ""), "missing .env in git matches an empty project .env")
+
+	items := fileDriftInternal("services: {}\n", nil, "services: {web: {}}\n", "A=1\n")
+	assert.Equal(t, []string{driftKindComposeFile, driftKindEnvFile}, driftKindsInternal(items))
+}
+
+func TestContainerDriftInternal_InSync(t *testing.T) {
+	project := driftProjectInternal(composetypes.ServiceConfig{
+		Name:        "app",
+		Image:       "nginx",
+		Environment: composetypes.MappingWithEquals{"MODE": new("prod")},
+		Ports:       []composetypes.ServicePortConfig{{Target: 80, Published: "8080", Protocol: "tcp"}},
+	})
+	containers := []container.InspectResponse{
+		driftContainerInternal("web-app-1", "app", "docker.io/library/nginx:latest",
+			[]string{"MODE=prod", "PATH=/usr/bin"},
+			nat.PortMap{"80/tcp": {{HostIP: "0.0.0.0", HostPort: "8080"}}}),
+	}
+
+	assert.Empty(t, containerDriftInternal(project, containers))
+}
+
+func TestContainerDriftInternal_ServiceChanges(t *testing.T) {
+	project := driftProjectInternal(composetypes.ServiceConfig{
+		Name:        "app",
+		Image:       "nginx:1.27",
+		Environment: composetypes.MappingWithEquals{"MODE": new("prod"), "TOKEN": new("secret")},
+		Ports:       []composetypes.ServicePortConfig{{Target: 80, Published: "8080", Protocol: "tcp"}},
+	})
+	c := driftContainerInternal("web-app-1", "app", "nginx:1.26",
+		[]string{"MODE=dev"},
+		nat.PortMap{"80/tcp": {{HostPort: "9090"}}})
+	c.State = &container.State{Running: false, Status: "exited", ExitCode: 1}
+
+	items := containerDriftInternal(project, []container.InspectResponse{c})
+	assert.Equal(t, []string{driftKindStopped, driftKindImage, driftKindEnv, driftKindEnv, driftKindPort, driftKindPort}, driftKindsInternal(items))
+	for _, item := range items {
+		assert.Equal(t, "app", item.Service)
+		assert.NotContains(t, item.Message, "secret", "environment values must not be reported")
+		assert.NotContains(t, item.Message, "dev", "environment values must not be reported")
+	}
+}
+
+func TestContainerDriftInternal_CompletedOneShotContainers(t *testing.T) {
+	project := driftProjectInternal(composetypes.ServiceConfig{Name: "migrate", Image: "app"})
+	exited := func(exitCode int, restart container.RestartPolicyMode) container.InspectResponse {
+		c := driftContainerInternal("web-migrate-1", "migrate", "app", nil, nil)
+		c.State = &container.State{Status: container.StateExited, ExitCode: exitCode}
+		c.HostConfig.RestartPolicy = container.RestartPolicy{Name: restart}
+		return c
+	}
+
+	for _, restart := range []container.RestartPolicyMode{"", container.RestartPolicyDisabled, container.RestartPolicyOnFailure} {
+		assert.Empty(t, containerDriftInternal(project, []container.InspectResponse{exited(0, restart)}), "restart %q", restart)
+	}
+
+	items := containerDriftInternal(project, []container.InspectResponse{exited(0, container.RestartPolicyUnlessStopped)})
+	assert.Equal(t, []string{driftKindStopped}, driftKindsInternal(items))
+	items = containerDriftInternal(project, []container.InspectResponse{exited(1, container.RestartPolicyDisabled)})
+	assert.Equal(t, []string{driftKindStopped}, driftKindsInternal(items))
+}
+
+func TestContainerDriftInternal_MissingReplacedAndOrphaned(t *testing.T) {
+	project := driftProjectInternal(
+		composetypes.ServiceConfig{Name: "app", Image: "nginx"},
+		composetypes.ServiceConfig{Name: "db", Image: "postgres", ContainerName: "web-db"},
+	)
+	containers := []container.InspectResponse{
+		// docker run over the db service's container name.
+		driftContainerInternal("web-db", "", "postgres", nil, nil),
+		driftContainerInternal("web-cache-1", "cache", "redis", nil, nil),
+	}
+
+	items := containerDriftInternal(project, containers)
+	require.Len(t, items, 3)
+	assert.Equal(t, gitops.DriftItem{Kind: driftKindMissing, Service: "app", Message: "service app has no container"}, items[0])
+	assert.Equal(t, driftKindReplaced, items[1].Kind)
+	assert.Equal(t, "db", items[1].Service)
+	assert.Equal(t, driftKindOrphaned, items[2].Kind)
+	assert.Equal(t, "cache", items[2].Service)
+}
+
+func TestSameImageRefInternal(t *testing.T) {
+	assert.True(t, sameImageRefInternal("nginx", "docker.io/library/nginx:latest"))
+	assert.True(t, sameImageRefInternal("ghcr.io/acme/app:1.0", "ghcr.io/acme/app:1.0"))
+	assert.False(t, sameImageRefInternal("nginx:1.27", "nginx:1.26"))
+	assert.False(t, sameImageRefInternal("nginx", "ghcr.io/library/nginx"))
+}
+
+func TestValidateDriftActionInternal(t *testing.T) {
+	for _, action := range []string{gitops.DriftActionReport, gitops.DriftActionNotify, gitops.DriftActionHeal} {
+		assert.NoError(t, validateDriftActionInternal(action))
+	}
+	assert.Error(t, validateDriftActionInternal("ignore"))
+}
+
+func TestCheckDrift_RequiresProject(t *testing.T) {
+	svc, sync := setupGitOpsWebhookTest(t, "")
+
+	_, err := svc.CheckDrift(context.Background(), sync.EnvironmentID, sync.ID)
+	require.ErrorIs(t, err, ErrGitOpsDriftNoProject)
+
+	status, err := svc.GetSyncStatus(context.Background(), sync.EnvironmentID, sync.ID)
+	require.NoError(t, err)
+	require.NotNil(t, status.DriftStatus)
+	assert.Equal(t, gitops.DriftStatusError, *status.DriftStatus)
+	require.NotNil(t, status.DriftError)
+	assert.Contains(t, *status.DriftError, "not deployed")
+}
+
+func TestRecordDriftInternal_StoresItems(t *testing.T) {
+	svc, sync := setupGitOpsWebhookTest(t, "")
+	ctx := context.Background()
+	items := []gitops.DriftItem{{Kind: driftKindImage, Service: "app", Message: "container web-app-1 runs image nginx:1.26 instead of nginx:1.27"}}
+
+	svc.recordDriftInternal(ctx, sync.ID, gitops.DriftStatusDrifted, "", items)
+
+	status, err := svc.GetSyncStatus(ctx, sync.EnvironmentID, sync.ID)
+	require.NoError(t, err)
+	require.NotNil(t, status.DriftStatus)
+	assert.Equal(t, gitops.DriftStatusDrifted, *status.DriftStatus)
+	assert.NotNil(t, status.DriftCheckedAt)
+	assert.Nil(t, status.DriftError)
+	assert.Equal(t, items, status.DriftItems)
+
+	svc.recordDriftInternal(ctx, sync.ID, gitops.DriftStatusInSync, "", nil)
+
+	status, err = svc.GetSyncStatus(ctx, sync.EnvironmentID, sync.ID)
+	require.NoError(t, err)
+	assert.Equal(t, gitops.DriftStatusInSync, *status.DriftStatus)
+	assert.Empty(t, status.DriftItems)
+}
@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+
+	"github.com/getarcaneapp/arcane/backend/internal/models"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/git"
+	"github.com/getarcaneapp/arcane/types/gitops"
+	"gorm.io/gorm"
+)
+
+// defaultSyncCommitsLimit is the number of commits listed as rollback targets.
+const defaultSyncCommitsLimit = 30
+
+var commitHashRe = regexp.MustCompile(`^[0-9a-fA-F]{4,40}$`)
+
+func validateSyncRefInternal(refType, ref string) error {
+	switch refType {
+	case gitops.RefTypeBranch:
+		return nil
+	case gitops.RefTypeTag:
+		if ref == "" {
+			return &models.ValidationError{Field: "ref", Message: "a tag is required for tag syncs"}
+		}
+	case gitops.RefTypeCommit:
+		if !commitHashRe.MatchString(ref) {
+			return &models.ValidationError{Field: "ref", Message: fmt.Sprintf("invalid commit %q: must be a commit hash", ref)}
+		}
+	case gitops.RefTypeSemver:
+		if _, err := git.ParseSemverRange(ref); err != nil {
+			return &models.ValidationError{Field: "ref", Message: err.Error()}
+		}
+	default:
+		return &models.ValidationError{Field: "refType", Message: fmt.Sprintf("invalid ref type %q: must be branch, tag, commit or semver", refType)}
+	}
+	return nil
+}
+
+// syncRefInternal returns the ref a sync deploys. Syncs created before ref types were
+// added have an empty ref type and follow their branch.
+func syncRefInternal(gitSync *models.GitOpsSync) git.Ref {
+	return git.Ref{Type: gitSync.RefType, Branch: gitSync.Branch, Name: gitSync.Ref}
+}
+
+// cloneSyncRefInternal clones a sync's repository at the ref it deploys and returns the
+// path and what the ref resolved to.
+func (s *GitOpsSyncService) cloneSyncRefInternal(ctx context.Context, gitSync *models.GitOpsSync, authConfig git.AuthConfig) (string, string, error) {
+	return s.repoService.gitClient.CloneRef(ctx, gitSync.Repository.URL, syncRefInternal(gitSync), authConfig)
+}
+
+// RollbackSync pins a sync to a commit and deploys it. The commit is resolved in the
+// repository first and pinned by its full hash; the sync is left unchanged if it does not
+// exist. The sync keeps deploying that commit until its ref type is changed back. The
+// rollback is recorded as done by user.
+func (s *GitOpsSyncService) RollbackSync(ctx context.Context, environmentID, id, commit string, user models.User) (*gitops.SyncResult, error) {
+	gitSync, err := s.GetSyncByID(ctx, environmentID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateSyncRefInternal(gitops.RefTypeCommit, commit); err != nil {
+		return nil, err
+	}
+	if gitSync.Repository == nil {
+		return nil, fmt.Errorf("repository not found")
+	}
+	authConfig, err := s.repoService.GetAuthConfig(ctx, gitSync.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(gitSync).Updates(map[string]any{
+			"ref_type": gitops.RefTypeCommit,
+			"ref":      commit,
+		}).Error; err != nil {
+			return fmt.Errorf("failed to update sync: %w", err)
+		}
+
+		pinned := *gitSync
+		pinned.RefType, pinned.Ref = gitops.RefTypeCommit, commit
+		resolveCtx, cancel := context.WithTimeout(ctx, defaultGitSyncTimeout)
+		defer cancel()
+		repoPath, resolved, err := s.cloneSyncRefInternal(resolveCtx, &pinned, authConfig)
+		if err != nil {
+			if errors.Is(err, git.ErrCommitNotFound) {
+				return &models.ValidationError{Field: "commit", Message: err.Error()}
+			}
+			return fmt.Errorf("failed to resolve commit %s: %w", commit, err)
+		}
+		if cleanupErr := s.repoService.gitClient.Cleanup(repoPath); cleanupErr != nil {
+			slog.WarnContext(ctx, "Failed to cleanup repository", "path", repoPath, "error", cleanupErr)
+		}
+
+		commit = resolved
+		if err := tx.Model(gitSync).Update("ref", commit).Error; err != nil {
+			return fmt.Errorf("failed to update sync: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "Rolling back GitOps sync", "syncId", id, "commit", commit)
+
+	_, _ = s.eventService.CreateEvent(ctx, CreateEventRequest{
+		Type:         models.EventTypeGitSyncUpdate,
+		Severity:     models.EventSeverityInfo,
+		Title:        "Git sync rolled back",
+		Description:  fmt.Sprintf("Rolled back git sync '%s' to commit %s", gitSync.Name, commit),
+		ResourceType: new("git_sync"),
+		ResourceID:   new(gitSync.ID),
+		ResourceName: new(gitSync.Name),
+		UserID:       new(user.ID),
+		Username:     new(user.Username),
+	})
+
+	return s.PerformSync(ctx, environmentID, id)
+}
+
+// ListSyncCommits lists the latest commits of a sync's branch, newest first, as
+// rollback targets.
+func (s *GitOpsSyncService) ListSyncCommits(ctx context.Context, environmentID, id string) ([]gitops.CommitInfo, error) {
+	listCtx, cancel := context.WithTimeout(ctx, defaultGitSyncTimeout)
+	defer cancel()
+
+	gitSync, err := s.GetSyncByID(listCtx, environmentID, id)
+	if err != nil {
+		return nil, err
+	}
+	if gitSync.Repository == nil {
+		return nil, fmt.Errorf("repository not found")
+	}
+
+	authConfig, err := s.repoService.GetAuthConfig(listCtx, gitSync.Repository)
+	if err != nil {
+		return nil, err
+	}
+	repoPath, err := s.repoService.gitClient.Clone(listCtx, gitSync.Repository.URL, gitSync.Branch, authConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone repository: %w", err)
+	}
+	defer func() {
+		if cleanupErr := s.repoService.gitClient.Cleanup(repoPath); cleanupErr != nil {
+			slog.WarnContext(listCtx, "Failed to cleanup repository", "path", repoPath, "error", cleanupErr)
+		}
+	}()
+
+	commits, err := s.repoService.gitClient.ListCommits(listCtx, repoPath, defaultSyncCommitsLimit)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]gitops.CommitInfo, 0, len(commits))
+	for _, commit := range commits {
+		result = append(result, gitops.CommitInfo{
+			Hash:     commit.Hash,
+			Author:   commit.Author,
+			Message:  commit.Message,
+			Date:     commit.Date,
+			Deployed: gitSync.LastSyncCommit != nil && *gitSync.LastSyncCommit == commit.Hash,
+		})
+	}
+	return result, nil
+}
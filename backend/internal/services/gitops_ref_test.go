@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/getarcaneapp/arcane/backend/internal/models"
+	"github.com/getarcaneapp/arcane/types/gitops"
+)
+
+func TestValidateSyncRefInternal(t *testing.T) {
+	valid := map[string]string{
+		gitops.RefTypeBranch: "",
+		gitops.RefTypeTag:    "v1.2.0",
+		gitops.RefTypeCommit: "0d1a26e",
+		gitops.RefTypeSemver: ">=1.2.0 <2",
+	}
+	for refType, ref := range valid {
+		assert.NoError(t, validateSyncRefInternal(refType, ref), refType)
+	}
+
+	invalid := []struct{ refType, ref, field string }{
+		{gitops.RefTypeTag, "", "ref"},
+		{gitops.RefTypeCommit, "main", "ref"},
+		{gitops.RefTypeSemver, "latest", "ref"},
+		{"sha", "0d1a26e", "refType"},
+	}
+	for _, tt := range invalid {
+		err := validateSyncRefInternal(tt.refType, tt.ref)
+		var validationErr *models.ValidationError
+		require.ErrorAs(t, err, &validationErr, tt.refType)
+		assert.Equal(t, tt.field, validationErr.Field)
+	}
+}
+
+func TestSyncRefInternal(t *testing.T) {
+	legacy := &models.GitOpsSync{Branch: "main"}
+	assert.Equal(t, "branch main", syncRefInternal(legacy).String())
+
+	pinned := &models.GitOpsSync{Branch: "main", RefType: gitops.RefTypeSemver, Ref: "v1.*"}
+	assert.Equal(t, "semver v1.*", syncRefInternal(pinned).String())
+}
+
+// rollbackTestRepoInternal points the sync's repository at a local repository with one
+// commit, which has no compose file so that deploying it fails, and returns its hash.
+func rollbackTestRepoInternal(t *testing.T, svc *GitOpsSyncService) string {
+	t.Helper()
+	dir := t.TempDir()
+	repo, err := gogit.PlainInit(dir, false)
+	require.NoError(t, err)
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("web\n"), 0o600))
+	_, err = worktree.Add("README.md")
+	require.NoError(t, err)
+	hash, err := worktree.Commit("initial", &gogit.CommitOptions{Author: &object.Signature{Name: "Arcane", Email: "arcane@example.com", When: time.Now()}})
+	require.NoError(t, err)
+
+	require.NoError(t, svc.db.Create(&models.GitRepository{BaseModel: models.BaseModel{ID: "repo-1"}, Name: "web", URL: dir, AuthType: "none"}).Error)
+	svc.repoService = NewGitRepositoryService(svc.db, t.TempDir(), nil, nil)
+	return hash.String()
+}
+
+func TestGitOpsSyncService_RollbackSyncRecordsUser(t *testing.T) {
+	svc, sync := setupGitOpsWebhookTest(t, "")
+	require.NoError(t, svc.db.AutoMigrate(&models.Event{}))
+	svc.eventService = NewEventService(svc.db)
+	commit := rollbackTestRepoInternal(t, svc)
+
+	alice := models.User{BaseModel: models.BaseModel{ID: "u1"}, Username: "alice"}
+	// The commit has no compose file, so the deploy after pinning it fails.
+	result, err := svc.RollbackSync(context.Background(), "0", sync.ID, commit[:7], alice)
+	require.Error(t, err)
+	assert.False(t, result.Success)
+
+	var event models.Event
+	require.NoError(t, svc.db.Where("title = ?", "Git sync rolled back").First(&event).Error)
+	require.NotNil(t, event.UserID)
+	assert.Equal(t, "u1", *event.UserID)
+	require.NotNil(t, event.Username)
+	assert.Equal(t, "alice", *event.Username)
+
+	pinned, err := svc.GetSyncByID(context.Background(), "0", sync.ID)
+	require.NoError(t, err)
+	assert.Equal(t, gitops.RefTypeCommit, pinned.RefType)
+	assert.Equal(t, commit, pinned.Ref, "the full hash is pinned")
+}
+
+func TestGitOpsSyncService_RollbackSyncUnknownCommit(t *testing.T) {
+	svc, sync := setupGitOpsWebhookTest(t, "")
+	rollbackTestRepoInternal(t, svc)
+
+	_, err := svc.RollbackSync(context.Background(), "0", sync.ID, "0d1a26e", models.User{})
+	var validationErr *models.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "commit", validationErr.Field)
+
+	unchanged, err := svc.GetSyncByID(context.Background(), "0", sync.ID)
+	require.NoError(t, err)
+	assert.Empty(t, unchanged.RefType)
+	assert.Empty(t, unchanged.Ref)
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/getarcaneapp/arcane/backend/internal/models"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/crypto"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/sops"
+)
+
+// ErrGitOpsSopsNoKey is returned when a synced file is SOPS-encrypted but the sync has no
+// age key to decrypt it with.
+var ErrGitOpsSopsNoKey = errors.New("file is SOPS-encrypted but the sync has no age key")
+
+// encryptSopsAgeKeyInternal validates age secret keys and encrypts them for storage.
+func encryptSopsAgeKeyInternal(keys string) (string, error) {
+	if _, err := sops.ParseAgeKeys(keys); err != nil {
+		return "", &models.ValidationError{Field: "sopsAgeKey", Message: err.Error()}
+	}
+	encrypted, err := crypto.Encrypt(keys)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt SOPS age key: %w", err)
+	}
+	return encrypted, nil
+}
+
+// decryptSyncFileInternal returns the plaintext of a file read from a sync's repository.
+// Files that are not SOPS-encrypted are returned as they are. Callers must only write
+// the result to the project directory: it must never be logged, stored in the database
+// or returned by the API. Errors never include decrypted values.
+func decryptSyncFileInternal(gitSync *models.GitOpsSync, path, content string) (plaintext string, decrypted bool, err error) {
+	format, ok := sops.FormatForPath(path)
+	if !ok || !sops.IsEncrypted(content, format) {
+		return content, false, nil
+	}
+	if gitSync.SopsAgeKey == nil || *gitSync.SopsAgeKey == "" {
+		return "", false, fmt.Errorf("%s: %w", path, ErrGitOpsSopsNoKey)
+	}
+
+	keys, err := crypto.Decrypt(*gitSync.SopsAgeKey)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to decrypt SOPS age key: %w", err)
+	}
+	identities, err := sops.ParseAgeKeys(keys)
+	if err != nil {
+		return "", false, err
+	}
+	plaintext, err = sops.Decrypt(content, format, identities)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to decrypt %s: %w", path, err)
+	}
+	return plaintext, true, nil
+}
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/getarcaneapp/arcane/backend/internal/config"
+	"github.com/getarcaneapp/arcane/backend/internal/models"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/crypto"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/pagination"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/sops"
+)
+
+func readSopsFixtureInternal(t *testing.T, name string) string {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join("..", "utils", "sops", "testdata", name))
+	require.NoError(t, err)
+	return string(content)
+}
+
+func sopsSyncInternal(t *testing.T, keys string) *models.GitOpsSync {
+	t.Helper()
+	crypto.InitEncryption(&config.Config{
+		EncryptionKey: "test-encryption-key-for-testing-32bytes-min",
+		Environment:   "test",
+	})
+	sync := &models.GitOpsSync{Name: "web", ComposePath: "web/compose.yaml"}
+	if keys != "" {
+		encrypted, err := encryptSopsAgeKeyInternal(keys)
+		require.NoError(t, err)
+		sync.SopsAgeKey = &encrypted
+	}
+	return sync
+}
+
+func TestDecryptSyncFileInternal(t *testing.T) {
+	encrypted := readSopsFixtureInternal(t, "secrets.enc.env")
+
+	t.Run("plain files are unchanged", func(t *testing.T) {
+		plain := readSopsFixtureInternal(t, "secrets.env")
+		got, decrypted, err := decryptSyncFileInternal(sopsSyncInternal(t, ""), "web/.env", plain)
+		require.NoError(t, err)
+		assert.False(t, decrypted)
+		assert.Equal(t, plain, got)
+	})
+
+	t.Run("encrypted files are decrypted with the stored key", func(t *testing.T) {
+		sync := sopsSyncInternal(t, readSopsFixtureInternal(t, "age.key"))
+		got, decrypted, err := decryptSyncFileInternal(sync, "web/.env", encrypted)
+		require.NoError(t, err)
+		assert.True(t, decrypted)
+		assert.Equal(t, readSopsFixtureInternal(t, "secrets.env"), got)
+	})
+
+	t.Run("encrypted files need a key", func(t *testing.T) {
+		_, _, err := decryptSyncFileInternal(sopsSyncInternal(t, ""), "web/.env", encrypted)
+		require.ErrorIs(t, err, ErrGitOpsSopsNoKey)
+	})
+
+	t.Run("other keys are rejected", func(t *testing.T) {
+		other, err := age.GenerateX25519Identity()
+		require.NoError(t, err)
+		_, _, err = decryptSyncFileInternal(sopsSyncInternal(t, other.String()), "web/.env", encrypted)
+		require.ErrorIs(t, err, sops.ErrNoMatchingKey)
+		assert.NotContains(t, err.Error(), "s3cr3t")
+	})
+}
+
+func TestEncryptSopsAgeKeyInternal(t *testing.T) {
+	sopsSyncInternal(t, "")
+
+	_, err := encryptSopsAgeKeyInternal("AGE-SECRET-KEY-1NOTAKEY")
+	var validationErr *models.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "sopsAgeKey", validationErr.Field)
+
+	keys := readSopsFixtureInternal(t, "age.key")
+	encrypted, err := encryptSopsAgeKeyInternal(keys)
+	require.NoError(t, err)
+	assert.NotContains(t, encrypted, "AGE-SECRET-KEY")
+	decrypted, err := crypto.Decrypt(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, keys, decrypted)
+}
+
+func TestProjectService_UpdateProjectWithoutRevision(t *testing.T) {
+	ctx := context.Background()
+	db := setupProjectTestDB(t)
+	require.NoError(t, db.AutoMigrate(&models.ProjectRevision{}))
+	settingsService, err := NewSettingsService(ctx, db)
+	require.NoError(t, err)
+	root := t.TempDir()
+	require.NoError(t, settingsService.UpdateSetting(ctx, "projectsDirectory", root))
+	require.NoError(t, settingsService.LoadDatabaseSettings(ctx))
+	svc := NewProjectService(db, settingsService, nil, nil, nil, nil)
+
+	dir := filepath.Join(root, "web")
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "compose.yaml"), []byte("services:\n  app:\n    image: nginx:1.25\n"), 0o600))
+	require.NoError(t, db.Create(&models.Project{BaseModel: models.BaseModel{ID: "p1"}, Name: "web", Path: dir}).Error)
+
+	compose := "services:\n  app:\n    image: nginx:1.27\n"
+	env := "DB_PASSWORD=s3cr3t\n"
+	_, err = svc.updateProjectInternal(ctx, "p1", nil, &compose, &env, projectRevisionSourceNone, systemUser)
+	require.NoError(t, err)
+
+	written, err := os.ReadFile(filepath.Join(dir, ".env"))
+	require.NoError(t, err)
+	assert.Equal(t, env, string(written))
+
+	revisions, _, err := svc.ListProjectRevisions(ctx, "p1", pagination.QueryParams{})
+	require.NoError(t, err)
+	assert.Empty(t, revisions)
+}
+
+func TestProjectService_SopsDecryptedProjectsStoreNoRevisions(t *testing.T) {
+	ctx := context.Background()
+	db := setupProjectTestDB(t)
+	require.NoError(t, db.AutoMigrate(&models.ProjectRevision{}))
+	settingsService, err := NewSettingsService(ctx, db)
+	require.NoError(t, err)
+	root := t.TempDir()
+	require.NoError(t, settingsService.UpdateSetting(ctx, "projectsDirectory", root))
+	require.NoError(t, settingsService.LoadDatabaseSettings(ctx))
+	svc := NewProjectService(db, settingsService, nil, nil, nil, nil)
+
+	dir := filepath.Join(root, "web")
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "compose.yaml"), []byte("services:\n  web:\n    image: nginx\n"), 0o600))
+	proj := &models.Project{BaseModel: models.BaseModel{ID: "p1"}, Name: "web", Path: dir}
+	require.NoError(t, db.Create(proj).Error)
+
+	// Revisions from before the repository was encrypted are dropped once it is.
+	plain := "services:\n  web:\n    image: nginx:1.27\n"
+	_, err = svc.UpdateProject(ctx, "p1", nil, &plain, nil, systemUser)
+	require.NoError(t, err)
+	var count int64
+	require.NoError(t, db.Model(&models.ProjectRevision{}).Where("project_id = ?", "p1").Count(&count).Error)
+	require.EqualValues(t, 2, count)
+
+	// A GitOps sync writes the decrypted files without a revision and flags the project.
+	sync := sopsSyncInternal(t, readSopsFixtureInternal(t, "age.key"))
+	compose, decrypted, err := decryptSyncFileInternal(sync, "web/compose.yaml", readSopsFixtureInternal(t, "compose.enc.yaml"))
+	require.NoError(t, err)
+	require.True(t, decrypted)
+	require.Contains(t, compose, "hunter2")
+	_, err = svc.updateProjectInternal(ctx, "p1", nil, &compose, nil, projectRevisionSourceNone, systemUser)
+	require.NoError(t, err)
+	require.NoError(t, svc.setProjectSopsDecryptedInternal(ctx, proj, true))
+
+	// Editing the decrypted project in the UI stores nothing either.
+	edited := strings.Replace(compose, "postgres:16", "postgres:17", 1)
+	_, err = svc.UpdateProject(ctx, "p1", nil, &edited, nil, systemUser)
+	require.NoError(t, err)
+
+	require.NoError(t, db.Model(&models.ProjectRevision{}).Where("project_id = ?", "p1").Count(&count).Error)
+	assert.Zero(t, count)
+	_, err = svc.GetProjectRevision(ctx, "p1", 1)
+	require.ErrorIs(t, err, ErrProjectRevisionNotFound)
+
+	written, err := os.ReadFile(filepath.Join(dir, "compose.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, edited, string(written))
+}
@@ -0,0 +1,223 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/getarcaneapp/arcane/backend/internal/models"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/mapper"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/pagination"
+	"github.com/getarcaneapp/arcane/types/gitops"
+	"gorm.io/gorm"
+)
+
+// maxSyncRunOutputBytes caps the log stored with a sync run.
+const maxSyncRunOutputBytes = 64 << 10
+
+// gitOpsSyncRun collects what a sync run did while PerformSync runs.
+type gitOpsSyncRun struct {
+	record models.GitOpsSyncRun
+	mu     sync.Mutex
+	output strings.Builder
+}
+
+func newGitOpsSyncRun(syncID string) *gitOpsSyncRun {
+	return &gitOpsSyncRun{record: models.GitOpsSyncRun{SyncID: syncID, ChangedFiles: []string{}, StartedAt: time.Now()}}
+}
+
+// logfInternal appends a line to the run's output.
+func (r *gitOpsSyncRun) logfInternal(format string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	fmt.Fprintf(&r.output, "%s %s\n", time.Now().Format(time.TimeOnly), fmt.Sprintf(format, args...))
+}
+
+// outputInternal returns the run's output so far.
+func (r *gitOpsSyncRun) outputInternal() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.output.String()
+}
+
+// progressWriterInternal returns a writer for the JSON-line progress of a project deploy
+// that appends each update to the run's output. Deploys report progress from another
+// goroutine, hence the lock around the output.
+func (r *gitOpsSyncRun) progressWriterInternal() io.Writer {
+	return &syncRunProgressWriter{run: r}
+}
+
+type syncRunProgressWriter struct {
+	run     *gitOpsSyncRun
+	pending []byte
+}
+
+func (w *syncRunProgressWriter) Write(p []byte) (int, error) {
+	w.pending = append(w.pending, p...)
+	for {
+		i := bytes.IndexByte(w.pending, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		line := strings.TrimSpace(string(w.pending[:i]))
+		w.pending = w.pending[i+1:]
+		if line != "" {
+			w.run.logfInternal("%s", formatSyncProgressLineInternal(line))
+		}
+	}
+}
+
+// formatSyncProgressLineInternal turns a deploy or build progress line into a log line.
+// Lines that are not progress updates are kept as they are.
+func formatSyncProgressLineInternal(line string) string {
+	var msg struct {
+		Type    string `json:"type"`
+		Phase   string `json:"phase"`
+		Service string `json:"service"`
+		State   string `json:"state"`
+		Status  string `json:"status"`
+		Stream  string `json:"stream"`
+		Error   string `json:"error"`
+	}
+	if err := json.Unmarshal([]byte(line), &msg); err != nil || msg.Type == "" {
+		return line
+	}
+	switch {
+	case msg.Error != "":
+		return fmt.Sprintf("%s %s: %s", msg.Type, msg.Service, msg.Error)
+	case msg.Stream != "":
+		return fmt.Sprintf("%s %s: %s", msg.Type, msg.Service, strings.TrimSpace(msg.Stream))
+	case msg.Service != "":
+		status := msg.Status
+		if status == "" {
+			status = msg.State
+		}
+		return fmt.Sprintf("%s %s: %s", msg.Type, msg.Service, status)
+	default:
+		return fmt.Sprintf("%s %s", msg.Type, msg.Phase)
+	}
+}
+
+// saveSyncRunInternal stores a finished run with its result. Failing to store it is
+// logged but does not fail the sync.
+func (s *GitOpsSyncService) saveSyncRunInternal(ctx context.Context, run *gitOpsSyncRun, result *gitops.SyncResult) {
+	record := &run.record
+	record.Status = "failed"
+	if result.Success {
+		record.Status = "success"
+	}
+	record.Message = result.Message
+	record.Error = result.Error
+	if result.Error != nil {
+		run.logfInternal("%s: %s", result.Message, *result.Error)
+	}
+	record.Output = truncateSyncRunOutputInternal(run.outputInternal())
+	record.DurationMs = time.Since(record.StartedAt).Milliseconds()
+
+	if err := s.db.WithContext(ctx).Create(record).Error; err != nil {
+		slog.ErrorContext(ctx, "Failed to record GitOps sync run", "syncId", record.SyncID, "error", err)
+	}
+}
+
+// truncateSyncRunOutputInternal caps output at maxSyncRunOutputBytes, cutting before a
+// multi-byte character rather than through it.
+func truncateSyncRunOutputInternal(output string) string {
+	if len(output) <= maxSyncRunOutputBytes {
+		return output
+	}
+	cut := maxSyncRunOutputBytes
+	for cut > 0 && !utf8.RuneStart(output[cut]) {
+		cut--
+	}
+	return output[:cut] + "\n...<truncated>"
+}
+
+// recordChangedFilesInternal sets the commit of a run and the files changed since the
+// commit the previous run deployed.
+func (s *GitOpsSyncService) recordChangedFilesInternal(ctx context.Context, run *gitOpsSyncRun, gitSync *models.GitOpsSync, repoPath string) {
+	commit, err := s.repoService.gitClient.HeadCommit(ctx, repoPath)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to read commit", "error", err)
+		return
+	}
+	run.record.Commit = commit.Hash
+	run.record.CommitMessage = commit.Message
+	run.record.CommitAuthor = commit.Author
+	run.logfInternal("Checked out commit %s by %s: %s", shortCommitInternal(commit.Hash), commit.Author, commit.Message)
+
+	previous := ""
+	if gitSync.LastSyncCommit != nil {
+		previous = *gitSync.LastSyncCommit
+	}
+	switch previous {
+	case "":
+		return
+	case commit.Hash:
+		run.logfInternal("No new commits since the previous run")
+		return
+	}
+
+	files, err := s.repoService.gitClient.ChangedFiles(ctx, repoPath, previous)
+	if err != nil {
+		run.logfInternal("Could not list changed files since %s: %v", shortCommitInternal(previous), err)
+		return
+	}
+	run.record.ChangedFiles = files
+	run.logfInternal("%d files changed since %s", len(files), shortCommitInternal(previous))
+}
+
+func shortCommitInternal(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
+
+// ListSyncRuns returns the recorded runs of a sync, newest first unless params sort
+// otherwise.
+func (s *GitOpsSyncService) ListSyncRuns(ctx context.Context, environmentID, id string, params pagination.QueryParams) ([]gitops.SyncRun, pagination.Response, error) {
+	if _, err := s.GetSyncByID(ctx, environmentID, id); err != nil {
+		return nil, pagination.Response{}, err
+	}
+
+	query := s.db.WithContext(ctx).Model(&models.GitOpsSyncRun{}).Where("sync_id = ?", id)
+	query = pagination.ApplyFilter(query, "status", params.Filters["status"])
+	if params.Sort == "" {
+		query = query.Order("started_at DESC")
+	}
+
+	var runs []models.GitOpsSyncRun
+	paginationResp, err := pagination.PaginateAndSortDB(params, query, &runs)
+	if err != nil {
+		return nil, pagination.Response{}, fmt.Errorf("failed to list sync runs: %w", err)
+	}
+
+	out, err := mapper.MapSlice[models.GitOpsSyncRun, gitops.SyncRun](runs)
+	if err != nil {
+		return nil, pagination.Response{}, fmt.Errorf("failed to map sync runs: %w", err)
+	}
+	for i := range out {
+		if out[i].ChangedFiles == nil {
+			out[i].ChangedFiles = []string{}
+		}
+	}
+	return out, paginationResp, nil
+}
+
+// DeleteOldSyncRuns removes the runs of all syncs that started before olderThan ago.
+func (s *GitOpsSyncService) DeleteOldSyncRuns(ctx context.Context, olderThan time.Duration) error {
+	cutoff := time.Now().Add(-olderThan)
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("started_at < ?", cutoff).Delete(&models.GitOpsSyncRun{}).Error; err != nil {
+			return fmt.Errorf("failed to delete old sync runs: %w", err)
+		}
+		return nil
+	})
+}
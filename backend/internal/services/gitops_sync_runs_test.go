@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/getarcaneapp/arcane/backend/internal/models"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/pagination"
+	"github.com/getarcaneapp/arcane/types/gitops"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitOpsSyncService_SyncRunHistory(t *testing.T) {
+	ctx := context.Background()
+	svc, sync := setupGitOpsWebhookTest(t, "")
+
+	failed := newGitOpsSyncRun(sync.ID)
+	failed.record.StartedAt = time.Now().Add(-2 * time.Hour)
+	failed.logfInternal("Cloning repo at branch main")
+	svc.saveSyncRunInternal(ctx, failed, &gitops.SyncResult{Message: "Failed to clone repository", Error: new("authentication required")})
+
+	succeeded := newGitOpsSyncRun(sync.ID)
+	succeeded.record.Commit = "0d1a26e67d8f5eaf1f6ba5c57fc3c7d91ac0fd1c"
+	succeeded.record.ChangedFiles = []string{"web/docker-compose.yml"}
+	svc.saveSyncRunInternal(ctx, succeeded, &gitops.SyncResult{Success: true, Message: "Successfully synced"})
+
+	params := pagination.QueryParams{
+		PaginationParams: pagination.PaginationParams{Limit: 20},
+		Filters:          map[string]string{},
+	}
+	runs, resp, err := svc.ListSyncRuns(ctx, "0", sync.ID, params)
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	assert.Equal(t, int64(2), resp.TotalItems)
+
+	assert.Equal(t, "success", runs[0].Status, "newest run first")
+	assert.Equal(t, []string{"web/docker-compose.yml"}, runs[0].ChangedFiles)
+	assert.Nil(t, runs[0].Error)
+
+	assert.Equal(t, "failed", runs[1].Status)
+	require.NotNil(t, runs[1].Error)
+	assert.Equal(t, "authentication required", *runs[1].Error)
+	assert.Equal(t, []string{}, runs[1].ChangedFiles)
+	assert.Contains(t, runs[1].Output, "Cloning repo at branch main")
+	assert.Contains(t, runs[1].Output, "Failed to clone repository: authentication required")
+	assert.GreaterOrEqual(t, runs[1].DurationMs, (2 * time.Hour).Milliseconds())
+
+	params.Filters["status"] = "failed"
+	runs, _, err = svc.ListSyncRuns(ctx, "0", sync.ID, params)
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, "failed", runs[0].Status)
+
+	_, _, err = svc.ListSyncRuns(ctx, "other", sync.ID, params)
+	require.Error(t, err, "runs are scoped to the sync's environment")
+
+	require.NoError(t, svc.DeleteOldSyncRuns(ctx, time.Hour))
+	var remaining []models.GitOpsSyncRun
+	require.NoError(t, svc.db.Find(&remaining).Error)
+	require.Len(t, remaining, 1)
+	assert.Equal(t, "success", remaining[0].Status)
+}
+
+func TestTruncateSyncRunOutputInternal(t *testing.T) {
+	assert.Equal(t, "short\n", truncateSyncRunOutputInternal("short\n"))
+
+	truncated := truncateSyncRunOutputInternal(strings.Repeat("x", maxSyncRunOutputBytes+10))
+	assert.True(t, strings.HasSuffix(truncated, "...<truncated>"))
+	assert.Len(t, truncated, maxSyncRunOutputBytes+len("\n...<truncated>"))
+
+	// A three-byte character straddling the limit is dropped whole.
+	truncated = truncateSyncRunOutputInternal(strings.Repeat("x", maxSyncRunOutputBytes-1) + "€tail")
+	assert.True(t, utf8.ValidString(truncated))
+	assert.Equal(t, strings.Repeat("x", maxSyncRunOutputBytes-1)+"\n...<truncated>", truncated)
+}
+
+func TestGitOpsSyncRun_ProgressWriter(t *testing.T) {
+	run := newGitOpsSyncRun("sync-1")
+	w := run.progressWriterInternal()
+
+	_, err := w.Write([]byte(`{"type":"deploy","phase":"begin"}` + "\n" + `{"type":"build","phase":"output","service":"app","stream":"Step 1/2\n"}` + "\n"))
+	require.NoError(t, err)
+	// Lines may arrive in pieces.
+	_, err = w.Write([]byte(`{"type":"deploy","phase":"service_status","service":"app",`))
+	require.NoError(t, err)
+	_, err = w.Write([]byte(`"state":"running","status":"Up 2 seconds"}` + "\nplain line\n"))
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(run.outputInternal()), "\n")
+	require.Len(t, lines, 4)
+	for i, want := range []string{"deploy begin", "build app: Step 1/2", "deploy app: Up 2 seconds", "plain line"} {
+		assert.True(t, strings.HasSuffix(lines[i], " "+want), lines[i])
+	}
+}
@@ -12,8 +12,10 @@ import (
 	"github.com/getarcaneapp/arcane/backend/internal/database"
 	"github.com/getarcaneapp/arcane/backend/internal/models"
 	bootstraputils "github.com/getarcaneapp/arcane/backend/internal/utils"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/crypto"
 	"github.com/getarcaneapp/arcane/backend/internal/utils/mapper"
 	"github.com/getarcaneapp/arcane/backend/internal/utils/pagination"
+	"github.com/getarcaneapp/arcane/backend/pkg/projects"
 	"github.com/getarcaneapp/arcane/types/gitops"
 	"gorm.io/gorm"
 )
@@ -23,6 +25,7 @@ type GitOpsSyncService struct {
 	repoService    *GitRepositoryService
 	projectService *ProjectService
 	eventService   *EventService
+	webhookRuns    *gitOpsWebhookRuns
 }
 
 const defaultGitSyncTimeout = 5 * time.Minute
@@ -33,6 +36,7 @@ func NewGitOpsSyncService(db *database.DB, repoService *GitRepositoryService, pr
 		repoService:    repoService,
 		projectService: projectService,
 		eventService:   eventService,
+		webhookRuns:    newGitOpsWebhookRuns(),
 	}
 }
 
@@ -140,7 +144,7 @@ func (s *GitOpsSyncService) GetSyncByID(ctx context.Context, environmentID, id s
 	if err := q.First(&sync).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			slog.WarnContext(ctx, "GitOps sync not found", "syncID", id, "environmentID", environmentID)
-			return nil, fmt.Errorf("sync not found")
+			return nil, &models.NotFoundError{Message: "sync not found"}
 		}
 		slog.ErrorContext(ctx, "Failed to get GitOps sync", "syncID", id, "environmentID", environmentID, "error", err)
 		return nil, fmt.Errorf("failed to get sync: %w", err)
@@ -170,11 +174,13 @@ func (s *GitOpsSyncService) CreateSync(ctx context.Context, environmentID string
 		EnvironmentID: environmentID,
 		RepositoryID:  req.RepositoryID,
 		Branch:        req.Branch,
+		RefType:       gitops.RefTypeBranch,
 		ComposePath:   req.ComposePath,
 		ProjectName:   projectName,
 		ProjectID:     nil, // Will be set during first sync
 		AutoSync:      false,
 		SyncInterval:  60,
+		DriftAction:   gitops.DriftActionReport,
 	}
 
 	if req.AutoSync != nil {
@@ -183,6 +189,35 @@ func (s *GitOpsSyncService) CreateSync(ctx context.Context, environmentID string
 	if req.SyncInterval != nil {
 		sync.SyncInterval = *req.SyncInterval
 	}
+	if req.RefType != nil {
+		sync.RefType = *req.RefType
+	}
+	if req.Ref != nil && sync.RefType != gitops.RefTypeBranch {
+		sync.Ref = *req.Ref
+	}
+	if err := validateSyncRefInternal(sync.RefType, sync.Ref); err != nil {
+		return nil, err
+	}
+	if req.DriftAction != nil {
+		if err := validateDriftActionInternal(*req.DriftAction); err != nil {
+			return nil, err
+		}
+		sync.DriftAction = *req.DriftAction
+	}
+	if req.WebhookSecret != nil && *req.WebhookSecret != "" {
+		encrypted, err := crypto.Encrypt(*req.WebhookSecret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt webhook secret: %w", err)
+		}
+		sync.WebhookSecret = &encrypted
+	}
+	if req.SopsAgeKey != nil && *req.SopsAgeKey != "" {
+		encrypted, err := encryptSopsAgeKeyInternal(*req.SopsAgeKey)
+		if err != nil {
+			return nil, err
+		}
+		sync.SopsAgeKey = &encrypted
+	}
 
 	if err := s.db.WithContext(ctx).Create(&sync).Error; err != nil {
 		slog.ErrorContext(ctx, "Failed to create GitOps sync in database", "name", req.Name, "repositoryID", req.RepositoryID, "environmentID", environmentID, "error", err)
@@ -245,6 +280,49 @@ func (s *GitOpsSyncService) UpdateSync(ctx context.Context, environmentID, id st
 	if req.SyncInterval != nil {
 		updates["sync_interval"] = *req.SyncInterval
 	}
+	if req.RefType != nil || req.Ref != nil {
+		refType, ref := syncRefInternal(sync).Type, sync.Ref
+		if req.RefType != nil {
+			refType = *req.RefType
+		}
+		if req.Ref != nil {
+			ref = *req.Ref
+		}
+		if refType == "" || refType == gitops.RefTypeBranch {
+			refType, ref = gitops.RefTypeBranch, ""
+		}
+		if err := validateSyncRefInternal(refType, ref); err != nil {
+			return nil, err
+		}
+		updates["ref_type"] = refType
+		updates["ref"] = ref
+	}
+	if req.DriftAction != nil {
+		if err := validateDriftActionInternal(*req.DriftAction); err != nil {
+			return nil, err
+		}
+		updates["drift_action"] = *req.DriftAction
+	}
+	if req.WebhookSecret != nil {
+		updates["webhook_secret"] = nil
+		if *req.WebhookSecret != "" {
+			encrypted, err := crypto.Encrypt(*req.WebhookSecret)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encrypt webhook secret: %w", err)
+			}
+			updates["webhook_secret"] = encrypted
+		}
+	}
+	if req.SopsAgeKey != nil {
+		updates["sops_age_key"] = nil
+		if *req.SopsAgeKey != "" {
+			encrypted, err := encryptSopsAgeKeyInternal(*req.SopsAgeKey)
+			if err != nil {
+				return nil, err
+			}
+			updates["sops_age_key"] = encrypted
+		}
+	}
 
 	if len(updates) > 0 {
 		if err := s.db.WithContext(ctx).Model(sync).Updates(updates).Error; err != nil {
@@ -283,6 +361,10 @@ func (s *GitOpsSyncService) DeleteSync(ctx context.Context, environmentID, id st
 			}
 		}
 
+		if err := tx.Where("sync_id = ?", id).Delete(&models.GitOpsSyncRun{}).Error; err != nil {
+			return fmt.Errorf("failed to delete sync runs: %w", err)
+		}
+
 		if err := tx.Where("id = ?", id).Delete(&models.GitOpsSync{}).Error; err != nil {
 			return fmt.Errorf("failed to delete sync: %w", err)
 		}
@@ -321,6 +403,10 @@ func (s *GitOpsSyncService) PerformSync(ctx context.Context, environmentID, id s
 		SyncedAt: time.Now(),
 	}
 
+	// Record the run however it ends, even when the sync timed out
+	run := newGitOpsSyncRun(sync.ID)
+	defer s.saveSyncRunInternal(context.WithoutCancel(syncCtx), run, result)
+
 	// Get repository and auth config
 	repository := sync.Repository
 	if repository == nil {
@@ -332,23 +418,22 @@ func (s *GitOpsSyncService) PerformSync(ctx context.Context, environmentID, id s
 		return result, s.failSync(syncCtx, id, result, sync, "Failed to get authentication config", err.Error())
 	}
 
-	// Clone the repository
-	repoPath, err := s.repoService.gitClient.Clone(syncCtx, repository.URL, sync.Branch, authConfig)
+	// Clone the repository at the ref the sync deploys
+	run.logfInternal("Cloning %s at %s", repository.Name, syncRefInternal(sync))
+	repoPath, resolvedRef, err := s.cloneSyncRefInternal(syncCtx, sync, authConfig)
 	if err != nil {
 		return result, s.failSync(syncCtx, id, result, sync, "Failed to clone repository", err.Error())
 	}
+	run.record.Ref = resolvedRef
 	defer func() {
 		if cleanupErr := s.repoService.gitClient.Cleanup(repoPath); cleanupErr != nil {
 			slog.WarnContext(syncCtx, "Failed to cleanup repository", "path", repoPath, "error", cleanupErr)
 		}
 	}()
 
-	// Get the current commit hash
-	commitHash, err := s.repoService.gitClient.GetCurrentCommit(syncCtx, repoPath)
-	if err != nil {
-		slog.WarnContext(syncCtx, "Failed to get commit hash", "error", err)
-		commitHash = ""
-	}
+	// Get the current commit and the files changed since the previous run
+	s.recordChangedFilesInternal(syncCtx, run, sync, repoPath)
+	commitHash := run.record.Commit
 
 	// Check if compose file exists
 	if !s.repoService.gitClient.FileExists(syncCtx, repoPath, sync.ComposePath) {
@@ -362,29 +447,54 @@ func (s *GitOpsSyncService) PerformSync(ctx context.Context, environmentID, id s
 		return result, s.failSync(syncCtx, id, result, sync, "Failed to read compose file", err.Error())
 	}
 
+	composeContent, composeDecrypted, err := decryptSyncFileInternal(sync, sync.ComposePath, composeContent)
+	if err != nil {
+		return result, s.failSync(syncCtx, id, result, sync, "Failed to decrypt compose file", err.Error())
+	}
+	if composeDecrypted {
+		run.logfInternal("Decrypted %s", sync.ComposePath)
+	}
+
 	// Try to read .env file from the same directory as the compose file
 	var envContent *string
+	envDecrypted := false
 	envPath := filepath.Join(filepath.Dir(sync.ComposePath), ".env")
 	if s.repoService.gitClient.FileExists(syncCtx, repoPath, envPath) {
 		content, err := s.repoService.gitClient.ReadFile(syncCtx, repoPath, envPath)
 		if err != nil {
 			slog.WarnContext(syncCtx, "Failed to read .env file", "path", envPath, "error", err)
 		} else {
+			content, envDecrypted, err = decryptSyncFileInternal(sync, envPath, content)
+			if err != nil {
+				return result, s.failSync(syncCtx, id, result, sync, "Failed to decrypt .env file", err.Error())
+			}
+			if envDecrypted {
+				run.logfInternal("Decrypted %s", envPath)
+			}
 			envContent = &content
 		}
 	}
 
+	// Decrypted files are only written to the project directory, never stored as revisions
+	revisionSource := models.ProjectRevisionSourceGitOps
+	if composeDecrypted || envDecrypted {
+		revisionSource = projectRevisionSourceNone
+	}
+
 	// Get or create project
-	project, err := s.getOrCreateProjectInternal(syncCtx, sync, id, composeContent, envContent, result)
+	project, err := s.getOrCreateProjectInternal(syncCtx, sync, id, composeContent, envContent, revisionSource, run, result)
 	if err != nil {
 		return result, err
 	}
+	if err := s.projectService.setProjectSopsDecryptedInternal(syncCtx, project, composeDecrypted || envDecrypted); err != nil {
+		slog.ErrorContext(syncCtx, "Failed to flag project with decrypted files", "projectId", project.ID, "error", err)
+	}
 
 	// Update sync status
 	s.updateSyncStatus(syncCtx, id, "success", "", commitHash)
 
 	result.Success = true
-	result.Message = fmt.Sprintf("Successfully synced compose file from %s to project %s", sync.ComposePath, project.Name)
+	result.Message = fmt.Sprintf("Successfully synced compose file from %s at %s to project %s", sync.ComposePath, resolvedRef, project.Name)
 
 	// Log success event
 	_, _ = s.eventService.CreateEvent(syncCtx, CreateEventRequest{
@@ -439,6 +549,10 @@ func (s *GitOpsSyncService) GetSyncStatus(ctx context.Context, environmentID, id
 		LastSyncStatus: sync.LastSyncStatus,
 		LastSyncError:  sync.LastSyncError,
 		LastSyncCommit: sync.LastSyncCommit,
+		DriftStatus:    sync.DriftStatus,
+		DriftCheckedAt: sync.DriftCheckedAt,
+		DriftError:     sync.DriftError,
+		DriftItems:     sync.DriftItems,
 	}
 
 	// Calculate next sync time
@@ -484,6 +598,8 @@ func (s *GitOpsSyncService) SyncAllEnabled(ctx context.Context) error {
 	return nil
 }
 
+// BrowseFiles lists the files of a sync's repository. It returns the file tree only, so
+// SOPS-encrypted files are never decrypted for it.
 func (s *GitOpsSyncService) BrowseFiles(ctx context.Context, environmentID, id string, path string) (*gitops.BrowseResponse, error) {
 	browseCtx, cancel := context.WithTimeout(ctx, defaultGitSyncTimeout)
 	defer cancel()
@@ -504,7 +620,7 @@ func (s *GitOpsSyncService) BrowseFiles(ctx context.Context, environmentID, id s
 	}
 
 	// Clone the repository
-	repoPath, err := s.repoService.gitClient.Clone(browseCtx, repository.URL, sync.Branch, authConfig)
+	repoPath, _, err := s.cloneSyncRefInternal(browseCtx, sync, authConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to clone repository: %w", err)
 	}
@@ -586,8 +702,11 @@ func (s *GitOpsSyncService) failSync(ctx context.Context, id string, result *git
 	return fmt.Errorf("%s", errMsg)
 }
 
-func (s *GitOpsSyncService) createProjectForSyncInternal(ctx context.Context, sync *models.GitOpsSync, id string, composeContent string, envContent *string, result *gitops.SyncResult) (*models.Project, error) {
-	project, err := s.projectService.CreateProject(ctx, sync.ProjectName, composeContent, envContent, systemUser)
+func (s *GitOpsSyncService) createProjectForSyncInternal(ctx context.Context, sync *models.GitOpsSync, id string, composeContent string, envContent *string, revisionSource string, run *gitOpsSyncRun, result *gitops.SyncResult) (*models.Project, error) {
+	if revisionSource == models.ProjectRevisionSourceGitOps {
+		revisionSource = models.ProjectRevisionSourceCreate
+	}
+	project, err := s.projectService.createProjectInternal(ctx, sync.ProjectName, composeContent, envContent, revisionSource, systemUser)
 	if err != nil {
 		return nil, s.failSync(ctx, id, result, sync, "Failed to create project", err.Error())
 	}
@@ -605,17 +724,22 @@ func (s *GitOpsSyncService) createProjectForSyncInternal(ctx context.Context, sy
 	}
 
 	slog.InfoContext(ctx, "Created project for GitOps sync", "projectName", sync.ProjectName, "projectId", project.ID)
+	run.logfInternal("Created project %s", project.Name)
 
 	// Deploy the project immediately after creation
 	slog.InfoContext(ctx, "Deploying project after initial Git sync", "projectName", project.Name, "projectId", project.ID)
-	if err := s.projectService.DeployProject(ctx, project.ID, systemUser); err != nil {
+	deployCtx := context.WithValue(ctx, projects.ProgressWriterKey{}, run.progressWriterInternal())
+	if err := s.projectService.DeployProject(deployCtx, project.ID, systemUser); err != nil {
 		slog.ErrorContext(ctx, "Failed to deploy project after initial Git sync", "error", err, "projectId", project.ID)
+		run.logfInternal("Failed to deploy project %s: %v", project.Name, err)
+	} else {
+		run.logfInternal("Deployed project %s", project.Name)
 	}
 
 	return project, nil
 }
 
-func (s *GitOpsSyncService) getOrCreateProjectInternal(ctx context.Context, sync *models.GitOpsSync, id string, composeContent string, envContent *string, result *gitops.SyncResult) (*models.Project, error) {
+func (s *GitOpsSyncService) getOrCreateProjectInternal(ctx context.Context, sync *models.GitOpsSync, id string, composeContent string, envContent *string, revisionSource string, run *gitOpsSyncRun, result *gitops.SyncResult) (*models.Project, error) {
 	var project *models.Project
 	var err error
 
@@ -628,16 +752,16 @@ func (s *GitOpsSyncService) getOrCreateProjectInternal(ctx context.Context, sync
 	}
 
 	if project == nil {
-		return s.createProjectForSyncInternal(ctx, sync, id, composeContent, envContent, result)
+		return s.createProjectForSyncInternal(ctx, sync, id, composeContent, envContent, revisionSource, run, result)
 	}
 
-	if err := s.updateProjectForSyncInternal(ctx, sync, id, project, composeContent, envContent, result); err != nil {
+	if err := s.updateProjectForSyncInternal(ctx, sync, id, project, composeContent, envContent, revisionSource, run, result); err != nil {
 		return nil, err
 	}
 	return project, nil
 }
 
-func (s *GitOpsSyncService) updateProjectForSyncInternal(ctx context.Context, sync *models.GitOpsSync, id string, project *models.Project, composeContent string, envContent *string, result *gitops.SyncResult) error {
+func (s *GitOpsSyncService) updateProjectForSyncInternal(ctx context.Context, sync *models.GitOpsSync, id string, project *models.Project, composeContent string, envContent *string, revisionSource string, run *gitOpsSyncRun, result *gitops.SyncResult) error {
 	// Get current content to see if it changed
 	oldCompose, oldEnv, _ := s.projectService.GetProjectContent(ctx, project.ID)
 	contentChanged := oldCompose != composeContent
@@ -650,21 +774,30 @@ func (s *GitOpsSyncService) updateProjectForSyncInternal(ctx context.Context, sy
 	}
 
 	// Update existing project's compose and env files
-	_, err := s.projectService.UpdateProject(ctx, project.ID, nil, &composeContent, envContent)
+	_, err := s.projectService.updateProjectInternal(ctx, project.ID, nil, &composeContent, envContent, revisionSource, systemUser)
 	if err != nil {
 		return s.failSync(ctx, id, result, sync, "Failed to update project files", err.Error())
 	}
 	slog.InfoContext(ctx, "Updated project files", "projectName", project.Name, "projectId", project.ID)
+	if !contentChanged {
+		run.logfInternal("Project files of %s are unchanged", project.Name)
+		return nil
+	}
+	run.logfInternal("Updated project files of %s", project.Name)
 
 	// If content changed and project is running, redeploy
-	if contentChanged {
-		details, err := s.projectService.GetProjectDetails(ctx, project.ID)
-		if err == nil && (details.Status == string(models.ProjectStatusRunning) || details.Status == string(models.ProjectStatusPartiallyRunning)) {
-			slog.InfoContext(ctx, "Redeploying project due to content change from Git sync", "projectName", project.Name, "projectId", project.ID)
-			if err := s.projectService.RedeployProject(ctx, project.ID, systemUser); err != nil {
-				slog.ErrorContext(ctx, "Failed to redeploy project after Git sync", "error", err, "projectId", project.ID)
-			}
+	details, err := s.projectService.GetProjectDetails(ctx, project.ID)
+	if err == nil && (details.Status == string(models.ProjectStatusRunning) || details.Status == string(models.ProjectStatusPartiallyRunning)) {
+		slog.InfoContext(ctx, "Redeploying project due to content change from Git sync", "projectName", project.Name, "projectId", project.ID)
+		deployCtx := context.WithValue(ctx, projects.ProgressWriterKey{}, run.progressWriterInternal())
+		if err := s.projectService.RedeployProject(deployCtx, project.ID, systemUser); err != nil {
+			slog.ErrorContext(ctx, "Failed to redeploy project after Git sync", "error", err, "projectId", project.ID)
+			run.logfInternal("Failed to redeploy project %s: %v", project.Name, err)
+		} else {
+			run.logfInternal("Redeployed project %s", project.Name)
 		}
+	} else {
+		run.logfInternal("Project %s is not running, skipped redeploy", project.Name)
 	}
 
 	return nil
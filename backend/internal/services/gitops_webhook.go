@@ -0,0 +1,126 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+
+	"github.com/getarcaneapp/arcane/backend/internal/utils/crypto"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/git"
+	"github.com/getarcaneapp/arcane/types/gitops"
+)
+
+var (
+	// ErrGitOpsWebhookUnauthorized is returned for webhooks that fail verification,
+	// including webhooks for syncs without a webhook secret.
+	ErrGitOpsWebhookUnauthorized = errors.New("webhook verification failed")
+	// ErrGitOpsWebhookInvalid is returned for requests that are not webhooks of a
+	// supported provider, or carry a malformed payload.
+	ErrGitOpsWebhookInvalid = errors.New("invalid webhook")
+)
+
+// HandleWebhook verifies a push webhook for a sync and, when the push updated the sync's
+// branch, starts the sync in the background. Pushes to other branches, tag pushes, ping
+// events and pushes for syncs pinned to a tag, commit or semver range are acknowledged
+// without syncing.
+func (s *GitOpsSyncService) HandleWebhook(ctx context.Context, environmentID, id string, header http.Header, body []byte) (*gitops.WebhookResult, error) {
+	gitSync, err := s.GetSyncByID(ctx, environmentID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var secret string
+	if gitSync.WebhookSecret != nil && *gitSync.WebhookSecret != "" {
+		if secret, err = crypto.Decrypt(*gitSync.WebhookSecret); err != nil {
+			return nil, fmt.Errorf("failed to decrypt webhook secret: %w", err)
+		}
+	}
+
+	push, err := git.ParseWebhook(header, body, secret)
+	switch {
+	case errors.Is(err, git.ErrWebhookSignature):
+		slog.WarnContext(ctx, "Rejected GitOps webhook with invalid signature", "syncID", id, "webhookEnabled", secret != "")
+		return nil, fmt.Errorf("%w: %w", ErrGitOpsWebhookUnauthorized, err)
+	case errors.Is(err, git.ErrWebhookUnknownProvider), errors.Is(err, git.ErrWebhookPayload):
+		return nil, fmt.Errorf("%w: %w", ErrGitOpsWebhookInvalid, err)
+	case err != nil:
+		return nil, err
+	}
+
+	if push.Ping {
+		return &gitops.WebhookResult{Message: fmt.Sprintf("Webhook from %s verified", push.Provider)}, nil
+	}
+	if ref := syncRefInternal(gitSync); ref.Type != "" && ref.Type != gitops.RefTypeBranch {
+		return &gitops.WebhookResult{Message: fmt.Sprintf("Sync is pinned to %s", ref)}, nil
+	}
+	commit, ok := push.Commit(gitSync.Branch)
+	if !ok {
+		return &gitops.WebhookResult{Message: fmt.Sprintf("Push did not update branch %s", gitSync.Branch)}, nil
+	}
+	slog.InfoContext(ctx, "GitOps webhook received", "syncID", id, "provider", push.Provider, "branch", gitSync.Branch, "commit", commit)
+
+	if !s.webhookRuns.startInternal(id) {
+		return &gitops.WebhookResult{
+			Triggered: true,
+			Message:   "A sync is already running; another sync was queued",
+			Commit:    commit,
+		}, nil
+	}
+	go s.runWebhookSyncInternal(context.WithoutCancel(ctx), gitSync.EnvironmentID, id)
+
+	return &gitops.WebhookResult{Triggered: true, Message: "Sync started", Commit: commit}, nil
+}
+
+// runWebhookSyncInternal runs a sync and repeats it while pushes arrive during the run,
+// so the last push is always deployed.
+func (s *GitOpsSyncService) runWebhookSyncInternal(ctx context.Context, environmentID, id string) {
+	for {
+		if _, err := s.PerformSync(ctx, environmentID, id); err != nil {
+			slog.ErrorContext(ctx, "Webhook triggered GitOps sync failed", "syncID", id, "error", err)
+		}
+		if !s.webhookRuns.finishInternal(id) {
+			return
+		}
+	}
+}
+
+// gitOpsWebhookRuns tracks the syncs started by webhooks. A push that arrives while its
+// sync is running marks the sync for another run instead of starting a concurrent one.
+type gitOpsWebhookRuns struct {
+	mu sync.Mutex
+	// running maps the ID of each running sync to whether another run was requested.
+	running map[string]bool
+}
+
+func newGitOpsWebhookRuns() *gitOpsWebhookRuns {
+	return &gitOpsWebhookRuns{running: map[string]bool{}}
+}
+
+// startInternal marks a sync as running. It returns false, and queues another run, if
+// the sync is already running.
+func (r *gitOpsWebhookRuns) startInternal(id string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.running[id]; ok {
+		r.running[id] = true
+		return false
+	}
+	r.running[id] = false
+	return true
+}
+
+// finishInternal is called when a run ends. It returns true if another run was queued,
+// in which case the sync stays marked as running.
+func (r *gitOpsWebhookRuns) finishInternal(id string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.running[id] {
+		r.running[id] = false
+		return true
+	}
+	delete(r.running, id)
+	return false
+}
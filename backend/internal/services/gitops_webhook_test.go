@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/getarcaneapp/arcane/backend/internal/config"
+	"github.com/getarcaneapp/arcane/backend/internal/database"
+	"github.com/getarcaneapp/arcane/backend/internal/models"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/crypto"
+	glsqlite "github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func setupGitOpsWebhookTest(t *testing.T, secret string) (*GitOpsSyncService, *models.GitOpsSync) {
+	t.Helper()
+	db, err := gorm.Open(glsqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.GitRepository{}, &models.Project{}, &models.GitOpsSync{}, &models.GitOpsSyncRun{}))
+
+	crypto.InitEncryption(&config.Config{
+		EncryptionKey: "test-encryption-key-for-testing-32bytes-min",
+		Environment:   "test",
+	})
+
+	sync := &models.GitOpsSync{
+		BaseModel:     models.BaseModel{ID: "sync-1"},
+		Name:          "web",
+		EnvironmentID: "0",
+		RepositoryID:  "repo-1",
+		Branch:        "main",
+		ComposePath:   "web/docker-compose.yml",
+		ProjectName:   "web",
+	}
+	if secret != "" {
+		encrypted, err := crypto.Encrypt(secret)
+		require.NoError(t, err)
+		sync.WebhookSecret = &encrypted
+	}
+	require.NoError(t, db.Create(sync).Error)
+
+	return NewGitOpsSyncService(&database.DB{DB: db}, nil, nil, nil), sync
+}
+
+func gitHubWebhookRequestInternal(t *testing.T, fixture, event, secret string) (http.Header, []byte) {
+	t.Helper()
+	body, err := os.ReadFile(filepath.Join("..", "utils", "git", "testdata", "webhooks", fixture))
+	require.NoError(t, err)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return http.Header{
+		"X-Github-Event":      {event},
+		"X-Hub-Signature-256": {"sha256=" + hex.EncodeToString(mac.Sum(nil))},
+	}, body
+}
+
+func TestGitOpsSyncService_HandleWebhook(t *testing.T) {
+	ctx := context.Background()
+	svc, sync := setupGitOpsWebhookTest(t, "hook-secret")
+
+	t.Run("ping is acknowledged", func(t *testing.T) {
+		header, body := gitHubWebhookRequestInternal(t, "github_ping.json", "ping", "hook-secret")
+		result, err := svc.HandleWebhook(ctx, "0", sync.ID, header, body)
+		require.NoError(t, err)
+		assert.False(t, result.Triggered)
+	})
+
+	t.Run("wrong secret is rejected", func(t *testing.T) {
+		header, body := gitHubWebhookRequestInternal(t, "github_push.json", "push", "guess")
+		_, err := svc.HandleWebhook(ctx, "0", sync.ID, header, body)
+		require.ErrorIs(t, err, ErrGitOpsWebhookUnauthorized)
+	})
+
+	t.Run("unknown provider is invalid", func(t *testing.T) {
+		_, err := svc.HandleWebhook(ctx, "0", sync.ID, http.Header{}, []byte(`{}`))
+		require.ErrorIs(t, err, ErrGitOpsWebhookInvalid)
+	})
+
+	t.Run("sync of another environment is not found", func(t *testing.T) {
+		header, body := gitHubWebhookRequestInternal(t, "github_push.json", "push", "hook-secret")
+		_, err := svc.HandleWebhook(ctx, "other-env", sync.ID, header, body)
+		var notFound *models.NotFoundError
+		require.ErrorAs(t, err, &notFound)
+	})
+
+	t.Run("push to another branch does not sync", func(t *testing.T) {
+		require.NoError(t, svc.db.Model(sync).Update("branch", "production").Error)
+		t.Cleanup(func() { require.NoError(t, svc.db.Model(sync).Update("branch", "main").Error) })
+
+		header, body := gitHubWebhookRequestInternal(t, "github_push.json", "push", "hook-secret")
+		result, err := svc.HandleWebhook(ctx, "0", sync.ID, header, body)
+		require.NoError(t, err)
+		assert.False(t, result.Triggered)
+	})
+
+	t.Run("push to a pinned sync does not sync", func(t *testing.T) {
+		require.NoError(t, svc.db.Model(sync).Updates(map[string]any{"ref_type": "tag", "ref": "v1.0.0"}).Error)
+		t.Cleanup(func() {
+			require.NoError(t, svc.db.Model(sync).Updates(map[string]any{"ref_type": "branch", "ref": ""}).Error)
+		})
+
+		header, body := gitHubWebhookRequestInternal(t, "github_push.json", "push", "hook-secret")
+		result, err := svc.HandleWebhook(ctx, "0", sync.ID, header, body)
+		require.NoError(t, err)
+		assert.False(t, result.Triggered)
+		assert.Equal(t, "Sync is pinned to tag v1.0.0", result.Message)
+	})
+
+	t.Run("push during a running sync is queued", func(t *testing.T) {
+		require.True(t, svc.webhookRuns.startInternal(sync.ID))
+
+		header, body := gitHubWebhookRequestInternal(t, "github_push.json", "push", "hook-secret")
+		result, err := svc.HandleWebhook(ctx, "0", sync.ID, header, body)
+		require.NoError(t, err)
+		assert.True(t, result.Triggered)
+		assert.Equal(t, "0d1a26e67d8f5eaf1f6ba5c57fc3c7d91ac0fd1c", result.Commit)
+
+		assert.True(t, svc.webhookRuns.finishInternal(sync.ID), "queued push should run the sync again")
+		assert.False(t, svc.webhookRuns.finishInternal(sync.ID))
+		assert.True(t, svc.webhookRuns.startInternal(sync.ID))
+		assert.False(t, svc.webhookRuns.finishInternal(sync.ID))
+	})
+}
+
+func TestGitOpsSyncService_HandleWebhookWithoutSecret(t *testing.T) {
+	svc, sync := setupGitOpsWebhookTest(t, "")
+
+	header, body := gitHubWebhookRequestInternal(t, "github_push.json", "push", "")
+	_, err := svc.HandleWebhook(context.Background(), "0", sync.ID, header, body)
+	require.ErrorIs(t, err, ErrGitOpsWebhookUnauthorized)
+
+	var stored models.GitOpsSync
+	require.NoError(t, svc.db.First(&stored, "id = ?", sync.ID).Error)
+	assert.False(t, stored.WebhookEnabled)
+}
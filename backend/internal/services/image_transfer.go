@@ -0,0 +1,191 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/getarcaneapp/arcane/backend/internal/models"
+)
+
+// emptyRegistryAuth is sent when pushing without credentials; the daemon rejects pushes
+// without an auth header.
+var emptyRegistryAuth = base64.URLEncoding.EncodeToString([]byte("{}"))
+
+// TagImage adds the reference target to an existing image.
+func (s *ImageService) TagImage(ctx context.Context, source, target string, user models.User) error {
+	dockerClient, err := s.dockerService.GetClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to Docker: %w", err)
+	}
+
+	if err := dockerClient.ImageTag(ctx, source, target); err != nil {
+		s.eventService.LogErrorEvent(ctx, models.EventTypeImageError, "image", "", source, user.ID, user.Username, "0", err, models.JSON{"action": "tag", "target": target})
+		return fmt.Errorf("failed to tag image %s as %s: %w", source, target, err)
+	}
+
+	metadata := models.JSON{"action": "tag", "source": source, "target": target}
+	if logErr := s.eventService.LogImageEvent(ctx, models.EventTypeImageTag, "", target, user.ID, user.Username, "0", metadata); logErr != nil {
+		slog.WarnContext(ctx, "could not log image tag action", "error", logErr, "image", target)
+	}
+	return nil
+}
+
+// PushImage pushes an image and streams the Docker progress messages to progressWriter
+// as JSON lines. With a registryID the image is first tagged for that configured
+// registry and pushed with its credentials; otherwise the credentials of the configured
+// registry matching the image reference are used. It returns the pushed reference.
+func (s *ImageService) PushImage(ctx context.Context, imageName, registryID string, progressWriter io.Writer, user models.User) (string, error) {
+	dockerClient, err := s.dockerService.GetClient()
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to Docker: %w", err)
+	}
+
+	ref := imageName
+	registryAuth := ""
+	if registryID != "" {
+		if s.registryService == nil {
+			return "", fmt.Errorf("container registries are not available")
+		}
+		reg, err := s.registryService.GetRegistryByID(ctx, registryID)
+		if err != nil {
+			return "", err
+		}
+		token, err := s.registryService.GetDecryptedToken(ctx, reg.ID)
+		if err != nil {
+			return "", fmt.Errorf("failed to decrypt token for registry %s: %w", reg.URL, err)
+		}
+		if registryAuth, err = s.createAuthHeader(reg.Username, token, s.normalizeRegistryURL(reg.URL)); err != nil {
+			return "", fmt.Errorf("failed to create auth header: %w", err)
+		}
+
+		ref = retagForRegistryInternal(imageName, s.normalizeRegistryForComparison(reg.URL))
+		if ref != imageName {
+			if err := s.TagImage(ctx, imageName, ref, user); err != nil {
+				return "", err
+			}
+		}
+	} else {
+		pullOptions, err := s.getPullOptionsWithAuth(ctx, ref, nil)
+		if err != nil {
+			slog.WarnContext(ctx, "Failed to get registry authentication for image; proceeding without auth", "image", ref, "error", err.Error())
+		}
+		registryAuth = pullOptions.RegistryAuth
+	}
+	if registryAuth == "" {
+		registryAuth = emptyRegistryAuth
+	}
+
+	reader, err := dockerClient.ImagePush(ctx, ref, image.PushOptions{RegistryAuth: registryAuth})
+	if err != nil {
+		s.eventService.LogErrorEvent(ctx, models.EventTypeImageError, "image", "", ref, user.ID, user.Username, "0", err, models.JSON{"action": "push"})
+		return "", fmt.Errorf("failed to initiate image push for %s: %w", ref, err)
+	}
+	defer func() { _ = reader.Close() }()
+
+	if err := forwardJSONMessagesInternal(reader, progressWriter); err != nil {
+		s.eventService.LogErrorEvent(ctx, models.EventTypeImageError, "image", "", ref, user.ID, user.Username, "0", err, models.JSON{"action": "push"})
+		return "", fmt.Errorf("failed to push image %s: %w", ref, err)
+	}
+
+	metadata := models.JSON{"action": "push", "imageName": ref, "registryId": registryID}
+	if logErr := s.eventService.LogImageEvent(ctx, models.EventTypeImagePush, "", ref, user.ID, user.Username, "0", metadata); logErr != nil {
+		slog.WarnContext(ctx, "could not log image push action", "error", logErr, "image", ref)
+	}
+	return ref, nil
+}
+
+// SaveImages writes the given images as a single tar archive to w, as docker save does.
+func (s *ImageService) SaveImages(ctx context.Context, images []string, w io.Writer, user models.User) error {
+	if len(images) == 0 {
+		return errors.New("at least one image is required")
+	}
+
+	dockerClient, err := s.dockerService.GetClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to Docker: %w", err)
+	}
+
+	// Inspect first so a missing image fails before any archive bytes are written.
+	for _, ref := range images {
+		if _, err := dockerClient.ImageInspect(ctx, ref); err != nil {
+			return fmt.Errorf("failed to inspect image %s: %w", ref, err)
+		}
+	}
+
+	reader, err := dockerClient.ImageSave(ctx, images)
+	if err != nil {
+		s.eventService.LogErrorEvent(ctx, models.EventTypeImageError, "image", "", strings.Join(images, ", "), user.ID, user.Username, "0", err, models.JSON{"action": "save"})
+		return fmt.Errorf("failed to export images: %w", err)
+	}
+	defer func() { _ = reader.Close() }()
+
+	if _, err := io.Copy(w, reader); err != nil {
+		return fmt.Errorf("failed to write image archive: %w", err)
+	}
+
+	metadata := models.JSON{"action": "save", "images": images}
+	if logErr := s.eventService.LogImageEvent(ctx, models.EventTypeImageSave, "", strings.Join(images, ", "), user.ID, user.Username, "0", metadata); logErr != nil {
+		slog.WarnContext(ctx, "could not log image save action", "error", logErr)
+	}
+	return nil
+}
+
+// forwardJSONMessagesInternal copies a Docker JSON message stream to w line by line,
+// flushing after each line, and returns the first error reported in the stream.
+func forwardJSONMessagesInternal(r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	flusher, implementsFlusher := w.(http.Flusher)
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if _, err := w.Write(line); err != nil {
+			return fmt.Errorf("error writing progress: %w", err)
+		}
+		if _, err := w.Write([]byte("\n")); err != nil {
+			return fmt.Errorf("error writing progress: %w", err)
+		}
+		if implementsFlusher {
+			flusher.Flush()
+		}
+
+		var msg jsonmessage.JSONMessage
+		if err := json.Unmarshal(line, &msg); err != nil {
+			continue
+		}
+		if msg.Error != nil {
+			return errors.New(msg.Error.Message)
+		}
+		if msg.ErrorMessage != "" {
+			return errors.New(msg.ErrorMessage)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading progress stream: %w", err)
+	}
+	return nil
+}
+
+// retagForRegistryInternal returns the reference of an image on the registry host,
+// replacing the registry in the reference if there is one. Images are pushed to Docker
+// Hub under their own name.
+func retagForRegistryInternal(imageName, registryHost string) string {
+	name := imageName
+	if first, rest, found := strings.Cut(name, "/"); found && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		name = rest
+	}
+	if registryHost == "" || registryHost == "docker.io" {
+		return name
+	}
+	return registryHost + "/" + name
+}
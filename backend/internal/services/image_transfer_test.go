@@ -0,0 +1,47 @@
+package services
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetagForRegistryInternal(t *testing.T) {
+	tests := []struct {
+		name     string
+		image    string
+		registry string
+		want     string
+	}{
+		{name: "docker hub image", image: "nginx:alpine", registry: "registry.example.com", want: "registry.example.com/nginx:alpine"},
+		{name: "namespaced image", image: "team/app:1.2", registry: "ghcr.io", want: "ghcr.io/team/app:1.2"},
+		{name: "other registry", image: "quay.io/team/app:1.2", registry: "registry.example.com:5000", want: "registry.example.com:5000/team/app:1.2"},
+		{name: "localhost", image: "localhost/app", registry: "ghcr.io", want: "ghcr.io/app"},
+		{name: "already on registry", image: "ghcr.io/team/app", registry: "ghcr.io", want: "ghcr.io/team/app"},
+		{name: "to docker hub", image: "ghcr.io/team/app:1", registry: "docker.io", want: "team/app:1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, retagForRegistryInternal(tt.image, tt.registry))
+		})
+	}
+}
+
+func TestForwardJSONMessagesInternal(t *testing.T) {
+	stream := `{"status":"The push refers to repository [ghcr.io/team/app]"}
+{"status":"Pushed","id":"abc"}
+`
+	var out bytes.Buffer
+	require.NoError(t, forwardJSONMessagesInternal(strings.NewReader(stream), &out))
+	assert.Equal(t, stream, out.String())
+
+	failing := stream + `{"errorDetail":{"message":"denied: requested access to the resource is denied"},"error":"denied"}` + "\n"
+	out.Reset()
+	err := forwardJSONMessagesInternal(strings.NewReader(failing), &out)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "denied: requested access")
+	assert.Equal(t, failing, out.String())
+}
@@ -9,9 +9,11 @@ import (
 	"sync"
 	"time"
 
+	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/image"
 	"github.com/getarcaneapp/arcane/backend/internal/database"
 	"github.com/getarcaneapp/arcane/backend/internal/models"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/arcaneupdater"
 	"github.com/getarcaneapp/arcane/backend/internal/utils/crypto"
 	registry "github.com/getarcaneapp/arcane/backend/internal/utils/registry"
 	"github.com/getarcaneapp/arcane/types/containerregistry"
@@ -83,14 +85,27 @@ func (s *ImageUpdateService) CheckImageUpdate(ctx context.Context, imageRef stri
 		return result, err
 	}
 
+	policy := s.getUpdatePoliciesInternal(ctx)[parts.key()]
+	if policy.AllowsTagUpdates() {
+		token, _, tokenErr := s.getRegistryToken(ctx, parts.Registry, parts.Repository, registries)
+		if tokenErr != nil {
+			slog.DebugContext(ctx, "Failed to get registry token for tag check", "imageRef", imageRef, "error", tokenErr.Error())
+		}
+		s.checkTagUpdate(ctx, registry.NewClient(), parts, token, policy, registries, digestResult)
+	} else {
+		digestResult.UpdatePolicy = string(arcaneupdater.UpdatePolicyDigest)
+	}
+
 	digestResult.ResponseTimeMs = int(time.Since(startTime).Milliseconds())
 	metadata := models.JSON{
 		"action":         "check_update",
 		"imageRef":       imageRef,
 		"hasUpdate":      digestResult.HasUpdate,
-		"updateType":     "digest",
+		"updateType":     digestResult.UpdateType,
+		"updatePolicy":   digestResult.UpdatePolicy,
 		"currentDigest":  digestResult.CurrentDigest,
 		"latestDigest":   digestResult.LatestDigest,
+		"latestVersion":  digestResult.LatestVersion,
 		"responseTimeMs": digestResult.ResponseTimeMs,
 	}
 	if logErr := s.eventService.LogImageEvent(ctx, models.EventTypeImageScan, "", imageRef, systemUser.ID, systemUser.Username, "0", metadata); logErr != nil {
@@ -218,7 +233,7 @@ func (s *ImageUpdateService) checkDigestUpdate(ctx context.Context, parts *Image
 
 	return &imageupdate.Response{
 		HasUpdate:      hasUpdate,
-		UpdateType:     "digest",
+		UpdateType:     models.UpdateTypeDigest,
 		CurrentDigest:  localDigest,
 		LatestDigest:   remoteDigest,
 		CheckTime:      time.Now(),
@@ -230,6 +245,96 @@ func (s *ImageUpdateService) checkDigestUpdate(ctx context.Context, parts *Image
 	}, nil
 }
 
+// checkTagUpdate lists the repository tags and, when a newer version tag allowed by policy exists,
+// turns result into a tag update. The digest fields of result are left untouched and failures to
+// list tags are logged rather than returned, so the digest check still stands on its own.
+func (s *ImageUpdateService) checkTagUpdate(ctx context.Context, rc *registry.Client, parts *ImageParts, token string, policy arcaneupdater.UpdatePolicy, registries []models.ContainerRegistry, result *imageupdate.Response) {
+	result.UpdatePolicy = string(policy)
+	result.CurrentVersion = parts.Tag
+
+	if !policy.AllowsTagUpdates() || !arcaneupdater.IsVersionTag(parts.Tag) {
+		return
+	}
+
+	normalizedRepo := s.normalizeRepository(parts.Registry, parts.Repository)
+	tags, err := rc.ListTags(ctx, parts.Registry, normalizedRepo, token)
+	if err != nil && strings.Contains(strings.ToLower(err.Error()), "unauthorized") {
+		authHeader, _, _, resolveErr := registry.ResolveAuthHeaderForRepository(ctx, parts.Registry, normalizedRepo, parts.Tag, registries)
+		if resolveErr == nil && authHeader != "" {
+			tags, err = rc.ListTags(ctx, parts.Registry, normalizedRepo, authHeader)
+		}
+	}
+	if err != nil {
+		slog.DebugContext(ctx, "Failed to list tags for tag update check",
+			"registry", parts.Registry,
+			"repository", normalizedRepo,
+			"error", err.Error())
+		return
+	}
+
+	newer, bump, ok := arcaneupdater.FindNewerTag(parts.Tag, tags, policy)
+	if !ok {
+		return
+	}
+
+	slog.DebugContext(ctx, "newer tag available",
+		"repository", normalizedRepo,
+		"currentTag", parts.Tag,
+		"latestTag", newer,
+		"bump", bump,
+		"policy", policy)
+
+	result.HasUpdate = true
+	result.UpdateType = models.UpdateTypeTag
+	result.LatestVersion = newer
+	result.VersionBump = string(bump)
+}
+
+// getUpdatePoliciesInternal maps image references (see ImageParts.key) to the narrowest update
+// policy declared by the containers using them. Containers without the policy label count as
+// digest-only, so a tag is only followed when every container using the image opts in.
+func (s *ImageUpdateService) getUpdatePoliciesInternal(ctx context.Context) map[string]arcaneupdater.UpdatePolicy {
+	policies := make(map[string]arcaneupdater.UpdatePolicy)
+	if s.dockerService == nil {
+		return policies
+	}
+
+	dockerClient, err := s.dockerService.GetClient()
+	if err != nil {
+		slog.DebugContext(ctx, "Failed to connect to Docker for update policies", "error", err.Error())
+		return policies
+	}
+
+	containers, err := dockerClient.ContainerList(ctx, container.ListOptions{All: true})
+	if err != nil {
+		slog.DebugContext(ctx, "Failed to list containers for update policies", "error", err.Error())
+		return policies
+	}
+
+	for _, c := range containers {
+		if c.Image == "" || strings.HasPrefix(c.Image, "sha256:") {
+			continue
+		}
+		parts := s.parseImageReference(c.Image)
+		if parts == nil {
+			continue
+		}
+		key := parts.key()
+		policy := arcaneupdater.GetUpdatePolicy(c.Labels)
+		if existing, ok := policies[key]; ok {
+			policy = arcaneupdater.NarrowestPolicy(existing, policy)
+		}
+		policies[key] = policy
+	}
+
+	return policies
+}
+
+// key returns the canonical "registry/repository:tag" form used to match images across lookups.
+func (p *ImageParts) key() string {
+	return fmt.Sprintf("%s/%s:%s", p.Registry, p.Repository, p.Tag)
+}
+
 func (s *ImageUpdateService) parseImageReference(imageRef string) *ImageParts {
 	// Use the official Docker reference parser to handle all edge cases
 	named, err := ref.ParseNormalizedNamed(imageRef)
@@ -865,6 +970,7 @@ func (s *ImageUpdateService) checkSingleImageInBatch(
 	authMap map[string]regAuth,
 	enabledRegs []models.ContainerRegistry,
 	parts *ImageParts,
+	policy arcaneupdater.UpdatePolicy,
 ) *imageupdate.Response {
 
 	start := time.Now()
@@ -917,18 +1023,21 @@ func (s *ImageUpdateService) checkSingleImageInBatch(
 		}
 	}
 
-	return &imageupdate.Response{
+	result := &imageupdate.Response{
 		HasUpdate:      hasDigestUpdate,
-		UpdateType:     "digest",
+		UpdateType:     models.UpdateTypeDigest,
 		CurrentDigest:  localDigest,
 		LatestDigest:   remoteDigest,
 		CheckTime:      time.Now(),
-		ResponseTimeMs: int(time.Since(start).Milliseconds()),
 		AuthMethod:     auth.Method,
 		AuthUsername:   auth.Username,
 		AuthRegistry:   auth.Registry,
 		UsedCredential: auth.Method == "credential",
 	}
+	s.checkTagUpdate(ctx, rc, parts, token, policy, enabledRegs, result)
+	result.ResponseTimeMs = int(time.Since(start).Milliseconds())
+
+	return result
 }
 
 func (s *ImageUpdateService) CheckMultipleImages(ctx context.Context, imageRefs []string, externalCreds []containerregistry.Credential) (map[string]*imageupdate.Response, error) {
@@ -950,6 +1059,7 @@ func (s *ImageUpdateService) CheckMultipleImages(ctx context.Context, imageRefs
 	slog.DebugContext(ctx, "Built credential map", "credMapSize", len(credMap), "enabledRegsCount", len(enabledRegs))
 
 	regAuthMap := s.buildRegistryAuthMap(ctx, rc, regRepos, credMap)
+	policies := s.getUpdatePoliciesInternal(ctx)
 
 	var mu sync.Mutex
 	g, groupCtx := errgroup.WithContext(ctx)
@@ -957,7 +1067,7 @@ func (s *ImageUpdateService) CheckMultipleImages(ctx context.Context, imageRefs
 
 	for _, img := range images {
 		g.Go(func() error {
-			res := s.checkSingleImageInBatch(groupCtx, rc, regAuthMap, enabledRegs, img.parts)
+			res := s.checkSingleImageInBatch(groupCtx, rc, regAuthMap, enabledRegs, img.parts, policies[img.parts.key()])
 
 			mu.Lock()
 			results[img.ref] = res
@@ -1132,6 +1242,7 @@ func (s *ImageUpdateService) getUpdateSummaryForImageIDsInternal(ctx context.Con
 	var (
 		imagesWithUpdates int64
 		digestUpdates     int64
+		tagUpdates        int64
 		errorsCount       int64
 	)
 
@@ -1146,9 +1257,15 @@ func (s *ImageUpdateService) getUpdateSummaryForImageIDsInternal(ctx context.Con
 	g.Go(func() error {
 		return s.db.WithContext(groupCtx).
 			Model(&models.ImageUpdateRecord{}).
-			Where("id IN ? AND has_update = ? AND update_type = ?", imageIDs, true, "digest").
+			Where("id IN ? AND has_update = ? AND update_type = ?", imageIDs, true, models.UpdateTypeDigest).
 			Count(&digestUpdates).Error
 	})
+	g.Go(func() error {
+		return s.db.WithContext(groupCtx).
+			Model(&models.ImageUpdateRecord{}).
+			Where("id IN ? AND has_update = ? AND update_type = ?", imageIDs, true, models.UpdateTypeTag).
+			Count(&tagUpdates).Error
+	})
 	g.Go(func() error {
 		return s.db.WithContext(groupCtx).
 			Model(&models.ImageUpdateRecord{}).
@@ -1162,6 +1279,7 @@ func (s *ImageUpdateService) getUpdateSummaryForImageIDsInternal(ctx context.Con
 
 	summary.ImagesWithUpdates = int(imagesWithUpdates)
 	summary.DigestUpdates = int(digestUpdates)
+	summary.TagUpdates = int(tagUpdates)
 	summary.ErrorsCount = int(errorsCount)
 
 	return summary, nil
@@ -60,7 +60,9 @@ func (s *JobService) GetJobSchedules(ctx context.Context) jobschedule.Config {
 		AutoUpdateInterval:         s.settings.GetStringSetting(ctx, "autoUpdateInterval", "0 0 0 * * *"),
 		PollingInterval:            s.settings.GetStringSetting(ctx, "pollingInterval", "0 */15 * * * *"),
 		ScheduledPruneInterval:     s.settings.GetStringSetting(ctx, "scheduledPruneInterval", "0 0 0 * * *"),
+		ScheduledBackupInterval:    s.settings.GetStringSetting(ctx, "scheduledBackupInterval", "0 0 2 * * *"),
 		GitopsSyncInterval:         s.settings.GetStringSetting(ctx, "gitopsSyncInterval", "0 */1 * * * *"),
+		GitopsDriftInterval:        s.settings.GetStringSetting(ctx, "gitopsDriftInterval", "0 */15 * * * *"),
 		VulnerabilityScanInterval:  s.settings.GetStringSetting(ctx, "vulnerabilityScanInterval", "0 0 0 * * *"),
 	}
 }
@@ -86,7 +88,9 @@ func (s *JobService) UpdateJobSchedules(ctx context.Context, updates jobschedule
 		{key: "autoUpdateInterval", current: current.AutoUpdateInterval, update: updates.AutoUpdateInterval},
 		{key: "pollingInterval", current: current.PollingInterval, update: updates.PollingInterval},
 		{key: "scheduledPruneInterval", current: current.ScheduledPruneInterval, update: updates.ScheduledPruneInterval},
+		{key: "scheduledBackupInterval", current: current.ScheduledBackupInterval, update: updates.ScheduledBackupInterval},
 		{key: "gitopsSyncInterval", current: current.GitopsSyncInterval, update: updates.GitopsSyncInterval},
+		{key: "gitopsDriftInterval", current: current.GitopsDriftInterval, update: updates.GitopsDriftInterval},
 		{key: "vulnerabilityScanInterval", current: current.VulnerabilityScanInterval, update: updates.VulnerabilityScanInterval},
 	}
 
@@ -226,7 +230,9 @@ func (s *JobService) getJobScheduleInternal(ctx context.Context, meta meta.JobMe
 		"autoUpdateInterval":         "0 0 0 * * *",
 		"pollingInterval":            "0 */15 * * * *",
 		"scheduledPruneInterval":     "0 0 0 * * *",
+		"scheduledBackupInterval":    "0 0 2 * * *",
 		"gitopsSyncInterval":         "0 */1 * * * *",
+		"gitopsDriftInterval":        "0 */15 * * * *",
 		"vulnerabilityScanInterval":  "0 0 0 * * *",
 	}
 
@@ -2,12 +2,15 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/netip"
 	"strings"
 
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
 	"github.com/getarcaneapp/arcane/backend/internal/database"
 	"github.com/getarcaneapp/arcane/backend/internal/models"
 	dockerutil "github.com/getarcaneapp/arcane/backend/internal/utils/docker"
@@ -15,6 +18,10 @@ import (
 	networktypes "github.com/getarcaneapp/arcane/types/network"
 )
 
+// ErrInvalidEndpointAddress is returned when a static address for a network endpoint
+// cannot be parsed or belongs to the wrong address family.
+var ErrInvalidEndpointAddress = errors.New("invalid endpoint address")
+
 type NetworkService struct {
 	db            *database.DB
 	dockerService *DockerClientService
@@ -99,6 +106,110 @@ func (s *NetworkService) RemoveNetwork(ctx context.Context, id string, user mode
 	return nil
 }
 
+// ConnectContainer attaches a container to a network, optionally with DNS aliases and
+// static IPv4/IPv6 addresses.
+func (s *NetworkService) ConnectContainer(ctx context.Context, networkID string, req networktypes.ConnectRequest, user models.User) error {
+	endpoint, err := endpointSettingsInternal(req)
+	if err != nil {
+		return err
+	}
+
+	dockerClient, err := s.dockerService.GetClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to Docker: %w", err)
+	}
+
+	networkName := s.networkNameInternal(ctx, dockerClient, networkID)
+	metadata := models.JSON{
+		"action":      "connect",
+		"networkId":   networkID,
+		"containerId": req.ContainerID,
+		"aliases":     req.Aliases,
+		"ipv4Address": req.IPv4Address,
+		"ipv6Address": req.IPv6Address,
+	}
+
+	if err := dockerClient.NetworkConnect(ctx, networkID, req.ContainerID, endpoint); err != nil {
+		s.eventService.LogErrorEvent(ctx, models.EventTypeNetworkError, "network", networkID, networkName, user.ID, user.Username, "0", err, metadata)
+		return fmt.Errorf("failed to connect container %s to network %s: %w", req.ContainerID, networkName, err)
+	}
+
+	if logErr := s.eventService.LogNetworkEvent(ctx, models.EventTypeNetworkConnect, networkID, networkName, user.ID, user.Username, "0", metadata); logErr != nil {
+		fmt.Printf("Could not log network connect action: %s\n", logErr)
+	}
+
+	return nil
+}
+
+// DisconnectContainer detaches a container from a network. With force the container is
+// disconnected even if it is not running.
+func (s *NetworkService) DisconnectContainer(ctx context.Context, networkID string, req networktypes.DisconnectRequest, user models.User) error {
+	dockerClient, err := s.dockerService.GetClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to Docker: %w", err)
+	}
+
+	networkName := s.networkNameInternal(ctx, dockerClient, networkID)
+	metadata := models.JSON{
+		"action":      "disconnect",
+		"networkId":   networkID,
+		"containerId": req.ContainerID,
+		"force":       req.Force,
+	}
+
+	if err := dockerClient.NetworkDisconnect(ctx, networkID, req.ContainerID, req.Force); err != nil {
+		s.eventService.LogErrorEvent(ctx, models.EventTypeNetworkError, "network", networkID, networkName, user.ID, user.Username, "0", err, metadata)
+		return fmt.Errorf("failed to disconnect container %s from network %s: %w", req.ContainerID, networkName, err)
+	}
+
+	if logErr := s.eventService.LogNetworkEvent(ctx, models.EventTypeNetworkDisconnect, networkID, networkName, user.ID, user.Username, "0", metadata); logErr != nil {
+		fmt.Printf("Could not log network disconnect action: %s\n", logErr)
+	}
+
+	return nil
+}
+
+// networkNameInternal returns the name of a network for events, falling back to the
+// given ID if it cannot be inspected.
+func (s *NetworkService) networkNameInternal(ctx context.Context, dockerClient *client.Client, networkID string) string {
+	networkInfo, err := dockerClient.NetworkInspect(ctx, networkID, network.InspectOptions{})
+	if err != nil {
+		return networkID
+	}
+	return networkInfo.Name
+}
+
+// endpointSettingsInternal maps a connect request to Docker endpoint settings,
+// validating the static addresses.
+func endpointSettingsInternal(req networktypes.ConnectRequest) (*network.EndpointSettings, error) {
+	endpoint := &network.EndpointSettings{}
+	for _, alias := range req.Aliases {
+		if alias = strings.TrimSpace(alias); alias != "" {
+			endpoint.Aliases = append(endpoint.Aliases, alias)
+		}
+	}
+
+	ipv4 := strings.TrimSpace(req.IPv4Address)
+	ipv6 := strings.TrimSpace(req.IPv6Address)
+	if ipv4 != "" {
+		addr, err := netip.ParseAddr(ipv4)
+		if err != nil || !addr.Is4() {
+			return nil, fmt.Errorf("%w: %q is not an IPv4 address", ErrInvalidEndpointAddress, ipv4)
+		}
+	}
+	if ipv6 != "" {
+		addr, err := netip.ParseAddr(ipv6)
+		if err != nil || !addr.Is6() || addr.Is4In6() {
+			return nil, fmt.Errorf("%w: %q is not an IPv6 address", ErrInvalidEndpointAddress, ipv6)
+		}
+	}
+	if ipv4 != "" || ipv6 != "" {
+		endpoint.IPAMConfig = &network.EndpointIPAMConfig{IPv4Address: ipv4, IPv6Address: ipv6}
+	}
+
+	return endpoint, nil
+}
+
 func (s *NetworkService) PruneNetworks(ctx context.Context) (*network.PruneReport, error) {
 	dockerClient, err := s.dockerService.GetClient()
 	if err != nil {
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/docker/compose/v5/pkg/api"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	dockerutil "github.com/getarcaneapp/arcane/backend/internal/utils/docker"
+	networktypes "github.com/getarcaneapp/arcane/types/network"
+)
+
+// GetTopology returns a graph of the networks, the containers attached to them and the
+// compose projects those containers belong to.
+func (s *NetworkService) GetTopology(ctx context.Context) (networktypes.Topology, error) {
+	dockerClient, err := s.dockerService.GetClient()
+	if err != nil {
+		return networktypes.Topology{}, fmt.Errorf("failed to connect to Docker: %w", err)
+	}
+
+	networks, err := dockerClient.NetworkList(ctx, network.ListOptions{})
+	if err != nil {
+		return networktypes.Topology{}, fmt.Errorf("failed to list Docker networks: %w", err)
+	}
+
+	containers, err := dockerClient.ContainerList(ctx, container.ListOptions{All: true})
+	if err != nil {
+		return networktypes.Topology{}, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	return buildTopologyInternal(networks, containers), nil
+}
+
+// buildTopologyInternal builds the topology graph from the network and container lists.
+// Endpoints are matched to networks by ID, falling back to the network name. Nodes and
+// edges are sorted so the result is stable between calls.
+func buildTopologyInternal(networks []network.Summary, containers []container.Summary) networktypes.Topology {
+	topology := networktypes.Topology{
+		Nodes: []networktypes.TopologyNode{},
+		Edges: []networktypes.TopologyEdge{},
+	}
+
+	networkIDByName := make(map[string]string, len(networks))
+	for _, n := range networks {
+		networkIDByName[n.Name] = n.ID
+		topology.Nodes = append(topology.Nodes, networktypes.TopologyNode{
+			ID:        n.ID,
+			Type:      networktypes.TopologyNodeNetwork,
+			Name:      n.Name,
+			Driver:    n.Driver,
+			IsDefault: dockerutil.IsDefaultNetwork(n.Name),
+		})
+	}
+
+	projects := map[string]bool{}
+	for _, c := range containers {
+		topology.Nodes = append(topology.Nodes, networktypes.TopologyNode{
+			ID:    c.ID,
+			Type:  networktypes.TopologyNodeContainer,
+			Name:  containerDisplayNameInternal(c),
+			State: string(c.State),
+		})
+
+		if projectName := c.Labels[api.ProjectLabel]; projectName != "" {
+			projects[projectName] = true
+			topology.Edges = append(topology.Edges, networktypes.TopologyEdge{
+				Source: topologyProjectIDInternal(projectName),
+				Target: c.ID,
+				Type:   networktypes.TopologyEdgeMember,
+			})
+		}
+
+		if c.NetworkSettings == nil {
+			continue
+		}
+		for netName, endpoint := range c.NetworkSettings.Networks {
+			if endpoint == nil {
+				continue
+			}
+			networkID := endpoint.NetworkID
+			if networkID == "" {
+				networkID = networkIDByName[netName]
+			}
+			if networkID == "" {
+				continue
+			}
+			topology.Edges = append(topology.Edges, networktypes.TopologyEdge{
+				Source:      c.ID,
+				Target:      networkID,
+				Type:        networktypes.TopologyEdgeAttached,
+				IPv4Address: endpoint.IPAddress,
+				IPv6Address: endpoint.GlobalIPv6Address,
+				Aliases:     slices.Clone(endpoint.Aliases),
+			})
+		}
+	}
+
+	for projectName := range projects {
+		topology.Nodes = append(topology.Nodes, networktypes.TopologyNode{
+			ID:   topologyProjectIDInternal(projectName),
+			Type: networktypes.TopologyNodeProject,
+			Name: projectName,
+		})
+	}
+
+	slices.SortFunc(topology.Nodes, func(a, b networktypes.TopologyNode) int {
+		return cmp.Or(cmp.Compare(a.Type, b.Type), cmp.Compare(a.Name, b.Name), cmp.Compare(a.ID, b.ID))
+	})
+	slices.SortFunc(topology.Edges, func(a, b networktypes.TopologyEdge) int {
+		return cmp.Or(cmp.Compare(a.Type, b.Type), cmp.Compare(a.Source, b.Source), cmp.Compare(a.Target, b.Target))
+	})
+
+	return topology
+}
+
+func topologyProjectIDInternal(projectName string) string {
+	return "project:" + projectName
+}
+
+func containerDisplayNameInternal(c container.Summary) string {
+	if len(c.Names) > 0 {
+		return strings.TrimPrefix(c.Names[0], "/")
+	}
+	if len(c.ID) > 12 {
+		return c.ID[:12]
+	}
+	return c.ID
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/docker/compose/v5/pkg/api"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	networktypes "github.com/getarcaneapp/arcane/types/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildTopologyInternal(t *testing.T) {
+	networks := []network.Summary{
+		{ID: "net-bridge", Name: "bridge", Driver: "bridge"},
+		{ID: "net-shop", Name: "shop_default", Driver: "bridge"},
+	}
+	containers := []container.Summary{
+		{
+			ID:     "c-web",
+			Names:  []string{"/shop-web-1"},
+			State:  container.StateRunning,
+			Labels: map[string]string{api.ProjectLabel: "shop"},
+			NetworkSettings: &container.NetworkSettingsSummary{Networks: map[string]*network.EndpointSettings{
+				"shop_default": {NetworkID: "net-shop", IPAddress: "172.20.0.2", Aliases: []string{"web"}},
+			}},
+		},
+		{
+			ID:    "c-debug",
+			Names: []string{"/debug"},
+			State: container.StateExited,
+			NetworkSettings: &container.NetworkSettingsSummary{Networks: map[string]*network.EndpointSettings{
+				"bridge":       {},
+				"shop_default": {NetworkID: "net-shop", IPAddress: "172.20.0.3"},
+			}},
+		},
+	}
+
+	topology := buildTopologyInternal(networks, containers)
+
+	require.Len(t, topology.Nodes, 5)
+	assert.Equal(t, networktypes.TopologyNode{ID: "c-debug", Type: networktypes.TopologyNodeContainer, Name: "debug", State: "exited"}, topology.Nodes[0])
+	assert.Equal(t, "shop-web-1", topology.Nodes[1].Name)
+	assert.Equal(t, networktypes.TopologyNode{ID: "net-bridge", Type: networktypes.TopologyNodeNetwork, Name: "bridge", Driver: "bridge", IsDefault: true}, topology.Nodes[2])
+	assert.Equal(t, "shop_default", topology.Nodes[3].Name)
+	assert.Equal(t, networktypes.TopologyNode{ID: "project:shop", Type: networktypes.TopologyNodeProject, Name: "shop"}, topology.Nodes[4])
+
+	assert.Equal(t, []networktypes.TopologyEdge{
+		{Source: "c-debug", Target: "net-bridge", Type: networktypes.TopologyEdgeAttached},
+		{Source: "c-debug", Target: "net-shop", Type: networktypes.TopologyEdgeAttached, IPv4Address: "172.20.0.3"},
+		{Source: "c-web", Target: "net-shop", Type: networktypes.TopologyEdgeAttached, IPv4Address: "172.20.0.2", Aliases: []string{"web"}},
+		{Source: "project:shop", Target: "c-web", Type: networktypes.TopologyEdgeMember},
+	}, topology.Edges)
+}
+
+func TestEndpointSettingsInternal(t *testing.T) {
+	endpoint, err := endpointSettingsInternal(networktypes.ConnectRequest{
+		ContainerID: "web",
+		Aliases:     []string{" api ", ""},
+		IPv4Address: "172.20.0.10",
+		IPv6Address: "fd00::10",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api"}, endpoint.Aliases)
+	assert.Equal(t, &network.EndpointIPAMConfig{IPv4Address: "172.20.0.10", IPv6Address: "fd00::10"}, endpoint.IPAMConfig)
+
+	endpoint, err = endpointSettingsInternal(networktypes.ConnectRequest{ContainerID: "web"})
+	require.NoError(t, err)
+	assert.Nil(t, endpoint.IPAMConfig)
+
+	_, err = endpointSettingsInternal(networktypes.ConnectRequest{ContainerID: "web", IPv4Address: "fd00::10"})
+	require.ErrorIs(t, err, ErrInvalidEndpointAddress)
+
+	_, err = endpointSettingsInternal(networktypes.ConnectRequest{ContainerID: "web", IPv6Address: "not-an-ip"})
+	require.ErrorIs(t, err, ErrInvalidEndpointAddress)
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"html"
 	"log/slog"
 	"net/mail"
 	"strings"
@@ -20,6 +21,7 @@ import (
 	"github.com/getarcaneapp/arcane/backend/internal/utils/crypto"
 	"github.com/getarcaneapp/arcane/backend/internal/utils/notifications"
 	"github.com/getarcaneapp/arcane/backend/resources"
+	"github.com/getarcaneapp/arcane/types/gitops"
 	"github.com/getarcaneapp/arcane/types/imageupdate"
 	"github.com/getarcaneapp/arcane/types/system"
 )
@@ -3193,6 +3195,277 @@ func (s *NotificationService) sendGenericPruneNotification(ctx context.Context,
 	return notifications.SendGenericWithTitle(ctx, genericConfig, "System Prune Report", message)
 }
 
+// VolumeBackupFailure describes a volume whose scheduled backup failed.
+type VolumeBackupFailure struct {
+	VolumeName string
+	Error      string
+}
+
+// SendVolumeBackupFailureNotification reports failed scheduled volume backups to every
+// enabled provider subscribed to the volume_backup event.
+func (s *NotificationService) SendVolumeBackupFailureNotification(ctx context.Context, failures []VolumeBackupFailure) error {
+	if len(failures) == 0 {
+		return nil
+	}
+
+	title := fmt.Sprintf("Scheduled Backup Failed: %d Volume(s)", len(failures))
+	var body strings.Builder
+	body.WriteString("The following scheduled volume backups failed:\n\n")
+	for _, f := range failures {
+		fmt.Fprintf(&body, "• %s: %s\n", f.VolumeName, f.Error)
+	}
+	message := body.String()
+
+	if appriseErr := s.appriseService.SendNotification(ctx, title, message, "text", models.NotificationEventVolumeBackup); appriseErr != nil {
+		slog.WarnContext(ctx, "Failed to send Apprise volume backup notification", "error", appriseErr)
+	}
+
+	settings, err := s.GetAllSettings(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get notification settings: %w", err)
+	}
+
+	var errors []string
+	for _, setting := range settings {
+		if !setting.Enabled || !s.isEventEnabled(setting.Config, models.NotificationEventVolumeBackup) {
+			continue
+		}
+
+		var sendErr error
+		if setting.Provider == models.NotificationProviderEmail {
+			sendErr = s.sendEmailVolumeBackupFailureNotification(ctx, title, failures, setting.Config)
+		} else {
+			sendErr = s.sendPlainTextNotificationInternal(ctx, setting.Provider, title, message, setting.Config)
+		}
+
+		status := "success"
+		var errMsg *string
+		if sendErr != nil {
+			status = "failed"
+			msg := sendErr.Error()
+			errMsg = new(msg)
+			errors = append(errors, fmt.Sprintf("%s: %s", setting.Provider, msg))
+		}
+
+		s.logNotification(ctx, setting.Provider, "Scheduled Volume Backup", status, errMsg, models.JSON{
+			"failedVolumes": len(failures),
+			"eventType":     string(models.NotificationEventVolumeBackup),
+		})
+	}
+
+	if len(errors) > 0 {
+		return fmt.Errorf("notification errors: %s", strings.Join(errors, "; "))
+	}
+	return nil
+}
+
+func (s *NotificationService) sendEmailVolumeBackupFailureNotification(ctx context.Context, subject string, failures []VolumeBackupFailure, config models.JSON) error {
+	var emailConfig models.EmailConfig
+	if err := s.unmarshalConfigInternal(config, &emailConfig); err != nil {
+		return err
+	}
+
+	if err := s.validateEmailConfigInternal(&emailConfig); err != nil {
+		return err
+	}
+
+	s.decryptEmailPasswordInternal(&emailConfig)
+
+	appURL := s.config.GetAppURL()
+	htmlBody, _, err := s.renderTemplatesInternal("volume-backup-failure", map[string]any{
+		"LogoURL":     appURL + logoURLPath,
+		"AppURL":      appURL,
+		"FailedCount": len(failures),
+		"Failures":    failures,
+		"Time":        time.Now().Format(time.RFC1123),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to render email template: %w", err)
+	}
+
+	if err := notifications.SendEmail(ctx, emailConfig, subject, htmlBody); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
+
+// SendGitOpsDriftNotification reports GitOps syncs whose project drifted from the
+// repository to every enabled provider subscribed to the gitops_drift event.
+func (s *NotificationService) SendGitOpsDriftNotification(ctx context.Context, reports []gitops.DriftReport) error {
+	if len(reports) == 0 {
+		return nil
+	}
+
+	title := fmt.Sprintf("GitOps Drift Detected: %d Project(s)", len(reports))
+	var body strings.Builder
+	body.WriteString("The following projects no longer match their git repository:\n")
+	for _, report := range reports {
+		action := "not changed"
+		if report.Healed {
+			action = "redeployed from git"
+		}
+		fmt.Fprintf(&body, "\n%s (sync %s, %s):\n", report.ProjectName, report.SyncName, action)
+		for _, item := range report.Items {
+			fmt.Fprintf(&body, "• %s\n", item.Message)
+		}
+	}
+	message := body.String()
+
+	if appriseErr := s.appriseService.SendNotification(ctx, title, message, "text", models.NotificationEventGitOpsDrift); appriseErr != nil {
+		slog.WarnContext(ctx, "Failed to send Apprise GitOps drift notification", "error", appriseErr)
+	}
+
+	settings, err := s.GetAllSettings(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get notification settings: %w", err)
+	}
+
+	var errors []string
+	for _, setting := range settings {
+		if !setting.Enabled || !s.isEventEnabled(setting.Config, models.NotificationEventGitOpsDrift) {
+			continue
+		}
+
+		var sendErr error
+		if setting.Provider == models.NotificationProviderEmail {
+			sendErr = s.sendEmailGitOpsDriftNotification(ctx, title, reports, setting.Config)
+		} else {
+			sendErr = s.sendPlainTextNotificationInternal(ctx, setting.Provider, title, message, setting.Config)
+		}
+
+		status := "success"
+		var errMsg *string
+		if sendErr != nil {
+			status = "failed"
+			msg := sendErr.Error()
+			errMsg = new(msg)
+			errors = append(errors, fmt.Sprintf("%s: %s", setting.Provider, msg))
+		}
+
+		s.logNotification(ctx, setting.Provider, "GitOps Drift", status, errMsg, models.JSON{
+			"driftedProjects": len(reports),
+			"eventType":       string(models.NotificationEventGitOpsDrift),
+		})
+	}
+
+	if len(errors) > 0 {
+		return fmt.Errorf("notification errors: %s", strings.Join(errors, "; "))
+	}
+	return nil
+}
+
+func (s *NotificationService) sendEmailGitOpsDriftNotification(ctx context.Context, subject string, reports []gitops.DriftReport, config models.JSON) error {
+	var emailConfig models.EmailConfig
+	if err := s.unmarshalConfigInternal(config, &emailConfig); err != nil {
+		return err
+	}
+
+	if err := s.validateEmailConfigInternal(&emailConfig); err != nil {
+		return err
+	}
+
+	s.decryptEmailPasswordInternal(&emailConfig)
+
+	appURL := s.config.GetAppURL()
+	htmlBody, _, err := s.renderTemplatesInternal("gitops-drift", map[string]any{
+		"LogoURL":      appURL + logoURLPath,
+		"AppURL":       appURL,
+		"DriftedCount": len(reports),
+		"Reports":      reports,
+		"Time":         time.Now().Format(time.RFC1123),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to render email template: %w", err)
+	}
+
+	if err := notifications.SendEmail(ctx, emailConfig, subject, htmlBody); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
+
+// sendPlainTextNotificationInternal sends a title and plain-text message through any
+// non-email provider.
+func (s *NotificationService) sendPlainTextNotificationInternal(ctx context.Context, provider models.NotificationProvider, title, message string, config models.JSON) error {
+	switch provider {
+	case models.NotificationProviderDiscord:
+		var discordConfig models.DiscordConfig
+		if err := s.unmarshalConfigInternal(config, &discordConfig); err != nil {
+			return err
+		}
+		if discordConfig.WebhookID == "" || discordConfig.Token == "" {
+			return fmt.Errorf("discord webhook ID or token not configured")
+		}
+		s.decryptDiscordTokenInternal(&discordConfig)
+		return notifications.SendDiscord(ctx, discordConfig, fmt.Sprintf("**%s**\n\n%s", title, message))
+	case models.NotificationProviderTelegram:
+		var telegramConfig models.TelegramConfig
+		if err := s.unmarshalConfigInternal(config, &telegramConfig); err != nil {
+			return err
+		}
+		if telegramConfig.BotToken == "" || len(telegramConfig.ChatIDs) == 0 {
+			return fmt.Errorf("telegram bot token or chat IDs not configured")
+		}
+		s.decryptTelegramTokenInternal(&telegramConfig)
+		if telegramConfig.ParseMode == "" {
+			telegramConfig.ParseMode = "HTML"
+		}
+		return notifications.SendTelegram(ctx, telegramConfig, fmt.Sprintf("<b>%s</b>\n\n%s", html.EscapeString(title), html.EscapeString(message)))
+	case models.NotificationProviderSignal:
+		var signalConfig models.SignalConfig
+		if err := s.unmarshalConfigInternal(config, &signalConfig); err != nil {
+			return err
+		}
+		return notifications.SendSignal(ctx, signalConfig, title+"\n\n"+message)
+	case models.NotificationProviderSlack:
+		var slackConfig models.SlackConfig
+		if err := s.unmarshalConfigInternal(config, &slackConfig); err != nil {
+			return err
+		}
+		return notifications.SendSlack(ctx, slackConfig, fmt.Sprintf("*%s*\n\n%s", title, message))
+	case models.NotificationProviderNtfy:
+		var ntfyConfig models.NtfyConfig
+		if err := s.unmarshalConfigInternal(config, &ntfyConfig); err != nil {
+			return err
+		}
+		return notifications.SendNtfy(ctx, ntfyConfig, title+"\n\n"+message)
+	case models.NotificationProviderPushover:
+		var pushoverConfig models.PushoverConfig
+		if err := s.unmarshalConfigInternal(config, &pushoverConfig); err != nil {
+			return err
+		}
+		if pushoverConfig.Title == "" {
+			pushoverConfig.Title = title
+		}
+		return notifications.SendPushover(ctx, pushoverConfig, message)
+	case models.NotificationProviderGotify:
+		var gotifyConfig models.GotifyConfig
+		if err := s.unmarshalConfigInternal(config, &gotifyConfig); err != nil {
+			return err
+		}
+		if gotifyConfig.Title == "" {
+			gotifyConfig.Title = title
+		}
+		return notifications.SendGotify(ctx, gotifyConfig, message)
+	case models.NotificationProviderMatrix:
+		var matrixConfig models.MatrixConfig
+		if err := s.unmarshalConfigInternal(config, &matrixConfig); err != nil {
+			return err
+		}
+		return notifications.SendMatrix(ctx, matrixConfig, title+"\n\n"+message)
+	case models.NotificationProviderGeneric:
+		var genericConfig models.GenericConfig
+		if err := s.unmarshalConfigInternal(config, &genericConfig); err != nil {
+			return err
+		}
+		return notifications.SendGenericWithTitle(ctx, genericConfig, title, message)
+	default:
+		return fmt.Errorf("unknown notification provider: %s", provider)
+	}
+}
+
 // Helper methods to reduce code duplication
 func (s *NotificationService) unmarshalConfigInternal(config models.JSON, dest any) error {
 	configBytes, err := json.Marshal(config)
@@ -0,0 +1,314 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+
+	composetypes "github.com/compose-spec/compose-go/v2/types"
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/docker/compose/v5/pkg/api"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/getarcaneapp/arcane/backend/internal/models"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/fs"
+	"github.com/getarcaneapp/arcane/backend/pkg/projects"
+	"github.com/getarcaneapp/arcane/types/project"
+	"gorm.io/gorm"
+)
+
+// ErrProjectBackupNotFound is returned when a project backup does not exist or belongs
+// to another project.
+var ErrProjectBackupNotFound = errors.New("project backup not found")
+
+func (s *ProjectService) backupRunnerInternal() (projectRunner, error) {
+	if s.runner == nil && s.volumeService == nil {
+		return nil, fmt.Errorf("volume backups are not available")
+	}
+	return s.runnerInternal(), nil
+}
+
+// BackupProject backs up all named volumes of a project as one set, together with its
+// compose and env files. With req.StopProject a running project is brought down first
+// and deployed again afterwards, so all volumes are captured in a consistent state. If
+// any volume fails, the volume backups already taken for the set are deleted again.
+func (s *ProjectService) BackupProject(ctx context.Context, projectID string, req project.CreateBackup, passphrase string, user models.User) (*models.ProjectBackup, error) {
+	runner, err := s.backupRunnerInternal()
+	if err != nil {
+		return nil, err
+	}
+	proj, err := s.GetProjectFromDatabaseByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	volumes, err := runner.ProjectVolumes(ctx, proj)
+	if err != nil {
+		return nil, err
+	}
+	if len(volumes) == 0 {
+		return nil, fmt.Errorf("project %s has no named volumes to back up", proj.Name)
+	}
+
+	composeContent, envContent, err := fs.ReadProjectFiles(proj.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read project files: %w", err)
+	}
+
+	stopped := false
+	if req.StopProject && isProjectActiveInternal(proj.Status) {
+		if err := runner.DownProject(ctx, projectID, user); err != nil {
+			return nil, fmt.Errorf("failed to stop project for backup: %w", err)
+		}
+		stopped = true
+		defer func() {
+			if err := runner.DeployProject(context.WithoutCancel(ctx), projectID, user); err != nil {
+				slog.ErrorContext(ctx, "failed to redeploy project after backup", "projectID", projectID, "error", err)
+			}
+		}()
+	}
+
+	opts := BackupOptions{TargetID: req.TargetID, Encrypt: req.Encrypt, Passphrase: passphrase}
+	backup := &models.ProjectBackup{
+		ProjectID:      proj.ID,
+		ProjectName:    proj.Name,
+		StoppedProject: stopped,
+		ComposeContent: composeContent,
+		EnvContent:     envContent,
+	}
+	for _, v := range volumes {
+		volumeBackup, err := runner.BackupVolume(ctx, v.VolumeName, opts, user)
+		if err != nil {
+			deleteVolumeBackupsInternal(ctx, runner, backup.Volumes, &user)
+			return nil, fmt.Errorf("failed to back up volume %s: %w", v.VolumeName, err)
+		}
+		v.BackupID = volumeBackup.ID
+		v.Size = volumeBackup.Size
+		backup.Volumes = append(backup.Volumes, v)
+	}
+
+	if err := s.db.WithContext(ctx).Create(backup).Error; err != nil {
+		deleteVolumeBackupsInternal(ctx, runner, backup.Volumes, &user)
+		return nil, fmt.Errorf("failed to save project backup: %w", err)
+	}
+
+	metadata := models.JSON{
+		"action":         "backup",
+		"backupId":       backup.ID,
+		"volumes":        len(backup.Volumes),
+		"stoppedProject": stopped,
+	}
+	if logErr := s.eventService.LogProjectEvent(ctx, models.EventTypeProjectBackupCreate, proj.ID, proj.Name, user.ID, user.Username, "0", metadata); logErr != nil {
+		slog.WarnContext(ctx, "could not log project backup action", "projectID", proj.ID, "error", logErr)
+	}
+
+	return backup, nil
+}
+
+// ListProjectBackups returns the backups of a project, newest first.
+func (s *ProjectService) ListProjectBackups(ctx context.Context, projectID string) ([]models.ProjectBackup, error) {
+	var backups []models.ProjectBackup
+	if err := s.db.WithContext(ctx).Where("project_id = ?", projectID).Order("created_at DESC").Find(&backups).Error; err != nil {
+		return nil, fmt.Errorf("failed to list project backups: %w", err)
+	}
+	return backups, nil
+}
+
+func (s *ProjectService) GetProjectBackup(ctx context.Context, projectID, backupID string) (*models.ProjectBackup, error) {
+	var backup models.ProjectBackup
+	if err := s.db.WithContext(ctx).Where("id = ? AND project_id = ?", backupID, projectID).First(&backup).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProjectBackupNotFound
+		}
+		return nil, fmt.Errorf("failed to get project backup: %w", err)
+	}
+	return &backup, nil
+}
+
+// DeleteProjectBackup deletes a project backup and the volume backups of its set.
+func (s *ProjectService) DeleteProjectBackup(ctx context.Context, projectID, backupID string, user models.User) error {
+	runner, err := s.backupRunnerInternal()
+	if err != nil {
+		return err
+	}
+	backup, err := s.GetProjectBackup(ctx, projectID, backupID)
+	if err != nil {
+		return err
+	}
+	if err := s.db.WithContext(ctx).Delete(backup).Error; err != nil {
+		return fmt.Errorf("failed to delete project backup: %w", err)
+	}
+	deleteVolumeBackupsInternal(ctx, runner, backup.Volumes, &user)
+
+	metadata := models.JSON{"action": "backup_delete", "backupId": backup.ID}
+	if logErr := s.eventService.LogProjectEvent(ctx, models.EventTypeProjectBackupDelete, projectID, backup.ProjectName, user.ID, user.Username, "0", metadata); logErr != nil {
+		slog.WarnContext(ctx, "could not log project backup delete action", "projectID", projectID, "error", logErr)
+	}
+	return nil
+}
+
+// RestoreProjectBackup restores all volumes of a project backup. The project is brought
+// down for the restore and deployed again afterwards if it was running, unless
+// req.KeepStopped is set; that also happens when the restore fails, and a failed deploy
+// is reported together with the restore error. With req.RestoreFiles the compose and env
+// files of the backup are written back first. Volumes that no longer exist are recreated.
+func (s *ProjectService) RestoreProjectBackup(ctx context.Context, projectID, backupID string, req project.RestoreBackup, passphrase string, user models.User) (err error) {
+	runner, err := s.backupRunnerInternal()
+	if err != nil {
+		return err
+	}
+	proj, err := s.GetProjectFromDatabaseByID(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	backup, err := s.GetProjectBackup(ctx, projectID, backupID)
+	if err != nil {
+		return err
+	}
+
+	wasActive := isProjectActiveInternal(proj.Status)
+	if err := runner.DownProject(ctx, projectID, user); err != nil {
+		return fmt.Errorf("failed to stop project for restore: %w", err)
+	}
+	if wasActive && !req.KeepStopped {
+		defer func() {
+			deployErr := runner.DeployProject(context.WithoutCancel(ctx), projectID, user)
+			switch {
+			case deployErr == nil:
+			case err == nil:
+				err = fmt.Errorf("volumes were restored but the project failed to start: %w", deployErr)
+			default:
+				err = errors.Join(err, fmt.Errorf("failed to redeploy project after the failed restore: %w", deployErr))
+			}
+		}()
+	}
+
+	if req.RestoreFiles {
+		if _, err := s.updateProjectInternal(ctx, projectID, nil, &backup.ComposeContent, &backup.EnvContent, models.ProjectRevisionSourceBackupRestore, user); err != nil {
+			return fmt.Errorf("failed to restore project files: %w", err)
+		}
+	}
+
+	composeName := normalizeComposeProjectName(proj.Name)
+	for _, v := range backup.Volumes {
+		if err := runner.EnsureVolume(ctx, composeName, v, user); err != nil {
+			return err
+		}
+		if err := runner.RestoreVolume(ctx, v.VolumeName, v.BackupID, passphrase, user); err != nil {
+			return fmt.Errorf("failed to restore volume %s: %w", v.VolumeName, err)
+		}
+	}
+
+	metadata := models.JSON{
+		"action":       "backup_restore",
+		"backupId":     backup.ID,
+		"volumes":      len(backup.Volumes),
+		"restoreFiles": req.RestoreFiles,
+	}
+	if logErr := s.eventService.LogProjectEvent(ctx, models.EventTypeProjectBackupRestore, projectID, proj.Name, user.ID, user.Username, "0", metadata); logErr != nil {
+		slog.WarnContext(ctx, "could not log project backup restore action", "projectID", projectID, "error", logErr)
+	}
+	return nil
+}
+
+func (s *ProjectService) loadComposeProjectInternal(ctx context.Context, proj *models.Project) (*composetypes.Project, error) {
+	projectsDirectory, err := fs.GetProjectsDirectory(ctx, strings.TrimSpace(s.settingsService.GetStringSetting(ctx, "projectsDirectory", "/app/data/projects")))
+	if err != nil {
+		slog.WarnContext(ctx, "unable to determine projects directory; using default", "error", err)
+		projectsDirectory = "/app/data/projects"
+	}
+
+	pathMapper, pmErr := s.getPathMapper(ctx)
+	if pmErr != nil {
+		slog.WarnContext(ctx, "failed to create path mapper, continuing without translation", "error", pmErr)
+	}
+
+	autoInjectEnv := s.settingsService.GetBoolSetting(ctx, "autoInjectEnv", false)
+	composeProject, _, err := projects.LoadComposeProjectFromDir(ctx, proj.Path, normalizeComposeProjectName(proj.Name), projectsDirectory, autoInjectEnv, pathMapper)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load compose project: %w", err)
+	}
+	return composeProject, nil
+}
+
+// existingProjectVolumesInternal returns the named volumes of a compose project that
+// exist on the host, sorted by their compose key. Volumes that were never created have
+// nothing to back up and are skipped.
+func (s *ProjectService) existingProjectVolumesInternal(ctx context.Context, composeProject *composetypes.Project) ([]project.BackupVolume, error) {
+	dockerClient, err := s.dockerService.GetClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Docker: %w", err)
+	}
+
+	volumes := make([]project.BackupVolume, 0, len(composeProject.Volumes))
+	for _, key := range slices.Sorted(maps.Keys(composeProject.Volumes)) {
+		v := project.BackupVolume{
+			Name:       key,
+			VolumeName: composeVolumeNameInternal(composeProject.Name, key, composeProject.Volumes[key]),
+			External:   bool(composeProject.Volumes[key].External),
+		}
+		if _, err := dockerClient.VolumeInspect(ctx, v.VolumeName); err != nil {
+			if cerrdefs.IsNotFound(err) {
+				slog.DebugContext(ctx, "skipping project volume that does not exist", "volume", v.VolumeName)
+				continue
+			}
+			return nil, fmt.Errorf("failed to inspect volume %s: %w", v.VolumeName, err)
+		}
+		volumes = append(volumes, v)
+	}
+	return volumes, nil
+}
+
+// ensureProjectVolumeInternal recreates a volume of a project backup that was removed
+// since, labelled like compose would so the next deploy adopts it.
+func (s *ProjectService) ensureProjectVolumeInternal(ctx context.Context, composeName string, v project.BackupVolume, user models.User) error {
+	dockerClient, err := s.dockerService.GetClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to Docker: %w", err)
+	}
+	if _, err := dockerClient.VolumeInspect(ctx, v.VolumeName); err == nil {
+		return nil
+	} else if !cerrdefs.IsNotFound(err) {
+		return fmt.Errorf("failed to inspect volume %s: %w", v.VolumeName, err)
+	}
+
+	options := volume.CreateOptions{Name: v.VolumeName}
+	if !v.External {
+		options.Labels = map[string]string{
+			api.ProjectLabel: composeName,
+			api.VolumeLabel:  v.Name,
+		}
+	}
+	if _, err := s.volumeService.CreateVolume(ctx, options, user); err != nil {
+		return fmt.Errorf("failed to recreate volume %s: %w", v.VolumeName, err)
+	}
+	return nil
+}
+
+// deleteVolumeBackupsInternal deletes the volume backups of a set. It is best effort.
+func deleteVolumeBackupsInternal(ctx context.Context, runner projectRunner, volumes []project.BackupVolume, user *models.User) {
+	ctx = context.WithoutCancel(ctx)
+	for _, v := range volumes {
+		if err := runner.DeleteVolumeBackup(ctx, v.BackupID, user); err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			slog.WarnContext(ctx, "failed to delete volume backup of project backup", "volume", v.VolumeName, "backup_id", v.BackupID, "error", err)
+		}
+	}
+}
+
+// composeVolumeNameInternal returns the Docker volume name of a compose volume.
+func composeVolumeNameInternal(projectName, key string, cfg composetypes.VolumeConfig) string {
+	if cfg.Name != "" {
+		return cfg.Name
+	}
+	if cfg.External {
+		return key
+	}
+	return projectName + "_" + key
+}
+
+func isProjectActiveInternal(status models.ProjectStatus) bool {
+	return status == models.ProjectStatusRunning || status == models.ProjectStatusPartiallyRunning
+}
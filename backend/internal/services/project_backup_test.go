@@ -0,0 +1,196 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	composetypes "github.com/compose-spec/compose-go/v2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/getarcaneapp/arcane/backend/internal/models"
+	"github.com/getarcaneapp/arcane/types/project"
+)
+
+func TestProjectService_ProjectBackupsAreScopedToTheirProject(t *testing.T) {
+	ctx := context.Background()
+	db := setupBackupTargetTestDB(t)
+	require.NoError(t, db.AutoMigrate(&models.ProjectBackup{}))
+	svc := &ProjectService{db: db}
+
+	now := time.Now()
+	volumes := []project.BackupVolume{{Name: "data", VolumeName: "shop_data", BackupID: "shop_data-1", Size: 42}}
+	require.NoError(t, db.Create(&models.ProjectBackup{BaseModel: models.BaseModel{ID: "old", CreatedAt: now.Add(-time.Hour)}, ProjectID: "shop", ProjectName: "shop", Volumes: volumes}).Error)
+	require.NoError(t, db.Create(&models.ProjectBackup{BaseModel: models.BaseModel{ID: "new", CreatedAt: now}, ProjectID: "shop", ProjectName: "shop"}).Error)
+	require.NoError(t, db.Create(&models.ProjectBackup{BaseModel: models.BaseModel{ID: "blog", CreatedAt: now}, ProjectID: "blog", ProjectName: "blog"}).Error)
+
+	backups, err := svc.ListProjectBackups(ctx, "shop")
+	require.NoError(t, err)
+	require.Len(t, backups, 2)
+	assert.Equal(t, "new", backups[0].ID)
+	assert.Equal(t, volumes, backups[1].Volumes)
+	assert.Equal(t, []project.BackupVolume{}, backups[0].ToDTO().Volumes)
+
+	_, err = svc.GetProjectBackup(ctx, "shop", "blog")
+	require.ErrorIs(t, err, ErrProjectBackupNotFound)
+}
+
+func TestComposeVolumeNameInternal(t *testing.T) {
+	assert.Equal(t, "shop_data", composeVolumeNameInternal("shop", "data", composetypes.VolumeConfig{}))
+	assert.Equal(t, "custom", composeVolumeNameInternal("shop", "data", composetypes.VolumeConfig{Name: "custom"}))
+	assert.Equal(t, "data", composeVolumeNameInternal("shop", "data", composetypes.VolumeConfig{External: true}))
+}
+
+// fakeProjectRunner records the Docker steps of project operations and fails the ones
+// configured to.
+type fakeProjectRunner struct {
+	volumes    []project.BackupVolume
+	backupErr  map[string]error
+	restoreErr error
+	deployErr  error
+	calls      []string
+}
+
+func (f *fakeProjectRunner) ProjectVolumes(context.Context, *models.Project) ([]project.BackupVolume, error) {
+	return f.volumes, nil
+}
+
+func (f *fakeProjectRunner) DownProject(context.Context, string, models.User) error {
+	f.calls = append(f.calls, "down")
+	return nil
+}
+
+func (f *fakeProjectRunner) DeployProject(context.Context, string, models.User) error {
+	f.calls = append(f.calls, "deploy")
+	return f.deployErr
+}
+
+func (f *fakeProjectRunner) RedeployProject(context.Context, string, models.User) error {
+	f.calls = append(f.calls, "redeploy")
+	return f.deployErr
+}
+
+func (f *fakeProjectRunner) EnsureVolume(_ context.Context, _ string, v project.BackupVolume, _ models.User) error {
+	f.calls = append(f.calls, "ensure "+v.VolumeName)
+	return nil
+}
+
+func (f *fakeProjectRunner) BackupVolume(_ context.Context, volumeName string, _ BackupOptions, _ models.User) (*models.VolumeBackup, error) {
+	f.calls = append(f.calls, "backup "+volumeName)
+	if err := f.backupErr[volumeName]; err != nil {
+		return nil, err
+	}
+	return &models.VolumeBackup{BaseModel: models.BaseModel{ID: volumeName + "-1"}, VolumeName: volumeName, Size: 10}, nil
+}
+
+func (f *fakeProjectRunner) RestoreVolume(_ context.Context, volumeName, _, _ string, _ models.User) error {
+	f.calls = append(f.calls, "restore "+volumeName)
+	return f.restoreErr
+}
+
+func (f *fakeProjectRunner) DeleteVolumeBackup(_ context.Context, backupID string, _ *models.User) error {
+	f.calls = append(f.calls, "delete "+backupID)
+	return nil
+}
+
+func setupProjectBackupTest(t *testing.T, runner *fakeProjectRunner) *ProjectService {
+	t.Helper()
+	db := setupBackupTargetTestDB(t)
+	require.NoError(t, db.AutoMigrate(&models.Project{}, &models.ProjectBackup{}, &models.Event{}))
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "compose.yaml"), []byte("services:\n  app:\n    image: nginx\n"), 0o600))
+	require.NoError(t, db.Create(&models.Project{BaseModel: models.BaseModel{ID: "shop"}, Name: "shop", Path: dir, Status: models.ProjectStatusRunning}).Error)
+
+	return &ProjectService{db: db, eventService: NewEventService(db), runner: runner}
+}
+
+func TestProjectService_BackupProjectStopsAndRedeploys(t *testing.T) {
+	ctx := context.Background()
+	runner := &fakeProjectRunner{volumes: []project.BackupVolume{
+		{Name: "cache", VolumeName: "shop_cache"},
+		{Name: "data", VolumeName: "shop_data"},
+	}}
+	svc := setupProjectBackupTest(t, runner)
+
+	backup, err := svc.BackupProject(ctx, "shop", project.CreateBackup{StopProject: true}, "", models.User{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"down", "backup shop_cache", "backup shop_data", "deploy"}, runner.calls)
+	assert.True(t, backup.StoppedProject)
+	assert.Contains(t, backup.ComposeContent, "image: nginx")
+	require.Len(t, backup.Volumes, 2)
+	assert.Equal(t, "shop_data-1", backup.Volumes[1].BackupID)
+
+	stored, err := svc.GetProjectBackup(ctx, "shop", backup.ID)
+	require.NoError(t, err)
+	assert.Len(t, stored.Volumes, 2)
+}
+
+func TestProjectService_BackupProjectFailureDeletesPartialSet(t *testing.T) {
+	ctx := context.Background()
+	runner := &fakeProjectRunner{
+		volumes: []project.BackupVolume{
+			{Name: "cache", VolumeName: "shop_cache"},
+			{Name: "data", VolumeName: "shop_data"},
+		},
+		backupErr: map[string]error{"shop_data": errors.New("disk full")},
+	}
+	svc := setupProjectBackupTest(t, runner)
+
+	_, err := svc.BackupProject(ctx, "shop", project.CreateBackup{StopProject: true}, "", models.User{})
+	require.ErrorContains(t, err, "disk full")
+	assert.Equal(t, []string{"down", "backup shop_cache", "backup shop_data", "delete shop_cache-1", "deploy"}, runner.calls)
+
+	backups, err := svc.ListProjectBackups(ctx, "shop")
+	require.NoError(t, err)
+	assert.Empty(t, backups)
+}
+
+func TestProjectService_RestoreProjectBackup(t *testing.T) {
+	ctx := context.Background()
+	volumes := []project.BackupVolume{{Name: "data", VolumeName: "shop_data", BackupID: "shop_data-1"}}
+
+	t.Run("redeploys a running project", func(t *testing.T) {
+		runner := &fakeProjectRunner{}
+		svc := setupProjectBackupTest(t, runner)
+		require.NoError(t, svc.db.Create(&models.ProjectBackup{BaseModel: models.BaseModel{ID: "b1"}, ProjectID: "shop", ProjectName: "shop", Volumes: volumes}).Error)
+
+		require.NoError(t, svc.RestoreProjectBackup(ctx, "shop", "b1", project.RestoreBackup{}, "", models.User{}))
+		assert.Equal(t, []string{"down", "ensure shop_data", "restore shop_data", "deploy"}, runner.calls)
+	})
+
+	t.Run("keeps the project stopped on request", func(t *testing.T) {
+		runner := &fakeProjectRunner{}
+		svc := setupProjectBackupTest(t, runner)
+		require.NoError(t, svc.db.Create(&models.ProjectBackup{BaseModel: models.BaseModel{ID: "b1"}, ProjectID: "shop", ProjectName: "shop", Volumes: volumes}).Error)
+
+		require.NoError(t, svc.RestoreProjectBackup(ctx, "shop", "b1", project.RestoreBackup{KeepStopped: true}, "", models.User{}))
+		assert.Equal(t, []string{"down", "ensure shop_data", "restore shop_data"}, runner.calls)
+	})
+
+	t.Run("redeploys after a failed restore", func(t *testing.T) {
+		restoreErr := errors.New("checksum mismatch")
+		runner := &fakeProjectRunner{restoreErr: restoreErr}
+		svc := setupProjectBackupTest(t, runner)
+		require.NoError(t, svc.db.Create(&models.ProjectBackup{BaseModel: models.BaseModel{ID: "b1"}, ProjectID: "shop", ProjectName: "shop", Volumes: volumes}).Error)
+
+		err := svc.RestoreProjectBackup(ctx, "shop", "b1", project.RestoreBackup{}, "", models.User{})
+		require.ErrorIs(t, err, restoreErr)
+		assert.Equal(t, []string{"down", "ensure shop_data", "restore shop_data", "deploy"}, runner.calls)
+	})
+
+	t.Run("reports both errors when the redeploy fails too", func(t *testing.T) {
+		restoreErr, deployErr := errors.New("checksum mismatch"), errors.New("port in use")
+		runner := &fakeProjectRunner{restoreErr: restoreErr, deployErr: deployErr}
+		svc := setupProjectBackupTest(t, runner)
+		require.NoError(t, svc.db.Create(&models.ProjectBackup{BaseModel: models.BaseModel{ID: "b1"}, ProjectID: "shop", ProjectName: "shop", Volumes: volumes}).Error)
+
+		err := svc.RestoreProjectBackup(ctx, "shop", "b1", project.RestoreBackup{}, "", models.User{})
+		require.ErrorIs(t, err, restoreErr)
+		require.ErrorIs(t, err, deployErr)
+	})
+}
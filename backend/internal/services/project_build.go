@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+
+	composetypes "github.com/compose-spec/compose-go/v2/types"
+	"github.com/docker/compose/v5/pkg/api"
+	"github.com/getarcaneapp/arcane/backend/internal/models"
+	"github.com/getarcaneapp/arcane/backend/pkg/projects"
+	"github.com/getarcaneapp/arcane/types/project"
+)
+
+// BuildProjectImages builds the images of the project services that have a build
+// section, using the project directory as build context, and streams the build output
+// to progressWriter as JSON lines. With req.Services only those services are built.
+func (s *ProjectService) BuildProjectImages(ctx context.Context, projectID string, req project.BuildImages, progressWriter io.Writer, user models.User) ([]string, error) {
+	proj, err := s.GetProjectFromDatabaseByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	composeProject, err := s.loadComposeProjectInternal(ctx, proj)
+	if err != nil {
+		return nil, err
+	}
+
+	services := req.Services
+	if len(services) == 0 {
+		services = buildableServicesInternal(composeProject)
+	}
+	for _, name := range services {
+		svc, ok := composeProject.Services[name]
+		if !ok {
+			return nil, fmt.Errorf("service %s not found in project", name)
+		}
+		if svc.Build == nil {
+			return nil, fmt.Errorf("service %s has no build section", name)
+		}
+	}
+	if len(services) == 0 {
+		return nil, fmt.Errorf("project has no services with a build section")
+	}
+
+	images, err := s.buildServiceImagesInternal(ctx, composeProject, services, projects.BuildOptions{NoCache: req.NoCache, Pull: req.Pull}, progressWriter)
+	if err != nil {
+		s.eventService.LogErrorEvent(ctx, models.EventTypeProjectError, "project", projectID, proj.Name, user.ID, user.Username, "0", err, models.JSON{"action": "build"})
+		return images, err
+	}
+
+	metadata := models.JSON{"action": "build", "projectID": projectID, "services": services, "images": images}
+	if logErr := s.eventService.LogProjectEvent(ctx, models.EventTypeProjectBuild, projectID, proj.Name, user.ID, user.Username, "0", metadata); logErr != nil {
+		slog.ErrorContext(ctx, "could not log project build action", "error", logErr)
+	}
+	return images, nil
+}
+
+// buildServiceImagesInternal builds the images of the given services in order and
+// returns the names of the images built so far.
+func (s *ProjectService) buildServiceImagesInternal(ctx context.Context, composeProject *composetypes.Project, services []string, opts projects.BuildOptions, progressWriter io.Writer) ([]string, error) {
+	dockerClient, err := s.dockerService.GetClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Docker: %w", err)
+	}
+
+	images := make([]string, 0, len(services))
+	for _, name := range services {
+		image, err := projects.BuildServiceImage(ctx, dockerClient, composeProject, name, opts, progressWriter)
+		if err != nil {
+			return images, err
+		}
+		images = append(images, image)
+	}
+	return images, nil
+}
+
+// buildableServicesInternal returns the sorted names of the services with a build section.
+func buildableServicesInternal(composeProject *composetypes.Project) []string {
+	var services []string
+	for _, name := range slices.Sorted(maps.Keys(composeProject.Services)) {
+		if composeProject.Services[name].Build != nil {
+			services = append(services, name)
+		}
+	}
+	return services
+}
+
+// servicesToBuildInternal returns the buildable services whose image is missing locally
+// or whose pull policy asks for a build on every deploy, as compose up does.
+func (s *ProjectService) servicesToBuildInternal(ctx context.Context, composeProject *composetypes.Project) []string {
+	var services []string
+	for _, name := range buildableServicesInternal(composeProject) {
+		svc := composeProject.Services[name]
+		if strings.EqualFold(svc.PullPolicy, composetypes.PullPolicyBuild) {
+			services = append(services, name)
+			continue
+		}
+		image := api.GetImageNameOrDefault(svc, composeProject.Name)
+		exists, err := s.imageService.ImageExistsLocally(ctx, image)
+		if err != nil {
+			slog.WarnContext(ctx, "failed to check local image existence", "image", image, "error", err)
+		}
+		if !exists {
+			services = append(services, name)
+		}
+	}
+	return services
+}
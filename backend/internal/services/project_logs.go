@@ -0,0 +1,432 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/compose/v5/pkg/api"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/logparse"
+	"github.com/getarcaneapp/arcane/types/project"
+)
+
+const (
+	logStreamStdout = "stdout"
+	logStreamStderr = "stderr"
+
+	// projectLogSearchDefaultLimit and projectLogSearchMaxLimit bound the number of lines
+	// a project log search returns.
+	projectLogSearchDefaultLimit = 1000
+	projectLogSearchMaxLimit     = 10000
+	// projectLogMaxLineSize caps the length of a single log line; longer lines are cut.
+	projectLogMaxLineSize = 256 << 10
+)
+
+// ErrInvalidLogQuery is returned for a log query with a malformed time, pattern, stream
+// or level.
+var ErrInvalidLogQuery = errors.New("invalid log query")
+
+// ProjectLogQuery selects log lines of the containers of a project. Empty fields match
+// all lines.
+type ProjectLogQuery struct {
+	// Since and Until bound the lines by time. They accept RFC 3339 times, Unix
+	// timestamps and durations relative to now, such as 2h.
+	Since string
+	Until string
+	// Pattern is a regular expression the message must match.
+	Pattern    string
+	IgnoreCase bool
+	// Streams are stdout and/or stderr.
+	Streams  []string
+	Services []string
+	// Levels are log levels as detected by logparse.DetectLevel.
+	Levels []string
+	// Limit is the maximum number of lines a search returns.
+	Limit int
+}
+
+// projectLogFilter is a validated ProjectLogQuery.
+type projectLogFilter struct {
+	since, until time.Time
+	pattern      *regexp.Regexp
+	stdout       bool
+	stderr       bool
+	services     []string
+	levels       []string
+	limit        int
+}
+
+// timedLogEntry is a log entry with its parsed timestamp for ordering.
+type timedLogEntry struct {
+	time  time.Time
+	entry project.LogEntry
+}
+
+// ProjectLogExport streams the logs of a project selected by a ProjectLogQuery.
+type ProjectLogExport struct {
+	ProjectName string
+
+	dockerClient *client.Client
+	containers   []container.Summary
+	filter       *projectLogFilter
+}
+
+// SearchProjectLogs returns the log lines of a project's containers that match q, in
+// chronological order. When more lines match than the limit, the most recent are kept.
+func (s *ProjectService) SearchProjectLogs(ctx context.Context, projectID string, q ProjectLogQuery) (project.LogSearchResult, error) {
+	export, err := s.OpenProjectLogExport(ctx, projectID, q)
+	if err != nil {
+		return project.LogSearchResult{}, err
+	}
+
+	limit := export.filter.limit
+	var matches []timedLogEntry
+	total := 0
+	for _, c := range export.containers {
+		var containerMatches []timedLogEntry
+		err := export.readContainerInternal(ctx, c, func(t time.Time, entry project.LogEntry) error {
+			total++
+			containerMatches = append(containerMatches, timedLogEntry{time: t, entry: entry})
+			// Keep only the newest lines, trimming in batches.
+			if len(containerMatches) >= 2*limit {
+				containerMatches = append(containerMatches[:0], containerMatches[len(containerMatches)-limit:]...)
+			}
+			return nil
+		})
+		if err != nil {
+			return project.LogSearchResult{}, err
+		}
+		matches = append(matches, containerMatches...)
+	}
+
+	slices.SortStableFunc(matches, func(a, b timedLogEntry) int { return a.time.Compare(b.time) })
+	if len(matches) > limit {
+		matches = matches[len(matches)-limit:]
+	}
+
+	result := project.LogSearchResult{
+		Entries:    make([]project.LogEntry, 0, len(matches)),
+		Truncated:  total > limit,
+		Containers: len(export.containers),
+	}
+	for _, m := range matches {
+		result.Entries = append(result.Entries, m.entry)
+	}
+	return result, nil
+}
+
+// OpenProjectLogExport validates q and finds the containers of a project, so that errors
+// are reported before an export starts writing.
+func (s *ProjectService) OpenProjectLogExport(ctx context.Context, projectID string, q ProjectLogQuery) (*ProjectLogExport, error) {
+	filter, err := compileProjectLogQueryInternal(q, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	proj, err := s.GetProjectFromDatabaseByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	dockerClient, err := s.dockerService.GetClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Docker: %w", err)
+	}
+
+	projectName := normalizeComposeProjectName(proj.Name)
+	containers, err := dockerClient.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("label", api.ProjectLabel+"="+projectName)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list project containers: %w", err)
+	}
+
+	containers = slices.DeleteFunc(containers, func(c container.Summary) bool {
+		return len(filter.services) > 0 && !slices.Contains(filter.services, c.Labels[api.ServiceLabel])
+	})
+	slices.SortFunc(containers, func(a, b container.Summary) int {
+		if c := strings.Compare(a.Labels[api.ServiceLabel], b.Labels[api.ServiceLabel]); c != 0 {
+			return c
+		}
+		return strings.Compare(summaryContainerNameInternal(a), summaryContainerNameInternal(b))
+	})
+
+	return &ProjectLogExport{
+		ProjectName:  projectName,
+		dockerClient: dockerClient,
+		containers:   containers,
+		filter:       filter,
+	}, nil
+}
+
+// WriteNDJSON writes the matching lines as newline-delimited JSON log entries, grouped
+// by container and in chronological order within each container.
+func (e *ProjectLogExport) WriteNDJSON(ctx context.Context, w io.Writer) error {
+	enc := json.NewEncoder(w)
+	for _, c := range e.containers {
+		if err := e.readContainerInternal(ctx, c, func(_ time.Time, entry project.LogEntry) error {
+			return enc.Encode(entry)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteZip writes a zip archive with a <container>.log file per container. Each line is
+// prefixed with its timestamp and stream.
+func (e *ProjectLogExport) WriteZip(ctx context.Context, w io.Writer) error {
+	zw := zip.NewWriter(w)
+	for _, c := range e.containers {
+		f, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     summaryContainerNameInternal(c) + ".log",
+			Method:   zip.Deflate,
+			Modified: time.Now(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to write log archive: %w", err)
+		}
+		if err := e.readContainerInternal(ctx, c, func(_ time.Time, entry project.LogEntry) error {
+			_, err := fmt.Fprintf(f, "%s %s %s\n", entry.Timestamp, entry.Stream, entry.Message)
+			return err
+		}); err != nil {
+			return err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to write log archive: %w", err)
+	}
+	return nil
+}
+
+// readContainerInternal calls fn for each line of a container's logs that matches the
+// filter.
+func (e *ProjectLogExport) readContainerInternal(ctx context.Context, c container.Summary, fn func(time.Time, project.LogEntry) error) error {
+	if !e.filter.stdout && !e.filter.stderr {
+		return nil
+	}
+
+	inspect, err := e.dockerClient.ContainerInspect(ctx, c.ID)
+	if err != nil {
+		return fmt.Errorf("failed to inspect container %s: %w", summaryContainerNameInternal(c), err)
+	}
+	tty := inspect.Config != nil && inspect.Config.Tty
+	if tty && !e.filter.stdout {
+		// Containers with a TTY only have a combined stdout stream.
+		return nil
+	}
+
+	opts := container.LogsOptions{
+		ShowStdout: e.filter.stdout,
+		ShowStderr: e.filter.stderr,
+		Timestamps: true,
+	}
+	if !e.filter.since.IsZero() {
+		opts.Since = formatDockerLogTimeInternal(e.filter.since)
+	}
+	if !e.filter.until.IsZero() {
+		opts.Until = formatDockerLogTimeInternal(e.filter.until)
+	}
+
+	logs, err := e.dockerClient.ContainerLogs(ctx, c.ID, opts)
+	if err != nil {
+		return fmt.Errorf("failed to get logs of container %s: %w", summaryContainerNameInternal(c), err)
+	}
+	defer func() { _ = logs.Close() }()
+
+	newWriter := func(stream string) *logLineWriter {
+		return &logLineWriter{fn: func(line []byte) error {
+			t, entry, ok := e.filter.matchInternal(line)
+			if !ok {
+				return ctx.Err()
+			}
+			entry.Service = c.Labels[api.ServiceLabel]
+			entry.ContainerID = c.ID
+			entry.ContainerName = summaryContainerNameInternal(c)
+			entry.Stream = stream
+			return fn(t, entry)
+		}}
+	}
+	stdout, stderr := newWriter(logStreamStdout), newWriter(logStreamStderr)
+
+	if tty {
+		_, err = io.Copy(stdout, logs)
+	} else {
+		_, err = stdcopy.StdCopy(stdout, stderr, logs)
+	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to read logs of container %s: %w", summaryContainerNameInternal(c), err)
+	}
+	if err := stdout.Flush(); err != nil {
+		return err
+	}
+	return stderr.Flush()
+}
+
+// matchInternal parses a timestamped Docker log line and reports whether it matches
+// the filter.
+func (f *projectLogFilter) matchInternal(line []byte) (time.Time, project.LogEntry, bool) {
+	timestamp, message, _ := bytes.Cut(line, []byte(" "))
+	t, err := time.Parse(time.RFC3339Nano, string(timestamp))
+	if err != nil {
+		// Not a timestamped line; keep it whole.
+		timestamp, message = nil, line
+	}
+	message = bytes.TrimRight(message, "\r")
+
+	if f.pattern != nil && !f.pattern.Match(message) {
+		return time.Time{}, project.LogEntry{}, false
+	}
+	level := logparse.DetectLevel(message)
+	if len(f.levels) > 0 && !slices.Contains(f.levels, level) {
+		return time.Time{}, project.LogEntry{}, false
+	}
+
+	return t, project.LogEntry{Timestamp: string(timestamp), Level: level, Message: string(message)}, true
+}
+
+// compileProjectLogQueryInternal validates q, resolving relative times against now.
+func compileProjectLogQueryInternal(q ProjectLogQuery, now time.Time) (*projectLogFilter, error) {
+	f := &projectLogFilter{limit: q.Limit}
+	if f.limit <= 0 {
+		f.limit = projectLogSearchDefaultLimit
+	}
+	f.limit = min(f.limit, projectLogSearchMaxLimit)
+
+	var err error
+	if f.since, err = parseLogTimeInternal(q.Since, now); err != nil {
+		return nil, fmt.Errorf("%w: since: %w", ErrInvalidLogQuery, err)
+	}
+	if f.until, err = parseLogTimeInternal(q.Until, now); err != nil {
+		return nil, fmt.Errorf("%w: until: %w", ErrInvalidLogQuery, err)
+	}
+	if !f.since.IsZero() && !f.until.IsZero() && f.until.Before(f.since) {
+		return nil, fmt.Errorf("%w: until is before since", ErrInvalidLogQuery)
+	}
+
+	if q.Pattern != "" {
+		expr := q.Pattern
+		if q.IgnoreCase {
+			expr = "(?i)" + expr
+		}
+		if f.pattern, err = regexp.Compile(expr); err != nil {
+			return nil, fmt.Errorf("%w: pattern: %w", ErrInvalidLogQuery, err)
+		}
+	}
+
+	if len(q.Streams) == 0 {
+		f.stdout, f.stderr = true, true
+	}
+	for _, stream := range q.Streams {
+		switch strings.ToLower(strings.TrimSpace(stream)) {
+		case logStreamStdout:
+			f.stdout = true
+		case logStreamStderr:
+			f.stderr = true
+		default:
+			return nil, fmt.Errorf("%w: unknown stream %q", ErrInvalidLogQuery, stream)
+		}
+	}
+
+	for _, level := range q.Levels {
+		normalized := logparse.NormalizeLevel(level)
+		if normalized == "" {
+			return nil, fmt.Errorf("%w: unknown level %q", ErrInvalidLogQuery, level)
+		}
+		f.levels = append(f.levels, normalized)
+	}
+
+	for _, service := range q.Services {
+		if service = strings.TrimSpace(service); service != "" {
+			f.services = append(f.services, service)
+		}
+	}
+	return f, nil
+}
+
+// parseLogTimeInternal parses an RFC 3339 time, a Unix timestamp or a duration before
+// now. An empty value returns the zero time.
+func parseLogTimeInternal(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, nil
+	}
+	if secs, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(secs, 0), nil
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("%q is not an RFC 3339 time, Unix timestamp or duration", value)
+}
+
+// formatDockerLogTimeInternal formats t for the since and until options of the Docker
+// logs API.
+func formatDockerLogTimeInternal(t time.Time) string {
+	return fmt.Sprintf("%d.%09d", t.Unix(), t.Nanosecond())
+}
+
+func summaryContainerNameInternal(c container.Summary) string {
+	if len(c.Names) > 0 {
+		return strings.TrimPrefix(c.Names[0], "/")
+	}
+	return c.ID
+}
+
+// logLineWriter splits written data into lines. Lines longer than
+// projectLogMaxLineSize are cut.
+type logLineWriter struct {
+	buf []byte
+	fn  func(line []byte) error
+}
+
+func (w *logLineWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		i := bytes.IndexByte(p, '\n')
+		if i < 0 {
+			w.buf = append(w.buf, p[:min(len(p), projectLogMaxLineSize-len(w.buf))]...)
+			break
+		}
+		w.buf = append(w.buf, p[:min(i, projectLogMaxLineSize-len(w.buf))]...)
+		p = p[i+1:]
+		if err := w.emitInternal(); err != nil {
+			return 0, err
+		}
+	}
+	return n, nil
+}
+
+// Flush emits a final line without a trailing newline.
+func (w *logLineWriter) Flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	return w.emitInternal()
+}
+
+func (w *logLineWriter) emitInternal() error {
+	line := w.buf
+	w.buf = w.buf[:0]
+	if len(line) == 0 {
+		return nil
+	}
+	return w.fn(line)
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLogTimeInternal(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	got, err := parseLogTimeInternal("", now)
+	require.NoError(t, err)
+	assert.True(t, got.IsZero())
+
+	got, err = parseLogTimeInternal("2024-05-01T10:30:00Z", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC), got)
+
+	got, err = parseLogTimeInternal("1714557600", now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1714557600), got.Unix())
+
+	got, err = parseLogTimeInternal("90m", now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(-90*time.Minute), got)
+
+	_, err = parseLogTimeInternal("yesterday", now)
+	require.Error(t, err)
+}
+
+func TestCompileProjectLogQueryInternal(t *testing.T) {
+	now := time.Now()
+
+	f, err := compileProjectLogQueryInternal(ProjectLogQuery{}, now)
+	require.NoError(t, err)
+	assert.True(t, f.stdout)
+	assert.True(t, f.stderr)
+	assert.Equal(t, projectLogSearchDefaultLimit, f.limit)
+
+	f, err = compileProjectLogQueryInternal(ProjectLogQuery{Streams: []string{"stderr"}, Levels: []string{"WARNING", "err"}, Limit: 1 << 20}, now)
+	require.NoError(t, err)
+	assert.False(t, f.stdout)
+	assert.True(t, f.stderr)
+	assert.Equal(t, []string{"warn", "error"}, f.levels)
+	assert.Equal(t, projectLogSearchMaxLimit, f.limit)
+
+	for name, q := range map[string]ProjectLogQuery{
+		"pattern":     {Pattern: "("},
+		"stream":      {Streams: []string{"stdin"}},
+		"level":       {Levels: []string{"loud"}},
+		"since":       {Since: "soon"},
+		"until first": {Since: "1h", Until: "2h"},
+	} {
+		_, err := compileProjectLogQueryInternal(q, now)
+		require.ErrorIs(t, err, ErrInvalidLogQuery, name)
+	}
+}
+
+func TestProjectLogFilter_MatchInternal(t *testing.T) {
+	f, err := compileProjectLogQueryInternal(ProjectLogQuery{Pattern: "TIMEOUT", IgnoreCase: true, Levels: []string{"error"}}, time.Now())
+	require.NoError(t, err)
+
+	ts, entry, ok := f.matchInternal([]byte("2024-05-01T10:30:00.123456789Z level=error msg=\"upstream timeout\"\r"))
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2024, 5, 1, 10, 30, 0, 123456789, time.UTC), ts)
+	assert.Equal(t, "2024-05-01T10:30:00.123456789Z", entry.Timestamp)
+	assert.Equal(t, "error", entry.Level)
+	assert.Equal(t, `level=error msg="upstream timeout"`, entry.Message)
+
+	_, _, ok = f.matchInternal([]byte("2024-05-01T10:30:00Z level=info msg=\"upstream timeout\""))
+	assert.False(t, ok)
+	_, _, ok = f.matchInternal([]byte("2024-05-01T10:30:00Z level=error msg=\"connection refused\""))
+	assert.False(t, ok)
+}
+
+func TestLogLineWriter(t *testing.T) {
+	var lines []string
+	w := &logLineWriter{fn: func(line []byte) error {
+		lines = append(lines, string(line))
+		return nil
+	}}
+
+	_, err := w.Write([]byte("first\nsec"))
+	require.NoError(t, err)
+	_, err = w.Write([]byte("ond\n\nthird"))
+	require.NoError(t, err)
+	require.NoError(t, w.Flush())
+
+	assert.Equal(t, []string{"first", "second", "third"}, lines)
+}
@@ -0,0 +1,352 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	composetypes "github.com/compose-spec/compose-go/v2/types"
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/docker/compose/v5/pkg/api"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/fs"
+	"github.com/getarcaneapp/arcane/backend/pkg/projects"
+	"github.com/getarcaneapp/arcane/types/project"
+)
+
+// Plan issue severities, kinds and service actions.
+const (
+	planSeverityError   = "error"
+	planSeverityWarning = "warning"
+
+	planKindSchema   = "schema"
+	planKindVariable = "variable"
+	planKindEnvFile  = "env_file"
+	planKindPort     = "port"
+	planKindNetwork  = "network"
+	planKindVolume   = "volume"
+
+	planActionCreate    = "create"
+	planActionRecreate  = "recreate"
+	planActionStart     = "start"
+	planActionUnchanged = "unchanged"
+	planActionRemove    = "remove"
+	planActionOrphaned  = "orphaned"
+)
+
+// PlanProject validates a project and compares it to its containers without changing
+// anything. It reports schema errors, unresolved variables, missing env files, ports
+// taken by other containers and missing external networks and volumes, and lists what
+// deploying the project would do with each service.
+func (s *ProjectService) PlanProject(ctx context.Context, projectID string) (project.Plan, error) {
+	proj, err := s.GetProjectFromDatabaseByID(ctx, projectID)
+	if err != nil {
+		return project.Plan{}, err
+	}
+
+	plan := project.Plan{
+		ProjectID:   proj.ID,
+		ProjectName: normalizeComposeProjectName(proj.Name),
+		Issues:      []project.PlanIssue{},
+		Services:    []project.ServicePlan{},
+	}
+
+	composeFile, err := projects.DetectComposeFile(proj.Path)
+	if err != nil {
+		addPlanIssueInternal(&plan, planSeverityError, planKindSchema, "", fmt.Sprintf("no compose file found in project directory: %s", proj.Path))
+		return finishPlanInternal(plan), nil
+	}
+
+	projectsDirectory, pdErr := fs.GetProjectsDirectory(ctx, strings.TrimSpace(s.settingsService.GetStringSetting(ctx, "projectsDirectory", "/app/data/projects")))
+	if pdErr != nil {
+		slog.WarnContext(ctx, "unable to determine projects directory; using default", "error", pdErr)
+		projectsDirectory = "/app/data/projects"
+	}
+	pathMapper, pmErr := s.getPathMapper(ctx)
+	if pmErr != nil {
+		slog.WarnContext(ctx, "failed to create path mapper, continuing without translation", "error", pmErr)
+	}
+	autoInjectEnv := s.settingsService.GetBoolSetting(ctx, "autoInjectEnv", false)
+
+	composeProject, err := projects.LoadComposeProject(ctx, composeFile, plan.ProjectName, projectsDirectory, autoInjectEnv, pathMapper)
+	if err != nil {
+		kind := planKindSchema
+		if errors.Is(err, os.ErrNotExist) {
+			kind = planKindEnvFile
+		}
+		addPlanIssueInternal(&plan, planSeverityError, kind, "", err.Error())
+		return finishPlanInternal(plan), nil
+	}
+	plan.ProjectName = composeProject.Name
+
+	unresolved, err := projects.UnresolvedVariables(composeFile, composeProject.Environment)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to scan compose file for variables", "projectID", projectID, "error", err)
+	}
+	for _, name := range unresolved {
+		addPlanIssueInternal(&plan, planSeverityWarning, planKindVariable, "", fmt.Sprintf("variable %s is not set and has no default; an empty string will be used", name))
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(composeProject.Services)) {
+		for _, envFile := range composeProject.Services[name].EnvFiles {
+			if _, statErr := os.Stat(envFile.Path); statErr == nil {
+				continue
+			}
+			severity := planSeverityError
+			if !envFile.Required {
+				severity = planSeverityWarning
+			}
+			addPlanIssueInternal(&plan, severity, planKindEnvFile, name, fmt.Sprintf("env file %s not found", envFile.Path))
+		}
+	}
+
+	dockerClient, err := s.dockerService.GetClient()
+	if err != nil {
+		return project.Plan{}, fmt.Errorf("failed to connect to Docker: %w", err)
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(composeProject.Networks)) {
+		n := composeProject.Networks[key]
+		if !bool(n.External) {
+			continue
+		}
+		if _, err := dockerClient.NetworkInspect(ctx, n.Name, network.InspectOptions{}); err != nil {
+			if !cerrdefs.IsNotFound(err) {
+				return project.Plan{}, fmt.Errorf("failed to inspect network %s: %w", n.Name, err)
+			}
+			addPlanIssueInternal(&plan, planSeverityError, planKindNetwork, "", fmt.Sprintf("external network %s does not exist", n.Name))
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(composeProject.Volumes)) {
+		v := composeProject.Volumes[key]
+		if !bool(v.External) {
+			continue
+		}
+		if _, err := dockerClient.VolumeInspect(ctx, v.Name); err != nil {
+			if !cerrdefs.IsNotFound(err) {
+				return project.Plan{}, fmt.Errorf("failed to inspect volume %s: %w", v.Name, err)
+			}
+			addPlanIssueInternal(&plan, planSeverityError, planKindVolume, "", fmt.Sprintf("external volume %s does not exist", v.Name))
+		}
+	}
+
+	containers, err := dockerClient.ContainerList(ctx, container.ListOptions{All: true})
+	if err != nil {
+		return project.Plan{}, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	plan.Issues = append(plan.Issues, planPortConflictsInternal(composeProject, containers)...)
+
+	removeOrphans := proj.GitOpsManagedBy != nil && *proj.GitOpsManagedBy != ""
+	plan.Services = planServiceActionsInternal(composeProject, containers, removeOrphans)
+
+	return finishPlanInternal(plan), nil
+}
+
+func addPlanIssueInternal(plan *project.Plan, severity, kind, service, message string) {
+	plan.Issues = append(plan.Issues, project.PlanIssue{Severity: severity, Kind: kind, Service: service, Message: message})
+}
+
+// finishPlanInternal marks a plan valid when none of its issues is an error.
+func finishPlanInternal(plan project.Plan) project.Plan {
+	plan.Valid = !slices.ContainsFunc(plan.Issues, func(issue project.PlanIssue) bool {
+		return issue.Severity == planSeverityError
+	})
+	return plan
+}
+
+// planPort is a host port published by a service or container.
+type planPort struct {
+	hostIP   string
+	port     int
+	protocol string
+}
+
+func (p planPort) overlaps(other planPort) bool {
+	if p.port != other.port || p.protocol != other.protocol {
+		return false
+	}
+	return isWildcardHostIPInternal(p.hostIP) || isWildcardHostIPInternal(other.hostIP) || p.hostIP == other.hostIP
+}
+
+func isWildcardHostIPInternal(ip string) bool {
+	return ip == "" || ip == "0.0.0.0" || ip == "::"
+}
+
+// planPortConflictsInternal reports host ports published more than once within the
+// project and ports already taken by running containers of other projects. The
+// project's own containers are ignored since they are replaced on deploy.
+func planPortConflictsInternal(composeProject *composetypes.Project, containers []container.Summary) []project.PlanIssue {
+	var issues []project.PlanIssue
+
+	type owner struct {
+		port    planPort
+		service string
+	}
+	var claimed []owner
+	for _, name := range slices.Sorted(maps.Keys(composeProject.Services)) {
+		for _, p := range composeProject.Services[name].Ports {
+			for _, port := range publishedPortsInternal(p) {
+				for _, other := range claimed {
+					if other.port.overlaps(port) {
+						issues = append(issues, project.PlanIssue{
+							Severity: planSeverityError,
+							Kind:     planKindPort,
+							Service:  name,
+							Message:  fmt.Sprintf("port %d/%s is also published by service %s", port.port, port.protocol, other.service),
+						})
+					}
+				}
+				claimed = append(claimed, owner{port: port, service: name})
+			}
+		}
+	}
+
+	for _, c := range containers {
+		if c.State != container.StateRunning || c.Labels[api.ProjectLabel] == composeProject.Name {
+			continue
+		}
+		containerName := strings.TrimPrefix(firstOrEmptyInternal(c.Names), "/")
+		for _, cp := range c.Ports {
+			if cp.PublicPort == 0 {
+				continue
+			}
+			used := planPort{hostIP: cp.IP, port: int(cp.PublicPort), protocol: strings.ToLower(cp.Type)}
+			for _, own := range claimed {
+				if own.port.overlaps(used) {
+					issues = append(issues, project.PlanIssue{
+						Severity: planSeverityError,
+						Kind:     planKindPort,
+						Service:  own.service,
+						Message:  fmt.Sprintf("port %d/%s is already used by container %s", used.port, used.protocol, containerName),
+					})
+				}
+			}
+		}
+	}
+
+	return dedupePlanIssuesInternal(issues)
+}
+
+// publishedPortsInternal expands the host ports of a port mapping, including ranges
+// such as 8000-8010. Mappings without a published port get a random one and are skipped.
+func publishedPortsInternal(p composetypes.ServicePortConfig) []planPort {
+	if p.Published == "" {
+		return nil
+	}
+	protocol := strings.ToLower(p.Protocol)
+	if protocol == "" {
+		protocol = "tcp"
+	}
+
+	start, end, isRange := strings.Cut(p.Published, "-")
+	first, err := strconv.Atoi(start)
+	if err != nil {
+		return nil
+	}
+	last := first
+	if isRange {
+		if last, err = strconv.Atoi(end); err != nil || last < first {
+			return nil
+		}
+	}
+
+	ports := make([]planPort, 0, last-first+1)
+	for port := first; port <= last; port++ {
+		ports = append(ports, planPort{hostIP: p.HostIP, port: port, protocol: protocol})
+	}
+	return ports
+}
+
+func dedupePlanIssuesInternal(issues []project.PlanIssue) []project.PlanIssue {
+	seen := make(map[project.PlanIssue]struct{}, len(issues))
+	out := make([]project.PlanIssue, 0, len(issues))
+	for _, issue := range issues {
+		if _, ok := seen[issue]; ok {
+			continue
+		}
+		seen[issue] = struct{}{}
+		out = append(out, issue)
+	}
+	return out
+}
+
+func firstOrEmptyInternal(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// planServiceActionsInternal decides per service what compose up would do, using the
+// same config hash compose compares to decide whether a container has diverged.
+// Containers of services that are no longer defined are removed only when orphans are
+// removed on deploy.
+func planServiceActionsInternal(composeProject *composetypes.Project, containers []container.Summary, removeOrphans bool) []project.ServicePlan {
+	byService := map[string][]container.Summary{}
+	for _, c := range containers {
+		if c.Labels[api.ProjectLabel] != composeProject.Name || c.Labels[api.OneoffLabel] == "True" {
+			continue
+		}
+		service := c.Labels[api.ServiceLabel]
+		byService[service] = append(byService[service], c)
+	}
+
+	plans := make([]project.ServicePlan, 0, len(composeProject.Services))
+	for _, name := range slices.Sorted(maps.Keys(composeProject.Services)) {
+		service := composeProject.Services[name]
+		plan := project.ServicePlan{Name: name, Image: service.Image}
+		existing := byService[name]
+		delete(byService, name)
+
+		if len(existing) == 0 {
+			plan.Action = planActionCreate
+			plan.Reason = "no container exists"
+			plans = append(plans, plan)
+			continue
+		}
+
+		hash, err := projects.ServiceConfigHash(service)
+		if err != nil {
+			plan.Action = planActionRecreate
+			plan.Reason = fmt.Sprintf("unable to compute configuration hash: %v", err)
+			plans = append(plans, plan)
+			continue
+		}
+
+		plan.Action = planActionUnchanged
+		for _, c := range existing {
+			if c.Labels[api.ConfigHashLabel] != hash {
+				plan.Action = planActionRecreate
+				plan.Reason = "configuration changed"
+				break
+			}
+			if c.State != container.StateRunning {
+				plan.Action = planActionStart
+				plan.Reason = fmt.Sprintf("container is %s", c.State)
+			}
+		}
+		plans = append(plans, plan)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(byService)) {
+		plan := project.ServicePlan{Name: name, Image: byService[name][0].Image}
+		if removeOrphans {
+			plan.Action = planActionRemove
+			plan.Reason = "service is no longer defined"
+		} else {
+			plan.Action = planActionOrphaned
+			plan.Reason = "service is no longer defined; its containers are left in place"
+		}
+		plans = append(plans, plan)
+	}
+
+	return plans
+}
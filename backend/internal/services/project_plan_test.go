@@ -0,0 +1,100 @@
+package services
+
+import (
+	"testing"
+
+	composetypes "github.com/compose-spec/compose-go/v2/types"
+	"github.com/docker/compose/v5/pkg/api"
+	"github.com/docker/docker/api/types/container"
+	"github.com/getarcaneapp/arcane/backend/pkg/projects"
+	"github.com/getarcaneapp/arcane/types/project"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanPortConflictsInternal(t *testing.T) {
+	composeProject := &composetypes.Project{
+		Name: "shop",
+		Services: composetypes.Services{
+			"api": {Name: "api", Ports: []composetypes.ServicePortConfig{{Target: 80, Published: "8080", Protocol: "tcp"}}},
+			"web": {Name: "web", Ports: []composetypes.ServicePortConfig{{Target: 80, Published: "8080-8081", Protocol: "tcp"}}},
+			"db":  {Name: "db", Ports: []composetypes.ServicePortConfig{{Target: 5432, Published: "5432", HostIP: "127.0.0.1"}}},
+		},
+	}
+	containers := []container.Summary{
+		{
+			Names:  []string{"/other-db"},
+			State:  container.StateRunning,
+			Labels: map[string]string{api.ProjectLabel: "other"},
+			Ports:  []container.Port{{IP: "0.0.0.0", PrivatePort: 5432, PublicPort: 5432, Type: "tcp"}},
+		},
+		{
+			Names:  []string{"/shop-api-1"},
+			State:  container.StateRunning,
+			Labels: map[string]string{api.ProjectLabel: "shop"},
+			Ports:  []container.Port{{IP: "0.0.0.0", PrivatePort: 80, PublicPort: 8080, Type: "tcp"}},
+		},
+		{
+			Names:  []string{"/stopped"},
+			State:  container.StateExited,
+			Labels: map[string]string{},
+			Ports:  []container.Port{{PrivatePort: 80, PublicPort: 8081, Type: "tcp"}},
+		},
+	}
+
+	issues := planPortConflictsInternal(composeProject, containers)
+
+	assert.Equal(t, []project.PlanIssue{
+		{Severity: "error", Kind: "port", Service: "web", Message: "port 8080/tcp is also published by service api"},
+		{Severity: "error", Kind: "port", Service: "db", Message: "port 5432/tcp is already used by container other-db"},
+	}, issues)
+}
+
+func TestPlanServiceActionsInternal(t *testing.T) {
+	services := composetypes.Services{
+		"api":    {Name: "api", Image: "api:2"},
+		"cache":  {Name: "cache", Image: "redis:7"},
+		"web":    {Name: "web", Image: "nginx:alpine"},
+		"worker": {Name: "worker", Image: "worker:1"},
+	}
+	composeProject := &composetypes.Project{Name: "shop", Services: services}
+
+	hash := func(name string) string {
+		h, err := projects.ServiceConfigHash(services[name])
+		require.NoError(t, err)
+		return h
+	}
+	labels := func(service, configHash string) map[string]string {
+		return map[string]string{api.ProjectLabel: "shop", api.ServiceLabel: service, api.ConfigHashLabel: configHash}
+	}
+	containers := []container.Summary{
+		{State: container.StateRunning, Labels: labels("api", "outdated")},
+		{State: container.StateRunning, Labels: labels("web", hash("web"))},
+		{State: container.StateExited, Labels: labels("worker", hash("worker"))},
+		{State: container.StateRunning, Image: "legacy:1", Labels: labels("legacy", "x")},
+		{State: container.StateRunning, Labels: map[string]string{api.ProjectLabel: "other", api.ServiceLabel: "cache"}},
+	}
+
+	plans := planServiceActionsInternal(composeProject, containers, false)
+
+	actions := map[string]string{}
+	for _, p := range plans {
+		actions[p.Name] = p.Action
+	}
+	assert.Equal(t, map[string]string{
+		"api":    "recreate",
+		"cache":  "create",
+		"web":    "unchanged",
+		"worker": "start",
+		"legacy": "orphaned",
+	}, actions)
+
+	plans = planServiceActionsInternal(composeProject, containers, true)
+	assert.Equal(t, "remove", plans[len(plans)-1].Action)
+}
+
+func TestPublishedPortsInternal(t *testing.T) {
+	assert.Nil(t, publishedPortsInternal(composetypes.ServicePortConfig{Target: 80}))
+	assert.Equal(t, []planPort{{port: 53, protocol: "udp"}}, publishedPortsInternal(composetypes.ServicePortConfig{Published: "53", Protocol: "UDP"}))
+	assert.Len(t, publishedPortsInternal(composetypes.ServicePortConfig{Published: "9000-9002"}), 3)
+}
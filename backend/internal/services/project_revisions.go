@@ -0,0 +1,275 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/getarcaneapp/arcane/backend/internal/models"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/fs"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/pagination"
+	"github.com/getarcaneapp/arcane/backend/pkg/projects"
+	"github.com/getarcaneapp/arcane/types/project"
+	"github.com/pmezard/go-difflib/difflib"
+	"gorm.io/gorm"
+)
+
+// ErrProjectRevisionNotFound is returned when a project has no revision with the
+// requested number.
+var ErrProjectRevisionNotFound = errors.New("project revision not found")
+
+// projectFiles is a snapshot of the files of a project that are tracked in revisions.
+type projectFiles struct {
+	compose  string
+	env      string
+	includes map[string]string
+}
+
+func (f projectFiles) equal(other projectFiles) bool {
+	return f.compose == other.compose && f.env == other.env && maps.Equal(f.includes, other.includes)
+}
+
+func projectFilesFromRevisionInternal(r *models.ProjectRevision) projectFiles {
+	return projectFiles{compose: r.ComposeContent, env: r.EnvContent, includes: r.IncludeFiles}
+}
+
+// ListProjectRevisions returns the revisions of a project without their content, newest
+// first unless params sort otherwise.
+func (s *ProjectService) ListProjectRevisions(ctx context.Context, projectID string, params pagination.QueryParams) ([]models.ProjectRevision, pagination.Response, error) {
+	query := s.db.WithContext(ctx).Model(&models.ProjectRevision{}).
+		Omit("compose_content", "env_content", "include_files", "diff").
+		Where("project_id = ?", projectID)
+	if params.Sort == "" {
+		query = query.Order("revision DESC")
+	}
+
+	var revisions []models.ProjectRevision
+	paginationResp, err := pagination.PaginateAndSortDB(params, query, &revisions)
+	if err != nil {
+		return nil, pagination.Response{}, fmt.Errorf("failed to list project revisions: %w", err)
+	}
+	return revisions, paginationResp, nil
+}
+
+func (s *ProjectService) GetProjectRevision(ctx context.Context, projectID string, revision int) (*models.ProjectRevision, error) {
+	var rev models.ProjectRevision
+	if err := s.db.WithContext(ctx).Where("project_id = ? AND revision = ?", projectID, revision).First(&rev).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProjectRevisionNotFound
+		}
+		return nil, fmt.Errorf("failed to get project revision: %w", err)
+	}
+	return &rev, nil
+}
+
+// DiffProjectRevisions returns the unified diff of all files between two revisions.
+func (s *ProjectService) DiffProjectRevisions(ctx context.Context, projectID string, from, to int) (project.RevisionDiff, error) {
+	fromRev, err := s.GetProjectRevision(ctx, projectID, from)
+	if err != nil {
+		return project.RevisionDiff{}, err
+	}
+	toRev, err := s.GetProjectRevision(ctx, projectID, to)
+	if err != nil {
+		return project.RevisionDiff{}, err
+	}
+
+	diff, _, _ := diffProjectFilesInternal(projectFilesFromRevisionInternal(fromRev), projectFilesFromRevisionInternal(toRev))
+	return project.RevisionDiff{From: from, To: to, Diff: diff}, nil
+}
+
+// RedeployProjectRevision writes the files of an earlier revision back to the project,
+// stores them as a new rollback revision and redeploys the project.
+func (s *ProjectService) RedeployProjectRevision(ctx context.Context, projectID string, revision int, user models.User) (*models.ProjectRevision, error) {
+	proj, err := s.GetProjectFromDatabaseByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	rev, err := s.GetProjectRevision(ctx, projectID, revision)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureProjectPathUnderRoot(ctx, proj, true); err != nil {
+		return nil, err
+	}
+	projectsDirectory, err := fs.GetProjectsDirectory(ctx, s.settingsService.GetStringSetting(ctx, "projectsDirectory", "/app/data/projects"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects directory: %w", err)
+	}
+
+	s.ensureBaselineRevisionInternal(ctx, proj)
+
+	for _, relativePath := range slices.Sorted(maps.Keys(rev.IncludeFiles)) {
+		if err := projects.WriteIncludeFile(proj.Path, relativePath, rev.IncludeFiles[relativePath]); err != nil {
+			return nil, fmt.Errorf("failed to restore include file %s: %w", relativePath, err)
+		}
+	}
+	if err := fs.SaveOrUpdateProjectFiles(projectsDirectory, proj.Path, rev.ComposeContent, &rev.EnvContent); err != nil {
+		return nil, fmt.Errorf("failed to restore project files: %w", err)
+	}
+
+	created := s.recordRevisionInternal(ctx, proj, models.ProjectRevisionSourceRollback, fmt.Sprintf("Redeployed revision %d", revision), &user)
+
+	metadata := models.JSON{"action": "rollback", "projectID": projectID, "revision": revision}
+	if logErr := s.eventService.LogProjectEvent(ctx, models.EventTypeProjectRollback, projectID, proj.Name, user.ID, user.Username, "0", metadata); logErr != nil {
+		slog.ErrorContext(ctx, "could not log project rollback action", "error", logErr)
+	}
+
+	if err := s.runnerInternal().RedeployProject(ctx, projectID, user); err != nil {
+		return created, fmt.Errorf("files of revision %d were restored but the project failed to redeploy: %w", revision, err)
+	}
+	return created, nil
+}
+
+// projectRevisionSourceNone writes project files without storing them as a revision, for
+// files that must not be kept in the database, such as decrypted secrets.
+const projectRevisionSourceNone = ""
+
+// ensureBaselineRevisionInternal stores the current files of a project as its first
+// revision when none is stored yet, so the state before the first tracked change can be
+// restored. Failures are logged and never block the change itself.
+func (s *ProjectService) ensureBaselineRevisionInternal(ctx context.Context, proj *models.Project) {
+	var count int64
+	if err := s.db.WithContext(ctx).Model(&models.ProjectRevision{}).Where("project_id = ?", proj.ID).Count(&count).Error; err != nil {
+		slog.WarnContext(ctx, "failed to count project revisions", "projectID", proj.ID, "error", err)
+		return
+	}
+	if count > 0 {
+		return
+	}
+	s.recordRevisionInternal(ctx, proj, models.ProjectRevisionSourceInitial, "", nil)
+}
+
+// recordRevisionInternal stores the current files of a project as a new revision unless
+// they are unchanged since the latest one, and returns the latest revision. Failures are
+// logged: the files are already written, so they must not fail the change. Nothing is
+// stored for projects with SOPS-decrypted files, as any of their files may hold secrets.
+func (s *ProjectService) recordRevisionInternal(ctx context.Context, proj *models.Project, source, message string, user *models.User) *models.ProjectRevision {
+	if proj.SopsDecrypted {
+		return nil
+	}
+	files, err := readProjectFilesInternal(proj.Path)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to read project files for revision", "projectID", proj.ID, "error", err)
+		return nil
+	}
+
+	var latest models.ProjectRevision
+	err = s.db.WithContext(ctx).Where("project_id = ?", proj.ID).Order("revision DESC").First(&latest).Error
+	var previous projectFiles
+	switch {
+	case err == nil:
+		previous = projectFilesFromRevisionInternal(&latest)
+		if previous.equal(files) {
+			return &latest
+		}
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		latest = models.ProjectRevision{}
+	default:
+		slog.WarnContext(ctx, "failed to load latest project revision", "projectID", proj.ID, "error", err)
+		return nil
+	}
+
+	diff, added, removed := diffProjectFilesInternal(previous, files)
+	rev := &models.ProjectRevision{
+		ProjectID:      proj.ID,
+		Revision:       latest.Revision + 1,
+		Source:         source,
+		Message:        message,
+		ComposeContent: files.compose,
+		EnvContent:     files.env,
+		IncludeFiles:   files.includes,
+		Diff:           diff,
+		LinesAdded:     added,
+		LinesRemoved:   removed,
+	}
+	if user != nil {
+		rev.AuthorID = new(user.ID)
+		rev.AuthorName = user.Username
+	}
+	if err := s.db.WithContext(ctx).Create(rev).Error; err != nil {
+		slog.WarnContext(ctx, "failed to store project revision", "projectID", proj.ID, "revision", rev.Revision, "error", err)
+		return nil
+	}
+	return rev
+}
+
+// readProjectFilesInternal reads the compose and env files of a project and the include
+// files inside the project directory. Include files elsewhere cannot be written back, so
+// they are not tracked.
+func readProjectFilesInternal(projectPath string) (projectFiles, error) {
+	compose, env, err := fs.ReadProjectFiles(projectPath)
+	if err != nil {
+		return projectFiles{}, err
+	}
+	files := projectFiles{compose: compose, env: env}
+
+	composeFile, err := projects.DetectComposeFile(projectPath)
+	if err != nil {
+		return files, nil
+	}
+	includes, err := projects.ParseIncludes(composeFile)
+	if err != nil {
+		return files, nil
+	}
+	for _, inc := range includes {
+		if _, err := projects.ValidateIncludePathForWrite(projectPath, inc.RelativePath); err != nil {
+			continue
+		}
+		if files.includes == nil {
+			files.includes = map[string]string{}
+		}
+		files.includes[inc.RelativePath] = inc.Content
+	}
+	return files, nil
+}
+
+// diffProjectFilesInternal returns the unified diff from a to b over all tracked files
+// and the number of added and removed lines.
+func diffProjectFilesInternal(a, b projectFiles) (string, int, int) {
+	var out strings.Builder
+	addFile := func(name, from, to string) {
+		if from == to {
+			return
+		}
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(from),
+			B:        difflib.SplitLines(to),
+			FromFile: "a/" + name,
+			ToFile:   "b/" + name,
+			Context:  3,
+		})
+		if err == nil {
+			out.WriteString(diff)
+		}
+	}
+
+	addFile("compose.yaml", a.compose, b.compose)
+	addFile(".env", a.env, b.env)
+	names := slices.Collect(maps.Keys(a.includes))
+	for name := range b.includes {
+		if _, ok := a.includes[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		addFile(name, a.includes[name], b.includes[name])
+	}
+
+	diff := out.String()
+	added, removed := 0, 0
+	for line := range strings.Lines(diff) {
+		switch {
+		case strings.HasPrefix(line, "+++ b/"), strings.HasPrefix(line, "--- a/"):
+		case strings.HasPrefix(line, "+"):
+			added++
+		case strings.HasPrefix(line, "-"):
+			removed++
+		}
+	}
+	return diff, added, removed
+}
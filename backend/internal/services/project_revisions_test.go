@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/getarcaneapp/arcane/backend/internal/models"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/pagination"
+)
+
+func TestProjectService_UpdateProjectStoresRevisions(t *testing.T) {
+	ctx := context.Background()
+	db := setupProjectTestDB(t)
+	require.NoError(t, db.AutoMigrate(&models.ProjectRevision{}))
+	settingsService, err := NewSettingsService(ctx, db)
+	require.NoError(t, err)
+	root := t.TempDir()
+	require.NoError(t, settingsService.UpdateSetting(ctx, "projectsDirectory", root))
+	require.NoError(t, settingsService.LoadDatabaseSettings(ctx))
+	svc := NewProjectService(db, settingsService, nil, nil, nil, nil)
+
+	dir := filepath.Join(root, "shop")
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "compose.yaml"), []byte("services:\n  app:\n    image: nginx:1.25\n"), 0o600))
+	require.NoError(t, db.Create(&models.Project{BaseModel: models.BaseModel{ID: "p1"}, Name: "shop", Path: dir}).Error)
+
+	alice := models.User{BaseModel: models.BaseModel{ID: "u1"}, Username: "alice"}
+	compose := "services:\n  app:\n    image: nginx:1.27\n"
+	_, err = svc.UpdateProject(ctx, "p1", nil, &compose, nil, alice)
+	require.NoError(t, err)
+	// Unchanged content and renames store no revision.
+	_, err = svc.UpdateProject(ctx, "p1", new("shop2"), &compose, nil, alice)
+	require.NoError(t, err)
+
+	revisions, _, err := svc.ListProjectRevisions(ctx, "p1", pagination.QueryParams{})
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	assert.Equal(t, 2, revisions[0].Revision)
+	assert.Equal(t, models.ProjectRevisionSourceUpdate, revisions[0].Source)
+	assert.Equal(t, "alice", revisions[0].AuthorName)
+	assert.Equal(t, 1, revisions[0].LinesAdded)
+	assert.Equal(t, 1, revisions[0].LinesRemoved)
+	assert.Empty(t, revisions[0].ComposeContent, "list omits content")
+	assert.Equal(t, models.ProjectRevisionSourceInitial, revisions[1].Source)
+
+	diff, err := svc.DiffProjectRevisions(ctx, "p1", 1, 2)
+	require.NoError(t, err)
+	assert.Contains(t, diff.Diff, "--- a/compose.yaml")
+	assert.Contains(t, diff.Diff, "-    image: nginx:1.25")
+	assert.Contains(t, diff.Diff, "+    image: nginx:1.27")
+
+	_, err = svc.DiffProjectRevisions(ctx, "p1", 1, 9)
+	require.ErrorIs(t, err, ErrProjectRevisionNotFound)
+}
+
+func TestProjectService_RedeployProjectRevision(t *testing.T) {
+	ctx := context.Background()
+	db := setupProjectTestDB(t)
+	require.NoError(t, db.AutoMigrate(&models.ProjectRevision{}, &models.Event{}))
+	settingsService, err := NewSettingsService(ctx, db)
+	require.NoError(t, err)
+	root := t.TempDir()
+	require.NoError(t, settingsService.UpdateSetting(ctx, "projectsDirectory", root))
+	require.NoError(t, settingsService.LoadDatabaseSettings(ctx))
+	runner := &fakeProjectRunner{}
+	svc := NewProjectService(db, settingsService, NewEventService(db), nil, nil, nil)
+	svc.runner = runner
+
+	// A project created before revisions were tracked has no history.
+	dir := filepath.Join(root, "shop")
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "compose.yaml"), []byte("services:\n  app:\n    image: nginx:1.25\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".env"), []byte("TAG=old\n"), 0o600))
+	require.NoError(t, db.Create(&models.Project{BaseModel: models.BaseModel{ID: "p1"}, Name: "shop", Path: dir}).Error)
+
+	alice := models.User{BaseModel: models.BaseModel{ID: "u1"}, Username: "alice"}
+	compose := "services:\n  app:\n    image: nginx:1.27\n"
+	env := "TAG=new\n"
+	_, err = svc.UpdateProject(ctx, "p1", nil, &compose, &env, alice)
+	require.NoError(t, err)
+
+	baseline, err := svc.GetProjectRevision(ctx, "p1", 1)
+	require.NoError(t, err)
+	assert.Equal(t, models.ProjectRevisionSourceInitial, baseline.Source)
+	assert.Contains(t, baseline.ComposeContent, "nginx:1.25")
+	assert.Equal(t, "TAG=old\n", baseline.EnvContent)
+	assert.Nil(t, baseline.AuthorID)
+
+	created, err := svc.RedeployProjectRevision(ctx, "p1", 1, alice)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"redeploy"}, runner.calls)
+
+	written, err := os.ReadFile(filepath.Join(dir, "compose.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(written), "nginx:1.25")
+	written, err = os.ReadFile(filepath.Join(dir, ".env"))
+	require.NoError(t, err)
+	assert.Equal(t, "TAG=old\n", string(written))
+
+	require.NotNil(t, created)
+	assert.Equal(t, 3, created.Revision)
+	assert.Equal(t, models.ProjectRevisionSourceRollback, created.Source)
+	assert.Equal(t, "Redeployed revision 1", created.Message)
+	assert.Equal(t, "alice", created.AuthorName)
+	assert.Equal(t, baseline.ComposeContent, created.ComposeContent)
+	assert.Contains(t, created.Diff, "+    image: nginx:1.25")
+
+	runner.deployErr = assert.AnError
+	_, err = svc.RedeployProjectRevision(ctx, "p1", 2, alice)
+	require.ErrorIs(t, err, assert.AnError)
+	written, err = os.ReadFile(filepath.Join(dir, "compose.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(written), "nginx:1.27", "files are restored even when the redeploy fails")
+}
+
+func TestDiffProjectFilesInternal(t *testing.T) {
+	a := projectFiles{compose: "x\n", includes: map[string]string{"db.yaml": "old\n"}}
+	b := projectFiles{compose: "x\n", env: "A=1\n", includes: map[string]string{"db.yaml": "new\n", "cache.yaml": "c\n"}}
+
+	diff, added, removed := diffProjectFilesInternal(a, b)
+	assert.NotContains(t, diff, "compose.yaml")
+	assert.Contains(t, diff, "+++ b/.env")
+	assert.Contains(t, diff, "+++ b/cache.yaml")
+	assert.Less(t, strings.Index(diff, "cache.yaml"), strings.Index(diff, "db.yaml"))
+	assert.Equal(t, 3, added)
+	assert.Equal(t, 1, removed)
+
+	diff, added, removed = diffProjectFilesInternal(a, a)
+	assert.Empty(t, diff)
+	assert.Zero(t, added+removed)
+}
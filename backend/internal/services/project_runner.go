@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+
+	"github.com/getarcaneapp/arcane/backend/internal/models"
+	"github.com/getarcaneapp/arcane/types/project"
+)
+
+// projectRunner performs the Docker side of project backups, restores and revision
+// rollbacks.
+type projectRunner interface {
+	ProjectVolumes(ctx context.Context, proj *models.Project) ([]project.BackupVolume, error)
+	DownProject(ctx context.Context, projectID string, user models.User) error
+	DeployProject(ctx context.Context, projectID string, user models.User) error
+	RedeployProject(ctx context.Context, projectID string, user models.User) error
+	EnsureVolume(ctx context.Context, composeName string, v project.BackupVolume, user models.User) error
+	BackupVolume(ctx context.Context, volumeName string, opts BackupOptions, user models.User) (*models.VolumeBackup, error)
+	RestoreVolume(ctx context.Context, volumeName, backupID, passphrase string, user models.User) error
+	DeleteVolumeBackup(ctx context.Context, backupID string, user *models.User) error
+}
+
+// dockerProjectRunner runs projects against Docker and the volume service.
+type dockerProjectRunner struct {
+	s *ProjectService
+}
+
+func (r dockerProjectRunner) ProjectVolumes(ctx context.Context, proj *models.Project) ([]project.BackupVolume, error) {
+	composeProject, err := r.s.loadComposeProjectInternal(ctx, proj)
+	if err != nil {
+		return nil, err
+	}
+	return r.s.existingProjectVolumesInternal(ctx, composeProject)
+}
+
+func (r dockerProjectRunner) DownProject(ctx context.Context, projectID string, user models.User) error {
+	return r.s.DownProject(ctx, projectID, user)
+}
+
+func (r dockerProjectRunner) DeployProject(ctx context.Context, projectID string, user models.User) error {
+	return r.s.DeployProject(ctx, projectID, user)
+}
+
+func (r dockerProjectRunner) RedeployProject(ctx context.Context, projectID string, user models.User) error {
+	return r.s.RedeployProject(ctx, projectID, user)
+}
+
+func (r dockerProjectRunner) EnsureVolume(ctx context.Context, composeName string, v project.BackupVolume, user models.User) error {
+	return r.s.ensureProjectVolumeInternal(ctx, composeName, v, user)
+}
+
+func (r dockerProjectRunner) BackupVolume(ctx context.Context, volumeName string, opts BackupOptions, user models.User) (*models.VolumeBackup, error) {
+	return r.s.volumeService.CreateBackupWithOptions(ctx, volumeName, opts, user)
+}
+
+func (r dockerProjectRunner) RestoreVolume(ctx context.Context, volumeName, backupID, passphrase string, user models.User) error {
+	return r.s.volumeService.RestoreBackup(ctx, volumeName, backupID, passphrase, user)
+}
+
+func (r dockerProjectRunner) DeleteVolumeBackup(ctx context.Context, backupID string, user *models.User) error {
+	return r.s.volumeService.DeleteBackup(ctx, backupID, user)
+}
+
+func (s *ProjectService) runnerInternal() projectRunner {
+	if s.runner != nil {
+		return s.runner
+	}
+	return dockerProjectRunner{s: s}
+}
@@ -38,15 +38,19 @@ type ProjectService struct {
 	eventService    *EventService
 	imageService    *ImageService
 	dockerService   *DockerClientService
+	volumeService   *VolumeService
+	// runner replaces the Docker side of project backups and rollbacks in tests.
+	runner projectRunner
 }
 
-func NewProjectService(db *database.DB, settingsService *SettingsService, eventService *EventService, imageService *ImageService, dockerService *DockerClientService) *ProjectService {
+func NewProjectService(db *database.DB, settingsService *SettingsService, eventService *EventService, imageService *ImageService, dockerService *DockerClientService, volumeService *VolumeService) *ProjectService {
 	return &ProjectService{
 		db:              db,
 		settingsService: settingsService,
 		eventService:    eventService,
 		imageService:    imageService,
 		dockerService:   dockerService,
+		volumeService:   volumeService,
 	}
 }
 
@@ -185,6 +189,29 @@ func (s *ProjectService) updateProjectStatusInternal(ctx context.Context, id str
 	return nil
 }
 
+// setProjectSopsDecryptedInternal records whether the files of a project were written
+// from SOPS-decrypted content. Flagging a project drops its stored revisions, which are
+// not kept for such projects.
+func (s *ProjectService) setProjectSopsDecryptedInternal(ctx context.Context, proj *models.Project, decrypted bool) error {
+	if proj.SopsDecrypted == decrypted {
+		return nil
+	}
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Project{}).Where("id = ?", proj.ID).Update("sops_decrypted", decrypted).Error; err != nil {
+			return err
+		}
+		if decrypted {
+			return tx.Where("project_id = ?", proj.ID).Delete(&models.ProjectRevision{}).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update project: %w", err)
+	}
+	proj.SopsDecrypted = decrypted
+	return nil
+}
+
 func (s *ProjectService) GetProjectServices(ctx context.Context, projectID string) ([]ProjectServiceInfo, error) {
 	projectFromDb, err := s.GetProjectFromDatabaseByID(ctx, projectID)
 	if err != nil {
@@ -735,6 +762,14 @@ func (s *ProjectService) DeployProject(ctx context.Context, projectID string, us
 		slog.Warn("ensure images present failed (continuing to compose up)", "projectID", projectID, "error", perr)
 	}
 
+	if toBuild := s.servicesToBuildInternal(ctx, project); len(toBuild) > 0 {
+		buildWriter, _ := ctx.Value(projects.ProgressWriterKey{}).(io.Writer)
+		if _, berr := s.buildServiceImagesInternal(ctx, project, toBuild, projects.BuildOptions{}, buildWriter); berr != nil {
+			_ = s.updateProjectStatusandCountsInternal(ctx, projectID, models.ProjectStatusStopped)
+			return fmt.Errorf("failed to build project images: %w", berr)
+		}
+	}
+
 	removeOrphans := projectFromDb.GitOpsManagedBy != nil && *projectFromDb.GitOpsManagedBy != ""
 
 	slog.Info("starting compose up with health check support", "projectID", projectID, "projectName", project.Name, "services", len(project.Services), "removeOrphans", removeOrphans)
@@ -815,6 +850,12 @@ func (s *ProjectService) DownProject(ctx context.Context, projectID string, user
 }
 
 func (s *ProjectService) CreateProject(ctx context.Context, name, composeContent string, envContent *string, user models.User) (*models.Project, error) {
+	return s.createProjectInternal(ctx, name, composeContent, envContent, models.ProjectRevisionSourceCreate, user)
+}
+
+// createProjectInternal creates a project and stores its files as its first revision,
+// unless source is projectRevisionSourceNone.
+func (s *ProjectService) createProjectInternal(ctx context.Context, name, composeContent string, envContent *string, source string, user models.User) (*models.Project, error) {
 	sanitized := fs.SanitizeProjectName(name)
 
 	projectsDirectory, err := fs.GetProjectsDirectory(ctx, s.settingsService.GetStringSetting(ctx, "projectsDirectory", "/app/data/projects"))
@@ -847,6 +888,10 @@ func (s *ProjectService) CreateProject(ctx context.Context, name, composeContent
 		return nil, fmt.Errorf("failed to save project files: %w", err)
 	}
 
+	if source != projectRevisionSourceNone {
+		s.recordRevisionInternal(ctx, proj, source, "", &user)
+	}
+
 	metadata := models.JSON{"action": "create", "projectID": proj.ID, "projectName": name, "path": projectPath}
 	if logErr := s.eventService.LogProjectEvent(ctx, models.EventTypeProjectCreate, proj.ID, name, user.ID, user.Username, "0", metadata); logErr != nil {
 		slog.ErrorContext(ctx, "could not log project creation", "error", logErr)
@@ -969,7 +1014,8 @@ func (s *ProjectService) PullProjectImages(ctx context.Context, projectID string
 	images := map[string]struct{}{}
 	for _, svc := range compProj.Services {
 		img := strings.TrimSpace(svc.Image)
-		if img == "" {
+		// Services with a build section are built locally instead of pulled.
+		if img == "" || svc.Build != nil {
 			continue
 		}
 		images[img] = struct{}{}
@@ -1026,7 +1072,8 @@ func (s *ProjectService) EnsureProjectImagesPresent(ctx context.Context, project
 	images := map[string]struct{}{}
 	for _, svc := range compProj.Services {
 		img := strings.TrimSpace(svc.Image)
-		if img == "" {
+		// Services with a build section are built locally instead of pulled.
+		if img == "" || svc.Build != nil {
 			continue
 		}
 		images[img] = struct{}{}
@@ -1109,7 +1156,15 @@ func (s *ProjectService) RestartProject(ctx context.Context, projectID string, u
 	return s.updateProjectStatusandCountsInternal(ctx, projectID, models.ProjectStatusRunning)
 }
 
-func (s *ProjectService) UpdateProject(ctx context.Context, projectID string, name *string, composeContent, envContent *string) (*models.Project, error) {
+// UpdateProject renames a project and/or overwrites its compose and env files. Changed
+// files are stored as a new project revision authored by user.
+func (s *ProjectService) UpdateProject(ctx context.Context, projectID string, name *string, composeContent, envContent *string, user models.User) (*models.Project, error) {
+	return s.updateProjectInternal(ctx, projectID, name, composeContent, envContent, models.ProjectRevisionSourceUpdate, user)
+}
+
+// updateProjectInternal is UpdateProject with the source of the revision recorded for
+// changed files. projectRevisionSourceNone writes the files without recording one.
+func (s *ProjectService) updateProjectInternal(ctx context.Context, projectID string, name *string, composeContent, envContent *string, source string, user models.User) (*models.Project, error) {
 	var proj models.Project
 	if err := s.db.WithContext(ctx).First(&proj, "id = ?", projectID).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -1134,6 +1189,11 @@ func (s *ProjectService) UpdateProject(ctx context.Context, projectID string, na
 		}
 	}
 
+	recordRevision := (composeContent != nil || envContent != nil) && source != projectRevisionSourceNone
+	if recordRevision {
+		s.ensureBaselineRevisionInternal(ctx, &proj)
+	}
+
 	switch {
 	case composeContent != nil:
 		if err := fs.SaveOrUpdateProjectFiles(projectsDirectory, proj.Path, *composeContent, envContent); err != nil {
@@ -1149,11 +1209,15 @@ func (s *ProjectService) UpdateProject(ctx context.Context, projectID string, na
 		return nil, fmt.Errorf("failed to update project: %w", err)
 	}
 
+	if recordRevision {
+		s.recordRevisionInternal(ctx, &proj, source, "", &user)
+	}
+
 	slog.InfoContext(ctx, "project updated", "projectID", proj.ID, "name", proj.Name)
 	return &proj, nil
 }
 
-func (s *ProjectService) UpdateProjectIncludeFile(ctx context.Context, projectID, relativePath, content string) error {
+func (s *ProjectService) UpdateProjectIncludeFile(ctx context.Context, projectID, relativePath, content string, user models.User) error {
 	proj, err := s.GetProjectFromDatabaseByID(ctx, projectID)
 	if err != nil {
 		return err
@@ -1164,10 +1228,14 @@ func (s *ProjectService) UpdateProjectIncludeFile(ctx context.Context, projectID
 		return err
 	}
 
+	s.ensureBaselineRevisionInternal(ctx, proj)
+
 	if err := projects.WriteIncludeFile(proj.Path, relativePath, content); err != nil {
 		return fmt.Errorf("failed to update include file: %w", err)
 	}
 
+	s.recordRevisionInternal(ctx, proj, models.ProjectRevisionSourceInclude, relativePath, &user)
+
 	slog.InfoContext(ctx, "project include file updated", "projectID", proj.ID, "file", relativePath)
 	return nil
 }
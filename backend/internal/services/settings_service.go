@@ -90,6 +90,7 @@ func (s *SettingsService) getDefaultSettings() *models.Settings {
 		AutoUpdate:                    models.SettingVariable{Value: "false"},
 		AutoUpdateInterval:            models.SettingVariable{Value: "0 0 0 * * *"},
 		AutoUpdateExcludedContainers:  models.SettingVariable{Value: ""},
+		AutoUpdateHealthTimeout:       models.SettingVariable{Value: "60"},
 		PollingEnabled:                models.SettingVariable{Value: "true"},
 		PollingInterval:               models.SettingVariable{Value: "0 0 * * * *"},
 		EventCleanupInterval:          models.SettingVariable{Value: "0 0 */6 * * *"},
@@ -103,6 +104,10 @@ func (s *SettingsService) getDefaultSettings() *models.Settings {
 		ScheduledPruneVolumes:         models.SettingVariable{Value: "false"},
 		ScheduledPruneNetworks:        models.SettingVariable{Value: "true"},
 		ScheduledPruneBuildCache:      models.SettingVariable{Value: "false"},
+		ScheduledBackupEnabled:        models.SettingVariable{Value: "false"},
+		ScheduledBackupInterval:       models.SettingVariable{Value: "0 0 2 * * *"},
+		GitopsDriftEnabled:            models.SettingVariable{Value: "false"},
+		GitopsDriftInterval:           models.SettingVariable{Value: "0 */15 * * * *"},
 		GitopsSyncInterval:            models.SettingVariable{Value: "0 */1 * * * *"},
 		BaseServerURL:                 models.SettingVariable{Value: "http://localhost"},
 		EnableGravatar:                models.SettingVariable{Value: "true"},
@@ -131,6 +136,8 @@ func (s *SettingsService) getDefaultSettings() *models.Settings {
 		OidcScopes:                 models.SettingVariable{Value: "openid email profile"},
 		OidcAdminClaim:             models.SettingVariable{Value: ""},
 		OidcAdminValue:             models.SettingVariable{Value: ""},
+		OidcRoleClaim:              models.SettingVariable{Value: ""},
+		OidcRoleMapping:            models.SettingVariable{Value: ""},
 		OidcSkipTlsVerify:          models.SettingVariable{Value: "false"},
 		OidcAutoRedirectToProvider: models.SettingVariable{Value: "false"},
 		OidcMergeAccounts:          models.SettingVariable{Value: "false"},
@@ -543,7 +550,7 @@ func (s *SettingsService) prepareUpdateValues(updates settings.Update, cfg, defa
 		}
 
 		// Validate cron settings
-		cronFields := []string{"scheduledPruneInterval", "autoUpdateInterval", "pollingInterval", "environmentHealthInterval", "eventCleanupInterval", "analyticsHeartbeatInterval", "vulnerabilityScanInterval", "gitopsSyncInterval"}
+		cronFields := []string{"scheduledPruneInterval", "autoUpdateInterval", "pollingInterval", "environmentHealthInterval", "eventCleanupInterval", "analyticsHeartbeatInterval", "vulnerabilityScanInterval", "gitopsSyncInterval", "scheduledBackupInterval", "gitopsDriftInterval"}
 		if slices.Contains(cronFields, key) && value != "" {
 			if _, err := cron.NewParser(cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow).Parse(value); err != nil {
 				return nil, false, false, false, false, nil, fmt.Errorf("invalid cron expression for %s: %w", key, err)
@@ -2,9 +2,11 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"maps"
 	"slices"
 	"strings"
 	"time"
@@ -272,6 +274,7 @@ func (s *UpdaterService) ApplyPending(ctx context.Context, dryRun bool) (*update
 				OldImages:     r.OldImages,
 				NewImages:     r.NewImages,
 				UpdateApplied: r.UpdateApplied,
+				Details:       r.Details,
 			}
 			out.Items = append(out.Items, item)
 			out.Checked++
@@ -293,6 +296,7 @@ func (s *UpdaterService) ApplyPending(ctx context.Context, dryRun bool) (*update
 				"oldImageMain": r.OldImages["main"],
 				"newImageMain": r.NewImages["main"],
 				"error":        r.Error,
+				"rolledBack":   r.Details["rolledBack"],
 			})
 		}
 	}
@@ -525,22 +529,21 @@ func (s *UpdaterService) UpdateSingleContainer(ctx context.Context, containerID
 	}
 
 	// Update the container
+	res := updater.ResourceResult{
+		ResourceID:   targetContainer.ID,
+		ResourceType: "container",
+		ResourceName: containerName,
+		OldImages:    map[string]string{"main": normalizedRef, "imageId": inspect.Image},
+		NewImages:    map[string]string{"main": normalizedRef},
+	}
 	if err := s.updateContainer(ctx, *targetContainer, inspect, normalizedRef); err != nil {
-		out.Items = append(out.Items, updater.ResourceResult{
-			ResourceID:   targetContainer.ID,
-			ResourceType: "container",
-			ResourceName: containerName,
-			Status:       "failed",
-			Error:        err.Error(),
-		})
+		s.applyUpdateError(&res, err)
+		out.Items = append(out.Items, res)
 		out.Failed++
 	} else {
-		out.Items = append(out.Items, updater.ResourceResult{
-			ResourceID:   targetContainer.ID,
-			ResourceType: "container",
-			ResourceName: containerName,
-			Status:       "updated",
-		})
+		res.Status = "updated"
+		res.UpdateApplied = true
+		out.Items = append(out.Items, res)
 		out.Updated++
 
 		// Clear the update record for this image
@@ -549,6 +552,8 @@ func (s *UpdaterService) UpdateSingleContainer(ctx context.Context, containerID
 		}
 	}
 
+	_ = s.recordRun(ctx, res)
+
 	out.Checked = 1
 	out.Duration = time.Since(start).String()
 
@@ -656,6 +661,7 @@ func (s *UpdaterService) updateContainer(ctx context.Context, cnt container.Summ
 	slog.DebugContext(ctx, "updateContainer: starting update", "containerId", cnt.ID, "containerName", name, "newRef", newRef, "isArcane", isArcane)
 
 	originalName := inspect.Name
+	previousImageID := inspect.Image
 
 	// Get custom stop signal if configured
 	stopSignal := arcaneupdater.GetStopSignal(labels)
@@ -707,18 +713,66 @@ func (s *UpdaterService) updateContainer(ctx context.Context, cnt container.Summ
 	// Use original name for new container
 	containerName := strings.TrimPrefix(originalName, "/")
 
+	// rollback puts the container back on the previous image after the update failed for
+	// reason. failedID is the container created from the new image, if any.
+	rollback := func(failedID, reason string) error {
+		slog.WarnContext(ctx, "updateContainer: update failed, rolling back", "newContainerId", failedID, "containerName", containerName, "previousImageId", previousImageID, "reason", reason)
+
+		rbErr := &containerRollbackError{reason: reason, previousImageID: previousImageID}
+		if previousImageID == "" {
+			rbErr.rollbackErr = fmt.Errorf("previous image id unknown")
+			return rbErr
+		}
+		rolledBackID, err := s.rollbackContainer(ctx, dcli, failedID, containerName, cfg, inspect.HostConfig, networkingConfig, previousImageID)
+		rbErr.rollbackContainerID = rolledBackID
+		rbErr.rollbackErr = err
+
+		eventContainerID := rolledBackID
+		if eventContainerID == "" {
+			eventContainerID = failedID
+		}
+		if eventContainerID == "" {
+			eventContainerID = cnt.ID
+		}
+		_ = s.eventService.LogContainerEvent(ctx, models.EventTypeContainerRollback, eventContainerID, name, systemUser.ID, systemUser.Username, "0", models.JSON{
+			"failedContainerId": failedID,
+			"failedImage":       newRef,
+			"previousImageId":   previousImageID,
+			"reason":            reason,
+			"rollbackSucceeded": err == nil,
+		})
+		return rbErr
+	}
+
 	resp, err := dcli.ContainerCreate(ctx, cfg, inspect.HostConfig, networkingConfig, nil, containerName)
 	if err != nil {
 		slog.DebugContext(ctx, "updateContainer: create failed", "containerName", containerName, "err", err)
-		return fmt.Errorf("create: %w", err)
+		// The old container is already removed, so put it back rather than leave the service down.
+		return rollback("", fmt.Sprintf("create: %v", err))
 	}
 	_ = s.eventService.LogContainerEvent(ctx, models.EventTypeContainerCreate, resp.ID, name, systemUser.ID, systemUser.Username, "0", models.JSON{"action": "updater_create", "newImageId": resp.ID})
 
-	if err := dcli.ContainerStart(ctx, resp.ID, container.StartOptions{}); err != nil {
-		slog.DebugContext(ctx, "updateContainer: start failed", "newContainerId", resp.ID, "err", err)
-		return fmt.Errorf("start: %w", err)
+	startErr := dcli.ContainerStart(ctx, resp.ID, container.StartOptions{})
+	if startErr != nil {
+		slog.DebugContext(ctx, "updateContainer: start failed", "newContainerId", resp.ID, "err", startErr)
+	} else {
+		_ = s.eventService.LogContainerEvent(ctx, models.EventTypeContainerStart, resp.ID, name, systemUser.ID, systemUser.Username, "0", models.JSON{"action": "updater_start"})
+	}
+
+	// Health gate: wait for the new container to prove itself before treating the update as done.
+	healthErr := startErr
+	if healthErr == nil {
+		timeout := s.healthTimeoutFor(ctx, labels)
+		slog.DebugContext(ctx, "updateContainer: waiting for container health", "newContainerId", resp.ID, "timeout", timeout)
+		healthErr = arcaneupdater.WaitForHealthy(ctx, dcli, resp.ID, timeout, arcaneupdater.DefaultHealthPollInterval)
+	}
+	if healthErr != nil {
+		reason := healthErr.Error()
+		if startErr != nil {
+			reason = fmt.Sprintf("start: %v", startErr)
+		}
+		return rollback(resp.ID, reason)
 	}
-	_ = s.eventService.LogContainerEvent(ctx, models.EventTypeContainerStart, resp.ID, name, systemUser.ID, systemUser.Username, "0", models.JSON{"action": "updater_start"})
 
 	_ = s.eventService.LogContainerEvent(ctx, models.EventTypeContainerUpdate, resp.ID, name, systemUser.ID, systemUser.Username, "0", models.JSON{
 		"oldContainerId": cnt.ID,
@@ -730,6 +784,95 @@ func (s *UpdaterService) updateContainer(ctx context.Context, cnt container.Summ
 	return nil
 }
 
+// containerRollbackError is returned by updateContainer when the container could not be
+// recreated from the new image or failed its health gate, and was put back on the previous image.
+type containerRollbackError struct {
+	reason              string
+	previousImageID     string
+	rollbackContainerID string
+	rollbackErr         error
+}
+
+func (e *containerRollbackError) Error() string {
+	if e.rollbackErr != nil {
+		return fmt.Sprintf("update failed (%s) and rollback to %s failed: %v", e.reason, e.previousImageID, e.rollbackErr)
+	}
+	return fmt.Sprintf("rolled back to previous image %s: %s", e.previousImageID, e.reason)
+}
+
+func (e *containerRollbackError) Unwrap() error {
+	return e.rollbackErr
+}
+
+// details returns the rollback information stored on the AutoUpdateRecord.
+func (e *containerRollbackError) details() map[string]any {
+	d := map[string]any{
+		"rolledBack":      e.rollbackErr == nil,
+		"rollbackReason":  e.reason,
+		"previousImageId": e.previousImageID,
+	}
+	if e.rollbackContainerID != "" {
+		d["rollbackContainerId"] = e.rollbackContainerID
+	}
+	if e.rollbackErr != nil {
+		d["rollbackError"] = e.rollbackErr.Error()
+	}
+	return d
+}
+
+// applyUpdateError marks res as failed and attaches rollback details when the update was rolled back.
+func (s *UpdaterService) applyUpdateError(res *updater.ResourceResult, err error) {
+	res.Status = "failed"
+	res.Error = err.Error()
+
+	var rbErr *containerRollbackError
+	if errors.As(err, &rbErr) {
+		if res.Details == nil {
+			res.Details = map[string]any{}
+		}
+		maps.Copy(res.Details, rbErr.details())
+	}
+}
+
+// healthTimeoutFor returns how long to wait for an updated container to become healthy.
+// The per-container label wins over the global autoUpdateHealthTimeout setting.
+func (s *UpdaterService) healthTimeoutFor(ctx context.Context, labels map[string]string) time.Duration {
+	if d, ok := arcaneupdater.GetHealthTimeout(labels); ok {
+		return d
+	}
+	if s.settingsService == nil {
+		return 0
+	}
+	secs := s.settingsService.GetIntSetting(ctx, "autoUpdateHealthTimeout", 60)
+	if secs <= 0 {
+		return 0
+	}
+	return time.Duration(secs) * time.Second
+}
+
+// rollbackContainer removes the failed container, if one was created, and recreates it from
+// previousImageID using the same configuration. It returns the ID of the recreated container.
+func (s *UpdaterService) rollbackContainer(ctx context.Context, dcli *client.Client, failedID, containerName string, cfg *container.Config, hostCfg *container.HostConfig, networkingConfig *network.NetworkingConfig, previousImageID string) (string, error) {
+	if failedID != "" {
+		if err := dcli.ContainerRemove(ctx, failedID, container.RemoveOptions{Force: true}); err != nil {
+			return "", fmt.Errorf("remove failed container: %w", err)
+		}
+	}
+
+	cfg.Image = previousImageID
+	resp, err := dcli.ContainerCreate(ctx, cfg, hostCfg, networkingConfig, nil, containerName)
+	if err != nil {
+		return "", fmt.Errorf("recreate from previous image: %w", err)
+	}
+
+	if err := dcli.ContainerStart(ctx, resp.ID, container.StartOptions{}); err != nil {
+		return resp.ID, fmt.Errorf("start rolled back container: %w", err)
+	}
+
+	slog.InfoContext(ctx, "rollbackContainer: container restored to previous image", "containerName", containerName, "containerId", resp.ID, "imageId", previousImageID)
+	return resp.ID, nil
+}
+
 // normalizeRef returns a canonical "registry/repository:tag" without digest.
 // Examples:
 // - "redis:latest" -> "docker.io/library/redis:latest"
@@ -928,6 +1071,10 @@ func (s *UpdaterService) recordRun(ctx context.Context, item updater.ResourceRes
 		rec.NewImageVersions = newv
 	}
 
+	if len(item.Details) > 0 {
+		rec.Details = models.JSON(item.Details)
+	}
+
 	end := time.Now()
 	rec.EndTime = &end
 
@@ -1134,7 +1281,7 @@ func (s *UpdaterService) restartContainersUsingOldIDs(ctx context.Context, oldID
 			ResourceName: name,
 			ResourceType: "container",
 			Status:       "checked",
-			OldImages:    map[string]string{"main": p.match},
+			OldImages:    map[string]string{"main": p.match, "imageId": p.inspect.Image},
 			NewImages:    map[string]string{"main": s.normalizeRef(p.newRef)},
 		}
 
@@ -1162,8 +1309,7 @@ func (s *UpdaterService) restartContainersUsingOldIDs(ctx context.Context, oldID
 				slog.InfoContext(ctx, "restartContainersUsingOldIDs: CLI upgrade triggered successfully", "containerId", p.cnt.ID)
 			}
 		} else if err := s.updateContainer(ctx, p.cnt, p.inspect, p.newRef); err != nil {
-			res.Status = "failed"
-			res.Error = err.Error()
+			s.applyUpdateError(&res, err)
 			slog.DebugContext(ctx, "restartContainersUsingOldIDs: update failed", "containerId", p.cnt.ID, "err", err)
 		} else {
 			res.Status = "updated"
@@ -2,14 +2,26 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+	glsqlite "github.com/glebarez/sqlite"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
 
+	"github.com/getarcaneapp/arcane/backend/internal/database"
 	"github.com/getarcaneapp/arcane/backend/internal/models"
 	"github.com/getarcaneapp/arcane/backend/internal/utils/arcaneupdater"
+	"github.com/getarcaneapp/arcane/types/settings"
+	"github.com/getarcaneapp/arcane/types/updater"
 )
 
 // mockSystemUpgradeService is a simple mock implementation for testing
@@ -229,3 +241,134 @@ func TestUpdaterService_UpgradeServiceNotNilCheck(t *testing.T) {
 
 	assert.True(t, mockUpgrade.triggerCalled, "Should call CLI upgrade when service is not nil")
 }
+
+func TestUpdaterService_HealthTimeoutFor(t *testing.T) {
+	ctx := context.Background()
+	db := setupSettingsTestDB(t)
+	settingsSvc, err := NewSettingsService(ctx, db)
+	require.NoError(t, err)
+	require.NoError(t, settingsSvc.EnsureDefaultSettings(ctx))
+
+	svc := &UpdaterService{settingsService: settingsSvc}
+
+	// Default setting applies when no label is present
+	assert.Equal(t, 60*time.Second, svc.healthTimeoutFor(ctx, nil))
+
+	// Label overrides the global setting
+	labels := map[string]string{arcaneupdater.LabelHealthTimeout: "5"}
+	assert.Equal(t, 5*time.Second, svc.healthTimeoutFor(ctx, labels))
+
+	// Setting to 0 disables the health gate
+	disabled := "0"
+	_, err = settingsSvc.UpdateSettings(ctx, settings.Update{AutoUpdateHealthTimeout: &disabled})
+	require.NoError(t, err)
+	assert.Equal(t, time.Duration(0), svc.healthTimeoutFor(ctx, nil))
+
+	// Without a settings service the gate is disabled unless labelled
+	assert.Equal(t, time.Duration(0), (&UpdaterService{}).healthTimeoutFor(ctx, nil))
+}
+
+func TestUpdaterService_ApplyUpdateError_RecordsRollback(t *testing.T) {
+	svc := &UpdaterService{}
+
+	res := updater.ResourceResult{ResourceID: "abc", ResourceType: "container"}
+	svc.applyUpdateError(&res, &containerRollbackError{
+		reason:              "container exited with code 1",
+		previousImageID:     "sha256:old",
+		rollbackContainerID: "def",
+	})
+
+	assert.Equal(t, "failed", res.Status)
+	assert.Contains(t, res.Error, "rolled back to previous image sha256:old")
+	assert.Equal(t, true, res.Details["rolledBack"])
+	assert.Equal(t, "container exited with code 1", res.Details["rollbackReason"])
+	assert.Equal(t, "sha256:old", res.Details["previousImageId"])
+	assert.Equal(t, "def", res.Details["rollbackContainerId"])
+	assert.NotContains(t, res.Details, "rollbackError")
+}
+
+func TestUpdaterService_ApplyUpdateError_RollbackFailed(t *testing.T) {
+	svc := &UpdaterService{}
+
+	rollbackErr := errors.New("no such image")
+	res := updater.ResourceResult{ResourceID: "abc", ResourceType: "container"}
+	err := &containerRollbackError{reason: "healthcheck reported unhealthy", previousImageID: "sha256:old", rollbackErr: rollbackErr}
+	svc.applyUpdateError(&res, err)
+
+	assert.Equal(t, "failed", res.Status)
+	assert.Contains(t, res.Error, "rollback to sha256:old failed")
+	assert.Equal(t, false, res.Details["rolledBack"])
+	assert.Equal(t, "no such image", res.Details["rollbackError"])
+	assert.ErrorIs(t, err, rollbackErr)
+}
+
+func TestUpdaterService_ApplyUpdateError_PlainError(t *testing.T) {
+	svc := &UpdaterService{}
+
+	res := updater.ResourceResult{ResourceID: "abc", ResourceType: "container"}
+	svc.applyUpdateError(&res, errors.New("stop: timeout"))
+
+	assert.Equal(t, "failed", res.Status)
+	assert.Equal(t, "stop: timeout", res.Error)
+	assert.Nil(t, res.Details)
+}
+
+func TestUpdaterService_UpdateContainer_RollsBackWhenCreateFails(t *testing.T) {
+	db, err := gorm.Open(glsqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.Event{}))
+
+	// A Docker API that cannot create containers from the new image.
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := r.URL.Path[strings.Index(r.URL.Path, "/containers"):]
+		calls = append(calls, r.Method+" "+path)
+		switch {
+		case r.Method == http.MethodPost && path == "/containers/create":
+			var body container.Config
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			w.Header().Set("Content-Type", "application/json")
+			if body.Image == "nginx:1.27" {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"message":"No such image: nginx:1.27"}`))
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"Id":"restored"}`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+	cli, err := client.NewClientWithOpts(client.WithHost("tcp://"+strings.TrimPrefix(server.URL, "http://")), client.WithVersion("1.47"))
+	require.NoError(t, err)
+
+	svc := &UpdaterService{
+		dockerService: &DockerClientService{client: cli},
+		eventService:  NewEventService(&database.DB{DB: db}),
+	}
+	inspect := container.InspectResponse{
+		ContainerJSONBase: &container.ContainerJSONBase{Name: "/web", Image: "sha256:old", HostConfig: &container.HostConfig{}},
+		Config:            &container.Config{Image: "nginx:1.25"},
+		NetworkSettings:   &container.NetworkSettings{},
+	}
+
+	err = svc.updateContainer(context.Background(), container.Summary{ID: "old", Names: []string{"/web"}}, inspect, "nginx:1.27")
+
+	var rbErr *containerRollbackError
+	require.ErrorAs(t, err, &rbErr)
+	require.NoError(t, rbErr.rollbackErr)
+	assert.Equal(t, "restored", rbErr.rollbackContainerID)
+	assert.Contains(t, rbErr.reason, "No such image")
+	assert.Equal(t, []string{
+		"POST /containers/old/stop",
+		"DELETE /containers/old",
+		"POST /containers/create",
+		"POST /containers/create",
+		"POST /containers/restored/start",
+	}, calls)
+
+	var event models.Event
+	require.NoError(t, db.Where("type = ?", models.EventTypeContainerRollback).First(&event).Error)
+	assert.Equal(t, "restored", *event.ResourceID)
+}
@@ -351,15 +351,16 @@ func (s *UserService) ListUsersPaginated(ctx context.Context, params pagination.
 
 func toUserResponseDto(u models.User) user.User {
 	return user.User{
-		ID:            u.ID,
-		Username:      u.Username,
-		DisplayName:   u.DisplayName,
-		Email:         u.Email,
-		Roles:         u.Roles,
-		OidcSubjectId: u.OidcSubjectId,
-		Locale:        u.Locale,
-		CreatedAt:     u.CreatedAt.Format("2006-01-02T15:04:05.999999Z"),
-		UpdatedAt:     u.UpdatedAt.Format("2006-01-02T15:04:05.999999Z"),
+		ID:                u.ID,
+		Username:          u.Username,
+		DisplayName:       u.DisplayName,
+		Email:             u.Email,
+		Roles:             u.Roles,
+		OidcSubjectId:     u.OidcSubjectId,
+		Locale:            u.Locale,
+		EnvironmentAccess: u.EnvironmentAccess.ToMap(),
+		CreatedAt:         u.CreatedAt.Format("2006-01-02T15:04:05.999999Z"),
+		UpdatedAt:         u.UpdatedAt.Format("2006-01-02T15:04:05.999999Z"),
 	}
 }
 
@@ -0,0 +1,333 @@
+package services
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/getarcaneapp/arcane/backend/internal/models"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/backuptarget"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/crypto"
+)
+
+// ErrBackupChecksumMismatch is returned when a backup archive no longer matches the
+// checksum recorded when it was created.
+var ErrBackupChecksumMismatch = errors.New("backup archive checksum mismatch: the archive is corrupted or was tampered with")
+
+// ErrBackupUnverified is returned when restoring a backup stored on a backup target that
+// has no recorded checksum, so the downloaded archive cannot be verified.
+var ErrBackupUnverified = errors.New("backup archive has no recorded checksum and cannot be verified")
+
+// BackupOptions controls where a new backup archive is stored and how it is protected.
+type BackupOptions struct {
+	// TargetID selects a backup target; empty stores the archive in the local backup volume.
+	TargetID string
+	// Encrypt encrypts the archive with Passphrase, or with the instance key when Passphrase is empty.
+	Encrypt    bool
+	Passphrase string
+}
+
+func (o BackupOptions) encryption() string {
+	switch {
+	case !o.Encrypt:
+		return ""
+	case o.Passphrase != "":
+		return crypto.StreamKeyPassphrase
+	default:
+		return crypto.StreamKeyInstance
+	}
+}
+
+// storeBackupArchiveInternal turns the plain archive tar just wrote to the local backup
+// volume into the stored archive: it is encrypted when requested, moved to target when one
+// is given, and its SHA-256 checksum and stored size are recorded on backup. On a target
+// the checksum is also stored in a sidecar file so the archive can be verified after it
+// is imported on another host.
+func (s *VolumeService) storeBackupArchiveInternal(ctx context.Context, target backuptarget.Target, backup *models.VolumeBackup, plainSize int64, opts BackupOptions) error {
+	filename := backup.ID + backupArchiveSuffix
+	backup.Encryption = opts.encryption()
+
+	if !opts.Encrypt && target == nil {
+		checksum, err := s.localBackupChecksumInternal(ctx, filename)
+		if err != nil {
+			return err
+		}
+		backup.Checksum = checksum
+		backup.Size = plainSize
+		return nil
+	}
+
+	plain, _, err := s.DownloadFile(ctx, s.backupVolumeName, filename)
+	if err != nil {
+		return fmt.Errorf("failed to read backup archive: %w", err)
+	}
+	defer func() { _ = plain.Close() }()
+
+	hasher := sha256.New()
+	var stored io.Reader = io.TeeReader(plain, hasher)
+	size := plainSize
+	if opts.Encrypt {
+		encrypted := encryptBackupStreamInternal(plain, opts.Passphrase, hasher)
+		defer func() { _ = encrypted.Close() }()
+		stored = encrypted
+		size = crypto.EncryptedStreamSize(plainSize)
+	}
+
+	if target != nil {
+		if err := target.Put(ctx, backupObjectNameInternal(backup), stored, size); err != nil {
+			return fmt.Errorf("failed to upload backup to target: %w", err)
+		}
+	} else {
+		if err := s.replaceLocalBackupFileInternal(ctx, filename, stored, size); err != nil {
+			return fmt.Errorf("failed to encrypt backup archive: %w", err)
+		}
+	}
+
+	backup.Checksum = hex.EncodeToString(hasher.Sum(nil))
+	backup.Size = size
+
+	if target != nil {
+		if err := putBackupChecksumInternal(ctx, target, backup); err != nil {
+			_ = target.Delete(context.WithoutCancel(ctx), backupObjectNameInternal(backup))
+			return fmt.Errorf("failed to upload backup checksum to target: %w", err)
+		}
+	}
+	return nil
+}
+
+// encryptBackupStreamInternal encrypts src on the fly. The ciphertext is also written to
+// hasher. Callers must Close the returned reader.
+func encryptBackupStreamInternal(src io.Reader, passphrase string, hasher hash.Hash) io.ReadCloser {
+	pr, pw := io.Pipe()
+	go func() {
+		enc, err := crypto.NewStreamEncrypter(io.MultiWriter(pw, hasher), passphrase)
+		if err == nil {
+			_, err = io.Copy(enc, src)
+		}
+		if err == nil {
+			err = enc.Close()
+		}
+		_ = pw.CloseWithError(err)
+	}()
+	return pr
+}
+
+// localBackupChecksumInternal returns the SHA-256 of a file in the local backup volume.
+func (s *VolumeService) localBackupChecksumInternal(ctx context.Context, filename string) (string, error) {
+	containerID, cleanup, err := s.createTempContainerInternal(ctx, s.backupVolumeName, true)
+	if err != nil {
+		return "", err
+	}
+	defer cleanup()
+
+	stdout, stderr, err := s.execInContainerInternal(ctx, containerID, []string{"sha256sum", path.Join("/volume", filename)})
+	if err != nil {
+		return "", fmt.Errorf("failed to checksum backup archive: %w", err)
+	}
+	fields := strings.Fields(stdout)
+	if len(fields) == 0 || len(fields[0]) != sha256.Size*2 {
+		return "", fmt.Errorf("failed to checksum backup archive: %s", strings.TrimSpace(stderr))
+	}
+	return strings.ToLower(fields[0]), nil
+}
+
+// replaceLocalBackupFileInternal writes r to filename in the local backup volume, replacing
+// the existing file only once the write completed.
+func (s *VolumeService) replaceLocalBackupFileInternal(ctx context.Context, filename string, r io.Reader, size int64) error {
+	containerID, cleanup, err := s.createTempContainerInternal(ctx, s.backupVolumeName, false)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	tmp := ".tmp-" + filename
+	if err := s.copyIntoBackupVolumeInternal(ctx, containerID, tmp, r, size); err != nil {
+		_, _, _ = s.execInContainerInternal(ctx, containerID, []string{"rm", "-f", path.Join("/volume", tmp)})
+		return err
+	}
+	if _, stderr, err := s.execInContainerInternal(ctx, containerID, []string{"mv", "-f", path.Join("/volume", tmp), path.Join("/volume", filename)}); err != nil || strings.TrimSpace(stderr) != "" {
+		if err == nil {
+			err = errors.New(strings.TrimSpace(stderr))
+		}
+		return fmt.Errorf("failed to replace backup archive: %w", err)
+	}
+	return nil
+}
+
+// copyIntoBackupVolumeInternal streams size bytes from r into name at the root of the
+// volume mounted at /volume in containerID.
+func (s *VolumeService) copyIntoBackupVolumeInternal(ctx context.Context, containerID, name string, r io.Reader, size int64) error {
+	dockerClient, err := s.dockerService.GetClient()
+	if err != nil {
+		return err
+	}
+
+	pr, pw := io.Pipe()
+	writeErr := make(chan error, 1)
+	go func() {
+		tw := tar.NewWriter(pw)
+		err := tw.WriteHeader(&tar.Header{
+			Name:    name,
+			Mode:    0o600,
+			Size:    size,
+			ModTime: time.Now(),
+		})
+		if err == nil {
+			_, err = io.Copy(tw, r)
+		}
+		if err == nil {
+			err = tw.Close()
+		}
+		writeErr <- err
+		_ = pw.CloseWithError(err)
+	}()
+
+	err = dockerClient.CopyToContainer(ctx, containerID, "/volume", pr, container.CopyToContainerOptions{})
+	_ = pr.CloseWithError(err)
+	if werr := <-writeErr; werr != nil {
+		return werr
+	}
+	return err
+}
+
+// stageBackupArchiveInternal makes the plain archive of a backup available in the local
+// backup volume and returns its file name there. The stored archive is verified against
+// the recorded checksum first, so nothing is restored from a corrupted or tampered
+// archive. Plain local archives are used in place; remote or encrypted archives are
+// copied (and decrypted with passphrase) into a temporary file the returned function removes.
+func (s *VolumeService) stageBackupArchiveInternal(ctx context.Context, backup *models.VolumeBackup, passphrase string) (string, func(), error) {
+	filename := backup.ID + backupArchiveSuffix
+	if backup.TargetID == nil && backup.Encryption == "" {
+		if backup.Checksum != "" {
+			checksum, err := s.localBackupChecksumInternal(ctx, filename)
+			if err != nil {
+				return "", nil, err
+			}
+			if !strings.EqualFold(checksum, backup.Checksum) {
+				return "", nil, ErrBackupChecksumMismatch
+			}
+		}
+		return filename, func() {}, nil
+	}
+
+	if backup.TargetID != nil && backup.Checksum == "" {
+		return "", nil, ErrBackupUnverified
+	}
+	if backup.Encryption == crypto.StreamKeyPassphrase && passphrase == "" {
+		return "", nil, crypto.ErrPassphraseRequired
+	}
+	if err := s.ensureBackupVolumeInternal(ctx); err != nil {
+		return "", nil, err
+	}
+
+	var (
+		source io.ReadCloser
+		size   int64
+		err    error
+	)
+	if backup.TargetID != nil {
+		source, size, err = s.openRemoteBackupInternal(ctx, backup)
+	} else {
+		source, size, err = s.DownloadFile(ctx, s.backupVolumeName, filename)
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	defer func() { _ = source.Close() }()
+	if size < 0 {
+		return "", nil, fmt.Errorf("backup target did not report the archive size")
+	}
+
+	hasher := sha256.New()
+	var plain io.Reader = io.TeeReader(source, hasher)
+	plainSize := size
+	if backup.Encryption != "" {
+		if plainSize, err = crypto.DecryptedStreamSize(size); err != nil {
+			return "", nil, err
+		}
+		if plain, err = crypto.NewStreamDecrypter(plain, passphrase); err != nil {
+			return "", nil, err
+		}
+	}
+
+	containerID, cleanup, err := s.createTempContainerInternal(ctx, s.backupVolumeName, false)
+	if err != nil {
+		return "", nil, err
+	}
+	defer cleanup()
+
+	staged := ".staged-" + filename
+	unstage := func() {
+		s.removeLocalBackupFilesInternal(context.WithoutCancel(ctx), []string{staged})
+	}
+	if err := s.copyIntoBackupVolumeInternal(ctx, containerID, staged, plain, plainSize); err != nil {
+		unstage()
+		return "", nil, fmt.Errorf("failed to stage backup archive: %w", err)
+	}
+	if backup.Checksum != "" && !strings.EqualFold(hex.EncodeToString(hasher.Sum(nil)), backup.Checksum) {
+		unstage()
+		return "", nil, ErrBackupChecksumMismatch
+	}
+
+	return staged, unstage, nil
+}
+
+// recordedBackupChecksumInternal returns the checksum recorded for the backup an uploaded
+// archive was downloaded from, matched by its file name, or "" when it is unknown.
+func (s *VolumeService) recordedBackupChecksumInternal(ctx context.Context, filename string) string {
+	backupID := strings.TrimSuffix(path.Base(filename), backupArchiveSuffix)
+	if backupID == "" {
+		return ""
+	}
+	var backup models.VolumeBackup
+	if err := s.db.WithContext(ctx).Select("checksum").Where("id = ?", backupID).First(&backup).Error; err != nil {
+		return ""
+	}
+	return backup.Checksum
+}
+
+// decryptUploadedBackupInternal returns f itself when it holds a plain archive. Encrypted
+// archives are decrypted into a new temporary file, which the returned function removes.
+// Either way the returned file is positioned at its start.
+func decryptUploadedBackupInternal(f *os.File, passphrase string) (*os.File, func(), error) {
+	header := make([]byte, 8)
+	n, _ := io.ReadFull(f, header)
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, nil, fmt.Errorf("failed to read buffered upload: %w", err)
+	}
+	if !crypto.IsEncryptedStream(header[:n]) {
+		return f, func() {}, nil
+	}
+
+	plain, err := os.CreateTemp("", "arcane-restore-*.tar.gz")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to buffer upload: %w", err)
+	}
+	remove := func() {
+		_ = plain.Close()
+		_ = os.Remove(plain.Name()) //nolint:gosec // temp file path is generated by os.CreateTemp
+	}
+
+	dec, err := crypto.NewStreamDecrypter(f, passphrase)
+	if err == nil {
+		_, err = io.Copy(plain, dec)
+	}
+	if err == nil {
+		_, err = plain.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		remove()
+		return nil, nil, err
+	}
+	return plain, remove, nil
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/getarcaneapp/arcane/backend/internal/models"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/crypto"
+	"github.com/getarcaneapp/arcane/types/volume"
+)
+
+func TestVolumeService_SaveBackupPolicyEncryptsPassphrase(t *testing.T) {
+	ctx := context.Background()
+	db := setupBackupTargetTestDB(t)
+	svc := &VolumeService{db: db}
+
+	policy, err := svc.SaveBackupPolicy(ctx, "data", volume.UpdateBackupPolicy{Enabled: true, Encrypt: true, Passphrase: new("s3cret")})
+	require.NoError(t, err)
+	assert.True(t, policy.HasPassphrase)
+	assert.NotContains(t, policy.Passphrase, "s3cret")
+
+	body, err := json.Marshal(policy)
+	require.NoError(t, err)
+	assert.NotContains(t, string(body), "s3cret")
+
+	stored, err := svc.GetBackupPolicy(ctx, "data")
+	require.NoError(t, err)
+	assert.True(t, stored.HasPassphrase)
+	opts, err := backupOptionsFromPolicyInternal(stored)
+	require.NoError(t, err)
+	assert.Equal(t, BackupOptions{Encrypt: true, Passphrase: "s3cret"}, opts)
+
+	// Omitting the passphrase keeps it, an empty one removes it.
+	policy, err = svc.SaveBackupPolicy(ctx, "data", volume.UpdateBackupPolicy{Enabled: true, Encrypt: true})
+	require.NoError(t, err)
+	assert.True(t, policy.HasPassphrase)
+
+	policy, err = svc.SaveBackupPolicy(ctx, "data", volume.UpdateBackupPolicy{Enabled: true, Encrypt: true, Passphrase: new("")})
+	require.NoError(t, err)
+	assert.False(t, policy.HasPassphrase)
+	opts, err = backupOptionsFromPolicyInternal(policy)
+	require.NoError(t, err)
+	assert.Equal(t, crypto.StreamKeyInstance, opts.encryption())
+}
+
+func TestVolumeService_SaveBackupPolicyRejectsNegativeRetention(t *testing.T) {
+	svc := &VolumeService{db: setupBackupTargetTestDB(t)}
+
+	_, err := svc.SaveBackupPolicy(context.Background(), "data", volume.UpdateBackupPolicy{Enabled: true, KeepWeekly: -1})
+	var validationErr *models.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "keepWeekly", validationErr.Field)
+}
+
+func TestDecryptUploadedBackupInternal(t *testing.T) {
+	setupBackupTargetTestDB(t) // initializes the instance key
+
+	writeTemp := func(t *testing.T, data []byte) *os.File {
+		t.Helper()
+		f, err := os.CreateTemp(t.TempDir(), "upload-*")
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = f.Close() })
+		_, err = f.Write(data)
+		require.NoError(t, err)
+		_, err = f.Seek(0, io.SeekStart)
+		require.NoError(t, err)
+		return f
+	}
+	plain := []byte("\x1f\x8bnot really a gzip archive")
+
+	t.Run("plain archives are used as is", func(t *testing.T) {
+		f := writeTemp(t, plain)
+		got, cleanup, err := decryptUploadedBackupInternal(f, "")
+		require.NoError(t, err)
+		defer cleanup()
+		assert.Same(t, f, got)
+	})
+
+	t.Run("encrypted archives are decrypted", func(t *testing.T) {
+		var encrypted bytes.Buffer
+		w, err := crypto.NewStreamEncrypter(&encrypted, "pass")
+		require.NoError(t, err)
+		_, err = w.Write(plain)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+
+		_, _, err = decryptUploadedBackupInternal(writeTemp(t, encrypted.Bytes()), "")
+		require.ErrorIs(t, err, crypto.ErrPassphraseRequired)
+
+		_, _, err = decryptUploadedBackupInternal(writeTemp(t, encrypted.Bytes()), "wrong")
+		require.ErrorIs(t, err, crypto.ErrStreamCorrupted)
+
+		got, cleanup, err := decryptUploadedBackupInternal(writeTemp(t, encrypted.Bytes()), "pass")
+		require.NoError(t, err)
+		defer cleanup()
+		data, err := io.ReadAll(got)
+		require.NoError(t, err)
+		assert.Equal(t, plain, data)
+	})
+}
+
+func TestVolumeService_RecordedBackupChecksumInternal(t *testing.T) {
+	ctx := context.Background()
+	db := setupBackupTargetTestDB(t)
+	svc := &VolumeService{db: db}
+
+	backup := models.VolumeBackup{BaseModel: models.BaseModel{ID: "data-1-abc"}, VolumeName: "data", Checksum: "deadbeef"}
+	require.NoError(t, db.Create(&backup).Error)
+
+	assert.Equal(t, "deadbeef", svc.recordedBackupChecksumInternal(ctx, "data-1-abc.tar.gz"))
+	assert.Equal(t, "deadbeef", svc.recordedBackupChecksumInternal(ctx, "downloads/data-1-abc.tar.gz"))
+	assert.Empty(t, svc.recordedBackupChecksumInternal(ctx, "other.tar.gz"))
+}
@@ -0,0 +1,295 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/docker/compose/v5/pkg/api"
+	"github.com/docker/docker/api/types/container"
+	"github.com/getarcaneapp/arcane/backend/internal/models"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/docker"
+	"github.com/getarcaneapp/arcane/backend/pkg/projects"
+)
+
+// backupHookOutputLimit caps the hook output stored on a backup record.
+const backupHookOutputLimit = 256 << 10
+
+// backupHookContainer is a container considered while a volume is backed up, with the
+// hooks declared for it when it uses that volume.
+type backupHookContainer struct {
+	id      string
+	name    string
+	project string
+	service string
+	running bool
+	hooks   projects.BackupHooks
+}
+
+// backupHookRun tracks the hooks of one backup so they can be undone once the archive
+// was written.
+type backupHookRun struct {
+	s          *VolumeService
+	user       models.User
+	containers []backupHookContainer
+	// prepared holds the IDs of the containers whose post-backup hook is due: their
+	// pre-backup hook succeeded, or they have none and no pre-backup hook failed.
+	prepared map[string]struct{}
+	stopped  []string
+	output   strings.Builder
+	executed bool
+	// runExec runs a hook command in a container.
+	runExec func(ctx context.Context, containerID string, cmd []string) (string, int, error)
+}
+
+// runPreBackupHooksInternal runs the pre-backup hooks of the containers using a volume
+// and stops the containers the hooks ask for. Hooks are read from container labels and
+// from the x-arcane block of the compose project a container belongs to; labels win. If
+// a pre-backup hook fails, everything done so far is undone and an error is returned.
+// Otherwise the caller must call finish on the returned run once the archive was written.
+func (s *VolumeService) runPreBackupHooksInternal(ctx context.Context, volumeName string, user models.User) (*backupHookRun, error) {
+	run := &backupHookRun{s: s, user: user, prepared: map[string]struct{}{}}
+	if s.containerService == nil {
+		return run, nil
+	}
+	run.runExec = s.containerService.RunExec
+
+	containers, err := s.findBackupHookContainersInternal(ctx, volumeName)
+	if err != nil {
+		return nil, err
+	}
+	run.containers = containers
+
+	if err := run.prepareInternal(ctx); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// prepareInternal runs the pre-backup hooks and stops the containers the hooks ask for.
+// On failure it undoes what was done through finish and returns an error with the output.
+func (r *backupHookRun) prepareInternal(ctx context.Context) error {
+	for _, c := range r.containers {
+		if c.hooks.Pre == "" {
+			continue
+		}
+		if !c.running {
+			r.logf("pre-backup hook in %s skipped: container is not running\n", c.name)
+			continue
+		}
+		if err := r.exec(ctx, c, "pre-backup", c.hooks.Pre); err != nil {
+			return fmt.Errorf("%w; backup aborted. Hook output:\n%s", err, tailInternal(r.finish(ctx), 4<<10))
+		}
+		r.prepared[c.id] = struct{}{}
+	}
+	for _, c := range r.containers {
+		if c.hooks.Pre == "" && c.running {
+			r.prepared[c.id] = struct{}{}
+		}
+	}
+
+	if err := r.stopContainers(ctx); err != nil {
+		return fmt.Errorf("%w; backup aborted. Hook output:\n%s", err, tailInternal(r.finish(ctx), 4<<10))
+	}
+	return nil
+}
+
+// finish starts the containers stopped for the backup, runs the post-backup hooks of the
+// prepared containers and returns the output of all hooks. Failures are recorded in the output and logged but do
+// not fail the backup, which is already written.
+func (r *backupHookRun) finish(ctx context.Context) string {
+	ctx = context.WithoutCancel(ctx)
+
+	for i := len(r.stopped) - 1; i >= 0; i-- {
+		id := r.stopped[i]
+		if err := r.s.containerService.StartContainer(ctx, id, r.user); err != nil {
+			r.logf("failed to restart %s after backup: %s\n", r.containerName(id), err.Error())
+			slog.WarnContext(ctx, "failed to restart container after backup", "container", id, "error", err.Error())
+		}
+	}
+	r.stopped = nil
+
+	for _, c := range r.containers {
+		if _, ok := r.prepared[c.id]; !ok || c.hooks.Post == "" {
+			continue
+		}
+		if err := r.exec(ctx, c, "post-backup", c.hooks.Post); err != nil {
+			slog.WarnContext(ctx, "post-backup hook failed", "container", c.name, "error", err.Error())
+		}
+	}
+
+	return r.output.String()
+}
+
+// ran reports whether any hook did something.
+func (r *backupHookRun) ran() bool {
+	return r.executed
+}
+
+func (r *backupHookRun) exec(ctx context.Context, c backupHookContainer, stage, command string) error {
+	r.executed = true
+	output, exitCode, err := r.runExec(ctx, c.id, []string{"sh", "-c", command})
+	r.logf("==> %s hook in %s (exit %d)\n", stage, c.name, exitCode)
+	r.logf("%s", output)
+	if output != "" && !strings.HasSuffix(output, "\n") {
+		r.logf("\n")
+	}
+	switch {
+	case err != nil:
+		r.logf("%s\n", err.Error())
+		return fmt.Errorf("%s hook in %s failed: %w", stage, c.name, err)
+	case exitCode != 0:
+		return fmt.Errorf("%s hook in %s exited with status %d", stage, c.name, exitCode)
+	}
+	return nil
+}
+
+func (r *backupHookRun) stopContainers(ctx context.Context) error {
+	for _, id := range r.containersToStop() {
+		r.executed = true
+		if err := r.s.containerService.StopContainer(ctx, id, r.user); err != nil {
+			r.logf("failed to stop %s for backup: %s\n", r.containerName(id), err.Error())
+			return fmt.Errorf("failed to stop container %s for backup: %w", r.containerName(id), err)
+		}
+		r.logf("==> stopped %s for backup\n", r.containerName(id))
+		r.stopped = append(r.stopped, id)
+	}
+	return nil
+}
+
+// containersToStop resolves the stop hooks to the IDs of running containers. Names match
+// a service of the same compose project first and a container name otherwise.
+func (r *backupHookRun) containersToStop() []string {
+	var ids []string
+	add := func(c backupHookContainer) {
+		if c.running && !slices.Contains(ids, c.id) {
+			ids = append(ids, c.id)
+		}
+	}
+	for _, c := range r.containers {
+		if c.hooks.StopSelf {
+			add(c)
+		}
+		for _, name := range c.hooks.Stop {
+			if match, ok := r.findStopTarget(c, name); ok {
+				add(match)
+			} else {
+				r.logf("container %s to stop for backup (declared by %s) was not found\n", name, c.name)
+			}
+		}
+	}
+	return ids
+}
+
+func (r *backupHookRun) findStopTarget(owner backupHookContainer, name string) (backupHookContainer, bool) {
+	if owner.project != "" {
+		for _, c := range r.containers {
+			if c.project == owner.project && c.service == name {
+				return c, true
+			}
+		}
+	}
+	for _, c := range r.containers {
+		if c.name == name {
+			return c, true
+		}
+	}
+	return backupHookContainer{}, false
+}
+
+func (r *backupHookRun) containerName(id string) string {
+	for _, c := range r.containers {
+		if c.id == id {
+			return c.name
+		}
+	}
+	return id
+}
+
+func (r *backupHookRun) logf(format string, args ...any) {
+	if r.output.Len() >= backupHookOutputLimit {
+		return
+	}
+	msg := fmt.Sprintf(format, args...)
+	if remaining := backupHookOutputLimit - r.output.Len(); len(msg) > remaining {
+		msg = msg[:remaining]
+	}
+	r.output.WriteString(msg)
+}
+
+// findBackupHookContainersInternal returns all containers, with the backup hooks of those
+// using the volume. The others carry no hooks and are only there so stop hooks can refer
+// to them by name.
+func (s *VolumeService) findBackupHookContainersInternal(ctx context.Context, volumeName string) ([]backupHookContainer, error) {
+	dockerClient, err := s.dockerService.GetClient()
+	if err != nil {
+		return nil, err
+	}
+
+	usingIDs, err := docker.GetContainersUsingVolume(ctx, dockerClient, volumeName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get containers using volume: %w", err)
+	}
+	if len(usingIDs) == 0 {
+		return nil, nil
+	}
+
+	all, err := dockerClient.ContainerList(ctx, container.ListOptions{All: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	composeHooks := map[string]map[string]projects.BackupHooks{}
+	hooksFromCompose := func(labels map[string]string) projects.BackupHooks {
+		configFiles := labels[api.ConfigFilesLabel]
+		if configFiles == "" {
+			return projects.BackupHooks{}
+		}
+		byService, ok := composeHooks[configFiles]
+		if !ok {
+			byService = map[string]projects.BackupHooks{}
+			for _, file := range strings.Split(configFiles, ",") {
+				meta, err := projects.ParseArcaneComposeMetadata(ctx, strings.TrimSpace(file))
+				if err != nil {
+					slog.DebugContext(ctx, "could not read backup hooks from compose file", "file", file, "error", err.Error())
+					continue
+				}
+				for name, hooks := range meta.ServiceBackupHooks {
+					byService[name] = projects.MergeBackupHooks(hooks, byService[name])
+				}
+			}
+			composeHooks[configFiles] = byService
+		}
+		return byService[labels[api.ServiceLabel]]
+	}
+
+	containers := make([]backupHookContainer, 0, len(all))
+	for _, c := range all {
+		name := c.ID
+		if len(c.Names) > 0 {
+			name = strings.TrimPrefix(c.Names[0], "/")
+		}
+		entry := backupHookContainer{
+			id:      c.ID,
+			name:    name,
+			project: c.Labels[api.ProjectLabel],
+			service: c.Labels[api.ServiceLabel],
+			running: c.State == container.StateRunning,
+		}
+		if slices.Contains(usingIDs, c.ID) {
+			entry.hooks = projects.MergeBackupHooks(projects.ParseBackupHookLabels(c.Labels), hooksFromCompose(c.Labels))
+		}
+		containers = append(containers, entry)
+	}
+	return containers, nil
+}
+
+// tailInternal returns the last n bytes of s.
+func tailInternal(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return "..." + s[len(s)-n:]
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/getarcaneapp/arcane/backend/pkg/projects"
+)
+
+func TestBackupHookRun_ContainersToStop(t *testing.T) {
+	run := &backupHookRun{containers: []backupHookContainer{
+		{id: "db1", name: "shop-db-1", project: "shop", service: "db", running: true, hooks: projects.BackupHooks{Stop: []string{"app", "worker", "metrics", "missing"}}},
+		{id: "app1", name: "shop-app-1", project: "shop", service: "app", running: true},
+		{id: "app2", name: "blog-app-1", project: "blog", service: "app", running: true},
+		{id: "worker1", name: "shop-worker-1", project: "shop", service: "worker", running: false},
+		{id: "metrics1", name: "metrics", running: true, hooks: projects.BackupHooks{StopSelf: true}},
+	}}
+
+	// Services resolve within the owner's project, other names to container names;
+	// stopped containers are left alone and every container is stopped once.
+	assert.Equal(t, []string{"app1", "metrics1"}, run.containersToStop())
+	assert.Contains(t, run.output.String(), "missing")
+}
+
+func TestBackupHookRun_OutputIsCapped(t *testing.T) {
+	run := &backupHookRun{}
+	chunk := strings.Repeat("x", 100<<10)
+	for range 5 {
+		run.logf("%s", chunk)
+	}
+	assert.Equal(t, backupHookOutputLimit, run.output.Len())
+	assert.Equal(t, "...abc", tailInternal("0123456789abc", 3))
+}
+
+func TestBackupHookRun_PostHooksOnlyFollowSuccessfulPreHooks(t *testing.T) {
+	var ran []string
+	run := &backupHookRun{
+		prepared: map[string]struct{}{},
+		containers: []backupHookContainer{
+			{id: "db1", name: "db", running: true, hooks: projects.BackupHooks{Pre: "lock", Post: "unlock"}},
+			{id: "cache1", name: "cache", running: true, hooks: projects.BackupHooks{Pre: "flush", Post: "resume"}},
+			{id: "search1", name: "search", running: true, hooks: projects.BackupHooks{Pre: "pause", Post: "unpause"}},
+			{id: "web1", name: "web", running: true, hooks: projects.BackupHooks{Post: "notify"}},
+		},
+		runExec: func(_ context.Context, containerID string, cmd []string) (string, int, error) {
+			ran = append(ran, containerID+" "+cmd[2])
+			if cmd[2] == "flush" {
+				return "flush failed", 1, nil
+			}
+			return "", 0, nil
+		},
+	}
+
+	err := run.prepareInternal(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "pre-backup hook in cache exited with status 1")
+	// Only the database was locked, so only it is unlocked; the backup never ran, so web
+	// is not told it finished.
+	assert.Equal(t, []string{"db1 lock", "cache1 flush", "db1 unlock"}, ran)
+}
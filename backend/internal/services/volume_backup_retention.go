@@ -0,0 +1,70 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/getarcaneapp/arcane/backend/internal/models"
+)
+
+// selectBackupsToPruneInternal returns the backups that fall outside the policy's
+// retention rules. A backup is kept when any rule keeps it:
+//   - KeepLast keeps the newest N backups.
+//   - KeepDaily keeps the newest backup of each of the N most recent days that have one.
+//   - KeepWeekly keeps the newest backup of each of the N most recent ISO weeks that have one.
+//
+// Days and weeks are evaluated in loc. Nothing is pruned when no rule is configured.
+func selectBackupsToPruneInternal(backups []models.VolumeBackup, policy *models.VolumeBackupPolicy, loc *time.Location) []models.VolumeBackup {
+	if policy == nil || !policy.HasRetention() || len(backups) == 0 {
+		return nil
+	}
+	if loc == nil {
+		loc = time.Local
+	}
+
+	sorted := make([]models.VolumeBackup, len(backups))
+	copy(sorted, backups)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt.After(sorted[j].CreatedAt)
+	})
+
+	keep := make(map[string]struct{}, len(sorted))
+	for i := 0; i < policy.KeepLast && i < len(sorted); i++ {
+		keep[sorted[i].ID] = struct{}{}
+	}
+
+	keepNewestPerBucket := func(limit int, bucket func(time.Time) string) {
+		if limit <= 0 {
+			return
+		}
+		seen := make(map[string]struct{}, limit)
+		for _, b := range sorted {
+			key := bucket(b.CreatedAt.In(loc))
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			if len(seen) == limit {
+				return
+			}
+			seen[key] = struct{}{}
+			keep[b.ID] = struct{}{}
+		}
+	}
+
+	keepNewestPerBucket(policy.KeepDaily, func(t time.Time) string {
+		return t.Format(time.DateOnly)
+	})
+	keepNewestPerBucket(policy.KeepWeekly, func(t time.Time) string {
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	})
+
+	var prune []models.VolumeBackup
+	for _, b := range sorted {
+		if _, ok := keep[b.ID]; !ok {
+			prune = append(prune, b)
+		}
+	}
+	return prune
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/getarcaneapp/arcane/backend/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func backupAt(ts string) models.VolumeBackup {
+	t, err := time.Parse(time.RFC3339, ts)
+	if err != nil {
+		panic(err)
+	}
+	return models.VolumeBackup{BaseModel: models.BaseModel{ID: ts}, VolumeName: "data", Scheduled: true, CreatedAt: t}
+}
+
+// nightlyBackupsInternal returns one backup per day at 02:00 UTC from 2026-01-01 through
+// 2026-01-15 plus a second backup on 2026-01-15 at 14:00 UTC.
+func nightlyBackupsInternal() []models.VolumeBackup {
+	var backups []models.VolumeBackup
+	for day := 1; day <= 15; day++ {
+		backups = append(backups, backupAt(fmt.Sprintf("2026-01-%02dT02:00:00Z", day)))
+	}
+	return append(backups, backupAt("2026-01-15T14:00:00Z"))
+}
+
+func keptIDsInternal(all, pruned []models.VolumeBackup) []string {
+	prunedIDs := make(map[string]struct{}, len(pruned))
+	for _, b := range pruned {
+		prunedIDs[b.ID] = struct{}{}
+	}
+	var kept []string
+	for _, b := range all {
+		if _, ok := prunedIDs[b.ID]; !ok {
+			kept = append(kept, b.ID)
+		}
+	}
+	return kept
+}
+
+func TestSelectBackupsToPruneInternal(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy models.VolumeBackupPolicy
+		want   []string
+	}{
+		{
+			name:   "keep last",
+			policy: models.VolumeBackupPolicy{KeepLast: 3},
+			want:   []string{"2026-01-14T02:00:00Z", "2026-01-15T02:00:00Z", "2026-01-15T14:00:00Z"},
+		},
+		{
+			name:   "keep daily keeps newest backup per day",
+			policy: models.VolumeBackupPolicy{KeepDaily: 3},
+			want:   []string{"2026-01-13T02:00:00Z", "2026-01-14T02:00:00Z", "2026-01-15T14:00:00Z"},
+		},
+		{
+			name:   "keep weekly uses ISO weeks",
+			policy: models.VolumeBackupPolicy{KeepWeekly: 2},
+			want:   []string{"2026-01-11T02:00:00Z", "2026-01-15T14:00:00Z"},
+		},
+		{
+			name:   "rules are combined",
+			policy: models.VolumeBackupPolicy{KeepLast: 1, KeepDaily: 2, KeepWeekly: 3},
+			want:   []string{"2026-01-04T02:00:00Z", "2026-01-11T02:00:00Z", "2026-01-14T02:00:00Z", "2026-01-15T14:00:00Z"},
+		},
+		{
+			name:   "limit larger than backups keeps everything",
+			policy: models.VolumeBackupPolicy{KeepLast: 100},
+			want:   keptIDsInternal(nightlyBackupsInternal(), nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backups := nightlyBackupsInternal()
+			pruned := selectBackupsToPruneInternal(backups, &tt.policy, time.UTC)
+			require.Equal(t, tt.want, keptIDsInternal(backups, pruned))
+		})
+	}
+}
+
+func TestSelectBackupsToPruneInternal_NoRetention(t *testing.T) {
+	backups := nightlyBackupsInternal()
+
+	require.Nil(t, selectBackupsToPruneInternal(backups, &models.VolumeBackupPolicy{Enabled: true}, time.UTC))
+	require.Nil(t, selectBackupsToPruneInternal(backups, nil, time.UTC))
+}
+
+func TestSelectBackupsToPruneInternal_UsesLocation(t *testing.T) {
+	backups := []models.VolumeBackup{
+		backupAt("2026-01-13T12:00:00Z"),
+		backupAt("2026-01-14T23:30:00Z"),
+		backupAt("2026-01-15T00:30:00Z"),
+	}
+	policy := &models.VolumeBackupPolicy{KeepDaily: 2}
+
+	// In UTC the two late backups fall on different days.
+	require.Equal(t,
+		[]string{"2026-01-14T23:30:00Z", "2026-01-15T00:30:00Z"},
+		keptIDsInternal(backups, selectBackupsToPruneInternal(backups, policy, time.UTC)))
+
+	// Five hours behind UTC both fall on 2026-01-14, so the 13th is kept instead.
+	loc := time.FixedZone("UTC-5", -5*60*60)
+	require.Equal(t,
+		[]string{"2026-01-13T12:00:00Z", "2026-01-15T00:30:00Z"},
+		keptIDsInternal(backups, selectBackupsToPruneInternal(backups, policy, loc)))
+}
@@ -0,0 +1,271 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"strings"
+
+	"github.com/getarcaneapp/arcane/backend/internal/models"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/backuptarget"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/crypto"
+)
+
+const (
+	backupArchiveSuffix  = ".tar.gz"
+	backupChecksumSuffix = ".sha256"
+)
+
+// backupObjectNameInternal returns the name a backup archive is stored under on a backup target.
+func backupObjectNameInternal(backup *models.VolumeBackup) string {
+	return path.Join(backup.VolumeName, backup.ID+backupArchiveSuffix)
+}
+
+// backupChecksumSignaturePurpose keys the signatures of checksum files on backup targets.
+const backupChecksumSignaturePurpose = "backup-checksum"
+
+// putBackupChecksumInternal stores the checksum of an uploaded archive next to it on the
+// target, in the format of sha256sum. A second line signs the checksum and archive name
+// with the instance encryption key, so that a checksum file replaced on the target
+// together with its archive is not trusted when the archive is imported.
+func putBackupChecksumInternal(ctx context.Context, target backuptarget.Target, backup *models.VolumeBackup) error {
+	name := backupObjectNameInternal(backup)
+	signature, err := crypto.Sign(backupChecksumSignaturePurpose, backupChecksumMessageInternal(path.Base(name), backup.Checksum))
+	if err != nil {
+		return err
+	}
+	content := fmt.Sprintf("%s  %s\n# signature %s\n", backup.Checksum, path.Base(name), signature)
+	return target.Put(ctx, name+backupChecksumSuffix, strings.NewReader(content), int64(len(content)))
+}
+
+// readBackupChecksumInternal returns the checksum stored next to the archive name on
+// target. The checksum file must be signed by this instance.
+func readBackupChecksumInternal(ctx context.Context, target backuptarget.Target, name string) (string, error) {
+	reader, _, err := target.Get(ctx, name+backupChecksumSuffix)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = reader.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(reader, 1024))
+	if err != nil {
+		return "", err
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) != 5 || len(fields[0]) != sha256.Size*2 || fields[2] != "#" || fields[3] != "signature" {
+		return "", fmt.Errorf("invalid checksum file for %s", name)
+	}
+	if _, err := hex.DecodeString(fields[0]); err != nil {
+		return "", fmt.Errorf("invalid checksum file for %s", name)
+	}
+	checksum := strings.ToLower(fields[0])
+	if !crypto.Verify(backupChecksumSignaturePurpose, backupChecksumMessageInternal(path.Base(name), checksum), fields[4]) {
+		return "", fmt.Errorf("checksum file for %s is not signed by this instance", name)
+	}
+	return checksum, nil
+}
+
+func backupChecksumMessageInternal(archiveName, checksum string) string {
+	return archiveName + "\x00" + checksum
+}
+
+// readBackupEncryptionInternal returns how the archive name on target is encrypted, read
+// from its stream header; "" for plain archives.
+func readBackupEncryptionInternal(ctx context.Context, target backuptarget.Target, name string) (string, error) {
+	reader, _, err := target.Get(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = reader.Close() }()
+	return crypto.ReadStreamKeyMode(reader)
+}
+
+func (s *VolumeService) getBackupTargetInternal(ctx context.Context, targetID string) (*models.BackupTarget, error) {
+	if s.backupTargets == nil {
+		return nil, fmt.Errorf("backup targets are not available")
+	}
+	return s.backupTargets.GetTarget(ctx, targetID)
+}
+
+func (s *VolumeService) openBackupTargetInternal(ctx context.Context, targetID string) (backuptarget.Target, error) {
+	if s.backupTargets == nil {
+		return nil, fmt.Errorf("backup targets are not available")
+	}
+	target, err := s.backupTargets.OpenTarget(ctx, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open backup target: %w", err)
+	}
+	return target, nil
+}
+
+// openRemoteBackupInternal streams a backup archive from its backup target.
+func (s *VolumeService) openRemoteBackupInternal(ctx context.Context, backup *models.VolumeBackup) (io.ReadCloser, int64, error) {
+	target, err := s.openBackupTargetInternal(ctx, *backup.TargetID)
+	if err != nil {
+		return nil, 0, err
+	}
+	reader, size, err := target.Get(ctx, backupObjectNameInternal(backup))
+	if err != nil {
+		_ = target.Close()
+		if errors.Is(err, backuptarget.ErrNotFound) {
+			return nil, 0, fmt.Errorf("backup archive %s is missing on its backup target", backup.ID)
+		}
+		return nil, 0, fmt.Errorf("failed to download backup from target: %w", err)
+	}
+	return &cleanupReadCloser{
+		Reader:  reader,
+		Closer:  reader,
+		cleanup: func() { _ = target.Close() },
+	}, size, nil
+}
+
+// removeLocalBackupFilesInternal deletes files from the local backup volume. It is best
+// effort: failures only leave orphan files behind and are logged.
+func (s *VolumeService) removeLocalBackupFilesInternal(ctx context.Context, filenames []string) {
+	if len(filenames) == 0 {
+		return
+	}
+	containerID, cleanup, err := s.createTempContainerInternal(ctx, s.backupVolumeName, false)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to create container for backup file cleanup", "files", filenames, "error", err.Error())
+		return
+	}
+	defer cleanup()
+
+	cmd := []string{"rm", "-f"}
+	for _, name := range filenames {
+		cmd = append(cmd, path.Join("/volume", name))
+	}
+	if _, _, err := s.execInContainerInternal(ctx, containerID, cmd); err != nil {
+		slog.WarnContext(ctx, "failed to delete backup files (orphan files may remain)", "files", filenames, "error", err.Error())
+	}
+}
+
+// removeBackupArchivesInternal deletes the archives of backups whose rows are already
+// gone, from the local backup volume or their backup target. It is best effort.
+func (s *VolumeService) removeBackupArchivesInternal(ctx context.Context, backups []models.VolumeBackup) {
+	var local []string
+	remote := map[string][]models.VolumeBackup{}
+	for _, b := range backups {
+		if b.TargetID == nil {
+			local = append(local, b.ID+backupArchiveSuffix)
+			continue
+		}
+		remote[*b.TargetID] = append(remote[*b.TargetID], b)
+	}
+
+	s.removeLocalBackupFilesInternal(ctx, local)
+
+	for targetID, targetBackups := range remote {
+		target, err := s.openBackupTargetInternal(ctx, targetID)
+		if err != nil {
+			slog.WarnContext(ctx, "failed to open backup target for backup cleanup (orphan files may remain)", "target_id", targetID, "error", err.Error())
+			continue
+		}
+		for i := range targetBackups {
+			name := backupObjectNameInternal(&targetBackups[i])
+			for _, object := range []string{name, name + backupChecksumSuffix} {
+				if err := target.Delete(ctx, object); err != nil {
+					slog.WarnContext(ctx, "failed to delete backup from target (orphan file may remain)", "target_id", targetID, "backup_id", targetBackups[i].ID, "object", object, "error", err.Error())
+				}
+			}
+		}
+		_ = target.Close()
+	}
+}
+
+// RescanBackupTargets records archives found on backup targets that are missing from
+// the database, e.g. after Arcane was reinstalled on a new host, and returns how many
+// were imported. It opens every configured target, so it runs from the scheduled backup
+// job or on request rather than on every listing. An unreachable target is logged and
+// skipped so it never hides backups stored on the others.
+func (s *VolumeService) RescanBackupTargets(ctx context.Context, volumeName string) (int, error) {
+	if s.backupTargets == nil {
+		return 0, nil
+	}
+	targets, err := s.backupTargets.ListTargets(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list backup targets: %w", err)
+	}
+	if len(targets) == 0 {
+		return 0, nil
+	}
+
+	var knownIDs []string
+	if err := s.db.WithContext(ctx).Model(&models.VolumeBackup{}).Where("volume_name = ?", volumeName).Pluck("id", &knownIDs).Error; err != nil {
+		return 0, fmt.Errorf("failed to load known backups: %w", err)
+	}
+	known := make(map[string]struct{}, len(knownIDs))
+	for _, id := range knownIDs {
+		known[id] = struct{}{}
+	}
+
+	imported := 0
+	for _, t := range targets {
+		target, err := s.backupTargets.OpenTarget(ctx, t.ID)
+		if err != nil {
+			slog.WarnContext(ctx, "failed to open backup target", "target", t.Name, "error", err.Error())
+			continue
+		}
+		imported += s.importTargetBackupsInternal(ctx, target, &t, volumeName, known)
+		_ = target.Close()
+	}
+	return imported, nil
+}
+
+// importTargetBackupsInternal records the archives of volumeName on target that are not
+// in known. Archives without a valid checksum file signed by this instance are skipped,
+// as they could not be verified when restored; backups written by an installation with
+// another encryption key are therefore not imported. The encryption of each archive is read from its header.
+func (s *VolumeService) importTargetBackupsInternal(ctx context.Context, target backuptarget.Target, t *models.BackupTarget, volumeName string, known map[string]struct{}) int {
+	objects, err := target.List(ctx, volumeName)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to list backup target", "target", t.Name, "volume", volumeName, "error", err.Error())
+		return 0
+	}
+
+	imported := 0
+	for _, obj := range objects {
+		base := path.Base(obj.Name)
+		if !strings.HasSuffix(base, backupArchiveSuffix) || strings.HasPrefix(base, ".") {
+			continue
+		}
+		id := strings.TrimSuffix(base, backupArchiveSuffix)
+		if _, ok := known[id]; ok {
+			continue
+		}
+
+		checksum, err := readBackupChecksumInternal(ctx, target, obj.Name)
+		if err != nil {
+			slog.WarnContext(ctx, "skipping backup without a valid checksum file on target", "target", t.Name, "backup_id", id, "error", err.Error())
+			continue
+		}
+		encryption, err := readBackupEncryptionInternal(ctx, target, obj.Name)
+		if err != nil {
+			slog.WarnContext(ctx, "skipping backup with an unreadable header on target", "target", t.Name, "backup_id", id, "error", err.Error())
+			continue
+		}
+
+		backup := &models.VolumeBackup{
+			BaseModel:  models.BaseModel{ID: id},
+			VolumeName: volumeName,
+			Size:       obj.Size,
+			TargetID:   new(t.ID),
+			Checksum:   checksum,
+			Encryption: encryption,
+			CreatedAt:  obj.ModTime,
+		}
+		if err := s.db.WithContext(ctx).Create(backup).Error; err != nil {
+			slog.WarnContext(ctx, "failed to import backup from target", "target", t.Name, "backup_id", id, "error", err.Error())
+			continue
+		}
+		known[id] = struct{}{}
+		imported++
+	}
+	return imported
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/getarcaneapp/arcane/backend/internal/models"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/crypto"
+	"github.com/getarcaneapp/arcane/types/volume"
+)
+
+func TestVolumeService_RescanBackupTargets(t *testing.T) {
+	ctx := context.Background()
+	db := setupBackupTargetTestDB(t)
+	targets := NewBackupTargetService(db)
+	svc := &VolumeService{db: db, backupTargets: targets}
+
+	created, err := targets.CreateTarget(ctx, volume.CreateBackupTarget{Name: "disk", Type: "path", Config: map[string]any{"path": t.TempDir()}})
+	require.NoError(t, err)
+	target, err := targets.OpenTarget(ctx, created.ID)
+	require.NoError(t, err)
+	defer func() { _ = target.Close() }()
+
+	put := func(id string, data []byte, withChecksum bool) string {
+		backup := &models.VolumeBackup{BaseModel: models.BaseModel{ID: id}, VolumeName: "data"}
+		require.NoError(t, target.Put(ctx, backupObjectNameInternal(backup), bytes.NewReader(data), int64(len(data))))
+		sum := sha256.Sum256(data)
+		backup.Checksum = hex.EncodeToString(sum[:])
+		if withChecksum {
+			require.NoError(t, putBackupChecksumInternal(ctx, target, backup))
+		}
+		return backup.Checksum
+	}
+
+	var encrypted bytes.Buffer
+	enc, err := crypto.NewStreamEncrypter(&encrypted, "s3cret")
+	require.NoError(t, err)
+	_, err = enc.Write([]byte("archive"))
+	require.NoError(t, err)
+	require.NoError(t, enc.Close())
+
+	plainSum := put("data-1-plain", []byte{0x1f, 0x8b, 0x08, 0x00}, true)
+	encSum := put("data-2-enc", encrypted.Bytes(), true)
+	put("data-3-unverified", []byte{0x1f, 0x8b, 0x08, 0x00}, false)
+
+	// Whoever can write to the target can replace an archive and its checksum file, but
+	// cannot sign the checksum, nor reuse the signed checksum file of another archive.
+	forgedSum := put("data-4-forged", []byte("forged"), false)
+	forged := fmt.Sprintf("%s  data-4-forged.tar.gz\n# signature %s\n", forgedSum, strings.Repeat("0", 64))
+	require.NoError(t, target.Put(ctx, "data/data-4-forged.tar.gz.sha256", strings.NewReader(forged), int64(len(forged))))
+	put("data-5-moved", []byte{0x1f, 0x8b, 0x08, 0x00}, false)
+	reader, size, err := target.Get(ctx, "data/data-1-plain.tar.gz.sha256")
+	require.NoError(t, err)
+	require.NoError(t, target.Put(ctx, "data/data-5-moved.tar.gz.sha256", reader, size))
+	_ = reader.Close()
+
+	imported, err := svc.RescanBackupTargets(ctx, "data")
+	require.NoError(t, err)
+	assert.Equal(t, 2, imported)
+
+	var backups []models.VolumeBackup
+	require.NoError(t, db.Order("id").Find(&backups).Error)
+	require.Len(t, backups, 2)
+	assert.Equal(t, plainSum, backups[0].Checksum)
+	assert.Empty(t, backups[0].Encryption)
+	assert.Equal(t, encSum, backups[1].Checksum)
+	assert.Equal(t, crypto.StreamKeyPassphrase, backups[1].Encryption)
+	require.NotNil(t, backups[1].TargetID)
+	assert.Equal(t, created.ID, *backups[1].TargetID)
+
+	imported, err = svc.RescanBackupTargets(ctx, "data")
+	require.NoError(t, err)
+	assert.Zero(t, imported)
+}
+
+func TestVolumeService_StageRefusesUnverifiedTargetBackup(t *testing.T) {
+	svc := &VolumeService{}
+	backup := &models.VolumeBackup{BaseModel: models.BaseModel{ID: "data-1"}, VolumeName: "data", TargetID: new("t1")}
+
+	_, _, err := svc.stageBackupArchiveInternal(context.Background(), backup, "")
+	require.ErrorIs(t, err, ErrBackupUnverified)
+}
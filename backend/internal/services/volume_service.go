@@ -5,6 +5,9 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -25,12 +28,15 @@ import (
 	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/getarcaneapp/arcane/backend/internal/database"
 	"github.com/getarcaneapp/arcane/backend/internal/models"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/backuptarget"
+	"github.com/getarcaneapp/arcane/backend/internal/utils/crypto"
 	"github.com/getarcaneapp/arcane/backend/internal/utils/docker"
 	"github.com/getarcaneapp/arcane/backend/internal/utils/pagination"
 	"github.com/getarcaneapp/arcane/backend/internal/utils/timeouts"
 	"github.com/getarcaneapp/arcane/backend/pkg/libarcane"
 	volumetypes "github.com/getarcaneapp/arcane/types/volume"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type VolumeService struct {
@@ -40,6 +46,7 @@ type VolumeService struct {
 	settingsService  *SettingsService
 	containerService *ContainerService
 	imageService     *ImageService
+	backupTargets    *BackupTargetService
 	backupVolumeName string
 	helperMu         sync.Mutex
 	helperByVolume   map[string]string
@@ -47,7 +54,7 @@ type VolumeService struct {
 
 const volumeHelperImage = "busybox:stable-musl"
 
-func NewVolumeService(db *database.DB, dockerService *DockerClientService, eventService *EventService, settingsService *SettingsService, containerService *ContainerService, imageService *ImageService, backupVolumeName string) *VolumeService {
+func NewVolumeService(db *database.DB, dockerService *DockerClientService, eventService *EventService, settingsService *SettingsService, containerService *ContainerService, imageService *ImageService, backupTargets *BackupTargetService, backupVolumeName string) *VolumeService {
 	slog.Debug("volume service: new")
 	if strings.TrimSpace(backupVolumeName) == "" {
 		backupVolumeName = "arcane-backups"
@@ -59,6 +66,7 @@ func NewVolumeService(db *database.DB, dockerService *DockerClientService, event
 		settingsService:  settingsService,
 		containerService: containerService,
 		imageService:     imageService,
+		backupTargets:    backupTargets,
 		backupVolumeName: backupVolumeName,
 		helperByVolume:   make(map[string]string),
 	}
@@ -159,6 +167,10 @@ func (s *VolumeService) DeleteVolume(ctx context.Context, name string, force boo
 		slog.WarnContext(ctx, "could not log volume deletion action", "volume", name, "error", logErr.Error())
 	}
 
+	if err := s.DeleteBackupPolicy(ctx, name); err != nil {
+		slog.WarnContext(ctx, "could not remove backup policy of deleted volume", "volume", name, "error", err.Error())
+	}
+
 	s.removeHelperEntry(name)
 	return nil
 }
@@ -221,7 +233,7 @@ func (s *VolumeService) PruneVolumesWithOptions(ctx context.Context, all bool) (
 func (s *VolumeService) ListDirectory(ctx context.Context, volumeName, dirPath string) ([]volumetypes.FileEntry, error) {
 	slog.DebugContext(ctx, "volume service: list directory", "volume", volumeName, "path", dirPath)
 
-	sanitizedPath, err := s.sanitizeBrowsePathInternal(dirPath)
+	sanitizedPath, err := sanitizeBrowsePathInternal(dirPath)
 	if err != nil {
 		return nil, fmt.Errorf("invalid path: %w", err)
 	}
@@ -304,7 +316,7 @@ func (s *VolumeService) ListDirectory(ctx context.Context, volumeName, dirPath s
 func (s *VolumeService) GetFileContent(ctx context.Context, volumeName, filePath string, maxBytes int64) ([]byte, string, error) {
 	slog.DebugContext(ctx, "volume service: get file content", "volume", volumeName, "path", filePath, "max_bytes", maxBytes)
 
-	sanitizedPath, err := s.sanitizeBrowsePathInternal(filePath)
+	sanitizedPath, err := sanitizeBrowsePathInternal(filePath)
 	if err != nil {
 		return nil, "", fmt.Errorf("invalid path: %w", err)
 	}
@@ -331,7 +343,7 @@ func (s *VolumeService) GetFileContent(ctx context.Context, volumeName, filePath
 func (s *VolumeService) DownloadFile(ctx context.Context, volumeName, filePath string) (io.ReadCloser, int64, error) {
 	slog.DebugContext(ctx, "volume service: download file", "volume", volumeName, "path", filePath)
 
-	sanitizedPath, err := s.sanitizeBrowsePathInternal(filePath)
+	sanitizedPath, err := sanitizeBrowsePathInternal(filePath)
 	if err != nil {
 		return nil, 0, fmt.Errorf("invalid path: %w", err)
 	}
@@ -722,7 +734,7 @@ func (s *VolumeService) execInContainerInternal(ctx context.Context, containerID
 func (s *VolumeService) DeleteFile(ctx context.Context, volumeName, filePath string, user *models.User) error {
 	slog.DebugContext(ctx, "volume service: delete file", "volume", volumeName, "path", filePath)
 
-	sanitizedPath, err := s.sanitizeBrowsePathInternal(filePath)
+	sanitizedPath, err := sanitizeBrowsePathInternal(filePath)
 	if err != nil {
 		return fmt.Errorf("invalid path: %w", err)
 	}
@@ -763,7 +775,7 @@ func (s *VolumeService) DeleteFile(ctx context.Context, volumeName, filePath str
 func (s *VolumeService) CreateDirectory(ctx context.Context, volumeName, dirPath string, user *models.User) error {
 	slog.DebugContext(ctx, "volume service: create directory", "volume", volumeName, "path", dirPath)
 
-	sanitizedPath, err := s.sanitizeBrowsePathInternal(dirPath)
+	sanitizedPath, err := sanitizeBrowsePathInternal(dirPath)
 	if err != nil {
 		return fmt.Errorf("invalid path: %w", err)
 	}
@@ -800,7 +812,7 @@ func (s *VolumeService) CreateDirectory(ctx context.Context, volumeName, dirPath
 func (s *VolumeService) UploadFile(ctx context.Context, volumeName, destPath string, content io.Reader, filename string, user *models.User) error {
 	slog.DebugContext(ctx, "volume service: upload file", "volume", volumeName, "dest_path", destPath, "filename", filename)
 
-	sanitizedPath, err := s.sanitizeBrowsePathInternal(destPath)
+	sanitizedPath, err := sanitizeBrowsePathInternal(destPath)
 	if err != nil {
 		return fmt.Errorf("invalid path: %w", err)
 	}
@@ -882,11 +894,36 @@ func (s *VolumeService) ensureBackupVolumeInternal(ctx context.Context) error {
 }
 
 func (s *VolumeService) CreateBackup(ctx context.Context, volumeName string, user models.User) (*models.VolumeBackup, error) {
-	slog.DebugContext(ctx, "volume service: create backup", "volume", volumeName, "user", user.ID)
+	return s.createBackupInternal(ctx, volumeName, BackupOptions{}, user, false)
+}
+
+// CreateBackupWithOptions backs up a volume to the backup target selected in opts,
+// encrypting the archive when requested. Empty options behave like CreateBackup.
+func (s *VolumeService) CreateBackupWithOptions(ctx context.Context, volumeName string, opts BackupOptions, user models.User) (*models.VolumeBackup, error) {
+	return s.createBackupInternal(ctx, volumeName, opts, user, false)
+}
+
+func (s *VolumeService) createBackupInternal(ctx context.Context, volumeName string, opts BackupOptions, user models.User, scheduled bool) (*models.VolumeBackup, error) {
+	targetID := opts.TargetID
+	if opts.Passphrase != "" {
+		opts.Encrypt = true
+	}
+	slog.DebugContext(ctx, "volume service: create backup", "volume", volumeName, "user", user.ID, "scheduled", scheduled, "target_id", targetID, "encrypted", opts.Encrypt)
 	if err := s.ensureBackupVolumeInternal(ctx); err != nil {
 		return nil, err
 	}
 
+	// Connect to the target before archiving so an unreachable target fails fast.
+	var target backuptarget.Target
+	if targetID != "" {
+		t, err := s.openBackupTargetInternal(ctx, targetID)
+		if err != nil {
+			return nil, err
+		}
+		target = t
+		defer func() { _ = target.Close() }()
+	}
+
 	dockerClient, err := s.dockerService.GetClient()
 	if err != nil {
 		return nil, err
@@ -895,11 +932,82 @@ func (s *VolumeService) CreateBackup(ctx context.Context, volumeName string, use
 	backupID := fmt.Sprintf("%s-%d-%s", volumeName, time.Now().UnixNano(), uuid.NewString()[:8])
 	filename := fmt.Sprintf("%s.tar.gz", backupID)
 
-	helperImage, err := s.getHelperImageInternal(ctx)
+	// Quiesce the containers using the volume; they are resumed and the post-backup hooks
+	// run right after the archive was written, also when archiving failed.
+	hooks, err := s.runPreBackupHooksInternal(ctx, volumeName, user)
+	if err != nil {
+		return nil, err
+	}
+	archiveErr := s.archiveVolumeInternal(ctx, dockerClient, volumeName, filename)
+	hookOutput := hooks.finish(ctx)
+	if archiveErr != nil {
+		return nil, archiveErr
+	}
+
+	tempContainerID, cleanup, err := s.createTempContainerInternal(ctx, s.backupVolumeName, true)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	sizeStr, _, err := s.execInContainerInternal(ctx, tempContainerID, []string{"stat", "-c", "%s", path.Join("/volume", filename)})
+	if err != nil {
+		return nil, err
+	}
+	size, err := strconv.ParseInt(strings.TrimSpace(sizeStr), 10, 64)
 	if err != nil {
 		return nil, err
 	}
 
+	backup := &models.VolumeBackup{
+		VolumeName: volumeName,
+		Size:       size,
+		Scheduled:  scheduled,
+		HookOutput: hookOutput,
+		CreatedAt:  time.Now(),
+	}
+	backup.ID = backupID
+	if target != nil {
+		backup.TargetID = &targetID
+	}
+
+	storeErr := s.storeBackupArchiveInternal(ctx, target, backup, size, opts)
+	// The local archive is only a staging copy once a target is used.
+	if target != nil || storeErr != nil {
+		s.removeLocalBackupFilesInternal(ctx, []string{filename})
+	}
+	if storeErr != nil {
+		return nil, storeErr
+	}
+
+	if err := s.db.WithContext(ctx).Create(backup).Error; err != nil {
+		return nil, err
+	}
+
+	metadata := models.JSON{
+		"action":     "backup_create",
+		"backup_id":  backup.ID,
+		"filename":   filename,
+		"size":       backup.Size,
+		"scheduled":  scheduled,
+		"target_id":  targetID,
+		"encryption": backup.Encryption,
+		"hooks":      hooks.ran(),
+	}
+	if logErr := s.eventService.LogVolumeEvent(ctx, models.EventTypeVolumeBackupCreate, volumeName, volumeName, user.ID, user.Username, "0", metadata); logErr != nil {
+		slog.WarnContext(ctx, "could not log volume backup create event", "volume", volumeName, "error", logErr.Error())
+	}
+
+	return backup, nil
+}
+
+// archiveVolumeInternal writes a gzipped tar of the volume to filename in the backup volume.
+func (s *VolumeService) archiveVolumeInternal(ctx context.Context, dockerClient *client.Client, volumeName, filename string) error {
+	helperImage, err := s.getHelperImageInternal(ctx)
+	if err != nil {
+		return err
+	}
+
 	config := &container.Config{
 		Image:  helperImage,
 		Cmd:    []string{"sh", "-c", fmt.Sprintf("tar -czf /backups/%s -C /volume .", filename)},
@@ -913,67 +1021,208 @@ func (s *VolumeService) CreateBackup(ctx context.Context, volumeName string, use
 
 	resp, err := dockerClient.ContainerCreate(ctx, config, hostConfig, nil, nil, "")
 	if err != nil {
-		return nil, fmt.Errorf("failed to create backup container: %w", err)
+		return fmt.Errorf("failed to create backup container: %w", err)
 	}
 
 	if err := dockerClient.ContainerStart(ctx, resp.ID, container.StartOptions{}); err != nil {
 		_ = dockerClient.ContainerRemove(ctx, resp.ID, volumeHelperRemoveOptionsInternal())
-		return nil, fmt.Errorf("failed to start backup container: %w", err)
+		return fmt.Errorf("failed to start backup container: %w", err)
 	}
 
 	statusCh, errCh := dockerClient.ContainerWait(ctx, resp.ID, container.WaitConditionNotRunning)
 	select {
 	case err := <-errCh:
 		if err != nil {
-			return nil, err
+			return err
 		}
 	case status := <-statusCh:
 		if status.StatusCode != 0 {
-			return nil, fmt.Errorf("backup container exited with status %d", status.StatusCode)
+			return fmt.Errorf("backup container exited with status %d", status.StatusCode)
 		}
 	}
 
-	tempContainerID, cleanup, err := s.createTempContainerInternal(ctx, s.backupVolumeName, true)
+	return nil
+}
+
+// CreateScheduledBackup backs up the policy's volume to the policy's target on behalf
+// of the scheduled backup job. Failures are recorded as volume.backup.failed events.
+func (s *VolumeService) CreateScheduledBackup(ctx context.Context, policy *models.VolumeBackupPolicy) (*models.VolumeBackup, error) {
+	volumeName := policy.VolumeName
+	opts, err := backupOptionsFromPolicyInternal(policy)
+	var backup *models.VolumeBackup
+	if err == nil {
+		backup, err = s.createBackupInternal(ctx, volumeName, opts, systemUser, true)
+	}
 	if err != nil {
+		metadata := models.JSON{
+			"action": "scheduled_backup",
+			"error":  err.Error(),
+		}
+		if logErr := s.eventService.LogVolumeEvent(ctx, models.EventTypeVolumeBackupFailed, volumeName, volumeName, systemUser.ID, systemUser.Username, "0", metadata); logErr != nil {
+			slog.WarnContext(ctx, "could not log volume backup failed event", "volume", volumeName, "error", logErr.Error())
+		}
 		return nil, err
 	}
-	defer cleanup()
+	return backup, nil
+}
 
-	sizeStr, _, err := s.execInContainerInternal(ctx, tempContainerID, []string{"stat", "-c", "%s", path.Join("/volume", filename)})
+// backupOptionsFromPolicyInternal returns the target and encryption settings of a policy,
+// decrypting its stored passphrase.
+func backupOptionsFromPolicyInternal(policy *models.VolumeBackupPolicy) (BackupOptions, error) {
+	opts := BackupOptions{Encrypt: policy.Encrypt}
+	if policy.TargetID != nil {
+		opts.TargetID = *policy.TargetID
+	}
+	if policy.Encrypt && policy.Passphrase != "" {
+		passphrase, err := crypto.Decrypt(policy.Passphrase)
+		if err != nil {
+			return BackupOptions{}, fmt.Errorf("failed to decrypt backup passphrase: %w", err)
+		}
+		opts.Passphrase = passphrase
+	}
+	return opts, nil
+}
+
+// GetBackupPolicy returns the scheduled backup policy of a volume. Volumes without a
+// stored policy get a disabled policy with no retention rules.
+func (s *VolumeService) GetBackupPolicy(ctx context.Context, volumeName string) (*models.VolumeBackupPolicy, error) {
+	var policy models.VolumeBackupPolicy
+	err := s.db.WithContext(ctx).Where("volume_name = ?", volumeName).First(&policy).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &models.VolumeBackupPolicy{VolumeName: volumeName}, nil
+	}
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to get backup policy: %w", err)
 	}
-	size, err := strconv.ParseInt(strings.TrimSpace(sizeStr), 10, 64)
+	return &policy, nil
+}
+
+// SaveBackupPolicy creates or replaces the scheduled backup policy of a volume.
+func (s *VolumeService) SaveBackupPolicy(ctx context.Context, volumeName string, req volumetypes.UpdateBackupPolicy) (*models.VolumeBackupPolicy, error) {
+	switch {
+	case req.KeepLast < 0:
+		return nil, &models.ValidationError{Field: "keepLast", Message: "retention counts must not be negative"}
+	case req.KeepDaily < 0:
+		return nil, &models.ValidationError{Field: "keepDaily", Message: "retention counts must not be negative"}
+	case req.KeepWeekly < 0:
+		return nil, &models.ValidationError{Field: "keepWeekly", Message: "retention counts must not be negative"}
+	}
+
+	policy, err := s.GetBackupPolicy(ctx, volumeName)
 	if err != nil {
 		return nil, err
 	}
+	policy.Enabled = req.Enabled
+	policy.KeepLast = req.KeepLast
+	policy.KeepDaily = req.KeepDaily
+	policy.KeepWeekly = req.KeepWeekly
+	policy.TargetID = nil
+	if targetID := strings.TrimSpace(req.TargetID); targetID != "" {
+		if _, err := s.getBackupTargetInternal(ctx, targetID); err != nil {
+			return nil, err
+		}
+		policy.TargetID = &targetID
+	}
+	policy.Encrypt = req.Encrypt
+	if req.Passphrase != nil {
+		policy.Passphrase = ""
+		if *req.Passphrase != "" {
+			encrypted, err := crypto.Encrypt(*req.Passphrase)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encrypt backup passphrase: %w", err)
+			}
+			policy.Passphrase = encrypted
+		}
+	}
+	policy.HasPassphrase = policy.Passphrase != ""
 
-	backup := &models.VolumeBackup{
-		VolumeName: volumeName,
-		Size:       size,
-		CreatedAt:  time.Now(),
+	if err := s.db.WithContext(ctx).Save(policy).Error; err != nil {
+		return nil, fmt.Errorf("failed to save backup policy: %w", err)
 	}
-	backup.ID = backupID
+	return policy, nil
+}
 
-	if err := s.db.WithContext(ctx).Create(backup).Error; err != nil {
-		return nil, err
+// DeleteBackupPolicy removes a volume from scheduled backups. Existing backups are kept.
+func (s *VolumeService) DeleteBackupPolicy(ctx context.Context, volumeName string) error {
+	if err := s.db.WithContext(ctx).Where("volume_name = ?", volumeName).Delete(&models.VolumeBackupPolicy{}).Error; err != nil {
+		return fmt.Errorf("failed to delete backup policy: %w", err)
+	}
+	return nil
+}
+
+// ListEnabledBackupPolicies returns the policies of all volumes selected for scheduled backups.
+func (s *VolumeService) ListEnabledBackupPolicies(ctx context.Context) ([]models.VolumeBackupPolicy, error) {
+	var policies []models.VolumeBackupPolicy
+	if err := s.db.WithContext(ctx).Where("enabled = ?", true).Order("volume_name ASC").Find(&policies).Error; err != nil {
+		return nil, fmt.Errorf("failed to list backup policies: %w", err)
 	}
+	return policies, nil
+}
+
+// RecordBackupPolicyRun stores the outcome of a scheduled backup run on the policy.
+func (s *VolumeService) RecordBackupPolicyRun(ctx context.Context, policy *models.VolumeBackupPolicy, runErr error) error {
+	now := time.Now()
+	var lastError *string
+	if runErr != nil {
+		lastError = new(runErr.Error())
+	}
+	policy.LastRunAt = &now
+	policy.LastError = lastError
+
+	return s.db.WithContext(ctx).
+		Model(&models.VolumeBackupPolicy{}).
+		Where("id = ?", policy.ID).
+		Updates(map[string]any{"last_run_at": now, "last_error": lastError}).Error
+}
+
+// PruneScheduledBackups deletes the scheduled backups of the policy's volume that fall
+// outside its retention rules and returns how many were removed. Manual backups are
+// never pruned. Days and weeks are evaluated in loc.
+func (s *VolumeService) PruneScheduledBackups(ctx context.Context, policy *models.VolumeBackupPolicy, loc *time.Location) (int, error) {
+	if !policy.HasRetention() {
+		return 0, nil
+	}
+
+	var backups []models.VolumeBackup
+	if err := s.db.WithContext(ctx).
+		Where("volume_name = ? AND scheduled = ?", policy.VolumeName, true).
+		Find(&backups).Error; err != nil {
+		return 0, fmt.Errorf("failed to list scheduled backups: %w", err)
+	}
+
+	prune := selectBackupsToPruneInternal(backups, policy, loc)
+	if len(prune) == 0 {
+		return 0, nil
+	}
+
+	ids := make([]string, 0, len(prune))
+	for _, b := range prune {
+		ids = append(ids, b.ID)
+	}
+
+	// Same ordering as DeleteBackup: rows first, then the archives (best effort).
+	if err := s.db.WithContext(ctx).Where("id IN ?", ids).Delete(&models.VolumeBackup{}).Error; err != nil {
+		return 0, fmt.Errorf("failed to delete pruned backups: %w", err)
+	}
+	s.removeBackupArchivesInternal(ctx, prune)
 
 	metadata := models.JSON{
-		"action":    "backup_create",
-		"backup_id": backup.ID,
-		"filename":  filename,
-		"size":      size,
+		"action":     "backup_prune",
+		"backup_ids": ids,
+		"keepLast":   policy.KeepLast,
+		"keepDaily":  policy.KeepDaily,
+		"keepWeekly": policy.KeepWeekly,
 	}
-	if logErr := s.eventService.LogVolumeEvent(ctx, models.EventTypeVolumeBackupCreate, volumeName, volumeName, user.ID, user.Username, "0", metadata); logErr != nil {
-		slog.WarnContext(ctx, "could not log volume backup create event", "volume", volumeName, "error", logErr.Error())
+	if logErr := s.eventService.LogVolumeEvent(ctx, models.EventTypeVolumeBackupPrune, policy.VolumeName, policy.VolumeName, systemUser.ID, systemUser.Username, "0", metadata); logErr != nil {
+		slog.WarnContext(ctx, "could not log volume backup prune event", "volume", policy.VolumeName, "error", logErr.Error())
 	}
 
-	return backup, nil
+	return len(ids), nil
 }
 
 func (s *VolumeService) ListBackupsPaginated(ctx context.Context, volumeName string, params pagination.QueryParams) ([]models.VolumeBackup, pagination.Response, error) {
 	slog.DebugContext(ctx, "volume service: list backups paginated", "volume", volumeName, "search", params.Search, "sort", params.Sort, "order", params.Order, "start", params.Start, "limit", params.Limit)
+
 	var backups []models.VolumeBackup
 	query := s.db.WithContext(ctx).Model(&models.VolumeBackup{}).Where("volume_name = ?", volumeName)
 
@@ -1043,6 +1292,7 @@ func (s *VolumeService) buildPaginationResponseFromCountsInternal(totalCount int
 
 func (s *VolumeService) ListBackups(ctx context.Context, volumeName string) ([]models.VolumeBackup, error) {
 	slog.DebugContext(ctx, "volume service: list backups", "volume", volumeName)
+
 	var backups []models.VolumeBackup
 	err := s.db.WithContext(ctx).Where("volume_name = ?", volumeName).Order("created_at DESC").Find(&backups).Error
 	return backups, err
@@ -1064,16 +1314,7 @@ func (s *VolumeService) DeleteBackup(ctx context.Context, backupID string, user
 	}
 
 	// Now delete the actual file - best effort since DB record is already gone
-	containerID, cleanup, err := s.createTempContainerInternal(ctx, s.backupVolumeName, false)
-	if err != nil {
-		slog.WarnContext(ctx, "failed to create container for backup file cleanup", "backup_id", backupID, "error", err.Error())
-	} else {
-		defer cleanup()
-		filename := fmt.Sprintf("%s.tar.gz", backupID)
-		if _, _, err = s.execInContainerInternal(ctx, containerID, []string{"rm", "-f", path.Join("/volume", filename)}); err != nil {
-			slog.WarnContext(ctx, "failed to delete backup file (orphan file may remain)", "backup_id", backupID, "error", err.Error())
-		}
-	}
+	s.removeBackupArchivesInternal(ctx, []models.VolumeBackup{backup})
 
 	actingUser := user
 	if actingUser == nil {
@@ -1090,7 +1331,7 @@ func (s *VolumeService) DeleteBackup(ctx context.Context, backupID string, user
 	return nil
 }
 
-func (s *VolumeService) RestoreBackup(ctx context.Context, volumeName, backupID string, user models.User) error {
+func (s *VolumeService) RestoreBackup(ctx context.Context, volumeName, backupID, passphrase string, user models.User) error {
 	slog.DebugContext(ctx, "volume service: restore backup", "volume", volumeName, "backup_id", backupID, "user", user.ID)
 	var backup models.VolumeBackup
 	if err := s.db.WithContext(ctx).Where("id = ?", backupID).First(&backup).Error; err != nil {
@@ -1110,6 +1351,13 @@ func (s *VolumeService) RestoreBackup(ctx context.Context, volumeName, backupID
 		return fmt.Errorf("volume is in use by %d container(s): restoring while containers are running may cause data corruption. Stop the containers first or use selective file restore", len(containerIDs))
 	}
 
+	// Verify the archive before touching the volume.
+	filename, unstage, err := s.stageBackupArchiveInternal(ctx, &backup, passphrase)
+	if err != nil {
+		return err
+	}
+	defer unstage()
+
 	preBackup, err := s.CreateBackup(ctx, volumeName, user)
 	if err != nil {
 		return fmt.Errorf("failed to create pre-restore backup: %w", err)
@@ -1120,8 +1368,6 @@ func (s *VolumeService) RestoreBackup(ctx context.Context, volumeName, backupID
 		return err
 	}
 
-	filename := fmt.Sprintf("%s.tar.gz", backupID)
-
 	helperImage, err := s.getHelperImageInternal(ctx)
 	if err != nil {
 		return err
@@ -1196,9 +1442,9 @@ func (s *VolumeService) sanitizeBackupPathInternal(input string) (string, error)
 	return cleaned, nil
 }
 
-// sanitizeBrowsePath validates and cleans a path for file browser operations.
-// It ensures the path stays within the volume boundary.
-func (s *VolumeService) sanitizeBrowsePathInternal(input string) (string, error) {
+// sanitizeBrowsePathInternal validates and cleans a path for file browser operations.
+// It ensures the path stays within the volume or container filesystem boundary.
+func sanitizeBrowsePathInternal(input string) (string, error) {
 	trimmed := strings.TrimSpace(input)
 	if trimmed == "" || trimmed == "/" {
 		return "/", nil // Root is valid for browse
@@ -1219,7 +1465,7 @@ func (s *VolumeService) sanitizeBrowsePathInternal(input string) (string, error)
 	return cleaned, nil
 }
 
-func (s *VolumeService) BackupHasPath(ctx context.Context, backupID string, filePath string) (bool, error) {
+func (s *VolumeService) BackupHasPath(ctx context.Context, backupID, filePath, passphrase string) (bool, error) {
 	slog.DebugContext(ctx, "volume service: backup has path", "backup_id", backupID, "path", filePath)
 	if err := s.ensureBackupVolumeInternal(ctx); err != nil {
 		return false, err
@@ -1235,13 +1481,19 @@ func (s *VolumeService) BackupHasPath(ctx context.Context, backupID string, file
 		return false, err
 	}
 
+	filename, unstage, err := s.stageBackupArchiveInternal(ctx, &backup, passphrase)
+	if err != nil {
+		return false, err
+	}
+	defer unstage()
+
 	containerID, cleanup, err := s.createTempContainerInternal(ctx, s.backupVolumeName, true)
 	if err != nil {
 		return false, err
 	}
 	defer cleanup()
 
-	archivePath := path.Join("/volume", fmt.Sprintf("%s.tar.gz", backupID))
+	archivePath := path.Join("/volume", filename)
 	cmd := []string{"tar", "-tzf", archivePath}
 	stdout, stderr, err := s.execInContainerInternal(ctx, containerID, cmd)
 	if err != nil {
@@ -1265,7 +1517,7 @@ func (s *VolumeService) BackupHasPath(ctx context.Context, backupID string, file
 	return false, nil
 }
 
-func (s *VolumeService) ListBackupFiles(ctx context.Context, backupID string) ([]string, error) {
+func (s *VolumeService) ListBackupFiles(ctx context.Context, backupID, passphrase string) ([]string, error) {
 	slog.DebugContext(ctx, "volume service: list backup files", "backup_id", backupID)
 	if err := s.ensureBackupVolumeInternal(ctx); err != nil {
 		return nil, err
@@ -1276,13 +1528,19 @@ func (s *VolumeService) ListBackupFiles(ctx context.Context, backupID string) ([
 		return nil, err
 	}
 
+	filename, unstage, err := s.stageBackupArchiveInternal(ctx, &backup, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer unstage()
+
 	containerID, cleanup, err := s.createTempContainerInternal(ctx, s.backupVolumeName, true)
 	if err != nil {
 		return nil, err
 	}
 	defer cleanup()
 
-	archivePath := path.Join("/volume", fmt.Sprintf("%s.tar.gz", backupID))
+	archivePath := path.Join("/volume", filename)
 	cmd := []string{"tar", "-tzf", archivePath}
 	stdout, _, err := s.execInContainerInternal(ctx, containerID, cmd)
 	if err != nil {
@@ -1311,7 +1569,7 @@ func (s *VolumeService) ListBackupFiles(ctx context.Context, backupID string) ([
 	return files, nil
 }
 
-func (s *VolumeService) RestoreBackupFiles(ctx context.Context, volumeName, backupID string, paths []string, user models.User) error {
+func (s *VolumeService) RestoreBackupFiles(ctx context.Context, volumeName, backupID string, paths []string, passphrase string, user models.User) error {
 	slog.DebugContext(ctx, "volume service: restore backup files", "volume", volumeName, "backup_id", backupID, "paths_count", len(paths), "user", user.ID)
 	if len(paths) == 0 {
 		return fmt.Errorf("no paths provided")
@@ -1325,6 +1583,13 @@ func (s *VolumeService) RestoreBackupFiles(ctx context.Context, volumeName, back
 		return fmt.Errorf("backup does not belong to volume")
 	}
 
+	// Verify the archive before touching the volume.
+	filename, unstage, err := s.stageBackupArchiveInternal(ctx, &backup, passphrase)
+	if err != nil {
+		return err
+	}
+	defer unstage()
+
 	// Create pre-restore backup for safety (consistent with RestoreBackup behavior)
 	preBackup, err := s.CreateBackup(ctx, volumeName, user)
 	if err != nil {
@@ -1386,7 +1651,6 @@ func (s *VolumeService) RestoreBackupFiles(ctx context.Context, volumeName, back
 	}
 	defer cleanup()
 
-	filename := fmt.Sprintf("%s.tar.gz", backupID)
 	cmd := append([]string{"tar", "-xzf", path.Join("/backups", filename), "-C", "/volume", "--"}, tarPaths...)
 	_, stderr, err := s.execInContainerInternal(ctx, resp.ID, cmd)
 	if err != nil {
@@ -1415,8 +1679,22 @@ func (s *VolumeService) RestoreBackupFiles(ctx context.Context, volumeName, back
 
 func (s *VolumeService) DownloadBackup(ctx context.Context, backupID string, user *models.User) (io.ReadCloser, int64, error) {
 	slog.DebugContext(ctx, "volume service: download backup", "backup_id", backupID)
-	filename := fmt.Sprintf("%s.tar.gz", backupID)
-	reader, size, err := s.DownloadFile(ctx, s.backupVolumeName, filename)
+	volumeName := ""
+	var backup models.VolumeBackup
+	if err := s.db.WithContext(ctx).Where("id = ?", backupID).First(&backup).Error; err == nil {
+		volumeName = backup.VolumeName
+	}
+
+	var (
+		reader io.ReadCloser
+		size   int64
+		err    error
+	)
+	if backup.TargetID != nil {
+		reader, size, err = s.openRemoteBackupInternal(ctx, &backup)
+	} else {
+		reader, size, err = s.DownloadFile(ctx, s.backupVolumeName, fmt.Sprintf("%s.tar.gz", backupID))
+	}
 	if err != nil {
 		return nil, 0, err
 	}
@@ -1425,11 +1703,6 @@ func (s *VolumeService) DownloadBackup(ctx context.Context, backupID string, use
 	if actingUser == nil {
 		actingUser = &systemUser
 	}
-	volumeName := ""
-	var backup models.VolumeBackup
-	if err := s.db.WithContext(ctx).Where("id = ?", backupID).First(&backup).Error; err == nil {
-		volumeName = backup.VolumeName
-	}
 	if volumeName != "" {
 		metadata := models.JSON{
 			"action":    "backup_download",
@@ -1444,7 +1717,11 @@ func (s *VolumeService) DownloadBackup(ctx context.Context, backupID string, use
 	return reader, size, nil
 }
 
-func (s *VolumeService) UploadAndRestore(ctx context.Context, volumeName string, archive io.Reader, filename string, user models.User) error {
+// UploadAndRestore replaces the contents of a volume with an uploaded backup archive.
+// The archive is verified against checksum, or against the checksum recorded for the
+// backup it was downloaded from when checksum is empty, and encrypted archives are
+// decrypted with passphrase (or the instance key) before anything is restored.
+func (s *VolumeService) UploadAndRestore(ctx context.Context, volumeName string, archive io.Reader, filename, checksum, passphrase string, user models.User) error {
 	slog.DebugContext(ctx, "volume service: upload and restore", "volume", volumeName, "filename", filename, "user", user.ID)
 
 	tmpFile, err := os.CreateTemp("", "arcane-restore-*.tar.gz")
@@ -1455,12 +1732,24 @@ func (s *VolumeService) UploadAndRestore(ctx context.Context, volumeName string,
 		_ = tmpFile.Close()
 		_ = os.Remove(tmpFile.Name()) //nolint:gosec // temp file path is generated by os.CreateTemp
 	}()
-	if _, err := io.Copy(tmpFile, archive); err != nil {
+	hasher := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmpFile, hasher), archive); err != nil {
 		return fmt.Errorf("failed to buffer upload: %w", err)
 	}
+	if checksum == "" {
+		checksum = s.recordedBackupChecksumInternal(ctx, filename)
+	}
+	if checksum != "" && !strings.EqualFold(strings.TrimSpace(checksum), hex.EncodeToString(hasher.Sum(nil))) {
+		return ErrBackupChecksumMismatch
+	}
 	if _, err := tmpFile.Seek(0, io.SeekStart); err != nil {
 		return fmt.Errorf("failed to read buffered upload: %w", err)
 	}
+	tmpFile, removeDecrypted, err := decryptUploadedBackupInternal(tmpFile, passphrase)
+	if err != nil {
+		return err
+	}
+	defer removeDecrypted()
 	gzr, err := gzip.NewReader(tmpFile)
 	if err != nil {
 		return fmt.Errorf("invalid archive: %w", err)
@@ -0,0 +1,110 @@
+package arcaneupdater
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+// DefaultHealthPollInterval is how often WaitForHealthy re-inspects the container.
+const DefaultHealthPollInterval = 2 * time.Second
+
+// ErrContainerUnhealthy is returned (wrapped) when an updated container fails its health gate.
+var ErrContainerUnhealthy = errors.New("container failed health check")
+
+// ContainerInspector is the subset of the Docker client needed to watch container health.
+type ContainerInspector interface {
+	ContainerInspect(ctx context.Context, containerID string) (container.InspectResponse, error)
+}
+
+// WaitForHealthy blocks until the container is considered healthy or the timeout elapses.
+//
+// Containers with a Docker healthcheck must reach the "healthy" state before the timeout.
+// Containers without one must stay running, without restarting, for the whole timeout
+// (which then acts as a grace period). A timeout of zero or less disables the check.
+func WaitForHealthy(ctx context.Context, inspector ContainerInspector, containerID string, timeout, interval time.Duration) error {
+	if timeout <= 0 {
+		return nil
+	}
+	if interval <= 0 {
+		interval = DefaultHealthPollInterval
+	}
+
+	deadline := time.Now().Add(timeout)
+	initialRestarts := -1
+
+	for {
+		inspect, err := inspector.ContainerInspect(ctx, containerID)
+		if err != nil {
+			return fmt.Errorf("inspect: %w", err)
+		}
+
+		if inspect.ContainerJSONBase == nil {
+			return fmt.Errorf("%w: container state unavailable", ErrContainerUnhealthy)
+		}
+
+		if initialRestarts < 0 {
+			initialRestarts = inspect.RestartCount
+		}
+
+		if reason := unhealthyReason(inspect, initialRestarts); reason != "" {
+			return fmt.Errorf("%w: %s", ErrContainerUnhealthy, reason)
+		}
+
+		hasHealthcheck := inspect.State != nil && inspect.State.Health != nil
+		if hasHealthcheck && inspect.State.Health.Status == container.Healthy {
+			slog.DebugContext(ctx, "WaitForHealthy: container reported healthy", "containerId", containerID)
+			return nil
+		}
+
+		if !time.Now().Before(deadline) {
+			if hasHealthcheck {
+				return fmt.Errorf("%w: healthcheck did not pass within %s (status: %s)", ErrContainerUnhealthy, timeout, inspect.State.Health.Status)
+			}
+			slog.DebugContext(ctx, "WaitForHealthy: container stayed up for grace period", "containerId", containerID, "grace", timeout)
+			return nil
+		}
+
+		wait := min(interval, time.Until(deadline))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// unhealthyReason returns a human readable reason when the inspected state is a definite failure.
+func unhealthyReason(inspect container.InspectResponse, initialRestarts int) string {
+	state := inspect.State
+	if state == nil {
+		return "container state unavailable"
+	}
+
+	if state.Health != nil && state.Health.Status == container.Unhealthy {
+		reason := "healthcheck reported unhealthy"
+		if n := len(state.Health.Log); n > 0 && state.Health.Log[n-1] != nil && state.Health.Log[n-1].Output != "" {
+			reason = fmt.Sprintf("%s: %s", reason, strings.TrimSpace(state.Health.Log[n-1].Output))
+		}
+		return reason
+	}
+
+	if state.OOMKilled {
+		return "container was killed (out of memory)"
+	}
+
+	if state.Restarting || inspect.RestartCount > initialRestarts {
+		return "container is restarting (crash loop)"
+	}
+
+	if !state.Running {
+		return fmt.Sprintf("container exited with code %d", state.ExitCode)
+	}
+
+	return ""
+}
@@ -0,0 +1,127 @@
+package arcaneupdater
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+// fakeInspector returns the queued responses in order, repeating the last one.
+type fakeInspector struct {
+	responses []container.InspectResponse
+	calls     int
+}
+
+func (f *fakeInspector) ContainerInspect(_ context.Context, _ string) (container.InspectResponse, error) {
+	idx := min(f.calls, len(f.responses)-1)
+	f.calls++
+	return f.responses[idx], nil
+}
+
+func inspectWithState(state *container.State, restarts int) container.InspectResponse {
+	return container.InspectResponse{
+		ContainerJSONBase: &container.ContainerJSONBase{State: state, RestartCount: restarts},
+	}
+}
+
+func TestWaitForHealthy(t *testing.T) {
+	running := &container.State{Running: true, Status: container.StateRunning}
+	starting := &container.State{Running: true, Health: &container.Health{Status: container.Starting}}
+	healthy := &container.State{Running: true, Health: &container.Health{Status: container.Healthy}}
+	unhealthy := &container.State{Running: true, Health: &container.Health{
+		Status: container.Unhealthy,
+		Log:    []*container.HealthcheckResult{{ExitCode: 1, Output: "connection refused\n"}},
+	}}
+	exited := &container.State{Running: false, ExitCode: 137}
+
+	tests := []struct {
+		name      string
+		responses []container.InspectResponse
+		timeout   time.Duration
+		wantErr   bool
+		errSubstr string
+	}{
+		{
+			name:      "disabled when timeout is zero",
+			responses: []container.InspectResponse{inspectWithState(exited, 0)},
+			timeout:   0,
+		},
+		{
+			name:      "healthcheck becomes healthy",
+			responses: []container.InspectResponse{inspectWithState(starting, 0), inspectWithState(healthy, 0)},
+			timeout:   time.Second,
+		},
+		{
+			name:      "healthcheck reports unhealthy",
+			responses: []container.InspectResponse{inspectWithState(starting, 0), inspectWithState(unhealthy, 0)},
+			timeout:   time.Second,
+			wantErr:   true,
+			errSubstr: "connection refused",
+		},
+		{
+			name:      "healthcheck still starting at deadline",
+			responses: []container.InspectResponse{inspectWithState(starting, 0)},
+			timeout:   20 * time.Millisecond,
+			wantErr:   true,
+			errSubstr: "did not pass",
+		},
+		{
+			name:      "no healthcheck stays running for grace period",
+			responses: []container.InspectResponse{inspectWithState(running, 0)},
+			timeout:   20 * time.Millisecond,
+		},
+		{
+			name:      "no healthcheck container exits",
+			responses: []container.InspectResponse{inspectWithState(running, 0), inspectWithState(exited, 0)},
+			timeout:   time.Second,
+			wantErr:   true,
+			errSubstr: "exited with code 137",
+		},
+		{
+			name:      "restart count increases",
+			responses: []container.InspectResponse{inspectWithState(running, 2), inspectWithState(running, 3)},
+			timeout:   time.Second,
+			wantErr:   true,
+			errSubstr: "crash loop",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inspector := &fakeInspector{responses: tt.responses}
+			err := WaitForHealthy(context.Background(), inspector, "abc", tt.timeout, 5*time.Millisecond)
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("WaitForHealthy() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("WaitForHealthy() expected error, got nil")
+			}
+			if !errors.Is(err, ErrContainerUnhealthy) {
+				t.Errorf("WaitForHealthy() error = %v, want wrapped ErrContainerUnhealthy", err)
+			}
+			if tt.errSubstr != "" && !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Errorf("WaitForHealthy() error = %q, want substring %q", err.Error(), tt.errSubstr)
+			}
+		})
+	}
+}
+
+func TestWaitForHealthy_ContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	inspector := &fakeInspector{responses: []container.InspectResponse{
+		inspectWithState(&container.State{Running: true}, 0),
+	}}
+	err := WaitForHealthy(ctx, inspector, "abc", time.Minute, time.Second)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("WaitForHealthy() error = %v, want context.Canceled", err)
+	}
+}
@@ -1,15 +1,25 @@
 package arcaneupdater
 
-import "strings"
+import (
+	"strconv"
+	"strings"
+	"time"
+)
 
 const (
 	// Core labels
 	LabelArcane  = "com.getarcaneapp.arcane"         // Identifies the Arcane container itself
 	LabelUpdater = "com.getarcaneapp.arcane.updater" // Enable/disable updates (true/false)
 
+	// Update policy label
+	LabelUpdatePolicy = "com.getarcaneapp.arcane.update-policy" // digest (default), patch, minor or major
+
 	// Dependency labels
 	LabelDependsOn  = "com.getarcaneapp.arcane.depends-on"  // Comma-separated list of container names this depends on
 	LabelStopSignal = "com.getarcaneapp.arcane.stop-signal" // Custom stop signal (e.g., SIGINT)
+
+	// Health gate labels
+	LabelHealthTimeout = "com.getarcaneapp.arcane.health-timeout" // Seconds to wait for the updated container to become healthy (0 disables)
 )
 
 // IsArcaneContainer checks if the container is the Arcane application itself
@@ -59,3 +69,31 @@ func GetStopSignal(labels map[string]string) string {
 	}
 	return ""
 }
+
+// GetHealthTimeout returns the health gate timeout configured by label.
+// The second return value is false when the label is absent or not a valid number of seconds.
+func GetHealthTimeout(labels map[string]string) (time.Duration, bool) {
+	if labels == nil {
+		return 0, false
+	}
+	for k, v := range labels {
+		if strings.EqualFold(k, LabelHealthTimeout) {
+			secs, err := strconv.Atoi(strings.TrimSpace(v))
+			if err != nil || secs < 0 {
+				return 0, false
+			}
+			return time.Duration(secs) * time.Second, true
+		}
+	}
+	return 0, false
+}
+
+// GetUpdatePolicy returns the update policy configured by label, defaulting to digest-only updates.
+func GetUpdatePolicy(labels map[string]string) UpdatePolicy {
+	for k, v := range labels {
+		if strings.EqualFold(k, LabelUpdatePolicy) {
+			return ParseUpdatePolicy(v)
+		}
+	}
+	return UpdatePolicyDigest
+}
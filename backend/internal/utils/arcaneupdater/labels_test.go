@@ -2,6 +2,7 @@ package arcaneupdater
 
 import (
 	"testing"
+	"time"
 )
 
 func TestIsArcaneContainer(t *testing.T) {
@@ -204,3 +205,79 @@ func TestGetStopSignal(t *testing.T) {
 		})
 	}
 }
+
+func TestGetHealthTimeout(t *testing.T) {
+	tests := []struct {
+		name   string
+		labels map[string]string
+		want   time.Duration
+		wantOK bool
+	}{
+		{
+			name:   "nil labels",
+			labels: nil,
+		},
+		{
+			name:   "no health timeout label",
+			labels: map[string]string{"other": "value"},
+		},
+		{
+			name:   "seconds",
+			labels: map[string]string{LabelHealthTimeout: "90"},
+			want:   90 * time.Second,
+			wantOK: true,
+		},
+		{
+			name:   "zero disables",
+			labels: map[string]string{LabelHealthTimeout: "0"},
+			want:   0,
+			wantOK: true,
+		},
+		{
+			name:   "invalid value",
+			labels: map[string]string{LabelHealthTimeout: "soon"},
+		},
+		{
+			name:   "negative value",
+			labels: map[string]string{LabelHealthTimeout: "-5"},
+		},
+		{
+			name:   "case insensitive label key with whitespace",
+			labels: map[string]string{"COM.GETARCANEAPP.ARCANE.HEALTH-TIMEOUT": " 15 "},
+			want:   15 * time.Second,
+			wantOK: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := GetHealthTimeout(tt.labels)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("GetHealthTimeout() = (%v, %v), want (%v, %v)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestGetUpdatePolicy(t *testing.T) {
+	tests := []struct {
+		name   string
+		labels map[string]string
+		want   UpdatePolicy
+	}{
+		{name: "nil labels", labels: nil, want: UpdatePolicyDigest},
+		{name: "no policy label", labels: map[string]string{"other": "value"}, want: UpdatePolicyDigest},
+		{name: "minor", labels: map[string]string{LabelUpdatePolicy: "minor"}, want: UpdatePolicyMinor},
+		{name: "case insensitive value", labels: map[string]string{LabelUpdatePolicy: " Major "}, want: UpdatePolicyMajor},
+		{name: "unknown value", labels: map[string]string{LabelUpdatePolicy: "latest"}, want: UpdatePolicyDigest},
+		{name: "case insensitive label key", labels: map[string]string{"COM.GETARCANEAPP.ARCANE.UPDATE-POLICY": "patch"}, want: UpdatePolicyPatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetUpdatePolicy(tt.labels); got != tt.want {
+				t.Errorf("GetUpdatePolicy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package arcaneupdater
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// UpdatePolicy controls which kind of image update is reported for a container.
+type UpdatePolicy string
+
+const (
+	// UpdatePolicyDigest only detects re-pushes of the same tag (default).
+	UpdatePolicyDigest UpdatePolicy = "digest"
+	// UpdatePolicyPatch also follows newer patch versions (16.2.1 -> 16.2.4).
+	UpdatePolicyPatch UpdatePolicy = "patch"
+	// UpdatePolicyMinor also follows newer minor versions (16.2 -> 16.5).
+	UpdatePolicyMinor UpdatePolicy = "minor"
+	// UpdatePolicyMajor follows any newer version (16.2 -> 17.0).
+	UpdatePolicyMajor UpdatePolicy = "major"
+)
+
+// policyRank orders policies from most to least restrictive.
+var policyRank = map[UpdatePolicy]int{
+	UpdatePolicyDigest: 0,
+	UpdatePolicyPatch:  1,
+	UpdatePolicyMinor:  2,
+	UpdatePolicyMajor:  3,
+}
+
+// ParseUpdatePolicy converts a label value into an UpdatePolicy. Unknown values map to digest.
+func ParseUpdatePolicy(value string) UpdatePolicy {
+	p := UpdatePolicy(strings.TrimSpace(strings.ToLower(value)))
+	if _, ok := policyRank[p]; ok {
+		return p
+	}
+	return UpdatePolicyDigest
+}
+
+// NarrowestPolicy returns the more restrictive of the two policies.
+func NarrowestPolicy(a, b UpdatePolicy) UpdatePolicy {
+	if policyRank[ParseUpdatePolicy(string(b))] < policyRank[ParseUpdatePolicy(string(a))] {
+		return ParseUpdatePolicy(string(b))
+	}
+	return ParseUpdatePolicy(string(a))
+}
+
+// AllowsTagUpdates reports whether the policy follows version tags at all.
+func (p UpdatePolicy) AllowsTagUpdates() bool {
+	return policyRank[p] > policyRank[UpdatePolicyDigest]
+}
+
+// versionTag is a tag split into an optional "v" prefix, numeric components and a suffix
+// such as "-alpine". Only tags with the same prefix, component count and suffix are compared,
+// so "16.2-alpine" never jumps to "17" or "16.3-bookworm".
+type versionTag struct {
+	raw    string
+	prefix string
+	parts  []int
+	suffix string
+}
+
+var versionTagRe = regexp.MustCompile(`^(v?)(\d+)(?:\.(\d+))?(?:\.(\d+))?([-+_].*)?$`)
+
+func parseVersionTag(tag string) (versionTag, bool) {
+	m := versionTagRe.FindStringSubmatch(tag)
+	if m == nil {
+		return versionTag{}, false
+	}
+
+	vt := versionTag{raw: tag, prefix: m[1], suffix: m[5]}
+	for _, s := range m[2:5] {
+		if s == "" {
+			break
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return versionTag{}, false
+		}
+		vt.parts = append(vt.parts, n)
+	}
+	return vt, true
+}
+
+func (v versionTag) sameShape(o versionTag) bool {
+	return v.prefix == o.prefix && v.suffix == o.suffix && len(v.parts) == len(o.parts)
+}
+
+// compare returns -1, 0 or 1 like strings.Compare. Both tags must have the same shape.
+func (v versionTag) compare(o versionTag) int {
+	for i := range v.parts {
+		switch {
+		case v.parts[i] < o.parts[i]:
+			return -1
+		case v.parts[i] > o.parts[i]:
+			return 1
+		}
+	}
+	return 0
+}
+
+// bump returns the semver level ("major" | "minor" | "patch") at which o differs from v.
+func (v versionTag) bump(o versionTag) UpdatePolicy {
+	switch {
+	case v.parts[0] != o.parts[0]:
+		return UpdatePolicyMajor
+	case len(v.parts) > 1 && v.parts[1] != o.parts[1]:
+		return UpdatePolicyMinor
+	default:
+		return UpdatePolicyPatch
+	}
+}
+
+// IsVersionTag reports whether the tag looks like a semantic version (e.g. "16.2", "v1.2.3", "7-alpine").
+func IsVersionTag(tag string) bool {
+	_, ok := parseVersionTag(tag)
+	return ok
+}
+
+// FindNewerTag returns the newest tag from candidates that is newer than current and allowed
+// by policy, together with the semver level of the change. ok is false when there is none.
+func FindNewerTag(current string, candidates []string, policy UpdatePolicy) (tag string, bump UpdatePolicy, ok bool) {
+	policy = ParseUpdatePolicy(string(policy))
+	if !policy.AllowsTagUpdates() {
+		return "", "", false
+	}
+
+	cur, valid := parseVersionTag(current)
+	if !valid {
+		return "", "", false
+	}
+
+	var best versionTag
+	found := false
+	for _, c := range candidates {
+		vt, valid := parseVersionTag(c)
+		if !valid || !cur.sameShape(vt) || vt.compare(cur) <= 0 {
+			continue
+		}
+		if policyRank[cur.bump(vt)] > policyRank[policy] {
+			continue
+		}
+		if !found || vt.compare(best) > 0 {
+			best = vt
+			found = true
+		}
+	}
+
+	if !found {
+		return "", "", false
+	}
+	return best.raw, cur.bump(best), true
+}
@@ -0,0 +1,79 @@
+package arcaneupdater
+
+import "testing"
+
+func TestFindNewerTag(t *testing.T) {
+	postgres := []string{"15", "15.6", "16", "16.1", "16.2", "16.5", "16.5-alpine", "17.0", "17.2", "latest", "bookworm"}
+
+	tests := []struct {
+		name     string
+		current  string
+		tags     []string
+		policy   UpdatePolicy
+		wantTag  string
+		wantBump UpdatePolicy
+		wantOK   bool
+	}{
+		{name: "digest policy never follows tags", current: "16.2", tags: postgres, policy: UpdatePolicyDigest},
+		{name: "minor stays within major", current: "16.2", tags: postgres, policy: UpdatePolicyMinor, wantTag: "16.5", wantBump: UpdatePolicyMinor, wantOK: true},
+		{name: "major follows newest", current: "16.2", tags: postgres, policy: UpdatePolicyMajor, wantTag: "17.2", wantBump: UpdatePolicyMajor, wantOK: true},
+		{name: "patch rejects minor bump", current: "16.2", tags: postgres, policy: UpdatePolicyPatch},
+		{
+			name:     "patch follows patch releases",
+			current:  "1.25.1",
+			tags:     []string{"1.25.0", "1.25.3", "1.25.10", "1.26.0", "2.0.0"},
+			policy:   UpdatePolicyPatch,
+			wantTag:  "1.25.10",
+			wantBump: UpdatePolicyPatch,
+			wantOK:   true,
+		},
+		{
+			name:     "suffix must match",
+			current:  "16.2-alpine",
+			tags:     []string{"16.5", "16.4-alpine", "16.6-bookworm"},
+			policy:   UpdatePolicyMinor,
+			wantTag:  "16.4-alpine",
+			wantBump: UpdatePolicyMinor,
+			wantOK:   true,
+		},
+		{
+			name:     "v prefix must match",
+			current:  "v1.2.0",
+			tags:     []string{"1.9.0", "v1.3.0", "v1.2.5"},
+			policy:   UpdatePolicyMinor,
+			wantTag:  "v1.3.0",
+			wantBump: UpdatePolicyMinor,
+			wantOK:   true,
+		},
+		{name: "single component tag only compares with single component", current: "16", tags: postgres, policy: UpdatePolicyMajor, wantTag: "", wantOK: false},
+		{name: "non version current tag", current: "latest", tags: postgres, policy: UpdatePolicyMajor},
+		{name: "already newest", current: "17.2", tags: postgres, policy: UpdatePolicyMajor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tag, bump, ok := FindNewerTag(tt.current, tt.tags, tt.policy)
+			if tag != tt.wantTag || bump != tt.wantBump || ok != tt.wantOK {
+				t.Errorf("FindNewerTag() = (%q, %q, %v), want (%q, %q, %v)", tag, bump, ok, tt.wantTag, tt.wantBump, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestNarrowestPolicy(t *testing.T) {
+	tests := []struct {
+		a, b UpdatePolicy
+		want UpdatePolicy
+	}{
+		{UpdatePolicyMajor, UpdatePolicyMinor, UpdatePolicyMinor},
+		{UpdatePolicyPatch, UpdatePolicyMajor, UpdatePolicyPatch},
+		{UpdatePolicyMinor, UpdatePolicyDigest, UpdatePolicyDigest},
+		{UpdatePolicyMajor, "bogus", UpdatePolicyDigest},
+	}
+
+	for _, tt := range tests {
+		if got := NarrowestPolicy(tt.a, tt.b); got != tt.want {
+			t.Errorf("NarrowestPolicy(%q, %q) = %q, want %q", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+// Package asciicast writes terminal sessions in the asciicast v2 format used by
+// asciinema (https://docs.asciinema.org/manual/asciicast/v2/).
+package asciicast
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Event codes of the asciicast v2 format.
+const (
+	EventOutput = "o"
+	EventInput  = "i"
+	EventResize = "r"
+)
+
+// Header is the first line of an asciicast v2 recording.
+type Header struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp,omitempty"`
+	Command   string            `json:"command,omitempty"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// Writer records the events of a terminal session. It is safe for concurrent use, so
+// input and output may be recorded from different goroutines.
+type Writer struct {
+	mu      sync.Mutex
+	w       *bufio.Writer
+	start   time.Time
+	now     func() time.Time
+	pending map[string][]byte
+	err     error
+}
+
+// NewWriter writes the header to w and returns a Writer for the events that follow.
+// A zero header timestamp is set to the current time.
+func NewWriter(w io.Writer, header Header) (*Writer, error) {
+	return newWriterInternal(w, header, time.Now)
+}
+
+func newWriterInternal(w io.Writer, header Header, now func() time.Time) (*Writer, error) {
+	start := now()
+	header.Version = 2
+	if header.Timestamp == 0 {
+		header.Timestamp = start.Unix()
+	}
+
+	bw := bufio.NewWriter(w)
+	line, err := json.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := bw.Write(append(line, '\n')); err != nil {
+		return nil, err
+	}
+	if err := bw.Flush(); err != nil {
+		return nil, err
+	}
+
+	return &Writer{w: bw, start: start, now: now, pending: map[string][]byte{}}, nil
+}
+
+// Output records data written to the terminal.
+func (w *Writer) Output(data []byte) error {
+	return w.writeEventInternal(EventOutput, data, false)
+}
+
+// Input records data typed into the terminal.
+func (w *Writer) Input(data []byte) error {
+	return w.writeEventInternal(EventInput, data, false)
+}
+
+// Resize records a change of the terminal size.
+func (w *Writer) Resize(width, height int) error {
+	return w.writeEventInternal(EventResize, fmt.Appendf(nil, "%dx%d", width, height), true)
+}
+
+// writeEventInternal writes one event line. A multi-byte UTF-8 sequence split across
+// two writes of the same stream is held back until it is complete, as the event data
+// must be valid UTF-8, unless final is set.
+func (w *Writer) writeEventInternal(code string, data []byte, final bool) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.err != nil {
+		return w.err
+	}
+
+	if pending := w.pending[code]; len(pending) > 0 {
+		data = append(pending, data...)
+		delete(w.pending, code)
+	}
+	if cut := incompleteSuffixInternal(data); cut > 0 && !final {
+		w.pending[code] = append([]byte(nil), data[len(data)-cut:]...)
+		data = data[:len(data)-cut]
+	}
+	if len(data) == 0 {
+		return nil
+	}
+
+	elapsed := w.now().Sub(w.start).Seconds()
+	line, err := json.Marshal([]any{json.Number(fmt.Sprintf("%.6f", elapsed)), code, string(data)})
+	if err != nil {
+		w.err = err
+		return err
+	}
+	if _, err := w.w.Write(append(line, '\n')); err != nil {
+		w.err = err
+		return err
+	}
+	if err := w.w.Flush(); err != nil {
+		w.err = err
+		return err
+	}
+	return nil
+}
+
+// Close writes any held back bytes and flushes the recording.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	pending := w.pending
+	w.pending = map[string][]byte{}
+	w.mu.Unlock()
+
+	for _, code := range []string{EventInput, EventOutput} {
+		if data := pending[code]; len(data) > 0 {
+			if err := w.writeEventInternal(code, data, true); err != nil {
+				return err
+			}
+		}
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.err != nil {
+		return w.err
+	}
+	return w.w.Flush()
+}
+
+// incompleteSuffixInternal returns the length of an incomplete UTF-8 sequence at the
+// end of data, or 0 if data ends on a rune boundary or with invalid bytes.
+func incompleteSuffixInternal(data []byte) int {
+	for i := 1; i <= utf8.UTFMax-1 && i <= len(data); i++ {
+		b := data[len(data)-i]
+		if !utf8.RuneStart(b) {
+			continue
+		}
+		if utf8.FullRune(data[len(data)-i:]) {
+			return 0
+		}
+		return i
+	}
+	return 0
+}
@@ -0,0 +1,58 @@
+package asciicast
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriter(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	clock := start
+	var buf bytes.Buffer
+
+	w, err := newWriterInternal(&buf, Header{Width: 120, Height: 40, Command: "/bin/sh"}, func() time.Time { return clock })
+	require.NoError(t, err)
+
+	clock = start.Add(500 * time.Millisecond)
+	require.NoError(t, w.Output([]byte("$ ")))
+	clock = start.Add(1250 * time.Millisecond)
+	require.NoError(t, w.Input([]byte("ls\r")))
+	require.NoError(t, w.Resize(100, 30))
+	require.NoError(t, w.Close())
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 4)
+
+	var header Header
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &header))
+	assert.Equal(t, Header{Version: 2, Width: 120, Height: 40, Timestamp: 1700000000, Command: "/bin/sh"}, header)
+	assert.Equal(t, `[0.500000,"o","$ "]`, lines[1])
+	assert.Equal(t, `[1.250000,"i","ls\r"]`, lines[2])
+	assert.Equal(t, `[1.250000,"r","100x30"]`, lines[3])
+}
+
+func TestWriter_SplitRune(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, Header{Width: 80, Height: 24})
+	require.NoError(t, err)
+
+	euro := []byte("€")
+	require.NoError(t, w.Output(append([]byte("a"), euro[:2]...)))
+	require.NoError(t, w.Output(append(euro[2:], 'b')))
+	require.NoError(t, w.Output(euro[:1]))
+	require.NoError(t, w.Close())
+
+	var events []string
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n")[1:] {
+		var event []any
+		require.NoError(t, json.Unmarshal([]byte(line), &event))
+		events = append(events, event[2].(string))
+	}
+	assert.Equal(t, []string{"a", "€b", "�"}, events)
+}
@@ -0,0 +1,150 @@
+package backuptarget
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// PathConfig configures a target that writes to a directory on the Arcane host. When
+// Arcane runs in a container the directory must be mounted into it.
+type PathConfig struct {
+	Path string `json:"path"`
+}
+
+// Validate checks that cfg names an absolute directory.
+func (cfg PathConfig) Validate() error {
+	root := strings.TrimSpace(cfg.Path)
+	if root == "" || !filepath.IsAbs(root) {
+		return fmt.Errorf("path target requires an absolute directory")
+	}
+	return nil
+}
+
+type pathTarget struct {
+	root string
+}
+
+// NewPath returns a target storing archives below cfg.Path. The directory is created when missing.
+func NewPath(cfg PathConfig) (Target, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	root := filepath.Clean(strings.TrimSpace(cfg.Path))
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create backup directory: %w", err)
+	}
+	return &pathTarget{root: root}, nil
+}
+
+func (t *pathTarget) resolve(name string) (string, error) {
+	cleaned, err := cleanName(name)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(t.root, filepath.FromSlash(cleaned)), nil
+}
+
+func (t *pathTarget) Put(ctx context.Context, name string, r io.Reader, size int64) error {
+	dest, err := t.resolve(name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0o750); err != nil {
+		return fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	// Write to a temp file first so a failed upload never leaves a truncated archive behind.
+	tmp, err := os.CreateTemp(filepath.Dir(dest), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create backup file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	written, err := io.Copy(tmp, contextReader{ctx: ctx, r: r})
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write backup file: %w", err)
+	}
+	if size >= 0 && written != size {
+		return fmt.Errorf("failed to write backup file: wrote %d of %d bytes", written, size)
+	}
+
+	if err := os.Rename(tmp.Name(), dest); err != nil {
+		return fmt.Errorf("failed to store backup file: %w", err)
+	}
+	return nil
+}
+
+func (t *pathTarget) Get(_ context.Context, name string) (io.ReadCloser, int64, error) {
+	src, err := t.resolve(name)
+	if err != nil {
+		return nil, 0, err
+	}
+	f, err := os.Open(src)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, 0, ErrNotFound
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to open backup file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, 0, fmt.Errorf("failed to stat backup file: %w", err)
+	}
+	return f, info.Size(), nil
+}
+
+func (t *pathTarget) Delete(_ context.Context, name string) error {
+	target, err := t.resolve(name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(target); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete backup file: %w", err)
+	}
+	return nil
+}
+
+func (t *pathTarget) List(_ context.Context, dir string) ([]Object, error) {
+	cleaned, err := cleanDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(filepath.Join(t.root, filepath.FromSlash(cleaned)))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list backup directory: %w", err)
+	}
+
+	objects := make([]Object, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		objects = append(objects, Object{
+			Name:    joinName(cleaned, entry.Name()),
+			Size:    info.Size(),
+			ModTime: info.ModTime(),
+		})
+	}
+	return objects, nil
+}
+
+func (t *pathTarget) Close() error {
+	return nil
+}
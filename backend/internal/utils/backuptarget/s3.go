@@ -0,0 +1,473 @@
+package backuptarget
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// S3Config configures an S3-compatible object storage target (AWS S3, MinIO, Backblaze B2,
+// Cloudflare R2, ...).
+type S3Config struct {
+	// Endpoint is the service URL, e.g. "https://s3.eu-central-1.amazonaws.com" or "http://minio:9000".
+	Endpoint        string `json:"endpoint"`
+	Region          string `json:"region,omitempty"`
+	Bucket          string `json:"bucket"`
+	Prefix          string `json:"prefix,omitempty"`
+	AccessKeyID     string `json:"accessKeyId"`
+	SecretAccessKey string `json:"secretAccessKey"`
+	// PathStyle addresses the bucket as endpoint/bucket instead of bucket.endpoint.
+	// Most self-hosted services (MinIO, Garage, ...) need path-style requests.
+	PathStyle bool `json:"pathStyle,omitempty"`
+}
+
+// Validate checks that cfg has everything needed to reach the bucket.
+func (cfg S3Config) Validate() error {
+	if strings.TrimSpace(cfg.Bucket) == "" {
+		return fmt.Errorf("s3 target requires a bucket")
+	}
+	if cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return fmt.Errorf("s3 target requires an access key and secret")
+	}
+	endpoint, err := url.Parse(strings.TrimSpace(cfg.Endpoint))
+	if err != nil || endpoint.Host == "" || (endpoint.Scheme != "http" && endpoint.Scheme != "https") {
+		return fmt.Errorf("s3 target requires an http(s) endpoint URL")
+	}
+	return nil
+}
+
+const (
+	s3DefaultRegion = "us-east-1"
+	s3UnsignedBody  = "UNSIGNED-PAYLOAD"
+	s3EmptyBodyHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+	// s3MultipartThreshold is the object size above which uploads are split into parts.
+	s3MultipartThreshold = 64 << 20
+	s3MinPartSize        = 16 << 20
+	s3MaxParts           = 10000
+)
+
+type s3Target struct {
+	cfg      S3Config
+	endpoint *url.URL
+	prefix   string
+	http     *http.Client
+	now      func() time.Time
+
+	multipartThreshold int64
+	minPartSize        int64
+}
+
+// NewS3 returns a target storing archives in an S3-compatible bucket. Requests are signed
+// with AWS Signature Version 4.
+func NewS3(cfg S3Config, httpClient *http.Client) (Target, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	endpoint, err := url.Parse(strings.TrimSpace(cfg.Endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("s3 target requires an http(s) endpoint URL")
+	}
+	if cfg.Region == "" {
+		cfg.Region = s3DefaultRegion
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+
+	return &s3Target{
+		cfg:      cfg,
+		endpoint: endpoint,
+		prefix:   strings.Trim(cfg.Prefix, "/"),
+		http:     httpClient,
+		now:      time.Now,
+
+		multipartThreshold: s3MultipartThreshold,
+		minPartSize:        s3MinPartSize,
+	}, nil
+}
+
+func (t *s3Target) key(name string) string {
+	if t.prefix == "" {
+		return name
+	}
+	return t.prefix + "/" + name
+}
+
+// objectURL returns the URL of key (or of the bucket when key is empty).
+func (t *s3Target) objectURL(key string, query url.Values) *url.URL {
+	u := *t.endpoint
+	basePath := strings.TrimSuffix(u.Path, "/")
+	switch {
+	case t.cfg.PathStyle && key == "":
+		u.Path = basePath + "/" + t.cfg.Bucket
+	case t.cfg.PathStyle:
+		u.Path = basePath + "/" + t.cfg.Bucket + "/" + key
+	default:
+		u.Host = t.cfg.Bucket + "." + u.Host
+		u.Path = basePath + "/" + key
+	}
+	// Keep the wire encoding identical to the one that is signed.
+	u.RawPath = s3EscapePath(u.Path)
+	u.RawQuery = ""
+	if len(query) > 0 {
+		u.RawQuery = canonicalQuery(query)
+	}
+	return &u
+}
+
+func (t *s3Target) Put(ctx context.Context, name string, r io.Reader, size int64) error {
+	cleaned, err := cleanName(name)
+	if err != nil {
+		return err
+	}
+	key := t.key(cleaned)
+
+	if size <= t.multipartThreshold {
+		resp, err := t.do(ctx, http.MethodPut, key, nil, r, size, s3UnsignedBody)
+		if err != nil {
+			return fmt.Errorf("s3 upload failed: %w", err)
+		}
+		_ = resp.Body.Close()
+		return nil
+	}
+	return t.putMultipart(ctx, key, r, size)
+}
+
+type s3InitiateMultipartResult struct {
+	UploadID string `xml:"UploadId"`
+}
+
+type s3CompletePart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+type s3CompleteMultipartUpload struct {
+	XMLName xml.Name         `xml:"CompleteMultipartUpload"`
+	Parts   []s3CompletePart `xml:"Part"`
+}
+
+// putMultipart uploads large archives in parts so objects above the 5 GiB single-PUT limit
+// work and memory use stays bounded by one part.
+func (t *s3Target) putMultipart(ctx context.Context, key string, r io.Reader, size int64) (err error) {
+	resp, err := t.do(ctx, http.MethodPost, key, url.Values{"uploads": {""}}, nil, 0, s3EmptyBodyHash)
+	if err != nil {
+		return fmt.Errorf("s3 multipart upload failed to start: %w", err)
+	}
+	var initiated s3InitiateMultipartResult
+	decodeErr := xml.NewDecoder(resp.Body).Decode(&initiated)
+	_ = resp.Body.Close()
+	if decodeErr != nil || initiated.UploadID == "" {
+		return fmt.Errorf("s3 multipart upload failed to start: invalid response")
+	}
+	uploadID := initiated.UploadID
+
+	defer func() {
+		if err == nil {
+			return
+		}
+		// Abort so the partial upload does not keep consuming storage.
+		abortResp, abortErr := t.do(context.WithoutCancel(ctx), http.MethodDelete, key, url.Values{"uploadId": {uploadID}}, nil, 0, s3EmptyBodyHash)
+		if abortErr == nil {
+			_ = abortResp.Body.Close()
+		}
+	}()
+
+	partSize := max(t.minPartSize, (size+s3MaxParts-1)/s3MaxParts)
+	buf := make([]byte, partSize)
+	var parts []s3CompletePart
+	for partNumber, remaining := 1, size; remaining > 0; partNumber++ {
+		n := min(partSize, remaining)
+		if _, err := io.ReadFull(r, buf[:n]); err != nil {
+			return fmt.Errorf("s3 multipart upload failed to read part %d: %w", partNumber, err)
+		}
+		sum := sha256.Sum256(buf[:n])
+		query := url.Values{"partNumber": {strconv.Itoa(partNumber)}, "uploadId": {uploadID}}
+		partResp, err := t.do(ctx, http.MethodPut, key, query, bytes.NewReader(buf[:n]), n, hex.EncodeToString(sum[:]))
+		if err != nil {
+			return fmt.Errorf("s3 multipart upload failed for part %d: %w", partNumber, err)
+		}
+		_ = partResp.Body.Close()
+		parts = append(parts, s3CompletePart{PartNumber: partNumber, ETag: partResp.Header.Get("ETag")})
+		remaining -= n
+	}
+
+	body, err := xml.Marshal(s3CompleteMultipartUpload{Parts: parts})
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(body)
+	completeResp, err := t.do(ctx, http.MethodPost, key, url.Values{"uploadId": {uploadID}}, bytes.NewReader(body), int64(len(body)), hex.EncodeToString(sum[:]))
+	if err != nil {
+		return fmt.Errorf("s3 multipart upload failed to complete: %w", err)
+	}
+	// S3 may report a failed completion with a 200 status and an <Error> body.
+	respBody, _ := io.ReadAll(io.LimitReader(completeResp.Body, 64<<10))
+	_ = completeResp.Body.Close()
+	if bytes.Contains(respBody, []byte("<Error>")) {
+		return fmt.Errorf("s3 multipart upload failed to complete: %s", s3ErrorMessage(respBody))
+	}
+	return nil
+}
+
+func (t *s3Target) Get(ctx context.Context, name string) (io.ReadCloser, int64, error) {
+	cleaned, err := cleanName(name)
+	if err != nil {
+		return nil, 0, err
+	}
+	resp, err := t.do(ctx, http.MethodGet, t.key(cleaned), nil, nil, 0, s3EmptyBodyHash)
+	if err != nil {
+		if isS3NotFound(err) {
+			return nil, 0, ErrNotFound
+		}
+		return nil, 0, fmt.Errorf("s3 download failed: %w", err)
+	}
+	return resp.Body, resp.ContentLength, nil
+}
+
+func (t *s3Target) Delete(ctx context.Context, name string) error {
+	cleaned, err := cleanName(name)
+	if err != nil {
+		return err
+	}
+	resp, err := t.do(ctx, http.MethodDelete, t.key(cleaned), nil, nil, 0, s3EmptyBodyHash)
+	if err != nil {
+		if isS3NotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("s3 delete failed: %w", err)
+	}
+	_ = resp.Body.Close()
+	return nil
+}
+
+type s3ListResult struct {
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		Size         int64     `xml:"Size"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+func (t *s3Target) List(ctx context.Context, dir string) ([]Object, error) {
+	cleaned, err := cleanDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	listPrefix := t.key(cleaned)
+	if listPrefix != "" {
+		listPrefix += "/"
+	}
+
+	var objects []Object
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}, "delimiter": {"/"}, "prefix": {listPrefix}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		resp, err := t.do(ctx, http.MethodGet, "", query, nil, 0, s3EmptyBodyHash)
+		if err != nil {
+			return nil, fmt.Errorf("s3 list failed: %w", err)
+		}
+		var result s3ListResult
+		decodeErr := xml.NewDecoder(resp.Body).Decode(&result)
+		_ = resp.Body.Close()
+		if decodeErr != nil {
+			return nil, fmt.Errorf("s3 list failed: %w", decodeErr)
+		}
+
+		for _, c := range result.Contents {
+			base := path.Base(c.Key)
+			if strings.HasSuffix(c.Key, "/") || strings.HasPrefix(base, ".") {
+				continue
+			}
+			objects = append(objects, Object{Name: joinName(cleaned, base), Size: c.Size, ModTime: c.LastModified})
+		}
+
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return objects, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+func (t *s3Target) Close() error {
+	t.http.CloseIdleConnections()
+	return nil
+}
+
+// s3StatusError is returned for non-2xx responses.
+type s3StatusError struct {
+	Status  int
+	Message string
+}
+
+func (e *s3StatusError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("status %d", e.Status)
+	}
+	return fmt.Sprintf("status %d: %s", e.Status, e.Message)
+}
+
+func isS3NotFound(err error) bool {
+	statusErr, ok := err.(*s3StatusError) //nolint:errorlint // do never wraps s3StatusError
+	return ok && statusErr.Status == http.StatusNotFound
+}
+
+// s3ErrorMessage extracts the <Code> and <Message> of an S3 XML error body.
+func s3ErrorMessage(body []byte) string {
+	var e struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	if xml.Unmarshal(body, &e) != nil || e.Code == "" {
+		return strings.TrimSpace(string(body))
+	}
+	if e.Message == "" {
+		return e.Code
+	}
+	return e.Code + ": " + e.Message
+}
+
+// do sends a signed request and returns the response when its status is 2xx. The caller
+// must close the response body.
+func (t *s3Target) do(ctx context.Context, method, key string, query url.Values, body io.Reader, size int64, payloadHash string) (*http.Response, error) {
+	u := t.objectURL(key, query)
+	if body != nil && size == 0 {
+		body = http.NoBody
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.ContentLength = size
+	}
+	req.Header.Set("User-Agent", "Arcane")
+	signS3Request(req, t.cfg.AccessKeyID, t.cfg.SecretAccessKey, t.cfg.Region, payloadHash, t.now().UTC())
+
+	resp, err := t.http.Do(req) //nolint:gosec // intentional request to the user-configured storage endpoint
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+		_ = resp.Body.Close()
+		return nil, &s3StatusError{Status: resp.StatusCode, Message: s3ErrorMessage(msg)}
+	}
+	return resp, nil
+}
+
+// signS3Request adds AWS Signature Version 4 headers to req. The host, range and all
+// x-amz-* headers are signed.
+func signS3Request(req *http.Request, accessKey, secretKey, region, payloadHash string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	day := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		lower := strings.ToLower(name)
+		if lower == "range" || strings.HasPrefix(lower, "x-amz-") {
+			headers[lower] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		s3EscapePath(req.URL.Path),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := day + "/" + region + "/s3/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+secretKey), day)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s", accessKey, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// s3Escape percent-encodes everything except the RFC 3986 unreserved characters.
+func s3Escape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') || c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+func s3EscapePath(p string) string {
+	if p == "" {
+		return "/"
+	}
+	segments := strings.Split(p, "/")
+	for i, s := range segments {
+		segments[i] = s3Escape(s)
+	}
+	return strings.Join(segments, "/")
+}
+
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		values := append([]string(nil), query[k]...)
+		sort.Strings(values)
+		for _, v := range values {
+			parts = append(parts, s3Escape(k)+"="+s3Escape(v))
+		}
+	}
+	return strings.Join(parts, "&")
+}
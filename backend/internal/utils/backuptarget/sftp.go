@@ -0,0 +1,235 @@
+package backuptarget
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+// SFTPConfig configures a target on an SFTP server. Either Password or PrivateKey is required.
+type SFTPConfig struct {
+	Host       string `json:"host"`
+	Port       int    `json:"port,omitempty"`
+	Username   string `json:"username"`
+	Password   string `json:"password,omitempty"`
+	PrivateKey string `json:"privateKey,omitempty"`
+	// Path is the remote directory archives are stored in.
+	Path string `json:"path"`
+	// HostKeyVerification is "strict", "accept_new" (default) or "skip", as for Git repositories.
+	HostKeyVerification string `json:"hostKeyVerification,omitempty"`
+}
+
+// Validate checks that cfg has everything needed to log in to the server.
+func (cfg SFTPConfig) Validate() error {
+	if strings.TrimSpace(cfg.Host) == "" || cfg.Username == "" {
+		return fmt.Errorf("sftp target requires a host and username")
+	}
+	if cfg.Port < 0 || cfg.Port > 65535 {
+		return fmt.Errorf("invalid sftp port %d", cfg.Port)
+	}
+	if cfg.Password == "" && cfg.PrivateKey == "" {
+		return fmt.Errorf("sftp target requires a password or private key")
+	}
+	return nil
+}
+
+const sftpDialTimeout = 30 * time.Second
+
+type sftpTarget struct {
+	client *sftp.Client
+	conn   io.Closer
+	root   string
+}
+
+// NewSFTP connects to the server described by cfg. hostKeyCallback verifies the server's host key.
+func NewSFTP(ctx context.Context, cfg SFTPConfig, hostKeyCallback ssh.HostKeyCallback) (Target, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if hostKeyCallback == nil {
+		return nil, fmt.Errorf("sftp target requires a host key callback")
+	}
+
+	var auth []ssh.AuthMethod
+	if cfg.PrivateKey != "" {
+		signer, err := ssh.ParsePrivateKey([]byte(cfg.PrivateKey))
+		if err != nil {
+			return nil, fmt.Errorf("invalid sftp private key: %w", err)
+		}
+		auth = append(auth, ssh.PublicKeys(signer))
+	}
+	if cfg.Password != "" {
+		auth = append(auth, ssh.Password(cfg.Password))
+	}
+
+	port := cfg.Port
+	if port == 0 {
+		port = 22
+	}
+	addr := net.JoinHostPort(strings.TrimSpace(cfg.Host), strconv.Itoa(port))
+
+	dialer := net.Dialer{Timeout: sftpDialTimeout}
+	netConn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to sftp server: %w", err)
+	}
+	sshConn, chans, reqs, err := ssh.NewClientConn(netConn, addr, &ssh.ClientConfig{
+		User:            cfg.Username,
+		Auth:            auth,
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         sftpDialTimeout,
+	})
+	if err != nil {
+		_ = netConn.Close()
+		return nil, fmt.Errorf("failed to connect to sftp server: %w", err)
+	}
+	sshClient := ssh.NewClient(sshConn, chans, reqs)
+
+	client, err := sftp.NewClient(sshClient)
+	if err != nil {
+		_ = sshClient.Close()
+		return nil, fmt.Errorf("failed to start sftp session: %w", err)
+	}
+
+	t, err := newSFTPTarget(client, sshClient, cfg.Path)
+	if err != nil {
+		_ = client.Close()
+		_ = sshClient.Close()
+		return nil, err
+	}
+	return t, nil
+}
+
+// newSFTPTarget wraps an established SFTP session. conn, when set, is closed together with the session.
+func newSFTPTarget(client *sftp.Client, conn io.Closer, root string) (*sftpTarget, error) {
+	root = path.Clean(strings.TrimSpace(root))
+	if root == "" || root == "." {
+		root = "."
+	}
+	if err := client.MkdirAll(root); err != nil {
+		return nil, fmt.Errorf("failed to create sftp backup directory: %w", err)
+	}
+	return &sftpTarget{client: client, conn: conn, root: root}, nil
+}
+
+func (t *sftpTarget) resolve(name string) (string, error) {
+	cleaned, err := cleanName(name)
+	if err != nil {
+		return "", err
+	}
+	return path.Join(t.root, cleaned), nil
+}
+
+func (t *sftpTarget) Put(ctx context.Context, name string, r io.Reader, size int64) error {
+	dest, err := t.resolve(name)
+	if err != nil {
+		return err
+	}
+	if err := t.client.MkdirAll(path.Dir(dest)); err != nil {
+		return fmt.Errorf("failed to create sftp backup directory: %w", err)
+	}
+
+	// Upload to a temp name first so a failed upload never leaves a truncated archive behind.
+	tmp := path.Join(path.Dir(dest), ".upload-"+path.Base(dest))
+	f, err := t.client.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("failed to create sftp backup file: %w", err)
+	}
+	written, err := io.Copy(f, contextReader{ctx: ctx, r: r})
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && size >= 0 && written != size {
+		err = fmt.Errorf("wrote %d of %d bytes", written, size)
+	}
+	if err != nil {
+		_ = t.client.Remove(tmp)
+		return fmt.Errorf("failed to write sftp backup file: %w", err)
+	}
+
+	if err := t.client.PosixRename(tmp, dest); err != nil {
+		// Not every server supports the posix-rename extension; fall back to remove + rename.
+		_ = t.client.Remove(dest)
+		if err := t.client.Rename(tmp, dest); err != nil {
+			_ = t.client.Remove(tmp)
+			return fmt.Errorf("failed to store sftp backup file: %w", err)
+		}
+	}
+	return nil
+}
+
+func (t *sftpTarget) Get(_ context.Context, name string) (io.ReadCloser, int64, error) {
+	src, err := t.resolve(name)
+	if err != nil {
+		return nil, 0, err
+	}
+	f, err := t.client.Open(src)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, 0, ErrNotFound
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to open sftp backup file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, 0, fmt.Errorf("failed to stat sftp backup file: %w", err)
+	}
+	return f, info.Size(), nil
+}
+
+func (t *sftpTarget) Delete(_ context.Context, name string) error {
+	target, err := t.resolve(name)
+	if err != nil {
+		return err
+	}
+	if err := t.client.Remove(target); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete sftp backup file: %w", err)
+	}
+	return nil
+}
+
+func (t *sftpTarget) List(_ context.Context, dir string) ([]Object, error) {
+	cleaned, err := cleanDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := t.client.ReadDir(path.Join(t.root, cleaned))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sftp backup directory: %w", err)
+	}
+
+	objects := make([]Object, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.Mode().IsRegular() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		objects = append(objects, Object{
+			Name:    joinName(cleaned, entry.Name()),
+			Size:    entry.Size(),
+			ModTime: entry.ModTime(),
+		})
+	}
+	return objects, nil
+}
+
+func (t *sftpTarget) Close() error {
+	err := t.client.Close()
+	if t.conn != nil {
+		_ = t.conn.Close()
+	}
+	return err
+}
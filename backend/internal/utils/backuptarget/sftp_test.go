@@ -0,0 +1,50 @@
+package backuptarget
+
+import (
+	"io"
+	"net"
+	"testing"
+
+	"github.com/pkg/sftp"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+// newPipeSFTPTarget connects a client to an in-process SFTP server serving the local filesystem.
+func newPipeSFTPTarget(t *testing.T, root string) *sftpTarget {
+	t.Helper()
+
+	clientRead, serverWrite := io.Pipe()
+	serverRead, clientWrite := io.Pipe()
+
+	server, err := sftp.NewServer(struct {
+		io.Reader
+		io.WriteCloser
+	}{serverRead, serverWrite})
+	require.NoError(t, err)
+	go func() { _ = server.Serve() }()
+
+	client, err := sftp.NewClientPipe(clientRead, clientWrite)
+	require.NoError(t, err)
+
+	target, err := newSFTPTarget(client, nil, root)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		// Closing the server ends the client's read loop so Close does not block.
+		_ = server.Close()
+		_ = target.Close()
+	})
+	return target
+}
+
+func TestSFTPTarget(t *testing.T) {
+	exerciseTarget(t, newPipeSFTPTarget(t, t.TempDir()+"/backups"))
+}
+
+func TestNewSFTP_Validation(t *testing.T) {
+	_, err := NewSFTP(t.Context(), SFTPConfig{Host: "example.com", Username: "arcane"}, nil)
+	require.Error(t, err)
+
+	_, err = NewSFTP(t.Context(), SFTPConfig{Host: "example.com", Username: "arcane", PrivateKey: "not a key"}, func(string, net.Addr, ssh.PublicKey) error { return nil })
+	require.ErrorContains(t, err, "invalid sftp private key")
+}
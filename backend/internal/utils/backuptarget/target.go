@@ -0,0 +1,111 @@
+// Package backuptarget implements the off-host storage backends volume backups can be
+// written to: S3-compatible object storage, SFTP servers and plain directories.
+package backuptarget
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+)
+
+// Target types.
+const (
+	TypeS3   = "s3"
+	TypeSFTP = "sftp"
+	TypePath = "path"
+)
+
+// ErrNotFound is returned by Get when the object does not exist on the target.
+var ErrNotFound = errors.New("backup object not found")
+
+// Object describes a stored backup archive.
+type Object struct {
+	// Name is the object's key relative to the target root, e.g. "data/data-1700000000-abcd1234.tar.gz".
+	Name    string
+	Size    int64
+	ModTime time.Time
+}
+
+// Target stores backup archives under slash-separated names relative to its root.
+type Target interface {
+	// Put stores size bytes read from r under name, replacing any existing object.
+	Put(ctx context.Context, name string, r io.Reader, size int64) error
+	// Get opens the named object and returns its size. It returns ErrNotFound when the object does not exist.
+	Get(ctx context.Context, name string) (io.ReadCloser, int64, error)
+	// Delete removes the named object. Deleting a missing object is not an error.
+	Delete(ctx context.Context, name string) error
+	// List returns the objects directly below dir ("" for the root).
+	List(ctx context.Context, dir string) ([]Object, error)
+	// Close releases connections held by the target.
+	Close() error
+}
+
+// IsValidType reports whether t is a supported target type.
+func IsValidType(t string) bool {
+	switch t {
+	case TypeS3, TypeSFTP, TypePath:
+		return true
+	default:
+		return false
+	}
+}
+
+// SecretKeys returns the config keys of a target type that hold credentials. Callers
+// store these encrypted and never return them to clients.
+func SecretKeys(targetType string) []string {
+	switch targetType {
+	case TypeS3:
+		return []string{"secretAccessKey"}
+	case TypeSFTP:
+		return []string{"password", "privateKey"}
+	default:
+		return nil
+	}
+}
+
+// cleanName validates an object name and returns it without leading slashes. Names must
+// not escape the target root.
+func cleanName(name string) (string, error) {
+	cleaned := path.Clean("/" + strings.TrimSpace(name))
+	cleaned = strings.TrimPrefix(cleaned, "/")
+	if cleaned == "" || cleaned == "." {
+		return "", fmt.Errorf("invalid object name %q", name)
+	}
+	if cleaned != strings.Trim(strings.TrimSpace(name), "/") {
+		return "", fmt.Errorf("invalid object name %q", name)
+	}
+	return cleaned, nil
+}
+
+// cleanDir validates a directory name; "" refers to the target root.
+func cleanDir(dir string) (string, error) {
+	if strings.Trim(strings.TrimSpace(dir), "/") == "" {
+		return "", nil
+	}
+	return cleanName(dir)
+}
+
+// joinName joins a directory and base name into an object name.
+func joinName(dir, base string) string {
+	if dir == "" {
+		return base
+	}
+	return dir + "/" + base
+}
+
+// contextReader stops a copy once ctx is cancelled.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (c contextReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.r.Read(p)
+}
@@ -0,0 +1,91 @@
+package backuptarget
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// exerciseTarget runs the behaviour every Target implementation must share.
+func exerciseTarget(t *testing.T, target Target) {
+	t.Helper()
+	ctx := context.Background()
+
+	payload := bytes.Repeat([]byte("arcane-backup "), 1000)
+	require.NoError(t, target.Put(ctx, "data/data-1.tar.gz", bytes.NewReader(payload), int64(len(payload))))
+	require.NoError(t, target.Put(ctx, "data/data-2.tar.gz", bytes.NewReader([]byte("second")), 6))
+	require.NoError(t, target.Put(ctx, "other/other-1.tar.gz", bytes.NewReader([]byte("other")), 5))
+
+	rc, size, err := target.Get(ctx, "data/data-1.tar.gz")
+	require.NoError(t, err)
+	got, err := io.ReadAll(rc)
+	require.NoError(t, rc.Close())
+	require.NoError(t, err)
+	require.Equal(t, int64(len(payload)), size)
+	require.Equal(t, payload, got)
+
+	objects, err := target.List(ctx, "data")
+	require.NoError(t, err)
+	names := make([]string, 0, len(objects))
+	for _, o := range objects {
+		names = append(names, o.Name)
+	}
+	sort.Strings(names)
+	require.Equal(t, []string{"data/data-1.tar.gz", "data/data-2.tar.gz"}, names)
+
+	missing, err := target.List(ctx, "missing")
+	require.NoError(t, err)
+	require.Empty(t, missing)
+
+	require.NoError(t, target.Delete(ctx, "data/data-1.tar.gz"))
+	require.NoError(t, target.Delete(ctx, "data/data-1.tar.gz"), "deleting a missing object is not an error")
+
+	_, _, err = target.Get(ctx, "data/data-1.tar.gz")
+	require.True(t, errors.Is(err, ErrNotFound), "got %v", err)
+
+	require.Error(t, target.Put(ctx, "../escape.tar.gz", bytes.NewReader(nil), 0))
+	_, _, err = target.Get(ctx, "data/../../etc/passwd")
+	require.Error(t, err)
+}
+
+func TestCleanName(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "data/backup.tar.gz", want: "data/backup.tar.gz"},
+		{in: "/data/backup.tar.gz", want: "data/backup.tar.gz"},
+		{in: "", wantErr: true},
+		{in: "../backup.tar.gz", wantErr: true},
+		{in: "data/../../backup.tar.gz", wantErr: true},
+		{in: "data/./backup.tar.gz", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := cleanName(tt.in)
+		if tt.wantErr {
+			require.Error(t, err, tt.in)
+			continue
+		}
+		require.NoError(t, err, tt.in)
+		require.Equal(t, tt.want, got)
+	}
+}
+
+func TestPathTarget(t *testing.T) {
+	target, err := NewPath(PathConfig{Path: t.TempDir()})
+	require.NoError(t, err)
+	defer func() { _ = target.Close() }()
+
+	exerciseTarget(t, target)
+}
+
+func TestNewPath_RequiresAbsolutePath(t *testing.T) {
+	_, err := NewPath(PathConfig{Path: "relative/dir"})
+	require.Error(t, err)
+}
@@ -3,6 +3,7 @@ package crypto
 import (
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/hmac"
 	crand "crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
@@ -223,3 +224,36 @@ func Decrypt(ciphertext string) (string, error) {
 
 	return string(plaintext), nil
 }
+
+// Sign returns the hex HMAC-SHA256 of message, keyed by the encryption key and purpose.
+// The purpose separates signatures made for different uses of the same key.
+func Sign(purpose, message string) (string, error) {
+	if encryptionKey == nil {
+		return "", fmt.Errorf("encryption not initialized - call InitEncryption first")
+	}
+
+	mac := hmac.New(sha256.New, signingKey(purpose))
+	mac.Write([]byte(message))
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// Verify reports whether signature is the Sign output for purpose and message.
+func Verify(purpose, message, signature string) bool {
+	if encryptionKey == nil {
+		return false
+	}
+
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, signingKey(purpose))
+	mac.Write([]byte(message))
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+func signingKey(purpose string) []byte {
+	mac := hmac.New(sha256.New, encryptionKey)
+	mac.Write([]byte("arcane-signing:" + purpose))
+	return mac.Sum(nil)
+}
@@ -299,6 +299,26 @@ func TestEncryptDecrypt(t *testing.T) {
 	})
 }
 
+func TestSignVerify(t *testing.T) {
+	InitEncryption(&config.Config{
+		EncryptionKey: "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+		Environment:   "test",
+	})
+
+	signature, err := Sign("backup", "data-1.tar.gz abc")
+	require.NoError(t, err)
+	assert.True(t, Verify("backup", "data-1.tar.gz abc", signature))
+	assert.False(t, Verify("backup", "data-2.tar.gz abc", signature), "other messages must not verify")
+	assert.False(t, Verify("other", "data-1.tar.gz abc", signature), "other purposes must not verify")
+	assert.False(t, Verify("backup", "data-1.tar.gz abc", "not-hex"))
+
+	InitEncryption(&config.Config{
+		EncryptionKey: "fedcba9876543210fedcba9876543210fedcba9876543210fedcba9876543210",
+		Environment:   "test",
+	})
+	assert.False(t, Verify("backup", "data-1.tar.gz abc", signature), "other keys must not verify")
+}
+
 func TestEncryptDecryptWithoutInit(t *testing.T) {
 	encryptionKey = nil
 
@@ -0,0 +1,309 @@
+package crypto
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	crand "crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// Streams are encrypted in chunks of streamChunkSize bytes with AES-256-GCM, so archives of
+// any size can be encrypted and verified without buffering them. Every chunk is sealed with
+// a nonce made of its index and a final-chunk flag, which detects reordered, dropped and
+// truncated chunks. The stream header (magic, key mode and salt) is authenticated as
+// additional data of every chunk.
+//
+// Layout: magic (8) | key mode (1) | salt (16) | chunk... where each chunk is at most
+// streamChunkSize bytes of plaintext followed by a 16 byte tag.
+
+// Key modes of an encrypted stream.
+const (
+	// StreamKeyInstance derives the stream key from the instance encryption key (ENCRYPTION_KEY).
+	StreamKeyInstance = "instance"
+	// StreamKeyPassphrase derives the stream key from a user-provided passphrase.
+	StreamKeyPassphrase = "passphrase"
+)
+
+const (
+	streamMagic      = "ARCENC01"
+	streamSaltSize   = 16
+	streamHeaderSize = len(streamMagic) + 1 + streamSaltSize
+	streamChunkSize  = 64 << 10
+	streamTagSize    = 16
+	streamKeyInfo    = "arcane stream encryption"
+
+	streamModeInstance   byte = 1
+	streamModePassphrase byte = 2
+)
+
+var (
+	// ErrPassphraseRequired is returned when decrypting a passphrase-protected stream without a passphrase.
+	ErrPassphraseRequired = errors.New("a passphrase is required to decrypt this archive")
+	// ErrStreamCorrupted is returned when an encrypted stream fails authentication, e.g. because it
+	// was modified, truncated or decrypted with the wrong passphrase.
+	ErrStreamCorrupted = errors.New("encrypted archive is corrupted or the passphrase is wrong")
+)
+
+// IsEncryptedStream reports whether header starts with the marker of an encrypted stream.
+func IsEncryptedStream(header []byte) bool {
+	return bytes.HasPrefix(header, []byte(streamMagic))
+}
+
+// StreamKeyMode returns the key mode (StreamKeyInstance or StreamKeyPassphrase) of an
+// encrypted stream header.
+func StreamKeyMode(header []byte) (string, error) {
+	if len(header) < streamHeaderSize || !IsEncryptedStream(header) {
+		return "", fmt.Errorf("not an encrypted archive")
+	}
+	switch header[len(streamMagic)] {
+	case streamModeInstance:
+		return StreamKeyInstance, nil
+	case streamModePassphrase:
+		return StreamKeyPassphrase, nil
+	default:
+		return "", fmt.Errorf("unsupported archive key mode %d", header[len(streamMagic)])
+	}
+}
+
+// ReadStreamKeyMode reads the header at the start of r and returns the key mode of the
+// encrypted stream it holds, or "" when r is not an encrypted stream.
+func ReadStreamKeyMode(r io.Reader) (string, error) {
+	header := make([]byte, streamHeaderSize)
+	n, err := io.ReadFull(r, header)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", fmt.Errorf("failed to read archive header: %w", err)
+	}
+	if !IsEncryptedStream(header[:n]) {
+		return "", nil
+	}
+	return StreamKeyMode(header[:n])
+}
+
+// EncryptedStreamSize returns the size of the encrypted stream of plainSize bytes.
+func EncryptedStreamSize(plainSize int64) int64 {
+	chunks := max((plainSize+streamChunkSize-1)/streamChunkSize, 1)
+	return int64(streamHeaderSize) + plainSize + chunks*streamTagSize
+}
+
+// DecryptedStreamSize returns the plaintext size of an encrypted stream of encSize bytes.
+func DecryptedStreamSize(encSize int64) (int64, error) {
+	body := encSize - int64(streamHeaderSize)
+	if body < streamTagSize {
+		return 0, ErrStreamCorrupted
+	}
+	const sealed = streamChunkSize + streamTagSize
+	full, rest := body/sealed, body%sealed
+	switch {
+	case rest == 0:
+		return full * streamChunkSize, nil
+	case rest < streamTagSize:
+		return 0, ErrStreamCorrupted
+	default:
+		return full*streamChunkSize + rest - streamTagSize, nil
+	}
+}
+
+// NewStreamEncrypter returns a writer that encrypts everything written to it into w. The key
+// is derived from passphrase, or from the instance encryption key when passphrase is empty.
+// Close must be called to write the final chunk; it does not close w.
+func NewStreamEncrypter(w io.Writer, passphrase string) (io.WriteCloser, error) {
+	header := make([]byte, streamHeaderSize)
+	copy(header, streamMagic)
+	header[len(streamMagic)] = streamModeInstance
+	if passphrase != "" {
+		header[len(streamMagic)] = streamModePassphrase
+	}
+	if _, err := io.ReadFull(crand.Reader, header[len(streamMagic)+1:]); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	aead, err := newStreamAEAD(header, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(header); err != nil {
+		return nil, err
+	}
+
+	return &streamWriter{
+		w:    w,
+		aead: aead,
+		aad:  header,
+		buf:  make([]byte, 0, streamChunkSize),
+		out:  make([]byte, 0, streamChunkSize+streamTagSize),
+	}, nil
+}
+
+// NewStreamDecrypter returns a reader yielding the plaintext of the encrypted stream r.
+// passphrase is only used for passphrase-protected streams. Read returns ErrStreamCorrupted
+// as soon as a chunk fails authentication, so callers must not trust data read before an
+// error was returned.
+func NewStreamDecrypter(r io.Reader, passphrase string) (io.Reader, error) {
+	header := make([]byte, streamHeaderSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("failed to read archive header: %w", err)
+	}
+	mode, err := StreamKeyMode(header)
+	if err != nil {
+		return nil, err
+	}
+	if mode == StreamKeyInstance {
+		passphrase = ""
+	} else if passphrase == "" {
+		return nil, ErrPassphraseRequired
+	}
+
+	aead, err := newStreamAEAD(header, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	return &streamReader{
+		r:     bufio.NewReaderSize(r, streamChunkSize+streamTagSize),
+		aead:  aead,
+		aad:   header,
+		chunk: make([]byte, streamChunkSize+streamTagSize),
+	}, nil
+}
+
+func newStreamAEAD(header []byte, passphrase string) (cipher.AEAD, error) {
+	salt := header[len(streamMagic)+1:]
+
+	var key []byte
+	var err error
+	if header[len(streamMagic)] == streamModePassphrase {
+		key, err = scrypt.Key([]byte(passphrase), salt, 1<<15, 8, 1, 32)
+	} else {
+		if encryptionKey == nil {
+			return nil, fmt.Errorf("encryption not initialized - call InitEncryption first")
+		}
+		key, err = hkdf.Key(sha256.New, encryptionKey, salt, streamKeyInfo, 32)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+func streamNonce(counter uint64, final bool) []byte {
+	nonce := make([]byte, 12)
+	binary.BigEndian.PutUint64(nonce, counter)
+	if final {
+		nonce[11] = 1
+	}
+	return nonce
+}
+
+type streamWriter struct {
+	w       io.Writer
+	aead    cipher.AEAD
+	aad     []byte
+	buf     []byte
+	out     []byte
+	counter uint64
+	closed  bool
+}
+
+func (s *streamWriter) Write(p []byte) (int, error) {
+	if s.closed {
+		return 0, fmt.Errorf("write to closed stream")
+	}
+	written := 0
+	for len(p) > 0 {
+		// A full chunk is only flushed once more data arrives, so the last chunk is
+		// always sealed as final by Close.
+		if len(s.buf) == streamChunkSize {
+			if err := s.flush(false); err != nil {
+				return written, err
+			}
+		}
+		n := min(streamChunkSize-len(s.buf), len(p))
+		s.buf = append(s.buf, p[:n]...)
+		p = p[n:]
+		written += n
+	}
+	return written, nil
+}
+
+func (s *streamWriter) Close() error {
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	return s.flush(true)
+}
+
+func (s *streamWriter) flush(final bool) error {
+	s.out = s.aead.Seal(s.out[:0], streamNonce(s.counter, final), s.buf, s.aad)
+	s.counter++
+	s.buf = s.buf[:0]
+	_, err := s.w.Write(s.out)
+	return err
+}
+
+type streamReader struct {
+	r       *bufio.Reader
+	aead    cipher.AEAD
+	aad     []byte
+	chunk   []byte
+	plain   []byte
+	counter uint64
+	done    bool
+	err     error
+}
+
+func (s *streamReader) Read(p []byte) (int, error) {
+	for len(s.plain) == 0 {
+		if s.err != nil {
+			return 0, s.err
+		}
+		if s.done {
+			return 0, io.EOF
+		}
+		s.err = s.next()
+	}
+	n := copy(p, s.plain)
+	s.plain = s.plain[n:]
+	return n, nil
+}
+
+func (s *streamReader) next() error {
+	n, err := io.ReadFull(s.r, s.chunk)
+	final := false
+	switch {
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		final = true
+	case errors.Is(err, io.EOF):
+		// The stream ended without a final chunk.
+		return ErrStreamCorrupted
+	case err != nil:
+		return err
+	default:
+		if _, peekErr := s.r.Peek(1); errors.Is(peekErr, io.EOF) {
+			final = true
+		}
+	}
+
+	plain, err := s.aead.Open(s.chunk[:0], streamNonce(s.counter, final), s.chunk[:n], s.aad)
+	if err != nil {
+		return ErrStreamCorrupted
+	}
+	s.counter++
+	s.plain = plain
+	s.done = final
+	return nil
+}
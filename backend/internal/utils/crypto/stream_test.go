@@ -0,0 +1,147 @@
+package crypto
+
+import (
+	"bytes"
+	crand "crypto/rand"
+	"io"
+	"testing"
+
+	"github.com/getarcaneapp/arcane/backend/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func initStreamTestKey(t *testing.T) {
+	t.Helper()
+	InitEncryption(&config.Config{
+		EncryptionKey: "test-encryption-key-for-testing-32bytes-min",
+		Environment:   "test",
+	})
+}
+
+func encryptStream(t *testing.T, plain []byte, passphrase string) []byte {
+	t.Helper()
+	var out bytes.Buffer
+	w, err := NewStreamEncrypter(&out, passphrase)
+	require.NoError(t, err)
+	// Write in odd-sized pieces to exercise chunk boundaries.
+	for rest := plain; len(rest) > 0; {
+		n := min(len(rest), 10007)
+		_, err := w.Write(rest[:n])
+		require.NoError(t, err)
+		rest = rest[n:]
+	}
+	require.NoError(t, w.Close())
+	return out.Bytes()
+}
+
+func decryptStream(encrypted []byte, passphrase string) ([]byte, error) {
+	r, err := NewStreamDecrypter(bytes.NewReader(encrypted), passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(r)
+}
+
+func TestStreamEncryption_RoundTrip(t *testing.T) {
+	initStreamTestKey(t)
+
+	sizes := []int{0, 1, streamChunkSize - 1, streamChunkSize, streamChunkSize + 1, 3*streamChunkSize + 12345}
+	for _, passphrase := range []string{"", "correct horse battery staple"} {
+		for _, size := range sizes {
+			plain := make([]byte, size)
+			_, _ = crand.Read(plain)
+
+			encrypted := encryptStream(t, plain, passphrase)
+			assert.Equal(t, EncryptedStreamSize(int64(size)), int64(len(encrypted)), "size %d", size)
+			plainSize, err := DecryptedStreamSize(int64(len(encrypted)))
+			require.NoError(t, err)
+			assert.Equal(t, int64(size), plainSize)
+
+			got, err := decryptStream(encrypted, passphrase)
+			require.NoError(t, err, "size %d", size)
+			assert.Equal(t, plain, got, "size %d", size)
+		}
+	}
+}
+
+func TestStreamEncryption_KeyMode(t *testing.T) {
+	initStreamTestKey(t)
+
+	instance := encryptStream(t, []byte("data"), "")
+	assert.True(t, IsEncryptedStream(instance))
+	mode, err := StreamKeyMode(instance)
+	require.NoError(t, err)
+	assert.Equal(t, StreamKeyInstance, mode)
+
+	protected := encryptStream(t, []byte("data"), "secret")
+	mode, err = StreamKeyMode(protected)
+	require.NoError(t, err)
+	assert.Equal(t, StreamKeyPassphrase, mode)
+
+	assert.False(t, IsEncryptedStream([]byte{0x1f, 0x8b, 0x08}))
+
+	mode, err = ReadStreamKeyMode(bytes.NewReader(protected))
+	require.NoError(t, err)
+	assert.Equal(t, StreamKeyPassphrase, mode)
+	mode, err = ReadStreamKeyMode(bytes.NewReader([]byte{0x1f, 0x8b, 0x08}))
+	require.NoError(t, err)
+	assert.Empty(t, mode)
+}
+
+func TestStreamEncryption_RejectsTampering(t *testing.T) {
+	initStreamTestKey(t)
+
+	plain := make([]byte, 2*streamChunkSize+100)
+	_, _ = crand.Read(plain)
+	encrypted := encryptStream(t, plain, "secret")
+
+	t.Run("flipped bit", func(t *testing.T) {
+		tampered := bytes.Clone(encrypted)
+		tampered[streamHeaderSize+streamChunkSize+50] ^= 0x01
+		_, err := decryptStream(tampered, "secret")
+		require.ErrorIs(t, err, ErrStreamCorrupted)
+	})
+
+	t.Run("modified header", func(t *testing.T) {
+		tampered := bytes.Clone(encrypted)
+		tampered[streamHeaderSize-1] ^= 0x01
+		_, err := decryptStream(tampered, "secret")
+		require.ErrorIs(t, err, ErrStreamCorrupted)
+	})
+
+	t.Run("truncated at chunk boundary", func(t *testing.T) {
+		cut := streamHeaderSize + 2*(streamChunkSize+streamTagSize)
+		_, err := decryptStream(encrypted[:cut], "secret")
+		require.ErrorIs(t, err, ErrStreamCorrupted)
+	})
+
+	t.Run("trailing data", func(t *testing.T) {
+		_, err := decryptStream(append(bytes.Clone(encrypted), 0x00), "secret")
+		require.ErrorIs(t, err, ErrStreamCorrupted)
+	})
+
+	t.Run("wrong passphrase", func(t *testing.T) {
+		_, err := decryptStream(encrypted, "not the secret")
+		require.ErrorIs(t, err, ErrStreamCorrupted)
+	})
+
+	t.Run("missing passphrase", func(t *testing.T) {
+		_, err := decryptStream(encrypted, "")
+		require.ErrorIs(t, err, ErrPassphraseRequired)
+	})
+}
+
+func TestStreamEncryption_InstanceKeyChange(t *testing.T) {
+	initStreamTestKey(t)
+	encrypted := encryptStream(t, []byte("data"), "")
+
+	InitEncryption(&config.Config{
+		EncryptionKey: "another-encryption-key-for-testing-32bytes",
+		Environment:   "test",
+	})
+	t.Cleanup(func() { initStreamTestKey(t) })
+
+	_, err := decryptStream(encrypted, "")
+	require.ErrorIs(t, err, ErrStreamCorrupted)
+}
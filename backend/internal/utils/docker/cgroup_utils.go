@@ -44,6 +44,27 @@ func DetectCgroupLimits() (*CgroupLimits, error) {
 	return detectCgroupV1Limits(limits)
 }
 
+// EffectiveHostCapacity narrows the CPU count and total memory reported by the Docker
+// daemon to the cgroup limits of the current environment (LXC, Docker, etc.) when those
+// are lower.
+func EffectiveHostCapacity(cpuCount int, memTotal int64) (int, int64) {
+	cgroupLimits, err := DetectCgroupLimits()
+	if err != nil {
+		return cpuCount, memTotal
+	}
+	return applyCgroupCapacity(cgroupLimits, cpuCount, memTotal)
+}
+
+func applyCgroupCapacity(cgroupLimits *CgroupLimits, cpuCount int, memTotal int64) (int, int64) {
+	if limit := cgroupLimits.MemoryLimit; limit > 0 && (memTotal == 0 || limit < memTotal) {
+		memTotal = limit
+	}
+	if cgroupLimits.CPUCount > 0 && (cpuCount == 0 || cgroupLimits.CPUCount < cpuCount) {
+		cpuCount = cgroupLimits.CPUCount
+	}
+	return cpuCount, memTotal
+}
+
 func isInCgroup() bool {
 	if info, err := host.Info(); err == nil {
 		if info.VirtualizationSystem != "" && strings.EqualFold(info.VirtualizationRole, "guest") {
@@ -90,6 +90,17 @@ func (c *Client) getAuth(config AuthConfig) (transport.AuthMethod, error) {
 
 // getSSHHostKeyCallback returns the appropriate SSH host key callback based on verification mode
 func (c *Client) getSSHHostKeyCallback(mode string) (gossh.HostKeyCallback, error) {
+	return SSHHostKeyCallback(mode)
+}
+
+// createAcceptNewHostKeyCallback creates a callback that accepts new host keys and saves them
+func (c *Client) createAcceptNewHostKeyCallback() (gossh.HostKeyCallback, error) {
+	return createAcceptNewHostKeyCallback()
+}
+
+// SSHHostKeyCallback returns the SSH host key callback for a verification mode
+// (strict, accept_new or skip). It is shared by other SSH-based clients such as SFTP backup targets.
+func SSHHostKeyCallback(mode string) (gossh.HostKeyCallback, error) {
 	switch mode {
 	case SSHHostKeyVerificationStrict:
 		// Use known_hosts verification respecting SSH_KNOWN_HOSTS env var
@@ -99,15 +110,14 @@ func (c *Client) getSSHHostKeyCallback(mode string) (gossh.HostKeyCallback, erro
 		return gossh.InsecureIgnoreHostKey(), nil //nolint:gosec // User explicitly chose to skip verification
 	case SSHHostKeyVerificationAcceptNew, "":
 		// Default: accept and remember new host keys
-		return c.createAcceptNewHostKeyCallback()
+		return createAcceptNewHostKeyCallback()
 	default:
 		// Fall back to accept_new for unknown modes
-		return c.createAcceptNewHostKeyCallback()
+		return createAcceptNewHostKeyCallback()
 	}
 }
 
-// createAcceptNewHostKeyCallback creates a callback that accepts new host keys and saves them
-func (c *Client) createAcceptNewHostKeyCallback() (gossh.HostKeyCallback, error) {
+func createAcceptNewHostKeyCallback() (gossh.HostKeyCallback, error) {
 	knownHostsPath := getKnownHostsPath()
 
 	// Ensure the directory exists
@@ -208,6 +218,17 @@ func addHostKey(knownHostsPath, hostname string, key gossh.PublicKey) (err error
 
 // Clone clones a repository to a temporary directory
 func (c *Client) Clone(ctx context.Context, url, branch string, auth AuthConfig) (string, error) {
+	var referenceName plumbing.ReferenceName
+	if branch != "" {
+		referenceName = plumbing.NewBranchReferenceName(branch)
+	}
+	tmpDir, _, err := c.cloneInternal(ctx, url, referenceName, auth)
+	return tmpDir, err
+}
+
+// cloneInternal clones a single reference of a repository to a temporary directory, or
+// all of its branches and tags when referenceName is empty.
+func (c *Client) cloneInternal(ctx context.Context, url string, referenceName plumbing.ReferenceName, auth AuthConfig) (string, *git.Repository, error) {
 	if _, hasDeadline := ctx.Deadline(); !hasDeadline {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(ctx, 5*time.Minute)
@@ -215,7 +236,7 @@ func (c *Client) Clone(ctx context.Context, url, branch string, auth AuthConfig)
 	}
 
 	if err := ctx.Err(); err != nil {
-		return "", err
+		return "", nil, err
 	}
 
 	// Create a temporary directory
@@ -225,17 +246,17 @@ func (c *Client) Clone(ctx context.Context, url, branch string, auth AuthConfig)
 	}
 	// Ensure the work directory exists
 	if err := os.MkdirAll(workDir, 0755); err != nil {
-		return "", fmt.Errorf("failed to create work dir: %w", err)
+		return "", nil, fmt.Errorf("failed to create work dir: %w", err)
 	}
 	tmpDir, err := os.MkdirTemp(workDir, "gitops-*")
 	if err != nil {
-		return "", fmt.Errorf("failed to create temp dir: %w", err)
+		return "", nil, fmt.Errorf("failed to create temp dir: %w", err)
 	}
 
 	authMethod, err := c.getAuth(auth)
 	if err != nil {
 		_ = os.RemoveAll(tmpDir)
-		return "", err
+		return "", nil, err
 	}
 
 	cloneOptions := &git.CloneOptions{
@@ -247,18 +268,20 @@ func (c *Client) Clone(ctx context.Context, url, branch string, auth AuthConfig)
 		cloneOptions.Auth = authMethod
 	}
 
-	if branch != "" {
-		cloneOptions.ReferenceName = plumbing.NewBranchReferenceName(branch)
+	if referenceName != "" {
+		cloneOptions.ReferenceName = referenceName
 		cloneOptions.SingleBranch = true
+	} else {
+		cloneOptions.Tags = git.AllTags
 	}
 
-	_, err = git.PlainCloneContext(ctx, tmpDir, false, cloneOptions)
+	repo, err := git.PlainCloneContext(ctx, tmpDir, false, cloneOptions)
 	if err != nil {
 		_ = os.RemoveAll(tmpDir)
-		return "", fmt.Errorf("failed to clone repository: %w", err)
+		return "", nil, fmt.Errorf("failed to clone repository: %w", err)
 	}
 
-	return tmpDir, nil
+	return tmpDir, repo, nil
 }
 
 // GetCurrentCommit returns the HEAD commit hash of a cloned repository
@@ -287,33 +310,11 @@ type BranchInfo struct {
 
 // ListBranches lists all branches in a remote repository
 func (c *Client) ListBranches(ctx context.Context, url string, auth AuthConfig) ([]BranchInfo, error) {
-	if err := ctx.Err(); err != nil {
-		return nil, err
-	}
-	authMethod, err := c.getAuth(auth)
+	refs, err := c.listRemoteRefsInternal(ctx, url, auth)
 	if err != nil {
 		return nil, err
 	}
 
-	// Create a remote without cloning
-	rem := git.NewRemote(nil, &config.RemoteConfig{
-		Name: "origin",
-		URLs: []string{url},
-	})
-
-	listOptions := &git.ListOptions{}
-	if authMethod != nil {
-		listOptions.Auth = authMethod
-	}
-
-	listCtx, cancel := context.WithTimeout(ctx, 60*time.Second)
-	defer cancel()
-
-	refs, err := rem.ListContext(listCtx, listOptions)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list remote references: %w", err)
-	}
-
 	var branches []BranchInfo
 	var defaultBranch string
 
@@ -359,6 +360,37 @@ func (c *Client) ListBranches(ctx context.Context, url string, auth AuthConfig)
 	return branches, nil
 }
 
+// listRemoteRefsInternal lists the references of a remote repository without cloning it.
+func (c *Client) listRemoteRefsInternal(ctx context.Context, url string, auth AuthConfig) ([]*plumbing.Reference, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	authMethod, err := c.getAuth(auth)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a remote without cloning
+	rem := git.NewRemote(nil, &config.RemoteConfig{
+		Name: "origin",
+		URLs: []string{url},
+	})
+
+	listOptions := &git.ListOptions{}
+	if authMethod != nil {
+		listOptions.Auth = authMethod
+	}
+
+	listCtx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	refs, err := rem.ListContext(listCtx, listOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list remote references: %w", err)
+	}
+	return refs, nil
+}
+
 // ValidatePath ensures the path is safe and doesn't escape the repo
 func ValidatePath(repoPath, requestedPath string) error {
 	// Clean the paths
@@ -0,0 +1,250 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/getarcaneapp/arcane/types/gitops"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"golang.org/x/mod/semver"
+)
+
+// ErrCommitNotFound is returned when a commit ref is not in the repository.
+var ErrCommitNotFound = errors.New("commit not found in repository")
+
+// Ref is the revision of a repository a sync deploys.
+type Ref struct {
+	// Type is branch, tag, commit or semver. Empty means branch.
+	Type string
+	// Branch is the branch followed by branch refs.
+	Branch string
+	// Name is the tag, commit hash or semver range of the other ref types.
+	Name string
+}
+
+// String returns the ref as shown to users, e.g. "branch main" or "tag v1.2.0".
+func (r Ref) String() string {
+	if r.Type == "" || r.Type == gitops.RefTypeBranch {
+		return "branch " + r.Branch
+	}
+	return r.Type + " " + r.Name
+}
+
+// CloneRef clones a repository at a ref to a temporary directory. It also returns what
+// the ref resolved to: the branch, the tag or the full commit hash.
+func (c *Client) CloneRef(ctx context.Context, url string, ref Ref, auth AuthConfig) (string, string, error) {
+	switch ref.Type {
+	case "", gitops.RefTypeBranch:
+		repoPath, err := c.Clone(ctx, url, ref.Branch, auth)
+		return repoPath, ref.Branch, err
+	case gitops.RefTypeTag:
+		repoPath, _, err := c.cloneInternal(ctx, url, plumbing.NewTagReferenceName(ref.Name), auth)
+		return repoPath, ref.Name, err
+	case gitops.RefTypeSemver:
+		tag, err := c.ResolveSemverTag(ctx, url, ref.Name, auth)
+		if err != nil {
+			return "", "", err
+		}
+		repoPath, _, err := c.cloneInternal(ctx, url, plumbing.NewTagReferenceName(tag), auth)
+		return repoPath, tag, err
+	case gitops.RefTypeCommit:
+		return c.cloneCommitInternal(ctx, url, ref.Name, auth)
+	default:
+		return "", "", fmt.Errorf("unknown ref type %q", ref.Type)
+	}
+}
+
+// cloneCommitInternal clones all branches and tags of a repository and checks out a
+// commit, which may be abbreviated.
+func (c *Client) cloneCommitInternal(ctx context.Context, url, commit string, auth AuthConfig) (string, string, error) {
+	repoPath, repo, err := c.cloneInternal(ctx, url, "", auth)
+	if err != nil {
+		return "", "", err
+	}
+
+	hash, err := repo.ResolveRevision(plumbing.Revision(commit))
+	if err != nil {
+		_ = os.RemoveAll(repoPath)
+		return "", "", fmt.Errorf("%w: %s", ErrCommitNotFound, commit)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		_ = os.RemoveAll(repoPath)
+		return "", "", fmt.Errorf("failed to open worktree: %w", err)
+	}
+	if err := worktree.Checkout(&git.CheckoutOptions{Hash: *hash}); err != nil {
+		_ = os.RemoveAll(repoPath)
+		return "", "", fmt.Errorf("failed to check out commit %s: %w", commit, err)
+	}
+	return repoPath, hash.String(), nil
+}
+
+// ListTags lists the tags of a remote repository. Semantic version tags come first,
+// newest first, followed by the other tags by name.
+func (c *Client) ListTags(ctx context.Context, url string, auth AuthConfig) ([]string, error) {
+	refs, err := c.listRemoteRefsInternal(ctx, url, auth)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := []string{}
+	seen := make(map[string]bool)
+	for _, ref := range refs {
+		if !ref.Name().IsTag() {
+			continue
+		}
+		tag := ref.Name().Short()
+		if !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		vi, iok := tagVersionInternal(tags[i])
+		vj, jok := tagVersionInternal(tags[j])
+		switch {
+		case iok && jok:
+			if cmp := semver.Compare(vi, vj); cmp != 0 {
+				return cmp > 0
+			}
+			return tags[i] < tags[j]
+		case iok != jok:
+			return iok
+		default:
+			return tags[i] < tags[j]
+		}
+	})
+	return tags, nil
+}
+
+// ResolveSemverTag returns the highest tag of a remote repository within a version
+// range. See ParseSemverRange for the range syntax.
+func (c *Client) ResolveSemverTag(ctx context.Context, url, versionRange string, auth AuthConfig) (string, error) {
+	r, err := ParseSemverRange(versionRange)
+	if err != nil {
+		return "", err
+	}
+	tags, err := c.ListTags(ctx, url, auth)
+	if err != nil {
+		return "", err
+	}
+	tag, err := r.Latest(tags)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", err, versionRange)
+	}
+	return tag, nil
+}
+
+// ListCommits returns up to limit commits of a cloned repository, starting at HEAD.
+func (c *Client) ListCommits(ctx context.Context, repoPath string, limit int) ([]CommitInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repository: %w", err)
+	}
+	iter, err := repo.Log(&git.LogOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read commit log: %w", err)
+	}
+	defer iter.Close()
+
+	commits := []CommitInfo{}
+	err = iter.ForEach(func(commit *object.Commit) error {
+		if len(commits) >= limit {
+			return storer.ErrStop
+		}
+		commits = append(commits, commitInfoInternal(commit))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read commit log: %w", err)
+	}
+	return commits, nil
+}
+
+// HeadCommit returns the HEAD commit of a cloned repository.
+func (c *Client) HeadCommit(ctx context.Context, repoPath string) (CommitInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return CommitInfo{}, err
+	}
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return CommitInfo{}, fmt.Errorf("failed to open repository: %w", err)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return CommitInfo{}, fmt.Errorf("failed to get HEAD: %w", err)
+	}
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return CommitInfo{}, fmt.Errorf("failed to read HEAD commit: %w", err)
+	}
+	return commitInfoInternal(commit), nil
+}
+
+// ChangedFiles lists the paths of the files that differ between a commit and HEAD of a
+// cloned repository, sorted. It returns ErrCommitNotFound when the commit is not part
+// of the clone, e.g. because it is on another branch.
+func (c *Client) ChangedFiles(ctx context.Context, repoPath, since string) ([]string, error) {
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repository: %w", err)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HEAD: %w", err)
+	}
+	headCommit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read HEAD commit: %w", err)
+	}
+	sinceCommit, err := repo.CommitObject(plumbing.NewHash(since))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrCommitNotFound, since)
+	}
+
+	sinceTree, err := sinceCommit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tree of %s: %w", since, err)
+	}
+	headTree, err := headCommit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tree of HEAD: %w", err)
+	}
+	changes, err := sinceTree.DiffContext(ctx, headTree)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff commits: %w", err)
+	}
+
+	files := make([]string, 0, len(changes))
+	for _, change := range changes {
+		name := change.To.Name
+		if name == "" {
+			name = change.From.Name
+		}
+		files = append(files, name)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// commitInfoInternal describes a commit by the first line of its message.
+func commitInfoInternal(commit *object.Commit) CommitInfo {
+	message, _, _ := strings.Cut(commit.Message, "\n")
+	return CommitInfo{
+		Hash:    commit.Hash.String(),
+		Author:  commit.Author.Name,
+		Message: message,
+		Date:    commit.Author.When,
+	}
+}
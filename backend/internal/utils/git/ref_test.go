@@ -0,0 +1,247 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/getarcaneapp/arcane/types/gitops"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// newTestRepoInternal creates a repository with one commit per version of compose.yaml
+// and returns its path and the commit hashes, oldest first. Commits are tagged v1.0.0,
+// v1.1.0 (annotated), v2.0.0-rc.1 and release-2; the last commit is untagged.
+func newTestRepoInternal(t *testing.T) (string, []string) {
+	t.Helper()
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("failed to init repository: %v", err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("failed to open worktree: %v", err)
+	}
+
+	signature := &object.Signature{Name: "Arcane", Email: "arcane@example.com", When: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	tags := []string{"v1.0.0", "v1.1.0", "v2.0.0-rc.1", "release-2", ""}
+	var hashes []string
+	for i, tag := range tags {
+		content := []byte("services:\n  app:\n    image: app:" + string(rune('a'+i)) + "\n")
+		if err := os.WriteFile(filepath.Join(dir, "compose.yaml"), content, 0o600); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+		if _, err := worktree.Add("compose.yaml"); err != nil {
+			t.Fatalf("failed to add file: %v", err)
+		}
+		hash, err := worktree.Commit("commit "+string(rune('a'+i))+"\n\nbody", &git.CommitOptions{Author: signature})
+		if err != nil {
+			t.Fatalf("failed to commit: %v", err)
+		}
+		hashes = append(hashes, hash.String())
+
+		var opts *git.CreateTagOptions
+		if tag == "v1.1.0" {
+			opts = &git.CreateTagOptions{Tagger: signature, Message: "release " + tag}
+		}
+		if tag != "" {
+			if _, err := repo.CreateTag(tag, hash, opts); err != nil {
+				t.Fatalf("failed to tag: %v", err)
+			}
+		}
+	}
+	return dir, hashes
+}
+
+func headOfCloneInternal(t *testing.T, c *Client, repoPath string) string {
+	t.Helper()
+	commit, err := c.GetCurrentCommit(context.Background(), repoPath)
+	if err != nil {
+		t.Fatalf("failed to get commit: %v", err)
+	}
+	return commit
+}
+
+func TestClient_CloneRef(t *testing.T) {
+	ctx := context.Background()
+	url, hashes := newTestRepoInternal(t)
+	c := NewClient(t.TempDir())
+
+	tests := []struct {
+		name         string
+		ref          Ref
+		wantResolved string
+		wantCommit   string
+	}{
+		{"branch", Ref{Type: gitops.RefTypeBranch, Branch: "master"}, "master", hashes[4]},
+		{"lightweight tag", Ref{Type: gitops.RefTypeTag, Name: "v1.0.0"}, "v1.0.0", hashes[0]},
+		{"annotated tag", Ref{Type: gitops.RefTypeTag, Name: "v1.1.0"}, "v1.1.0", hashes[1]},
+		{"semver range", Ref{Type: gitops.RefTypeSemver, Name: "v1.*"}, "v1.1.0", hashes[1]},
+		{"commit", Ref{Type: gitops.RefTypeCommit, Name: hashes[2]}, hashes[2], hashes[2]},
+		{"abbreviated commit", Ref{Type: gitops.RefTypeCommit, Name: hashes[3][:10]}, hashes[3], hashes[3]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoPath, resolved, err := c.CloneRef(ctx, url, tt.ref, AuthConfig{})
+			if err != nil {
+				t.Fatalf("CloneRef failed: %v", err)
+			}
+			defer func() { _ = c.Cleanup(repoPath) }()
+
+			if resolved != tt.wantResolved {
+				t.Errorf("resolved = %q, want %q", resolved, tt.wantResolved)
+			}
+			if commit := headOfCloneInternal(t, c, repoPath); commit != tt.wantCommit {
+				t.Errorf("HEAD = %s, want %s", commit, tt.wantCommit)
+			}
+		})
+	}
+
+	t.Run("unknown commit", func(t *testing.T) {
+		_, _, err := c.CloneRef(ctx, url, Ref{Type: gitops.RefTypeCommit, Name: "0123456789abcdef"}, AuthConfig{})
+		if !errors.Is(err, ErrCommitNotFound) {
+			t.Errorf("expected ErrCommitNotFound, got %v", err)
+		}
+	})
+
+	t.Run("range without a match", func(t *testing.T) {
+		_, _, err := c.CloneRef(ctx, url, Ref{Type: gitops.RefTypeSemver, Name: ">=3"}, AuthConfig{})
+		if !errors.Is(err, ErrNoMatchingTag) {
+			t.Errorf("expected ErrNoMatchingTag, got %v", err)
+		}
+	})
+}
+
+func TestClient_ListTags(t *testing.T) {
+	url, _ := newTestRepoInternal(t)
+	c := NewClient(t.TempDir())
+
+	tags, err := c.ListTags(context.Background(), url, AuthConfig{})
+	if err != nil {
+		t.Fatalf("ListTags failed: %v", err)
+	}
+	want := []string{"v2.0.0-rc.1", "v1.1.0", "v1.0.0", "release-2"}
+	if !slices.Equal(tags, want) {
+		t.Errorf("tags = %v, want %v", tags, want)
+	}
+}
+
+func TestClient_ListCommits(t *testing.T) {
+	ctx := context.Background()
+	url, hashes := newTestRepoInternal(t)
+	c := NewClient(t.TempDir())
+
+	repoPath, err := c.Clone(ctx, url, "", AuthConfig{})
+	if err != nil {
+		t.Fatalf("Clone failed: %v", err)
+	}
+	defer func() { _ = c.Cleanup(repoPath) }()
+
+	commits, err := c.ListCommits(ctx, repoPath, 2)
+	if err != nil {
+		t.Fatalf("ListCommits failed: %v", err)
+	}
+	if len(commits) != 2 {
+		t.Fatalf("expected 2 commits, got %d", len(commits))
+	}
+	if commits[0].Hash != hashes[4] || commits[1].Hash != hashes[3] {
+		t.Errorf("commits = %s, %s, want newest first", commits[0].Hash, commits[1].Hash)
+	}
+	if commits[0].Message != "commit e" || commits[0].Author != "Arcane" {
+		t.Errorf("unexpected commit info: %+v", commits[0])
+	}
+}
+
+func TestClient_ChangedFiles(t *testing.T) {
+	ctx := context.Background()
+	url, hashes := newTestRepoInternal(t)
+	c := NewClient(t.TempDir())
+
+	repoPath, err := c.Clone(ctx, url, "", AuthConfig{})
+	if err != nil {
+		t.Fatalf("Clone failed: %v", err)
+	}
+	defer func() { _ = c.Cleanup(repoPath) }()
+
+	head, err := c.HeadCommit(ctx, repoPath)
+	if err != nil {
+		t.Fatalf("HeadCommit failed: %v", err)
+	}
+	if head.Hash != hashes[4] || head.Message != "commit e" {
+		t.Errorf("unexpected HEAD commit: %+v", head)
+	}
+
+	files, err := c.ChangedFiles(ctx, repoPath, hashes[0])
+	if err != nil {
+		t.Fatalf("ChangedFiles failed: %v", err)
+	}
+	if !slices.Equal(files, []string{"compose.yaml"}) {
+		t.Errorf("files = %v, want [compose.yaml]", files)
+	}
+
+	files, err = c.ChangedFiles(ctx, repoPath, hashes[4])
+	if err != nil || len(files) != 0 {
+		t.Errorf("expected no changes since HEAD, got %v, %v", files, err)
+	}
+
+	if _, err := c.ChangedFiles(ctx, repoPath, "0123456789abcdef0123456789abcdef01234567"); !errors.Is(err, ErrCommitNotFound) {
+		t.Errorf("expected ErrCommitNotFound, got %v", err)
+	}
+}
+
+func TestParseSemverRange(t *testing.T) {
+	tags := []string{"v1.0.0", "v1.2.3", "1.2.4", "v1.10.0", "v2.0.0-rc.1", "v2.0.0", "v2.1.0+build.5", "latest", "v1.2"}
+
+	tests := []struct {
+		rangeStr string
+		want     string
+	}{
+		{"v1.*", "v1.10.0"},
+		{"1.2.x", "1.2.4"},
+		{"v1", "v1.10.0"},
+		{"*", "v2.1.0+build.5"},
+		{">=1.2.0 <1.10", "1.2.4"},
+		{">=1.0.0, <2", "v1.10.0"},
+		{"=v2.0.0-rc.1", "v2.0.0-rc.1"},
+		{"v1.0.0", "v1.0.0"},
+		{"<1", ""},
+	}
+	for _, tt := range tests {
+		r, err := ParseSemverRange(tt.rangeStr)
+		if err != nil {
+			t.Errorf("ParseSemverRange(%q) failed: %v", tt.rangeStr, err)
+			continue
+		}
+		got, err := r.Latest(tags)
+		if tt.want == "" {
+			if !errors.Is(err, ErrNoMatchingTag) {
+				t.Errorf("%q: expected ErrNoMatchingTag, got %q, %v", tt.rangeStr, got, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("%q: got %q, %v, want %q", tt.rangeStr, got, err, tt.want)
+		}
+	}
+
+	for _, invalid := range []string{"", "latest", ">=*", ">=1.*", "v1.*.*", "~>1.2"} {
+		if _, err := ParseSemverRange(invalid); err == nil {
+			t.Errorf("ParseSemverRange(%q) should fail", invalid)
+		}
+	}
+}
+
+func TestRef_String(t *testing.T) {
+	if got := (Ref{Branch: "main"}).String(); got != "branch main" {
+		t.Errorf("got %q", got)
+	}
+	if got := (Ref{Type: gitops.RefTypeSemver, Name: "v1.*"}).String(); got != "semver v1.*" {
+		t.Errorf("got %q", got)
+	}
+}
@@ -0,0 +1,169 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+// ErrNoMatchingTag is returned when no tag satisfies a semver range.
+var ErrNoMatchingTag = errors.New("no tag matches the version range")
+
+// SemverRange is a set of version constraints that tags are matched against.
+type SemverRange struct {
+	terms []semverTermInternal
+}
+
+type semverTermInternal struct {
+	op      string
+	version string
+	// prefix matches versions by major ("v1") or major and minor ("v1.2") for wildcard
+	// and partial versions.
+	prefix string
+}
+
+// ParseSemverRange parses a version range. Constraints are separated by spaces or
+// commas and must all match:
+//
+//   - wildcards and partial versions match a release line: "v1.*", "1.2.x", "v1"
+//   - comparisons: ">=1.2.0", "<2", "=v1.4.1"
+//   - "*" matches any release
+//
+// The v prefix is optional, on constraints and tags alike. Pre-releases only match
+// constraints that name a pre-release exactly.
+func ParseSemverRange(s string) (SemverRange, error) {
+	fields := strings.FieldsFunc(s, func(r rune) bool { return r == ' ' || r == ',' })
+	if len(fields) == 0 {
+		return SemverRange{}, fmt.Errorf("version range is empty")
+	}
+
+	var r SemverRange
+	for _, field := range fields {
+		term, err := parseSemverTermInternal(field)
+		if err != nil {
+			return SemverRange{}, err
+		}
+		r.terms = append(r.terms, term)
+	}
+	return r, nil
+}
+
+func parseSemverTermInternal(field string) (semverTermInternal, error) {
+	op := ""
+	for _, candidate := range []string{">=", "<=", ">", "<", "="} {
+		if rest, ok := strings.CutPrefix(field, candidate); ok {
+			op, field = candidate, rest
+			break
+		}
+	}
+	if field == "*" || field == "x" {
+		if op != "" {
+			return semverTermInternal{}, fmt.Errorf("invalid version constraint %q", op+field)
+		}
+		return semverTermInternal{}, nil
+	}
+
+	version := "v" + strings.TrimPrefix(field, "v")
+	wildcard := false
+	for _, suffix := range []string{".*", ".x", ".X"} {
+		if trimmed, ok := strings.CutSuffix(version, suffix); ok {
+			version, wildcard = trimmed, true
+			break
+		}
+	}
+	if !semver.IsValid(version) || (wildcard && strings.Count(version, ".") > 1) {
+		return semverTermInternal{}, fmt.Errorf("invalid version constraint %q", field)
+	}
+
+	partial := strings.Count(strings.SplitN(version, "-", 2)[0], ".") < 2
+	if op == "" && (wildcard || partial) {
+		return semverTermInternal{prefix: version}, nil
+	}
+	if wildcard {
+		return semverTermInternal{}, fmt.Errorf("invalid version constraint %q", op+field)
+	}
+	if op == "" {
+		op = "="
+	}
+	return semverTermInternal{op: op, version: semver.Canonical(version)}, nil
+}
+
+// Matches reports whether a tag is a version within the range.
+func (r SemverRange) Matches(tag string) bool {
+	version, ok := tagVersionInternal(tag)
+	if !ok {
+		return false
+	}
+	if semver.Prerelease(version) != "" && !r.namesPrereleaseInternal(version) {
+		return false
+	}
+	for _, term := range r.terms {
+		if !term.matches(version) {
+			return false
+		}
+	}
+	return true
+}
+
+// Latest returns the highest tag within the range.
+func (r SemverRange) Latest(tags []string) (string, error) {
+	best, bestVersion := "", ""
+	for _, tag := range tags {
+		if !r.Matches(tag) {
+			continue
+		}
+		version, _ := tagVersionInternal(tag)
+		if best == "" || semver.Compare(version, bestVersion) > 0 {
+			best, bestVersion = tag, version
+		}
+	}
+	if best == "" {
+		return "", ErrNoMatchingTag
+	}
+	return best, nil
+}
+
+func (r SemverRange) namesPrereleaseInternal(version string) bool {
+	for _, term := range r.terms {
+		if term.op == "=" && term.version == version {
+			return true
+		}
+	}
+	return false
+}
+
+func (t semverTermInternal) matches(version string) bool {
+	switch {
+	case t.prefix != "":
+		return version == t.prefix || strings.HasPrefix(version, t.prefix+".")
+	case t.op == "":
+		return true
+	}
+
+	cmp := semver.Compare(version, t.version)
+	switch t.op {
+	case ">=":
+		return cmp >= 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case "<":
+		return cmp < 0
+	default:
+		return cmp == 0
+	}
+}
+
+// tagVersionInternal returns the canonical semantic version of a tag such as v1.2.3 or
+// 1.2.3, without build metadata.
+func tagVersionInternal(tag string) (string, bool) {
+	version := "v" + strings.TrimPrefix(tag, "v")
+	canonical := semver.Canonical(version)
+	if canonical == "" || (canonical != version && !strings.HasPrefix(version, canonical+"+")) {
+		return "", false
+	}
+	return canonical, true
+}
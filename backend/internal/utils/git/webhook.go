@@ -0,0 +1,207 @@
+package git
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Webhook providers recognized by ParseWebhook.
+const (
+	WebhookProviderGitHub    = "github"
+	WebhookProviderGitLab    = "gitlab"
+	WebhookProviderGitea     = "gitea"
+	WebhookProviderBitbucket = "bitbucket"
+)
+
+var (
+	// ErrWebhookUnknownProvider is returned for requests without the event header of a
+	// supported provider.
+	ErrWebhookUnknownProvider = errors.New("unrecognized webhook provider")
+	// ErrWebhookSignature is returned when the signature or token is missing or wrong.
+	ErrWebhookSignature = errors.New("invalid webhook signature")
+	// ErrWebhookPayload is returned for payloads that cannot be decoded.
+	ErrWebhookPayload = errors.New("invalid webhook payload")
+)
+
+// WebhookPush is a push event received from a git provider.
+type WebhookPush struct {
+	Provider string
+	// Event is the provider's event name, e.g. push or repo:push.
+	Event string
+	// Ping is true for the test events providers send when a webhook is created. Such
+	// events carry no refs.
+	Ping bool
+	// Branches are the branches the push updated, without refs/heads/. Deleted branches
+	// are not included.
+	Branches []string
+	// Commits maps each branch to the commit it was updated to.
+	Commits map[string]string
+}
+
+// ParseWebhook verifies and decodes a push webhook from GitHub, GitLab, Gitea, Forgejo
+// or Bitbucket. The secret is checked against the provider's HMAC-SHA256 signature
+// header, or the X-Gitlab-Token header for GitLab. Events other than pushes return a
+// WebhookPush without branches.
+func ParseWebhook(header http.Header, body []byte, secret string) (*WebhookPush, error) {
+	push := &WebhookPush{Commits: map[string]string{}}
+
+	var err error
+	switch {
+	case header.Get("X-GitHub-Event") != "":
+		push.Provider, push.Event = WebhookProviderGitHub, header.Get("X-GitHub-Event")
+		err = verifyHMACInternal(body, secret, strings.TrimPrefix(header.Get("X-Hub-Signature-256"), "sha256="))
+	case header.Get("X-Gitlab-Event") != "":
+		push.Provider, push.Event = WebhookProviderGitLab, header.Get("X-Gitlab-Event")
+		err = verifyTokenInternal(secret, header.Get("X-Gitlab-Token"))
+	case header.Get("X-Gitea-Event") != "" || header.Get("X-Forgejo-Event") != "":
+		push.Provider, push.Event = WebhookProviderGitea, firstHeaderInternal(header, "X-Forgejo-Event", "X-Gitea-Event")
+		err = verifyHMACInternal(body, secret, firstHeaderInternal(header, "X-Forgejo-Signature", "X-Gitea-Signature"))
+	case header.Get("X-Event-Key") != "":
+		push.Provider, push.Event = WebhookProviderBitbucket, header.Get("X-Event-Key")
+		err = verifyHMACInternal(body, secret, strings.TrimPrefix(header.Get("X-Hub-Signature"), "sha256="))
+	default:
+		return nil, ErrWebhookUnknownProvider
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch push.Event {
+	case "ping", "diagnostics:ping":
+		push.Ping = true
+	case "push", "Push Hook":
+		err = parseRefPushInternal(body, push)
+	case "repo:push":
+		err = parseBitbucketCloudPushInternal(body, push)
+	case "repo:refs_changed":
+		err = parseBitbucketServerPushInternal(body, push)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrWebhookPayload, err)
+	}
+	return push, nil
+}
+
+// Commit returns the commit branch was updated to, and false if the push did not update
+// branch.
+func (p *WebhookPush) Commit(branch string) (string, bool) {
+	commit, ok := p.Commits[strings.TrimPrefix(branch, "refs/heads/")]
+	return commit, ok
+}
+
+func (p *WebhookPush) addBranchInternal(branch, commit string) {
+	if _, ok := p.Commits[branch]; !ok {
+		p.Branches = append(p.Branches, branch)
+	}
+	p.Commits[branch] = commit
+}
+
+func firstHeaderInternal(header http.Header, keys ...string) string {
+	for _, key := range keys {
+		if v := header.Get(key); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// verifyHMACInternal checks a hex encoded HMAC-SHA256 of body.
+func verifyHMACInternal(body []byte, secret, signature string) error {
+	if secret == "" || signature == "" {
+		return ErrWebhookSignature
+	}
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return ErrWebhookSignature
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return ErrWebhookSignature
+	}
+	return nil
+}
+
+func verifyTokenInternal(secret, token string) error {
+	if secret == "" || subtle.ConstantTimeCompare([]byte(secret), []byte(token)) != 1 {
+		return ErrWebhookSignature
+	}
+	return nil
+}
+
+// zeroCommit is the commit GitHub, GitLab and Gitea report as "after" for deleted refs.
+const zeroCommit = "0000000000000000000000000000000000000000"
+
+// parseRefPushInternal decodes the push payloads of GitHub, GitLab and Gitea, which share
+// the ref, after and deleted fields.
+func parseRefPushInternal(body []byte, push *WebhookPush) error {
+	var payload struct {
+		Ref     string `json:"ref"`
+		After   string `json:"after"`
+		Deleted bool   `json:"deleted"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return err
+	}
+	branch, ok := strings.CutPrefix(payload.Ref, "refs/heads/")
+	if !ok || payload.Deleted || payload.After == zeroCommit {
+		return nil
+	}
+	push.addBranchInternal(branch, payload.After)
+	return nil
+}
+
+func parseBitbucketCloudPushInternal(body []byte, push *WebhookPush) error {
+	var payload struct {
+		Push struct {
+			Changes []struct {
+				New *struct {
+					Type   string `json:"type"`
+					Name   string `json:"name"`
+					Target struct {
+						Hash string `json:"hash"`
+					} `json:"target"`
+				} `json:"new"`
+			} `json:"changes"`
+		} `json:"push"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return err
+	}
+	for _, change := range payload.Push.Changes {
+		// new is null when the branch was deleted.
+		if change.New != nil && change.New.Type == "branch" {
+			push.addBranchInternal(change.New.Name, change.New.Target.Hash)
+		}
+	}
+	return nil
+}
+
+func parseBitbucketServerPushInternal(body []byte, push *WebhookPush) error {
+	var payload struct {
+		Changes []struct {
+			Ref struct {
+				DisplayID string `json:"displayId"`
+				Type      string `json:"type"`
+			} `json:"ref"`
+			ToHash string `json:"toHash"`
+			Type   string `json:"type"`
+		} `json:"changes"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return err
+	}
+	for _, change := range payload.Changes {
+		if change.Ref.Type == "BRANCH" && change.Type != "DELETE" {
+			push.addBranchInternal(change.Ref.DisplayID, change.ToHash)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,224 @@
+package git
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+const testWebhookSecret = "s3cr3t-webhook"
+
+func loadWebhookFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	body, err := os.ReadFile(filepath.Join("testdata", "webhooks", name))
+	if err != nil {
+		t.Fatalf("failed to read fixture %s: %v", name, err)
+	}
+	return body
+}
+
+func signWebhook(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestParseWebhook(t *testing.T) {
+	tests := []struct {
+		name     string
+		fixture  string
+		header   func(body []byte) http.Header
+		provider string
+		ping     bool
+		branches []string
+		commit   string
+	}{
+		{
+			name:    "github push",
+			fixture: "github_push.json",
+			header: func(body []byte) http.Header {
+				return http.Header{
+					"X-Github-Event":      {"push"},
+					"X-Hub-Signature-256": {"sha256=" + signWebhook(body, testWebhookSecret)},
+				}
+			},
+			provider: WebhookProviderGitHub,
+			branches: []string{"main"},
+			commit:   "0d1a26e67d8f5eaf1f6ba5c57fc3c7d91ac0fd1c",
+		},
+		{
+			name:    "github ping",
+			fixture: "github_ping.json",
+			header: func(body []byte) http.Header {
+				return http.Header{
+					"X-Github-Event":      {"ping"},
+					"X-Hub-Signature-256": {"sha256=" + signWebhook(body, testWebhookSecret)},
+				}
+			},
+			provider: WebhookProviderGitHub,
+			ping:     true,
+		},
+		{
+			name:    "gitlab push",
+			fixture: "gitlab_push.json",
+			header: func([]byte) http.Header {
+				return http.Header{"X-Gitlab-Event": {"Push Hook"}, "X-Gitlab-Token": {testWebhookSecret}}
+			},
+			provider: WebhookProviderGitLab,
+			branches: []string{"production"},
+			commit:   "da1560886d4f094c3e6c9ef40349f7d38b5d27d7",
+		},
+		{
+			name:    "gitlab branch deletion",
+			fixture: "gitlab_branch_delete.json",
+			header: func([]byte) http.Header {
+				return http.Header{"X-Gitlab-Event": {"Push Hook"}, "X-Gitlab-Token": {testWebhookSecret}}
+			},
+			provider: WebhookProviderGitLab,
+		},
+		{
+			name:    "gitea push",
+			fixture: "gitea_push.json",
+			header: func(body []byte) http.Header {
+				return http.Header{"X-Gitea-Event": {"push"}, "X-Gitea-Signature": {signWebhook(body, testWebhookSecret)}}
+			},
+			provider: WebhookProviderGitea,
+			branches: []string{"main"},
+			commit:   "bffeb74224043ba2feb48d137756c8a9331c449a",
+		},
+		{
+			name:    "forgejo tag push",
+			fixture: "gitea_tag_push.json",
+			header: func(body []byte) http.Header {
+				return http.Header{"X-Forgejo-Event": {"push"}, "X-Forgejo-Signature": {signWebhook(body, testWebhookSecret)}}
+			},
+			provider: WebhookProviderGitea,
+		},
+		{
+			name:    "bitbucket cloud push",
+			fixture: "bitbucket_cloud_push.json",
+			header: func(body []byte) http.Header {
+				return http.Header{"X-Event-Key": {"repo:push"}, "X-Hub-Signature": {"sha256=" + signWebhook(body, testWebhookSecret)}}
+			},
+			provider: WebhookProviderBitbucket,
+			branches: []string{"main"},
+			commit:   "4b3a0c5b6f31e4d9e5a1f7b2c8d9e0f1a2b3c4d5",
+		},
+		{
+			name:    "bitbucket server push",
+			fixture: "bitbucket_server_push.json",
+			header: func(body []byte) http.Header {
+				return http.Header{"X-Event-Key": {"repo:refs_changed"}, "X-Hub-Signature": {"sha256=" + signWebhook(body, testWebhookSecret)}}
+			},
+			provider: WebhookProviderBitbucket,
+			branches: []string{"release"},
+			commit:   "178864a7d521b6f5e720b386b2c2b0ef8563e0dc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := loadWebhookFixture(t, tt.fixture)
+			push, err := ParseWebhook(tt.header(body), body, testWebhookSecret)
+			if err != nil {
+				t.Fatalf("ParseWebhook() error = %v", err)
+			}
+			if push.Provider != tt.provider {
+				t.Errorf("Provider = %q, want %q", push.Provider, tt.provider)
+			}
+			if push.Ping != tt.ping {
+				t.Errorf("Ping = %v, want %v", push.Ping, tt.ping)
+			}
+			if !slices.Equal(push.Branches, tt.branches) {
+				t.Errorf("Branches = %v, want %v", push.Branches, tt.branches)
+			}
+			if _, ok := push.Commit("unrelated"); ok {
+				t.Errorf("Commit(%q) = true", "unrelated")
+			}
+			if len(tt.branches) > 0 {
+				if got, ok := push.Commit("refs/heads/" + tt.branches[0]); !ok || got != tt.commit {
+					t.Errorf("Commit(%q) = %q, %v, want %q", tt.branches[0], got, ok, tt.commit)
+				}
+			}
+		})
+	}
+}
+
+func TestParseWebhook_RejectsBadSignatures(t *testing.T) {
+	body := loadWebhookFixture(t, "github_push.json")
+	tampered := append([]byte{}, body...)
+	tampered[len(tampered)-2] = ' '
+
+	tests := []struct {
+		name   string
+		header http.Header
+		body   []byte
+		secret string
+	}{
+		{
+			name:   "github signature with another secret",
+			header: http.Header{"X-Github-Event": {"push"}, "X-Hub-Signature-256": {"sha256=" + signWebhook(body, "other")}},
+			body:   body,
+			secret: testWebhookSecret,
+		},
+		{
+			name:   "github tampered body",
+			header: http.Header{"X-Github-Event": {"push"}, "X-Hub-Signature-256": {"sha256=" + signWebhook(body, testWebhookSecret)}},
+			body:   tampered,
+			secret: testWebhookSecret,
+		},
+		{
+			name:   "github missing signature",
+			header: http.Header{"X-Github-Event": {"push"}},
+			body:   body,
+			secret: testWebhookSecret,
+		},
+		{
+			name:   "gitlab wrong token",
+			header: http.Header{"X-Gitlab-Event": {"Push Hook"}, "X-Gitlab-Token": {"guess"}},
+			body:   loadWebhookFixture(t, "gitlab_push.json"),
+			secret: testWebhookSecret,
+		},
+		{
+			name:   "no secret configured",
+			header: http.Header{"X-Gitlab-Event": {"Push Hook"}, "X-Gitlab-Token": {""}},
+			body:   loadWebhookFixture(t, "gitlab_push.json"),
+			secret: "",
+		},
+		{
+			name:   "gitea signature not hex",
+			header: http.Header{"X-Gitea-Event": {"push"}, "X-Gitea-Signature": {"not-hex"}},
+			body:   loadWebhookFixture(t, "gitea_push.json"),
+			secret: testWebhookSecret,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseWebhook(tt.header, tt.body, tt.secret); !errors.Is(err, ErrWebhookSignature) {
+				t.Errorf("ParseWebhook() error = %v, want %v", err, ErrWebhookSignature)
+			}
+		})
+	}
+}
+
+func TestParseWebhook_UnknownProvider(t *testing.T) {
+	body := loadWebhookFixture(t, "github_push.json")
+	if _, err := ParseWebhook(http.Header{}, body, testWebhookSecret); !errors.Is(err, ErrWebhookUnknownProvider) {
+		t.Errorf("ParseWebhook() error = %v, want %v", err, ErrWebhookUnknownProvider)
+	}
+}
+
+func TestParseWebhook_InvalidPayload(t *testing.T) {
+	body := []byte(`{"ref": 42}`)
+	header := http.Header{"X-Gitea-Event": {"push"}, "X-Gitea-Signature": {signWebhook(body, testWebhookSecret)}}
+	if _, err := ParseWebhook(header, body, testWebhookSecret); !errors.Is(err, ErrWebhookPayload) {
+		t.Errorf("ParseWebhook() error = %v, want %v", err, ErrWebhookPayload)
+	}
+}
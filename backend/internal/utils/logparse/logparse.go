@@ -0,0 +1,408 @@
+// Package logparse extracts the level, message, timestamp and fields of log lines in
+// common formats: JSON, logfmt, nginx/apache access logs and klog.
+package logparse
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Formats recognized by a Parser. FormatText is reported for lines in none of them.
+const (
+	FormatJSON   = "json"
+	FormatLogfmt = "logfmt"
+	FormatAccess = "access"
+	FormatKlog   = "klog"
+	FormatText   = "text"
+)
+
+// maxFields caps the number of fields kept per line.
+const maxFields = 64
+
+// Entry is a parsed log line.
+type Entry struct {
+	Format    string            `json:"format"`
+	Level     string            `json:"level,omitempty"`
+	Message   string            `json:"message,omitempty"`
+	Timestamp string            `json:"timestamp,omitempty"`
+	Fields    map[string]string `json:"fields,omitempty"`
+}
+
+// Parser parses log lines in a set of formats.
+type Parser struct {
+	json, logfmt, access, klog bool
+}
+
+// NewParser returns a Parser for the given formats, or for all formats if none are
+// given. "auto" also selects all formats.
+func NewParser(formats ...string) (*Parser, error) {
+	p := &Parser{}
+	for _, format := range formats {
+		switch strings.ToLower(strings.TrimSpace(format)) {
+		case "auto", "":
+			p.json, p.logfmt, p.access, p.klog = true, true, true, true
+		case FormatJSON:
+			p.json = true
+		case FormatLogfmt:
+			p.logfmt = true
+		case FormatAccess, "nginx", "apache":
+			p.access = true
+		case FormatKlog:
+			p.klog = true
+		default:
+			return nil, fmt.Errorf("unknown log format %q", format)
+		}
+	}
+	if len(formats) == 0 {
+		p.json, p.logfmt, p.access, p.klog = true, true, true, true
+	}
+	return p, nil
+}
+
+// Parse parses a log line. Lines in none of the parser's formats are returned with
+// FormatText and the level found by DetectLevel, without message or fields.
+func (p *Parser) Parse(line string) Entry {
+	line = strings.TrimSpace(line)
+	if p.json && len(line) > 1 && line[0] == '{' && line[len(line)-1] == '}' {
+		if e, ok := parseJSON(line); ok {
+			return e
+		}
+	}
+	if p.klog && len(line) > 0 && strings.IndexByte("IWEF", line[0]) >= 0 {
+		if e, ok := parseKlog(line); ok {
+			return e
+		}
+	}
+	if p.access && strings.Contains(line, `] "`) {
+		if e, ok := parseAccess(line); ok {
+			return e
+		}
+	}
+	if p.logfmt && strings.IndexByte(line, '=') > 0 {
+		if e, ok := parseLogfmt(line); ok {
+			return e
+		}
+	}
+	return Entry{Format: FormatText, Level: DetectLevel([]byte(line))}
+}
+
+var (
+	levelKeys     = []string{"level", "lvl", "severity", "loglevel", "log.level", "levelname"}
+	messageKeys   = []string{"msg", "message", "log", "text"}
+	timestampKeys = []string{"time", "ts", "timestamp", "@timestamp", "t", "datetime"}
+)
+
+func parseJSON(line string) (Entry, bool) {
+	dec := json.NewDecoder(strings.NewReader(line))
+	dec.UseNumber()
+	var obj map[string]any
+	if err := dec.Decode(&obj); err != nil {
+		return Entry{}, false
+	}
+
+	fields := make(map[string]string, len(obj))
+	for k, v := range obj {
+		if len(fields) == maxFields {
+			break
+		}
+		fields[k] = jsonValueString(v)
+	}
+
+	e := Entry{Format: FormatJSON}
+	if level, ok := takeField(fields, levelKeys); ok {
+		e.Level = normalizeLevel(level)
+	}
+	e.Message, _ = takeField(fields, messageKeys)
+	if ts, ok := takeField(fields, timestampKeys); ok {
+		e.Timestamp = normalizeTimestamp(ts)
+	}
+	if len(fields) > 0 {
+		e.Fields = fields
+	}
+	return e, true
+}
+
+func jsonValueString(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case nil:
+		return ""
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		b, _ := json.Marshal(v)
+		return string(b)
+	}
+}
+
+// takeField removes and returns the first of keys present in fields.
+func takeField(fields map[string]string, keys []string) (string, bool) {
+	for _, k := range keys {
+		if v, ok := fields[k]; ok {
+			delete(fields, k)
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// parseLogfmt parses a line of key=value pairs. It needs at least two pairs to tell
+// logfmt apart from text that happens to contain an equals sign.
+func parseLogfmt(line string) (Entry, bool) {
+	fields, pairs, ok := parsePairs(line)
+	if !ok || pairs < 2 {
+		return Entry{}, false
+	}
+
+	e := Entry{Format: FormatLogfmt}
+	if level, ok := takeField(fields, levelKeys); ok {
+		e.Level = normalizeLevel(level)
+	}
+	e.Message, _ = takeField(fields, messageKeys)
+	if ts, ok := takeField(fields, timestampKeys); ok {
+		e.Timestamp = normalizeTimestamp(ts)
+	}
+	if len(fields) > 0 {
+		e.Fields = fields
+	}
+	return e, true
+}
+
+// parsePairs parses key=value pairs and bare keys. Values may be double-quoted with Go
+// escapes. It returns the fields, the number of pairs and false if the line contains
+// anything else.
+func parsePairs(line string) (map[string]string, int, bool) {
+	fields := map[string]string{}
+	pairs := 0
+	for i := 0; i < len(line); {
+		for i < len(line) && line[i] == ' ' {
+			i++
+		}
+		if i == len(line) {
+			break
+		}
+
+		start := i
+		for i < len(line) && line[i] != '=' && line[i] != ' ' && line[i] != '"' {
+			i++
+		}
+		key := line[start:i]
+		if key == "" {
+			return nil, 0, false
+		}
+		if i == len(line) || line[i] == ' ' {
+			fields[key] = "true"
+			continue
+		}
+		if line[i] != '=' {
+			return nil, 0, false
+		}
+		i++
+
+		var value string
+		if i < len(line) && line[i] == '"' {
+			end := i + 1
+			for end < len(line) && line[end] != '"' {
+				if line[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(line) {
+				return nil, 0, false
+			}
+			unquoted, err := strconv.Unquote(line[i : end+1])
+			if err != nil {
+				return nil, 0, false
+			}
+			value, i = unquoted, end+1
+		} else {
+			start := i
+			for i < len(line) && line[i] != ' ' {
+				i++
+			}
+			value = line[start:i]
+		}
+		if i < len(line) && line[i] != ' ' {
+			return nil, 0, false
+		}
+		if len(fields) < maxFields {
+			fields[key] = value
+		}
+		pairs++
+	}
+	return fields, pairs, true
+}
+
+// accessPattern matches the common and combined log formats of nginx and apache.
+var accessPattern = regexp.MustCompile(`^(\S+) (\S+) (\S+) \[([^\]]+)\] "([^"]*)" (\d{3}) (\d+|-)(?: "([^"]*)" "([^"]*)")?`)
+
+func parseAccess(line string) (Entry, bool) {
+	m := accessPattern.FindStringSubmatch(line)
+	if m == nil {
+		return Entry{}, false
+	}
+
+	fields := map[string]string{
+		"remote_addr": m[1],
+		"status":      m[6],
+	}
+	if m[3] != "-" {
+		fields["remote_user"] = m[3]
+	}
+	if m[7] != "-" {
+		fields["body_bytes_sent"] = m[7]
+	}
+	if method, rest, ok := strings.Cut(m[5], " "); ok {
+		fields["method"] = method
+		path, protocol, _ := strings.Cut(rest, " ")
+		fields["path"] = path
+		if protocol != "" {
+			fields["protocol"] = protocol
+		}
+	}
+	if m[8] != "" && m[8] != "-" {
+		fields["referer"] = m[8]
+	}
+	if m[9] != "" && m[9] != "-" {
+		fields["user_agent"] = m[9]
+	}
+
+	e := Entry{Format: FormatAccess, Message: m[5], Fields: fields, Level: "info"}
+	switch m[6][0] {
+	case '5':
+		e.Level = "error"
+	case '4':
+		e.Level = "warn"
+	}
+	if t, err := time.Parse("02/Jan/2006:15:04:05 -0700", m[4]); err == nil {
+		e.Timestamp = t.UTC().Format(time.RFC3339Nano)
+	}
+	return e, true
+}
+
+// klogPattern matches the klog header: Lmmdd hh:mm:ss.uuuuuu threadid file:line] msg
+var klogPattern = regexp.MustCompile(`^([IWEF])(\d{4} \d{2}:\d{2}:\d{2}\.\d{6})\s+(\d+) ([^\]\s]+)\] (.*)$`)
+
+func parseKlog(line string) (Entry, bool) {
+	m := klogPattern.FindStringSubmatch(line)
+	if m == nil {
+		return Entry{}, false
+	}
+
+	e := Entry{
+		Format:  FormatKlog,
+		Level:   klogLevels[m[1]],
+		Message: m[5],
+		Fields:  map[string]string{"time": m[2], "thread": m[3], "source": m[4]},
+	}
+	// Structured klog: "message" key=value ...
+	if strings.HasPrefix(e.Message, `"`) {
+		if quoted, err := strconv.QuotedPrefix(e.Message); err == nil {
+			if kv, _, ok := parsePairs(e.Message[len(quoted):]); ok {
+				maps.Copy(e.Fields, kv)
+				e.Message, _ = strconv.Unquote(quoted)
+			}
+		}
+	}
+	return e, true
+}
+
+var klogLevels = map[string]string{"I": "info", "W": "warn", "E": "error", "F": "fatal"}
+
+// levelAliases maps level names found in logs to trace, debug, info, warn, error or
+// fatal.
+var levelAliases = map[string]string{
+	"trace": "trace", "trc": "trace",
+	"debug": "debug", "dbg": "debug",
+	"info": "info", "inf": "info", "notice": "info", "information": "info",
+	"warn": "warn", "warning": "warn", "wrn": "warn",
+	"error": "error", "err": "error", "eror": "error",
+	"fatal": "fatal", "ftl": "fatal", "crit": "fatal", "critical": "fatal", "panic": "fatal", "emerg": "fatal", "alert": "fatal",
+}
+
+// NormalizeLevel maps a level name to trace, debug, info, warn, error or fatal. It
+// returns "" for unknown names.
+func NormalizeLevel(level string) string {
+	return levelAliases[strings.ToLower(strings.TrimSpace(level))]
+}
+
+// normalizeLevel also accepts the numeric levels of pino and bunyan, and keeps unknown
+// names lower-cased.
+func normalizeLevel(level string) string {
+	if n, err := strconv.Atoi(level); err == nil {
+		switch {
+		case n >= 60:
+			return "fatal"
+		case n >= 50:
+			return "error"
+		case n >= 40:
+			return "warn"
+		case n >= 30:
+			return "info"
+		case n >= 20:
+			return "debug"
+		default:
+			return "trace"
+		}
+	}
+	if normalized := NormalizeLevel(level); normalized != "" {
+		return normalized
+	}
+	return strings.ToLower(level)
+}
+
+// normalizeTimestamp converts RFC 3339 times and Unix timestamps in seconds or
+// milliseconds to RFC 3339 in UTC, and keeps other values as they are.
+func normalizeTimestamp(ts string) string {
+	if t, err := time.Parse(time.RFC3339Nano, ts); err == nil {
+		return t.UTC().Format(time.RFC3339Nano)
+	}
+	if f, err := strconv.ParseFloat(ts, 64); err == nil && f > 0 {
+		if f > 1e12 {
+			f /= 1000
+		}
+		sec := int64(f)
+		return time.Unix(sec, int64((f-float64(sec))*1e9)).UTC().Format(time.RFC3339Nano)
+	}
+	return ts
+}
+
+var (
+	levelFieldPattern = regexp.MustCompile(`(?i)"?\b(?:level|lvl|severity|loglevel)"?\s*[:=]\s*"?([a-z]+)`)
+	levelWordPattern  = regexp.MustCompile(`(?i)(?:^|[\s\[(<|])(trace|trc|debug|dbg|info|inf|notice|warn|warning|wrn|error|err|eror|fatal|ftl|crit|critical|panic|emerg|alert)(?:$|[\s\]):>|])`)
+	klogPrefixPattern = regexp.MustCompile(`^([IWEF])\d{4} `)
+)
+
+// levelScanLimit is how far into a line a bare level word is looked for.
+const levelScanLimit = 128
+
+// DetectLevel returns the normalized level of an unparsed log line: a level or severity
+// field, a klog prefix or a level word near the start of the line. It returns "" if
+// none is found.
+func DetectLevel(line []byte) string {
+	if m := levelFieldPattern.FindSubmatch(line); m != nil {
+		if level := NormalizeLevel(string(m[1])); level != "" {
+			return level
+		}
+	}
+	if m := klogPrefixPattern.FindSubmatch(line); m != nil {
+		return klogLevels[string(m[1])]
+	}
+	if len(line) > levelScanLimit {
+		line = line[:levelScanLimit]
+	}
+	if m := levelWordPattern.FindSubmatch(bytes.TrimSpace(line)); m != nil {
+		return NormalizeLevel(string(m[1]))
+	}
+	return ""
+}
@@ -0,0 +1,139 @@
+package logparse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_ParseJSON(t *testing.T) {
+	p, err := NewParser()
+	require.NoError(t, err)
+
+	e := p.Parse(`{"level":"WARNING","msg":"disk almost full","time":"2024-05-01T12:30:00+02:00","disk":"/dev/sda1","used":0.93,"tags":["a"]}`)
+	assert.Equal(t, Entry{
+		Format:    FormatJSON,
+		Level:     "warn",
+		Message:   "disk almost full",
+		Timestamp: "2024-05-01T10:30:00Z",
+		Fields:    map[string]string{"disk": "/dev/sda1", "used": "0.93", "tags": `["a"]`},
+	}, e)
+
+	// pino levels and millisecond timestamps
+	e = p.Parse(`{"level":50,"time":1714559400000,"msg":"request failed","reqId":7}`)
+	assert.Equal(t, "error", e.Level)
+	assert.Equal(t, "2024-05-01T10:30:00Z", e.Timestamp)
+	assert.Equal(t, map[string]string{"reqId": "7"}, e.Fields)
+}
+
+func TestParser_ParseLogfmt(t *testing.T) {
+	p, err := NewParser(FormatLogfmt)
+	require.NoError(t, err)
+
+	e := p.Parse(`ts=2024-05-01T10:30:00Z level=error msg="upstream timeout" upstream=api:8080 retry`)
+	assert.Equal(t, Entry{
+		Format:    FormatLogfmt,
+		Level:     "error",
+		Message:   "upstream timeout",
+		Timestamp: "2024-05-01T10:30:00Z",
+		Fields:    map[string]string{"upstream": "api:8080", "retry": "true"},
+	}, e)
+
+	// A single pair or an unterminated quote is not logfmt.
+	assert.Equal(t, FormatText, p.Parse(`starting server port=8080`).Format)
+	assert.Equal(t, FormatText, p.Parse(`a=1 msg="unterminated`).Format)
+}
+
+func TestParser_ParseAccess(t *testing.T) {
+	p, err := NewParser("nginx")
+	require.NoError(t, err)
+
+	e := p.Parse(`172.17.0.1 - alice [01/May/2024:12:30:00 +0200] "GET /api/health?full=1 HTTP/1.1" 503 19 "-" "curl/8.5.0"`)
+	assert.Equal(t, Entry{
+		Format:    FormatAccess,
+		Level:     "error",
+		Message:   "GET /api/health?full=1 HTTP/1.1",
+		Timestamp: "2024-05-01T10:30:00Z",
+		Fields: map[string]string{
+			"remote_addr":     "172.17.0.1",
+			"remote_user":     "alice",
+			"method":          "GET",
+			"path":            "/api/health?full=1",
+			"protocol":        "HTTP/1.1",
+			"status":          "503",
+			"body_bytes_sent": "19",
+			"user_agent":      "curl/8.5.0",
+		},
+	}, e)
+
+	e = p.Parse(`10.0.0.2 - - [01/May/2024:12:30:00 +0000] "POST /login HTTP/1.1" 401 -`)
+	assert.Equal(t, "warn", e.Level)
+	assert.NotContains(t, e.Fields, "body_bytes_sent")
+}
+
+func TestParser_ParseKlog(t *testing.T) {
+	p, err := NewParser(FormatKlog)
+	require.NoError(t, err)
+
+	e := p.Parse(`E0501 10:30:00.123456       1 controller.go:42] sync failed`)
+	assert.Equal(t, Entry{
+		Format:  FormatKlog,
+		Level:   "error",
+		Message: "sync failed",
+		Fields:  map[string]string{"time": "0501 10:30:00.123456", "thread": "1", "source": "controller.go:42"},
+	}, e)
+
+	e = p.Parse(`I0501 10:30:00.123456    4242 reconciler.go:7] "Pod updated" pod="default/web" ready=true`)
+	assert.Equal(t, "info", e.Level)
+	assert.Equal(t, "Pod updated", e.Message)
+	assert.Equal(t, "default/web", e.Fields["pod"])
+	assert.Equal(t, "true", e.Fields["ready"])
+}
+
+func TestParser_ParseText(t *testing.T) {
+	p, err := NewParser(FormatJSON)
+	require.NoError(t, err)
+
+	// Formats that were not selected are left as text.
+	e := p.Parse(`level=error msg="upstream timeout"`)
+	assert.Equal(t, Entry{Format: FormatText, Level: "error"}, e)
+
+	assert.Equal(t, Entry{Format: FormatText}, p.Parse(`{not json}`))
+}
+
+func TestNewParser_UnknownFormat(t *testing.T) {
+	_, err := NewParser("json", "syslog")
+	require.Error(t, err)
+}
+
+func TestDetectLevel(t *testing.T) {
+	for line, want := range map[string]string{
+		`{"level":"warning","msg":"disk almost full"}`:         "warn",
+		`time=2024-05-01 lvl=dbg msg=tick`:                     "debug",
+		`E0501 10:30:00.000000       1 controller.go:42] boom`: "error",
+		`2024/05/01 10:30:00 [crit] worker exited`:             "fatal",
+		`[INFO] server started`:                                "info",
+		`GET /healthz 200`:                                     "",
+		`information about errors`:                             "",
+	} {
+		assert.Equal(t, want, DetectLevel([]byte(line)), line)
+	}
+}
+
+func BenchmarkParser_Parse(b *testing.B) {
+	p, err := NewParser()
+	require.NoError(b, err)
+
+	lines := []string{
+		`{"level":"info","time":"2024-05-01T10:30:00Z","msg":"request completed","method":"GET","path":"/api/health","status":200,"duration":0.0012}`,
+		`ts=2024-05-01T10:30:00Z level=info msg="request completed" method=GET path=/api/health status=200`,
+		`172.17.0.1 - - [01/May/2024:12:30:00 +0200] "GET /api/health HTTP/1.1" 200 19 "-" "curl/8.5.0"`,
+		`I0501 10:30:00.123456       1 controller.go:42] "Reconciled" object="default/web"`,
+		`2024/05/01 10:30:00 [notice] 1#1: start worker processes`,
+	}
+	b.ReportAllocs()
+	for i := 0; b.Loop(); i++ {
+		_ = p.Parse(lines[i%len(lines)])
+	}
+}
@@ -0,0 +1,340 @@
+// Package rbac defines Arcane's built-in user roles and the permissions they grant
+// on each resource type.
+package rbac
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/getarcaneapp/arcane/backend/internal/models"
+)
+
+// Built-in roles. A user may hold several roles; their permissions are combined.
+const (
+	// RoleAdmin has full access, including the admin-only endpoints (users, API keys, settings updates, ...).
+	RoleAdmin = "admin"
+	// RoleUser is the legacy non-admin role and keeps its historical read/write access to all resources.
+	RoleUser = "user"
+	// RoleOperator manages workloads (containers, projects, images, networks, volumes, GitOps)
+	// but can only view registries and settings, so it cannot push images with stored credentials.
+	RoleOperator = "operator"
+	// RoleProjectDeployer can deploy and manage projects and view everything else.
+	RoleProjectDeployer = "project-deployer"
+	// RoleViewer has read-only access to all resources, short of reading files inside containers.
+	RoleViewer = "viewer"
+)
+
+// Resource is a resource type permissions are granted on.
+type Resource string
+
+const (
+	ResourceContainers Resource = "containers"
+	ResourceProjects   Resource = "projects"
+	ResourceVolumes    Resource = "volumes"
+	ResourceImages     Resource = "images"
+	ResourceNetworks   Resource = "networks"
+	ResourceRegistries Resource = "registries"
+	ResourceSettings   Resource = "settings"
+	ResourceGitOps     Resource = "gitops"
+)
+
+// Action is the kind of access requested on a resource. Write implies read.
+type Action string
+
+const (
+	ActionRead  Action = "read"
+	ActionWrite Action = "write"
+)
+
+var allResources = []Resource{
+	ResourceContainers,
+	ResourceProjects,
+	ResourceVolumes,
+	ResourceImages,
+	ResourceNetworks,
+	ResourceRegistries,
+	ResourceSettings,
+	ResourceGitOps,
+}
+
+// rolePermissions holds the highest action each role is granted per resource.
+// Resources missing from a role's map are not accessible to that role.
+var rolePermissions = map[string]map[Resource]Action{
+	RoleAdmin: grantAll(ActionWrite),
+	RoleUser:  grantAll(ActionWrite),
+	RoleOperator: {
+		ResourceContainers: ActionWrite,
+		ResourceProjects:   ActionWrite,
+		ResourceVolumes:    ActionWrite,
+		ResourceImages:     ActionWrite,
+		ResourceNetworks:   ActionWrite,
+		ResourceGitOps:     ActionWrite,
+		ResourceRegistries: ActionRead,
+		ResourceSettings:   ActionRead,
+	},
+	RoleProjectDeployer: {
+		ResourceProjects:   ActionWrite,
+		ResourceContainers: ActionRead,
+		ResourceVolumes:    ActionRead,
+		ResourceImages:     ActionRead,
+		ResourceNetworks:   ActionRead,
+		ResourceGitOps:     ActionRead,
+		ResourceRegistries: ActionRead,
+		ResourceSettings:   ActionRead,
+	},
+	RoleViewer: grantAll(ActionRead),
+}
+
+func grantAll(action Action) map[Resource]Action {
+	out := make(map[Resource]Action, len(allResources))
+	for _, r := range allResources {
+		out[r] = action
+	}
+	return out
+}
+
+// Roles returns the names of all built-in roles.
+func Roles() []string {
+	return []string{RoleAdmin, RoleUser, RoleOperator, RoleProjectDeployer, RoleViewer}
+}
+
+// IsKnownRole reports whether role is one of the built-in roles (case-insensitive).
+func IsKnownRole(role string) bool {
+	_, ok := rolePermissions[normalizeRole(role)]
+	return ok
+}
+
+// NormalizeRoles lowercases and de-duplicates roles, preserving order.
+func NormalizeRoles(roles []string) []string {
+	out := make([]string, 0, len(roles))
+	seen := make(map[string]struct{}, len(roles))
+	for _, r := range roles {
+		n := normalizeRole(r)
+		if n == "" {
+			continue
+		}
+		if _, ok := seen[n]; ok {
+			continue
+		}
+		seen[n] = struct{}{}
+		out = append(out, n)
+	}
+	return out
+}
+
+// Allowed reports whether any of roles grants action on resource.
+func Allowed(roles []string, resource Resource, action Action) bool {
+	for _, r := range roles {
+		granted, ok := rolePermissions[normalizeRole(r)][resource]
+		if !ok {
+			continue
+		}
+		if granted == ActionWrite || granted == action {
+			return true
+		}
+	}
+	return false
+}
+
+// segmentResources maps the first path segment of an API route (after the
+// optional /environments/{id} prefix) to the resource it operates on.
+var segmentResources = map[string]Resource{
+	"containers":           ResourceContainers,
+	"updater":              ResourceContainers,
+	"projects":             ResourceProjects,
+	"templates":            ResourceProjects,
+	"volumes":              ResourceVolumes,
+	"images":               ResourceImages,
+	"image-updates":        ResourceImages,
+	"vulnerabilities":      ResourceImages,
+	"networks":             ResourceNetworks,
+	"container-registries": ResourceRegistries,
+	"settings":             ResourceSettings,
+	"backup-targets":       ResourceSettings,
+	"customize":            ResourceSettings,
+	"gitops-syncs":         ResourceGitOps,
+	"git-repositories":     ResourceGitOps,
+}
+
+// writeOnGet lists trailing path segments of GET routes that need write access: routes
+// that mutate state (e.g. an interactive exec session opened over a WebSocket) and routes
+// that read file contents out of a container, which may hold its secrets.
+var writeOnGet = []string{
+	"terminal",
+	"files/content",
+	"files/download",
+}
+
+// permission is an action on a resource.
+type permission struct {
+	resource Resource
+	action   Action
+}
+
+// extraPermissions lists trailing path segments of routes that need a permission on a
+// second resource on top of their own, e.g. pushing an image uses stored registry
+// credentials.
+var extraPermissions = map[string]permission{
+	"images/push": {ResourceRegistries, ActionWrite},
+}
+
+// ForRequest resolves the permission needed for an API request. path may be a
+// concrete request path or a route template and may include the /api prefix.
+// ok is false when the route is not subject to role permissions.
+func ForRequest(method, path string) (resource Resource, action Action, ok bool) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) > 0 && segments[0] == "api" {
+		segments = segments[1:]
+	}
+	if len(segments) >= 3 && segments[0] == "environments" {
+		segments = segments[2:]
+		if len(segments) > 0 && segments[0] == "ws" {
+			segments = segments[1:]
+		}
+	}
+	if len(segments) == 0 {
+		return "", "", false
+	}
+
+	first := segments[0]
+	if first == "customize" && len(segments) > 1 && segments[1] == "git-repositories" {
+		first = "git-repositories"
+	}
+
+	resource, ok = segmentResources[first]
+	if !ok {
+		return "", "", false
+	}
+
+	return resource, ActionFor(method, path), true
+}
+
+// RequestAllowed reports whether roles grant every permission an API request needs.
+// Requests outside the role model are allowed.
+func RequestAllowed(roles []string, method, path string) bool {
+	resource, action, ok := ForRequest(method, path)
+	if !ok {
+		return true
+	}
+	if !Allowed(roles, resource, action) {
+		return false
+	}
+	for suffix, extra := range extraPermissions {
+		if hasPathSuffixInternal(path, suffix) && !Allowed(roles, extra.resource, extra.action) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasPathSuffixInternal reports whether path ends with the path segments in suffix.
+func hasPathSuffixInternal(path, suffix string) bool {
+	return strings.HasSuffix("/"+strings.Trim(path, "/"), "/"+suffix)
+}
+
+// ActionFor returns the action an API request performs: safe methods read, everything
+// else (and the few GET routes listed in writeOnGet) writes.
+func ActionFor(method, path string) Action {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		for _, suffix := range writeOnGet {
+			if hasPathSuffixInternal(path, suffix) {
+				return ActionWrite
+			}
+		}
+		return ActionRead
+	default:
+		return ActionWrite
+	}
+}
+
+// EnvironmentIDFromPath extracts the environment ID from an API path such as
+// /api/environments/{id}/containers. ok is false for paths outside an environment.
+func EnvironmentIDFromPath(path string) (string, bool) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := 0; i+1 < len(segments); i++ {
+		if segments[i] != "environments" {
+			continue
+		}
+		id := segments[i+1]
+		if id == "" || id == "pair" {
+			return "", false
+		}
+		return id, true
+	}
+	return "", false
+}
+
+// CanAccessEnvironment reports whether user may perform action on the given environment
+// according to their environment grants and those of the API key they authenticated with.
+func CanAccessEnvironment(user *models.User, environmentID string, action Action) bool {
+	if user == nil {
+		return false
+	}
+	return EnvironmentAccessFor(user).Allows(environmentID, action == ActionWrite)
+}
+
+// EnvironmentAccessFor returns the environment grant that applies to user; nil means all
+// environments. An admin's own grant is ignored, but the restriction of the API key the
+// request authenticated with always applies.
+func EnvironmentAccessFor(user *models.User) models.EnvironmentAccess {
+	if user == nil {
+		return nil
+	}
+	access := user.EnvironmentAccess
+	if hasRole(user.Roles, RoleAdmin) {
+		access = nil
+	}
+	return access.Intersect(user.KeyEnvironmentAccess)
+}
+
+// IsAdmin reports whether user may use admin-only endpoints. An API key limited to
+// some environments never does, even when it belongs to an admin, so it cannot reach
+// global resources or mint keys wider than itself.
+func IsAdmin(user *models.User) bool {
+	return user != nil && hasRole(user.Roles, RoleAdmin) && user.KeyEnvironmentAccess == nil
+}
+
+func normalizeRole(role string) string {
+	return strings.ToLower(strings.TrimSpace(role))
+}
+
+func hasRole(roles []string, role string) bool {
+	for _, r := range roles {
+		if normalizeRole(r) == role {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseRoleMapping parses an OIDC role mapping of the form
+// "arcane-ops=operator,arcane-viewers=viewer" into claim value -> role.
+// Claim values are matched case-insensitively; entries naming unknown roles are skipped.
+func ParseRoleMapping(raw string) map[string]string {
+	out := map[string]string{}
+	for entry := range strings.SplitSeq(raw, ",") {
+		idx := strings.LastIndex(entry, "=")
+		if idx <= 0 {
+			continue
+		}
+		value := strings.ToLower(strings.TrimSpace(entry[:idx]))
+		role := normalizeRole(entry[idx+1:])
+		if value == "" || !IsKnownRole(role) {
+			continue
+		}
+		out[value] = role
+	}
+	return out
+}
+
+// MapClaimValues returns the roles mapping grants for the given claim values.
+func MapClaimValues(values []string, mapping map[string]string) []string {
+	var roles []string
+	for _, v := range values {
+		if role, ok := mapping[strings.ToLower(strings.TrimSpace(v))]; ok {
+			roles = append(roles, role)
+		}
+	}
+	return NormalizeRoles(roles)
+}
@@ -0,0 +1,225 @@
+package rbac
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/getarcaneapp/arcane/backend/internal/models"
+)
+
+func TestAllowed(t *testing.T) {
+	tests := []struct {
+		name     string
+		roles    []string
+		resource Resource
+		action   Action
+		want     bool
+	}{
+		{name: "admin writes settings", roles: []string{RoleAdmin}, resource: ResourceSettings, action: ActionWrite, want: true},
+		{name: "legacy user writes containers", roles: []string{RoleUser}, resource: ResourceContainers, action: ActionWrite, want: true},
+		{name: "viewer reads containers", roles: []string{RoleViewer}, resource: ResourceContainers, action: ActionRead, want: true},
+		{name: "viewer cannot write containers", roles: []string{RoleViewer}, resource: ResourceContainers, action: ActionWrite, want: false},
+		{name: "operator writes volumes", roles: []string{RoleOperator}, resource: ResourceVolumes, action: ActionWrite, want: true},
+		{name: "operator cannot write registries", roles: []string{RoleOperator}, resource: ResourceRegistries, action: ActionWrite, want: false},
+		{name: "operator cannot write settings", roles: []string{RoleOperator}, resource: ResourceSettings, action: ActionWrite, want: false},
+		{name: "deployer writes projects", roles: []string{RoleProjectDeployer}, resource: ResourceProjects, action: ActionWrite, want: true},
+		{name: "deployer cannot write containers", roles: []string{RoleProjectDeployer}, resource: ResourceContainers, action: ActionWrite, want: false},
+		{name: "deployer reads containers", roles: []string{RoleProjectDeployer}, resource: ResourceContainers, action: ActionRead, want: true},
+		{name: "roles are combined", roles: []string{RoleViewer, RoleProjectDeployer}, resource: ResourceProjects, action: ActionWrite, want: true},
+		{name: "role names are case-insensitive", roles: []string{" Operator "}, resource: ResourceImages, action: ActionWrite, want: true},
+		{name: "unknown role grants nothing", roles: []string{"guest"}, resource: ResourceContainers, action: ActionRead, want: false},
+		{name: "no roles", roles: nil, resource: ResourceContainers, action: ActionRead, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Allowed(tt.roles, tt.resource, tt.action); got != tt.want {
+				t.Errorf("Allowed(%v, %s, %s) = %v, want %v", tt.roles, tt.resource, tt.action, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestForRequest(t *testing.T) {
+	tests := []struct {
+		method       string
+		path         string
+		wantResource Resource
+		wantAction   Action
+		wantOK       bool
+	}{
+		{http.MethodGet, "/environments/{id}/containers", ResourceContainers, ActionRead, true},
+		{http.MethodPost, "/environments/{id}/containers/{containerId}/start", ResourceContainers, ActionWrite, true},
+		{http.MethodDelete, "/api/environments/abc/volumes/data", ResourceVolumes, ActionWrite, true},
+		{http.MethodPost, "/environments/{id}/projects/{projectId}/up", ResourceProjects, ActionWrite, true},
+		{http.MethodGet, "/environments/{id}/image-updates/summary", ResourceImages, ActionRead, true},
+		{http.MethodPut, "/container-registries/{id}", ResourceRegistries, ActionWrite, true},
+		{http.MethodPut, "/environments/{id}/settings", ResourceSettings, ActionWrite, true},
+		{http.MethodPost, "/customize/git-repositories", ResourceGitOps, ActionWrite, true},
+		{http.MethodGet, "/api/environments/:id/ws/containers/:containerId/logs", ResourceContainers, ActionRead, true},
+		{http.MethodGet, "/api/environments/:id/ws/containers/:containerId/terminal", ResourceContainers, ActionWrite, true},
+		{http.MethodGet, "/environments/{id}/containers/{containerId}/files", ResourceContainers, ActionRead, true},
+		{http.MethodGet, "/environments/{id}/containers/{containerId}/files/content", ResourceContainers, ActionWrite, true},
+		{http.MethodGet, "/api/environments/0/containers/abc/files/download", ResourceContainers, ActionWrite, true},
+		{http.MethodGet, "/api/environments/:id/ws/system/stats", "", "", false},
+		{http.MethodGet, "/environments/{id}", "", "", false},
+		{http.MethodGet, "/users", "", "", false},
+		{http.MethodPost, "/auth/login", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			resource, action, ok := ForRequest(tt.method, tt.path)
+			if resource != tt.wantResource || action != tt.wantAction || ok != tt.wantOK {
+				t.Errorf("ForRequest() = (%q, %q, %v), want (%q, %q, %v)", resource, action, ok, tt.wantResource, tt.wantAction, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestRequestAllowed(t *testing.T) {
+	tests := []struct {
+		roles  []string
+		method string
+		path   string
+		want   bool
+	}{
+		{[]string{RoleViewer}, http.MethodGet, "/environments/{id}/containers/{containerId}/files", true},
+		{[]string{RoleViewer}, http.MethodGet, "/environments/{id}/containers/{containerId}/files/content", false},
+		{[]string{RoleOperator}, http.MethodGet, "/environments/{id}/containers/{containerId}/files/download", true},
+		{[]string{RoleOperator}, http.MethodPost, "/environments/{id}/images/{imageId}/tag", true},
+		{[]string{RoleOperator}, http.MethodPost, "/environments/{id}/images/push", false},
+		{[]string{RoleUser}, http.MethodPost, "/environments/{id}/images/push", true},
+		{[]string{RoleViewer}, http.MethodGet, "/users", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.roles, ",")+" "+tt.method+" "+tt.path, func(t *testing.T) {
+			if got := RequestAllowed(tt.roles, tt.method, tt.path); got != tt.want {
+				t.Errorf("RequestAllowed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnvironmentIDFromPath(t *testing.T) {
+	tests := []struct {
+		path   string
+		wantID string
+		wantOK bool
+	}{
+		{"/api/environments/prod/containers", "prod", true},
+		{"/environments/0", "0", true},
+		{"/api/environments/:id/ws/system/stats", ":id", true},
+		{"/api/environments", "", false},
+		{"/api/environments/pair", "", false},
+		{"/api/users", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			id, ok := EnvironmentIDFromPath(tt.path)
+			if id != tt.wantID || ok != tt.wantOK {
+				t.Errorf("EnvironmentIDFromPath() = (%q, %v), want (%q, %v)", id, ok, tt.wantID, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestCanAccessEnvironment(t *testing.T) {
+	grant := models.EnvironmentAccess{"prod": models.EnvironmentAccessRead, "staging": models.EnvironmentAccessWrite}
+	readAll := models.EnvironmentAccess{models.EnvironmentAccessAll: models.EnvironmentAccessRead, "dev": models.EnvironmentAccessWrite}
+
+	tests := []struct {
+		name   string
+		roles  []string
+		access models.EnvironmentAccess
+		key    models.EnvironmentAccess
+		env    string
+		action Action
+		want   bool
+	}{
+		{name: "unrestricted user", roles: []string{RoleUser}, access: nil, env: "prod", action: ActionWrite, want: true},
+		{name: "read grant reads", roles: []string{RoleUser}, access: grant, env: "prod", action: ActionRead, want: true},
+		{name: "read grant cannot write", roles: []string{RoleUser}, access: grant, env: "prod", action: ActionWrite, want: false},
+		{name: "write grant writes", roles: []string{RoleUser}, access: grant, env: "staging", action: ActionWrite, want: true},
+		{name: "ungranted environment", roles: []string{RoleUser}, access: grant, env: "dev", action: ActionRead, want: false},
+		{name: "empty grant denies everything", roles: []string{RoleUser}, access: models.EnvironmentAccess{}, env: "0", action: ActionRead, want: false},
+		{name: "wildcard reads other environments", roles: []string{RoleUser}, access: readAll, env: "prod", action: ActionRead, want: true},
+		{name: "wildcard read cannot write", roles: []string{RoleUser}, access: readAll, env: "prod", action: ActionWrite, want: false},
+		{name: "explicit entry overrides wildcard", roles: []string{RoleUser}, access: readAll, env: "dev", action: ActionWrite, want: true},
+		{name: "admin grant is ignored", roles: []string{RoleAdmin}, access: models.EnvironmentAccess{}, env: "prod", action: ActionWrite, want: true},
+		{name: "admin-owned key reaches its environment", roles: []string{RoleAdmin}, key: models.EnvironmentAccess{"staging": models.EnvironmentAccessWrite}, env: "staging", action: ActionWrite, want: true},
+		{name: "admin-owned key is scoped", roles: []string{RoleAdmin}, key: models.EnvironmentAccess{"staging": models.EnvironmentAccessWrite}, env: "prod", action: ActionRead, want: false},
+		{name: "key narrows user grant", roles: []string{RoleUser}, access: grant, key: models.EnvironmentAccess{"staging": models.EnvironmentAccessRead}, env: "staging", action: ActionWrite, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &models.User{Roles: tt.roles, EnvironmentAccess: tt.access, KeyEnvironmentAccess: tt.key}
+			if got := CanAccessEnvironment(user, tt.env, tt.action); got != tt.want {
+				t.Errorf("CanAccessEnvironment(%s, %s) = %v, want %v", tt.env, tt.action, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsAdmin(t *testing.T) {
+	scoped := models.EnvironmentAccess{"staging": models.EnvironmentAccessWrite}
+
+	tests := []struct {
+		name string
+		user *models.User
+		want bool
+	}{
+		{name: "nil user", user: nil, want: false},
+		{name: "admin", user: &models.User{Roles: []string{RoleAdmin}}, want: true},
+		{name: "non-admin", user: &models.User{Roles: []string{RoleOperator}}, want: false},
+		{name: "admin-owned scoped key", user: &models.User{Roles: []string{RoleAdmin}, KeyEnvironmentAccess: scoped}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsAdmin(tt.user); got != tt.want {
+				t.Errorf("IsAdmin() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnvironmentAccessIntersect(t *testing.T) {
+	owner := models.EnvironmentAccess{models.EnvironmentAccessAll: models.EnvironmentAccessRead, "staging": models.EnvironmentAccessWrite}
+	key := models.EnvironmentAccess{"staging": models.EnvironmentAccessWrite, "prod": models.EnvironmentAccessWrite}
+
+	got := owner.Intersect(key)
+	want := models.EnvironmentAccess{"staging": models.EnvironmentAccessWrite, "prod": models.EnvironmentAccessRead}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Intersect() = %v, want %v", got, want)
+	}
+
+	if got := models.EnvironmentAccess(nil).Intersect(key); !reflect.DeepEqual(got, key) {
+		t.Errorf("nil.Intersect() = %v, want %v", got, key)
+	}
+	if got := owner.Intersect(nil); !reflect.DeepEqual(got, owner) {
+		t.Errorf("Intersect(nil) = %v, want %v", got, owner)
+	}
+}
+
+func TestParseRoleMapping(t *testing.T) {
+	mapping := ParseRoleMapping(" Arcane-Ops = operator, arcane-viewers=viewer,bad,x=superuser,=viewer,deploy=Project-Deployer")
+	want := map[string]string{
+		"arcane-ops":     RoleOperator,
+		"arcane-viewers": RoleViewer,
+		"deploy":         RoleProjectDeployer,
+	}
+	if !reflect.DeepEqual(mapping, want) {
+		t.Fatalf("ParseRoleMapping() = %v, want %v", mapping, want)
+	}
+
+	got := MapClaimValues([]string{"ARCANE-OPS", "unrelated", "deploy", "arcane-ops"}, mapping)
+	if !reflect.DeepEqual(got, []string{RoleOperator, RoleProjectDeployer}) {
+		t.Fatalf("MapClaimValues() = %v", got)
+	}
+}
@@ -86,3 +86,51 @@ func TestGetLatestDigestLowerCaseHeader(t *testing.T) {
 		t.Fatalf("digest %q", d)
 	}
 }
+
+func TestListTagsFollowsLinkPagination(t *testing.T) {
+	t.Parallel()
+	var authHeaders []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/library/postgres/tags/list" {
+			http.NotFound(w, r)
+			return
+		}
+		authHeaders = append(authHeaders, r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("last") == "" {
+			w.Header().Set("Link", `</v2/library/postgres/tags/list?n=2&last=16.1>; rel="next"`)
+			_ = json.NewEncoder(w).Encode(map[string]any{"name": "library/postgres", "tags": []string{"16.0", "16.1"}})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"name": "library/postgres", "tags": []string{"16.2"}})
+	}))
+	defer srv.Close()
+
+	c := NewClient()
+	tags, err := c.ListTags(context.Background(), srv.URL, "library/postgres", "tok")
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if fmt.Sprint(tags) != "[16.0 16.1 16.2]" {
+		t.Fatalf("tags = %v", tags)
+	}
+	for _, h := range authHeaders {
+		if h != "Bearer tok" {
+			t.Fatalf("authorization header = %q, want %q", h, "Bearer tok")
+		}
+	}
+}
+
+func TestListTagsUnauthorized(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="https://auth.example/token"`)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	c := NewClient()
+	if _, err := c.ListTags(context.Background(), srv.URL, "private/app", ""); err == nil {
+		t.Fatal("expected error for unauthorized tag list")
+	}
+}
@@ -0,0 +1,110 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// maxTagPages bounds tag list pagination so a misbehaving registry cannot loop forever.
+const maxTagPages = 50
+
+type tagListResponse struct {
+	Name string   `json:"name"`
+	Tags []string `json:"tags"`
+}
+
+// ListTags returns all tags of a repository using the registry's /v2/<name>/tags/list endpoint.
+// Pagination via the Link header (RFC 5988) is followed.
+func (c *Client) ListTags(ctx context.Context, registry, repository, token string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	base := c.GetRegistryURL(registry)
+	next := fmt.Sprintf("%s/v2/%s/tags/list?n=1000", base, repository)
+
+	var tags []string
+	for range maxTagPages {
+		page, link, err := c.fetchTagPage(ctx, next, token)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, page...)
+
+		if link == "" {
+			return tags, nil
+		}
+		next, err = resolveNextLink(base, link)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return tags, nil
+}
+
+func (c *Client) fetchTagPage(ctx context.Context, pageURL, token string) ([]string, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "Arcane")
+	if ah := buildAuthHeader(token); ah != "" {
+		req.Header.Set("Authorization", ah)
+	}
+
+	resp, err := c.http.Do(req) //nolint:gosec // intentional request to user-configured registry endpoint
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		if h := getHeaderCI(resp.Header, ChallengeHeader); h != "" {
+			return nil, "", fmt.Errorf("unauthorized: %s", h)
+		}
+		return nil, "", fmt.Errorf("tag list request failed with status: 401")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("tag list request failed with status: %d", resp.StatusCode)
+	}
+
+	var body tagListResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 16<<20)).Decode(&body); err != nil {
+		return nil, "", fmt.Errorf("decode tag list: %w", err)
+	}
+
+	return body.Tags, parseNextLink(getHeaderCI(resp.Header, "Link")), nil
+}
+
+// parseNextLink extracts the target of a rel="next" Link header, e.g.
+// `</v2/library/nginx/tags/list?n=1000&last=1.25>; rel="next"`.
+func parseNextLink(header string) string {
+	for part := range strings.SplitSeq(header, ",") {
+		target, params, ok := strings.Cut(part, ";")
+		if !ok || !strings.Contains(strings.ReplaceAll(params, " ", ""), `rel="next"`) {
+			continue
+		}
+		target = strings.TrimSpace(target)
+		return strings.TrimSuffix(strings.TrimPrefix(target, "<"), ">")
+	}
+	return ""
+}
+
+func resolveNextLink(base, link string) (string, error) {
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return "", fmt.Errorf("parse registry url: %w", err)
+	}
+	linkURL, err := url.Parse(link)
+	if err != nil {
+		return "", fmt.Errorf("parse next link: %w", err)
+	}
+	return baseURL.ResolveReference(linkURL).String(), nil
+}
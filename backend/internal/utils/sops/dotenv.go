@@ -0,0 +1,118 @@
+package sops
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"filippo.io/age"
+)
+
+// SOPS stores its metadata in dotenv files as flattened sops_ variables, e.g.
+// sops_age__list_0__map_enc.
+const dotenvMetadataPrefix = "sops_"
+
+func isEncryptedDotenvInternal(content string) bool {
+	for line := range strings.SplitSeq(content, "\n") {
+		if strings.HasPrefix(line, dotenvMetadataPrefix+"mac=") {
+			return true
+		}
+	}
+	return false
+}
+
+func dotenvMetadataInternal(lines []string) (metadata, error) {
+	var meta metadata
+	ageKeys := map[int]string{}
+	for _, line := range lines {
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || !strings.HasPrefix(key, dotenvMetadataPrefix) {
+			continue
+		}
+		key = strings.TrimPrefix(key, dotenvMetadataPrefix)
+		switch {
+		case key == "lastmodified":
+			meta.LastModified = value
+		case key == "mac":
+			meta.MAC = value
+		case key == "mac_only_encrypted":
+			meta.MACOnlyEncrypted = value == "true"
+		case strings.HasPrefix(key, "age__list_") && strings.HasSuffix(key, "__map_enc"):
+			index, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(key, "age__list_"), "__map_enc"))
+			if err != nil {
+				return meta, fmt.Errorf("%w: invalid age recipient %s", ErrMalformed, key)
+			}
+			ageKeys[index] = unescapeDotenvValueInternal(value)
+		}
+	}
+	for _, index := range slices.Sorted(maps.Keys(ageKeys)) {
+		meta.AgeKeys = append(meta.AgeKeys, ageKeys[index])
+	}
+	return meta, nil
+}
+
+// decryptDotenvInternal decrypts a dotenv file line by line, so unencrypted variables,
+// comments and blank lines are kept as they are.
+func decryptDotenvInternal(content string, identities []age.Identity) (string, error) {
+	lines := strings.Split(content, "\n")
+	meta, err := dotenvMetadataInternal(lines)
+	if err != nil {
+		return "", err
+	}
+	d, err := newDecrypterInternal(meta, identities)
+	if err != nil {
+		return "", err
+	}
+
+	out := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if comment, ok := strings.CutPrefix(line, "#"); ok {
+			if isEncryptedValueInternal(comment) {
+				// Comments belong to the file's root, which has an empty path. Like SOPS,
+				// keep comments that cannot be decrypted; they are not part of the MAC.
+				if plaintext, _, err := d.decryptValue(comment, additionalDataInternal(nil)); err == nil {
+					line = "#" + plaintext
+				}
+			}
+			out = append(out, line)
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			out = append(out, line)
+			continue
+		}
+		if strings.HasPrefix(key, dotenvMetadataPrefix) {
+			continue
+		}
+
+		if !isEncryptedValueInternal(value) {
+			d.addToMAC(unescapeDotenvValueInternal(value), false)
+			out = append(out, line)
+			continue
+		}
+		plaintext, _, err := d.decryptValue(value, additionalDataInternal([]string{key}))
+		if err != nil {
+			return "", fmt.Errorf("variable %s: %w", key, err)
+		}
+		d.addToMAC(plaintext, true)
+		out = append(out, key+"="+escapeDotenvValueInternal(plaintext))
+	}
+
+	if err := d.verifyMAC(meta); err != nil {
+		return "", err
+	}
+	return strings.Join(out, "\n"), nil
+}
+
+// SOPS writes newlines in dotenv values as \n.
+func unescapeDotenvValueInternal(value string) string {
+	return strings.ReplaceAll(value, `\n`, "\n")
+}
+
+func escapeDotenvValueInternal(value string) string {
+	return strings.ReplaceAll(value, "\n", `\n`)
+}
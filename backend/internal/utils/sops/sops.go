@@ -0,0 +1,227 @@
+// Package sops decrypts files encrypted with SOPS (https://github.com/getsops/sops).
+//
+// Only what GitOps syncs need is implemented: dotenv and YAML files whose data key is
+// encrypted for an age recipient. Values are decrypted with AES-256-GCM and the file's
+// MAC is verified, so tampered files are rejected.
+package sops
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+)
+
+// Format is the format of an encrypted file.
+type Format string
+
+const (
+	FormatDotenv Format = "dotenv"
+	FormatYAML   Format = "yaml"
+)
+
+var (
+	// ErrNoAgeRecipient is returned for files whose data key is not encrypted for an age
+	// recipient, e.g. files encrypted only with KMS or PGP keys.
+	ErrNoAgeRecipient = errors.New("file is not encrypted for an age recipient")
+	// ErrNoMatchingKey is returned when none of the age keys can decrypt the data key.
+	ErrNoMatchingKey = errors.New("no age key matches the file's recipients")
+	// ErrMACMismatch is returned when the file was modified after it was encrypted.
+	ErrMACMismatch = errors.New("MAC mismatch: file was modified after it was encrypted")
+	// ErrMalformed is returned for files with invalid SOPS metadata or encrypted values.
+	ErrMalformed = errors.New("malformed SOPS file")
+)
+
+const dataKeySize = 32
+
+// macOnlyEncryptedInitialization seeds the MAC of files with mac_only_encrypted set, so
+// it differs from the MAC over all values. It is sha256("sops").
+var macOnlyEncryptedInitialization = []byte{0x8a, 0x3f, 0xd2, 0xad, 0x54, 0xce, 0x66, 0x52, 0x7b, 0x10, 0x34, 0xf3, 0xd1, 0x47, 0xbe, 0xb, 0xb, 0x97, 0x5b, 0x3b, 0xf4, 0x4f, 0x72, 0xc6, 0xfd, 0xad, 0xec, 0x81, 0x76, 0xf2, 0x7d, 0x69}
+
+var encryptedValueRe = regexp.MustCompile(`^ENC\[AES256_GCM,data:(.*),iv:(.+),tag:(.+),type:(.+)\]$`)
+
+// Value types recorded in encrypted values.
+const (
+	typeString  = "str"
+	typeInt     = "int"
+	typeFloat   = "float"
+	typeBool    = "bool"
+	typeBytes   = "bytes"
+	typeComment = "comment"
+)
+
+// FormatForPath returns the format SOPS uses for a file: dotenv for .env files and YAML
+// for .yaml and .yml files.
+func FormatForPath(path string) (Format, bool) {
+	base := filepath.Base(path)
+	switch ext := strings.ToLower(filepath.Ext(base)); {
+	case base == ".env" || ext == ".env":
+		return FormatDotenv, true
+	case ext == ".yaml" || ext == ".yml":
+		return FormatYAML, true
+	default:
+		return "", false
+	}
+}
+
+// IsEncrypted reports whether content is a SOPS-encrypted file of the given format.
+func IsEncrypted(content string, format Format) bool {
+	switch format {
+	case FormatDotenv:
+		return isEncryptedDotenvInternal(content)
+	case FormatYAML:
+		return isEncryptedYAMLInternal(content)
+	default:
+		return false
+	}
+}
+
+// ParseAgeKeys parses age secret keys, one AGE-SECRET-KEY-1... per line, in the format
+// written by age-keygen. Comment and blank lines are ignored.
+func ParseAgeKeys(keys string) ([]age.Identity, error) {
+	identities, err := age.ParseIdentities(strings.NewReader(keys))
+	if err != nil {
+		return nil, fmt.Errorf("invalid age key: %w", err)
+	}
+	return identities, nil
+}
+
+// Decrypt decrypts a SOPS-encrypted file with the given age keys and returns the plain
+// file without its SOPS metadata. Errors never include decrypted values.
+func Decrypt(content string, format Format, identities []age.Identity) (string, error) {
+	switch format {
+	case FormatDotenv:
+		return decryptDotenvInternal(content, identities)
+	case FormatYAML:
+		return decryptYAMLInternal(content, identities)
+	default:
+		return "", fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+// metadata is the part of a file's SOPS metadata needed for decryption.
+type metadata struct {
+	// AgeKeys are the armored age files holding the data key, one per recipient.
+	AgeKeys          []string
+	LastModified     string
+	MAC              string
+	MACOnlyEncrypted bool
+}
+
+// decrypter decrypts the values of one file and computes its MAC as it goes.
+type decrypter struct {
+	key              []byte
+	mac              hash.Hash
+	macOnlyEncrypted bool
+}
+
+func newDecrypterInternal(meta metadata, identities []age.Identity) (*decrypter, error) {
+	if len(meta.AgeKeys) == 0 {
+		return nil, ErrNoAgeRecipient
+	}
+	if meta.MAC == "" || meta.LastModified == "" {
+		return nil, fmt.Errorf("%w: missing mac or lastmodified", ErrMalformed)
+	}
+
+	for _, enc := range meta.AgeKeys {
+		r, err := age.Decrypt(armor.NewReader(strings.NewReader(enc)), identities...)
+		if err != nil {
+			continue
+		}
+		key, err := io.ReadAll(r)
+		if err != nil || len(key) != dataKeySize {
+			return nil, fmt.Errorf("%w: invalid data key", ErrMalformed)
+		}
+		d := &decrypter{key: key, mac: sha512.New(), macOnlyEncrypted: meta.MACOnlyEncrypted}
+		if meta.MACOnlyEncrypted {
+			d.mac.Write(macOnlyEncryptedInitialization)
+		}
+		return d, nil
+	}
+	return nil, ErrNoMatchingKey
+}
+
+// decryptValue decrypts an ENC[...] value. additionalData binds the value to its
+// location in the file: the keys of its path, each followed by a colon.
+func (d *decrypter) decryptValue(value, additionalData string) (plaintext, valueType string, err error) {
+	m := encryptedValueRe.FindStringSubmatch(value)
+	if m == nil {
+		return "", "", fmt.Errorf("%w: invalid encrypted value", ErrMalformed)
+	}
+	data, errData := base64.StdEncoding.DecodeString(m[1])
+	iv, errIV := base64.StdEncoding.DecodeString(m[2])
+	tag, errTag := base64.StdEncoding.DecodeString(m[3])
+	if errData != nil || errIV != nil || errTag != nil || len(iv) == 0 {
+		return "", "", fmt.Errorf("%w: invalid encrypted value", ErrMalformed)
+	}
+
+	block, err := aes.NewCipher(d.key)
+	if err != nil {
+		return "", "", err
+	}
+	gcm, err := cipher.NewGCMWithNonceSize(block, len(iv))
+	if err != nil {
+		return "", "", err
+	}
+	out, err := gcm.Open(nil, iv, append(data, tag...), []byte(additionalData))
+	if err != nil {
+		return "", "", fmt.Errorf("%w: value could not be authenticated", ErrMACMismatch)
+	}
+	return string(out), m[4], nil
+}
+
+// addToMAC adds a value to the MAC in the representation SOPS uses: booleans are True
+// or False, everything else its plain text.
+func (d *decrypter) addToMAC(value string, encrypted bool) {
+	if encrypted || !d.macOnlyEncrypted {
+		d.mac.Write([]byte(value))
+	}
+}
+
+// verifyMAC checks the file's MAC against the values added so far. The MAC is encrypted
+// with the data key, bound to the file's last modification time.
+func (d *decrypter) verifyMAC(meta metadata) error {
+	lastModified, err := time.Parse(time.RFC3339, meta.LastModified)
+	if err != nil {
+		return fmt.Errorf("%w: invalid lastmodified", ErrMalformed)
+	}
+	want, _, err := d.decryptValue(meta.MAC, lastModified.Format(time.RFC3339))
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(want, fmt.Sprintf("%X", d.mac.Sum(nil))) {
+		return ErrMACMismatch
+	}
+	return nil
+}
+
+func isEncryptedValueInternal(value string) bool {
+	return encryptedValueRe.MatchString(value)
+}
+
+func additionalDataInternal(path []string) string {
+	return strings.Join(path, ":") + ":"
+}
+
+func boolMACValueInternal(b bool) string {
+	if b {
+		return "True"
+	}
+	return "False"
+}
+
+func floatMACValueInternal(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,150 @@
+package sops
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// The fixtures were encrypted with sops 3.13 for the test-only key in testdata/age.key.
+
+func readFixtureInternal(t *testing.T, name string) string {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return string(content)
+}
+
+func testIdentitiesInternal(t *testing.T) []age.Identity {
+	t.Helper()
+	identities, err := ParseAgeKeys(readFixtureInternal(t, "age.key"))
+	require.NoError(t, err)
+	return identities
+}
+
+func unmarshalYAMLInternal(t *testing.T, content string) map[string]any {
+	t.Helper()
+	var out map[string]any
+	require.NoError(t, yaml.Unmarshal([]byte(content), &out))
+	return out
+}
+
+func TestFormatForPath(t *testing.T) {
+	tests := map[string]Format{
+		".env":                   FormatDotenv,
+		"stack/.env":             FormatDotenv,
+		"secrets.env":            FormatDotenv,
+		"docker-compose.yml":     FormatYAML,
+		"stack/compose.enc.YAML": FormatYAML,
+	}
+	for path, want := range tests {
+		got, ok := FormatForPath(path)
+		assert.True(t, ok, path)
+		assert.Equal(t, want, got, path)
+	}
+
+	_, ok := FormatForPath("compose.json")
+	assert.False(t, ok)
+}
+
+func TestIsEncrypted(t *testing.T) {
+	assert.True(t, IsEncrypted(readFixtureInternal(t, "secrets.enc.env"), FormatDotenv))
+	assert.False(t, IsEncrypted(readFixtureInternal(t, "secrets.env"), FormatDotenv))
+	assert.True(t, IsEncrypted(readFixtureInternal(t, "compose.enc.yaml"), FormatYAML))
+	assert.True(t, IsEncrypted(readFixtureInternal(t, "compose.partial.enc.yaml"), FormatYAML))
+	assert.False(t, IsEncrypted(readFixtureInternal(t, "compose.yaml"), FormatYAML))
+	assert.False(t, IsEncrypted("services: [", FormatYAML))
+}
+
+func TestParseAgeKeys(t *testing.T) {
+	_, err := ParseAgeKeys("not a key")
+	require.Error(t, err)
+	_, err = ParseAgeKeys("")
+	require.Error(t, err)
+}
+
+func TestDecrypt_Dotenv(t *testing.T) {
+	got, err := Decrypt(readFixtureInternal(t, "secrets.enc.env"), FormatDotenv, testIdentitiesInternal(t))
+	require.NoError(t, err)
+	assert.Equal(t, readFixtureInternal(t, "secrets.env"), got)
+}
+
+func TestDecrypt_YAML(t *testing.T) {
+	got, err := Decrypt(readFixtureInternal(t, "compose.enc.yaml"), FormatYAML, testIdentitiesInternal(t))
+	require.NoError(t, err)
+
+	assert.Equal(t, unmarshalYAMLInternal(t, readFixtureInternal(t, "compose.yaml")), unmarshalYAMLInternal(t, got))
+	assert.NotContains(t, got, "sops:")
+	assert.NotContains(t, got, "ENC[")
+	for _, comment := range []string{"# web stack", "# pinned", "# database port", "# end of file"} {
+		assert.Contains(t, got, comment)
+	}
+}
+
+func TestDecrypt_YAMLMACOnlyEncrypted(t *testing.T) {
+	got, err := Decrypt(readFixtureInternal(t, "compose.partial.enc.yaml"), FormatYAML, testIdentitiesInternal(t))
+	require.NoError(t, err)
+	assert.Equal(t, unmarshalYAMLInternal(t, readFixtureInternal(t, "compose.yaml")), unmarshalYAMLInternal(t, got))
+}
+
+func TestDecrypt_WrongKey(t *testing.T) {
+	other, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	_, err = Decrypt(readFixtureInternal(t, "secrets.enc.env"), FormatDotenv, []age.Identity{other})
+	require.ErrorIs(t, err, ErrNoMatchingKey)
+	_, err = Decrypt(readFixtureInternal(t, "compose.enc.yaml"), FormatYAML, []age.Identity{other})
+	require.ErrorIs(t, err, ErrNoMatchingKey)
+}
+
+func TestDecrypt_DetectsTampering(t *testing.T) {
+	identities := testIdentitiesInternal(t)
+
+	t.Run("changed plain value", func(t *testing.T) {
+		content := strings.Replace(readFixtureInternal(t, "compose.enc.yaml"), "left in plain text", "changed", 1)
+		_, err := Decrypt(content, FormatYAML, identities)
+		require.ErrorIs(t, err, ErrMACMismatch)
+	})
+
+	t.Run("moved encrypted value", func(t *testing.T) {
+		lines := strings.Split(readFixtureInternal(t, "secrets.enc.env"), "\n")
+		var password, user int
+		for i, line := range lines {
+			switch {
+			case strings.HasPrefix(line, "DB_PASSWORD="):
+				password = i
+			case strings.HasPrefix(line, "DB_USER="):
+				user = i
+			}
+		}
+		_, passwordValue, _ := strings.Cut(lines[password], "=")
+		lines[user] = "DB_USER=" + passwordValue
+
+		_, err := Decrypt(strings.Join(lines, "\n"), FormatDotenv, identities)
+		require.ErrorIs(t, err, ErrMACMismatch)
+		assert.NotContains(t, err.Error(), "s3cr3t")
+	})
+
+	t.Run("removed variable", func(t *testing.T) {
+		var kept []string
+		for line := range strings.SplitSeq(readFixtureInternal(t, "secrets.enc.env"), "\n") {
+			if !strings.HasPrefix(line, "DB_USER=") {
+				kept = append(kept, line)
+			}
+		}
+		_, err := Decrypt(strings.Join(kept, "\n"), FormatDotenv, identities)
+		require.ErrorIs(t, err, ErrMACMismatch)
+	})
+}
+
+func TestDecrypt_RequiresAgeRecipient(t *testing.T) {
+	content := "A=ENC[AES256_GCM,data:AA==,iv:AA==,tag:AA==,type:str]\nsops_lastmodified=2024-01-01T00:00:00Z\nsops_mac=x\n"
+	_, err := Decrypt(content, FormatDotenv, testIdentitiesInternal(t))
+	require.ErrorIs(t, err, ErrNoAgeRecipient)
+}
@@ -0,0 +1,254 @@
+package sops
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"filippo.io/age"
+	// SOPS reads and writes YAML with yaml.v3. Using it here as well makes unencrypted
+	// scalars resolve to the same types SOPS added to the MAC.
+	"gopkg.in/yaml.v3"
+)
+
+const yamlMetadataKey = "sops"
+
+type yamlMetadata struct {
+	Age []struct {
+		Enc string `yaml:"enc"`
+	} `yaml:"age"`
+	LastModified     string `yaml:"lastmodified"`
+	MAC              string `yaml:"mac"`
+	MACOnlyEncrypted bool   `yaml:"mac_only_encrypted"`
+}
+
+func (m yamlMetadata) metadata() metadata {
+	meta := metadata{LastModified: m.LastModified, MAC: m.MAC, MACOnlyEncrypted: m.MACOnlyEncrypted}
+	for _, a := range m.Age {
+		meta.AgeKeys = append(meta.AgeKeys, a.Enc)
+	}
+	return meta
+}
+
+func isEncryptedYAMLInternal(content string) bool {
+	var probe struct {
+		Sops *yamlMetadata `yaml:"sops"`
+	}
+	if err := yaml.Unmarshal([]byte(content), &probe); err != nil {
+		return false
+	}
+	return probe.Sops != nil && probe.Sops.MAC != ""
+}
+
+// decryptYAMLInternal decrypts a YAML file's values and comments in place and writes it
+// without its sops metadata key.
+func decryptYAMLInternal(content string, identities []age.Identity) (string, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrMalformed, err)
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return "", fmt.Errorf("%w: document is not a mapping", ErrMalformed)
+	}
+	root := doc.Content[0]
+
+	metadataIndex := -1
+	var meta yamlMetadata
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == yamlMetadataKey {
+			if err := root.Content[i+1].Decode(&meta); err != nil {
+				return "", fmt.Errorf("%w: %w", ErrMalformed, err)
+			}
+			metadataIndex = i
+			break
+		}
+	}
+	if metadataIndex < 0 {
+		return "", fmt.Errorf("%w: missing sops metadata", ErrMalformed)
+	}
+
+	d, err := newDecrypterInternal(meta.metadata(), identities)
+	if err != nil {
+		return "", err
+	}
+	d.decryptYAMLCommentsInternal(&doc, nil)
+	if err := d.decryptYAMLNodeInternal(root, nil); err != nil {
+		return "", err
+	}
+	if err := d.verifyMAC(meta.metadata()); err != nil {
+		return "", err
+	}
+
+	// Comments at the end of the file are attached to the metadata key, which SOPS
+	// always writes last.
+	trailing := root.Content[metadataIndex].HeadComment
+	root.Content = slices.Delete(root.Content, metadataIndex, metadataIndex+2)
+	if trailing != "" {
+		root.FootComment = strings.TrimPrefix(root.FootComment+"\n"+trailing, "\n")
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return "", err
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// decryptYAMLNodeInternal decrypts a node and its children in place. path is the path
+// of the node: the keys leading to it. Sequence items share the path of their sequence.
+func (d *decrypter) decryptYAMLNodeInternal(node *yaml.Node, path []string) error {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			childPath := append(slices.Clone(path), key.Value)
+			d.decryptYAMLCommentsInternal(key, path, childPath)
+			if len(path) == 0 && key.Value == yamlMetadataKey {
+				continue
+			}
+			d.decryptYAMLCommentsInternal(value, path, childPath)
+			if err := d.decryptYAMLNodeInternal(value, childPath); err != nil {
+				return err
+			}
+		}
+	case yaml.SequenceNode:
+		return d.decryptYAMLSequenceInternal(node, path)
+	case yaml.ScalarNode:
+		return d.decryptYAMLScalarInternal(node, path)
+	case yaml.DocumentNode, yaml.AliasNode:
+	}
+	return nil
+}
+
+// decryptYAMLSequenceInternal decrypts a sequence's items. SOPS stores comments between
+// items as encrypted items of type comment; they are turned back into comments.
+func (d *decrypter) decryptYAMLSequenceInternal(node *yaml.Node, path []string) error {
+	items := make([]*yaml.Node, 0, len(node.Content))
+	var comments []string
+	for _, item := range node.Content {
+		d.decryptYAMLCommentsInternal(item, path)
+		if item.Kind == yaml.ScalarNode && isEncryptedValueInternal(item.Value) {
+			plaintext, valueType, err := d.decryptValue(item.Value, additionalDataInternal(path))
+			if err != nil {
+				return fmt.Errorf("%s: %w", strings.Join(path, "."), err)
+			}
+			if valueType == typeComment {
+				comments = append(comments, "#"+plaintext)
+				continue
+			}
+			d.addToMAC(plaintext, true)
+			setYAMLScalarInternal(item, plaintext, valueType)
+		} else if err := d.decryptYAMLNodeInternal(item, path); err != nil {
+			return err
+		}
+
+		if len(comments) > 0 {
+			item.HeadComment = strings.Join(append(comments, item.HeadComment), "\n")
+			item.HeadComment = strings.TrimSuffix(item.HeadComment, "\n")
+			comments = nil
+		}
+		items = append(items, item)
+	}
+	if len(comments) > 0 {
+		node.FootComment = strings.TrimPrefix(node.FootComment+"\n"+strings.Join(comments, "\n"), "\n")
+	}
+	node.Content = items
+	return nil
+}
+
+func (d *decrypter) decryptYAMLScalarInternal(node *yaml.Node, path []string) error {
+	if !isEncryptedValueInternal(node.Value) {
+		d.addToMAC(yamlMACValueInternal(node), false)
+		return nil
+	}
+	plaintext, valueType, err := d.decryptValue(node.Value, additionalDataInternal(path))
+	if err != nil {
+		return fmt.Errorf("%s: %w", strings.Join(path, "."), err)
+	}
+	d.addToMAC(plaintext, true)
+	setYAMLScalarInternal(node, plaintext, valueType)
+	return nil
+}
+
+// decryptYAMLCommentsInternal decrypts the comments attached to a node. SOPS binds
+// comments to the path of the mapping or sequence holding them, which does not always
+// match the node yaml.v3 attaches them to, so each candidate path is tried in turn.
+func (d *decrypter) decryptYAMLCommentsInternal(node *yaml.Node, paths ...[]string) {
+	if len(paths) == 0 {
+		paths = [][]string{nil}
+	}
+	for _, comment := range []*string{&node.HeadComment, &node.LineComment, &node.FootComment} {
+		if *comment == "" {
+			continue
+		}
+		lines := strings.Split(*comment, "\n")
+		for i, line := range lines {
+			text, ok := strings.CutPrefix(strings.TrimSpace(line), "#")
+			text = strings.TrimSpace(text)
+			if !ok || !isEncryptedValueInternal(text) {
+				continue
+			}
+			var plaintext string
+			var err error
+			for _, path := range paths {
+				if plaintext, _, err = d.decryptValue(text, additionalDataInternal(path)); err == nil {
+					break
+				}
+			}
+			// Like SOPS, keep comments that cannot be decrypted. They are not part of
+			// the MAC and may predate comment encryption.
+			if err == nil {
+				lines[i] = "#" + plaintext
+			}
+		}
+		*comment = strings.Join(lines, "\n")
+	}
+}
+
+// setYAMLScalarInternal replaces an encrypted scalar with its plaintext, tagged with the
+// type it had before it was encrypted.
+func setYAMLScalarInternal(node *yaml.Node, plaintext, valueType string) {
+	node.Value = plaintext
+	node.Style = 0
+	switch valueType {
+	case typeInt:
+		node.Tag = "!!int"
+	case typeFloat:
+		node.Tag = "!!float"
+	case typeBool:
+		node.Tag = "!!bool"
+		node.Value = strings.ToLower(plaintext)
+	default:
+		node.Tag = "!!str"
+	}
+}
+
+// yamlMACValueInternal returns the representation SOPS added to the MAC for an
+// unencrypted scalar. Null values add nothing.
+func yamlMACValueInternal(node *yaml.Node) string {
+	var value any
+	if err := node.Decode(&value); err != nil {
+		return node.Value
+	}
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return boolMACValueInternal(v)
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return floatMACValueInternal(v)
+	default:
+		return node.Value
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"time"
+
+	"github.com/getarcaneapp/arcane/backend/internal/utils/logparse"
 )
 
 type LogMessage struct {
@@ -13,6 +15,8 @@ type LogMessage struct {
 	Timestamp   string `json:"timestamp"` // RFC3339(9) string
 	Service     string `json:"service,omitempty"`
 	ContainerID string `json:"containerId,omitempty"`
+	// Parsed is set when the stream was opened with log parsing enabled.
+	Parsed *logparse.Entry `json:"parsed,omitempty"`
 }
 
 // ForwardLines forwards plain text lines to the hub.
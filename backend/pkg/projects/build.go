@@ -0,0 +1,243 @@
+package projects
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/docker/compose/v5/pkg/api"
+	"github.com/docker/docker/api/types/build"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/jsonmessage"
+	archive "github.com/moby/go-archive"
+	"github.com/moby/patternmatcher"
+	"github.com/moby/patternmatcher/ignorefile"
+)
+
+// ImageBuilder is the part of the Docker client used to build images.
+type ImageBuilder interface {
+	ImageBuild(ctx context.Context, buildContext io.Reader, options build.ImageBuildOptions) (build.ImageBuildResponse, error)
+}
+
+// BuildOptions controls how the images of compose services are built.
+type BuildOptions struct {
+	// NoCache disables the build cache, in addition to services that set no_cache.
+	NoCache bool
+	// Pull always attempts to pull newer versions of base images.
+	Pull bool
+}
+
+// BuildServiceImage builds the image of a compose service with a build section through
+// the Docker API and tags it with the name compose runs the service with. Build output
+// is written to progressWriter as JSON lines of type "build". Build args without a value
+// are taken from the project environment. It returns the name of the built image.
+func BuildServiceImage(ctx context.Context, builder ImageBuilder, proj *types.Project, serviceName string, opts BuildOptions, progressWriter io.Writer) (string, error) {
+	service, ok := proj.Services[serviceName]
+	if !ok {
+		return "", fmt.Errorf("service %s not found", serviceName)
+	}
+	if service.Build == nil {
+		return "", fmt.Errorf("service %s has no build section", serviceName)
+	}
+
+	buildOptions, err := imageBuildOptionsInternal(proj, service, opts)
+	if err != nil {
+		return "", fmt.Errorf("service %s: %w", serviceName, err)
+	}
+	imageName := buildOptions.Tags[0]
+
+	var buildContext io.ReadCloser
+	if buildOptions.RemoteContext == "" {
+		buildContext, err = buildContextTarInternal(service.Build.Context, buildOptions.Dockerfile)
+		if err != nil {
+			return "", fmt.Errorf("service %s: %w", serviceName, err)
+		}
+		defer func() { _ = buildContext.Close() }()
+	}
+
+	writeJSONLine(progressWriter, map[string]any{"type": "build", "phase": "begin", "service": serviceName, "image": imageName})
+
+	resp, err := builder.ImageBuild(ctx, buildContext, buildOptions)
+	if err != nil {
+		writeJSONLine(progressWriter, map[string]any{"type": "build", "phase": "error", "service": serviceName, "error": err.Error()})
+		return "", fmt.Errorf("failed to build image for service %s: %w", serviceName, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	imageID, err := streamBuildOutputInternal(resp.Body, serviceName, progressWriter)
+	if err != nil {
+		writeJSONLine(progressWriter, map[string]any{"type": "build", "phase": "error", "service": serviceName, "error": err.Error()})
+		return "", fmt.Errorf("failed to build image for service %s: %w", serviceName, err)
+	}
+
+	writeJSONLine(progressWriter, map[string]any{"type": "build", "phase": "complete", "service": serviceName, "image": imageName, "id": imageID})
+	return imageName, nil
+}
+
+// imageBuildOptionsInternal maps the build section of a service to Docker API build
+// options. Features that need a BuildKit session (secrets, ssh, multiple platforms,
+// inline Dockerfiles) cannot be used through the API and are rejected.
+func imageBuildOptionsInternal(proj *types.Project, service types.ServiceConfig, opts BuildOptions) (build.ImageBuildOptions, error) {
+	cfg := service.Build
+	switch {
+	case cfg.DockerfileInline != "":
+		return build.ImageBuildOptions{}, errors.New("dockerfile_inline is not supported")
+	case len(cfg.Secrets) > 0:
+		return build.ImageBuildOptions{}, errors.New("build secrets are not supported")
+	case len(cfg.SSH) > 0:
+		return build.ImageBuildOptions{}, errors.New("build ssh is not supported")
+	case len(cfg.Platforms) > 1:
+		return build.ImageBuildOptions{}, errors.New("building for multiple platforms is not supported")
+	}
+
+	tags := append([]string{api.GetImageNameOrDefault(service, proj.Name)}, cfg.Tags...)
+
+	buildArgs := map[string]*string{}
+	for key, value := range cfg.Args.Resolve(func(name string) (string, bool) {
+		v, ok := proj.Environment[name]
+		return v, ok
+	}) {
+		if value != nil {
+			buildArgs[key] = value
+		}
+	}
+
+	options := build.ImageBuildOptions{
+		Tags:        tags,
+		Remove:      true,
+		NoCache:     opts.NoCache || cfg.NoCache,
+		PullParent:  opts.Pull || cfg.Pull,
+		Dockerfile:  cfg.Dockerfile,
+		BuildArgs:   buildArgs,
+		Labels:      cfg.Labels,
+		Target:      cfg.Target,
+		NetworkMode: cfg.Network,
+		CacheFrom:   cfg.CacheFrom,
+		ExtraHosts:  cfg.ExtraHosts.AsList(":"),
+		ShmSize:     int64(cfg.ShmSize),
+		Isolation:   container.Isolation(cfg.Isolation),
+		Platform:    service.Platform,
+		Version:     build.BuilderV1,
+	}
+	if len(cfg.Platforms) == 1 {
+		options.Platform = cfg.Platforms[0]
+	}
+	if options.Dockerfile == "" {
+		options.Dockerfile = "Dockerfile"
+	}
+
+	if isRemoteBuildContextInternal(cfg.Context) {
+		options.RemoteContext = cfg.Context
+		return options, nil
+	}
+
+	// The Dockerfile must be part of the context sent to the daemon.
+	if filepath.IsAbs(options.Dockerfile) {
+		rel, err := filepath.Rel(cfg.Context, options.Dockerfile)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return build.ImageBuildOptions{}, fmt.Errorf("dockerfile %s is outside the build context %s", options.Dockerfile, cfg.Context)
+		}
+		options.Dockerfile = rel
+	}
+	options.Dockerfile = filepath.ToSlash(options.Dockerfile)
+	return options, nil
+}
+
+func isRemoteBuildContextInternal(context string) bool {
+	for _, prefix := range []string{"http://", "https://", "git://", "github.com/", "git@"} {
+		if strings.HasPrefix(context, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// buildContextTarInternal archives a build context directory, honouring its
+// .dockerignore file. The Dockerfile and .dockerignore are always sent, as the
+// Docker CLI does.
+func buildContextTarInternal(contextDir, dockerfile string) (io.ReadCloser, error) {
+	info, err := os.Stat(contextDir)
+	if err != nil {
+		return nil, fmt.Errorf("build context %s: %w", contextDir, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("build context %s is not a directory", contextDir)
+	}
+
+	excludes, err := readDockerignoreInternal(contextDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(excludes) > 0 {
+		for _, keep := range []string{dockerfile, ".dockerignore"} {
+			if excluded, _ := patternmatcher.MatchesOrParentMatches(keep, excludes); excluded {
+				excludes = append(excludes, "!"+keep)
+			}
+		}
+	}
+
+	return archive.TarWithOptions(contextDir, &archive.TarOptions{ExcludePatterns: excludes})
+}
+
+func readDockerignoreInternal(contextDir string) ([]string, error) {
+	f, err := os.Open(filepath.Join(contextDir, ".dockerignore"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open .dockerignore: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	excludes, err := ignorefile.ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read .dockerignore: %w", err)
+	}
+	return excludes, nil
+}
+
+// streamBuildOutputInternal forwards the JSON message stream of a Docker build as
+// "build" progress lines and returns the ID of the built image. Errors reported in
+// the stream are returned.
+func streamBuildOutputInternal(r io.Reader, serviceName string, progressWriter io.Writer) (string, error) {
+	decoder := json.NewDecoder(r)
+	var imageID string
+	for {
+		var msg jsonmessage.JSONMessage
+		if err := decoder.Decode(&msg); err != nil {
+			if errors.Is(err, io.EOF) {
+				return imageID, nil
+			}
+			return imageID, fmt.Errorf("failed to read build output: %w", err)
+		}
+
+		if msg.Error != nil {
+			return imageID, errors.New(msg.Error.Message)
+		}
+		if msg.ErrorMessage != "" {
+			return imageID, errors.New(msg.ErrorMessage)
+		}
+		if msg.Aux != nil {
+			var aux build.Result
+			if err := json.Unmarshal(*msg.Aux, &aux); err == nil && aux.ID != "" {
+				imageID = aux.ID
+			}
+			continue
+		}
+
+		line := msg.Stream
+		if line == "" {
+			line = strings.TrimSpace(strings.Join([]string{msg.ID, msg.Status}, " "))
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		writeJSONLine(progressWriter, map[string]any{"type": "build", "phase": "output", "service": serviceName, "stream": line})
+	}
+}
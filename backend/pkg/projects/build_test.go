@@ -0,0 +1,140 @@
+package projects
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/docker/docker/api/types/build"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeImageBuilder struct {
+	options build.ImageBuildOptions
+	files   []string
+	output  string
+}
+
+func (f *fakeImageBuilder) ImageBuild(_ context.Context, buildContext io.Reader, options build.ImageBuildOptions) (build.ImageBuildResponse, error) {
+	f.options = options
+	if buildContext != nil {
+		tr := tar.NewReader(buildContext)
+		for {
+			hdr, err := tr.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return build.ImageBuildResponse{}, err
+			}
+			f.files = append(f.files, hdr.Name)
+		}
+	}
+	return build.ImageBuildResponse{Body: io.NopCloser(strings.NewReader(f.output))}, nil
+}
+
+func newBuildProject(t *testing.T, cfg *types.BuildConfig) *types.Project {
+	t.Helper()
+	return &types.Project{
+		Name:        "shop",
+		Environment: types.Mapping{"VERSION": "1.2.3"},
+		Services: types.Services{
+			"sidecar": {Name: "sidecar", Build: cfg},
+			"web":     {Name: "web", Image: "nginx:alpine"},
+		},
+	}
+}
+
+func TestBuildServiceImage(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Dockerfile"), []byte("FROM alpine\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app.sh"), []byte("echo hi\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "secret.env"), []byte("TOKEN=x\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".dockerignore"), []byte("*.env\nDockerfile\n"), 0o600))
+
+	proj := newBuildProject(t, &types.BuildConfig{
+		Context: dir,
+		Args:    types.MappingWithEquals{"VERSION": nil, "MODE": new("prod"), "UNSET": nil},
+		Target:  "runtime",
+	})
+	builder := &fakeImageBuilder{output: `{"stream":"Step 1/1 : FROM alpine\n"}` + "\n" + `{"aux":{"ID":"sha256:abc"}}` + "\n"}
+	var progress bytes.Buffer
+
+	image, err := BuildServiceImage(context.Background(), builder, proj, "sidecar", BuildOptions{NoCache: true}, &progress)
+	require.NoError(t, err)
+
+	assert.Equal(t, "shop-sidecar", image)
+	assert.Equal(t, []string{"shop-sidecar"}, builder.options.Tags)
+	assert.Equal(t, "Dockerfile", builder.options.Dockerfile)
+	assert.Equal(t, "runtime", builder.options.Target)
+	assert.True(t, builder.options.NoCache)
+	assert.Equal(t, map[string]*string{"VERSION": new("1.2.3"), "MODE": new("prod")}, builder.options.BuildArgs)
+	assert.ElementsMatch(t, []string{".dockerignore", "Dockerfile", "app.sh"}, builder.files)
+
+	var phases []string
+	for line := range strings.Lines(progress.String()) {
+		var payload map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &payload))
+		assert.Equal(t, "build", payload["type"])
+		assert.Equal(t, "sidecar", payload["service"])
+		phases = append(phases, payload["phase"].(string))
+	}
+	assert.Equal(t, []string{"begin", "output", "complete"}, phases)
+	assert.Contains(t, progress.String(), `"id":"sha256:abc"`)
+}
+
+func TestBuildServiceImage_StreamError(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Dockerfile"), []byte("FROM alpine\nRUN false\n"), 0o600))
+
+	proj := newBuildProject(t, &types.BuildConfig{Context: dir})
+	builder := &fakeImageBuilder{output: `{"errorDetail":{"message":"RUN false returned 1"},"error":"RUN false returned 1"}` + "\n"}
+	var progress bytes.Buffer
+
+	_, err := BuildServiceImage(context.Background(), builder, proj, "sidecar", BuildOptions{}, &progress)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "RUN false returned 1")
+	assert.Contains(t, progress.String(), `"phase":"error"`)
+}
+
+func TestImageBuildOptionsInternal(t *testing.T) {
+	t.Parallel()
+
+	proj := newBuildProject(t, nil)
+
+	t.Run("remote context", func(t *testing.T) {
+		t.Parallel()
+		svc := types.ServiceConfig{Name: "api", Image: "registry.local/api:dev", Build: &types.BuildConfig{Context: "https://github.com/acme/api.git#main", Tags: []string{"api:latest"}}}
+		opts, err := imageBuildOptionsInternal(proj, svc, BuildOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "https://github.com/acme/api.git#main", opts.RemoteContext)
+		assert.Equal(t, []string{"registry.local/api:dev", "api:latest"}, opts.Tags)
+	})
+
+	t.Run("dockerfile outside context", func(t *testing.T) {
+		t.Parallel()
+		svc := types.ServiceConfig{Name: "api", Build: &types.BuildConfig{Context: "/srv/app", Dockerfile: "/srv/other/Dockerfile"}}
+		_, err := imageBuildOptionsInternal(proj, svc, BuildOptions{})
+		require.Error(t, err)
+	})
+
+	t.Run("secrets are rejected", func(t *testing.T) {
+		t.Parallel()
+		svc := types.ServiceConfig{Name: "api", Build: &types.BuildConfig{Context: "/srv/app", Secrets: []types.ServiceSecretConfig{{Source: "token"}}}}
+		_, err := imageBuildOptionsInternal(proj, svc, BuildOptions{})
+		require.Error(t, err)
+	})
+}
@@ -18,11 +18,22 @@ import (
 const (
 	// ArcaneIconLabel is the full reverse-DNS label key for service-level icons.
 	ArcaneIconLabel = "com.getarcaneapp.arcane.icon"
-
-	arcaneBlockKey = "x-arcane"
-	arcaneIconKey  = "icon"
-	arcaneIconsKey = "icons"
-	arcaneURLsKey  = "urls"
+	// ArcaneBackupPreLabel holds a shell command run in the container before its volumes are backed up.
+	ArcaneBackupPreLabel = "com.getarcaneapp.arcane.backup.pre"
+	// ArcaneBackupPostLabel holds a shell command run in the container after its volumes were backed up.
+	ArcaneBackupPostLabel = "com.getarcaneapp.arcane.backup.post"
+	// ArcaneBackupStopLabel is "true" to stop the container while its volumes are backed up, or a
+	// comma-separated list of other containers (or services of the same project) to stop.
+	ArcaneBackupStopLabel = "com.getarcaneapp.arcane.backup.stop"
+
+	arcaneBlockKey      = "x-arcane"
+	arcaneIconKey       = "icon"
+	arcaneIconsKey      = "icons"
+	arcaneURLsKey       = "urls"
+	arcaneBackupKey     = "backup"
+	arcaneBackupPreKey  = "pre"
+	arcaneBackupPostKey = "post"
+	arcaneBackupStopKey = "stop"
 )
 
 // ArcaneComposeMetadata represents Arcane-specific configuration extracted from a Compose file.
@@ -33,6 +44,83 @@ type ArcaneComposeMetadata struct {
 	ProjectURLS []string
 	// ServiceIcons maps service names to their respective icon identifiers or URLs.
 	ServiceIcons map[string]string
+	// ServiceBackupHooks maps service names to the hooks run when their volumes are backed up.
+	ServiceBackupHooks map[string]BackupHooks
+}
+
+// BackupHooks describes how a container is quiesced while its volumes are backed up.
+type BackupHooks struct {
+	// Pre is a shell command run in the container before the backup; the backup is aborted if it fails.
+	Pre string
+	// Post is a shell command run in the container after the backup, also when the backup failed.
+	Post string
+	// StopSelf stops the container for the duration of the backup.
+	StopSelf bool
+	// Stop lists other containers, by container name or by service name within the same
+	// project, that are stopped for the duration of the backup.
+	Stop []string
+}
+
+// IsZero reports whether no hook is configured.
+func (h BackupHooks) IsZero() bool {
+	return h.Pre == "" && h.Post == "" && !h.StopSelf && len(h.Stop) == 0
+}
+
+// ParseBackupHookLabels reads backup hooks from container labels.
+func ParseBackupHookLabels(labels map[string]string) BackupHooks {
+	hooks := BackupHooks{
+		Pre:  strings.TrimSpace(labels[ArcaneBackupPreLabel]),
+		Post: strings.TrimSpace(labels[ArcaneBackupPostLabel]),
+	}
+	if stop, ok := labels[ArcaneBackupStopLabel]; ok {
+		hooks = hooks.withStop(stop)
+	}
+	return hooks
+}
+
+// MergeBackupHooks returns primary with unset fields taken from fallback, so container
+// labels override hooks declared in the compose x-arcane block.
+func MergeBackupHooks(primary, fallback BackupHooks) BackupHooks {
+	merged := primary
+	merged.Pre = utils.FirstNonEmpty(primary.Pre, fallback.Pre)
+	merged.Post = utils.FirstNonEmpty(primary.Post, fallback.Post)
+	if !primary.StopSelf && len(primary.Stop) == 0 {
+		merged.StopSelf = fallback.StopSelf
+		merged.Stop = fallback.Stop
+	}
+	return merged
+}
+
+func (h BackupHooks) withStop(value any) BackupHooks {
+	if b, ok := value.(bool); ok {
+		h.StopSelf = b
+		return h
+	}
+	var names []string
+	for _, item := range utils.Collect(value, utils.ToString) {
+		names = append(names, strings.Split(item, ",")...)
+	}
+	for _, name := range utils.UniqueNonEmptyStrings(names) {
+		switch strings.ToLower(name) {
+		case "true":
+			h.StopSelf = true
+		case "false":
+		default:
+			h.Stop = append(h.Stop, name)
+		}
+	}
+	return h
+}
+
+func parseBackupHooksBlock(block any) BackupHooks {
+	backupBlock, ok := utils.AsStringMap(block)
+	if !ok {
+		return BackupHooks{}
+	}
+	return BackupHooks{
+		Pre:  utils.ToString(backupBlock[arcaneBackupPreKey]),
+		Post: utils.ToString(backupBlock[arcaneBackupPostKey]),
+	}.withStop(backupBlock[arcaneBackupStopKey])
 }
 
 // ParseArcaneComposeMetadata reads a Docker Compose file and extracts Arcane-specific metadata.
@@ -97,7 +185,7 @@ func parseArcaneComposeMetadataFromFileInternal(ctx context.Context, composeFile
 }
 
 func extractArcaneComposeMetadata(project *composetypes.Project) ArcaneComposeMetadata {
-	meta := ArcaneComposeMetadata{ServiceIcons: map[string]string{}}
+	meta := ArcaneComposeMetadata{ServiceIcons: map[string]string{}, ServiceBackupHooks: map[string]BackupHooks{}}
 	if project == nil {
 		return meta
 	}
@@ -119,6 +207,12 @@ func extractArcaneComposeMetadata(project *composetypes.Project) ArcaneComposeMe
 		if icon != "" {
 			meta.ServiceIcons[name] = icon
 		}
+
+		if arcaneBlock, ok := utils.AsStringMap(svc.Extensions[arcaneBlockKey]); ok {
+			if hooks := parseBackupHooksBlock(arcaneBlock[arcaneBackupKey]); !hooks.IsZero() {
+				meta.ServiceBackupHooks[name] = hooks
+			}
+		}
 	}
 
 	return meta
@@ -153,6 +247,15 @@ func mergeArcaneComposeMetadata(target *ArcaneComposeMetadata, source ArcaneComp
 			target.ServiceIcons[name] = icon
 		}
 	}
+
+	if target.ServiceBackupHooks == nil {
+		target.ServiceBackupHooks = map[string]BackupHooks{}
+	}
+	for name, hooks := range source.ServiceBackupHooks {
+		if _, exists := target.ServiceBackupHooks[name]; !exists {
+			target.ServiceBackupHooks[name] = hooks
+		}
+	}
 }
 
 func loadComposeProjectForMetadataFromFileInternal(ctx context.Context, composeFilePath string, envMap map[string]string) (*composetypes.Project, error) {
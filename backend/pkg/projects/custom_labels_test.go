@@ -58,3 +58,51 @@ services:
 	require.Equal(t, "https://example.com/icon.png", meta.ProjectIconURL)
 	require.Equal(t, []string{"https://example.com/docs"}, meta.ProjectURLS)
 }
+
+func TestParseArcaneComposeMetadata_BackupHooks(t *testing.T) {
+	tempDir := t.TempDir()
+
+	composeContent := `services:
+  db:
+    image: postgres:17
+    x-arcane:
+      backup:
+        pre: pg_dump -U postgres -f /var/lib/postgresql/data/dump.sql
+        post: rm -f /var/lib/postgresql/data/dump.sql
+        stop: [app, worker]
+  cache:
+    image: redis:7
+    x-arcane:
+      icon: redis
+      backup:
+        stop: true
+  app:
+    image: nginx:alpine
+`
+	composePath := filepath.Join(tempDir, "compose.yaml")
+	require.NoError(t, os.WriteFile(composePath, []byte(composeContent), 0o600))
+
+	meta, err := ParseArcaneComposeMetadata(context.Background(), composePath)
+	require.NoError(t, err)
+	require.Equal(t, map[string]BackupHooks{
+		"db": {
+			Pre:  "pg_dump -U postgres -f /var/lib/postgresql/data/dump.sql",
+			Post: "rm -f /var/lib/postgresql/data/dump.sql",
+			Stop: []string{"app", "worker"},
+		},
+		"cache": {StopSelf: true},
+	}, meta.ServiceBackupHooks)
+	require.Equal(t, "redis", meta.ServiceIcons["cache"])
+}
+
+func TestParseBackupHookLabels(t *testing.T) {
+	hooks := ParseBackupHookLabels(map[string]string{
+		ArcaneBackupPreLabel:  " mysql -e 'FLUSH TABLES' ",
+		ArcaneBackupStopLabel: "true, app",
+	})
+	require.Equal(t, BackupHooks{Pre: "mysql -e 'FLUSH TABLES'", StopSelf: true, Stop: []string{"app"}}, hooks)
+	require.True(t, ParseBackupHookLabels(map[string]string{"other": "x"}).IsZero())
+
+	merged := MergeBackupHooks(BackupHooks{Post: "from-label"}, BackupHooks{Pre: "from-compose", Post: "ignored", StopSelf: true})
+	require.Equal(t, BackupHooks{Pre: "from-compose", Post: "from-label", StopSelf: true}, merged)
+}
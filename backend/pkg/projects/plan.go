@@ -0,0 +1,47 @@
+package projects
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/compose-spec/compose-go/v2/template"
+	"github.com/compose-spec/compose-go/v2/types"
+	composev2 "github.com/docker/compose/v5/pkg/compose"
+	"github.com/goccy/go-yaml"
+)
+
+// UnresolvedVariables returns the sorted names of the variables referenced in a compose
+// file that are not set in env and have no default. Compose substitutes an empty string
+// for them, which is rarely intended. Escaped references ($$VAR) are ignored, and
+// required references (${VAR:?err}) are left to the loader, which fails on them.
+func UnresolvedVariables(composeFilePath string, env map[string]string) ([]string, error) {
+	content, err := os.ReadFile(composeFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read compose file: %w", err)
+	}
+
+	var composeData map[string]any
+	if err := yaml.Unmarshal(content, &composeData); err != nil {
+		return nil, fmt.Errorf("failed to parse compose file: %w", err)
+	}
+
+	unresolved := map[string]struct{}{}
+	for name, v := range template.ExtractVariables(composeData, template.DefaultPattern) {
+		if v.Required || v.DefaultValue != "" || v.PresenceValue != "" {
+			continue
+		}
+		if _, ok := env[name]; ok {
+			continue
+		}
+		unresolved[name] = struct{}{}
+	}
+	return slices.Sorted(maps.Keys(unresolved)), nil
+}
+
+// ServiceConfigHash returns the hash compose stores in the config-hash label of a
+// service's containers. A container whose label differs is recreated on the next up.
+func ServiceConfigHash(service types.ServiceConfig) (string, error) {
+	return composev2.ServiceHash(service)
+}
@@ -0,0 +1,43 @@
+package projects
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnresolvedVariables(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	composePath := filepath.Join(dir, "compose.yaml")
+	content := `services:
+  app:
+    image: nginx:${TAG}
+    environment:
+      - DB_HOST=${DB_HOST}
+      - DB_PORT=${DB_PORT:-5432}
+      - DEBUG=${DEBUG:+1}
+      - LITERAL=$$NOT_A_VAR
+      - TOKEN=$TOKEN
+`
+	require.NoError(t, os.WriteFile(composePath, []byte(content), 0o600))
+
+	unresolved, err := UnresolvedVariables(composePath, map[string]string{"TAG": "alpine"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"DB_HOST", "TOKEN"}, unresolved)
+}
+
+func TestUnresolvedVariables_InvalidYAML(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	composePath := filepath.Join(dir, "compose.yaml")
+	require.NoError(t, os.WriteFile(composePath, []byte("services: [\n"), 0o600))
+
+	_, err := UnresolvedVariables(composePath, nil)
+	require.Error(t, err)
+}
@@ -12,15 +12,25 @@ import (
 
 const EventCleanupJobName = "event-cleanup"
 
+// gitOpsSyncRunRetention is how long the run history of GitOps syncs is kept.
+const gitOpsSyncRunRetention = 30 * 24 * time.Hour
+
+// execSessionRetention is how long ended exec sessions and their recordings are kept.
+const execSessionRetention = 30 * 24 * time.Hour
+
 type EventCleanupJob struct {
-	eventService    *services.EventService
-	settingsService *services.SettingsService
+	eventService       *services.EventService
+	settingsService    *services.SettingsService
+	gitOpsSyncService  *services.GitOpsSyncService
+	execSessionService *services.ExecSessionService
 }
 
-func NewEventCleanupJob(eventService *services.EventService, settingsService *services.SettingsService) *EventCleanupJob {
+func NewEventCleanupJob(eventService *services.EventService, settingsService *services.SettingsService, gitOpsSyncService *services.GitOpsSyncService, execSessionService *services.ExecSessionService) *EventCleanupJob {
 	return &EventCleanupJob{
-		eventService:    eventService,
-		settingsService: settingsService,
+		eventService:       eventService,
+		settingsService:    settingsService,
+		gitOpsSyncService:  gitOpsSyncService,
+		execSessionService: execSessionService,
 	}
 }
 
@@ -58,6 +68,20 @@ func (j *EventCleanupJob) Run(ctx context.Context) {
 		return
 	}
 
+	if j.gitOpsSyncService != nil {
+		if err := j.gitOpsSyncService.DeleteOldSyncRuns(ctx, gitOpsSyncRunRetention); err != nil {
+			slog.ErrorContext(ctx, "Failed to delete old GitOps sync runs", "jobName", EventCleanupJobName, "olderThan", gitOpsSyncRunRetention.String(), "error", err)
+			return
+		}
+	}
+
+	if j.execSessionService != nil {
+		if err := j.execSessionService.PruneSessions(ctx, execSessionRetention); err != nil {
+			slog.ErrorContext(ctx, "Failed to prune old exec sessions", "jobName", EventCleanupJobName, "olderThan", execSessionRetention.String(), "error", err)
+			return
+		}
+	}
+
 	slog.InfoContext(ctx, "Event cleanup job completed successfully",
 		"jobName", EventCleanupJobName,
 		"olderThan", olderThan.String())
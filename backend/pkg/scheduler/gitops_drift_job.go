@@ -0,0 +1,76 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/getarcaneapp/arcane/backend/internal/services"
+	"github.com/robfig/cron/v3"
+)
+
+const GitOpsDriftJobName = "gitops-drift"
+
+const defaultGitOpsDriftInterval = "0 */15 * * * *"
+
+type GitOpsDriftJob struct {
+	syncService         *services.GitOpsSyncService
+	settingsService     *services.SettingsService
+	notificationService *services.NotificationService
+}
+
+func NewGitOpsDriftJob(syncService *services.GitOpsSyncService, settingsService *services.SettingsService, notificationService *services.NotificationService) *GitOpsDriftJob {
+	return &GitOpsDriftJob{
+		syncService:         syncService,
+		settingsService:     settingsService,
+		notificationService: notificationService,
+	}
+}
+
+func (j *GitOpsDriftJob) Name() string {
+	return GitOpsDriftJobName
+}
+
+func (j *GitOpsDriftJob) Schedule(ctx context.Context) string {
+	schedule := j.settingsService.GetStringSetting(ctx, "gitopsDriftInterval", defaultGitOpsDriftInterval)
+	if schedule == "" {
+		return defaultGitOpsDriftInterval
+	}
+
+	parser := cron.NewParser(cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+	if _, err := parser.Parse(schedule); err != nil {
+		slog.WarnContext(ctx, "Invalid cron expression for gitops-drift, using default", "invalid_schedule", schedule, "error", err)
+		return defaultGitOpsDriftInterval
+	}
+
+	return schedule
+}
+
+// Run checks every GitOps project for drift from its repository. Syncs set to heal are
+// redeployed from git; drift on syncs set to notify or heal is reported in a single
+// notification.
+func (j *GitOpsDriftJob) Run(ctx context.Context) {
+	if !j.settingsService.GetBoolSetting(ctx, "gitopsDriftEnabled", false) {
+		slog.DebugContext(ctx, "GitOps drift check disabled; skipping run")
+		return
+	}
+
+	slog.InfoContext(ctx, "GitOps drift check started")
+
+	reports, err := j.syncService.DetectDriftForAll(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "GitOps drift check failed", "error", err)
+	}
+
+	slog.InfoContext(ctx, "GitOps drift check completed", "notify", len(reports))
+
+	if len(reports) > 0 {
+		if err := j.notificationService.SendGitOpsDriftNotification(ctx, reports); err != nil {
+			slog.WarnContext(ctx, "failed to send GitOps drift notification", "error", err)
+		}
+	}
+}
+
+func (j *GitOpsDriftJob) Reschedule(ctx context.Context) error {
+	slog.InfoContext(ctx, "rescheduling GitOps drift job in new scheduler; currently requires restart")
+	return nil
+}
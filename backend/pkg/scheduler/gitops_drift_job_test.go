@@ -0,0 +1,46 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitOpsDriftJobSchedule_Default(t *testing.T) {
+	ctx := context.Background()
+	settingsSvc := setupAnalyticsSettingsService(t)
+	job := NewGitOpsDriftJob(nil, settingsSvc, nil)
+
+	got := job.Schedule(ctx)
+	require.Equal(t, "0 */15 * * * *", got)
+}
+
+func TestGitOpsDriftJobSchedule_UsesConfiguredCron(t *testing.T) {
+	ctx := context.Background()
+	settingsSvc := setupAnalyticsSettingsService(t)
+	require.NoError(t, settingsSvc.SetStringSetting(ctx, "gitopsDriftInterval", "0 0 */2 * * *"))
+	job := NewGitOpsDriftJob(nil, settingsSvc, nil)
+
+	got := job.Schedule(ctx)
+	require.Equal(t, "0 0 */2 * * *", got)
+}
+
+func TestGitOpsDriftJobSchedule_InvalidCronFallsBackToDefault(t *testing.T) {
+	ctx := context.Background()
+	settingsSvc := setupAnalyticsSettingsService(t)
+	require.NoError(t, settingsSvc.SetStringSetting(ctx, "gitopsDriftInterval", "not-a-cron"))
+	job := NewGitOpsDriftJob(nil, settingsSvc, nil)
+
+	got := job.Schedule(ctx)
+	require.Equal(t, "0 */15 * * * *", got)
+}
+
+func TestGitOpsDriftJobRun_DisabledSkips(t *testing.T) {
+	ctx := context.Background()
+	settingsSvc := setupAnalyticsSettingsService(t)
+	job := NewGitOpsDriftJob(nil, settingsSvc, nil)
+
+	// A nil sync service would panic if the disabled job tried to run a check.
+	require.NotPanics(t, func() { job.Run(ctx) })
+}
@@ -0,0 +1,138 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/getarcaneapp/arcane/backend/internal/models"
+	"github.com/getarcaneapp/arcane/backend/internal/services"
+	"github.com/robfig/cron/v3"
+)
+
+const ScheduledVolumeBackupJobName = "scheduled-volume-backup"
+
+const defaultScheduledVolumeBackupInterval = "0 0 2 * * *"
+
+type ScheduledVolumeBackupJob struct {
+	volumeService       *services.VolumeService
+	settingsService     *services.SettingsService
+	notificationService *services.NotificationService
+	location            *time.Location
+}
+
+func NewScheduledVolumeBackupJob(volumeService *services.VolumeService, settingsService *services.SettingsService, notificationService *services.NotificationService, location *time.Location) *ScheduledVolumeBackupJob {
+	return &ScheduledVolumeBackupJob{
+		volumeService:       volumeService,
+		settingsService:     settingsService,
+		notificationService: notificationService,
+		location:            location,
+	}
+}
+
+func (j *ScheduledVolumeBackupJob) Name() string {
+	return ScheduledVolumeBackupJobName
+}
+
+func (j *ScheduledVolumeBackupJob) Schedule(ctx context.Context) string {
+	schedule := j.settingsService.GetStringSetting(ctx, "scheduledBackupInterval", defaultScheduledVolumeBackupInterval)
+	if schedule == "" {
+		return defaultScheduledVolumeBackupInterval
+	}
+
+	parser := cron.NewParser(cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+	if _, err := parser.Parse(schedule); err != nil {
+		slog.WarnContext(ctx, "Invalid cron expression for scheduled-volume-backup, using default", "invalid_schedule", schedule, "error", err)
+		return defaultScheduledVolumeBackupInterval
+	}
+
+	return schedule
+}
+
+// Run backs up every volume with an enabled backup policy, then prunes that volume's
+// scheduled backups according to the policy's retention rules. Before that it imports
+// archives found on backup targets that are missing from the database. Failures are
+// collected and reported in a single notification.
+func (j *ScheduledVolumeBackupJob) Run(ctx context.Context) {
+	if !j.settingsService.GetBoolSetting(ctx, "scheduledBackupEnabled", false) {
+		slog.DebugContext(ctx, "scheduled volume backup disabled; skipping run")
+		return
+	}
+
+	policies, err := j.volumeService.ListEnabledBackupPolicies(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "scheduled volume backup run failed to list policies", "error", err)
+		return
+	}
+	if len(policies) == 0 {
+		slog.DebugContext(ctx, "scheduled volume backup run skipped; no volumes have an enabled policy")
+		return
+	}
+
+	slog.InfoContext(ctx, "scheduled volume backup run started", "volumes", len(policies))
+
+	var failures []services.VolumeBackupFailure
+	succeeded, pruned := 0, 0
+	for i := range policies {
+		if ctx.Err() != nil {
+			slog.WarnContext(ctx, "scheduled volume backup run cancelled", "error", ctx.Err())
+			break
+		}
+
+		policy := &policies[i]
+		if imported, err := j.volumeService.RescanBackupTargets(ctx, policy.VolumeName); err != nil {
+			slog.WarnContext(ctx, "failed to rescan backup targets", "volume", policy.VolumeName, "error", err)
+		} else if imported > 0 {
+			slog.InfoContext(ctx, "imported backups from backup targets", "volume", policy.VolumeName, "count", imported)
+		}
+
+		runErr := j.backupVolume(ctx, policy, &pruned)
+		if runErr != nil {
+			failures = append(failures, services.VolumeBackupFailure{VolumeName: policy.VolumeName, Error: runErr.Error()})
+		} else {
+			succeeded++
+		}
+
+		if err := j.volumeService.RecordBackupPolicyRun(ctx, policy, runErr); err != nil {
+			slog.WarnContext(ctx, "failed to record backup policy run", "volume", policy.VolumeName, "error", err)
+		}
+	}
+
+	slog.InfoContext(ctx, "scheduled volume backup run completed",
+		"succeeded", succeeded,
+		"failed", len(failures),
+		"backups_pruned", pruned,
+	)
+
+	if len(failures) > 0 {
+		if err := j.notificationService.SendVolumeBackupFailureNotification(ctx, failures); err != nil {
+			slog.WarnContext(ctx, "failed to send volume backup failure notification", "error", err)
+		}
+	}
+}
+
+// backupVolume creates a scheduled backup for the policy's volume and applies its retention
+// rules. Old backups are only pruned after a new one was created successfully.
+func (j *ScheduledVolumeBackupJob) backupVolume(ctx context.Context, policy *models.VolumeBackupPolicy, pruned *int) error {
+	backup, err := j.volumeService.CreateScheduledBackup(ctx, policy)
+	if err != nil {
+		slog.ErrorContext(ctx, "scheduled volume backup failed", "volume", policy.VolumeName, "error", err)
+		return err
+	}
+	slog.DebugContext(ctx, "scheduled volume backup created", "volume", policy.VolumeName, "backup_id", backup.ID, "size", backup.Size)
+
+	count, err := j.volumeService.PruneScheduledBackups(ctx, policy, j.location)
+	*pruned += count
+	if err != nil {
+		slog.ErrorContext(ctx, "scheduled volume backup retention failed", "volume", policy.VolumeName, "error", err)
+		return fmt.Errorf("backup created but retention failed: %w", err)
+	}
+
+	return nil
+}
+
+func (j *ScheduledVolumeBackupJob) Reschedule(ctx context.Context) error {
+	slog.InfoContext(ctx, "rescheduling scheduled volume backup job in new scheduler; currently requires restart")
+	return nil
+}
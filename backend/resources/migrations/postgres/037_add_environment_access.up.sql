@@ -0,0 +1,3 @@
+-- add per-environment access grants to users and API keys (NULL = unrestricted)
+ALTER TABLE users ADD COLUMN IF NOT EXISTS environment_access JSONB;
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS environment_access JSONB;
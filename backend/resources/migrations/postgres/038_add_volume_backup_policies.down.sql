@@ -0,0 +1,2 @@
+ALTER TABLE volume_backups DROP COLUMN IF EXISTS scheduled;
+DROP TABLE IF EXISTS volume_backup_policies;
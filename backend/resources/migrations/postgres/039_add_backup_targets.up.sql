@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS backup_targets (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    type TEXT NOT NULL,
+    config JSONB,
+    credentials TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_backup_targets_name ON backup_targets(name);
+
+-- Backups stored off-host record the target they were written to
+ALTER TABLE volume_backups ADD COLUMN IF NOT EXISTS target_id TEXT;
+CREATE INDEX IF NOT EXISTS idx_volume_backups_target_id ON volume_backups(target_id);
+
+ALTER TABLE volume_backup_policies ADD COLUMN IF NOT EXISTS target_id TEXT;
@@ -0,0 +1,4 @@
+ALTER TABLE volume_backup_policies DROP COLUMN IF EXISTS passphrase;
+ALTER TABLE volume_backup_policies DROP COLUMN IF EXISTS encrypt;
+ALTER TABLE volume_backups DROP COLUMN IF EXISTS encryption;
+ALTER TABLE volume_backups DROP COLUMN IF EXISTS checksum;
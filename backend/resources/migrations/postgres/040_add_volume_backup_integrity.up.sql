@@ -0,0 +1,6 @@
+-- SHA-256 of the stored archive and how it is encrypted ('' = plain, instance, passphrase)
+ALTER TABLE volume_backups ADD COLUMN IF NOT EXISTS checksum TEXT;
+ALTER TABLE volume_backups ADD COLUMN IF NOT EXISTS encryption TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE volume_backup_policies ADD COLUMN IF NOT EXISTS encrypt BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE volume_backup_policies ADD COLUMN IF NOT EXISTS passphrase TEXT;
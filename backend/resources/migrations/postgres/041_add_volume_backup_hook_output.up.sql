@@ -0,0 +1,2 @@
+-- Output of the pre/post-backup hooks run for a backup
+ALTER TABLE volume_backups ADD COLUMN IF NOT EXISTS hook_output TEXT;
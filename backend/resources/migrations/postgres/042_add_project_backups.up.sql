@@ -0,0 +1,14 @@
+-- A project backup is a set of volume backups of all named volumes of a project taken
+-- together; volumes holds the JSON list of {name, volumeName, external, backupId, size}.
+CREATE TABLE IF NOT EXISTS project_backups (
+    id TEXT PRIMARY KEY,
+    project_id TEXT NOT NULL,
+    project_name TEXT NOT NULL,
+    stopped_project BOOLEAN NOT NULL DEFAULT false,
+    compose_content TEXT NOT NULL DEFAULT '',
+    env_content TEXT NOT NULL DEFAULT '',
+    volumes TEXT NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+CREATE INDEX IF NOT EXISTS idx_project_backups_project_id ON project_backups(project_id);
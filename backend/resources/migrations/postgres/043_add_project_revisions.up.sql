@@ -0,0 +1,20 @@
+-- Every change to the compose, env or include files of a project is stored as a
+-- numbered revision holding a snapshot of all files and the diff to the previous one.
+CREATE TABLE IF NOT EXISTS project_revisions (
+    id TEXT PRIMARY KEY,
+    project_id TEXT NOT NULL,
+    revision INTEGER NOT NULL,
+    source TEXT NOT NULL DEFAULT 'update',
+    message TEXT NOT NULL DEFAULT '',
+    author_id TEXT,
+    author_name TEXT NOT NULL DEFAULT '',
+    compose_content TEXT NOT NULL DEFAULT '',
+    env_content TEXT NOT NULL DEFAULT '',
+    include_files TEXT NOT NULL DEFAULT '{}',
+    diff TEXT NOT NULL DEFAULT '',
+    lines_added INTEGER NOT NULL DEFAULT 0,
+    lines_removed INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_project_revisions_project_revision ON project_revisions(project_id, revision);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_exec_sessions_started_at;
+DROP INDEX IF EXISTS idx_exec_sessions_container_id;
+DROP TABLE IF EXISTS exec_sessions;
@@ -0,0 +1,21 @@
+-- Interactive exec sessions in containers. The full terminal I/O of each session is
+-- recorded in asciicast format to the file at recording_path.
+CREATE TABLE IF NOT EXISTS exec_sessions (
+    id TEXT PRIMARY KEY,
+    environment_id TEXT NOT NULL DEFAULT '0',
+    container_id TEXT NOT NULL,
+    container_name TEXT NOT NULL DEFAULT '',
+    command TEXT NOT NULL DEFAULT '',
+    user_id TEXT NOT NULL DEFAULT '',
+    username TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'active',
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ended_at TIMESTAMP WITH TIME ZONE,
+    exit_code INTEGER,
+    recording_path TEXT NOT NULL DEFAULT '',
+    recording_size BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+CREATE INDEX IF NOT EXISTS idx_exec_sessions_container_id ON exec_sessions(container_id);
+CREATE INDEX IF NOT EXISTS idx_exec_sessions_started_at ON exec_sessions(started_at);
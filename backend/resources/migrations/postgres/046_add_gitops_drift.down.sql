@@ -0,0 +1,5 @@
+ALTER TABLE gitops_syncs DROP COLUMN drift_items;
+ALTER TABLE gitops_syncs DROP COLUMN drift_error;
+ALTER TABLE gitops_syncs DROP COLUMN drift_checked_at;
+ALTER TABLE gitops_syncs DROP COLUMN drift_status;
+ALTER TABLE gitops_syncs DROP COLUMN drift_action;
@@ -0,0 +1,6 @@
+-- Drift detection: what to do when the deployed project no longer matches git, and the last result
+ALTER TABLE gitops_syncs ADD COLUMN drift_action TEXT NOT NULL DEFAULT 'report';
+ALTER TABLE gitops_syncs ADD COLUMN drift_status TEXT;
+ALTER TABLE gitops_syncs ADD COLUMN drift_checked_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE gitops_syncs ADD COLUMN drift_error TEXT;
+ALTER TABLE gitops_syncs ADD COLUMN drift_items TEXT;
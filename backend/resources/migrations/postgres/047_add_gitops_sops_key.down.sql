@@ -0,0 +1 @@
+ALTER TABLE gitops_syncs DROP COLUMN sops_age_key;
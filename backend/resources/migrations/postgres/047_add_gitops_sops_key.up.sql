@@ -0,0 +1,2 @@
+-- age keys SOPS-encrypted files in the repository are decrypted with (encrypted); NULL leaves files as they are
+ALTER TABLE gitops_syncs ADD COLUMN sops_age_key TEXT;
@@ -0,0 +1,2 @@
+ALTER TABLE gitops_syncs DROP COLUMN ref;
+ALTER TABLE gitops_syncs DROP COLUMN ref_type;
@@ -0,0 +1,2 @@
+-- Projects whose files a GitOps sync wrote from SOPS-decrypted content
+ALTER TABLE projects ADD COLUMN sops_decrypted BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,3 @@
+-- remove per-environment access grants from users and API keys
+ALTER TABLE api_keys DROP COLUMN environment_access;
+ALTER TABLE users DROP COLUMN environment_access;
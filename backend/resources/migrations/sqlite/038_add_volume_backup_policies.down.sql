@@ -0,0 +1,2 @@
+ALTER TABLE volume_backups DROP COLUMN scheduled;
+DROP TABLE IF EXISTS volume_backup_policies;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS volume_backup_policies (
+    id TEXT PRIMARY KEY,
+    volume_name TEXT NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    keep_last INTEGER NOT NULL DEFAULT 0,
+    keep_daily INTEGER NOT NULL DEFAULT 0,
+    keep_weekly INTEGER NOT NULL DEFAULT 0,
+    last_run_at TIMESTAMP,
+    last_error TEXT,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_volume_backup_policies_volume_name ON volume_backup_policies(volume_name);
+
+-- Only backups taken by the scheduler are subject to retention pruning
+ALTER TABLE volume_backups ADD COLUMN scheduled BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,4 @@
+ALTER TABLE volume_backup_policies DROP COLUMN target_id;
+DROP INDEX IF EXISTS idx_volume_backups_target_id;
+ALTER TABLE volume_backups DROP COLUMN target_id;
+DROP TABLE IF EXISTS backup_targets;
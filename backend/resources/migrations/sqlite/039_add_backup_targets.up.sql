@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS backup_targets (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    type TEXT NOT NULL,
+    config TEXT,
+    credentials TEXT,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_backup_targets_name ON backup_targets(name);
+
+-- Backups stored off-host record the target they were written to
+ALTER TABLE volume_backups ADD COLUMN target_id TEXT;
+CREATE INDEX IF NOT EXISTS idx_volume_backups_target_id ON volume_backups(target_id);
+
+ALTER TABLE volume_backup_policies ADD COLUMN target_id TEXT;
@@ -0,0 +1,4 @@
+ALTER TABLE volume_backup_policies DROP COLUMN passphrase;
+ALTER TABLE volume_backup_policies DROP COLUMN encrypt;
+ALTER TABLE volume_backups DROP COLUMN encryption;
+ALTER TABLE volume_backups DROP COLUMN checksum;
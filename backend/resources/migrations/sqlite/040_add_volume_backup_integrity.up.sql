@@ -0,0 +1,6 @@
+-- SHA-256 of the stored archive and how it is encrypted ('' = plain, instance, passphrase)
+ALTER TABLE volume_backups ADD COLUMN checksum TEXT;
+ALTER TABLE volume_backups ADD COLUMN encryption TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE volume_backup_policies ADD COLUMN encrypt BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE volume_backup_policies ADD COLUMN passphrase TEXT;
@@ -0,0 +1 @@
+ALTER TABLE volume_backups DROP COLUMN hook_output;
@@ -0,0 +1,2 @@
+-- Output of the pre/post-backup hooks run for a backup
+ALTER TABLE volume_backups ADD COLUMN hook_output TEXT;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_project_backups_project_id;
+DROP TABLE IF EXISTS project_backups;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_project_revisions_project_revision;
+DROP TABLE IF EXISTS project_revisions;
@@ -0,0 +1,2 @@
+-- Secret push webhooks for the sync are verified with (encrypted); NULL disables the webhook
+ALTER TABLE gitops_syncs ADD COLUMN webhook_secret TEXT;
@@ -0,0 +1,3 @@
+-- What the sync deploys: its branch head, a tag, a commit or the newest tag in a semver range
+ALTER TABLE gitops_syncs ADD COLUMN ref_type TEXT NOT NULL DEFAULT 'branch';
+ALTER TABLE gitops_syncs ADD COLUMN ref TEXT NOT NULL DEFAULT '';
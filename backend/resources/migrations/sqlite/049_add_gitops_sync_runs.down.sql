@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_gitops_sync_runs_started;
+DROP INDEX IF EXISTS idx_gitops_sync_runs_sync_started;
+DROP TABLE IF EXISTS gitops_sync_runs;
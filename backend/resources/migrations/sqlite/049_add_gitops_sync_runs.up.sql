@@ -0,0 +1,21 @@
+-- Every run of a GitOps sync is recorded with the commit it deployed, the files changed
+-- since the previous run and the log of its steps. Old runs are removed by the event cleanup job.
+CREATE TABLE IF NOT EXISTS gitops_sync_runs (
+    id TEXT PRIMARY KEY,
+    sync_id TEXT NOT NULL,
+    status TEXT NOT NULL,
+    message TEXT NOT NULL DEFAULT '',
+    error TEXT,
+    ref TEXT NOT NULL DEFAULT '',
+    commit_hash TEXT NOT NULL DEFAULT '',
+    commit_message TEXT NOT NULL DEFAULT '',
+    commit_author TEXT NOT NULL DEFAULT '',
+    changed_files TEXT NOT NULL DEFAULT '[]',
+    output TEXT NOT NULL DEFAULT '',
+    started_at TIMESTAMP NOT NULL,
+    duration_ms INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_gitops_sync_runs_sync_started ON gitops_sync_runs(sync_id, started_at);
+CREATE INDEX IF NOT EXISTS idx_gitops_sync_runs_started ON gitops_sync_runs(started_at);
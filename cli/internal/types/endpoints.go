@@ -41,13 +41,22 @@ type ArcaneApiEndpoints struct {
 	EnvironmentTestEndpoint  string
 
 	// Containers
-	ContainersEndpoint       string
-	ContainerEndpoint        string
-	ContainerStartEndpoint   string
-	ContainerStopEndpoint    string
-	ContainerRestartEndpoint string
-	ContainerUpdateEndpoint  string
-	ContainersCountsEndpoint string
+	ContainersEndpoint             string
+	ContainerEndpoint              string
+	ContainerStartEndpoint         string
+	ContainerStopEndpoint          string
+	ContainerRestartEndpoint       string
+	ContainerUpdateEndpoint        string
+	ContainerResourcesEndpoint     string
+	ContainerFilesEndpoint         string
+	ContainerFilesDownloadEndpoint string
+	ContainerFilesUploadEndpoint   string
+	ContainerChangesEndpoint       string
+	ContainerCommitEndpoint        string
+	ExecSessionsEndpoint           string
+	ExecSessionEndpoint            string
+	ExecSessionRecordingEndpoint   string
+	ContainersCountsEndpoint       string
 
 	// Images
 	ImagesEndpoint       string
@@ -56,6 +65,9 @@ type ArcaneApiEndpoints struct {
 	ImagesPruneEndpoint  string
 	ImagesCountsEndpoint string
 	ImagesUploadEndpoint string
+	ImagesTagEndpoint    string
+	ImagesPushEndpoint   string
+	ImagesSaveEndpoint   string
 
 	// Image Updates
 	ImageUpdatesCheckEndpoint      string
@@ -65,10 +77,13 @@ type ArcaneApiEndpoints struct {
 	ImageUpdatesSummaryEndpoint    string
 
 	// Networks
-	NetworksEndpoint       string
-	NetworkEndpoint        string
-	NetworksCountsEndpoint string
-	NetworksPruneEndpoint  string
+	NetworksEndpoint          string
+	NetworkEndpoint           string
+	NetworksCountsEndpoint    string
+	NetworksPruneEndpoint     string
+	NetworkConnectEndpoint    string
+	NetworkDisconnectEndpoint string
+	NetworksTopologyEndpoint  string
 
 	// Volumes
 	VolumesEndpoint       string
@@ -79,16 +94,27 @@ type ArcaneApiEndpoints struct {
 	VolumeUsageEndpoint   string
 
 	// Projects (Stacks)
-	ProjectsEndpoint        string
-	ProjectEndpoint         string
-	ProjectsCountsEndpoint  string
-	ProjectDestroyEndpoint  string
-	ProjectUpEndpoint       string
-	ProjectDownEndpoint     string
-	ProjectRestartEndpoint  string
-	ProjectRedeployEndpoint string
-	ProjectPullEndpoint     string
-	ProjectIncludesEndpoint string
+	ProjectsEndpoint                string
+	ProjectEndpoint                 string
+	ProjectsCountsEndpoint          string
+	ProjectDestroyEndpoint          string
+	ProjectUpEndpoint               string
+	ProjectDownEndpoint             string
+	ProjectRestartEndpoint          string
+	ProjectRedeployEndpoint         string
+	ProjectPullEndpoint             string
+	ProjectBuildEndpoint            string
+	ProjectIncludesEndpoint         string
+	ProjectBackupsEndpoint          string
+	ProjectBackupEndpoint           string
+	ProjectRestoreEndpoint          string
+	ProjectRevisionsEndpoint        string
+	ProjectRevisionEndpoint         string
+	ProjectRevisionDiffEndpoint     string
+	ProjectRevisionRedeployEndpoint string
+	ProjectPlanEndpoint             string
+	ProjectLogsSearchEndpoint       string
+	ProjectLogsExportEndpoint       string
 
 	// System
 	SystemPruneEndpoint                  string
@@ -201,13 +227,22 @@ var Endpoints = ArcaneApiEndpoints{ //nolint:gosec // static endpoint paths; aut
 	EnvironmentTestEndpoint:  "/api/environments/%s/test",
 
 	// Containers
-	ContainersEndpoint:       "/api/environments/%s/containers",
-	ContainerEndpoint:        "/api/environments/%s/containers/%s",
-	ContainerStartEndpoint:   "/api/environments/%s/containers/%s/start",
-	ContainerStopEndpoint:    "/api/environments/%s/containers/%s/stop",
-	ContainerRestartEndpoint: "/api/environments/%s/containers/%s/restart",
-	ContainerUpdateEndpoint:  "/api/environments/%s/containers/%s/update",
-	ContainersCountsEndpoint: "/api/environments/%s/containers/counts",
+	ContainersEndpoint:             "/api/environments/%s/containers",
+	ContainerEndpoint:              "/api/environments/%s/containers/%s",
+	ContainerStartEndpoint:         "/api/environments/%s/containers/%s/start",
+	ContainerStopEndpoint:          "/api/environments/%s/containers/%s/stop",
+	ContainerRestartEndpoint:       "/api/environments/%s/containers/%s/restart",
+	ContainerUpdateEndpoint:        "/api/environments/%s/containers/%s/update",
+	ContainerResourcesEndpoint:     "/api/environments/%s/containers/%s/resources",
+	ContainerFilesEndpoint:         "/api/environments/%s/containers/%s/files",
+	ContainerFilesDownloadEndpoint: "/api/environments/%s/containers/%s/files/download",
+	ContainerFilesUploadEndpoint:   "/api/environments/%s/containers/%s/files/upload",
+	ContainerChangesEndpoint:       "/api/environments/%s/containers/%s/changes",
+	ContainerCommitEndpoint:        "/api/environments/%s/containers/%s/commit",
+	ExecSessionsEndpoint:           "/api/environments/%s/containers/exec-sessions",
+	ExecSessionEndpoint:            "/api/environments/%s/containers/exec-sessions/%s",
+	ExecSessionRecordingEndpoint:   "/api/environments/%s/containers/exec-sessions/%s/recording",
+	ContainersCountsEndpoint:       "/api/environments/%s/containers/counts",
 
 	// Images
 	ImagesEndpoint:       "/api/environments/%s/images",
@@ -216,6 +251,9 @@ var Endpoints = ArcaneApiEndpoints{ //nolint:gosec // static endpoint paths; aut
 	ImagesPruneEndpoint:  "/api/environments/%s/images/prune",
 	ImagesCountsEndpoint: "/api/environments/%s/images/counts",
 	ImagesUploadEndpoint: "/api/environments/%s/images/upload",
+	ImagesTagEndpoint:    "/api/environments/%s/images/tag",
+	ImagesPushEndpoint:   "/api/environments/%s/images/push",
+	ImagesSaveEndpoint:   "/api/environments/%s/images/save",
 
 	// Image Updates
 	ImageUpdatesCheckEndpoint:      "/api/environments/%s/image-updates/check",
@@ -225,10 +263,13 @@ var Endpoints = ArcaneApiEndpoints{ //nolint:gosec // static endpoint paths; aut
 	ImageUpdatesSummaryEndpoint:    "/api/environments/%s/image-updates/summary",
 
 	// Networks
-	NetworksEndpoint:       "/api/environments/%s/networks",
-	NetworkEndpoint:        "/api/environments/%s/networks/%s",
-	NetworksCountsEndpoint: "/api/environments/%s/networks/counts",
-	NetworksPruneEndpoint:  "/api/environments/%s/networks/prune",
+	NetworksEndpoint:          "/api/environments/%s/networks",
+	NetworkEndpoint:           "/api/environments/%s/networks/%s",
+	NetworksCountsEndpoint:    "/api/environments/%s/networks/counts",
+	NetworksPruneEndpoint:     "/api/environments/%s/networks/prune",
+	NetworkConnectEndpoint:    "/api/environments/%s/networks/%s/connect",
+	NetworkDisconnectEndpoint: "/api/environments/%s/networks/%s/disconnect",
+	NetworksTopologyEndpoint:  "/api/environments/%s/networks/topology",
 
 	// Volumes
 	VolumesEndpoint:       "/api/environments/%s/volumes",
@@ -239,16 +280,27 @@ var Endpoints = ArcaneApiEndpoints{ //nolint:gosec // static endpoint paths; aut
 	VolumeUsageEndpoint:   "/api/environments/%s/volumes/%s/usage",
 
 	// Projects (Stacks)
-	ProjectsEndpoint:        "/api/environments/%s/projects",
-	ProjectEndpoint:         "/api/environments/%s/projects/%s",
-	ProjectsCountsEndpoint:  "/api/environments/%s/projects/counts",
-	ProjectDestroyEndpoint:  "/api/environments/%s/projects/%s/destroy",
-	ProjectUpEndpoint:       "/api/environments/%s/projects/%s/up",
-	ProjectDownEndpoint:     "/api/environments/%s/projects/%s/down",
-	ProjectRestartEndpoint:  "/api/environments/%s/projects/%s/restart",
-	ProjectRedeployEndpoint: "/api/environments/%s/projects/%s/redeploy",
-	ProjectPullEndpoint:     "/api/environments/%s/projects/%s/pull",
-	ProjectIncludesEndpoint: "/api/environments/%s/projects/%s/includes",
+	ProjectsEndpoint:                "/api/environments/%s/projects",
+	ProjectEndpoint:                 "/api/environments/%s/projects/%s",
+	ProjectsCountsEndpoint:          "/api/environments/%s/projects/counts",
+	ProjectDestroyEndpoint:          "/api/environments/%s/projects/%s/destroy",
+	ProjectUpEndpoint:               "/api/environments/%s/projects/%s/up",
+	ProjectDownEndpoint:             "/api/environments/%s/projects/%s/down",
+	ProjectRestartEndpoint:          "/api/environments/%s/projects/%s/restart",
+	ProjectRedeployEndpoint:         "/api/environments/%s/projects/%s/redeploy",
+	ProjectPullEndpoint:             "/api/environments/%s/projects/%s/pull",
+	ProjectBuildEndpoint:            "/api/environments/%s/projects/%s/build",
+	ProjectIncludesEndpoint:         "/api/environments/%s/projects/%s/includes",
+	ProjectBackupsEndpoint:          "/api/environments/%s/projects/%s/backups",
+	ProjectBackupEndpoint:           "/api/environments/%s/projects/%s/backups/%s",
+	ProjectRestoreEndpoint:          "/api/environments/%s/projects/%s/backups/%s/restore",
+	ProjectRevisionsEndpoint:        "/api/environments/%s/projects/%s/revisions",
+	ProjectRevisionEndpoint:         "/api/environments/%s/projects/%s/revisions/%d",
+	ProjectRevisionDiffEndpoint:     "/api/environments/%s/projects/%s/revisions/diff?from=%d&to=%d",
+	ProjectRevisionRedeployEndpoint: "/api/environments/%s/projects/%s/revisions/%d/redeploy",
+	ProjectPlanEndpoint:             "/api/environments/%s/projects/%s/plan",
+	ProjectLogsSearchEndpoint:       "/api/environments/%s/projects/%s/logs/search",
+	ProjectLogsExportEndpoint:       "/api/environments/%s/projects/%s/logs/export",
 
 	// System
 	SystemPruneEndpoint:                  "/api/environments/%s/system/prune",
@@ -378,6 +430,33 @@ func (e ArcaneApiEndpoints) ContainerRestart(envID, containerID string) string {
 func (e ArcaneApiEndpoints) ContainerUpdate(envID, containerID string) string {
 	return fmt.Sprintf(e.ContainerUpdateEndpoint, envID, containerID)
 }
+func (e ArcaneApiEndpoints) ContainerResources(envID, containerID string) string {
+	return fmt.Sprintf(e.ContainerResourcesEndpoint, envID, containerID)
+}
+func (e ArcaneApiEndpoints) ContainerFiles(envID, containerID string) string {
+	return fmt.Sprintf(e.ContainerFilesEndpoint, envID, containerID)
+}
+func (e ArcaneApiEndpoints) ContainerFilesDownload(envID, containerID string) string {
+	return fmt.Sprintf(e.ContainerFilesDownloadEndpoint, envID, containerID)
+}
+func (e ArcaneApiEndpoints) ContainerFilesUpload(envID, containerID string) string {
+	return fmt.Sprintf(e.ContainerFilesUploadEndpoint, envID, containerID)
+}
+func (e ArcaneApiEndpoints) ContainerChanges(envID, containerID string) string {
+	return fmt.Sprintf(e.ContainerChangesEndpoint, envID, containerID)
+}
+func (e ArcaneApiEndpoints) ContainerCommit(envID, containerID string) string {
+	return fmt.Sprintf(e.ContainerCommitEndpoint, envID, containerID)
+}
+func (e ArcaneApiEndpoints) ExecSessions(envID string) string {
+	return fmt.Sprintf(e.ExecSessionsEndpoint, envID)
+}
+func (e ArcaneApiEndpoints) ExecSession(envID, sessionID string) string {
+	return fmt.Sprintf(e.ExecSessionEndpoint, envID, sessionID)
+}
+func (e ArcaneApiEndpoints) ExecSessionRecording(envID, sessionID string) string {
+	return fmt.Sprintf(e.ExecSessionRecordingEndpoint, envID, sessionID)
+}
 func (e ArcaneApiEndpoints) ContainersCounts(envID string) string {
 	return fmt.Sprintf(e.ContainersCountsEndpoint, envID)
 }
@@ -399,6 +478,15 @@ func (e ArcaneApiEndpoints) ImagesCounts(envID string) string {
 func (e ArcaneApiEndpoints) ImagesUpload(envID string) string {
 	return fmt.Sprintf(e.ImagesUploadEndpoint, envID)
 }
+func (e ArcaneApiEndpoints) ImagesTag(envID string) string {
+	return fmt.Sprintf(e.ImagesTagEndpoint, envID)
+}
+func (e ArcaneApiEndpoints) ImagesPush(envID string) string {
+	return fmt.Sprintf(e.ImagesPushEndpoint, envID)
+}
+func (e ArcaneApiEndpoints) ImagesSave(envID string) string {
+	return fmt.Sprintf(e.ImagesSaveEndpoint, envID)
+}
 
 // Image Update endpoints
 func (e ArcaneApiEndpoints) ImageUpdatesCheck(envID string) string {
@@ -430,6 +518,15 @@ func (e ArcaneApiEndpoints) NetworksCounts(envID string) string {
 func (e ArcaneApiEndpoints) NetworksPrune(envID string) string {
 	return fmt.Sprintf(e.NetworksPruneEndpoint, envID)
 }
+func (e ArcaneApiEndpoints) NetworkConnect(envID, networkID string) string {
+	return fmt.Sprintf(e.NetworkConnectEndpoint, envID, networkID)
+}
+func (e ArcaneApiEndpoints) NetworkDisconnect(envID, networkID string) string {
+	return fmt.Sprintf(e.NetworkDisconnectEndpoint, envID, networkID)
+}
+func (e ArcaneApiEndpoints) NetworksTopology(envID string) string {
+	return fmt.Sprintf(e.NetworksTopologyEndpoint, envID)
+}
 
 // Volume endpoints
 func (e ArcaneApiEndpoints) Volumes(envID string) string {
@@ -479,9 +576,42 @@ func (e ArcaneApiEndpoints) ProjectRedeploy(envID, projectID string) string {
 func (e ArcaneApiEndpoints) ProjectPull(envID, projectID string) string {
 	return fmt.Sprintf(e.ProjectPullEndpoint, envID, projectID)
 }
+func (e ArcaneApiEndpoints) ProjectBuild(envID, projectID string) string {
+	return fmt.Sprintf(e.ProjectBuildEndpoint, envID, projectID)
+}
 func (e ArcaneApiEndpoints) ProjectIncludes(envID, projectID string) string {
 	return fmt.Sprintf(e.ProjectIncludesEndpoint, envID, projectID)
 }
+func (e ArcaneApiEndpoints) ProjectBackups(envID, projectID string) string {
+	return fmt.Sprintf(e.ProjectBackupsEndpoint, envID, projectID)
+}
+func (e ArcaneApiEndpoints) ProjectBackup(envID, projectID, backupID string) string {
+	return fmt.Sprintf(e.ProjectBackupEndpoint, envID, projectID, backupID)
+}
+func (e ArcaneApiEndpoints) ProjectRestore(envID, projectID, backupID string) string {
+	return fmt.Sprintf(e.ProjectRestoreEndpoint, envID, projectID, backupID)
+}
+func (e ArcaneApiEndpoints) ProjectRevisions(envID, projectID string) string {
+	return fmt.Sprintf(e.ProjectRevisionsEndpoint, envID, projectID)
+}
+func (e ArcaneApiEndpoints) ProjectRevision(envID, projectID string, revision int) string {
+	return fmt.Sprintf(e.ProjectRevisionEndpoint, envID, projectID, revision)
+}
+func (e ArcaneApiEndpoints) ProjectRevisionDiff(envID, projectID string, from, to int) string {
+	return fmt.Sprintf(e.ProjectRevisionDiffEndpoint, envID, projectID, from, to)
+}
+func (e ArcaneApiEndpoints) ProjectRevisionRedeploy(envID, projectID string, revision int) string {
+	return fmt.Sprintf(e.ProjectRevisionRedeployEndpoint, envID, projectID, revision)
+}
+func (e ArcaneApiEndpoints) ProjectPlan(envID, projectID string) string {
+	return fmt.Sprintf(e.ProjectPlanEndpoint, envID, projectID)
+}
+func (e ArcaneApiEndpoints) ProjectLogsSearch(envID, projectID string) string {
+	return fmt.Sprintf(e.ProjectLogsSearchEndpoint, envID, projectID)
+}
+func (e ArcaneApiEndpoints) ProjectLogsExport(envID, projectID string) string {
+	return fmt.Sprintf(e.ProjectLogsExportEndpoint, envID, projectID)
+}
 
 // System endpoints
 func (e ArcaneApiEndpoints) SystemPrune(envID string) string {
@@ -0,0 +1,90 @@
+package containers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/getarcaneapp/arcane/cli/internal/client"
+	"github.com/getarcaneapp/arcane/cli/internal/output"
+	"github.com/getarcaneapp/arcane/cli/internal/types"
+	"github.com/getarcaneapp/arcane/types/base"
+	"github.com/getarcaneapp/arcane/types/container"
+	"github.com/getarcaneapp/arcane/types/image"
+	"github.com/spf13/cobra"
+)
+
+var (
+	commitAuthor  string
+	commitMessage string
+	commitPause   bool
+)
+
+var containersCommitCmd = &cobra.Command{
+	Use:   "commit <container-id|name> [repository[:tag]]",
+	Short: "Create a new image from a container's changes",
+	Long: `Commit the current state of a container to a new image. The container is paused
+while it is committed unless --pause=false is given.`,
+	Example:      `  arcane containers commit web web:debug -m "state before upgrade"`,
+	Args:         cobra.RangeArgs(1, 2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := client.NewFromConfig()
+		if err != nil {
+			return err
+		}
+
+		resolved, _, err := resolveContainer(cmd.Context(), c, args[0], false)
+		if err != nil {
+			return err
+		}
+
+		req := container.Commit{
+			Author:  commitAuthor,
+			Message: commitMessage,
+			Pause:   &commitPause,
+		}
+		if len(args) == 2 {
+			req.Reference = args[1]
+		}
+
+		resp, err := c.Post(cmd.Context(), types.Endpoints.ContainerCommit(c.EnvID(), resolved.ID), req)
+		if err != nil {
+			return fmt.Errorf("failed to commit container: %w", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if err := checkResponse(resp); err != nil {
+			return fmt.Errorf("failed to commit container: %w", err)
+		}
+
+		var result base.ApiResponse[image.DetailSummary]
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+
+		if jsonOutput {
+			resultBytes, err := json.MarshalIndent(result.Data, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(resultBytes))
+			return nil
+		}
+
+		name := strings.Join(result.Data.RepoTags, ", ")
+		if name == "" {
+			name = result.Data.ID
+		}
+		output.Success("Container %s committed to image %s", containerDisplayName(resolved), name)
+		return nil
+	},
+}
+
+func init() {
+	ContainersCmd.AddCommand(containersCommitCmd)
+
+	containersCommitCmd.Flags().StringVarP(&commitAuthor, "author", "a", "", "Author of the image")
+	containersCommitCmd.Flags().StringVarP(&commitMessage, "message", "m", "", "Commit message")
+	containersCommitCmd.Flags().BoolVarP(&commitPause, "pause", "p", true, "Pause the container while committing")
+	containersCommitCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+}
@@ -0,0 +1,349 @@
+package containers
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/getarcaneapp/arcane/cli/internal/client"
+	"github.com/getarcaneapp/arcane/cli/internal/output"
+	"github.com/getarcaneapp/arcane/cli/internal/types"
+	"github.com/getarcaneapp/arcane/types/base"
+	"github.com/getarcaneapp/arcane/types/container"
+	"github.com/getarcaneapp/arcane/types/volume"
+	"github.com/spf13/cobra"
+)
+
+var containersFilesCmd = &cobra.Command{
+	Use:   "files <container-id|name> [path]",
+	Short: "List files in a container",
+	Long: `List a directory of a container's filesystem. Stopped containers and containers
+without a shell can be listed too.`,
+	Example:      `  arcane containers files web /etc/nginx`,
+	Args:         cobra.RangeArgs(1, 2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dirPath := "/"
+		if len(args) == 2 {
+			dirPath = args[1]
+		}
+
+		c, err := client.NewFromConfig()
+		if err != nil {
+			return err
+		}
+
+		resolved, _, err := resolveContainer(cmd.Context(), c, args[0], false)
+		if err != nil {
+			return err
+		}
+
+		reqPath := types.Endpoints.ContainerFiles(c.EnvID(), resolved.ID) + "?path=" + url.QueryEscape(dirPath)
+		resp, err := c.Get(cmd.Context(), reqPath)
+		if err != nil {
+			return fmt.Errorf("failed to list files: %w", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if err := checkResponse(resp); err != nil {
+			return fmt.Errorf("failed to list files: %w", err)
+		}
+
+		var result base.ApiResponse[[]volume.FileEntry]
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+
+		if jsonOutput {
+			resultBytes, err := json.MarshalIndent(result.Data, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(resultBytes))
+			return nil
+		}
+
+		headers := []string{"MODE", "SIZE", "MODIFIED", "NAME"}
+		rows := make([][]string, len(result.Data))
+		for i, entry := range result.Data {
+			name := entry.Name
+			if entry.IsDirectory {
+				name += "/"
+			}
+			if entry.IsSymlink && entry.LinkTarget != "" {
+				name += " -> " + entry.LinkTarget
+			}
+			rows[i] = []string{entry.Mode, fmt.Sprintf("%d", entry.Size), entry.ModTime.Format("2006-01-02 15:04"), name}
+		}
+
+		output.Table(headers, rows)
+		fmt.Printf("\nTotal: %d entries\n", len(result.Data))
+		return nil
+	},
+}
+
+var containersCpCmd = &cobra.Command{
+	Use:   "cp <container:src-path> <dest-path> | <src-file> <container:dest-dir>",
+	Short: "Copy files between a container and the local filesystem",
+	Long: `Copy a file or directory out of a container, or a single file into a directory of a
+container. Use '-' as the destination to write the tar archive of a container path to
+stdout.`,
+	Example: `  arcane containers cp web:/etc/nginx ./nginx
+  arcane containers cp web:/var/log/app.log - > app.tar
+  arcane containers cp ./index.html web:/usr/share/nginx/html`,
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		srcContainer, srcPath, srcRemote := splitContainerPath(args[0])
+		dstContainer, dstPath, dstRemote := splitContainerPath(args[1])
+		if srcRemote == dstRemote {
+			return fmt.Errorf("exactly one of source and destination must be a container path (container:path)")
+		}
+
+		c, err := client.NewFromConfig()
+		if err != nil {
+			return err
+		}
+
+		if srcRemote {
+			resolved, _, err := resolveContainer(cmd.Context(), c, srcContainer, false)
+			if err != nil {
+				return err
+			}
+			return copyFromContainer(cmd, c, resolved, srcPath, dstPath)
+		}
+
+		resolved, _, err := resolveContainer(cmd.Context(), c, dstContainer, false)
+		if err != nil {
+			return err
+		}
+		return copyToContainer(cmd, c, resolved, srcPath, dstPath)
+	},
+}
+
+var containersDiffCmd = &cobra.Command{
+	Use:          "diff <container-id|name>",
+	Short:        "List changes to a container's filesystem",
+	Long:         `List files added (A), changed (C) or deleted (D) in a container since it was created.`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := client.NewFromConfig()
+		if err != nil {
+			return err
+		}
+
+		resolved, _, err := resolveContainer(cmd.Context(), c, args[0], false)
+		if err != nil {
+			return err
+		}
+
+		resp, err := c.Get(cmd.Context(), types.Endpoints.ContainerChanges(c.EnvID(), resolved.ID))
+		if err != nil {
+			return fmt.Errorf("failed to get container changes: %w", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if err := checkResponse(resp); err != nil {
+			return fmt.Errorf("failed to get container changes: %w", err)
+		}
+
+		var result base.ApiResponse[[]container.FileChange]
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+
+		if jsonOutput {
+			resultBytes, err := json.MarshalIndent(result.Data, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(resultBytes))
+			return nil
+		}
+
+		kinds := map[string]string{
+			container.FileChangeAdded:    "A",
+			container.FileChangeModified: "C",
+			container.FileChangeDeleted:  "D",
+		}
+		for _, change := range result.Data {
+			fmt.Printf("%s %s\n", kinds[change.Kind], change.Path)
+		}
+		return nil
+	},
+}
+
+func init() {
+	ContainersCmd.AddCommand(containersFilesCmd)
+	ContainersCmd.AddCommand(containersCpCmd)
+	ContainersCmd.AddCommand(containersDiffCmd)
+
+	containersFilesCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	containersDiffCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+}
+
+// splitContainerPath splits a "container:path" argument. Arguments that start with a
+// path separator or a dot are always local paths.
+func splitContainerPath(arg string) (string, string, bool) {
+	if strings.HasPrefix(arg, "/") || strings.HasPrefix(arg, ".") || filepath.IsAbs(arg) {
+		return "", arg, false
+	}
+	name, p, ok := strings.Cut(arg, ":")
+	if !ok || name == "" {
+		return "", arg, false
+	}
+	if p == "" {
+		p = "/"
+	}
+	return name, p, true
+}
+
+func copyFromContainer(cmd *cobra.Command, c *client.Client, resolved *container.Details, srcPath, dstPath string) error {
+	reqPath := types.Endpoints.ContainerFilesDownload(c.EnvID(), resolved.ID) + "?path=" + url.QueryEscape(srcPath)
+	c.SetTimeout(30 * time.Minute)
+	resp, err := c.Get(cmd.Context(), reqPath)
+	if err != nil {
+		return fmt.Errorf("failed to copy from container: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if err := checkResponse(resp); err != nil {
+		return fmt.Errorf("failed to copy from container: %w", err)
+	}
+
+	if dstPath == "-" {
+		if _, err := io.Copy(os.Stdout, resp.Body); err != nil {
+			return fmt.Errorf("failed to write archive: %w", err)
+		}
+		return nil
+	}
+
+	target := dstPath
+	if info, err := os.Stat(dstPath); err == nil && info.IsDir() {
+		target = filepath.Join(dstPath, path.Base(path.Clean("/"+srcPath)))
+	}
+	count, err := extractArchive(resp.Body, target)
+	if err != nil {
+		return err
+	}
+
+	output.Success("Copied %d entries from %s:%s to %s", count, containerDisplayName(resolved), srcPath, target)
+	return nil
+}
+
+// extractArchive extracts a Docker archive of a single path to target, replacing the
+// top-level name of the archive with target. It returns the number of entries written.
+func extractArchive(r io.Reader, target string) (int, error) {
+	target = filepath.Clean(target)
+	tr := tar.NewReader(r)
+	count := 0
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return count, fmt.Errorf("failed to read archive: %w", err)
+		}
+
+		_, rel, _ := strings.Cut(strings.TrimPrefix(path.Clean(hdr.Name), "/"), "/")
+		dest := filepath.Join(target, filepath.FromSlash(rel))
+		if relToTarget, err := filepath.Rel(target, dest); err != nil || strings.HasPrefix(relToTarget, "..") {
+			return count, fmt.Errorf("archive entry %q escapes the destination", hdr.Name)
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(dest, hdr.FileInfo().Mode().Perm()|0o700); err != nil {
+				return count, err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+				return count, err
+			}
+			f, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, hdr.FileInfo().Mode().Perm())
+			if err != nil {
+				return count, err
+			}
+			if _, err := io.Copy(f, tr); err != nil {
+				_ = f.Close()
+				return count, fmt.Errorf("failed to write %s: %w", dest, err)
+			}
+			if err := f.Close(); err != nil {
+				return count, err
+			}
+		case tar.TypeSymlink:
+			if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+				return count, err
+			}
+			_ = os.Remove(dest)
+			if err := os.Symlink(hdr.Linkname, dest); err != nil {
+				return count, err
+			}
+		default:
+			continue
+		}
+		count++
+	}
+	return count, nil
+}
+
+func copyToContainer(cmd *cobra.Command, c *client.Client, resolved *container.Details, srcPath, dstPath string) error {
+	info, err := os.Stat(srcPath)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return fmt.Errorf("only single files can be copied into a container")
+	}
+
+	file, err := os.Open(srcPath)
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", filepath.Base(srcPath))
+	if err != nil {
+		return fmt.Errorf("failed to create form file: %w", err)
+	}
+	if _, err := io.Copy(part, file); err != nil {
+		return fmt.Errorf("failed to copy file: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to close writer: %w", err)
+	}
+
+	reqPath := types.Endpoints.ContainerFilesUpload(c.EnvID(), resolved.ID) + "?path=" + url.QueryEscape(dstPath)
+	headers := map[string]string{"Content-Type": writer.FormDataContentType()}
+	resp, err := c.RequestRaw(cmd.Context(), http.MethodPost, reqPath, body, headers)
+	if err != nil {
+		return fmt.Errorf("failed to copy to container: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if err := checkResponse(resp); err != nil {
+		return fmt.Errorf("failed to copy to container: %w", err)
+	}
+
+	output.Success("Copied %s to %s:%s", srcPath, containerDisplayName(resolved), path.Join(dstPath, filepath.Base(srcPath)))
+	return nil
+}
+
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	body, _ := io.ReadAll(resp.Body)
+	return fmt.Errorf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+}
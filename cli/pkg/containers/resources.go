@@ -0,0 +1,173 @@
+package containers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/getarcaneapp/arcane/cli/internal/client"
+	"github.com/getarcaneapp/arcane/cli/internal/output"
+	"github.com/getarcaneapp/arcane/cli/internal/types"
+	"github.com/getarcaneapp/arcane/types/base"
+	"github.com/getarcaneapp/arcane/types/container"
+	"github.com/spf13/cobra"
+)
+
+var (
+	resourcesMemory            string
+	resourcesMemoryReservation string
+	resourcesMemorySwap        string
+	resourcesCPUs              float64
+	resourcesCPUShares         int64
+	resourcesPidsLimit         int64
+	resourcesRestart           string
+)
+
+var containersResourcesCmd = &cobra.Command{
+	Use:   "resources <container-id|name>",
+	Short: "Change the resource limits of a running container",
+	Long: `Change the memory/CPU limits, pids limit or restart policy of a container without
+recreating it. Only the given flags are changed. Limits are checked against the
+capacity of the host.`,
+	Example: `  arcane containers resources web --memory 512m --cpus 0.5
+  arcane containers resources worker --pids-limit 200 --restart on-failure:5`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		req, err := resourcesRequestFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		c, err := client.NewFromConfig()
+		if err != nil {
+			return err
+		}
+
+		resolved, _, err := resolveContainer(cmd.Context(), c, args[0], false)
+		if err != nil {
+			return err
+		}
+
+		resp, err := c.Put(cmd.Context(), types.Endpoints.ContainerResources(c.EnvID(), resolved.ID), req)
+		if err != nil {
+			return fmt.Errorf("failed to update container resources: %w", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if err := checkResponse(resp); err != nil {
+			return fmt.Errorf("failed to update container resources: %w", err)
+		}
+
+		var result base.ApiResponse[container.UpdateResult]
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+
+		if jsonOutput {
+			resultBytes, err := json.MarshalIndent(result.Data, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(resultBytes))
+			return nil
+		}
+
+		for _, warning := range result.Data.Warnings {
+			output.Warning("%s", warning)
+		}
+		output.Success("Container %s resources updated successfully", containerDisplayName(resolved))
+		return nil
+	},
+}
+
+func init() {
+	ContainersCmd.AddCommand(containersResourcesCmd)
+
+	flags := containersResourcesCmd.Flags()
+	flags.StringVarP(&resourcesMemory, "memory", "m", "", "Memory limit (e.g. 512m, 2g)")
+	flags.StringVar(&resourcesMemoryReservation, "memory-reservation", "", "Soft memory limit (e.g. 256m)")
+	flags.StringVar(&resourcesMemorySwap, "memory-swap", "", "Memory plus swap limit (e.g. 1g), -1 for unlimited swap")
+	flags.Float64Var(&resourcesCPUs, "cpus", 0, "Number of CPUs (e.g. 1.5)")
+	flags.Int64Var(&resourcesCPUShares, "cpu-shares", 0, "Relative CPU weight")
+	flags.Int64Var(&resourcesPidsLimit, "pids-limit", 0, "Maximum number of processes, -1 for unlimited")
+	flags.StringVar(&resourcesRestart, "restart", "", "Restart policy (no, always, unless-stopped, on-failure[:max-retries])")
+	flags.BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+}
+
+// resourcesRequestFromFlags builds an update request from the flags that were set.
+func resourcesRequestFromFlags(cmd *cobra.Command) (container.UpdateResources, error) {
+	var req container.UpdateResources
+	flags := cmd.Flags()
+
+	memoryFlags := []struct {
+		name   string
+		value  string
+		target **int64
+	}{
+		{"memory", resourcesMemory, &req.Memory},
+		{"memory-reservation", resourcesMemoryReservation, &req.MemoryReservation},
+		{"memory-swap", resourcesMemorySwap, &req.MemorySwap},
+	}
+	for _, f := range memoryFlags {
+		if !flags.Changed(f.name) {
+			continue
+		}
+		bytes, err := parseMemorySize(f.value)
+		if err != nil {
+			return req, fmt.Errorf("invalid --%s: %w", f.name, err)
+		}
+		*f.target = &bytes
+	}
+
+	if flags.Changed("cpus") {
+		req.CPUs = &resourcesCPUs
+	}
+	if flags.Changed("cpu-shares") {
+		req.CPUShares = &resourcesCPUShares
+	}
+	if flags.Changed("pids-limit") {
+		req.PidsLimit = &resourcesPidsLimit
+	}
+	if flags.Changed("restart") {
+		name, retries, hasRetries := strings.Cut(resourcesRestart, ":")
+		policy := &container.RestartPolicyCreate{Name: name}
+		if hasRetries {
+			count, err := strconv.Atoi(retries)
+			if err != nil {
+				return req, fmt.Errorf("invalid --restart maximum retry count %q", retries)
+			}
+			policy.MaximumRetryCount = count
+		}
+		req.RestartPolicy = policy
+	}
+
+	if req == (container.UpdateResources{}) {
+		return req, fmt.Errorf("no changes given; set at least one of --memory, --memory-reservation, --memory-swap, --cpus, --cpu-shares, --pids-limit or --restart")
+	}
+	return req, nil
+}
+
+// parseMemorySize parses a byte count with an optional binary unit suffix
+// (b, k, m, g, t), as the Docker CLI does. "-1" is passed through.
+func parseMemorySize(value string) (int64, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "-1" {
+		return -1, nil
+	}
+
+	multiplier := int64(1)
+	units := map[byte]int64{'b': 1, 'k': 1 << 10, 'm': 1 << 20, 'g': 1 << 30, 't': 1 << 40}
+	if n := len(value); n > 0 {
+		if m, ok := units[value[n-1]]; ok {
+			multiplier = m
+			value = value[:n-1]
+		}
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || number < 0 {
+		return 0, fmt.Errorf("%q is not a valid size", value)
+	}
+	return int64(number * float64(multiplier)), nil
+}
@@ -0,0 +1,203 @@
+package containers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/getarcaneapp/arcane/cli/internal/client"
+	"github.com/getarcaneapp/arcane/cli/internal/output"
+	"github.com/getarcaneapp/arcane/cli/internal/types"
+	"github.com/getarcaneapp/arcane/types/base"
+	"github.com/getarcaneapp/arcane/types/container"
+	"github.com/spf13/cobra"
+)
+
+var (
+	sessionsLimit     int
+	sessionsContainer string
+	sessionsStatus    string
+	recordingOutput   string
+)
+
+var containersSessionsCmd = &cobra.Command{
+	Use:     "sessions",
+	Aliases: []string{"exec-sessions"},
+	Short:   "Manage recorded exec sessions",
+	Long: `Exec sessions opened from the container terminal are recorded in asciicast format.
+Recordings can be downloaded and replayed with asciinema.`,
+}
+
+var sessionsListCmd = &cobra.Command{
+	Use:          "list",
+	Aliases:      []string{"ls"},
+	Short:        "List exec sessions",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := client.NewFromConfig()
+		if err != nil {
+			return err
+		}
+
+		query := url.Values{}
+		query.Set("limit", strconv.Itoa(sessionsLimit))
+		if sessionsContainer != "" {
+			query.Set("containerId", sessionsContainer)
+		}
+		if sessionsStatus != "" {
+			query.Set("status", sessionsStatus)
+		}
+
+		resp, err := c.Get(cmd.Context(), types.Endpoints.ExecSessions(c.EnvID())+"?"+query.Encode())
+		if err != nil {
+			return fmt.Errorf("failed to list exec sessions: %w", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if err := checkResponse(resp); err != nil {
+			return fmt.Errorf("failed to list exec sessions: %w", err)
+		}
+
+		var result base.Paginated[container.ExecSession]
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+
+		if jsonOutput {
+			resultBytes, err := json.MarshalIndent(result, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(resultBytes))
+			return nil
+		}
+
+		headers := []string{"ID", "CONTAINER", "USER", "COMMAND", "STATUS", "STARTED", "DURATION", "EXIT"}
+		rows := make([][]string, len(result.Data))
+		for i, session := range result.Data {
+			exitCode := ""
+			if session.ExitCode != nil {
+				exitCode = strconv.Itoa(*session.ExitCode)
+			}
+			rows[i] = []string{
+				session.ID,
+				session.ContainerName,
+				session.Username,
+				session.Command,
+				session.Status,
+				session.StartedAt,
+				sessionDuration(session),
+				exitCode,
+			}
+		}
+
+		output.Table(headers, rows)
+		fmt.Printf("\nTotal: %d sessions\n", result.Pagination.TotalItems)
+		return nil
+	},
+}
+
+var sessionsRecordingCmd = &cobra.Command{
+	Use:   "recording <session-id>",
+	Short: "Download the recording of an exec session",
+	Long: `Download the asciicast recording of an exec session. Without --output the recording
+is written to stdout, e.g. to replay it with: asciinema play <(arcane containers sessions recording <id>)`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := client.NewFromConfig()
+		if err != nil {
+			return err
+		}
+
+		c.SetTimeout(30 * time.Minute)
+		resp, err := c.Get(cmd.Context(), types.Endpoints.ExecSessionRecording(c.EnvID(), args[0]))
+		if err != nil {
+			return fmt.Errorf("failed to download recording: %w", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if err := checkResponse(resp); err != nil {
+			return fmt.Errorf("failed to download recording: %w", err)
+		}
+
+		if recordingOutput == "" || recordingOutput == "-" {
+			if _, err := io.Copy(os.Stdout, resp.Body); err != nil {
+				return fmt.Errorf("failed to write recording: %w", err)
+			}
+			return nil
+		}
+
+		f, err := os.OpenFile(recordingOutput, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", recordingOutput, err)
+		}
+		if _, err := io.Copy(f, resp.Body); err != nil {
+			_ = f.Close()
+			return fmt.Errorf("failed to write recording: %w", err)
+		}
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("failed to write recording: %w", err)
+		}
+
+		output.Success("Recording of session %s saved to %s", args[0], recordingOutput)
+		return nil
+	},
+}
+
+var sessionsTerminateCmd = &cobra.Command{
+	Use:          "terminate <session-id>",
+	Aliases:      []string{"kill"},
+	Short:        "Terminate a running exec session",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := client.NewFromConfig()
+		if err != nil {
+			return err
+		}
+
+		resp, err := c.Delete(cmd.Context(), types.Endpoints.ExecSession(c.EnvID(), args[0]))
+		if err != nil {
+			return fmt.Errorf("failed to terminate exec session: %w", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if err := checkResponse(resp); err != nil {
+			return fmt.Errorf("failed to terminate exec session: %w", err)
+		}
+
+		output.Success("Exec session %s terminated", args[0])
+		return nil
+	},
+}
+
+// sessionDuration formats how long a session ran, or has been running.
+func sessionDuration(session container.ExecSession) string {
+	started, err := time.Parse(time.RFC3339, session.StartedAt)
+	if err != nil {
+		return ""
+	}
+	ended := time.Now()
+	if session.EndedAt != nil {
+		if t, err := time.Parse(time.RFC3339, *session.EndedAt); err == nil {
+			ended = t
+		}
+	}
+	return ended.Sub(started).Round(time.Second).String()
+}
+
+func init() {
+	ContainersCmd.AddCommand(containersSessionsCmd)
+	containersSessionsCmd.AddCommand(sessionsListCmd)
+	containersSessionsCmd.AddCommand(sessionsRecordingCmd)
+	containersSessionsCmd.AddCommand(sessionsTerminateCmd)
+
+	sessionsListCmd.Flags().IntVarP(&sessionsLimit, "limit", "n", 20, "Number of sessions to show")
+	sessionsListCmd.Flags().StringVarP(&sessionsContainer, "container", "c", "", "Only show sessions of this container ID or name")
+	sessionsListCmd.Flags().StringVar(&sessionsStatus, "status", "", "Only show sessions with this status (active, detached, ended)")
+	sessionsListCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+
+	sessionsRecordingCmd.Flags().StringVarP(&recordingOutput, "output", "o", "", "Write the recording to a file instead of stdout")
+}
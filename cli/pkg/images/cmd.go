@@ -13,6 +13,9 @@
 //   - prune: Remove unused images to reclaim disk space
 //   - counts: Display image usage statistics
 //   - upload: Upload a Docker image from a tar archive
+//   - tag: Add a new reference to an image
+//   - push: Push an image to a registry
+//   - save: Export images as a tar archive
 //   - updates: Check for image updates
 //
 // # Example Usage
@@ -28,6 +31,10 @@
 //
 //	# Remove unused images
 //	arcane images prune
+//
+//	# Push an image to a configured registry and export it for an air-gapped host
+//	arcane images push team/app:1.2 --registry <registry-id>
+//	arcane images save team/app:1.2 -o app.tar
 package images
 
 import (
@@ -0,0 +1,197 @@
+package images
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/getarcaneapp/arcane/cli/internal/client"
+	"github.com/getarcaneapp/arcane/cli/internal/output"
+	"github.com/getarcaneapp/arcane/cli/internal/types"
+	"github.com/getarcaneapp/arcane/types/base"
+	"github.com/getarcaneapp/arcane/types/image"
+	"github.com/spf13/cobra"
+	"go.withmatt.com/size"
+)
+
+var (
+	pushRegistryID string
+	saveOutputPath string
+)
+
+var imagesTagCmd = &cobra.Command{
+	Use:          "tag <SOURCE> <TARGET>",
+	Short:        "Add a new reference to an image",
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := client.NewFromConfig()
+		if err != nil {
+			return err
+		}
+
+		resp, err := c.Post(cmd.Context(), types.Endpoints.ImagesTag(c.EnvID()), image.TagOptions{Source: args[0], Target: args[1]})
+		if err != nil {
+			return fmt.Errorf("failed to tag image: %w", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if err := checkResponse(resp); err != nil {
+			return fmt.Errorf("failed to tag image: %w", err)
+		}
+
+		var result base.ApiResponse[base.MessageResponse]
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+
+		output.Success("%s", result.Data.Message)
+		return nil
+	},
+}
+
+var imagesPushCmd = &cobra.Command{
+	Use:          "push <IMAGE>",
+	Short:        "Push an image to a registry",
+	Long:         "Push an image to the registry in its reference, or with --registry to a configured container registry. The image is tagged for that registry first if needed.",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := client.NewFromConfig()
+		if err != nil {
+			return err
+		}
+
+		// Pushing large images can take a long time
+		c.SetTimeout(30 * time.Minute)
+
+		resp, err := c.Post(cmd.Context(), types.Endpoints.ImagesPush(c.EnvID()), image.PushOptions{ImageName: args[0], RegistryID: pushRegistryID})
+		if err != nil {
+			return fmt.Errorf("failed to push image: %w", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if err := checkResponse(resp); err != nil {
+			return fmt.Errorf("failed to push image: %w", err)
+		}
+
+		if jsonOutput, _ := cmd.Flags().GetBool("json"); jsonOutput {
+			if _, err := io.Copy(cmd.OutOrStdout(), resp.Body); err != nil {
+				return fmt.Errorf("failed to read push stream: %w", err)
+			}
+			return nil
+		}
+
+		output.Info("Pushing image: %s", args[0])
+
+		decoder := json.NewDecoder(resp.Body)
+		pushed := args[0]
+		for {
+			var event struct {
+				Status string `json:"status"`
+				Error  string `json:"error"`
+				ID     string `json:"id"`
+				Image  string `json:"image"`
+			}
+			if err := decoder.Decode(&event); err != nil {
+				if err == io.EOF {
+					break
+				}
+				return fmt.Errorf("failed to decode stream: %w", err)
+			}
+
+			switch {
+			case event.Error != "":
+				return fmt.Errorf("push error: %s", event.Error)
+			case event.Status == "complete":
+				pushed = event.Image
+			case event.Status == "Pushing":
+				// Per-chunk progress updates are too noisy to print.
+			case event.ID != "":
+				fmt.Printf("%s: %s\n", event.ID, event.Status)
+			case event.Status != "":
+				fmt.Println(event.Status)
+			}
+		}
+
+		output.Success("Image pushed: %s", pushed)
+		return nil
+	},
+}
+
+var imagesSaveCmd = &cobra.Command{
+	Use:          "save <IMAGE>...",
+	Short:        "Export images as a tar archive",
+	Long:         "Export one or more images as a tar archive, as docker save does. Load it on another host with 'arcane images upload' or 'docker load'.",
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if saveOutputPath == "" {
+			return fmt.Errorf("an output file is required (--output)")
+		}
+
+		c, err := client.NewFromConfig()
+		if err != nil {
+			return err
+		}
+
+		// Exporting large images can take a long time
+		c.SetTimeout(30 * time.Minute)
+
+		resp, err := c.Post(cmd.Context(), types.Endpoints.ImagesSave(c.EnvID()), image.SaveOptions{Images: args})
+		if err != nil {
+			return fmt.Errorf("failed to export images: %w", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if err := checkResponse(resp); err != nil {
+			return fmt.Errorf("failed to export images: %w", err)
+		}
+
+		var out io.Writer = cmd.OutOrStdout()
+		if saveOutputPath != "-" {
+			file, err := os.Create(saveOutputPath)
+			if err != nil {
+				return fmt.Errorf("failed to create output file: %w", err)
+			}
+			defer func() { _ = file.Close() }()
+			out = file
+		}
+
+		var reader io.Reader = resp.Body
+		if saveOutputPath != "-" {
+			progressUI := output.StartProgress("Downloading", resp.ContentLength)
+			reader = output.NewProgressReader(resp.Body, progressUI)
+			defer progressUI.Stop()
+		}
+
+		written, err := io.Copy(out, reader)
+		if err != nil {
+			return fmt.Errorf("failed to write archive: %w", err)
+		}
+
+		if saveOutputPath != "-" {
+			output.Success("Exported %d image(s) to %s (%s)", len(args), saveOutputPath, size.Capacity(written).String())
+		}
+		return nil
+	},
+}
+
+func init() {
+	ImagesCmd.AddCommand(imagesTagCmd)
+
+	ImagesCmd.AddCommand(imagesPushCmd)
+	imagesPushCmd.Flags().StringVar(&pushRegistryID, "registry", "", "ID of a configured container registry to push to")
+
+	ImagesCmd.AddCommand(imagesSaveCmd)
+	imagesSaveCmd.Flags().StringVarP(&saveOutputPath, "output", "o", "", "File to write the archive to ('-' for stdout)")
+}
+
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	body, _ := io.ReadAll(resp.Body)
+	return fmt.Errorf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+}
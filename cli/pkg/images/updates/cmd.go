@@ -186,6 +186,7 @@ var summaryCmd = &cobra.Command{
 		output.KeyValue("Total Images", fmt.Sprintf("%d", result.Data.TotalImages))
 		output.KeyValue("Images with Updates", fmt.Sprintf("%d", result.Data.ImagesWithUpdates))
 		output.KeyValue("Digest Updates", fmt.Sprintf("%d", result.Data.DigestUpdates))
+		output.KeyValue("Tag Updates", fmt.Sprintf("%d", result.Data.TagUpdates))
 		output.KeyValue("Errors", fmt.Sprintf("%d", result.Data.ErrorsCount))
 		return nil
 	},
@@ -0,0 +1,206 @@
+package networks
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/getarcaneapp/arcane/cli/internal/client"
+	"github.com/getarcaneapp/arcane/cli/internal/output"
+	"github.com/getarcaneapp/arcane/cli/internal/types"
+	"github.com/getarcaneapp/arcane/types/base"
+	"github.com/getarcaneapp/arcane/types/network"
+	"github.com/spf13/cobra"
+)
+
+var (
+	connectAliases []string
+	connectIPv4    string
+	connectIPv6    string
+)
+
+var connectCmd = &cobra.Command{
+	Use:          "connect <network-id|name> <container-id|name>",
+	Short:        "Connect a container to a network",
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := client.NewFromConfig()
+		if err != nil {
+			return err
+		}
+
+		resolvedID, resolvedName, err := resolveNetworkID(cmd.Context(), c, args[0], false)
+		if err != nil {
+			return err
+		}
+
+		req := network.ConnectRequest{
+			ContainerID: args[1],
+			Aliases:     connectAliases,
+			IPv4Address: connectIPv4,
+			IPv6Address: connectIPv6,
+		}
+		resp, err := c.Post(cmd.Context(), types.Endpoints.NetworkConnect(c.EnvID(), resolvedID), req)
+		if err != nil {
+			return fmt.Errorf("failed to connect container: %w", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if err := checkResponse(resp); err != nil {
+			return fmt.Errorf("failed to connect container: %w", err)
+		}
+
+		output.Success("Container %s connected to network %s", args[1], displayName(resolvedName, resolvedID))
+		return nil
+	},
+}
+
+var disconnectCmd = &cobra.Command{
+	Use:          "disconnect <network-id|name> <container-id|name>",
+	Short:        "Disconnect a container from a network",
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := client.NewFromConfig()
+		if err != nil {
+			return err
+		}
+
+		resolvedID, resolvedName, err := resolveNetworkID(cmd.Context(), c, args[0], false)
+		if err != nil {
+			return err
+		}
+
+		req := network.DisconnectRequest{ContainerID: args[1], Force: forceFlag}
+		resp, err := c.Post(cmd.Context(), types.Endpoints.NetworkDisconnect(c.EnvID(), resolvedID), req)
+		if err != nil {
+			return fmt.Errorf("failed to disconnect container: %w", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if err := checkResponse(resp); err != nil {
+			return fmt.Errorf("failed to disconnect container: %w", err)
+		}
+
+		output.Success("Container %s disconnected from network %s", args[1], displayName(resolvedName, resolvedID))
+		return nil
+	},
+}
+
+var topologyCmd = &cobra.Command{
+	Use:          "topology",
+	Aliases:      []string{"graph"},
+	Short:        "Show which containers and projects share which networks",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := client.NewFromConfig()
+		if err != nil {
+			return err
+		}
+
+		resp, err := c.Get(cmd.Context(), types.Endpoints.NetworksTopology(c.EnvID()))
+		if err != nil {
+			return fmt.Errorf("failed to get network topology: %w", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if err := checkResponse(resp); err != nil {
+			return fmt.Errorf("failed to get network topology: %w", err)
+		}
+
+		var result base.ApiResponse[network.Topology]
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+
+		if jsonOutput {
+			resultBytes, err := json.MarshalIndent(result.Data, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(resultBytes))
+			return nil
+		}
+
+		printTopology(result.Data)
+		return nil
+	},
+}
+
+func init() {
+	NetworksCmd.AddCommand(connectCmd)
+	NetworksCmd.AddCommand(disconnectCmd)
+	NetworksCmd.AddCommand(topologyCmd)
+
+	// Connect command flags
+	connectCmd.Flags().StringSliceVar(&connectAliases, "alias", nil, "Network-scoped alias for the container (repeatable)")
+	connectCmd.Flags().StringVar(&connectIPv4, "ip", "", "Static IPv4 address for the container")
+	connectCmd.Flags().StringVar(&connectIPv6, "ip6", "", "Static IPv6 address for the container")
+
+	// Disconnect command flags
+	disconnectCmd.Flags().BoolVarP(&forceFlag, "force", "f", false, "Force the container to disconnect")
+
+	// Topology command flags
+	topologyCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+}
+
+// printTopology prints each network with the containers attached to it and the
+// project each container belongs to.
+func printTopology(topology network.Topology) {
+	nodes := make(map[string]network.TopologyNode, len(topology.Nodes))
+	for _, node := range topology.Nodes {
+		nodes[node.ID] = node
+	}
+
+	projectOf := map[string]string{}
+	attached := map[string][]network.TopologyEdge{}
+	for _, edge := range topology.Edges {
+		switch edge.Type {
+		case network.TopologyEdgeMember:
+			projectOf[edge.Target] = nodes[edge.Source].Name
+		case network.TopologyEdgeAttached:
+			attached[edge.Target] = append(attached[edge.Target], edge)
+		}
+	}
+
+	output.Header("Network Topology")
+	for _, node := range topology.Nodes {
+		if node.Type != network.TopologyNodeNetwork {
+			continue
+		}
+		edges := attached[node.ID]
+		fmt.Printf("\n%s (%s, %d containers)\n", node.Name, node.Driver, len(edges))
+		for _, edge := range edges {
+			ctr := nodes[edge.Source]
+			line := fmt.Sprintf("  - %s [%s]", ctr.Name, ctr.State)
+			if project := projectOf[ctr.ID]; project != "" {
+				line += " project=" + project
+			}
+			if edge.IPv4Address != "" {
+				line += " ip=" + edge.IPv4Address
+			}
+			if edge.IPv6Address != "" {
+				line += " ip6=" + edge.IPv6Address
+			}
+			if len(edge.Aliases) > 0 {
+				line += " aliases=" + strings.Join(edge.Aliases, ",")
+			}
+			fmt.Println(line)
+		}
+	}
+}
+
+func displayName(name, id string) string {
+	if name != "" {
+		return name
+	}
+	return shortID(id)
+}
+
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	body, _ := io.ReadAll(resp.Body)
+	return fmt.Errorf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+}
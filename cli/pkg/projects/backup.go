@@ -0,0 +1,239 @@
+package projects
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/getarcaneapp/arcane/cli/internal/client"
+	"github.com/getarcaneapp/arcane/cli/internal/output"
+	"github.com/getarcaneapp/arcane/cli/internal/types"
+	"github.com/getarcaneapp/arcane/types/base"
+	"github.com/getarcaneapp/arcane/types/project"
+	"github.com/spf13/cobra"
+)
+
+var (
+	backupStopFlag         bool
+	backupTargetFlag       string
+	backupEncryptFlag      bool
+	backupPassphraseFlag   string
+	restoreFilesFlag       bool
+	restoreKeepStoppedFlag bool
+)
+
+// Backing up or restoring every volume of a project can take a long time.
+const backupTimeout = 2 * time.Hour
+
+var backupCmd = &cobra.Command{
+	Use:   "backup <project-id|name>",
+	Short: "Back up all volumes of a project",
+	Long: `Back up all named volumes of a project as one set, together with its compose and env files.
+
+Use --stop to bring the project down for the backup so all volumes are captured in a
+consistent state; it is deployed again afterwards.`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := client.NewFromConfig()
+		if err != nil {
+			return err
+		}
+
+		resolved, _, err := resolveProject(cmd.Context(), c, args[0], false)
+		if err != nil {
+			return err
+		}
+
+		c.SetTimeout(backupTimeout)
+		req := project.CreateBackup{
+			StopProject: backupStopFlag,
+			TargetID:    backupTargetFlag,
+			Encrypt:     backupEncryptFlag || backupPassphraseFlag != "",
+		}
+		resp, err := postWithPassphrase(cmd.Context(), c, types.Endpoints.ProjectBackups(c.EnvID(), resolved.ID), req)
+		if err != nil {
+			return fmt.Errorf("failed to back up project: %w", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+
+		var result base.ApiResponse[project.Backup]
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+
+		if jsonOutput {
+			resultBytes, err := json.MarshalIndent(result.Data, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(resultBytes))
+			return nil
+		}
+
+		output.Success("Project %s backed up successfully", resolved.Name)
+		output.KeyValue("Backup ID", result.Data.ID)
+		printBackupVolumes(result.Data.Volumes)
+		return nil
+	},
+}
+
+var backupsCmd = &cobra.Command{
+	Use:          "backups <project-id|name>",
+	Short:        "List backups of a project",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := client.NewFromConfig()
+		if err != nil {
+			return err
+		}
+
+		resolved, _, err := resolveProject(cmd.Context(), c, args[0], false)
+		if err != nil {
+			return err
+		}
+
+		resp, err := c.Get(cmd.Context(), types.Endpoints.ProjectBackups(c.EnvID(), resolved.ID))
+		if err != nil {
+			return fmt.Errorf("failed to list project backups: %w", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if err := checkResponse(resp); err != nil {
+			return fmt.Errorf("failed to list project backups: %w", err)
+		}
+
+		var result base.ApiResponse[[]project.Backup]
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+
+		if jsonOutput {
+			resultBytes, err := json.MarshalIndent(result.Data, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(resultBytes))
+			return nil
+		}
+
+		headers := []string{"ID", "VOLUMES", "SIZE", "STOPPED", "CREATED"}
+		rows := make([][]string, len(result.Data))
+		for i, b := range result.Data {
+			var size int64
+			for _, v := range b.Volumes {
+				size += v.Size
+			}
+			rows[i] = []string{
+				b.ID,
+				fmt.Sprintf("%d", len(b.Volumes)),
+				fmt.Sprintf("%d", size),
+				fmt.Sprintf("%t", b.StoppedProject),
+				b.CreatedAt,
+			}
+		}
+
+		output.Table(headers, rows)
+		fmt.Printf("\nTotal: %d backups\n", len(result.Data))
+		return nil
+	},
+}
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore <project-id|name> <backup-id>",
+	Short: "Restore all volumes of a project backup",
+	Long: `Restore all volumes of a project backup.
+
+The project is brought down for the restore and deployed again afterwards if it was
+running. Use --restore-files to also restore the compose and env files of the backup.`,
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := client.NewFromConfig()
+		if err != nil {
+			return err
+		}
+
+		resolved, _, err := resolveProject(cmd.Context(), c, args[0], false)
+		if err != nil {
+			return err
+		}
+
+		c.SetTimeout(backupTimeout)
+		req := project.RestoreBackup{
+			RestoreFiles: restoreFilesFlag,
+			KeepStopped:  restoreKeepStoppedFlag,
+		}
+		resp, err := postWithPassphrase(cmd.Context(), c, types.Endpoints.ProjectRestore(c.EnvID(), resolved.ID, args[1]), req)
+		if err != nil {
+			return fmt.Errorf("failed to restore project backup: %w", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+
+		output.Success("Backup %s restored to project %s", args[1], resolved.Name)
+		return nil
+	},
+}
+
+func init() {
+	ProjectsCmd.AddCommand(backupCmd)
+	ProjectsCmd.AddCommand(backupsCmd)
+	ProjectsCmd.AddCommand(restoreCmd)
+
+	backupCmd.Flags().BoolVar(&backupStopFlag, "stop", false, "Bring the project down during the backup for a consistent set")
+	backupCmd.Flags().StringVar(&backupTargetFlag, "target", "", "Backup target ID to store the archives on (default: local backup volume)")
+	backupCmd.Flags().BoolVar(&backupEncryptFlag, "encrypt", false, "Encrypt the archives (with --passphrase, or the instance key)")
+	backupCmd.Flags().StringVar(&backupPassphraseFlag, "passphrase", "", "Passphrase to encrypt the archives with")
+	backupCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+
+	backupsCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+
+	restoreCmd.Flags().BoolVar(&restoreFilesFlag, "restore-files", false, "Also restore the compose and env files of the backup")
+	restoreCmd.Flags().BoolVar(&restoreKeepStoppedFlag, "keep-stopped", false, "Leave the project down after the restore")
+	restoreCmd.Flags().StringVar(&backupPassphraseFlag, "passphrase", "", "Passphrase of passphrase-encrypted archives")
+}
+
+// postWithPassphrase posts body as JSON, passing --passphrase in the X-Backup-Passphrase
+// header, and fails on non-2xx responses.
+func postWithPassphrase(ctx context.Context, c *client.Client, path string, body any) (*http.Response, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+	headers := map[string]string{"Content-Type": "application/json"}
+	if backupPassphraseFlag != "" {
+		headers["X-Backup-Passphrase"] = backupPassphraseFlag
+	}
+
+	resp, err := c.RequestRaw(ctx, http.MethodPost, path, bytes.NewReader(payload), headers)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkResponse(resp); err != nil {
+		_ = resp.Body.Close()
+		return nil, err
+	}
+	return resp, nil
+}
+
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	body, _ := io.ReadAll(resp.Body)
+	return fmt.Errorf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+}
+
+func printBackupVolumes(volumes []project.BackupVolume) {
+	headers := []string{"VOLUME", "BACKUP ID", "SIZE"}
+	rows := make([][]string, len(volumes))
+	for i, v := range volumes {
+		rows[i] = []string{v.VolumeName, v.BackupID, fmt.Sprintf("%d", v.Size)}
+	}
+	output.Table(headers, rows)
+}
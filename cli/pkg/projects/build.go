@@ -0,0 +1,114 @@
+package projects
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/getarcaneapp/arcane/cli/internal/client"
+	"github.com/getarcaneapp/arcane/cli/internal/output"
+	"github.com/getarcaneapp/arcane/cli/internal/types"
+	"github.com/getarcaneapp/arcane/types/project"
+	"github.com/spf13/cobra"
+)
+
+var (
+	buildServicesFlag []string
+	buildNoCacheFlag  bool
+	buildPullFlag     bool
+)
+
+var buildCmd = &cobra.Command{
+	Use:          "build <project-id|name>",
+	Short:        "Build images for services with a build section",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := client.NewFromConfig()
+		if err != nil {
+			return err
+		}
+
+		resolved, _, err := resolveProject(cmd.Context(), c, args[0], false)
+		if err != nil {
+			return err
+		}
+
+		// Builds can take a long time
+		c.SetTimeout(60 * time.Minute)
+
+		req := project.BuildImages{Services: buildServicesFlag, NoCache: buildNoCacheFlag, Pull: buildPullFlag}
+		resp, err := c.Request(cmd.Context(), http.MethodPost, types.Endpoints.ProjectBuild(c.EnvID(), resolved.ID), req)
+		if err != nil {
+			return fmt.Errorf("failed to build images: %w", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if err := checkResponse(resp); err != nil {
+			return fmt.Errorf("failed to build images: %w", err)
+		}
+
+		if err := printBuildStream(resp.Body); err != nil {
+			return err
+		}
+
+		output.Success("Images built successfully for project %s", resolved.Name)
+		return nil
+	},
+}
+
+func init() {
+	ProjectsCmd.AddCommand(buildCmd)
+
+	buildCmd.Flags().StringSliceVarP(&buildServicesFlag, "service", "s", nil, "Only build these services (repeatable)")
+	buildCmd.Flags().BoolVar(&buildNoCacheFlag, "no-cache", false, "Do not use the build cache")
+	buildCmd.Flags().BoolVar(&buildPullFlag, "pull", false, "Always pull newer versions of base images")
+}
+
+// buildStreamLine is one JSON line of the build progress stream.
+type buildStreamLine struct {
+	Type    string `json:"type"`
+	Phase   string `json:"phase"`
+	Service string `json:"service"`
+	Image   string `json:"image"`
+	Stream  string `json:"stream"`
+	Error   string `json:"error"`
+}
+
+// printBuildStream prints the build output of the progress stream and returns the
+// first error reported in it.
+func printBuildStream(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var line buildStreamLine
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			continue
+		}
+		switch {
+		case line.Error != "" && line.Type == "build":
+			// The final error line repeats this one.
+			continue
+		case line.Error != "":
+			return fmt.Errorf("build failed: %s", line.Error)
+		case line.Type != "build":
+			continue
+		}
+
+		switch line.Phase {
+		case "begin":
+			output.Header("Building %s (%s)", line.Service, line.Image)
+		case "output":
+			fmt.Println(strings.TrimRight(line.Stream, "\n"))
+		case "complete":
+			output.Success("Built %s", line.Image)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read build output: %w", err)
+	}
+	return nil
+}
@@ -200,6 +200,29 @@ var upCmd = &cobra.Command{
 			return err
 		}
 
+		if planBeforeUpFlag {
+			plan, err := fetchPlan(cmd.Context(), c, resolved.ID)
+			if err != nil {
+				return err
+			}
+			printPlan(plan)
+			if !plan.Valid {
+				return fmt.Errorf("project %s has errors and would fail to deploy", resolved.Name)
+			}
+			if !forceFlag {
+				fmt.Printf("\nStart project %s with this plan? (y/N): ", resolved.Name)
+				var response string
+				if _, err := fmt.Scanln(&response); err != nil {
+					fmt.Println("Cancelled")
+					return nil
+				}
+				if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
+					fmt.Println("Cancelled")
+					return nil
+				}
+			}
+		}
+
 		resp, err := c.Post(cmd.Context(), types.Endpoints.ProjectUp(c.EnvID(), resolved.ID), nil)
 		if err != nil {
 			return fmt.Errorf("failed to start project: %w", err)
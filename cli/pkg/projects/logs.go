@@ -0,0 +1,210 @@
+package projects
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/getarcaneapp/arcane/cli/internal/client"
+	"github.com/getarcaneapp/arcane/cli/internal/output"
+	"github.com/getarcaneapp/arcane/cli/internal/types"
+	"github.com/getarcaneapp/arcane/types/base"
+	"github.com/getarcaneapp/arcane/types/project"
+	"github.com/spf13/cobra"
+)
+
+var (
+	logsSince      string
+	logsUntil      string
+	logsIgnoreCase bool
+	logsStreams    []string
+	logsServices   []string
+	logsLevels     []string
+	logsLimit      int
+	logsFormat     string
+	logsOutput     string
+)
+
+var logsCmd = &cobra.Command{
+	Use:   "logs",
+	Short: "Search and export project logs",
+}
+
+var logsSearchCmd = &cobra.Command{
+	Use:   "search <project-id|name> [regex]",
+	Short: "Search the logs of all containers of a project",
+	Example: `  arcane projects logs search shop 'timeout|refused' --since 2h --level error,warn
+  arcane projects logs search shop --service api --stream stderr`,
+	Args:         cobra.RangeArgs(1, 2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := client.NewFromConfig()
+		if err != nil {
+			return err
+		}
+
+		resolved, _, err := resolveProject(cmd.Context(), c, args[0], false)
+		if err != nil {
+			return err
+		}
+
+		query := logsQuery()
+		if len(args) == 2 {
+			query.Set("regex", args[1])
+		}
+		query.Set("limit", strconv.Itoa(logsLimit))
+
+		c.SetTimeout(10 * time.Minute)
+		resp, err := c.Get(cmd.Context(), types.Endpoints.ProjectLogsSearch(c.EnvID(), resolved.ID)+"?"+query.Encode())
+		if err != nil {
+			return fmt.Errorf("failed to search project logs: %w", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if err := checkResponse(resp); err != nil {
+			return fmt.Errorf("failed to search project logs: %w", err)
+		}
+
+		var result base.ApiResponse[project.LogSearchResult]
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+
+		if jsonOutput {
+			resultBytes, err := json.MarshalIndent(result.Data, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(resultBytes))
+			return nil
+		}
+
+		for _, entry := range result.Data.Entries {
+			source := entry.Service
+			if source == "" {
+				source = entry.ContainerName
+			}
+			if entry.Stream == "stderr" {
+				source += " (stderr)"
+			}
+			fmt.Printf("%s %s | %s\n", entry.Timestamp, source, entry.Message)
+		}
+		if result.Data.Truncated {
+			output.Warning("More than %d lines matched; showing the most recent. Narrow the search or raise --limit.", logsLimit)
+		}
+		fmt.Fprintf(os.Stderr, "\n%d lines from %d containers\n", len(result.Data.Entries), result.Data.Containers)
+		return nil
+	},
+}
+
+var logsExportCmd = &cobra.Command{
+	Use:   "export <project-id|name> [regex]",
+	Short: "Download the logs of all containers of a project",
+	Long: `Download the logs of all containers of a project as a zip archive with a log file per
+container, or as newline-delimited JSON. The search filters apply to the export as well.`,
+	Example:      `  arcane projects logs export shop --since 2024-05-01T00:00:00Z --until 2024-05-02T00:00:00Z -o shop-logs.zip`,
+	Args:         cobra.RangeArgs(1, 2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := client.NewFromConfig()
+		if err != nil {
+			return err
+		}
+
+		resolved, _, err := resolveProject(cmd.Context(), c, args[0], false)
+		if err != nil {
+			return err
+		}
+
+		query := logsQuery()
+		if len(args) == 2 {
+			query.Set("regex", args[1])
+		}
+		query.Set("format", logsFormat)
+
+		c.SetTimeout(30 * time.Minute)
+		resp, err := c.Get(cmd.Context(), types.Endpoints.ProjectLogsExport(c.EnvID(), resolved.ID)+"?"+query.Encode())
+		if err != nil {
+			return fmt.Errorf("failed to export project logs: %w", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if err := checkResponse(resp); err != nil {
+			return fmt.Errorf("failed to export project logs: %w", err)
+		}
+
+		target := logsOutput
+		if target == "" {
+			target = fmt.Sprintf("%s-logs.%s", resolved.Name, logsFormat)
+		}
+		if target == "-" {
+			if _, err := io.Copy(os.Stdout, resp.Body); err != nil {
+				return fmt.Errorf("failed to write logs: %w", err)
+			}
+			return nil
+		}
+
+		f, err := os.Create(target)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", target, err)
+		}
+		if _, err := io.Copy(f, resp.Body); err != nil {
+			_ = f.Close()
+			return fmt.Errorf("failed to write logs: %w", err)
+		}
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("failed to write logs: %w", err)
+		}
+
+		output.Success("Logs of project %s saved to %s", resolved.Name, target)
+		return nil
+	},
+}
+
+// logsQuery builds the filter query parameters shared by search and export.
+func logsQuery() url.Values {
+	query := url.Values{}
+	if logsSince != "" {
+		query.Set("since", logsSince)
+	}
+	if logsUntil != "" {
+		query.Set("until", logsUntil)
+	}
+	if logsIgnoreCase {
+		query.Set("ignoreCase", "true")
+	}
+	if len(logsStreams) > 0 {
+		query.Set("stream", strings.Join(logsStreams, ","))
+	}
+	if len(logsServices) > 0 {
+		query.Set("service", strings.Join(logsServices, ","))
+	}
+	if len(logsLevels) > 0 {
+		query.Set("level", strings.Join(logsLevels, ","))
+	}
+	return query
+}
+
+func init() {
+	ProjectsCmd.AddCommand(logsCmd)
+	logsCmd.AddCommand(logsSearchCmd)
+	logsCmd.AddCommand(logsExportCmd)
+
+	for _, cmd := range []*cobra.Command{logsSearchCmd, logsExportCmd} {
+		cmd.Flags().StringVar(&logsSince, "since", "", "Only lines at or after this time (RFC 3339, Unix timestamp or duration such as 2h)")
+		cmd.Flags().StringVar(&logsUntil, "until", "", "Only lines before this time (same formats as --since)")
+		cmd.Flags().BoolVarP(&logsIgnoreCase, "ignore-case", "i", false, "Match the regex case-insensitively")
+		cmd.Flags().StringSliceVar(&logsStreams, "stream", nil, "Streams to include: stdout, stderr")
+		cmd.Flags().StringSliceVarP(&logsServices, "service", "s", nil, "Compose services to include")
+		cmd.Flags().StringSliceVarP(&logsLevels, "level", "l", nil, "Log levels to include: trace, debug, info, warn, error, fatal")
+	}
+
+	logsSearchCmd.Flags().IntVarP(&logsLimit, "limit", "n", 1000, "Maximum number of lines to show")
+	logsSearchCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+
+	logsExportCmd.Flags().StringVarP(&logsFormat, "format", "f", "zip", "Export format: zip or ndjson")
+	logsExportCmd.Flags().StringVarP(&logsOutput, "output", "o", "", "File to write, or - for stdout (default <project>-logs.<format>)")
+}
@@ -0,0 +1,133 @@
+package projects
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/getarcaneapp/arcane/cli/internal/client"
+	"github.com/getarcaneapp/arcane/cli/internal/output"
+	"github.com/getarcaneapp/arcane/cli/internal/types"
+	"github.com/getarcaneapp/arcane/types/base"
+	"github.com/getarcaneapp/arcane/types/project"
+	"github.com/spf13/cobra"
+)
+
+var planBeforeUpFlag bool
+
+var planCmd = &cobra.Command{
+	Use:          "plan <project-id|name>",
+	Aliases:      []string{"validate"},
+	Short:        "Validate a project and show what deploying it would change",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := client.NewFromConfig()
+		if err != nil {
+			return err
+		}
+
+		resolved, _, err := resolveProject(cmd.Context(), c, args[0], false)
+		if err != nil {
+			return err
+		}
+
+		plan, err := fetchPlan(cmd.Context(), c, resolved.ID)
+		if err != nil {
+			return err
+		}
+
+		if jsonOutput {
+			resultBytes, err := json.MarshalIndent(plan, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(resultBytes))
+			return nil
+		}
+
+		printPlan(plan)
+		if !plan.Valid {
+			return fmt.Errorf("project %s has errors and would fail to deploy", resolved.Name)
+		}
+		return nil
+	},
+}
+
+func init() {
+	ProjectsCmd.AddCommand(planCmd)
+
+	planCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	upCmd.Flags().BoolVar(&planBeforeUpFlag, "plan", false, "Show the deployment plan and ask for confirmation before starting")
+	upCmd.Flags().BoolVarP(&forceFlag, "force", "f", false, "Start without confirmation after showing the plan")
+}
+
+func fetchPlan(ctx context.Context, c *client.Client, projectID string) (*project.Plan, error) {
+	resp, err := c.Get(ctx, types.Endpoints.ProjectPlan(c.EnvID(), projectID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to plan project: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if err := checkResponse(resp); err != nil {
+		return nil, fmt.Errorf("failed to plan project: %w", err)
+	}
+
+	var result base.ApiResponse[project.Plan]
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return &result.Data, nil
+}
+
+func printPlan(plan *project.Plan) {
+	output.Header("Plan for %s", plan.ProjectName)
+
+	if len(plan.Services) > 0 {
+		rows := make([][]string, len(plan.Services))
+		for i, svc := range plan.Services {
+			rows[i] = []string{planActionSymbol(svc.Action) + " " + svc.Name, svc.Action, svc.Image, svc.Reason}
+		}
+		output.Table([]string{"SERVICE", "ACTION", "IMAGE", "REASON"}, rows)
+	}
+
+	counts := map[string]int{}
+	for _, svc := range plan.Services {
+		counts[svc.Action]++
+	}
+	fmt.Printf("\nPlan: %d to create, %d to recreate, %d to start, %d unchanged, %d to remove\n",
+		counts["create"], counts["recreate"], counts["start"], counts["unchanged"], counts["remove"])
+
+	if len(plan.Issues) == 0 {
+		output.Success("No issues found")
+		return
+	}
+	fmt.Println()
+	for _, issue := range plan.Issues {
+		msg := issue.Message
+		if issue.Service != "" {
+			msg = fmt.Sprintf("%s: %s", issue.Service, msg)
+		}
+		if issue.Severity == "error" {
+			output.Error("[%s] %s", issue.Kind, msg)
+		} else {
+			output.Warning("[%s] %s", issue.Kind, msg)
+		}
+	}
+}
+
+func planActionSymbol(action string) string {
+	switch action {
+	case "create":
+		return "+"
+	case "recreate":
+		return "~"
+	case "start":
+		return ">"
+	case "remove":
+		return "-"
+	case "orphaned":
+		return "?"
+	default:
+		return " "
+	}
+}
@@ -0,0 +1,262 @@
+package projects
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/getarcaneapp/arcane/cli/internal/client"
+	"github.com/getarcaneapp/arcane/cli/internal/output"
+	"github.com/getarcaneapp/arcane/cli/internal/types"
+	"github.com/getarcaneapp/arcane/types/base"
+	"github.com/getarcaneapp/arcane/types/project"
+	"github.com/spf13/cobra"
+)
+
+var revisionsCmd = &cobra.Command{
+	Use:     "revisions",
+	Aliases: []string{"revision", "rev"},
+	Short:   "Show and roll back the file history of a project",
+}
+
+var revisionsListCmd = &cobra.Command{
+	Use:          "list <project-id|name>",
+	Aliases:      []string{"ls"},
+	Short:        "List revisions of a project's compose, env and include files",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := client.NewFromConfig()
+		if err != nil {
+			return err
+		}
+
+		resolved, _, err := resolveProject(cmd.Context(), c, args[0], false)
+		if err != nil {
+			return err
+		}
+
+		path := types.Endpoints.ProjectRevisions(c.EnvID(), resolved.ID)
+		if limitFlag > 0 {
+			path = fmt.Sprintf("%s?limit=%d", path, limitFlag)
+		}
+		resp, err := c.Get(cmd.Context(), path)
+		if err != nil {
+			return fmt.Errorf("failed to list revisions: %w", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if err := checkResponse(resp); err != nil {
+			return fmt.Errorf("failed to list revisions: %w", err)
+		}
+
+		var result base.Paginated[project.RevisionSummary]
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+
+		if jsonOutput {
+			resultBytes, err := json.MarshalIndent(result, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(resultBytes))
+			return nil
+		}
+
+		headers := []string{"REVISION", "SOURCE", "AUTHOR", "CHANGES", "CREATED", "MESSAGE"}
+		rows := make([][]string, len(result.Data))
+		for i, rev := range result.Data {
+			rows[i] = []string{
+				strconv.Itoa(rev.Revision),
+				rev.Source,
+				rev.AuthorName,
+				fmt.Sprintf("+%d -%d", rev.LinesAdded, rev.LinesRemoved),
+				rev.CreatedAt,
+				rev.Message,
+			}
+		}
+
+		output.Table(headers, rows)
+		fmt.Printf("\nTotal: %d revisions\n", result.Pagination.TotalItems)
+		return nil
+	},
+}
+
+var revisionsShowCmd = &cobra.Command{
+	Use:          "show <project-id|name> <revision>",
+	Short:        "Show a revision with its diff to the previous one",
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		revision, err := parseRevision(args[1])
+		if err != nil {
+			return err
+		}
+
+		c, err := client.NewFromConfig()
+		if err != nil {
+			return err
+		}
+
+		resolved, _, err := resolveProject(cmd.Context(), c, args[0], false)
+		if err != nil {
+			return err
+		}
+
+		resp, err := c.Get(cmd.Context(), types.Endpoints.ProjectRevision(c.EnvID(), resolved.ID, revision))
+		if err != nil {
+			return fmt.Errorf("failed to get revision: %w", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if err := checkResponse(resp); err != nil {
+			return fmt.Errorf("failed to get revision: %w", err)
+		}
+
+		var result base.ApiResponse[project.Revision]
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+
+		if jsonOutput {
+			resultBytes, err := json.MarshalIndent(result.Data, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(resultBytes))
+			return nil
+		}
+
+		rev := result.Data
+		output.Header("Revision %d", rev.Revision)
+		output.KeyValue("Source", rev.Source)
+		output.KeyValue("Author", rev.AuthorName)
+		output.KeyValue("Created", rev.CreatedAt)
+		if rev.Message != "" {
+			output.KeyValue("Message", rev.Message)
+		}
+		if rev.Diff != "" {
+			fmt.Println()
+			fmt.Print(rev.Diff)
+		}
+		return nil
+	},
+}
+
+var revisionsDiffCmd = &cobra.Command{
+	Use:          "diff <project-id|name> <from> <to>",
+	Short:        "Show the diff between two revisions",
+	Args:         cobra.ExactArgs(3),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		from, err := parseRevision(args[1])
+		if err != nil {
+			return err
+		}
+		to, err := parseRevision(args[2])
+		if err != nil {
+			return err
+		}
+
+		c, err := client.NewFromConfig()
+		if err != nil {
+			return err
+		}
+
+		resolved, _, err := resolveProject(cmd.Context(), c, args[0], false)
+		if err != nil {
+			return err
+		}
+
+		resp, err := c.Get(cmd.Context(), types.Endpoints.ProjectRevisionDiff(c.EnvID(), resolved.ID, from, to))
+		if err != nil {
+			return fmt.Errorf("failed to diff revisions: %w", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if err := checkResponse(resp); err != nil {
+			return fmt.Errorf("failed to diff revisions: %w", err)
+		}
+
+		var result base.ApiResponse[project.RevisionDiff]
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+
+		if jsonOutput {
+			resultBytes, err := json.MarshalIndent(result.Data, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(resultBytes))
+			return nil
+		}
+
+		if result.Data.Diff == "" {
+			output.Info("Revisions %d and %d are identical", from, to)
+			return nil
+		}
+		fmt.Print(result.Data.Diff)
+		return nil
+	},
+}
+
+var revisionsRedeployCmd = &cobra.Command{
+	Use:          "redeploy <project-id|name> <revision>",
+	Aliases:      []string{"rollback"},
+	Short:        "Restore the files of a revision and redeploy the project",
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		revision, err := parseRevision(args[1])
+		if err != nil {
+			return err
+		}
+
+		c, err := client.NewFromConfig()
+		if err != nil {
+			return err
+		}
+
+		resolved, _, err := resolveProject(cmd.Context(), c, args[0], false)
+		if err != nil {
+			return err
+		}
+
+		// Redeploying pulls images and recreates containers
+		c.SetTimeout(30 * time.Minute)
+
+		resp, err := c.Request(cmd.Context(), http.MethodPost, types.Endpoints.ProjectRevisionRedeploy(c.EnvID(), resolved.ID, revision), nil)
+		if err != nil {
+			return fmt.Errorf("failed to redeploy revision: %w", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if err := checkResponse(resp); err != nil {
+			return fmt.Errorf("failed to redeploy revision: %w", err)
+		}
+
+		output.Success("Project %s redeployed from revision %d", resolved.Name, revision)
+		return nil
+	},
+}
+
+func init() {
+	ProjectsCmd.AddCommand(revisionsCmd)
+	revisionsCmd.AddCommand(revisionsListCmd)
+	revisionsCmd.AddCommand(revisionsShowCmd)
+	revisionsCmd.AddCommand(revisionsDiffCmd)
+	revisionsCmd.AddCommand(revisionsRedeployCmd)
+
+	revisionsListCmd.Flags().IntVarP(&limitFlag, "limit", "n", 20, "Number of revisions to show")
+	revisionsListCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	revisionsShowCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	revisionsDiffCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+}
+
+func parseRevision(arg string) (int, error) {
+	revision, err := strconv.Atoi(arg)
+	if err != nil || revision < 1 {
+		return 0, fmt.Errorf("invalid revision %q: must be a positive number", arg)
+	}
+	return revision, nil
+}
@@ -62,12 +62,28 @@ async function buildTemplateFile(Component: any, templateName: string, isPlainTe
       /IMAGELIST_PLACEHOLDER/g,
       '{{range .ImageList}}• {{.}}\n{{end}}'
     );
+    normalized = normalized.replace(
+      /FAILURELIST_PLACEHOLDER/g,
+      '{{range .Failures}}• {{.VolumeName}}: {{.Error}}\n{{end}}'
+    );
+    normalized = normalized.replace(
+      /DRIFTLIST_PLACEHOLDER/g,
+      '{{range .Reports}}{{.ProjectName}} ({{.SyncName}}){{if .Healed}}: redeployed from git{{end}}\n{{range .Items}}• {{.Message}}\n{{end}}\n{{end}}'
+    );
   } else {
     // For HTML, wrap each item in a paragraph tag with proper styling
     normalized = normalized.replace(
       /<p[^>]*>IMAGELIST_PLACEHOLDER<\/p>/g,
       '{{range .ImageList}}<p style="font-size:13px;line-height:20px;color:#cbd5e1;margin:4px 0;font-family:monospace">• {{.}}</p>{{end}}'
     );
+    normalized = normalized.replace(
+      /<p[^>]*>FAILURELIST_PLACEHOLDER<\/p>/g,
+      '{{range .Failures}}<p style="font-size:14px;line-height:24px;color:#e2e8f0;margin:8px 0;word-break:break-word;font-family:monospace">• {{.VolumeName}}: {{.Error}}</p>{{end}}'
+    );
+    normalized = normalized.replace(
+      /<p[^>]*>DRIFTLIST_PLACEHOLDER<\/p>/g,
+      '{{range .Reports}}<p style="font-size:14px;line-height:24px;color:#f1f5f9;margin:16px 0 4px 0;font-weight:600;word-break:break-word">{{.ProjectName}} ({{.SyncName}}){{if .Healed}}: redeployed from git{{end}}</p>{{range .Items}}<p style="font-size:13px;line-height:20px;color:#e2e8f0;margin:4px 0;word-break:break-word;font-family:monospace">• {{.Message}}</p>{{end}}{{end}}'
+    );
   }
 
   // Enforce line length: prefer tag boundaries, never spaces
@@ -0,0 +1,95 @@
+import { Section, Text } from '@react-email/components';
+import { BaseTemplate } from '../components/base-template';
+import CardHeader from '../components/card-header';
+import { sharedPreviewProps, sharedTemplateProps } from '../props';
+
+interface GitOpsDriftEmailProps {
+  logoURL: string;
+  appURL: string;
+  driftedCount: string;
+  time: string;
+}
+
+export const GitOpsDriftEmail = ({ logoURL, appURL, driftedCount, time }: GitOpsDriftEmailProps) => {
+  return (
+    <BaseTemplate logoURL={logoURL} appURL={appURL}>
+      <CardHeader title="GitOps Drift Detected" />
+
+      <Section style={totalSectionStyle}>
+        <Text style={totalLabelStyle}>Drifted Projects</Text>
+        <Text style={totalValueStyle}>{driftedCount}</Text>
+      </Section>
+
+      <Section style={driftSectionStyle}>
+        <Text style={driftStyle}>DRIFTLIST_PLACEHOLDER</Text>
+      </Section>
+
+      <Section style={{ marginTop: '20px' }}>
+        <Text style={footerStyle}>Generated by Arcane at {time}</Text>
+      </Section>
+    </BaseTemplate>
+  );
+};
+
+export default GitOpsDriftEmail;
+
+const totalSectionStyle = {
+  marginTop: '20px',
+  textAlign: 'center' as const,
+  backgroundColor: 'rgba(15, 23, 42, 0.5)',
+  border: '1px solid rgba(148, 163, 184, 0.1)',
+  padding: '20px',
+  borderRadius: '12px',
+};
+
+const totalLabelStyle = {
+  fontSize: '12px',
+  lineHeight: '18px',
+  letterSpacing: '0.08em',
+  textTransform: 'uppercase' as const,
+  color: '#94a3b8',
+  margin: '0',
+};
+
+const totalValueStyle = {
+  fontSize: '30px',
+  lineHeight: '36px',
+  fontWeight: '700' as const,
+  color: '#fbbf24',
+  margin: '8px 0 0 0',
+};
+
+const driftSectionStyle = {
+  marginTop: '18px',
+  backgroundColor: 'rgba(15, 23, 42, 0.5)',
+  border: '1px solid rgba(148, 163, 184, 0.1)',
+  padding: '20px',
+  borderRadius: '12px',
+};
+
+const driftStyle = {
+  fontSize: '14px',
+  color: '#e2e8f0',
+  margin: '8px 0',
+  wordBreak: 'break-word' as const,
+  fontFamily: 'monospace',
+};
+
+const footerStyle = {
+  fontSize: '12px',
+  lineHeight: '18px',
+  color: '#94a3b8',
+  margin: '0',
+};
+
+GitOpsDriftEmail.TemplateProps = {
+  ...sharedTemplateProps,
+  driftedCount: '{{.DriftedCount}}',
+  time: '{{.Time}}',
+};
+
+GitOpsDriftEmail.PreviewProps = {
+  ...sharedPreviewProps,
+  driftedCount: '1',
+  time: 'Tue, 10 Feb 2026 02:00:00 UTC',
+};
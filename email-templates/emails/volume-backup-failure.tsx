@@ -0,0 +1,95 @@
+import { Section, Text } from '@react-email/components';
+import { BaseTemplate } from '../components/base-template';
+import CardHeader from '../components/card-header';
+import { sharedPreviewProps, sharedTemplateProps } from '../props';
+
+interface VolumeBackupFailureEmailProps {
+  logoURL: string;
+  appURL: string;
+  failedCount: string;
+  time: string;
+}
+
+export const VolumeBackupFailureEmail = ({ logoURL, appURL, failedCount, time }: VolumeBackupFailureEmailProps) => {
+  return (
+    <BaseTemplate logoURL={logoURL} appURL={appURL}>
+      <CardHeader title="Scheduled Backup Failed" />
+
+      <Section style={totalSectionStyle}>
+        <Text style={totalLabelStyle}>Failed Volumes</Text>
+        <Text style={totalValueStyle}>{failedCount}</Text>
+      </Section>
+
+      <Section style={failureSectionStyle}>
+        <Text style={failureStyle}>FAILURELIST_PLACEHOLDER</Text>
+      </Section>
+
+      <Section style={{ marginTop: '20px' }}>
+        <Text style={footerStyle}>Generated by Arcane at {time}</Text>
+      </Section>
+    </BaseTemplate>
+  );
+};
+
+export default VolumeBackupFailureEmail;
+
+const totalSectionStyle = {
+  marginTop: '20px',
+  textAlign: 'center' as const,
+  backgroundColor: 'rgba(15, 23, 42, 0.5)',
+  border: '1px solid rgba(148, 163, 184, 0.1)',
+  padding: '20px',
+  borderRadius: '12px',
+};
+
+const totalLabelStyle = {
+  fontSize: '12px',
+  lineHeight: '18px',
+  letterSpacing: '0.08em',
+  textTransform: 'uppercase' as const,
+  color: '#94a3b8',
+  margin: '0',
+};
+
+const totalValueStyle = {
+  fontSize: '30px',
+  lineHeight: '36px',
+  fontWeight: '700' as const,
+  color: '#f87171',
+  margin: '8px 0 0 0',
+};
+
+const failureSectionStyle = {
+  marginTop: '18px',
+  backgroundColor: 'rgba(15, 23, 42, 0.5)',
+  border: '1px solid rgba(148, 163, 184, 0.1)',
+  padding: '20px',
+  borderRadius: '12px',
+};
+
+const failureStyle = {
+  fontSize: '14px',
+  color: '#e2e8f0',
+  margin: '8px 0',
+  wordBreak: 'break-word' as const,
+  fontFamily: 'monospace',
+};
+
+const footerStyle = {
+  fontSize: '12px',
+  lineHeight: '18px',
+  color: '#94a3b8',
+  margin: '0',
+};
+
+VolumeBackupFailureEmail.TemplateProps = {
+  ...sharedTemplateProps,
+  failedCount: '{{.FailedCount}}',
+  time: '{{.Time}}',
+};
+
+VolumeBackupFailureEmail.PreviewProps = {
+  ...sharedPreviewProps,
+  failedCount: '2',
+  time: 'Tue, 10 Feb 2026 02:00:00 UTC',
+};
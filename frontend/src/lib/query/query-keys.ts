@@ -45,6 +45,7 @@ export const queryKeys = {
 		list: (options: SearchPaginationSortRequest) => ['git-repositories', stableSerialize(options)] as const,
 		syncDialog: () => ['git-repositories', 'sync-dialog'] as const,
 		branches: (repositoryId: string) => ['git-repositories', 'branches', repositoryId] as const,
+		tags: (repositoryId: string) => ['git-repositories', 'tags', repositoryId] as const,
 		files: (repositoryId: string, branch: string, path: string) => ['git-repository-files', repositoryId, branch, path] as const
 	},
 	containerRegistries: {
@@ -107,7 +108,10 @@ export const queryKeys = {
 	gitOpsSyncs: {
 		all: ['gitops-syncs'] as const,
 		list: (environmentId: string, options: SearchPaginationSortRequest) =>
-			['gitops-syncs', environmentId, stableSerialize(options)] as const
+			['gitops-syncs', environmentId, stableSerialize(options)] as const,
+		commits: (environmentId: string, syncId: string) => ['gitops-syncs', environmentId, syncId, 'commits'] as const,
+		runs: (environmentId: string, syncId: string, options: SearchPaginationSortRequest) =>
+			['gitops-syncs', environmentId, syncId, 'runs', stableSerialize(options)] as const
 	},
 	volumes: {
 		table: (environmentId: string, options: SearchPaginationSortRequest) =>
@@ -0,0 +1,59 @@
+import BaseAPIService from './api-service';
+import { environmentStore } from '$lib/stores/environment.store.svelte';
+import type { FileEntry, FileContentResponse } from '$lib/types/file-browser.type';
+import type { ContainerFileChange } from '$lib/types/container.type';
+
+export class ContainerBrowserService extends BaseAPIService {
+	async listDirectory(containerId: string, path: string = '/'): Promise<FileEntry[]> {
+		const envId = await environmentStore.getCurrentEnvironmentId();
+		const res = await this.api.get(`/environments/${envId}/containers/${containerId}/files`, {
+			params: { path }
+		});
+		return res.data.data;
+	}
+
+	async getFileContent(containerId: string, path: string): Promise<FileContentResponse> {
+		const envId = await environmentStore.getCurrentEnvironmentId();
+		const res = await this.api.get(`/environments/${envId}/containers/${containerId}/files/content`, {
+			params: { path }
+		});
+		return res.data.data;
+	}
+
+	async downloadPath(containerId: string, path: string): Promise<void> {
+		const envId = await environmentStore.getCurrentEnvironmentId();
+		const res = await this.api.get(`/environments/${envId}/containers/${containerId}/files/download`, {
+			params: { path },
+			responseType: 'blob'
+		});
+
+		const url = window.URL.createObjectURL(new Blob([res.data]));
+		const link = document.createElement('a');
+		link.href = url;
+		// The download is a tar archive named after the last path segment
+		const fileName = `${path.split('/').filter(Boolean).pop() || 'root'}.tar`;
+		link.setAttribute('download', fileName);
+		document.body.appendChild(link);
+		link.click();
+		link.remove();
+	}
+
+	async uploadFile(containerId: string, path: string, file: File): Promise<void> {
+		const envId = await environmentStore.getCurrentEnvironmentId();
+		const formData = new FormData();
+		formData.append('file', file);
+		return this.handleResponse(
+			this.api.post(`/environments/${envId}/containers/${containerId}/files/upload`, formData, {
+				params: { path }
+			})
+		);
+	}
+
+	async getChanges(containerId: string): Promise<ContainerFileChange[]> {
+		const envId = await environmentStore.getCurrentEnvironmentId();
+		const res = await this.api.get(`/environments/${envId}/containers/${containerId}/changes`);
+		return res.data.data;
+	}
+}
+
+export const containerBrowserService = new ContainerBrowserService();
@@ -4,8 +4,12 @@ import type {
 	ContainerStatusCounts,
 	ContainerSummaryDto,
 	ContainerStats,
-	ContainerCreateRequest
+	ContainerCreateRequest,
+	ContainerResourcesUpdateRequest,
+	ContainerResourcesUpdateResult,
+	ContainerCommitRequest
 } from '$lib/types/container.type';
+import type { ImageDetailSummaryDto } from '$lib/types/image.type';
 import type { SearchPaginationSortRequest, Paginated } from '$lib/types/pagination.type';
 import { transformPaginationParams } from '$lib/utils/params.util';
 
@@ -82,6 +86,19 @@ export class ContainerService extends BaseAPIService {
 		const envId = await environmentStore.getCurrentEnvironmentId();
 		return this.handleResponse(this.api.post(`/environments/${envId}/containers/${containerId}/update`));
 	}
+
+	async updateContainerResources(
+		containerId: string,
+		request: ContainerResourcesUpdateRequest
+	): Promise<ContainerResourcesUpdateResult> {
+		const envId = await environmentStore.getCurrentEnvironmentId();
+		return this.handleResponse(this.api.put(`/environments/${envId}/containers/${containerId}/resources`, request));
+	}
+
+	async commitContainer(containerId: string, request: ContainerCommitRequest): Promise<ImageDetailSummaryDto> {
+		const envId = await environmentStore.getCurrentEnvironmentId();
+		return this.handleResponse(this.api.post(`/environments/${envId}/containers/${containerId}/commit`, request));
+	}
 }
 
 export const containerService = new ContainerService();
@@ -0,0 +1,61 @@
+import BaseAPIService from './api-service';
+import { environmentStore } from '$lib/stores/environment.store.svelte';
+import type { SearchPaginationSortRequest, Paginated } from '$lib/types/pagination.type';
+import type { ExecSession, ExecSessionStatus } from '$lib/types/container.type';
+import { transformPaginationParams } from '$lib/utils/params.util';
+
+export class ExecSessionService extends BaseAPIService {
+	async getSessions(options?: SearchPaginationSortRequest): Promise<Paginated<ExecSession>> {
+		const envId = await environmentStore.getCurrentEnvironmentId();
+		const params = transformPaginationParams(options);
+		const res = await this.api.get(`/environments/${envId}/containers/exec-sessions`, { params });
+		return res.data;
+	}
+
+	async getSession(sessionId: string): Promise<ExecSession> {
+		const envId = await environmentStore.getCurrentEnvironmentId();
+		return this.handleResponse(this.api.get(`/environments/${envId}/containers/exec-sessions/${sessionId}`));
+	}
+
+	async getRecording(sessionId: string): Promise<string> {
+		const envId = await environmentStore.getCurrentEnvironmentId();
+		const res = await this.api.get(`/environments/${envId}/containers/exec-sessions/${sessionId}/recording`, {
+			responseType: 'text'
+		});
+		return res.data;
+	}
+
+	async downloadRecording(sessionId: string): Promise<void> {
+		const envId = await environmentStore.getCurrentEnvironmentId();
+		const res = await this.api.get(`/environments/${envId}/containers/exec-sessions/${sessionId}/recording`, {
+			responseType: 'blob'
+		});
+
+		const url = window.URL.createObjectURL(new Blob([res.data]));
+		const link = document.createElement('a');
+		link.href = url;
+		link.setAttribute('download', `${sessionId}.cast`);
+		document.body.appendChild(link);
+		link.click();
+		link.remove();
+	}
+
+	async terminateSession(sessionId: string): Promise<void> {
+		const envId = await environmentStore.getCurrentEnvironmentId();
+		return this.handleResponse(this.api.delete(`/environments/${envId}/containers/exec-sessions/${sessionId}`));
+	}
+
+	// findDetachedSession returns the newest session of the current user in the container
+	// that is still running without a terminal, if any.
+	async findDetachedSession(containerId: string, userId: string): Promise<ExecSession | undefined> {
+		const status: ExecSessionStatus = 'detached';
+		const result = await this.getSessions({
+			pagination: { page: 1, limit: 20 },
+			sort: { column: 'startedAt', direction: 'desc' },
+			filters: { containerId, status }
+		});
+		return result.data.find((session) => session.userId === userId);
+	}
+}
+
+export const execSessionService = new ExecSessionService();
@@ -5,6 +5,7 @@ import type {
 	GitRepository,
 	GitRepositoryTestResponse,
 	BranchesResponse,
+	TagsResponse,
 	BrowseResponse
 } from '$lib/types/gitops.type';
 import type { Paginated, SearchPaginationSortRequest } from '$lib/types/pagination.type';
@@ -42,6 +43,10 @@ export default class GitRepositoryService extends BaseAPIService {
 		return this.handleResponse(this.api.get(`/customize/git-repositories/${id}/branches`));
 	}
 
+	async getTags(id: string): Promise<TagsResponse> {
+		return this.handleResponse(this.api.get(`/customize/git-repositories/${id}/tags`));
+	}
+
 	async browseFiles(id: string, branch: string, path?: string): Promise<BrowseResponse> {
 		const params = { branch, ...(path && { path }) };
 		return this.handleResponse(this.api.get(`/customize/git-repositories/${id}/files`, { params }));
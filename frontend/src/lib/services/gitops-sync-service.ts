@@ -6,6 +6,9 @@ import type {
 	GitOpsSyncCounts,
 	SyncResult,
 	SyncStatus,
+	DriftReport,
+	CommitInfo,
+	SyncRun,
 	BrowseResponse,
 	ImportGitOpsSyncRequest,
 	ImportGitOpsSyncResponse
@@ -44,6 +47,24 @@ export default class GitOpsSyncService extends BaseAPIService {
 		return this.handleResponse(this.api.get(`/environments/${environmentId}/gitops-syncs/${syncId}/status`));
 	}
 
+	async checkDrift(environmentId: string, syncId: string): Promise<DriftReport> {
+		return this.handleResponse(this.api.post(`/environments/${environmentId}/gitops-syncs/${syncId}/drift-check`));
+	}
+
+	async rollback(environmentId: string, syncId: string, commit: string): Promise<SyncResult> {
+		return this.handleResponse(this.api.post(`/environments/${environmentId}/gitops-syncs/${syncId}/rollback`, { commit }));
+	}
+
+	async getCommits(environmentId: string, syncId: string): Promise<CommitInfo[]> {
+		return this.handleResponse(this.api.get(`/environments/${environmentId}/gitops-syncs/${syncId}/commits`));
+	}
+
+	async getRuns(environmentId: string, syncId: string, options?: SearchPaginationSortRequest): Promise<Paginated<SyncRun>> {
+		const params = transformPaginationParams(options);
+		const res = await this.api.get(`/environments/${environmentId}/gitops-syncs/${syncId}/runs`, { params });
+		return res.data;
+	}
+
 	async browseFiles(environmentId: string, syncId: string, path?: string): Promise<BrowseResponse> {
 		const params = path ? { path } : {};
 		return this.handleResponse(this.api.get(`/environments/${environmentId}/gitops-syncs/${syncId}/files`, { params }));
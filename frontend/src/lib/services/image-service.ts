@@ -1,6 +1,6 @@
 import BaseAPIService from './api-service';
 import { environmentStore } from '$lib/stores/environment.store.svelte';
-import type { ImageSummaryDto, ImageUsageCounts, ImageUpdateInfoDto } from '$lib/types/image.type';
+import type { ImageSummaryDto, ImageUsageCounts, ImageUpdateInfoDto, ImagePushRequest } from '$lib/types/image.type';
 import type { SearchPaginationSortRequest, Paginated } from '$lib/types/pagination.type';
 import type { AutoUpdateCheck, AutoUpdateResult } from '$lib/types/auto-update.type';
 import { transformPaginationParams } from '$lib/utils/params.util';
@@ -86,6 +86,68 @@ export class ImageService extends BaseAPIService {
 			})
 		);
 	}
+
+	async tagImage(source: string, target: string): Promise<any> {
+		const envId = await environmentStore.getCurrentEnvironmentId();
+		return this.handleResponse(this.api.post(`/environments/${envId}/images/tag`, { source, target }));
+	}
+
+	async pushImage(request: ImagePushRequest, onLine?: (data: any) => void): Promise<string | undefined> {
+		const envId = await environmentStore.getCurrentEnvironmentId();
+		const url = `/api/environments/${envId}/images/push`;
+
+		const res = await fetch(url, {
+			method: 'POST',
+			headers: { 'Content-Type': 'application/json' },
+			body: JSON.stringify(request)
+		});
+		if (!res.ok || !res.body) {
+			throw new Error(`Failed to start image push (${res.status})`);
+		}
+
+		const reader = res.body.getReader();
+		const decoder = new TextDecoder();
+		let buffer = '';
+		let error: string | undefined;
+		let pushed: string | undefined;
+
+		while (true) {
+			const { value, done } = await reader.read();
+			if (done) break;
+
+			buffer += decoder.decode(value, { stream: true });
+			const lines = buffer.split('\n');
+			buffer = lines.pop() || '';
+
+			for (const line of lines) {
+				const trimmed = line.trim();
+				if (!trimmed) continue;
+				try {
+					const obj = JSON.parse(trimmed);
+					if (obj?.error && !error) {
+						error = obj.error;
+					}
+					if (obj?.status === 'complete') {
+						pushed = obj.image;
+					}
+					onLine?.(obj);
+				} catch {
+					// ignore malformed line
+				}
+			}
+		}
+
+		if (error) {
+			throw new Error(error);
+		}
+		return pushed;
+	}
+
+	async saveImages(images: string[]): Promise<Blob> {
+		const envId = await environmentStore.getCurrentEnvironmentId();
+		const res = await this.api.post(`/environments/${envId}/images/save`, { images }, { responseType: 'blob' });
+		return res.data;
+	}
 }
 
 export const imageService = new ImageService();
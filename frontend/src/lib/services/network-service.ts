@@ -5,7 +5,10 @@ import type {
 	NetworkUsageCounts,
 	NetworkCreateRequest,
 	NetworkCreateOptions,
-	NetworkInspectDto
+	NetworkInspectDto,
+	NetworkConnectRequest,
+	NetworkDisconnectRequest,
+	NetworkTopology
 } from '$lib/types/network.type';
 import type { SearchPaginationSortRequest, Paginated } from '$lib/types/pagination.type';
 import { transformPaginationParams } from '$lib/utils/params.util';
@@ -64,6 +67,21 @@ export class NetworkService extends BaseAPIService {
 		const envId = await environmentStore.getCurrentEnvironmentId();
 		return this.handleResponse(this.api.delete(`/environments/${envId}/networks/${networkId}`));
 	}
+
+	async connectContainer(networkId: string, request: NetworkConnectRequest): Promise<any> {
+		const envId = await environmentStore.getCurrentEnvironmentId();
+		return this.handleResponse(this.api.post(`/environments/${envId}/networks/${networkId}/connect`, request));
+	}
+
+	async disconnectContainer(networkId: string, request: NetworkDisconnectRequest): Promise<any> {
+		const envId = await environmentStore.getCurrentEnvironmentId();
+		return this.handleResponse(this.api.post(`/environments/${envId}/networks/${networkId}/disconnect`, request));
+	}
+
+	async getTopology(): Promise<NetworkTopology> {
+		const envId = await environmentStore.getCurrentEnvironmentId();
+		return this.handleResponse(this.api.get(`/environments/${envId}/networks/topology`));
+	}
 }
 
 export const networkService = new NetworkService();
@@ -1,7 +1,20 @@
 import { m } from '$lib/paraglide/messages';
 import { environmentStore } from '$lib/stores/environment.store.svelte';
 import type { Paginated, SearchPaginationSortRequest } from '$lib/types/pagination.type';
-import type { Project, ProjectStatusCounts } from '$lib/types/project.type';
+import type {
+	Project,
+	ProjectBackup,
+	ProjectBackupCreate,
+	ProjectBackupRestore,
+	ProjectBuildRequest,
+	ProjectLogQuery,
+	ProjectLogSearchResult,
+	ProjectPlan,
+	ProjectRevision,
+	ProjectRevisionDiff,
+	ProjectRevisionSummary,
+	ProjectStatusCounts
+} from '$lib/types/project.type';
 import { transformPaginationParams } from '$lib/utils/params.util';
 import BaseAPIService from './api-service';
 
@@ -202,6 +215,52 @@ export class ProjectService extends BaseAPIService {
 		await this.streamProjectPull(projectId, onLine);
 	}
 
+	async buildProjectImages(projectId: string, request: ProjectBuildRequest = {}, onLine?: (data: any) => void): Promise<void> {
+		const envId = await environmentStore.getCurrentEnvironmentId();
+		const url = `/api/environments/${envId}/projects/${projectId}/build`;
+
+		const res = await fetch(url, {
+			method: 'POST',
+			headers: { 'Content-Type': 'application/json' },
+			body: JSON.stringify(request)
+		});
+		if (!res.ok || !res.body) {
+			throw new Error(`Failed to start project image build (${res.status})`);
+		}
+
+		const reader = res.body.getReader();
+		const decoder = new TextDecoder();
+		let buffer = '';
+		let error: string | undefined;
+
+		while (true) {
+			const { value, done } = await reader.read();
+			if (done) break;
+
+			buffer += decoder.decode(value, { stream: true });
+			const lines = buffer.split('\n');
+			buffer = lines.pop() || '';
+
+			for (const line of lines) {
+				const trimmed = line.trim();
+				if (!trimmed) continue;
+				try {
+					const obj = JSON.parse(trimmed);
+					if (obj?.error && !error) {
+						error = obj.error;
+					}
+					onLine?.(obj);
+				} catch {
+					// ignore malformed line
+				}
+			}
+		}
+
+		if (error) {
+			throw new Error(error);
+		}
+	}
+
 	async deployProjectMaybePull(
 		projectId: string,
 		onPullLine?: (data: any) => void,
@@ -223,6 +282,108 @@ export class ProjectService extends BaseAPIService {
 			})
 		);
 	}
+
+	async listProjectBackups(projectId: string): Promise<ProjectBackup[]> {
+		const envId = await environmentStore.getCurrentEnvironmentId();
+		const res = await this.api.get(`/environments/${envId}/projects/${projectId}/backups`);
+		return res.data.data ?? [];
+	}
+
+	async createProjectBackup(projectId: string, options: ProjectBackupCreate = {}, passphrase?: string): Promise<ProjectBackup> {
+		const envId = await environmentStore.getCurrentEnvironmentId();
+		const res = await this.api.post(`/environments/${envId}/projects/${projectId}/backups`, options, {
+			headers: passphrase ? { 'X-Backup-Passphrase': passphrase } : undefined
+		});
+		return res.data.data;
+	}
+
+	async restoreProjectBackup(
+		projectId: string,
+		backupId: string,
+		options: ProjectBackupRestore = {},
+		passphrase?: string
+	): Promise<void> {
+		const envId = await environmentStore.getCurrentEnvironmentId();
+		await this.handleResponse(
+			this.api.post(`/environments/${envId}/projects/${projectId}/backups/${backupId}/restore`, options, {
+				headers: passphrase ? { 'X-Backup-Passphrase': passphrase } : undefined
+			})
+		);
+	}
+
+	async deleteProjectBackup(projectId: string, backupId: string): Promise<void> {
+		const envId = await environmentStore.getCurrentEnvironmentId();
+		await this.handleResponse(this.api.delete(`/environments/${envId}/projects/${projectId}/backups/${backupId}`));
+	}
+
+	async listProjectRevisions(projectId: string, options?: SearchPaginationSortRequest): Promise<Paginated<ProjectRevisionSummary>> {
+		const envId = await environmentStore.getCurrentEnvironmentId();
+		const params = transformPaginationParams(options);
+		const res = await this.api.get(`/environments/${envId}/projects/${projectId}/revisions`, { params });
+		return res.data;
+	}
+
+	async getProjectRevision(projectId: string, revision: number): Promise<ProjectRevision> {
+		const envId = await environmentStore.getCurrentEnvironmentId();
+		const res = await this.api.get(`/environments/${envId}/projects/${projectId}/revisions/${revision}`);
+		return res.data.data;
+	}
+
+	async diffProjectRevisions(projectId: string, from: number, to: number): Promise<ProjectRevisionDiff> {
+		const envId = await environmentStore.getCurrentEnvironmentId();
+		const res = await this.api.get(`/environments/${envId}/projects/${projectId}/revisions/diff`, { params: { from, to } });
+		return res.data.data;
+	}
+
+	async redeployProjectRevision(projectId: string, revision: number): Promise<ProjectRevisionSummary> {
+		const envId = await environmentStore.getCurrentEnvironmentId();
+		const res = await this.api.post(`/environments/${envId}/projects/${projectId}/revisions/${revision}/redeploy`);
+		return res.data.data;
+	}
+
+	async planProject(projectId: string): Promise<ProjectPlan> {
+		const envId = await environmentStore.getCurrentEnvironmentId();
+		const res = await this.api.get(`/environments/${envId}/projects/${projectId}/plan`);
+		return res.data.data;
+	}
+
+	async searchProjectLogs(projectId: string, query: ProjectLogQuery = {}, limit = 1000): Promise<ProjectLogSearchResult> {
+		const envId = await environmentStore.getCurrentEnvironmentId();
+		const res = await this.api.get(`/environments/${envId}/projects/${projectId}/logs/search`, {
+			params: { ...this.logQueryParams(query), limit }
+		});
+		return res.data.data;
+	}
+
+	async exportProjectLogs(projectId: string, query: ProjectLogQuery = {}, format: 'zip' | 'ndjson' = 'zip'): Promise<void> {
+		const envId = await environmentStore.getCurrentEnvironmentId();
+		const res = await this.api.get(`/environments/${envId}/projects/${projectId}/logs/export`, {
+			params: { ...this.logQueryParams(query), format },
+			responseType: 'blob'
+		});
+
+		const disposition: string = res.headers['content-disposition'] ?? '';
+		const fileName = /filename="?([^"]+)"?/.exec(disposition)?.[1] ?? `${projectId}-logs.${format}`;
+		const url = window.URL.createObjectURL(new Blob([res.data]));
+		const link = document.createElement('a');
+		link.href = url;
+		link.setAttribute('download', fileName);
+		document.body.appendChild(link);
+		link.click();
+		link.remove();
+	}
+
+	private logQueryParams(query: ProjectLogQuery): Record<string, string | boolean> {
+		const params: Record<string, string | boolean> = {};
+		if (query.since) params.since = query.since;
+		if (query.until) params.until = query.until;
+		if (query.regex) params.regex = query.regex;
+		if (query.ignoreCase) params.ignoreCase = true;
+		if (query.streams?.length) params.stream = query.streams.join(',');
+		if (query.services?.length) params.service = query.services.join(',');
+		if (query.levels?.length) params.level = query.levels.join(',');
+		return params;
+	}
 }
 
 export const projectService = new ProjectService();
@@ -1,15 +1,38 @@
 import BaseAPIService from './api-service';
 import { environmentStore } from '$lib/stores/environment.store.svelte';
-import type { BackupEntry } from '$lib/types/file-browser.type';
+import type {
+	BackupEntry,
+	BackupTarget,
+	BackupTargetCreate,
+	BackupTargetUpdate,
+	VolumeBackupPolicy,
+	VolumeBackupPolicyUpdate
+} from '$lib/types/file-browser.type';
 import type { SearchPaginationSortRequest, Paginated } from '$lib/types/pagination.type';
 import { transformPaginationParams } from '$lib/utils/params.util';
 
 export type VolumeBackupListResponse = Paginated<BackupEntry> & { warnings?: string[] };
 
+export interface CreateBackupOptions {
+	targetId?: string;
+	encrypt?: boolean;
+	passphrase?: string;
+}
+
+function passphraseHeaders(passphrase?: string): Record<string, string> | undefined {
+	return passphrase ? { 'X-Backup-Passphrase': passphrase } : undefined;
+}
+
 export class VolumeBackupService extends BaseAPIService {
-	async createBackup(volumeName: string): Promise<BackupEntry> {
+	async createBackup(volumeName: string, options: CreateBackupOptions = {}): Promise<BackupEntry> {
 		const envId = await environmentStore.getCurrentEnvironmentId();
-		const res = await this.api.post(`/environments/${envId}/volumes/${volumeName}/backups`);
+		const params: Record<string, string | boolean> = {};
+		if (options.targetId) params.targetId = options.targetId;
+		if (options.encrypt || options.passphrase) params.encrypt = true;
+		const res = await this.api.post(`/environments/${envId}/volumes/${volumeName}/backups`, undefined, {
+			params,
+			headers: passphraseHeaders(options.passphrase)
+		});
 		return res.data.data;
 	}
 
@@ -20,31 +43,46 @@ export class VolumeBackupService extends BaseAPIService {
 		return res.data;
 	}
 
-	async restoreBackup(volumeName: string, backupId: string): Promise<void> {
+	async rescanBackups(volumeName: string): Promise<number> {
 		const envId = await environmentStore.getCurrentEnvironmentId();
-		return this.handleResponse(this.api.post(`/environments/${envId}/volumes/${volumeName}/backups/${backupId}/restore`));
+		const res = await this.api.post(`/environments/${envId}/volumes/${volumeName}/backups/rescan`);
+		return res.data.data?.imported ?? 0;
 	}
 
-	async restoreBackupFiles(volumeName: string, backupId: string, paths: string[]): Promise<void> {
+	async restoreBackup(volumeName: string, backupId: string, passphrase?: string): Promise<void> {
 		const envId = await environmentStore.getCurrentEnvironmentId();
 		return this.handleResponse(
-			this.api.post(`/environments/${envId}/volumes/${volumeName}/backups/${backupId}/restore-files`, {
-				paths
+			this.api.post(`/environments/${envId}/volumes/${volumeName}/backups/${backupId}/restore`, undefined, {
+				headers: passphraseHeaders(passphrase)
 			})
 		);
 	}
 
-	async backupHasPath(backupId: string, filePath: string): Promise<boolean> {
+	async restoreBackupFiles(volumeName: string, backupId: string, paths: string[], passphrase?: string): Promise<void> {
+		const envId = await environmentStore.getCurrentEnvironmentId();
+		return this.handleResponse(
+			this.api.post(
+				`/environments/${envId}/volumes/${volumeName}/backups/${backupId}/restore-files`,
+				{ paths },
+				{ headers: passphraseHeaders(passphrase) }
+			)
+		);
+	}
+
+	async backupHasPath(backupId: string, filePath: string, passphrase?: string): Promise<boolean> {
 		const envId = await environmentStore.getCurrentEnvironmentId();
 		const res = await this.api.get(`/environments/${envId}/volumes/backups/${backupId}/has-path`, {
-			params: { path: filePath }
+			params: { path: filePath },
+			headers: passphraseHeaders(passphrase)
 		});
 		return !!res.data.data?.exists;
 	}
 
-	async listBackupFiles(backupId: string): Promise<string[]> {
+	async listBackupFiles(backupId: string, passphrase?: string): Promise<string[]> {
 		const envId = await environmentStore.getCurrentEnvironmentId();
-		const res = await this.api.get(`/environments/${envId}/volumes/backups/${backupId}/files`);
+		const res = await this.api.get(`/environments/${envId}/volumes/backups/${backupId}/files`, {
+			headers: passphraseHeaders(passphrase)
+		});
 		return res.data.data ?? [];
 	}
 
@@ -68,12 +106,60 @@ export class VolumeBackupService extends BaseAPIService {
 		link.remove();
 	}
 
-	async uploadAndRestore(volumeName: string, file: File): Promise<void> {
+	async uploadAndRestore(volumeName: string, file: File, options: { checksum?: string; passphrase?: string } = {}): Promise<void> {
 		const envId = await environmentStore.getCurrentEnvironmentId();
 		const formData = new FormData();
 		formData.append('file', file);
-		return this.handleResponse(this.api.post(`/environments/${envId}/volumes/${volumeName}/backups/upload`, formData));
+		if (options.checksum) formData.append('checksum', options.checksum);
+		return this.handleResponse(
+			this.api.post(`/environments/${envId}/volumes/${volumeName}/backups/upload`, formData, {
+				headers: passphraseHeaders(options.passphrase)
+			})
+		);
+	}
+
+	async getBackupPolicy(volumeName: string): Promise<VolumeBackupPolicy> {
+		const envId = await environmentStore.getCurrentEnvironmentId();
+		const res = await this.api.get(`/environments/${envId}/volumes/${volumeName}/backup-policy`);
+		return res.data.data;
+	}
+
+	async updateBackupPolicy(volumeName: string, policy: VolumeBackupPolicyUpdate): Promise<VolumeBackupPolicy> {
+		const envId = await environmentStore.getCurrentEnvironmentId();
+		const res = await this.api.put(`/environments/${envId}/volumes/${volumeName}/backup-policy`, policy);
+		return res.data.data;
+	}
+
+	async deleteBackupPolicy(volumeName: string): Promise<void> {
+		const envId = await environmentStore.getCurrentEnvironmentId();
+		return this.handleResponse(this.api.delete(`/environments/${envId}/volumes/${volumeName}/backup-policy`));
+	}
+
+	async listBackupTargets(): Promise<BackupTarget[]> {
+		const envId = await environmentStore.getCurrentEnvironmentId();
+		const res = await this.api.get(`/environments/${envId}/backup-targets`);
+		return res.data.data ?? [];
+	}
+
+	async createBackupTarget(target: BackupTargetCreate): Promise<BackupTarget> {
+		const envId = await environmentStore.getCurrentEnvironmentId();
+		const res = await this.api.post(`/environments/${envId}/backup-targets`, target);
+		return res.data.data;
+	}
+
+	async updateBackupTarget(targetId: string, target: BackupTargetUpdate): Promise<BackupTarget> {
+		const envId = await environmentStore.getCurrentEnvironmentId();
+		const res = await this.api.put(`/environments/${envId}/backup-targets/${targetId}`, target);
+		return res.data.data;
 	}
-}
 
-export const volumeBackupService = new VolumeBackupService();
+	async deleteBackupTarget(targetId: string): Promise<void> {
+		const envId = await environmentStore.getCurrentEnvironmentId();
+		return this.handleResponse(this.api.delete(`/environments/${envId}/backup-targets/${targetId}`));
+	}
+
+	async testBackupTarget(targetId: string): Promise<void> {
+		const envId = await environmentStore.getCurrentEnvironmentId();
+		return this.handleResponse(this.api.post(`/environments/${envId}/backup-targets/${targetId}/test`));
+	}
+}
@@ -1,3 +1,5 @@
+import type { EnvironmentAccess } from './user.type';
+
 export type ApiKey = {
 	id: string;
 	name: string;
@@ -8,6 +10,7 @@ export type ApiKey = {
 	lastUsedAt?: string;
 	createdAt: string;
 	updatedAt?: string;
+	environmentAccess?: EnvironmentAccess | null;
 };
 
 export type ApiKeyCreated = ApiKey & {
@@ -18,10 +21,12 @@ export type CreateApiKey = {
 	name: string;
 	description?: string;
 	expiresAt?: string;
+	environmentAccess?: EnvironmentAccess;
 };
 
 export type UpdateApiKey = {
 	name?: string;
 	description?: string;
 	expiresAt?: string;
+	environmentAccess?: EnvironmentAccess;
 };
@@ -40,6 +40,34 @@ export interface HostConfigCreate {
 	cpuShares?: number;
 }
 
+export interface ContainerResourcesUpdateRequest {
+	memory?: number;
+	memoryReservation?: number;
+	memorySwap?: number;
+	cpus?: number;
+	cpuShares?: number;
+	pidsLimit?: number;
+	restartPolicy?: RestartPolicy;
+}
+
+export interface ContainerResourcesUpdateResult {
+	warnings?: string[];
+}
+
+export interface ContainerCommitRequest {
+	reference?: string;
+	author?: string;
+	message?: string;
+	pause?: boolean;
+}
+
+export type ContainerFileChangeKind = 'modified' | 'added' | 'deleted';
+
+export interface ContainerFileChange {
+	path: string;
+	kind: ContainerFileChangeKind;
+}
+
 export interface NetworkingConfig {
 	endpointsConfig?: Record<string, { aliases?: string[] }>;
 }
@@ -293,3 +321,20 @@ export interface ContainerStats {
 	networks: Record<string, NetworkStats>;
 	storage_stats: StorageStats;
 }
+
+export type ExecSessionStatus = 'active' | 'detached' | 'ended';
+
+export interface ExecSession {
+	id: string;
+	environmentId: string;
+	containerId: string;
+	containerName?: string;
+	command: string;
+	userId?: string;
+	username?: string;
+	status: ExecSessionStatus;
+	startedAt: string;
+	endedAt?: string;
+	exitCode?: number;
+	recordingSize: number;
+}
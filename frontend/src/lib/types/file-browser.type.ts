@@ -18,5 +18,54 @@ export interface BackupEntry {
 	id: string;
 	volumeName: string;
 	size: number;
+	scheduled?: boolean;
+	targetId?: string;
+	checksum?: string;
+	encryption?: '' | 'instance' | 'passphrase';
+	hookOutput?: string;
 	createdAt: string;
 }
+
+export interface VolumeBackupPolicy {
+	id?: string;
+	volumeName: string;
+	enabled: boolean;
+	keepLast: number;
+	keepDaily: number;
+	keepWeekly: number;
+	targetId?: string;
+	encrypt: boolean;
+	hasPassphrase: boolean;
+	lastRunAt?: string;
+	lastError?: string;
+}
+
+// passphrase is write-only: omit it to keep the stored passphrase, send '' to remove it.
+export type VolumeBackupPolicyUpdate = Pick<
+	VolumeBackupPolicy,
+	'enabled' | 'keepLast' | 'keepDaily' | 'keepWeekly' | 'targetId' | 'encrypt'
+> & { passphrase?: string };
+
+export type BackupTargetType = 's3' | 'sftp' | 'path';
+
+// Credentials (secretAccessKey, password, privateKey) are write-only and never returned.
+export interface BackupTarget {
+	id: string;
+	name: string;
+	type: BackupTargetType;
+	config: Record<string, unknown>;
+	hasCredentials: boolean;
+	createdAt: string;
+	updatedAt?: string;
+}
+
+export interface BackupTargetCreate {
+	name: string;
+	type: BackupTargetType;
+	config: Record<string, unknown>;
+}
+
+export interface BackupTargetUpdate {
+	name?: string;
+	config?: Record<string, unknown>;
+}
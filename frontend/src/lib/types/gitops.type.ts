@@ -35,24 +35,42 @@ export interface GitRepository {
 	updatedAt: string;
 }
 
+export type GitOpsDriftAction = 'report' | 'notify' | 'heal';
+
+export type GitOpsDriftStatus = 'in_sync' | 'drifted' | 'error';
+
+export type GitOpsRefType = 'branch' | 'tag' | 'commit' | 'semver';
+
 export interface GitOpsSyncCreateDto {
 	name: string;
 	repositoryId: string;
 	branch: string;
+	refType?: GitOpsRefType;
+	ref?: string;
 	composePath: string;
 	projectName?: string;
 	autoSync?: boolean;
 	syncInterval?: number;
+	webhookSecret?: string;
+	sopsAgeKey?: string;
+	driftAction?: GitOpsDriftAction;
 }
 
 export interface GitOpsSyncUpdateDto {
 	name?: string;
 	repositoryId?: string;
 	branch?: string;
+	refType?: GitOpsRefType;
+	ref?: string;
 	composePath?: string;
 	projectName?: string;
 	autoSync?: boolean;
 	syncInterval?: number;
+	// Omit to keep the stored secret, send an empty string to disable the webhook.
+	webhookSecret?: string;
+	// Omit to keep the stored age key, send an empty string to remove it.
+	sopsAgeKey?: string;
+	driftAction?: GitOpsDriftAction;
 }
 
 export interface GitOpsSync {
@@ -62,6 +80,8 @@ export interface GitOpsSync {
 	repositoryId: string;
 	repository?: GitRepository;
 	branch: string;
+	refType: GitOpsRefType;
+	ref?: string;
 	composePath: string;
 	projectName: string;
 	projectId?: string;
@@ -71,6 +91,11 @@ export interface GitOpsSync {
 	lastSyncStatus?: string;
 	lastSyncError?: string;
 	lastSyncCommit?: string;
+	webhookEnabled: boolean;
+	sopsEnabled: boolean;
+	driftAction: GitOpsDriftAction;
+	driftStatus?: GitOpsDriftStatus;
+	driftCheckedAt?: string;
 	createdAt: string;
 	updatedAt: string;
 }
@@ -81,6 +106,30 @@ export interface GitOpsSyncCounts {
 	successfulSyncs: number;
 }
 
+export interface CommitInfo {
+	hash: string;
+	author: string;
+	message: string;
+	date: string;
+	deployed: boolean;
+}
+
+export interface SyncRun {
+	id: string;
+	syncId: string;
+	status: 'success' | 'failed';
+	message: string;
+	error?: string;
+	ref?: string;
+	commit?: string;
+	commitMessage?: string;
+	commitAuthor?: string;
+	changedFiles: string[];
+	output: string;
+	startedAt: string;
+	durationMs: number;
+}
+
 export interface SyncResult {
 	success: boolean;
 	message: string;
@@ -109,6 +158,27 @@ export interface SyncStatus {
 	lastSyncStatus?: string;
 	lastSyncError?: string;
 	lastSyncCommit?: string;
+	driftStatus?: GitOpsDriftStatus;
+	driftCheckedAt?: string;
+	driftError?: string;
+	driftItems?: DriftItem[];
+}
+
+export interface DriftItem {
+	kind: string;
+	service?: string;
+	message: string;
+}
+
+export interface DriftReport {
+	syncId: string;
+	syncName: string;
+	projectName: string;
+	commit?: string;
+	drifted: boolean;
+	items: DriftItem[];
+	healed: boolean;
+	checkedAt: string;
 }
 
 export interface GitRepositoryTestResponse {
@@ -124,6 +194,10 @@ export interface BranchesResponse {
 	branches: BranchInfo[];
 }
 
+export interface TagsResponse {
+	tags: string[];
+}
+
 export interface ImportGitOpsSyncRequest {
 	syncName: string;
 	gitRepo: string;
@@ -3,6 +3,8 @@ import type { VulnerabilityScanSummary } from './vulnerability.type';
 export interface ImageUpdateInfoDto {
 	hasUpdate: boolean;
 	updateType: string;
+	updatePolicy?: 'digest' | 'patch' | 'minor' | 'major';
+	versionBump?: 'patch' | 'minor' | 'major';
 	currentVersion: string;
 	latestVersion: string;
 	currentDigest: string;
@@ -84,3 +86,8 @@ export interface ImageDetailSummaryDto {
 }
 
 export type ImageUpdateData = ImageUpdateInfoDto;
+
+export interface ImagePushRequest {
+	imageName: string;
+	registryId?: string;
+}
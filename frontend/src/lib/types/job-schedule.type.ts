@@ -5,7 +5,9 @@ export type JobSchedules = {
 	autoUpdateInterval: string;
 	pollingInterval: string;
 	scheduledPruneInterval: string;
+	scheduledBackupInterval: string;
 	gitopsSyncInterval: string;
+	gitopsDriftInterval: string;
 	vulnerabilityScanInterval: string;
 };
 
@@ -113,3 +113,38 @@ export interface NetworkInspectDto {
 	peers?: PeerInfo[];
 	services?: Record<string, ServiceInfo>;
 }
+
+export interface NetworkConnectRequest {
+	containerId: string;
+	aliases?: string[];
+	ipv4Address?: string;
+	ipv6Address?: string;
+}
+
+export interface NetworkDisconnectRequest {
+	containerId: string;
+	force?: boolean;
+}
+
+export interface NetworkTopologyNode {
+	id: string;
+	type: 'network' | 'container' | 'project';
+	name: string;
+	driver?: string;
+	state?: string;
+	isDefault?: boolean;
+}
+
+export interface NetworkTopologyEdge {
+	source: string;
+	target: string;
+	type: 'attached' | 'member';
+	ipv4Address?: string;
+	ipv6Address?: string;
+	aliases?: string[];
+}
+
+export interface NetworkTopology {
+	nodes: NetworkTopologyNode[];
+	edges: NetworkTopologyEdge[];
+}
@@ -90,3 +90,119 @@ export interface ProjectStatusCounts {
 	stoppedProjects: number;
 	totalProjects: number;
 }
+
+export interface ProjectBackupVolume {
+	name: string;
+	volumeName: string;
+	external?: boolean;
+	backupId: string;
+	size: number;
+}
+
+export interface ProjectBackup {
+	id: string;
+	projectId: string;
+	projectName: string;
+	stoppedProject: boolean;
+	composeContent: string;
+	envContent?: string;
+	volumes: ProjectBackupVolume[];
+	createdAt: string;
+}
+
+export interface ProjectBackupCreate {
+	stopProject?: boolean;
+	targetId?: string;
+	encrypt?: boolean;
+}
+
+export interface ProjectBackupRestore {
+	restoreFiles?: boolean;
+	keepStopped?: boolean;
+}
+
+export interface ProjectRevisionSummary {
+	id: string;
+	projectId: string;
+	revision: number;
+	source: 'initial' | 'create' | 'update' | 'include' | 'gitops' | 'backup_restore' | 'rollback';
+	message?: string;
+	authorId?: string;
+	authorName?: string;
+	linesAdded: number;
+	linesRemoved: number;
+	createdAt: string;
+}
+
+export interface ProjectRevision extends ProjectRevisionSummary {
+	composeContent: string;
+	envContent?: string;
+	includeFiles?: Record<string, string>;
+	diff?: string;
+}
+
+export interface ProjectRevisionDiff {
+	from: number;
+	to: number;
+	diff: string;
+}
+
+export type ProjectPlanSeverity = 'error' | 'warning';
+
+export type ProjectPlanAction = 'create' | 'recreate' | 'start' | 'unchanged' | 'remove' | 'orphaned';
+
+export interface ProjectPlanIssue {
+	severity: ProjectPlanSeverity;
+	kind: 'schema' | 'variable' | 'env_file' | 'port' | 'network' | 'volume';
+	service?: string;
+	message: string;
+}
+
+export interface ProjectServicePlan {
+	name: string;
+	action: ProjectPlanAction;
+	reason?: string;
+	image?: string;
+}
+
+export interface ProjectPlan {
+	projectId: string;
+	projectName: string;
+	valid: boolean;
+	issues: ProjectPlanIssue[];
+	services: ProjectServicePlan[];
+}
+
+export interface ProjectBuildRequest {
+	services?: string[];
+	noCache?: boolean;
+	pull?: boolean;
+}
+
+export type ProjectLogLevel = 'trace' | 'debug' | 'info' | 'warn' | 'error' | 'fatal';
+
+export interface ProjectLogQuery {
+	since?: string;
+	until?: string;
+	regex?: string;
+	ignoreCase?: boolean;
+	streams?: ('stdout' | 'stderr')[];
+	services?: string[];
+	levels?: ProjectLogLevel[];
+}
+
+export interface ProjectLogEntry {
+	timestamp: string;
+	service?: string;
+	containerId: string;
+	containerName: string;
+	stream: 'stdout' | 'stderr';
+	level?: ProjectLogLevel;
+	message: string;
+}
+
+export interface ProjectLogSearchResult {
+	entries: ProjectLogEntry[];
+	truncated: boolean;
+	containers: number;
+}
@@ -6,6 +6,7 @@ export type Settings = {
 	autoUpdate: boolean;
 	autoUpdateInterval: number;
 	autoUpdateExcludedContainers?: string;
+	autoUpdateHealthTimeout?: number;
 	pollingEnabled: boolean;
 	pollingInterval: number;
 	environmentHealthInterval: number;
@@ -17,6 +18,10 @@ export type Settings = {
 	scheduledPruneVolumes?: boolean;
 	scheduledPruneNetworks?: boolean;
 	scheduledPruneBuildCache?: boolean;
+	scheduledBackupEnabled?: boolean;
+	scheduledBackupInterval?: string;
+	gitopsDriftEnabled?: boolean;
+	gitopsDriftInterval?: string;
 	vulnerabilityScanEnabled?: boolean;
 	vulnerabilityScanInterval?: number;
 	maxImageUploadSize: number;
@@ -44,6 +49,8 @@ export type Settings = {
 	oidcScopes: string;
 	oidcAdminClaim: string;
 	oidcAdminValue: string;
+	oidcRoleClaim: string;
+	oidcRoleMapping: string;
 	oidcSkipTlsVerify: boolean;
 	oidcAutoRedirectToProvider: boolean;
 	oidcMergeAccounts: boolean;
@@ -1,5 +1,10 @@
 import type { Locale } from '$lib/paraglide/runtime';
 
+export type EnvironmentAccessLevel = 'read' | 'write';
+
+// Environment ID (or '*' for all others) to access level; null means all environments.
+export type EnvironmentAccess = Record<string, EnvironmentAccessLevel>;
+
 export type User = {
 	id: string;
 	username: string;
@@ -13,6 +18,7 @@ export type User = {
 	oidcSubjectId?: string;
 	locale?: Locale;
 	requiresPasswordChange?: boolean;
+	environmentAccess?: EnvironmentAccess | null;
 };
 
 export type CreateUser = Omit<
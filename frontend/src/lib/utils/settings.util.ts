@@ -26,6 +26,8 @@ const LOCAL_SETTING_KEYS = new Set([
 	'oidcScopes',
 	'oidcAdminClaim',
 	'oidcAdminValue',
+	'oidcRoleClaim',
+	'oidcRoleMapping',
 	'oidcProviderName',
 	'oidcProviderLogoUrl'
 ]);
@@ -0,0 +1,25 @@
+package container
+
+// Commit contains the options for committing a container to a new image.
+type Commit struct {
+	// Reference is the repository and tag of the new image, e.g. myapp:debug. Without a
+	// reference the image is created untagged.
+	//
+	// Required: false
+	Reference string `json:"reference,omitempty" doc:"Repository and tag of the new image, e.g. myapp:debug"`
+
+	// Author of the image.
+	//
+	// Required: false
+	Author string `json:"author,omitempty" doc:"Author of the image"`
+
+	// Message is the commit message stored with the image.
+	//
+	// Required: false
+	Message string `json:"message,omitempty" doc:"Commit message"`
+
+	// Pause pauses the container while it is committed. Defaults to true.
+	//
+	// Required: false
+	Pause *bool `json:"pause,omitempty" doc:"Pause the container while committing (default true)"`
+}
@@ -0,0 +1,74 @@
+package container
+
+const (
+	// ExecSessionActive marks an exec session with at least one attached terminal.
+	ExecSessionActive = "active"
+	// ExecSessionDetached marks a running exec session that no terminal is attached to.
+	// It can be reattached until it times out.
+	ExecSessionDetached = "detached"
+	// ExecSessionEnded marks an exec session whose process has exited or was terminated.
+	ExecSessionEnded = "ended"
+)
+
+// ExecSession describes a recorded interactive exec session in a container.
+type ExecSession struct {
+	// ID of the exec session.
+	//
+	// Required: true
+	ID string `json:"id"`
+
+	// EnvironmentID is the environment the container belongs to.
+	//
+	// Required: true
+	EnvironmentID string `json:"environmentId"`
+
+	// ContainerID is the ID of the container the session ran in.
+	//
+	// Required: true
+	ContainerID string `json:"containerId"`
+
+	// ContainerName is the name of the container when the session started.
+	//
+	// Required: false
+	ContainerName string `json:"containerName,omitempty"`
+
+	// Command is the command the session ran, e.g. /bin/sh.
+	//
+	// Required: true
+	Command string `json:"command"`
+
+	// UserID is the ID of the user who started the session.
+	//
+	// Required: false
+	UserID string `json:"userId,omitempty"`
+
+	// Username is the name of the user who started the session.
+	//
+	// Required: false
+	Username string `json:"username,omitempty"`
+
+	// Status is active, detached or ended.
+	//
+	// Required: true
+	Status string `json:"status" enum:"active,detached,ended"`
+
+	// StartedAt is when the session started.
+	//
+	// Required: true
+	StartedAt string `json:"startedAt"`
+
+	// EndedAt is when the session ended.
+	//
+	// Required: false
+	EndedAt *string `json:"endedAt,omitempty"`
+
+	// ExitCode of the command, if it exited.
+	//
+	// Required: false
+	ExitCode *int `json:"exitCode,omitempty"`
+
+	// RecordingSize is the size of the asciicast recording in bytes.
+	//
+	// Required: true
+	RecordingSize int64 `json:"recordingSize"`
+}
@@ -0,0 +1,24 @@
+package container
+
+const (
+	// FileChangeModified marks a path changed since the container was created.
+	FileChangeModified = "modified"
+	// FileChangeAdded marks a path added since the container was created.
+	FileChangeAdded = "added"
+	// FileChangeDeleted marks a path deleted since the container was created.
+	FileChangeDeleted = "deleted"
+)
+
+// FileChange is a change to the filesystem of a container relative to its image, as
+// reported by docker diff.
+type FileChange struct {
+	// Path of the changed file or directory.
+	//
+	// Required: true
+	Path string `json:"path" doc:"Path of the changed file or directory"`
+
+	// Kind of change: modified, added or deleted.
+	//
+	// Required: true
+	Kind string `json:"kind" enum:"modified,added,deleted" doc:"Kind of change"`
+}
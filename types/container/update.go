@@ -0,0 +1,48 @@
+package container
+
+// UpdateResources contains the resource limits and restart policy to change on an
+// existing container. Fields that are not set are left unchanged.
+type UpdateResources struct {
+	// Memory limit in bytes.
+	//
+	// Required: false
+	Memory *int64 `json:"memory,omitempty" doc:"Memory limit in bytes"`
+
+	// MemoryReservation is the soft memory limit in bytes.
+	//
+	// Required: false
+	MemoryReservation *int64 `json:"memoryReservation,omitempty" doc:"Soft memory limit in bytes"`
+
+	// MemorySwap limits total memory usage (memory + swap) in bytes; -1 allows unlimited swap.
+	//
+	// Required: false
+	MemorySwap *int64 `json:"memorySwap,omitempty" doc:"Memory plus swap limit in bytes, -1 for unlimited swap"`
+
+	// CPUs is the number of CPUs the container may use.
+	//
+	// Required: false
+	CPUs *float64 `json:"cpus,omitempty" doc:"Number of CPUs, e.g. 1.5"`
+
+	// CPUShares is the relative CPU weight.
+	//
+	// Required: false
+	CPUShares *int64 `json:"cpuShares,omitempty" doc:"Relative CPU weight"`
+
+	// PidsLimit is the maximum number of processes; -1 for unlimited.
+	//
+	// Required: false
+	PidsLimit *int64 `json:"pidsLimit,omitempty" doc:"Maximum number of processes, -1 for unlimited"`
+
+	// RestartPolicy for the container.
+	//
+	// Required: false
+	RestartPolicy *RestartPolicyCreate `json:"restartPolicy,omitempty" doc:"Restart policy"`
+}
+
+// UpdateResult is the result of updating the resources of a container.
+type UpdateResult struct {
+	// Warnings reported by Docker while applying the update.
+	//
+	// Required: false
+	Warnings []string `json:"warnings,omitempty"`
+}
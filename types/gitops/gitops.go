@@ -87,6 +87,18 @@ type GitOpsSync struct {
 	// Required: true
 	Branch string `json:"branch"`
 
+	// RefType is what the sync deploys: the head of its branch, a tag, a commit or the
+	// newest tag in a semver range.
+	//
+	// Required: true
+	RefType string `json:"refType" enum:"branch,tag,commit,semver"`
+
+	// Ref is the tag, commit hash or semver range the sync is pinned to. It is empty
+	// for branch syncs.
+	//
+	// Required: false
+	Ref string `json:"ref,omitempty"`
+
 	// ComposePath is the path to the docker-compose file in the repository.
 	//
 	// Required: true
@@ -132,6 +144,34 @@ type GitOpsSync struct {
 	// Required: false
 	LastSyncCommit *string `json:"lastSyncCommit,omitempty"`
 
+	// WebhookEnabled indicates if a webhook secret is set, so pushes to the branch trigger
+	// a sync through the webhook endpoint.
+	//
+	// Required: true
+	WebhookEnabled bool `json:"webhookEnabled"`
+
+	// SopsEnabled indicates if an age key is set, so SOPS-encrypted files in the
+	// repository are decrypted during syncs.
+	//
+	// Required: true
+	SopsEnabled bool `json:"sopsEnabled"`
+
+	// DriftAction is what the drift detection job does when the deployed project no
+	// longer matches the repository: report, notify or heal.
+	//
+	// Required: true
+	DriftAction string `json:"driftAction"`
+
+	// DriftStatus is the result of the last drift check: in_sync, drifted or error.
+	//
+	// Required: false
+	DriftStatus *string `json:"driftStatus,omitempty"`
+
+	// DriftCheckedAt is the date and time of the last drift check.
+	//
+	// Required: false
+	DriftCheckedAt *time.Time `json:"driftCheckedAt,omitempty"`
+
 	// CreatedAt is the date and time at which the sync was created.
 	//
 	// Required: true
@@ -277,6 +317,17 @@ type CreateSyncRequest struct {
 	// Required: true
 	Branch string `json:"branch" binding:"required"`
 
+	// RefType is what the sync deploys: branch (the default), tag, commit or semver.
+	//
+	// Required: false
+	RefType *string `json:"refType,omitempty" enum:"branch,tag,commit,semver"`
+
+	// Ref is the tag, commit hash or semver range, e.g. "v1.*" or ">=1.2.0 <2", to pin
+	// the sync to. Required for all ref types but branch.
+	//
+	// Required: false
+	Ref *string `json:"ref,omitempty"`
+
 	// ComposePath is the path to the docker-compose file in the repository.
 	//
 	// Required: true
@@ -298,6 +349,24 @@ type CreateSyncRequest struct {
 	//
 	// Required: false
 	SyncInterval *int `json:"syncInterval,omitempty"`
+
+	// WebhookSecret is the secret push webhooks are signed with, or the GitLab secret
+	// token. Webhooks are rejected until it is set. It is write-only.
+	//
+	// Required: false
+	WebhookSecret *string `json:"webhookSecret,omitempty"`
+
+	// SopsAgeKey holds the age secret keys, one per line, that SOPS-encrypted .env and
+	// YAML files in the repository are decrypted with. It is write-only.
+	//
+	// Required: false
+	SopsAgeKey *string `json:"sopsAgeKey,omitempty"`
+
+	// DriftAction is what the drift detection job does when the project drifts from the
+	// repository. Defaults to report.
+	//
+	// Required: false
+	DriftAction *string `json:"driftAction,omitempty" enum:"report,notify,heal"`
 }
 
 // UpdateSyncRequest represents the request to update a gitops sync.
@@ -317,6 +386,16 @@ type UpdateSyncRequest struct {
 	// Required: false
 	Branch *string `json:"branch,omitempty"`
 
+	// RefType is what the sync deploys: branch, tag, commit or semver.
+	//
+	// Required: false
+	RefType *string `json:"refType,omitempty" enum:"branch,tag,commit,semver"`
+
+	// Ref is the tag, commit hash or semver range to pin the sync to.
+	//
+	// Required: false
+	Ref *string `json:"ref,omitempty"`
+
 	// ComposePath is the path to the docker-compose file in the repository.
 	//
 	// Required: false
@@ -336,6 +415,79 @@ type UpdateSyncRequest struct {
 	//
 	// Required: false
 	SyncInterval *int `json:"syncInterval,omitempty"`
+
+	// WebhookSecret is write-only: omit it to keep the stored secret, send an empty string
+	// to disable the webhook.
+	//
+	// Required: false
+	WebhookSecret *string `json:"webhookSecret,omitempty"`
+
+	// SopsAgeKey is write-only: omit it to keep the stored keys, send an empty string to
+	// remove them.
+	//
+	// Required: false
+	SopsAgeKey *string `json:"sopsAgeKey,omitempty"`
+
+	// DriftAction is what the drift detection job does when the project drifts from the
+	// repository.
+	//
+	// Required: false
+	DriftAction *string `json:"driftAction,omitempty" enum:"report,notify,heal"`
+}
+
+// WebhookResult is the response to a push webhook.
+type WebhookResult struct {
+	// Triggered indicates if the push started a sync.
+	//
+	// Required: true
+	Triggered bool `json:"triggered"`
+
+	// Message explains why a sync was or was not started.
+	//
+	// Required: true
+	Message string `json:"message"`
+
+	// Commit is the commit the sync branch was pushed to.
+	//
+	// Required: false
+	Commit string `json:"commit,omitempty"`
+}
+
+// RollbackRequest represents the request to roll a sync back to a commit.
+type RollbackRequest struct {
+	// Commit is the hash of the commit to deploy. The sync stays pinned to it until its
+	// ref type is changed back.
+	//
+	// Required: true
+	Commit string `json:"commit" binding:"required"`
+}
+
+// CommitInfo represents a commit of a sync's branch.
+type CommitInfo struct {
+	// Hash of the commit.
+	//
+	// Required: true
+	Hash string `json:"hash"`
+
+	// Author of the commit.
+	//
+	// Required: true
+	Author string `json:"author"`
+
+	// Message is the first line of the commit message.
+	//
+	// Required: true
+	Message string `json:"message"`
+
+	// Date the commit was authored.
+	//
+	// Required: true
+	Date time.Time `json:"date"`
+
+	// Deployed indicates if this is the commit the sync last deployed.
+	//
+	// Required: true
+	Deployed bool `json:"deployed"`
 }
 
 // SyncResult represents the result of a sync operation.
@@ -441,6 +593,14 @@ type BranchesResponse struct {
 	Branches []BranchInfo `json:"branches"`
 }
 
+// TagsResponse represents the response for listing repository tags.
+type TagsResponse struct {
+	// Tags of the repository. Semantic version tags come first, newest first.
+	//
+	// Required: true
+	Tags []string `json:"tags"`
+}
+
 // RepositorySync represents a git repository for syncing to remote environments.
 type RepositorySync struct {
 	// ID of the git repository.
@@ -548,6 +708,110 @@ type SyncStatus struct {
 	//
 	// Required: false
 	LastSyncCommit *string `json:"lastSyncCommit,omitempty"`
+
+	// DriftStatus is the result of the last drift check: in_sync, drifted or error.
+	//
+	// Required: false
+	DriftStatus *string `json:"driftStatus,omitempty"`
+
+	// DriftCheckedAt is the date and time of the last drift check.
+	//
+	// Required: false
+	DriftCheckedAt *time.Time `json:"driftCheckedAt,omitempty"`
+
+	// DriftError is the error from the last drift check if it could not complete.
+	//
+	// Required: false
+	DriftError *string `json:"driftError,omitempty"`
+
+	// DriftItems are the differences found by the last drift check.
+	//
+	// Required: false
+	DriftItems []DriftItem `json:"driftItems,omitempty"`
+}
+
+// Ref types of a sync: what it deploys from the repository.
+const (
+	RefTypeBranch = "branch"
+	RefTypeTag    = "tag"
+	RefTypeCommit = "commit"
+	RefTypeSemver = "semver"
+)
+
+// Drift actions of a sync.
+const (
+	DriftActionReport = "report"
+	DriftActionNotify = "notify"
+	DriftActionHeal   = "heal"
+)
+
+// Drift statuses of a sync.
+const (
+	DriftStatusInSync  = "in_sync"
+	DriftStatusDrifted = "drifted"
+	DriftStatusError   = "error"
+)
+
+// DriftItem is a difference between a sync's repository and its deployed project.
+type DriftItem struct {
+	// Kind is what drifted: compose_file, env_file, missing, replaced, stopped, image,
+	// env, port or orphaned.
+	//
+	// Required: true
+	Kind string `json:"kind" enum:"compose_file,env_file,missing,replaced,stopped,image,env,port,orphaned"`
+
+	// Service is the compose service the difference belongs to, if any.
+	//
+	// Required: false
+	Service string `json:"service,omitempty"`
+
+	// Message describes the difference. Environment values are never included.
+	//
+	// Required: true
+	Message string `json:"message"`
+}
+
+// DriftReport is the result of a drift check.
+type DriftReport struct {
+	// SyncID is the ID of the checked sync.
+	//
+	// Required: true
+	SyncID string `json:"syncId"`
+
+	// SyncName is the name of the checked sync.
+	//
+	// Required: true
+	SyncName string `json:"syncName"`
+
+	// ProjectName is the name of the sync's project.
+	//
+	// Required: true
+	ProjectName string `json:"projectName"`
+
+	// Commit is the repository commit the project was compared with.
+	//
+	// Required: false
+	Commit string `json:"commit,omitempty"`
+
+	// Drifted indicates if the project differs from the repository.
+	//
+	// Required: true
+	Drifted bool `json:"drifted"`
+
+	// Items are the differences found.
+	//
+	// Required: true
+	Items []DriftItem `json:"items"`
+
+	// Healed indicates if the project was redeployed from the repository.
+	//
+	// Required: true
+	Healed bool `json:"healed"`
+
+	// CheckedAt is the date and time of the check.
+	//
+	// Required: true
+	CheckedAt time.Time `json:"checkedAt"`
 }
 
 // ImportGitOpsSyncRequest represents the request to import gitops syncs.
@@ -600,3 +864,72 @@ type ImportGitOpsSyncResponse struct {
 	// Required: true
 	Errors []string `json:"errors"`
 }
+
+// SyncRun is a recorded run of a sync.
+type SyncRun struct {
+	// ID of the run.
+	//
+	// Required: true
+	ID string `json:"id"`
+
+	// SyncID is the ID of the sync that ran.
+	//
+	// Required: true
+	SyncID string `json:"syncId"`
+
+	// Status is the result of the run: success or failed.
+	//
+	// Required: true
+	Status string `json:"status" enum:"success,failed"`
+
+	// Message is a human-readable summary of the run.
+	//
+	// Required: true
+	Message string `json:"message"`
+
+	// Error contains error details if the run failed.
+	//
+	// Required: false
+	Error *string `json:"error,omitempty"`
+
+	// Ref is what the sync's ref resolved to: the branch, the tag or the commit.
+	//
+	// Required: false
+	Ref string `json:"ref,omitempty"`
+
+	// Commit is the hash of the deployed commit.
+	//
+	// Required: false
+	Commit string `json:"commit,omitempty"`
+
+	// CommitMessage is the first line of the deployed commit's message.
+	//
+	// Required: false
+	CommitMessage string `json:"commitMessage,omitempty"`
+
+	// CommitAuthor is the author of the deployed commit.
+	//
+	// Required: false
+	CommitAuthor string `json:"commitAuthor,omitempty"`
+
+	// ChangedFiles are the repository files changed since the commit deployed by the
+	// previous run. Empty on the first run and when that commit is not an ancestor.
+	//
+	// Required: true
+	ChangedFiles []string `json:"changedFiles"`
+
+	// Output is the log of the steps the run took, including deploy errors.
+	//
+	// Required: true
+	Output string `json:"output"`
+
+	// StartedAt is when the run started.
+	//
+	// Required: true
+	StartedAt time.Time `json:"startedAt"`
+
+	// DurationMs is how long the run took in milliseconds.
+	//
+	// Required: true
+	DurationMs int64 `json:"durationMs"`
+}
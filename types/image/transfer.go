@@ -0,0 +1,37 @@
+package image
+
+// TagOptions is the request to add a tag to an existing image.
+type TagOptions struct {
+	// Source is the ID or reference of the image to tag.
+	//
+	// Required: true
+	Source string `json:"source" minLength:"1" doc:"ID or reference of the image to tag"`
+
+	// Target is the new reference, e.g. registry.example.com/team/app:1.2.
+	//
+	// Required: true
+	Target string `json:"target" minLength:"1" doc:"New reference for the image"`
+}
+
+// PushOptions is the request to push an image to a registry.
+type PushOptions struct {
+	// ImageName is the reference of the image to push.
+	//
+	// Required: true
+	ImageName string `json:"imageName" minLength:"1" doc:"Reference of the image to push"`
+
+	// RegistryID is a configured container registry to push to. The image is tagged for
+	// that registry first when its reference points elsewhere. Without it the registry in
+	// the image reference is used, with matching configured credentials.
+	//
+	// Required: false
+	RegistryID string `json:"registryId,omitempty" doc:"Configured container registry to push to"`
+}
+
+// SaveOptions is the request to export images as a tar archive, as docker save does.
+type SaveOptions struct {
+	// Images are the IDs or references of the images to export.
+	//
+	// Required: true
+	Images []string `json:"images" minItems:"1" doc:"IDs or references of the images to export"`
+}
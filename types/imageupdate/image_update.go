@@ -12,11 +12,22 @@ type Response struct {
 	// Required: true
 	HasUpdate bool `json:"hasUpdate"`
 
-	// UpdateType describes the type of update (e.g., major, minor, patch).
+	// UpdateType describes the type of update ("digest" | "tag").
 	//
 	// Required: true
 	UpdateType string `json:"updateType"`
 
+	// UpdatePolicy is the policy used for the check ("digest" | "patch" | "minor" | "major"),
+	// taken from the com.getarcaneapp.arcane.update-policy label of the containers using the image.
+	//
+	// Required: false
+	UpdatePolicy string `json:"updatePolicy,omitempty"`
+
+	// VersionBump is the semver level of the newer tag in LatestVersion ("patch" | "minor" | "major").
+	//
+	// Required: false
+	VersionBump string `json:"versionBump,omitempty"`
+
 	// CurrentVersion is the current version of the image.
 	//
 	// Required: true
@@ -89,6 +100,11 @@ type Summary struct {
 	// Required: true
 	DigestUpdates int `json:"digestUpdates"`
 
+	// TagUpdates is the number of images with a newer version tag available.
+	//
+	// Required: true
+	TagUpdates int `json:"tagUpdates"`
+
 	// ErrorsCount is the number of errors encountered during the check.
 	//
 	// Required: true
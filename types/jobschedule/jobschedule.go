@@ -13,7 +13,9 @@ type Config struct {
 	AutoUpdateInterval         string `json:"autoUpdateInterval"`
 	PollingInterval            string `json:"pollingInterval"`
 	ScheduledPruneInterval     string `json:"scheduledPruneInterval"`
+	ScheduledBackupInterval    string `json:"scheduledBackupInterval"`
 	GitopsSyncInterval         string `json:"gitopsSyncInterval"`
+	GitopsDriftInterval        string `json:"gitopsDriftInterval"`
 	VulnerabilityScanInterval  string `json:"vulnerabilityScanInterval"`
 }
 
@@ -27,7 +29,9 @@ type Update struct {
 	AutoUpdateInterval         *string `json:"autoUpdateInterval,omitempty"`
 	PollingInterval            *string `json:"pollingInterval,omitempty"`
 	ScheduledPruneInterval     *string `json:"scheduledPruneInterval,omitempty"`
+	ScheduledBackupInterval    *string `json:"scheduledBackupInterval,omitempty"`
 	GitopsSyncInterval         *string `json:"gitopsSyncInterval,omitempty"`
+	GitopsDriftInterval        *string `json:"gitopsDriftInterval,omitempty"`
 	VulnerabilityScanInterval  *string `json:"vulnerabilityScanInterval,omitempty"`
 }
 
@@ -124,6 +124,24 @@ var jobMetadataRegistry = map[string]JobMetadata{
 			},
 		},
 	},
+	"scheduled-volume-backup": {
+		ID:             "scheduled-volume-backup",
+		Name:           "Scheduled Volume Backup",
+		Description:    "Backs up volumes with a backup policy and prunes old backups",
+		Category:       "maintenance",
+		SettingsKey:    "scheduledBackupInterval",
+		EnabledKey:     "scheduledBackupEnabled",
+		ManagerOnly:    false,
+		IsContinuous:   false,
+		CanRunManually: true,
+		Prerequisites: []JobPrerequisiteMetadata{
+			{
+				SettingKey:  "scheduledBackupEnabled",
+				Label:       "Scheduled volume backups enabled",
+				SettingsURL: "/settings/general",
+			},
+		},
+	},
 	"gitops-sync": {
 		ID:             "gitops-sync",
 		Name:           "GitOps Sync",
@@ -142,6 +160,24 @@ var jobMetadataRegistry = map[string]JobMetadata{
 			},
 		},
 	},
+	"gitops-drift": {
+		ID:             "gitops-drift",
+		Name:           "GitOps Drift Check",
+		Description:    "Checks that deployed GitOps projects still match their repositories",
+		Category:       "sync",
+		SettingsKey:    "gitopsDriftInterval",
+		EnabledKey:     "gitopsDriftEnabled",
+		ManagerOnly:    false,
+		IsContinuous:   false,
+		CanRunManually: true,
+		Prerequisites: []JobPrerequisiteMetadata{
+			{
+				SettingKey:  "gitopsDriftEnabled",
+				Label:       "GitOps drift check enabled",
+				SettingsURL: "/settings/gitops",
+			},
+		},
+	},
 	"filesystem-watcher": {
 		ID:             "filesystem-watcher",
 		Name:           "Filesystem Watcher",
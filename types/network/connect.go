@@ -0,0 +1,37 @@
+package network
+
+// ConnectRequest contains the parameters for attaching a container to a network.
+type ConnectRequest struct {
+	// ContainerID is the ID or name of the container to connect.
+	//
+	// Required: true
+	ContainerID string `json:"containerId" minLength:"1" doc:"ID or name of the container to connect"`
+
+	// Aliases are additional DNS names of the container on the network.
+	//
+	// Required: false
+	Aliases []string `json:"aliases,omitempty" doc:"Network-scoped DNS aliases for the container"`
+
+	// IPv4Address is a static IPv4 address for the container on the network.
+	//
+	// Required: false
+	IPv4Address string `json:"ipv4Address,omitempty" doc:"Static IPv4 address on the network"`
+
+	// IPv6Address is a static IPv6 address for the container on the network.
+	//
+	// Required: false
+	IPv6Address string `json:"ipv6Address,omitempty" doc:"Static IPv6 address on the network"`
+}
+
+// DisconnectRequest contains the parameters for detaching a container from a network.
+type DisconnectRequest struct {
+	// ContainerID is the ID or name of the container to disconnect.
+	//
+	// Required: true
+	ContainerID string `json:"containerId" minLength:"1" doc:"ID or name of the container to disconnect"`
+
+	// Force disconnects the container even if it is not running.
+	//
+	// Required: false
+	Force bool `json:"force,omitempty" doc:"Force the container to disconnect"`
+}
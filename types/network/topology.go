@@ -0,0 +1,95 @@
+package network
+
+const (
+	// TopologyNodeNetwork is the type of network nodes in a topology.
+	TopologyNodeNetwork = "network"
+	// TopologyNodeContainer is the type of container nodes in a topology.
+	TopologyNodeContainer = "container"
+	// TopologyNodeProject is the type of compose project nodes in a topology.
+	TopologyNodeProject = "project"
+
+	// TopologyEdgeAttached links a container to a network it is connected to.
+	TopologyEdgeAttached = "attached"
+	// TopologyEdgeMember links a project to one of its containers.
+	TopologyEdgeMember = "member"
+)
+
+// TopologyNode is a network, container or project in a topology graph.
+type TopologyNode struct {
+	// ID is the unique identifier of the node. Project nodes use "project:<name>".
+	//
+	// Required: true
+	ID string `json:"id"`
+
+	// Type is the kind of node: network, container or project.
+	//
+	// Required: true
+	Type string `json:"type"`
+
+	// Name of the network, container or project.
+	//
+	// Required: true
+	Name string `json:"name"`
+
+	// Driver is the network driver. Only set for network nodes.
+	//
+	// Required: false
+	Driver string `json:"driver,omitempty"`
+
+	// State is the container state. Only set for container nodes.
+	//
+	// Required: false
+	State string `json:"state,omitempty"`
+
+	// IsDefault indicates a default network (bridge, host, none).
+	//
+	// Required: false
+	IsDefault bool `json:"isDefault,omitempty"`
+}
+
+// TopologyEdge connects two nodes of a topology graph.
+type TopologyEdge struct {
+	// Source is the ID of the container or project node.
+	//
+	// Required: true
+	Source string `json:"source"`
+
+	// Target is the ID of the network or container node.
+	//
+	// Required: true
+	Target string `json:"target"`
+
+	// Type is the kind of edge: attached or member.
+	//
+	// Required: true
+	Type string `json:"type"`
+
+	// IPv4Address of the container on the network. Only set for attached edges.
+	//
+	// Required: false
+	IPv4Address string `json:"ipv4Address,omitempty"`
+
+	// IPv6Address of the container on the network. Only set for attached edges.
+	//
+	// Required: false
+	IPv6Address string `json:"ipv6Address,omitempty"`
+
+	// Aliases of the container on the network. Only set for attached edges.
+	//
+	// Required: false
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+// Topology is a graph of networks, the containers attached to them and the compose
+// projects the containers belong to.
+type Topology struct {
+	// Nodes of the graph.
+	//
+	// Required: true
+	Nodes []TopologyNode `json:"nodes"`
+
+	// Edges of the graph.
+	//
+	// Required: true
+	Edges []TopologyEdge `json:"edges"`
+}
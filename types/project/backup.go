@@ -0,0 +1,106 @@
+package project
+
+// BackupVolume is the backup of one named volume within a project backup.
+type BackupVolume struct {
+	// Name is the volume key in the compose file.
+	//
+	// Required: true
+	Name string `json:"name"`
+
+	// VolumeName is the Docker volume that was backed up.
+	//
+	// Required: true
+	VolumeName string `json:"volumeName"`
+
+	// External is true for volumes declared as external in the compose file.
+	//
+	// Required: false
+	External bool `json:"external,omitempty"`
+
+	// BackupID is the ID of the volume backup.
+	//
+	// Required: true
+	BackupID string `json:"backupId"`
+
+	// Size is the size of the volume backup archive in bytes.
+	//
+	// Required: true
+	Size int64 `json:"size"`
+}
+
+// Backup is a set of backups of all named volumes of a project, taken together, with
+// the compose and env files that were active at backup time.
+type Backup struct {
+	// ID of the project backup.
+	//
+	// Required: true
+	ID string `json:"id"`
+
+	// ProjectID is the ID of the backed up project.
+	//
+	// Required: true
+	ProjectID string `json:"projectId"`
+
+	// ProjectName is the name of the project at backup time.
+	//
+	// Required: true
+	ProjectName string `json:"projectName"`
+
+	// StoppedProject is true when the project was brought down for the backup.
+	//
+	// Required: true
+	StoppedProject bool `json:"stoppedProject"`
+
+	// ComposeContent is the compose file content at backup time.
+	//
+	// Required: true
+	ComposeContent string `json:"composeContent"`
+
+	// EnvContent is the env file content at backup time.
+	//
+	// Required: false
+	EnvContent string `json:"envContent,omitempty"`
+
+	// Volumes are the volume backups of the set.
+	//
+	// Required: true
+	Volumes []BackupVolume `json:"volumes"`
+
+	// CreatedAt is when the backup was taken.
+	//
+	// Required: true
+	CreatedAt string `json:"createdAt"`
+}
+
+// CreateBackup is used to back up all named volumes of a project.
+type CreateBackup struct {
+	// StopProject brings the project down for the backup and deploys it again afterwards,
+	// so the volumes are backed up in a consistent state.
+	//
+	// Required: false
+	StopProject bool `json:"stopProject,omitempty"`
+
+	// TargetID is the backup target the archives are written to; empty for the local backup volume.
+	//
+	// Required: false
+	TargetID string `json:"targetId,omitempty"`
+
+	// Encrypt encrypts the archives, with the X-Backup-Passphrase header or else the instance key.
+	//
+	// Required: false
+	Encrypt bool `json:"encrypt,omitempty"`
+}
+
+// RestoreBackup is used to restore a project backup. The project is brought down for the
+// restore and deployed again afterwards if it was running.
+type RestoreBackup struct {
+	// RestoreFiles also writes the compose and env files of the backup back to the project.
+	//
+	// Required: false
+	RestoreFiles bool `json:"restoreFiles,omitempty"`
+
+	// KeepStopped leaves the project down after the restore.
+	//
+	// Required: false
+	KeepStopped bool `json:"keepStopped,omitempty"`
+}
@@ -0,0 +1,20 @@
+package project
+
+// BuildImages is the request to build the images of a project's services that have a
+// build section.
+type BuildImages struct {
+	// Services limits the build to these services; all buildable services when empty.
+	//
+	// Required: false
+	Services []string `json:"services,omitempty"`
+
+	// NoCache builds without using the build cache.
+	//
+	// Required: false
+	NoCache bool `json:"noCache,omitempty"`
+
+	// Pull always attempts to pull newer versions of the base images.
+	//
+	// Required: false
+	Pull bool `json:"pull,omitempty"`
+}
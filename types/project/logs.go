@@ -0,0 +1,59 @@
+package project
+
+// LogEntry is a log line of a project container.
+type LogEntry struct {
+	// Timestamp of the line as recorded by Docker, in RFC 3339 format with nanoseconds.
+	//
+	// Required: true
+	Timestamp string `json:"timestamp"`
+
+	// Service is the compose service of the container.
+	//
+	// Required: false
+	Service string `json:"service,omitempty"`
+
+	// ContainerID is the ID of the container that wrote the line.
+	//
+	// Required: true
+	ContainerID string `json:"containerId"`
+
+	// ContainerName is the name of the container that wrote the line.
+	//
+	// Required: true
+	ContainerName string `json:"containerName"`
+
+	// Stream is stdout or stderr.
+	//
+	// Required: true
+	Stream string `json:"stream"`
+
+	// Level is the log level detected in the line: trace, debug, info, warn, error or
+	// fatal. It is empty when the line has no recognizable level.
+	//
+	// Required: false
+	Level string `json:"level,omitempty"`
+
+	// Message is the log line without its timestamp.
+	//
+	// Required: true
+	Message string `json:"message"`
+}
+
+// LogSearchResult is the result of a project log search.
+type LogSearchResult struct {
+	// Entries are the matching lines in chronological order.
+	//
+	// Required: true
+	Entries []LogEntry `json:"entries"`
+
+	// Truncated is true when more lines matched than the limit. The most recent
+	// matches are returned.
+	//
+	// Required: true
+	Truncated bool `json:"truncated"`
+
+	// Containers is the number of containers searched.
+	//
+	// Required: true
+	Containers int `json:"containers"`
+}
@@ -0,0 +1,79 @@
+package project
+
+// PlanIssue is a problem found while validating a project before deployment.
+type PlanIssue struct {
+	// Severity is error when the deployment would fail and warning when it would
+	// succeed but probably not as intended.
+	//
+	// Required: true
+	Severity string `json:"severity"`
+
+	// Kind is the check that found the issue: schema, variable, env_file, port,
+	// network or volume.
+	//
+	// Required: true
+	Kind string `json:"kind"`
+
+	// Service is the compose service the issue applies to, if any.
+	//
+	// Required: false
+	Service string `json:"service,omitempty"`
+
+	// Message describes the issue.
+	//
+	// Required: true
+	Message string `json:"message"`
+}
+
+// ServicePlan describes what deploying a project would do with one of its services.
+type ServicePlan struct {
+	// Name of the compose service.
+	//
+	// Required: true
+	Name string `json:"name"`
+
+	// Action is create, recreate, start, unchanged, remove or orphaned. Orphaned services
+	// are no longer defined but their containers are kept.
+	//
+	// Required: true
+	Action string `json:"action"`
+
+	// Reason explains why the action is needed.
+	//
+	// Required: false
+	Reason string `json:"reason,omitempty"`
+
+	// Image is the image the service runs.
+	//
+	// Required: false
+	Image string `json:"image,omitempty"`
+}
+
+// Plan is the result of validating a project and comparing it to its running
+// containers without changing anything, like a dry-run of a deployment.
+type Plan struct {
+	// ProjectID is the ID of the project.
+	//
+	// Required: true
+	ProjectID string `json:"projectId"`
+
+	// ProjectName is the compose project name.
+	//
+	// Required: true
+	ProjectName string `json:"projectName"`
+
+	// Valid is false when any issue has error severity.
+	//
+	// Required: true
+	Valid bool `json:"valid"`
+
+	// Issues found while validating the project.
+	//
+	// Required: true
+	Issues []PlanIssue `json:"issues"`
+
+	// Services lists the action planned for each service.
+	//
+	// Required: true
+	Services []ServicePlan `json:"services"`
+}
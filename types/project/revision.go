@@ -0,0 +1,99 @@
+package project
+
+// RevisionSummary describes a stored revision of a project's compose, env and include
+// files without their content.
+type RevisionSummary struct {
+	// ID of the revision record.
+	//
+	// Required: true
+	ID string `json:"id"`
+
+	// ProjectID is the ID of the project.
+	//
+	// Required: true
+	ProjectID string `json:"projectId"`
+
+	// Revision is the revision number, counting up from 1 per project.
+	//
+	// Required: true
+	Revision int `json:"revision"`
+
+	// Source is what created the revision: initial, create, update, include, gitops,
+	// backup_restore or rollback.
+	//
+	// Required: true
+	Source string `json:"source"`
+
+	// Message describes the change, e.g. the include file that was edited.
+	//
+	// Required: false
+	Message string `json:"message,omitempty"`
+
+	// AuthorID is the ID of the user who made the change.
+	//
+	// Required: false
+	AuthorID *string `json:"authorId,omitempty"`
+
+	// AuthorName is the name of the user who made the change.
+	//
+	// Required: false
+	AuthorName string `json:"authorName,omitempty"`
+
+	// LinesAdded is the number of lines added compared to the previous revision.
+	//
+	// Required: true
+	LinesAdded int `json:"linesAdded"`
+
+	// LinesRemoved is the number of lines removed compared to the previous revision.
+	//
+	// Required: true
+	LinesRemoved int `json:"linesRemoved"`
+
+	// CreatedAt is when the revision was stored.
+	//
+	// Required: true
+	CreatedAt string `json:"createdAt"`
+}
+
+// Revision is a stored revision of a project's files with its content.
+type Revision struct {
+	RevisionSummary
+
+	// ComposeContent is the compose file content.
+	//
+	// Required: true
+	ComposeContent string `json:"composeContent"`
+
+	// EnvContent is the env file content.
+	//
+	// Required: false
+	EnvContent string `json:"envContent,omitempty"`
+
+	// IncludeFiles maps the relative path of each include file to its content.
+	//
+	// Required: false
+	IncludeFiles map[string]string `json:"includeFiles,omitempty"`
+
+	// Diff is the unified diff to the previous revision.
+	//
+	// Required: false
+	Diff string `json:"diff,omitempty"`
+}
+
+// RevisionDiff is the unified diff between two revisions of a project.
+type RevisionDiff struct {
+	// From is the older revision number.
+	//
+	// Required: true
+	From int `json:"from"`
+
+	// To is the newer revision number.
+	//
+	// Required: true
+	To int `json:"to"`
+
+	// Diff is the unified diff of all files; empty when the revisions are identical.
+	//
+	// Required: true
+	Diff string `json:"diff"`
+}
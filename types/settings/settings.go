@@ -87,6 +87,26 @@ type Update struct {
 	// Required: false
 	ScheduledPruneInterval *string `json:"scheduledPruneInterval,omitempty"`
 
+	// ScheduledBackupEnabled indicates if scheduled volume backups are enabled.
+	//
+	// Required: false
+	ScheduledBackupEnabled *string `json:"scheduledBackupEnabled,omitempty"`
+
+	// ScheduledBackupInterval is the cron expression for scheduled volume backups.
+	//
+	// Required: false
+	ScheduledBackupInterval *string `json:"scheduledBackupInterval,omitempty"`
+
+	// GitopsDriftEnabled indicates if scheduled GitOps drift checks are enabled.
+	//
+	// Required: false
+	GitopsDriftEnabled *string `json:"gitopsDriftEnabled,omitempty"`
+
+	// GitopsDriftInterval is the cron expression for GitOps drift checks.
+	//
+	// Required: false
+	GitopsDriftInterval *string `json:"gitopsDriftInterval,omitempty"`
+
 	// ScheduledPruneContainers indicates if stopped containers should be pruned.
 	//
 	// Required: false
@@ -240,6 +260,16 @@ type Update struct {
 	// Required: false
 	OidcAdminValue *string `json:"oidcAdminValue,omitempty"`
 
+	// OidcRoleClaim is the OIDC claim name (e.g. "groups") mapped onto Arcane roles.
+	//
+	// Required: false
+	OidcRoleClaim *string `json:"oidcRoleClaim,omitempty"`
+
+	// OidcRoleMapping maps OIDC claim values to roles, e.g. "arcane-ops=operator,arcane-viewers=viewer".
+	//
+	// Required: false
+	OidcRoleMapping *string `json:"oidcRoleMapping,omitempty"`
+
 	// OidcSkipTlsVerify indicates if TLS verification should be skipped for OIDC.
 	//
 	// Required: false
@@ -319,4 +349,10 @@ type Update struct {
 	//
 	// Required: false
 	AutoUpdateExcludedContainers *string `json:"autoUpdateExcludedContainers,omitempty"`
+
+	// AutoUpdateHealthTimeout is the number of seconds to wait for an updated container
+	// to become healthy before rolling it back to the previous image. 0 disables the check.
+	//
+	// Required: false
+	AutoUpdateHealthTimeout *string `json:"autoUpdateHealthTimeout,omitempty"`
 }
@@ -1,8 +1,50 @@
 package volume
 
 type BackupEntry struct {
-	ID         string `json:"id" doc:"Unique identifier of the backup"`
-	VolumeName string `json:"volumeName" doc:"Name of the volume"`
-	Size       int64  `json:"size" doc:"Size of the backup archive in bytes"`
-	CreatedAt  string `json:"createdAt" doc:"When the backup was created"`
+	ID         string  `json:"id" doc:"Unique identifier of the backup"`
+	VolumeName string  `json:"volumeName" doc:"Name of the volume"`
+	Size       int64   `json:"size" doc:"Size of the backup archive in bytes"`
+	Scheduled  bool    `json:"scheduled" doc:"Whether the backup was taken by the scheduled backup job"`
+	TargetID   *string `json:"targetId,omitempty" doc:"Backup target the archive is stored on; empty for the local backup volume"`
+	Checksum   string  `json:"checksum,omitempty" doc:"SHA-256 checksum of the stored archive"`
+	Encryption string  `json:"encryption,omitempty" doc:"How the archive is encrypted (instance, passphrase); empty when it is not encrypted"`
+	HookOutput string  `json:"hookOutput,omitempty" doc:"Output of the pre- and post-backup hooks run for this backup"`
+	CreatedAt  string  `json:"createdAt" doc:"When the backup was created"`
+}
+
+// UpdateBackupPolicy configures scheduled backups and retention for a volume.
+type UpdateBackupPolicy struct {
+	Enabled    bool   `json:"enabled" doc:"Whether the volume is included in scheduled backups"`
+	KeepLast   int    `json:"keepLast" minimum:"0" doc:"Number of most recent scheduled backups to keep (0 disables this rule)"`
+	KeepDaily  int    `json:"keepDaily" minimum:"0" doc:"Number of days for which the newest scheduled backup is kept (0 disables this rule)"`
+	KeepWeekly int    `json:"keepWeekly" minimum:"0" doc:"Number of weeks for which the newest scheduled backup is kept (0 disables this rule)"`
+	TargetID   string `json:"targetId,omitempty" doc:"Backup target scheduled backups are written to; empty for the local backup volume"`
+	Encrypt    bool   `json:"encrypt,omitempty" doc:"Whether scheduled backups are encrypted, with the passphrase or else the instance key"`
+	// Passphrase is write-only: omit it to keep the stored passphrase, send an empty string to remove it.
+	Passphrase *string `json:"passphrase,omitempty" doc:"Passphrase scheduled backups are encrypted with; omit to keep the stored one, empty to use the instance key"`
+}
+
+// BackupTarget is an off-host location volume backups can be stored in.
+// Credentials are never returned.
+type BackupTarget struct {
+	ID             string         `json:"id" doc:"Unique identifier of the backup target"`
+	Name           string         `json:"name" doc:"Display name of the backup target"`
+	Type           string         `json:"type" doc:"Target type (s3, sftp, path)"`
+	Config         map[string]any `json:"config" doc:"Type-specific settings, without credentials"`
+	HasCredentials bool           `json:"hasCredentials" doc:"Whether credentials are stored for the target"`
+	CreatedAt      string         `json:"createdAt" doc:"When the backup target was created"`
+	UpdatedAt      string         `json:"updatedAt,omitempty" doc:"When the backup target was last updated"`
+}
+
+// CreateBackupTarget is the request body for creating a backup target.
+type CreateBackupTarget struct {
+	Name   string         `json:"name" minLength:"1" doc:"Display name of the backup target"`
+	Type   string         `json:"type" enum:"s3,sftp,path" doc:"Target type"`
+	Config map[string]any `json:"config" doc:"Type-specific settings. s3: endpoint, region, bucket, prefix, accessKeyId, secretAccessKey, pathStyle. sftp: host, port, username, password, privateKey, path, hostKeyVerification. path: path"`
+}
+
+// UpdateBackupTarget is the request body for updating a backup target. The type cannot change.
+type UpdateBackupTarget struct {
+	Name   *string        `json:"name,omitempty" minLength:"1" doc:"Display name of the backup target"`
+	Config map[string]any `json:"config,omitempty" doc:"Replacement settings. Credentials that are omitted or empty keep their stored value"`
 }